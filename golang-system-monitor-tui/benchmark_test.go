@@ -346,7 +346,7 @@ func BenchmarkApplicationStartupShutdown(b *testing.B) {
 
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
-			program := createProgram(config)
+			program, _ := createProgram(config)
 			program.Kill()
 		}
 	})
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	appconfig "golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+)
+
+// bugReportCommand is the subcommand that writes an issue report
+const bugReportCommand = "bug-report"
+
+// maxReportErrors is the number of recent log errors included in a bug report
+const maxReportErrors = 20
+
+// redacted replaces secret values in a bug report
+const redacted = "REDACTED"
+
+// bugReportEnv lists the environment variables included in a bug report
+var bugReportEnv = []string{
+	"TERM", "COLORTERM", "TERM_PROGRAM", "LANG", "LC_ALL", "LC_NUMERIC", "LC_TIME",
+	"NO_COLOR", "DOCKER_HOST",
+	"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_PROTOCOL", "OTEL_EXPORTER_OTLP_METRICS_PROTOCOL",
+	"OTEL_EXPORTER_OTLP_HEADERS", "OTEL_EXPORTER_OTLP_METRICS_HEADERS",
+	"OTEL_RESOURCE_ATTRIBUTES", "OTEL_SERVICE_NAME", "OTEL_METRIC_EXPORT_INTERVAL",
+}
+
+// bugReport holds everything gathered for an issue report
+type bugReport struct {
+	Generated  time.Time
+	Build      *debug.BuildInfo // nil when the binary has no build info
+	Host       models.HostInfo
+	ConfigPath string
+	Settings   appconfig.Config
+	Flags      []string // Redacted monitor options given after --
+	ConfigErr  error
+	Env        map[string]string // Redacted values of the set bugReportEnv variables
+	LogPath    string
+	LogErrors  []string
+	LogErr     error
+	Snapshot   models.Snapshot
+	Problems   []string // Collection failures while gathering the report
+}
+
+// runBugReport implements `bug-report [-config path] [-log path] [-o file] [-- options]`:
+// it gathers version, platform, config and log details and a snapshot into
+// a Markdown file ready to paste into a GitHub issue. The monitor's own
+// options after -- are recorded, and supply -config and -log when those
+// are not given to bug-report itself.
+func runBugReport(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet(bugReportCommand, flag.ContinueOnError)
+	configPath := flags.String("config", "", "Config file path (default: "+appconfig.DefaultPath()+")")
+	logPath := flags.String("log", "", "Log file to collect recent errors from")
+	output := flags.String("o", "", "Write the report to this file, - for standard output (default: "+AppName+"-bug-report-<time>.md)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	monitor := &Config{}
+	monitorFlags := flag.NewFlagSet(AppName, flag.ContinueOnError)
+	registerFlags(monitorFlags, monitor)
+	if err := monitorFlags.Parse(flags.Args()); err != nil {
+		return err
+	}
+	if *configPath == "" {
+		*configPath = monitor.ConfigPath
+	}
+	if *logPath == "" {
+		*logPath = monitor.LogFile
+	}
+
+	report := gatherBugReport(services.NewGopsutilCollector(), *configPath, *logPath, os.Getenv, time.Now())
+	report.Flags = effectiveFlags(monitorFlags)
+
+	if *output == "-" {
+		return writeBugReport(stdout, report)
+	}
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("%s-bug-report-%s.md", AppName, report.Generated.Format("20060102-150405"))
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create bug report: %w", err)
+	}
+	defer file.Close()
+	if err := writeBugReport(file, report); err != nil {
+		return fmt.Errorf("failed to write bug report: %w", err)
+	}
+	fmt.Fprintf(stdout, "Bug report written to %s\nReview it for anything private before attaching it to an issue.\n", path)
+	return nil
+}
+
+// gatherBugReport collects the report; failures are recorded as problems
+// instead of aborting, a partial report is still useful
+func gatherBugReport(collector models.SystemCollector, configPath, logPath string, getenv func(string) string, now time.Time) bugReport {
+	report := bugReport{
+		Generated:  now,
+		ConfigPath: settingsPath(configPath),
+		LogPath:    logPath,
+		Env:        map[string]string{},
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		report.Build = info
+	}
+
+	report.Settings, report.ConfigErr = loadSettings(configPath)
+
+	for _, name := range bugReportEnv {
+		if value := getenv(name); value != "" {
+			report.Env[name] = redactEnv(name, value)
+		}
+	}
+
+	if logPath != "" {
+		report.LogErrors, report.LogErr = recentLogErrors(logPath, maxReportErrors)
+	}
+
+	if hostCollector, ok := collector.(models.HostCollector); ok {
+		host, err := hostCollector.CollectHost()
+		if err != nil {
+			report.Problems = append(report.Problems, err.Error())
+		}
+		report.Host = host
+	}
+
+	report.Snapshot.Timestamp = now
+	var err error
+	if report.Snapshot.CPU, err = collector.CollectCPU(); err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	}
+	if report.Snapshot.Memory, err = collector.CollectMemory(); err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	}
+	if report.Snapshot.Disks, err = collector.CollectDisk(); err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	}
+	if report.Snapshot.Network, err = collector.CollectNetwork(); err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	}
+	return report
+}
+
+// writeBugReport renders the report as Markdown
+func writeBugReport(w io.Writer, report bugReport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s bug report\n\n", AppName)
+	fmt.Fprintf(&b, "Generated %s\n\n", report.Generated.Format(time.RFC3339))
+	b.WriteString("<!-- Describe what happened and what you expected above this report. -->\n\n")
+
+	b.WriteString("## Version\n\n")
+	fmt.Fprintf(&b, "- Version: %s\n", AppVersion)
+	fmt.Fprintf(&b, "- Go: %s\n", runtime.Version())
+	if report.Build != nil {
+		if version := report.Build.Main.Version; version != "" && version != "(devel)" {
+			fmt.Fprintf(&b, "- Module: %s %s\n", report.Build.Main.Path, version)
+		}
+		for _, setting := range report.Build.Settings {
+			switch setting.Key {
+			case "vcs.revision", "vcs.time", "vcs.modified":
+				fmt.Fprintf(&b, "- %s: %s\n", setting.Key, setting.Value)
+			}
+		}
+	}
+
+	b.WriteString("\n## Platform\n\n")
+	fmt.Fprintf(&b, "- OS/arch: %s/%s, %d CPUs\n", runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+	if report.Host.Platform != "" {
+		fmt.Fprintf(&b, "- Platform: %s %s\n", report.Host.Platform, report.Host.PlatformVersion)
+	}
+	if report.Host.KernelVersion != "" {
+		fmt.Fprintf(&b, "- Kernel: %s %s\n", report.Host.KernelVersion, report.Host.KernelArch)
+	}
+	if uptime := report.Host.Uptime(report.Generated); uptime > 0 {
+		fmt.Fprintf(&b, "- Uptime: %s\n", uptime.Round(time.Minute))
+	}
+	names := make([]string, 0, len(report.Env))
+	for name := range report.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "- %s: `%s`\n", name, report.Env[name])
+	}
+
+	b.WriteString("\n## Configuration\n\n")
+	if len(report.Flags) > 0 {
+		fmt.Fprintf(&b, "Options: `%s`\n\n", strings.Join(report.Flags, " "))
+	} else {
+		b.WriteString("Options: none recorded (pass the monitor's options after `--` to include them)\n\n")
+	}
+	fmt.Fprintf(&b, "Config file: `%s`\n\n", report.ConfigPath)
+	if report.ConfigErr != nil {
+		fmt.Fprintf(&b, "Error: %v\n", report.ConfigErr)
+	} else if settings, err := redactSettings(report.Settings); err != nil {
+		return err
+	} else if err := writeJSONBlock(&b, settings); err != nil {
+		return err
+	}
+
+	b.WriteString("\n## Recent errors\n\n")
+	switch {
+	case report.LogPath == "":
+		b.WriteString("No log file given (run `bug-report -log <file>` to include one).\n")
+	case report.LogErr != nil:
+		fmt.Fprintf(&b, "Error: %v\n", report.LogErr)
+	case len(report.LogErrors) == 0:
+		fmt.Fprintf(&b, "No errors in `%s`.\n", report.LogPath)
+	default:
+		fmt.Fprintf(&b, "Last %d errors in `%s`:\n\n```\n", len(report.LogErrors), report.LogPath)
+		for _, line := range report.LogErrors {
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("```\n")
+	}
+
+	b.WriteString("\n## Snapshot\n\n")
+	if len(report.Problems) > 0 {
+		b.WriteString("Problems while collecting:\n\n")
+		for _, problem := range report.Problems {
+			fmt.Fprintf(&b, "- %s\n", problem)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("<details><summary>Metrics</summary>\n\n")
+	if err := writeJSONBlock(&b, report.Snapshot); err != nil {
+		return err
+	}
+	b.WriteString("\n</details>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeJSONBlock writes value as an indented JSON code block
+func writeJSONBlock(b *strings.Builder, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bug report: %w", err)
+	}
+	b.WriteString("```json\n")
+	b.Write(data)
+	b.WriteString("\n```\n")
+	return nil
+}
+
+// effectiveFlags lists the options set on flags as redacted -name=value pairs
+func effectiveFlags(flags *flag.FlagSet) []string {
+	var result []string
+	flags.Visit(func(f *flag.Flag) {
+		result = append(result, "-"+f.Name+"="+redactFlag(f.Name, f.Value.String()))
+	})
+	return result
+}
+
+// redactSettings returns the config file contents with plugin arguments,
+// which often carry tokens or DSNs, and URL credentials hidden. Plugin
+// executables are kept to show what runs.
+func redactSettings(settings appconfig.Config) (interface{}, error) {
+	plugins := make([]appconfig.Plugin, len(settings.Plugins))
+	for i, plugin := range settings.Plugins {
+		plugin.Command = append([]string(nil), plugin.Command...)
+		for j := 1; j < len(plugin.Command); j++ {
+			plugin.Command[j] = redacted
+		}
+		plugins[i] = plugin
+	}
+	settings.Plugins = plugins
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode bug report: %w", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to encode bug report: %w", err)
+	}
+	return redactURLs(value), nil
+}
+
+// redactURLs hides credentials in every URL-like string of a decoded JSON value
+func redactURLs(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = redactURLs(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactURLs(item)
+		}
+	case string:
+		if strings.Contains(v, "://") {
+			return redactURL(v)
+		}
+	}
+	return value
+}
+
+// redactFlag hides secrets in a monitor option. Webhook URLs carry their
+// token in the path, so only the host is kept.
+func redactFlag(name, value string) string {
+	if name == "webhook" {
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Host == "" {
+			return redacted
+		}
+		return parsed.Scheme + "://" + parsed.Host + "/" + redacted
+	}
+	if strings.Contains(value, "://") {
+		return redactURL(value)
+	}
+	return value
+}
+
+// recentLogErrors returns the last n log lines mentioning an error or failure
+func recentLogErrors(path string, n int) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log file: %w", err)
+	}
+	defer file.Close()
+
+	var errors []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.ToLower(scanner.Text())
+		if strings.Contains(line, "error") || strings.Contains(line, "fail") || strings.Contains(line, "panic") {
+			errors = append(errors, scanner.Text())
+			if len(errors) > n {
+				errors = errors[1:]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors, fmt.Errorf("failed to read log file: %w", err)
+	}
+	return errors, nil
+}
+
+// redactEnv hides secrets in an environment variable: header values such as
+// API keys, and credentials or query parameters in endpoint URLs
+func redactEnv(name, value string) string {
+	if strings.HasSuffix(name, "_HEADERS") {
+		pairs := strings.Split(value, ",")
+		for i, pair := range pairs {
+			if key, _, found := strings.Cut(pair, "="); found {
+				pairs[i] = strings.TrimSpace(key) + "=" + redacted
+			}
+		}
+		return strings.Join(pairs, ",")
+	}
+	if strings.HasSuffix(name, "_ENDPOINT") || name == "DOCKER_HOST" {
+		return redactURL(value)
+	}
+	return value
+}
+
+// redactURL hides the user info and query parameters of a URL
+func redactURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return redacted
+	}
+	if parsed.User != nil {
+		parsed.User = url.User(redacted)
+	}
+	if parsed.RawQuery != "" {
+		parsed.RawQuery = redacted
+	}
+	return parsed.String()
+}
@@ -0,0 +1,935 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang-system-monitor-tui/models"
+)
+
+// appDirName is the directory holding the config file under the user config dir
+const appDirName = "golang-system-monitor-tui"
+
+// PanelNames lists the panels that accept style overrides
+var PanelNames = []string{"cpu", "memory", "disk", "network", "sensors", "containers", "alerts", "plugins", "processes", "log", "self", "kernel"}
+
+// GridPanelNames lists the panels that can be placed in the main grid
+var GridPanelNames = []string{"cpu", "memory", "disk", "network", "sensors", "alerts", "log", "self", "kernel"}
+
+// DefaultGridPanels is the panel order of the default 2x2 grid
+var DefaultGridPanels = []string{"cpu", "memory", "disk", "network"}
+
+// DefaultGridColumns is the number of grid columns when none is configured
+const DefaultGridColumns = 2
+
+// Style holds optional style overrides; empty fields keep the inherited value.
+// Colors are ANSI color numbers (0-255) or hex values like "#7aa2f7".
+type Style struct {
+	Border        string `json:"border,omitempty"`         // Border color of an unfocused panel
+	FocusedBorder string `json:"focused_border,omitempty"` // Border color of the focused panel
+	Header        string `json:"header,omitempty"`         // Panel titles and highlighted text
+	Text          string `json:"text,omitempty"`           // Default text
+	Muted         string `json:"muted,omitempty"`          // Secondary text
+	Normal        string `json:"normal,omitempty"`         // Graphs and bars below 70% usage
+	Warning       string `json:"warning,omitempty"`        // Graphs and bars from 70% usage
+	Critical      string `json:"critical,omitempty"`       // Graphs and bars from 90% usage, errors
+	BarFilled     string `json:"bar_filled,omitempty"`     // Glyph for the filled part of progress bars
+	BarEmpty      string `json:"bar_empty,omitempty"`      // Glyph for the empty part of progress bars
+	Bars          string `json:"bars,omitempty"`           // Bar and graph glyphs: blocks, braille or ascii
+}
+
+// BarModes lists the glyph sets for progress bars and graphs
+var BarModes = []string{"blocks", "braille", "ascii"}
+
+// Backgrounds lists the terminal background settings; auto detects it
+var Backgrounds = []string{"auto", "dark", "light"}
+
+// Merge returns s with every field set in override replacing the inherited value
+func (s Style) Merge(override Style) Style {
+	pick := func(base, value string) string {
+		if value != "" {
+			return value
+		}
+		return base
+	}
+	return Style{
+		Border:        pick(s.Border, override.Border),
+		FocusedBorder: pick(s.FocusedBorder, override.FocusedBorder),
+		Header:        pick(s.Header, override.Header),
+		Text:          pick(s.Text, override.Text),
+		Muted:         pick(s.Muted, override.Muted),
+		Normal:        pick(s.Normal, override.Normal),
+		Warning:       pick(s.Warning, override.Warning),
+		Critical:      pick(s.Critical, override.Critical),
+		BarFilled:     pick(s.BarFilled, override.BarFilled),
+		BarEmpty:      pick(s.BarEmpty, override.BarEmpty),
+		Bars:          pick(s.Bars, override.Bars),
+	}
+}
+
+// Thresholds holds warning and critical temperatures in the configured unit
+type Thresholds struct {
+	Warning  float64 `json:"warning"`
+	Critical float64 `json:"critical"`
+}
+
+// MemoryThresholds holds the share of RAM the Memory panel is colored by and
+// its warning and critical percentages; unset percentages keep the defaults of
+// the basis
+type MemoryThresholds struct {
+	Basis    string   `json:"basis,omitempty"`    // used or available; unset selects used
+	Warning  *float64 `json:"warning,omitempty"`  // Percent used, or percent available with the available basis
+	Critical *float64 `json:"critical,omitempty"` // Percent used, or percent available with the available basis
+}
+
+// Split ratio bounds for the panel grid
+const (
+	DefaultSplit = 0.5 // Even split between columns or rows
+	MinSplit     = 0.2 // Smallest share a column or row can be given
+	MaxSplit     = 0.8 // Largest share a column or row can be given
+)
+
+// Layout describes the main panel grid; zero values select the default 2x2 grid
+// with an even split
+type Layout struct {
+	Panels      []string `json:"panels,omitempty"`       // Panels in the grid, filled row by row
+	Columns     int      `json:"columns,omitempty"`      // Number of grid columns
+	ColumnSplit float64  `json:"column_split,omitempty"` // Share of the width given to the left column of a two-column grid
+	RowSplit    float64  `json:"row_split,omitempty"`    // Share of the height given to the top row of a two-row grid
+}
+
+// Grid returns the panels and the number of columns of the grid, defaulting to
+// the 2x2 grid and never using more columns than panels
+func (l Layout) Grid() (panels []string, columns int) {
+	panels = l.Panels
+	if len(panels) == 0 {
+		panels = DefaultGridPanels
+	}
+	return panels, gridColumns(l.Columns, len(panels))
+}
+
+// gridColumns defaults an unset column count and caps it at the number of panels
+func gridColumns(columns, panels int) int {
+	if columns <= 0 {
+		columns = DefaultGridColumns
+	}
+	if columns > panels {
+		columns = panels
+	}
+	return columns
+}
+
+// Splits returns the column and row split ratios, defaulting unset values to an even split
+func (l Layout) Splits() (column, row float64) {
+	column, row = l.ColumnSplit, l.RowSplit
+	if column == 0 {
+		column = DefaultSplit
+	}
+	if row == 0 {
+		row = DefaultSplit
+	}
+	return column, row
+}
+
+// MaxTabs is the number of tabs reachable with the number keys, including Overview
+const MaxTabs = 9
+
+// Tab is a page of grid panels after the Overview grid of Layout
+type Tab struct {
+	Name    string   `json:"name"`              // Title in the tab bar
+	Panels  []string `json:"panels"`            // Panels in the tab's grid, filled row by row
+	Columns int      `json:"columns,omitempty"` // Number of grid columns
+}
+
+// Grid returns the tab's panels and number of columns, never using more columns than panels
+func (t Tab) Grid() (panels []string, columns int) {
+	return t.Panels, gridColumns(t.Columns, len(t.Panels))
+}
+
+// DefaultTabs returns the tabs shown after Overview when the config file sets none
+func DefaultTabs() []Tab {
+	return []Tab{
+		{Name: "Storage", Panels: []string{"disk"}},
+		{Name: "Network", Panels: []string{"network"}},
+		{Name: "Health", Panels: []string{"sensors", "alerts", "self", "kernel"}, Columns: 1},
+	}
+}
+
+// Config is the user configuration file
+type Config struct {
+	Style            Style                 `json:"style"`                       // Overrides applied to the whole application
+	Panels           map[string]Style      `json:"panels"`                      // Per-panel overrides on top of Style, keyed by panel name
+	Layout           Layout                `json:"layout"`                      // Overview grid, its split ratios are saved when panels are resized
+	Tabs             []Tab                 `json:"tabs"`                        // Tabs after Overview; unset or null selects DefaultTabs, [] disables them
+	TemperatureUnit  string                `json:"temperature_unit,omitempty"`  // celsius or fahrenheit
+	SensorThresholds map[string]Thresholds `json:"sensor_thresholds,omitempty"` // Per sensor kind (cpu, gpu, nvme, chassis, other), in TemperatureUnit
+	DiskThresholds   map[string]Thresholds `json:"disk_thresholds,omitempty"`   // Usage percentages per mountpoint; the others are yellow from 70 and red from 90
+	MemoryThresholds *MemoryThresholds     `json:"memory_thresholds,omitempty"` // Share of RAM and percentages coloring the Memory panel; unset is yellow from 70 and red from 90 used
+	RateUnit         string                `json:"rate_unit,omitempty"`         // Network rates in bytes or bits per second
+	CounterRollover  string                `json:"counter_rollover,omitempty"`  // Rate of a network counter that went backwards: zero, wrap or hold
+	InterfaceNotices *bool                 `json:"interface_notices,omitempty"` // Notice network interfaces appearing and going away; unset enables it
+	DiskSort         string                `json:"disk_sort,omitempty"`         // Order of the disk rows: mounted, mountpoint, usage or size
+	InterfaceSort    string                `json:"interface_sort,omitempty"`    // Order of the network rows: system, name or throughput
+	PinnedDisks      []string              `json:"pinned_disks,omitempty"`      // Mountpoints listed first in the disk rows, in this order
+	PinnedInterfaces []string              `json:"pinned_interfaces,omitempty"` // Interfaces listed first in the network rows, in this order
+	CollapseSimilar  int                   `json:"collapse_similar,omitempty"`  // Similar disk or network rows collapse into one from this many; unset or 0 never
+	Locale           string                `json:"locale,omitempty"`            // Number and clock conventions, e.g. de_DE; unset follows LANG and LC_*
+	Background       string                `json:"background,omitempty"`        // Terminal background selecting the color variants: auto, dark or light
+	Plugins          []Plugin              `json:"plugins,omitempty"`           // External executables contributing panels to the plugins page
+	Profiles         map[string]Profile    `json:"profiles,omitempty"`          // Named variants selected with -profile or :profile, keyed by name
+	ProcessStates    *ProcessStates        `json:"process_states,omitempty"`    // Zombie and D-state counts above which the status bar warns
+	InterfaceGroups  []InterfaceGroup      `json:"interface_groups"`            // Network interfaces shown as one row; unset or null selects the VPN group, [] disables grouping
+	Alerts           *Alerts               `json:"alerts,omitempty"`            // What happens when alerts fire besides the notifications
+	AnomalySigma     *float64              `json:"anomaly_sigma,omitempty"`     // Standard deviations from the baseline that mark a metric unusual; 0 disables the marker
+}
+
+// Alerts configures what happens when alerts fire
+type Alerts struct {
+	Record *AlertRecording `json:"record,omitempty"` // Record snapshots while alerts fire; unset disables recording
+}
+
+// DefaultRecordAfter is how long recording continues after the last alert
+// cleared when alerts.record sets no duration
+const DefaultRecordAfter = 5 * time.Minute
+
+// AlertRecording configures recording snapshots to disk while alerts fire
+type AlertRecording struct {
+	Dir   string `json:"dir,omitempty"`   // Directory of the recordings, the working directory when unset
+	After string `json:"after,omitempty"` // Recording continues this long after the last alert cleared, e.g. "10m"
+}
+
+// InterfaceGroup gathers the network interfaces matching its patterns, such
+// as VPN tunnels, into one row of the Network panel
+type InterfaceGroup struct {
+	Name     string   `json:"name"`
+	Patterns []string `json:"patterns"` // Interface name globs such as "wg*"
+}
+
+// ProcessStates holds the process counts above which the status bar warns;
+// unset counts keep their default
+type ProcessStates struct {
+	Zombie          *int `json:"zombie,omitempty"`
+	Uninterruptible *int `json:"uninterruptible,omitempty"`
+}
+
+// MinProfileInterval is the shortest refresh interval a profile can set
+const MinProfileInterval = 100 * time.Millisecond
+
+// Profile is a named variant of the configuration for one workflow, such as
+// "server" or "network-debug". Unset fields keep the value of the config file.
+type Profile struct {
+	Layout           *Layout               `json:"layout,omitempty"`            // Overview grid replacing Layout
+	Tabs             []Tab                 `json:"tabs"`                        // Tabs replacing Tabs; unset or null keeps them, [] disables them
+	Interval         string                `json:"interval,omitempty"`          // Refresh interval such as "5s"
+	SensorThresholds map[string]Thresholds `json:"sensor_thresholds,omitempty"` // Per sensor kind, merged over SensorThresholds
+}
+
+// IntervalDuration returns the refresh interval of the profile, 0 when it sets none
+func (p Profile) IntervalDuration() time.Duration {
+	interval, err := time.ParseDuration(p.Interval)
+	if err != nil {
+		return 0
+	}
+	return interval
+}
+
+// ProfileNames returns the names of the configured profiles in order
+func (c Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithProfile returns the configuration with the fields set by the named
+// profile replacing those of the config file; "" selects no profile
+func (c Config) WithProfile(name string) (Config, error) {
+	if name == "" {
+		return c, nil
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return c, fmt.Errorf("unknown profile %q (want one of %v)", name, c.ProfileNames())
+	}
+
+	if profile.Layout != nil {
+		c.Layout = *profile.Layout
+	}
+	if profile.Tabs != nil {
+		c.Tabs = profile.Tabs
+	}
+	if len(profile.SensorThresholds) > 0 {
+		thresholds := make(map[string]Thresholds, len(c.SensorThresholds)+len(profile.SensorThresholds))
+		for kind, value := range c.SensorThresholds {
+			thresholds[kind] = value
+		}
+		for kind, value := range profile.SensorThresholds {
+			thresholds[kind] = value
+		}
+		c.SensorThresholds = thresholds
+	}
+	return c, nil
+}
+
+// DefaultPluginTimeout bounds a plugin run when no timeout is configured
+const DefaultPluginTimeout = 5 * time.Second
+
+// Plugin is an external executable run on every tick that prints a JSON
+// models.PluginOutput to stdout
+type Plugin struct {
+	Name    string   `json:"name"`              // Unique name, the panel title unless the plugin sets one
+	Command []string `json:"command"`           // Executable and its arguments
+	Timeout string   `json:"timeout,omitempty"` // Run time limit such as "2s", default DefaultPluginTimeout
+}
+
+// TimeoutDuration returns the run time limit of the plugin
+func (p Plugin) TimeoutDuration() time.Duration {
+	timeout, err := time.ParseDuration(p.Timeout)
+	if err != nil || timeout <= 0 {
+		return DefaultPluginTimeout
+	}
+	return timeout
+}
+
+// Default returns the configuration used when no config file exists
+func Default() Config {
+	return Config{
+		Panels: map[string]Style{},
+	}
+}
+
+// DefaultPath returns the default config file location, e.g. ~/.config/golang-system-monitor-tui/config.json
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, appDirName, "config.json")
+}
+
+// DefaultBootStatePath returns where the boot record used to notice reboots
+// between runs is kept, e.g. ~/.cache/golang-system-monitor-tui/boot.json
+func DefaultBootStatePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, appDirName, "boot.json")
+}
+
+// ScriptsDir returns the directory panel scripts are loaded from: scripts/
+// next to the config file at configPath
+func ScriptsDir(configPath string) string {
+	if configPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(configPath), "scripts")
+}
+
+// Load reads and validates a config file. A missing file yields the default
+// configuration unless required is set.
+func Load(path string, required bool) (Config, error) {
+	config := Default()
+	if path == "" {
+		return config, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			return config, nil
+		}
+		return config, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Default(), fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if config.Panels == nil {
+		config.Panels = map[string]Style{}
+	}
+
+	if err := config.Validate(); err != nil {
+		return Default(), fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Save writes the config file, creating its directory when needed
+func Save(path string, config Config) error {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	// Only the application's own directory is created, never the user
+	// config directory holding it
+	if err := os.Mkdir(filepath.Dir(path), 0755); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// WriteFileAtomic replaces the file at path with data through a synced
+// temporary file in the same directory and a rename, so readers, concurrent
+// writers and crashes never leave it half written. The directory must exist.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Fails harmlessly once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Validate checks panel names, colors, glyphs, layout and temperature settings
+func (c Config) Validate() error {
+	if err := c.Style.validate("style"); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(c.Panels))
+	for name := range c.Panels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !isPanelName(name) {
+			return fmt.Errorf("unknown panel %q (want one of %v)", name, PanelNames)
+		}
+		if err := c.Panels[name].validate("panels." + name); err != nil {
+			return err
+		}
+	}
+
+	if err := validateGrid("layout", c.Layout.Panels, c.Layout.Columns); err != nil {
+		return err
+	}
+
+	if err := validateTabs("tabs", c.Tabs); err != nil {
+		return err
+	}
+
+	if err := validateSplits("layout", c.Layout); err != nil {
+		return err
+	}
+
+	if _, err := models.ParseTemperatureUnit(c.TemperatureUnit); err != nil {
+		return fmt.Errorf("temperature_unit: %w", err)
+	}
+	if _, err := models.ParseRateUnit(c.RateUnit); err != nil {
+		return fmt.Errorf("rate_unit: %w", err)
+	}
+	if _, err := models.ParseRolloverStrategy(c.CounterRollover); err != nil {
+		return fmt.Errorf("counter_rollover: %w", err)
+	}
+	if _, err := models.ParseDiskSort(c.DiskSort); err != nil {
+		return fmt.Errorf("disk_sort: %w", err)
+	}
+	if _, err := models.ParseInterfaceSort(c.InterfaceSort); err != nil {
+		return fmt.Errorf("interface_sort: %w", err)
+	}
+	if slices.Contains(c.PinnedDisks, "") {
+		return fmt.Errorf("pinned_disks: mountpoints must not be empty")
+	}
+	if slices.Contains(c.PinnedInterfaces, "") {
+		return fmt.Errorf("pinned_interfaces: interface names must not be empty")
+	}
+	if c.CollapseSimilar < 0 || c.CollapseSimilar == 1 {
+		return fmt.Errorf("collapse_similar: %d is out of range (want 0 or at least 2)", c.CollapseSimilar)
+	}
+	if err := validateThresholds("sensor_thresholds", c.SensorThresholds); err != nil {
+		return err
+	}
+	if err := validateDiskThresholds("disk_thresholds", c.DiskThresholds); err != nil {
+		return err
+	}
+	if err := validateMemoryThresholds("memory_thresholds", c.MemoryThresholds); err != nil {
+		return err
+	}
+	if states := c.ProcessStates; states != nil {
+		if states.Zombie != nil && *states.Zombie < 0 {
+			return fmt.Errorf("process_states.zombie: must not be negative")
+		}
+		if states.Uninterruptible != nil && *states.Uninterruptible < 0 {
+			return fmt.Errorf("process_states.uninterruptible: must not be negative")
+		}
+	}
+	if err := validateInterfaceGroups("interface_groups", c.InterfaceGroups); err != nil {
+		return err
+	}
+	if c.AnomalySigma != nil && *c.AnomalySigma < 0 {
+		return fmt.Errorf("anomaly_sigma: must not be negative")
+	}
+	if c.Alerts != nil && c.Alerts.Record != nil && c.Alerts.Record.After != "" {
+		if after, err := time.ParseDuration(c.Alerts.Record.After); err != nil || after < 0 {
+			return fmt.Errorf("alerts.record.after: invalid duration %q (want a duration such as 10m)", c.Alerts.Record.After)
+		}
+	}
+	if c.Locale != "" {
+		if _, err := models.ParseLocale(c.Locale); err != nil {
+			return fmt.Errorf("locale: %w", err)
+		}
+	}
+	if c.Background != "" && !contains(Backgrounds, c.Background) {
+		return fmt.Errorf("background: unknown background %q (want one of %v)", c.Background, Backgrounds)
+	}
+
+	pluginNames := map[string]bool{}
+	for i, plugin := range c.Plugins {
+		path := fmt.Sprintf("plugins[%d]", i)
+		if plugin.Name == "" {
+			return fmt.Errorf("%s: name is required", path)
+		}
+		if pluginNames[plugin.Name] {
+			return fmt.Errorf("%s: plugin name %q is used more than once", path, plugin.Name)
+		}
+		pluginNames[plugin.Name] = true
+		if len(plugin.Command) == 0 || plugin.Command[0] == "" {
+			return fmt.Errorf("%s: command is required", path)
+		}
+		if plugin.Timeout != "" {
+			if timeout, err := time.ParseDuration(plugin.Timeout); err != nil || timeout <= 0 {
+				return fmt.Errorf("%s: invalid timeout %q (want a duration such as 2s)", path, plugin.Timeout)
+			}
+		}
+	}
+
+	for _, name := range c.ProfileNames() {
+		if err := c.Profiles[name].validate("profiles." + name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks the layout, tabs, interval and thresholds of a profile
+func (p Profile) validate(path string) error {
+	if p.Layout != nil {
+		if err := validateGrid(path+".layout", p.Layout.Panels, p.Layout.Columns); err != nil {
+			return err
+		}
+		if err := validateSplits(path+".layout", *p.Layout); err != nil {
+			return err
+		}
+	}
+	if err := validateTabs(path+".tabs", p.Tabs); err != nil {
+		return err
+	}
+	if p.Interval != "" {
+		if interval, err := time.ParseDuration(p.Interval); err != nil || interval < MinProfileInterval {
+			return fmt.Errorf("%s.interval: invalid interval %q (want a duration of at least %v)", path, p.Interval, MinProfileInterval)
+		}
+	}
+	return validateThresholds(path+".sensor_thresholds", p.SensorThresholds)
+}
+
+// validateTabs checks the number, names and grids of the tabs after Overview
+func validateTabs(path string, tabs []Tab) error {
+	if len(tabs) > MaxTabs-1 {
+		return fmt.Errorf("%s: at most %d tabs can follow Overview, got %d", path, MaxTabs-1, len(tabs))
+	}
+	tabNames := map[string]bool{"overview": true}
+	for i, tab := range tabs {
+		tabPath := fmt.Sprintf("%s[%d]", path, i)
+		if tab.Name == "" {
+			return fmt.Errorf("%s: name is required", tabPath)
+		}
+		if tabNames[strings.ToLower(tab.Name)] {
+			return fmt.Errorf("%s: tab name %q is used more than once", tabPath, tab.Name)
+		}
+		tabNames[strings.ToLower(tab.Name)] = true
+		if len(tab.Panels) == 0 {
+			return fmt.Errorf("%s: panels are required", tabPath)
+		}
+		if err := validateGrid(tabPath, tab.Panels, tab.Columns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSplits checks that set split ratios of a layout are within range
+func validateSplits(path string, layout Layout) error {
+	splits := map[string]float64{"column_split": layout.ColumnSplit, "row_split": layout.RowSplit}
+	for field, value := range splits {
+		if value != 0 && (value < MinSplit || value > MaxSplit) {
+			return fmt.Errorf("%s.%s: %g is out of range (want %g-%g)", path, field, value, MinSplit, MaxSplit)
+		}
+	}
+	return nil
+}
+
+// validateThresholds checks the sensor kinds and the order of their thresholds
+func validateThresholds(path string, thresholds map[string]Thresholds) error {
+	for kind, value := range thresholds {
+		if _, ok := models.ParseSensorKind(kind); !ok {
+			return fmt.Errorf("%s: unknown sensor kind %q (want cpu, gpu, nvme, chassis or other)", path, kind)
+		}
+		if value.Warning >= value.Critical {
+			return fmt.Errorf("%s.%s: warning must be below critical", path, kind)
+		}
+	}
+	return nil
+}
+
+// validateDiskThresholds checks the mountpoints and percentages of the disk
+// thresholds
+func validateDiskThresholds(path string, thresholds map[string]Thresholds) error {
+	for mountpoint, value := range thresholds {
+		if mountpoint == "" {
+			return fmt.Errorf("%s: mountpoints must not be empty", path)
+		}
+		for field, percent := range map[string]float64{"warning": value.Warning, "critical": value.Critical} {
+			if percent < 0 || percent > 100 {
+				return fmt.Errorf("%s.%s.%s: %g is out of range (want 0-100)", path, mountpoint, field, percent)
+			}
+		}
+		if value.Warning >= value.Critical {
+			return fmt.Errorf("%s.%s: warning must be below critical", path, mountpoint)
+		}
+	}
+	return nil
+}
+
+// validateMemoryThresholds checks the basis and percentages of the memory
+// thresholds, which are reached going down with the available basis
+func validateMemoryThresholds(path string, thresholds *MemoryThresholds) error {
+	if thresholds == nil {
+		return nil
+	}
+	if _, err := models.ParseMemoryBasis(thresholds.Basis); err != nil {
+		return fmt.Errorf("%s.basis: %w", path, err)
+	}
+	for field, percent := range map[string]*float64{"warning": thresholds.Warning, "critical": thresholds.Critical} {
+		if percent != nil && (*percent < 0 || *percent > 100) {
+			return fmt.Errorf("%s.%s: %g is out of range (want 0-100)", path, field, *percent)
+		}
+	}
+	resolved := thresholds.resolve()
+	if resolved.Basis == models.MemoryBasisAvailable && resolved.Warning <= resolved.Critical {
+		return fmt.Errorf("%s: warning must be above critical with the available basis", path)
+	}
+	if resolved.Basis == models.MemoryBasisUsed && resolved.Warning >= resolved.Critical {
+		return fmt.Errorf("%s: warning must be below critical", path)
+	}
+	return nil
+}
+
+// validateInterfaceGroups checks the names and patterns of the network
+// interface groups
+func validateInterfaceGroups(path string, groups []InterfaceGroup) error {
+	names := map[string]bool{}
+	for i, group := range groups {
+		groupPath := fmt.Sprintf("%s[%d]", path, i)
+		if group.Name == "" {
+			return fmt.Errorf("%s: name is required", groupPath)
+		}
+		if names[group.Name] {
+			return fmt.Errorf("%s: group name %q is used more than once", groupPath, group.Name)
+		}
+		names[group.Name] = true
+		if len(group.Patterns) == 0 {
+			return fmt.Errorf("%s: patterns are required", groupPath)
+		}
+		for _, pattern := range group.Patterns {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%s: invalid pattern %q: %w", groupPath, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// NoticeInterfaces reports whether network interfaces appearing and going
+// away are shown in the status line
+func (c Config) NoticeInterfaces() bool {
+	return c.InterfaceNotices == nil || *c.InterfaceNotices
+}
+
+// Anomaly returns the standard deviations from their baselines from which
+// metrics are marked unusual, 0 when the marker is off
+func (c Config) Anomaly() float64 {
+	if c.AnomalySigma == nil {
+		return models.DefaultAnomalySigma
+	}
+	return *c.AnomalySigma
+}
+
+// AlertRecording returns the directory and duration past the last cleared
+// alert of recording snapshots while alerts fire, and whether recording is
+// configured at all
+func (c Config) AlertRecording() (string, time.Duration, bool) {
+	if c.Alerts == nil || c.Alerts.Record == nil {
+		return "", 0, false
+	}
+	after, err := time.ParseDuration(c.Alerts.Record.After)
+	if err != nil {
+		after = DefaultRecordAfter
+	}
+	return c.Alerts.Record.Dir, after, true
+}
+
+// NetworkGroups returns the network interface groups, the VPN group when
+// none are configured
+func (c Config) NetworkGroups() []models.InterfaceGroup {
+	if c.InterfaceGroups == nil {
+		return models.DefaultInterfaceGroups()
+	}
+	groups := make([]models.InterfaceGroup, len(c.InterfaceGroups))
+	for i, group := range c.InterfaceGroups {
+		groups[i] = models.InterfaceGroup{Name: group.Name, Patterns: group.Patterns}
+	}
+	return groups
+}
+
+// TabList returns the tabs shown after Overview
+func (c Config) TabList() []Tab {
+	if c.Tabs == nil {
+		return DefaultTabs()
+	}
+	return c.Tabs
+}
+
+// validateGrid checks the panel names and column count of a grid
+func validateGrid(path string, panels []string, columns int) error {
+	seen := make(map[string]bool)
+	for _, name := range panels {
+		if !isGridPanelName(name) {
+			return fmt.Errorf("%s.panels: %q cannot be placed in the grid (want one of %v)", path, name, GridPanelNames)
+		}
+		if seen[name] {
+			return fmt.Errorf("%s.panels: %q is listed more than once", path, name)
+		}
+		seen[name] = true
+	}
+	if columns < 0 || columns > len(GridPanelNames) {
+		return fmt.Errorf("%s.columns: %d is out of range (want 1-%d)", path, columns, len(GridPanelNames))
+	}
+	return nil
+}
+
+// Unit returns the configured temperature unit
+func (c Config) Unit() models.TemperatureUnit {
+	unit, _ := models.ParseTemperatureUnit(c.TemperatureUnit)
+	return unit
+}
+
+// Rates returns the configured network rate unit
+func (c Config) Rates() models.RateUnit {
+	unit, _ := models.ParseRateUnit(c.RateUnit)
+	return unit
+}
+
+// Rollover returns the configured strategy for network counters that went
+// backwards
+func (c Config) Rollover() models.RolloverStrategy {
+	strategy, _ := models.ParseRolloverStrategy(c.CounterRollover)
+	return strategy
+}
+
+// DiskOrder returns the configured order of the disk rows
+func (c Config) DiskOrder() models.DiskSort {
+	order, _ := models.ParseDiskSort(c.DiskSort)
+	return order
+}
+
+// InterfaceOrder returns the configured order of the network rows
+func (c Config) InterfaceOrder() models.InterfaceSort {
+	order, _ := models.ParseInterfaceSort(c.InterfaceSort)
+	return order
+}
+
+// LocaleSettings returns the configured locale, or the locale of the
+// environment when none is configured
+func (c Config) LocaleSettings() models.Locale {
+	if c.Locale == "" {
+		return models.LocaleFromEnv(os.Getenv)
+	}
+	locale, _ := models.ParseLocale(c.Locale)
+	return locale
+}
+
+// Thresholds returns the configured sensor thresholds per kind in Celsius.
+// Kinds left out are absent, so their sensors keep the hardware limits or the
+// built-in defaults.
+func (c Config) Thresholds() map[models.SensorKind]models.SensorThresholds {
+	thresholds := make(map[models.SensorKind]models.SensorThresholds, len(c.SensorThresholds))
+	unit := c.Unit()
+	for name, configured := range c.SensorThresholds {
+		if kind, ok := models.ParseSensorKind(name); ok {
+			thresholds[kind] = models.SensorThresholds{
+				Warning:  unit.ToCelsius(configured.Warning),
+				Critical: unit.ToCelsius(configured.Critical),
+			}
+		}
+	}
+	return thresholds
+}
+
+// DiskUsageThresholds returns the configured thresholds per mountpoint
+func (c Config) DiskUsageThresholds() map[string]models.UsageThresholds {
+	thresholds := make(map[string]models.UsageThresholds, len(c.DiskThresholds))
+	for mountpoint, configured := range c.DiskThresholds {
+		thresholds[mountpoint] = models.UsageThresholds{Warning: configured.Warning, Critical: configured.Critical}
+	}
+	return thresholds
+}
+
+// MemoryUsageThresholds returns the configured memory thresholds, the
+// defaults of the used basis when unset
+func (c Config) MemoryUsageThresholds() models.MemoryThresholds {
+	if c.MemoryThresholds == nil {
+		return models.DefaultMemoryThresholds(models.MemoryBasisUsed)
+	}
+	return c.MemoryThresholds.resolve()
+}
+
+// resolve returns the thresholds with unset percentages taken from the
+// defaults of the basis
+func (t MemoryThresholds) resolve() models.MemoryThresholds {
+	basis, _ := models.ParseMemoryBasis(t.Basis)
+	thresholds := models.DefaultMemoryThresholds(basis)
+	if t.Warning != nil {
+		thresholds.Warning = *t.Warning
+	}
+	if t.Critical != nil {
+		thresholds.Critical = *t.Critical
+	}
+	return thresholds
+}
+
+// AlertRules returns the built-in alert rules, with the Memory rule moved to
+// the critical memory threshold when memory_thresholds is set, and a Disk
+// rule at the critical threshold of every mountpoint that has thresholds of
+// its own
+func (c Config) AlertRules() []models.AlertRule {
+	rules := models.DefaultAlertRules()
+	if c.MemoryThresholds != nil {
+		for i, rule := range rules {
+			if rule.Component == "Memory" {
+				rules[i] = c.MemoryUsageThresholds().AlertRule()
+			}
+		}
+	}
+	mountpoints := make([]string, 0, len(c.DiskThresholds))
+	for mountpoint := range c.DiskThresholds {
+		mountpoints = append(mountpoints, mountpoint)
+	}
+	sort.Strings(mountpoints)
+	for _, mountpoint := range mountpoints {
+		rules = append(rules, models.AlertRule{Component: "Disk", Subject: mountpoint, Threshold: c.DiskThresholds[mountpoint].Critical})
+	}
+	return rules
+}
+
+// ProcessStateThresholds returns the configured zombie and uninterruptible
+// process counts above which the status bar warns
+func (c Config) ProcessStateThresholds() models.ProcessStateThresholds {
+	thresholds := models.DefaultProcessStateThresholds()
+	if c.ProcessStates != nil {
+		if c.ProcessStates.Zombie != nil {
+			thresholds.Zombie = *c.ProcessStates.Zombie
+		}
+		if c.ProcessStates.Uninterruptible != nil {
+			thresholds.Uninterruptible = *c.ProcessStates.Uninterruptible
+		}
+	}
+	return thresholds
+}
+
+// PanelStyle returns the effective overrides for a panel
+func (c Config) PanelStyle(name string) Style {
+	return c.Style.Merge(c.Panels[name])
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validate checks every color and glyph in the style
+func (s Style) validate(path string) error {
+	colors := map[string]string{
+		"border":         s.Border,
+		"focused_border": s.FocusedBorder,
+		"header":         s.Header,
+		"text":           s.Text,
+		"muted":          s.Muted,
+		"normal":         s.Normal,
+		"warning":        s.Warning,
+		"critical":       s.Critical,
+	}
+	for field, value := range colors {
+		if value != "" && !isColor(value) {
+			return fmt.Errorf("%s.%s: invalid color %q (want 0-255 or #rrggbb)", path, field, value)
+		}
+	}
+
+	glyphs := map[string]string{"bar_filled": s.BarFilled, "bar_empty": s.BarEmpty}
+	for field, value := range glyphs {
+		if value != "" && utf8.RuneCountInString(value) != 1 {
+			return fmt.Errorf("%s.%s: glyph %q must be a single character", path, field, value)
+		}
+	}
+	if s.Bars != "" && !contains(BarModes, s.Bars) {
+		return fmt.Errorf("%s.bars: unknown bar mode %q (want one of %v)", path, s.Bars, BarModes)
+	}
+	return nil
+}
+
+// isColor reports whether value is an ANSI color number or a hex color
+func isColor(value string) bool {
+	if hexColor.MatchString(value) {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// isPanelName reports whether name is a known panel
+func isPanelName(name string) bool {
+	return contains(PanelNames, name)
+}
+
+// isGridPanelName reports whether name is a panel that can be placed in the grid
+func isGridPanelName(name string) bool {
+	return contains(GridPanelNames, name)
+}
+
+// contains reports whether names includes name
+func contains(names []string, name string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
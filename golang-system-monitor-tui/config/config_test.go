@@ -0,0 +1,622 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "absent.json")
+
+	config, err := Load(missing, false)
+	if err != nil {
+		t.Fatalf("Expected defaults for a missing optional file, got %v", err)
+	}
+	if config.Panels == nil {
+		t.Error("Expected initialized panel map")
+	}
+
+	if _, err := Load(missing, true); err == nil {
+		t.Error("Expected error for a missing required file")
+	}
+}
+
+func TestLoad_PanelOverrides(t *testing.T) {
+	path := writeConfig(t, `{
+		"style": {"focused_border": "#7aa2f7", "bar_filled": "▰", "bar_empty": "▱"},
+		"panels": {
+			"cpu": {"normal": "#9ece6a", "focused_border": "212"},
+			"disk": {"bar_filled": "#"}
+		}
+	}`)
+
+	config, err := Load(path, true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	cpu := config.PanelStyle("cpu")
+	if cpu.FocusedBorder != "212" || cpu.Normal != "#9ece6a" || cpu.BarFilled != "▰" {
+		t.Errorf("Expected cpu overrides on top of global style, got %+v", cpu)
+	}
+
+	disk := config.PanelStyle("disk")
+	if disk.BarFilled != "#" || disk.BarEmpty != "▱" || disk.FocusedBorder != "#7aa2f7" {
+		t.Errorf("Expected disk glyph override with inherited colors, got %+v", disk)
+	}
+
+	if memory := config.PanelStyle("memory"); memory != config.Style {
+		t.Errorf("Expected panels without overrides to use the global style, got %+v", memory)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{`{"panels": {"gpu": {}}}`, "unknown panel"},
+		{`{"panels": {"cpu": {"border": "blue"}}}`, "invalid color"},
+		{`{"style": {"critical": "256"}}`, "invalid color"},
+		{`{"style": {"bar_filled": "##"}}`, "single character"},
+		{`{"panels": {"cpu": {"bars": "dots"}}}`, "panels.cpu.bars"},
+		{`{"style": `, "failed to parse"},
+		{`{"temperature_unit": "kelvin"}`, "temperature_unit"},
+		{`{"rate_unit": "baud"}`, "rate_unit"},
+		{`{"sensor_thresholds": {"psu": {"warning": 50, "critical": 60}}}`, "unknown sensor kind"},
+		{`{"sensor_thresholds": {"cpu": {"warning": 90, "critical": 80}}}`, "warning must be below critical"},
+		{`{"disk_thresholds": {"/data": {"warning": 97, "critical": 95}}}`, "disk_thresholds./data: warning must be below critical"},
+		{`{"disk_thresholds": {"/": {"warning": 80, "critical": 120}}}`, "disk_thresholds./.critical"},
+		{`{"disk_thresholds": {"": {"warning": 80, "critical": 90}}}`, "disk_thresholds"},
+		{`{"memory_thresholds": {"basis": "free"}}`, "memory_thresholds.basis"},
+		{`{"memory_thresholds": {"critical": 101}}`, "memory_thresholds.critical"},
+		{`{"memory_thresholds": {"warning": 95}}`, "memory_thresholds: warning must be below critical"},
+		{`{"memory_thresholds": {"basis": "available", "warning": 5}}`, "memory_thresholds: warning must be above critical"},
+		{`{"layout": {"column_split": 0.9}}`, "layout.column_split"},
+		{`{"layout": {"panels": ["cpu", "containers"]}}`, "cannot be placed in the grid"},
+		{`{"layout": {"panels": ["cpu", "cpu"]}}`, "more than once"},
+		{`{"layout": {"columns": -1}}`, "layout.columns"},
+		{`{"tabs": [{"name": "", "panels": ["disk"]}]}`, "name is required"},
+		{`{"tabs": [{"name": "overview", "panels": ["disk"]}]}`, "used more than once"},
+		{`{"tabs": [{"name": "Disks", "panels": []}]}`, "panels are required"},
+		{`{"tabs": [{"name": "Disks", "panels": ["processes"]}]}`, "tabs[0].panels"},
+		{`{"process_states": {"zombie": -1}}`, "process_states.zombie"},
+		{`{"alerts": {"record": {"after": "soon"}}}`, "alerts.record.after"},
+		{`{"anomaly_sigma": -1}`, "anomaly_sigma"},
+		{`{"counter_rollover": "clamp"}`, "counter_rollover"},
+		{`{"disk_sort": "inode"}`, "disk_sort"},
+		{`{"interface_sort": "speed"}`, "interface_sort"},
+		{`{"pinned_disks": ["/home", ""]}`, "pinned_disks"},
+		{`{"pinned_interfaces": [""]}`, "pinned_interfaces"},
+		{`{"collapse_similar": 1}`, "collapse_similar"},
+		{`{"interface_groups": [{"patterns": ["wg*"]}]}`, "interface_groups[0]: name is required"},
+		{`{"interface_groups": [{"name": "VPN"}]}`, "patterns are required"},
+		{`{"interface_groups": [{"name": "VPN", "patterns": ["wg["]}]}`, "invalid pattern"},
+		{`{"locale": "german"}`, "locale"},
+		{`{"background": "black"}`, "background"},
+		{`{"plugins": [{"command": ["db-stats"]}]}`, "plugins[0]: name is required"},
+		{`{"plugins": [{"name": "db", "command": []}]}`, "command is required"},
+		{`{"plugins": [{"name": "db", "command": ["a"]}, {"name": "db", "command": ["b"]}]}`, "used more than once"},
+		{`{"plugins": [{"name": "db", "command": ["a"], "timeout": "soon"}]}`, "invalid timeout"},
+		{`{"profiles": {"server": {"layout": {"panels": ["gpu"]}}}}`, "profiles.server.layout.panels"},
+		{`{"profiles": {"server": {"tabs": [{"name": "Disks"}]}}}`, "profiles.server.tabs[0]: panels are required"},
+		{`{"profiles": {"server": {"interval": "10ms"}}}`, "profiles.server.interval"},
+		{`{"profiles": {"server": {"sensor_thresholds": {"cpu": {"warning": 90, "critical": 80}}}}}`, "profiles.server.sensor_thresholds.cpu"},
+	}
+
+	for _, tt := range tests {
+		_, err := Load(writeConfig(t, tt.content), true)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.content, tt.want, err)
+		}
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	t.Setenv("HOME", "/tmp/home")
+
+	if path := DefaultPath(); !strings.HasSuffix(path, filepath.Join(appDirName, "config.json")) {
+		t.Errorf("Unexpected default path %q", path)
+	}
+}
+
+func TestDefaultBootStatePath(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg-cache")
+	t.Setenv("HOME", "/tmp/home")
+
+	if path := DefaultBootStatePath(); !strings.HasSuffix(path, filepath.Join(appDirName, "boot.json")) {
+		t.Errorf("Unexpected boot state path %q", path)
+	}
+}
+
+func TestScriptsDir(t *testing.T) {
+	if dir := ScriptsDir(filepath.Join("etc", "monitor", "config.json")); dir != filepath.Join("etc", "monitor", "scripts") {
+		t.Errorf("Expected scripts next to the config file, got %q", dir)
+	}
+	if dir := ScriptsDir(""); dir != "" {
+		t.Errorf("Expected no scripts directory without a config file, got %q", dir)
+	}
+}
+
+func TestTemperatureSettings(t *testing.T) {
+	path := writeConfig(t, `{
+		"temperature_unit": "fahrenheit",
+		"sensor_thresholds": {"cpu": {"warning": 176, "critical": 203}}
+	}`)
+
+	config, err := Load(path, true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.Unit() != models.Fahrenheit {
+		t.Errorf("Expected Fahrenheit, got %v", config.Unit())
+	}
+
+	thresholds := config.Thresholds()
+	if cpu := thresholds[models.SensorCPU]; cpu.Warning != 80 || cpu.Critical != 95 {
+		t.Errorf("Expected Fahrenheit thresholds converted to 80/95°C, got %+v", cpu)
+	}
+	if _, exists := thresholds[models.SensorNVMe]; exists {
+		t.Error("Expected unconfigured kinds to be left to the hardware limits and defaults")
+	}
+
+	if Default().Unit() != models.Celsius {
+		t.Error("Expected Celsius by default")
+	}
+}
+
+func TestProcessStateThresholds(t *testing.T) {
+	config, err := Load(writeConfig(t, `{"process_states": {"zombie": 0}}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := models.ProcessStateThresholds{Zombie: 0, Uninterruptible: models.DefaultProcessStateThresholds().Uninterruptible}
+	if got := config.ProcessStateThresholds(); got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if Default().ProcessStateThresholds() != models.DefaultProcessStateThresholds() {
+		t.Error("Expected the default thresholds without process_states")
+	}
+}
+
+func TestAnomaly(t *testing.T) {
+	if Default().Anomaly() != models.DefaultAnomalySigma {
+		t.Errorf("Expected %v standard deviations by default", models.DefaultAnomalySigma)
+	}
+	config, err := Load(writeConfig(t, `{"anomaly_sigma": 0}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.Anomaly() != 0 {
+		t.Errorf("Expected 0 to turn the marker off, got %v", config.Anomaly())
+	}
+}
+
+func TestNoticeInterfaces(t *testing.T) {
+	if !Default().NoticeInterfaces() {
+		t.Error("Expected interface notices by default")
+	}
+	config, err := Load(writeConfig(t, `{"interface_notices": false}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.NoticeInterfaces() {
+		t.Error("Expected interface_notices false to turn the notices off")
+	}
+}
+
+func TestSortOrders(t *testing.T) {
+	if Default().DiskOrder() != models.DiskSortMounted || Default().InterfaceOrder() != models.InterfaceSortSystem {
+		t.Error("Expected rows in the order they are listed by default")
+	}
+	config, err := Load(writeConfig(t, `{"disk_sort": "usage", "interface_sort": "name"}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.DiskOrder() != models.DiskSortUsage || config.InterfaceOrder() != models.InterfaceSortName {
+		t.Errorf("Expected the configured orders, got %v and %v", config.DiskOrder(), config.InterfaceOrder())
+	}
+}
+
+func TestDiskThresholds(t *testing.T) {
+	config, err := Load(writeConfig(t, `{"disk_thresholds": {"/data": {"warning": 95, "critical": 97}, "/": {"warning": 80, "critical": 90}}}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := config.DiskUsageThresholds()["/data"]; got != (models.UsageThresholds{Warning: 95, Critical: 97}) {
+		t.Errorf("Expected the thresholds of /data, got %+v", got)
+	}
+
+	// Every mountpoint with thresholds gets an alert rule at its critical one
+	rules := config.AlertRules()
+	want := append(models.DefaultAlertRules(),
+		models.AlertRule{Component: "Disk", Subject: "/", Threshold: 90},
+		models.AlertRule{Component: "Disk", Subject: "/data", Threshold: 97})
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("AlertRules() = %+v, want %+v", rules, want)
+	}
+}
+
+func TestMemoryThresholds(t *testing.T) {
+	if got := Default().MemoryUsageThresholds(); got != models.DefaultMemoryThresholds(models.MemoryBasisUsed) {
+		t.Errorf("Expected the used defaults without memory_thresholds, got %+v", got)
+	}
+	if !reflect.DeepEqual(Default().AlertRules(), models.DefaultAlertRules()) {
+		t.Errorf("Expected the built-in alert rules without memory_thresholds, got %+v", Default().AlertRules())
+	}
+
+	config, err := Load(writeConfig(t, `{"memory_thresholds": {"basis": "available", "critical": 5}}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := models.MemoryThresholds{Basis: models.MemoryBasisAvailable, Warning: 20, Critical: 5}
+	if got := config.MemoryUsageThresholds(); got != want {
+		t.Errorf("MemoryUsageThresholds() = %+v, want %+v", got, want)
+	}
+	// The Memory alert follows the critical threshold
+	for _, rule := range config.AlertRules() {
+		if rule.Component == "Memory" && rule != (models.AlertRule{Component: "Memory", Threshold: 5, Below: true}) {
+			t.Errorf("Expected the Memory alert at 5%% available, got %+v", rule)
+		}
+	}
+}
+
+func TestRollover(t *testing.T) {
+	if Default().Rollover() != models.RolloverZero {
+		t.Error("Expected rates of counters that went backwards to read 0 by default")
+	}
+	config, err := Load(writeConfig(t, `{"counter_rollover": "wrap"}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.Rollover() != models.RolloverWrap {
+		t.Errorf("Expected the wrap strategy, got %v", config.Rollover())
+	}
+}
+
+func TestAlertRecording(t *testing.T) {
+	if _, _, ok := Default().AlertRecording(); ok {
+		t.Error("Expected no recording without alerts.record")
+	}
+	config, err := Load(writeConfig(t, `{"alerts": {"record": {"dir": "/var/tmp", "after": "10m"}}}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if dir, after, ok := config.AlertRecording(); !ok || dir != "/var/tmp" || after != 10*time.Minute {
+		t.Errorf("Expected recording to /var/tmp for 10m, got %q, %v (%v)", dir, after, ok)
+	}
+	config, err = Load(writeConfig(t, `{"alerts": {"record": {}}}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if dir, after, ok := config.AlertRecording(); !ok || dir != "" || after != DefaultRecordAfter {
+		t.Errorf("Expected recording to the working directory for %v, got %q, %v (%v)", DefaultRecordAfter, dir, after, ok)
+	}
+}
+
+func TestNetworkGroups(t *testing.T) {
+	config, err := Load(writeConfig(t, `{"interface_groups": [{"name": "Containers", "patterns": ["veth*", "docker*"]}]}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	groups := config.NetworkGroups()
+	if len(groups) != 1 || groups[0].Name != "Containers" || !groups[0].Matches("veth1a2b") {
+		t.Errorf("Expected the configured group, got %+v", groups)
+	}
+	if groups := Default().NetworkGroups(); len(groups) != 1 || groups[0].Name != "VPN" {
+		t.Errorf("Expected the VPN group by default, got %+v", groups)
+	}
+
+	config, err = Load(writeConfig(t, `{"interface_groups": []}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if groups := config.NetworkGroups(); len(groups) != 0 {
+		t.Errorf("Expected [] to disable grouping, got %+v", groups)
+	}
+}
+
+func TestRateUnitSetting(t *testing.T) {
+	config, err := Load(writeConfig(t, `{"rate_unit": "bits"}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.Rates() != models.BitsPerSecond {
+		t.Errorf("Expected bits per second, got %v", config.Rates())
+	}
+	if Default().Rates() != models.BytesPerSecond {
+		t.Error("Expected bytes per second by default")
+	}
+}
+
+func TestLocaleSettings(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "")
+	t.Setenv("LC_TIME", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	if locale := Default().LocaleSettings(); locale.Name != "en_US.UTF-8" || locale.Clock24 {
+		t.Errorf("Expected the locale of LANG without a config override, got %+v", locale)
+	}
+
+	config, err := Load(writeConfig(t, `{"locale": "de_DE"}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if locale := config.LocaleSettings(); locale.Decimal != "," || !locale.Clock24 {
+		t.Errorf("Expected the configured German locale, got %+v", locale)
+	}
+}
+
+func TestPlugins(t *testing.T) {
+	config, err := Load(writeConfig(t, `{"plugins": [
+		{"name": "postgres", "command": ["pg-stats", "--db", "app"], "timeout": "2s"},
+		{"name": "queue", "command": ["queue-depth"]}
+	]}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(config.Plugins) != 2 || config.Plugins[0].Command[2] != "app" {
+		t.Fatalf("Unexpected plugins %+v", config.Plugins)
+	}
+	if timeout := config.Plugins[0].TimeoutDuration(); timeout != 2*time.Second {
+		t.Errorf("Expected a 2s timeout, got %v", timeout)
+	}
+	if timeout := config.Plugins[1].TimeoutDuration(); timeout != DefaultPluginTimeout {
+		t.Errorf("Expected the default timeout, got %v", timeout)
+	}
+}
+
+func TestSave_Layout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.json")
+
+	config := Default()
+	config.Style.Header = "212"
+	config.Layout = Layout{ColumnSplit: 0.65, RowSplit: 0.35}
+	if err := Save(path, config); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(path, true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if column, row := loaded.Layout.Splits(); column != 0.65 || row != 0.35 {
+		t.Errorf("Expected saved splits 0.65/0.35, got %v/%v", column, row)
+	}
+	if loaded.Style.Header != "212" {
+		t.Errorf("Expected other settings to be kept, got %+v", loaded.Style)
+	}
+
+	if column, row := Default().Layout.Splits(); column != DefaultSplit || row != DefaultSplit {
+		t.Errorf("Expected even split by default, got %v/%v", column, row)
+	}
+}
+
+func TestSave_KeepsDisabledTabs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	config := Default()
+	config.Tabs = []Tab{}
+	config.Profiles = map[string]Profile{"quiet": {Tabs: []Tab{}}, "plain": {}}
+	if err := Save(path, config); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(path, true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Tabs == nil || len(loaded.TabList()) != 0 {
+		t.Errorf("Expected tabs to stay disabled, got %+v", loaded.TabList())
+	}
+	if tabs := loaded.Profiles["quiet"].Tabs; tabs == nil || len(tabs) != 0 {
+		t.Errorf("Expected the profile to keep disabling tabs, got %#v", tabs)
+	}
+	if tabs := loaded.Profiles["plain"].Tabs; tabs != nil {
+		t.Errorf("Expected a profile without tabs to keep them unset, got %#v", tabs)
+	}
+
+	// Unset tabs round-trip as unset and keep their defaults
+	if err := Save(path, Default()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if loaded, err = Load(path, true); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.TabList()) != len(DefaultTabs()) {
+		t.Errorf("Expected the default tabs, got %+v", loaded.TabList())
+	}
+}
+
+func TestSave_KeepsDisabledInterfaceGroups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	config := Default()
+	config.InterfaceGroups = []InterfaceGroup{}
+	if err := Save(path, config); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(path, true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if groups := loaded.NetworkGroups(); groups == nil || len(groups) != 0 {
+		t.Errorf("Expected interface grouping to stay disabled, got %+v", groups)
+	}
+
+	if err := Save(path, Default()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if loaded, err = Load(path, true); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.NetworkGroups()) != len(models.DefaultInterfaceGroups()) {
+		t.Errorf("Expected the VPN group when unset, got %+v", loaded.NetworkGroups())
+	}
+}
+
+func TestSave_Atomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	for _, header := range []string{"212", "99"} {
+		config := Default()
+		config.Style.Header = header
+		if err := Save(path, config); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "config.json" {
+		t.Errorf("Expected only the config file left behind, got %v", entries)
+	}
+	if loaded, err := Load(path, true); err != nil || loaded.Style.Header != "99" {
+		t.Errorf("Expected the last save to win, got %q (%v)", loaded.Style.Header, err)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spool.jsonl")
+	if err := WriteFileAtomic(path, []byte("first\n"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomic failed: %v", err)
+	}
+	if err := WriteFileAtomic(path, []byte("second\n"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomic failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "second\n" {
+		t.Errorf("Expected the second write, got %q (%v)", data, err)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected mode 0600, got %v", info.Mode().Perm())
+	}
+
+	if err := WriteFileAtomic(filepath.Join(path, "missing", "file"), nil, 0644); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestSave_KeepsMissingConfigDir(t *testing.T) {
+	// Like ~/.config/golang-system-monitor-tui/config.json without ~/.config
+	userConfig := filepath.Join(t.TempDir(), ".config")
+	if err := Save(filepath.Join(userConfig, appDirName, "config.json"), Default()); err == nil {
+		t.Error("Expected saving to fail without the user config directory")
+	}
+	if _, err := os.Stat(userConfig); !os.IsNotExist(err) {
+		t.Errorf("Expected the user config directory not to be created, got %v", err)
+	}
+}
+
+func TestLayoutGrid(t *testing.T) {
+	tests := []struct {
+		layout  Layout
+		panels  []string
+		columns int
+	}{
+		{Layout{}, DefaultGridPanels, 2},
+		{Layout{Panels: []string{"cpu", "memory"}}, []string{"cpu", "memory"}, 2},
+		{Layout{Columns: 1}, DefaultGridPanels, 1},
+		{Layout{Panels: []string{"network"}, Columns: 3}, []string{"network"}, 1},
+	}
+
+	for _, tt := range tests {
+		panels, columns := tt.layout.Grid()
+		if strings.Join(panels, ",") != strings.Join(tt.panels, ",") || columns != tt.columns {
+			t.Errorf("%+v: expected %v in %d columns, got %v in %d", tt.layout, tt.panels, tt.columns, panels, columns)
+		}
+	}
+}
+
+func TestTabList(t *testing.T) {
+	if tabs := Default().TabList(); len(tabs) != len(DefaultTabs()) || tabs[0].Name != "Storage" {
+		t.Errorf("Expected default tabs, got %+v", tabs)
+	}
+
+	config, err := Load(writeConfig(t, `{"tabs": [{"name": "Disks", "panels": ["disk", "memory"], "columns": 1}]}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	tabs := config.TabList()
+	if len(tabs) != 1 || tabs[0].Name != "Disks" {
+		t.Fatalf("Expected configured tab, got %+v", tabs)
+	}
+	if panels, columns := tabs[0].Grid(); len(panels) != 2 || columns != 1 {
+		t.Errorf("Expected two panels in one column, got %v in %d", panels, columns)
+	}
+
+	// An empty list turns the tabs off
+	config, err = Load(writeConfig(t, `{"tabs": []}`), true)
+	if err != nil || len(config.TabList()) != 0 {
+		t.Errorf("Expected no tabs, got %+v (%v)", config.TabList(), err)
+	}
+}
+
+func TestWithProfile(t *testing.T) {
+	config, err := Load(writeConfig(t, `{
+		"layout": {"panels": ["cpu", "memory", "disk", "network"]},
+		"sensor_thresholds": {"cpu": {"warning": 70, "critical": 85}, "nvme": {"warning": 60, "critical": 75}},
+		"profiles": {
+			"server": {"layout": {"panels": ["cpu", "disk"], "columns": 1}, "tabs": [], "interval": "5s",
+				"sensor_thresholds": {"cpu": {"warning": 80, "critical": 95}}},
+			"laptop": {}
+		}
+	}`), true)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if names := config.ProfileNames(); strings.Join(names, ",") != "laptop,server" {
+		t.Errorf("Expected sorted profile names, got %v", names)
+	}
+
+	server, err := config.WithProfile("server")
+	if err != nil {
+		t.Fatalf("WithProfile failed: %v", err)
+	}
+	if panels, columns := server.Layout.Grid(); strings.Join(panels, ",") != "cpu,disk" || columns != 1 {
+		t.Errorf("Expected the profile's grid, got %v in %d columns", panels, columns)
+	}
+	if len(server.TabList()) != 0 {
+		t.Errorf("Expected the profile to turn the tabs off, got %+v", server.TabList())
+	}
+	if server.SensorThresholds["cpu"].Warning != 80 || server.SensorThresholds["nvme"].Warning != 60 {
+		t.Errorf("Expected the profile's thresholds merged over the file's, got %+v", server.SensorThresholds)
+	}
+	if config.SensorThresholds["cpu"].Warning != 70 {
+		t.Error("Expected WithProfile to leave the config file's thresholds alone")
+	}
+	if interval := config.Profiles["server"].IntervalDuration(); interval != 5*time.Second {
+		t.Errorf("Expected a 5s interval, got %v", interval)
+	}
+
+	// An empty profile keeps everything of the config file
+	laptop, _ := config.WithProfile("laptop")
+	if len(laptop.Layout.Panels) != 4 || len(laptop.TabList()) != len(DefaultTabs()) || config.Profiles["laptop"].IntervalDuration() != 0 {
+		t.Errorf("Expected the config file's settings, got %+v", laptop)
+	}
+
+	if _, err := config.WithProfile("desktop"); err == nil || !strings.Contains(err.Error(), "laptop server") {
+		t.Errorf("Expected an unknown profile to list the profiles, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Package format renders sizes and transfer rates for the panels, so every
+// panel shows them with the same units, precision and number conventions.
+package format
+
+import (
+	"fmt"
+
+	"golang-system-monitor-tui/models"
+)
+
+// Options control how values are rendered
+type Options struct {
+	Locale    models.Locale    // Decimal and thousands separators
+	Units     models.SizeUnits // Powers of 1024 (KiB) or of 1000 (KB)
+	Precision int              // Decimals shown for scaled values
+	Width     int              // Right-align to this many columns, 0 for no padding
+}
+
+// DefaultOptions returns the C locale, IEC units and one decimal
+func DefaultOptions() Options {
+	return Options{Locale: models.DefaultLocale(), Units: models.IECUnits, Precision: 1}
+}
+
+// Suffixes of each unit system, from kilo up
+var sizeSuffixes = map[models.SizeUnits][]string{
+	models.IECUnits: {"KiB", "MiB", "GiB", "TiB", "PiB"},
+	models.SIUnits:  {"KB", "MB", "GB", "TB", "PB"},
+}
+
+// Bits per second suffixes, link speeds are always quoted in powers of 1000
+var bitSuffixes = []string{"Kbps", "Mbps", "Gbps", "Tbps"}
+
+// Bytes formats a size in the largest multiple it reaches, e.g. "1.5GiB", or
+// in plain bytes below a kilobyte
+func Bytes(bytes uint64, opts Options) string {
+	value, suffix := scale(float64(bytes), opts.Units.Base(), sizeSuffixes[opts.Units])
+	if suffix == "" {
+		return pad(opts.Locale.FormatInt(int64(bytes))+"B", opts.Width)
+	}
+	return pad(opts.Locale.FormatFloat(value, opts.Precision)+suffix, opts.Width)
+}
+
+// Rate formats a transfer rate given in bytes per second, e.g. "1.5MiB/s",
+// or in bits per second such as "12.0Mbps" when unit asks for bits
+func Rate(bytesPerSecond float64, unit models.RateUnit, opts Options) string {
+	if unit == models.BitsPerSecond {
+		value, suffix := scale(bytesPerSecond*8, 1000, bitSuffixes)
+		if suffix == "" {
+			return pad(opts.Locale.FormatFloat(value, 0)+"bps", opts.Width)
+		}
+		return pad(opts.Locale.FormatFloat(value, opts.Precision)+suffix, opts.Width)
+	}
+
+	value, suffix := scale(bytesPerSecond, opts.Units.Base(), sizeSuffixes[opts.Units])
+	if suffix == "" {
+		return pad(opts.Locale.FormatFloat(value, 0)+"B/s", opts.Width)
+	}
+	return pad(opts.Locale.FormatFloat(value, opts.Precision)+suffix+"/s", opts.Width)
+}
+
+// scale divides value by base until it is below base or the suffixes run
+// out, returning no suffix when value is below base to begin with
+func scale(value, base float64, suffixes []string) (float64, string) {
+	if value < base {
+		return value, ""
+	}
+	i := 0
+	for value /= base; value >= base && i < len(suffixes)-1; i++ {
+		value /= base
+	}
+	return value, suffixes[i]
+}
+
+// pad right-aligns s to width columns
+func pad(s string, width int) string {
+	return fmt.Sprintf("%*s", width, s)
+}
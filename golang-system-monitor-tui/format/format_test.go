@@ -0,0 +1,74 @@
+package format
+
+import (
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestBytes(t *testing.T) {
+	si := DefaultOptions()
+	si.Units = models.SIUnits
+
+	tests := []struct {
+		bytes uint64
+		opts  Options
+		want  string
+	}{
+		{0, DefaultOptions(), "0B"},
+		{512, DefaultOptions(), "512B"},
+		{1536, DefaultOptions(), "1.5KiB"},
+		{1073741824, DefaultOptions(), "1.0GiB"},
+		{1099511627776, DefaultOptions(), "1.0TiB"},
+		{999, si, "999B"},
+		{1500, si, "1.5KB"},
+		{1073741824, si, "1.1GB"},
+		{2000000000000, si, "2.0TB"},
+	}
+	for _, tt := range tests {
+		if got := Bytes(tt.bytes, tt.opts); got != tt.want {
+			t.Errorf("Bytes(%d, %v) = %q, want %q", tt.bytes, tt.opts.Units, got, tt.want)
+		}
+	}
+}
+
+func TestBytes_Options(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Precision = 2
+	opts.Width = 9
+	if got := Bytes(1572864, opts); got != "  1.50MiB" {
+		t.Errorf("Expected two decimals padded to 9 columns, got %q", got)
+	}
+
+	opts = DefaultOptions()
+	opts.Locale, _ = models.ParseLocale("de_DE")
+	if got := Bytes(1572864, opts); got != "1,5MiB" {
+		t.Errorf("Expected a decimal comma, got %q", got)
+	}
+}
+
+func TestRate(t *testing.T) {
+	si := DefaultOptions()
+	si.Units = models.SIUnits
+
+	tests := []struct {
+		bytesPerSecond float64
+		unit           models.RateUnit
+		opts           Options
+		want           string
+	}{
+		{0, models.BytesPerSecond, DefaultOptions(), "0B/s"},
+		{512, models.BytesPerSecond, DefaultOptions(), "512B/s"},
+		{1572864, models.BytesPerSecond, DefaultOptions(), "1.5MiB/s"},
+		{2500000, models.BytesPerSecond, si, "2.5MB/s"},
+		{0, models.BitsPerSecond, DefaultOptions(), "0bps"},
+		{100, models.BitsPerSecond, DefaultOptions(), "800bps"},
+		{1500000, models.BitsPerSecond, DefaultOptions(), "12.0Mbps"},
+		{125000000, models.BitsPerSecond, DefaultOptions(), "1.0Gbps"},
+	}
+	for _, tt := range tests {
+		if got := Rate(tt.bytesPerSecond, tt.unit, tt.opts); got != tt.want {
+			t.Errorf("Rate(%.0f, %v) = %q, want %q", tt.bytesPerSecond, tt.unit, got, tt.want)
+		}
+	}
+}
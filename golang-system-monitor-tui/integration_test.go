@@ -4,7 +4,9 @@ import (
 	"context"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -173,6 +175,44 @@ func TestApplicationRuntime(t *testing.T) {
 		}
 	})
 
+	t.Run("history saved on SIGTERM", func(t *testing.T) {
+		historyFile := filepath.Join(t.TempDir(), "history.json")
+		cmd := exec.Command("./test_system_monitor.exe",
+			"-demo",
+			"-interval", "100ms",
+			"-history-file", historyFile,
+			"-no-alt-screen",
+		)
+		// Bubble Tea can't read input from /dev/null, a pipe stands in for the terminal
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			t.Fatalf("Failed to create stdin pipe: %v", err)
+		}
+		defer stdin.Close()
+		if err := cmd.Start(); err != nil {
+			t.Fatalf("Failed to start application: %v", err)
+		}
+		time.Sleep(700 * time.Millisecond)
+		if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+			t.Fatalf("Failed to signal process: %v", err)
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- cmd.Wait()
+		}()
+		select {
+		case <-done:
+		case <-time.After(7 * time.Second):
+			cmd.Process.Kill()
+			t.Fatal("Process did not exit within timeout")
+		}
+
+		if stat, err := os.Stat(historyFile); err != nil || stat.Size() == 0 {
+			t.Errorf("Expected the history to be saved on SIGTERM, got %v", err)
+		}
+	})
+
 	t.Run("application with custom interval", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 		defer cancel()
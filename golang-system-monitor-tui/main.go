@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
@@ -11,18 +12,58 @@ import (
 	"time"
 	
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
 	
+	appconfig "golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+	"golang-system-monitor-tui/services/fake"
 	"golang-system-monitor-tui/ui"
 )
 
 // Config holds application configuration options
 type Config struct {
 	UpdateInterval time.Duration
+	IntervalSet    bool   // -interval was given, overriding the interval of -profile
 	LogFile        string
 	Debug          bool
 	NoMouse        bool
 	NoAltScreen    bool
 	Version        bool
+	Notify         bool
+	WebhookURL     string
+	LogAlerts      bool
+	QueueSize      int
+	QueuePolicy    string
+	SpoolPath      string
+	SpoolLimit     int
+	OTLP           bool
+	ConfigPath     string
+	Focus          string // Grid panel focused at startup
+	Page           string // Full-screen page opened at startup
+	Zoom           bool   // Start with the focused panel zoomed
+	Braille        bool   // Draw bars and graphs with braille dots
+	ASCII          bool   // Draw bars, graphs and borders with ASCII only
+	Snapshot       string // Render one frame to this file, - for standard output, and exit
+	SnapshotANSI   bool   // Keep colors in the -snapshot frame
+	TailFile       string // Log file followed in the log panel
+	HistoryFile    string // Graph history saved on exit and restored at startup
+	Demo           bool   // Show a synthetic machine instead of collecting metrics
+	Pools          bool   // Show ZFS pool and btrfs filesystem health in the Disk panel
+	Pprof          string // Address serving net/http/pprof while running
+	FPS            int    // Maximum redraws per second, 0 for Bubble Tea's default
+	DiskFullWarning time.Duration // Filesystems estimated to fill up sooner are highlighted
+	Bits           bool   // Show network rates in bits per second
+	Units          string // Multiples of sizes and byte rates, si or iec
+	Locale         string // Number and clock conventions, overriding the config file and LANG
+	Profile        string // Profile of the config file selected at startup
+	Accessible     bool   // Text summaries for screen readers instead of panels
+	Settings       appconfig.Config // Contents of the config file
+	FirstRun       bool   // No config file existed at the default location
+	Scripts        []*services.Script // Panel scripts from the scripts directory next to the config file
 }
 
 // Version information
@@ -34,35 +75,92 @@ const (
 // parseFlags parses command-line arguments and returns configuration
 func parseFlags() *Config {
 	config := &Config{}
-	
-	flag.DurationVar(&config.UpdateInterval, "interval", time.Second, "Update interval for system metrics (e.g., 500ms, 2s)")
-	flag.StringVar(&config.LogFile, "log", "", "Log file path (default: no logging)")
-	flag.BoolVar(&config.Debug, "debug", false, "Enable debug logging")
-	flag.BoolVar(&config.NoMouse, "no-mouse", false, "Disable mouse support")
-	flag.BoolVar(&config.NoAltScreen, "no-alt-screen", false, "Disable alternate screen buffer")
-	flag.BoolVar(&config.Version, "version", false, "Show version information")
+	registerFlags(flag.CommandLine, config)
 	
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", AppName)
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n", AppName)
+		fmt.Fprintf(os.Stderr, "       %s %s [-config path] [-log path] [-o file] [-- options]\n\n", AppName, bugReportCommand)
 		fmt.Fprintf(os.Stderr, "%s - A terminal-based system resource monitor\n\n", AppName)
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nCommands:\n")
+		fmt.Fprintf(os.Stderr, "  %s   Write a Markdown issue report with version, platform, config, recent log errors and a snapshot\n", bugReportCommand)
 		fmt.Fprintf(os.Stderr, "\nKeyboard shortcuts:\n")
 		fmt.Fprintf(os.Stderr, "  q, Ctrl+C    Quit application\n")
 		fmt.Fprintf(os.Stderr, "  arrows, tab  Navigate between components\n")
 		fmt.Fprintf(os.Stderr, "  r            Manual refresh\n")
+		fmt.Fprintf(os.Stderr, "  t            Toggle temperature sensors\n")
+		fmt.Fprintf(os.Stderr, "  c            Toggle containers\n")
+		fmt.Fprintf(os.Stderr, "  a            Toggle alert history\n")
+		fmt.Fprintf(os.Stderr, "  p, P         Toggle plugins, processes\n")
+		fmt.Fprintf(os.Stderr, "  g            Toggle the correlation view\n")
+		fmt.Fprintf(os.Stderr, "  u            Switch temperatures between °C and °F\n")
+		fmt.Fprintf(os.Stderr, "  b            Switch network rates between bytes and bits\n")
+		fmt.Fprintf(os.Stderr, "  o            Cycle the order of the focused disk or network rows\n")
+		fmt.Fprintf(os.Stderr, "  x            Expand or collapse similar disk or network rows\n")
+		fmt.Fprintf(os.Stderr, "  Ctrl+arrows  Resize the panel grid\n")
+		fmt.Fprintf(os.Stderr, "  z            Zoom the focused panel\n")
+		fmt.Fprintf(os.Stderr, "  [, ]         Graph a shorter or longer time range\n")
+		fmt.Fprintf(os.Stderr, "  <, >         Select a core of the CPU heatmap\n")
+		fmt.Fprintf(os.Stderr, "  s, S         Save a screenshot (S keeps colors)\n")
+		fmt.Fprintf(os.Stderr, "  1-9, F1-F9   Switch tab\n")
 		fmt.Fprintf(os.Stderr, "  ?, h         Toggle help\n")
 	}
 	
 	flag.Parse()
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "interval" {
+			config.IntervalSet = true
+		}
+	})
 	return config
 }
 
+// registerFlags defines the monitor's options on flags; bug-report parses the
+// same options to record the effective command line
+func registerFlags(flags *flag.FlagSet, config *Config) {
+	flags.DurationVar(&config.UpdateInterval, "interval", time.Second, "Update interval for system metrics (e.g., 500ms, 2s)")
+	flags.StringVar(&config.LogFile, "log", "", "Log file path (default: no logging)")
+	flags.BoolVar(&config.Debug, "debug", false, "Enable debug logging")
+	flags.BoolVar(&config.NoMouse, "no-mouse", false, "Disable mouse support")
+	flags.BoolVar(&config.NoAltScreen, "no-alt-screen", false, "Disable alternate screen buffer")
+	flags.BoolVar(&config.Version, "version", false, "Show version information")
+	flags.BoolVar(&config.Notify, "notify", false, "Show desktop notifications when alerts fire")
+	flags.StringVar(&config.WebhookURL, "webhook", "", "POST alerts as JSON to this webhook URL (Slack, Discord or generic)")
+	flags.BoolVar(&config.LogAlerts, "log-alerts", false, "Write fired and cleared alerts to the log file")
+	flags.IntVar(&config.QueueSize, "queue-size", services.DefaultQueueConfig().Capacity, "Maximum events buffered in memory per export sink")
+	flags.StringVar(&config.QueuePolicy, "queue-policy", "drop-oldest", "Event dropped when an export queue is full (drop-oldest, drop-newest)")
+	flags.StringVar(&config.SpoolPath, "spool", "", "Spool export queue overflow to this file instead of dropping it")
+	flags.IntVar(&config.SpoolLimit, "spool-limit", services.DefaultSpoolLimit, "Maximum events kept in the -spool file")
+	flags.StringVar(&config.ConfigPath, "config", "", "Config file path (default: "+appconfig.DefaultPath()+")")
+	flags.BoolVar(&config.OTLP, "otlp", false, "Export metrics over OTLP/HTTP, configured with OTEL_* environment variables")
+	flags.StringVar(&config.Focus, "focus", "", "Panel focused at startup (cpu, memory, disk, network, sensors, alerts, log, self, kernel)")
+	flags.StringVar(&config.Page, "page", "", "Page or tab opened at startup (sensors, containers, alerts, plugins, processes, correlation, help, or a tab name such as storage)")
+	flags.BoolVar(&config.Zoom, "zoom", false, "Start with the focused panel zoomed to full screen")
+	flags.BoolVar(&config.Braille, "braille", false, "Draw bars and graphs with braille dots for twice the resolution")
+	flags.BoolVar(&config.ASCII, "ascii", false, "Draw bars, graphs and borders with ASCII characters only (overrides -braille)")
+	flags.StringVar(&config.Snapshot, "snapshot", "", "Collect metrics once, write the rendered frame to this file (- for standard output) and exit")
+	flags.BoolVar(&config.SnapshotANSI, "snapshot-ansi", false, "Keep colors in the -snapshot frame")
+	flags.StringVar(&config.TailFile, "tail", "", "Follow this log file in a log panel, shown in a Logs tab next to CPU, memory and network")
+	flags.StringVar(&config.HistoryFile, "history-file", "", "Keep graph history in this file across restarts (default: history is lost on exit)")
+	flags.IntVar(&config.FPS, "fps", 0, "Maximum redraws per second, independent of -interval (1-120, default 60)")
+	flags.StringVar(&config.Pprof, "pprof", "", "Serve net/http/pprof on this address while running (e.g. :6060 or localhost:6060)")
+	flags.BoolVar(&config.Bits, "bits", false, "Show network rates in bits per second (Kbps, Mbps, Gbps) instead of bytes")
+	flags.StringVar(&config.Units, "units", "iec", "Show sizes in powers of 1024 (iec: KiB, MiB, GiB) or of 1000 (si: KB, MB, GB)")
+	flags.StringVar(&config.Locale, "locale", "", "Number and clock conventions, e.g. de_DE or en_US (default: the locale key of the config file, then LC_ALL, LC_NUMERIC and LANG)")
+	flags.StringVar(&config.Profile, "profile", "", "Start with this profile of the config file, e.g. server (switch at runtime with :profile)")
+	flags.BoolVar(&config.Accessible, "accessible", false, "Screen reader mode: plain text summaries per panel instead of borders, bars and graphs")
+	flags.DurationVar(&config.DiskFullWarning, "disk-full-warning", ui.DefaultDiskFullHorizon, "Highlight filesystems estimated to fill up within this duration at their growth since startup")
+	flags.BoolVar(&config.Pools, "pools", false, "Show ZFS pool and btrfs filesystem health in the Disk panel (runs zpool, zfs and btrfs every 30s)")
+	flags.BoolVar(&config.Demo, "demo", false, "Show a synthetic busy machine instead of this one, for screenshots or where collection is restricted")
+}
+
 // setupLogging configures logging based on configuration
 func setupLogging(config *Config) (*os.File, error) {
-	if config.LogFile == "" && !config.Debug {
-		// Disable logging by default
-		log.SetOutput(os.Stderr)
+	if config.LogFile == "" {
+		// The TUI owns the terminal, anything logged to stderr would be
+		// drawn over it, so without a log file nothing is logged
+		log.SetOutput(io.Discard)
 		return nil, nil
 	}
 	
@@ -85,13 +183,321 @@ func setupLogging(config *Config) (*os.File, error) {
 	return logFile, nil
 }
 
-// createProgram creates and configures the Bubble Tea program
-func createProgram(config *Config) *tea.Program {
+// loggingWarning explains why -debug shows nothing when it is given without -log
+func loggingWarning(config *Config) string {
+	if config.Debug && config.LogFile == "" {
+		return "Warning: -debug has no effect without -log, add -log <file> to write debug output"
+	}
+	return ""
+}
+
+// loadSettings reads the config file at path, or the default location when path is empty
+func loadSettings(path string) (appconfig.Config, error) {
+	if path != "" {
+		return appconfig.Load(path, true)
+	}
+	return appconfig.Load(settingsPath(path), false)
+}
+
+// settingsPath returns the config file in use: the given path or the default location
+func settingsPath(path string) string {
+	if path != "" {
+		return path
+	}
+	return appconfig.DefaultPath()
+}
+
+// isFirstRun reports whether the monitor has not been set up yet: no config
+// file was given and none exists at the default location
+func isFirstRun(path string) bool {
+	if path != "" || appconfig.DefaultPath() == "" {
+		return false
+	}
+	_, err := os.Stat(appconfig.DefaultPath())
+	return os.IsNotExist(err)
+}
+
+// queueConfig builds the export queue configuration, falling back to
+// the default drop policy when the configured one is invalid
+func queueConfig(config *Config) services.QueueConfig {
+	policy, err := services.ParseDropPolicy(config.QueuePolicy)
+	if err != nil {
+		log.Printf("%v, using drop-oldest", err)
+	}
+	return services.QueueConfig{
+		Capacity:   config.QueueSize,
+		Policy:     policy,
+		SpoolPath:  config.SpoolPath,
+		SpoolLimit: config.SpoolLimit,
+	}
+}
+
+// startView applies the -focus, -page and -zoom flags to the model
+func startView(model ui.MainModel, config *Config) (ui.MainModel, error) {
+	// The page goes first, selecting a tab moves the focus into that tab
+	if config.Page != "" {
+		var err error
+		if model, err = model.OpenPage(config.Page); err != nil {
+			return model, err
+		}
+	}
+	if config.Focus != "" {
+		focus, ok := ui.ParseFocusedComponent(config.Focus)
+		if !ok {
+			return model, fmt.Errorf("unknown panel %q (want one of %v)", config.Focus, appconfig.GridPanelNames)
+		}
+		model = model.SetFocusedComponent(focus)
+	}
+	return model.SetZoomed(config.Zoom), nil
+}
+
+// applyBackground selects the color variants for the terminal background:
+// the configured one, or the detected one. Detection queries the terminal, so
+// it has to run before the program takes over its input.
+func applyBackground(settings appconfig.Config) {
+	switch settings.Background {
+	case "dark":
+		lipgloss.SetHasDarkBackground(true)
+	case "light":
+		lipgloss.SetHasDarkBackground(false)
+	default:
+		lipgloss.HasDarkBackground()
+	}
+}
+
+// applyProfile selects the -profile of the config file, validated by main
+// before the UI starts; an explicit -interval wins over the profile's
+func applyProfile(model ui.MainModel, config *Config) ui.MainModel {
+	if config.Profile == "" {
+		return model
+	}
+	model, _ = model.SetProfile(config.Profile)
+	if config.IntervalSet {
+		model = model.SetUpdateInterval(config.UpdateInterval)
+	}
+	return model
+}
+
+// applyTail follows the -tail log file in the log panel
+func applyTail(model ui.MainModel, config *Config) ui.MainModel {
+	if config.TailFile == "" {
+		return model
+	}
+	return model.SetLogFile(config.TailFile)
+}
+
+// applyDemo replaces every collector with the synthetic machine of -demo,
+// so nothing is read from the host
+func applyDemo(model ui.MainModel, config *Config) ui.MainModel {
+	if !config.Demo {
+		return model
+	}
+	demo := fake.NewDemo()
+	return model.SetCollector(demo).SetProcessCollector(demo).SetContainerCollector(nil)
+}
+
+// applyHistory restores the graph history saved in the -history-file and
+// keeps saving it there
+func applyHistory(model ui.MainModel, config *Config) ui.MainModel {
+	if config.HistoryFile == "" {
+		return model
+	}
+	record, err := services.LoadHistory(config.HistoryFile)
+	if err != nil {
+		log.Printf("Ignoring saved history: %v", err)
+	}
+	return model.SetHistoryFile(config.HistoryFile).RestoreHistory(record)
+}
+
+// saveHistory writes the graph history of the final model to the -history-file
+func saveHistory(final tea.Model, config *Config) {
+	model, ok := final.(ui.MainModel)
+	if config.HistoryFile == "" || !ok {
+		return
+	}
+	if err := services.SaveHistory(config.HistoryFile, model.History()); err != nil {
+		log.Printf("Saving history: %v", err)
+	}
+}
+
+// applyBarMode applies the -braille and -ascii flags on top of the config file
+func applyBarMode(model ui.MainModel, config *Config) ui.MainModel {
+	switch {
+	case config.ASCII:
+		return model.SetBarMode(ui.BarASCII)
+	case config.Braille:
+		return model.SetBarMode(ui.BarBraille)
+	default:
+		return model
+	}
+}
+
+// applyRateUnit applies the -bits flag on top of the config file
+func applyRateUnit(model ui.MainModel, config *Config) ui.MainModel {
+	if config.Bits {
+		return model.SetRateUnit(models.BitsPerSecond)
+	}
+	return model
+}
+
+// applyUnits applies -units, validated by main before the UI starts
+func applyUnits(model ui.MainModel, config *Config) ui.MainModel {
+	units, _ := models.ParseSizeUnits(config.Units)
+	return model.SetUnits(units)
+}
+
+// applyLocale applies -locale on top of the config file and the environment,
+// validated by main before the UI starts
+func applyLocale(model ui.MainModel, config *Config) ui.MainModel {
+	if config.Locale == "" {
+		return model
+	}
+	locale, _ := models.ParseLocale(config.Locale)
+	return model.SetLocale(locale)
+}
+
+// maxFPS is the highest redraw rate Bubble Tea supports
+const maxFPS = 120
+
+// checkFPS validates -fps; 0 keeps Bubble Tea's default of 60
+func checkFPS(fps int) error {
+	if fps < 0 || fps > maxFPS {
+		return fmt.Errorf("-fps must be between 1 and %d, got %d", maxFPS, fps)
+	}
+	return nil
+}
+
+// Frame size of -snapshot when standard output is not a terminal
+const (
+	snapshotWidth  = 120
+	snapshotHeight = 40
+)
+
+// writeSnapshot collects metrics once and writes the rendered frame of the
+// startup view to config.Snapshot, at the size of the terminal
+func writeSnapshot(config *Config, stdout *os.File) error {
+	width, height := snapshotWidth, snapshotHeight
+	if term.IsTerminal(stdout.Fd()) {
+		if w, h, err := term.GetSize(stdout.Fd()); err == nil {
+			width, height = w, h
+		}
+	}
+	if config.SnapshotANSI && lipgloss.ColorProfile() == termenv.Ascii {
+		// Files and pipes get no colors by default
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	}
+
+	model := ui.NewMainModelWithConfig(config.UpdateInterval).ApplyConfig(config.Settings).SetScripts(config.Scripts).SetAccessible(config.Accessible)
+	model = applyDemo(applyLocale(applyUnits(applyRateUnit(applyBarMode(applyTail(applyProfile(model, config), config), config), config), config), config), config)
+	model, err := startView(model, config)
+	if err != nil {
+		return err
+	}
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	frame := updated.(ui.MainModel).CollectOnce().View()
+
+	if config.Snapshot == "-" {
+		if !config.SnapshotANSI {
+			frame = ansi.Strip(frame)
+		}
+		_, err := fmt.Fprintln(stdout, frame)
+		return err
+	}
+	return ui.WriteScreenshot(config.Snapshot, frame, config.SnapshotANSI)
+}
+
+// createProgram creates and configures the Bubble Tea program. The returned
+// function closes the export sinks and has to run on exit, so they can spool
+// or flush what was not delivered yet.
+func createProgram(config *Config) (*tea.Program, func()) {
+	var closers []func()
 	// Create the main model with configuration
 	model := ui.NewMainModelWithConfig(config.UpdateInterval)
+	model = model.ApplyConfig(config.Settings).SetScripts(config.Scripts).SetConfigPath(settingsPath(config.ConfigPath))
+	model = model.SetBootStatePath(appconfig.DefaultBootStatePath()).SetDiskFullHorizon(config.DiskFullWarning)
+	model = model.SetColorSupport(ui.DetectColorSupport(os.Getenv)).SetAccessible(config.Accessible)
+	if !config.Demo {
+		if err := model.RegisterCollector(services.NewSelfCollector()); err != nil {
+			log.Printf("Self-monitoring disabled: %v", err)
+		}
+		if power := services.NewPowerCollector(); power != nil {
+			if err := model.RegisterCollector(power); err != nil {
+				log.Printf("Power metrics disabled: %v", err)
+			}
+		}
+		if files := services.NewFilesCollector(); files != nil {
+			if err := model.RegisterCollector(files); err != nil {
+				log.Printf("Open file metrics disabled: %v", err)
+			}
+		}
+		if kernel := services.NewKernelCollector(); kernel != nil {
+			if err := model.RegisterCollector(kernel); err != nil {
+				log.Printf("Kernel metrics disabled: %v", err)
+			}
+		}
+		if states := services.NewProcessStatesCollector(); states != nil {
+			if err := model.RegisterCollector(states); err != nil {
+				log.Printf("Process state metrics disabled: %v", err)
+			}
+		}
+		if diskIO := services.NewDiskIOCollector(); diskIO != nil {
+			if err := model.RegisterCollector(diskIO); err != nil {
+				log.Printf("Disk I/O metrics disabled: %v", err)
+			}
+		}
+		if wireless := services.NewWirelessCollector(); wireless != nil {
+			if err := model.RegisterCollector(wireless); err != nil {
+				log.Printf("Wireless metrics disabled: %v", err)
+			}
+		}
+		if config.Pools {
+			if pools := services.NewPoolsCollector(); pools != nil {
+				if err := model.RegisterCollector(pools); err != nil {
+					log.Printf("Pool health disabled: %v", err)
+				}
+			} else {
+				log.Printf("Pool health disabled: neither zpool nor btrfs is installed")
+			}
+		}
+	}
+	model = applyDemo(applyLocale(applyUnits(applyRateUnit(applyBarMode(applyTail(applyProfile(model, config), config), config), config), config), config), config)
+	model = applyHistory(model, config).SetOnboarding(config.FirstRun)
+	if started, err := startView(model, config); err != nil {
+		log.Printf("Ignoring startup view: %v", err)
+	} else {
+		model = started
+	}
+	if config.Notify {
+		model = model.AddNotifier(services.NewDesktopNotifier())
+	}
+	if config.WebhookURL != "" {
+		queue := services.NewQueuedNotifier(services.NewWebhookNotifier(config.WebhookURL), queueConfig(config))
+		model = model.AddNotifier(queue)
+		closers = append(closers, queue.Close)
+	}
+	if config.LogAlerts {
+		model = model.AddNotifier(services.NewLogNotifier(log.Default()))
+	}
+	if dir, after, ok := config.Settings.AlertRecording(); ok {
+		recorder := services.NewAlertRecorder(dir, after)
+		recorder.Start()
+		model = model.AddNotifier(recorder).AddExporter(recorder)
+		closers = append(closers, recorder.Stop)
+	}
+	if config.OTLP {
+		if otlpConfig, err := services.OTLPConfigFromEnv(os.Getenv); err != nil {
+			log.Printf("OTLP export disabled: %v", err)
+		} else {
+			exporter := services.NewOTLPExporter(otlpConfig)
+			exporter.Start()
+			model = model.AddExporter(exporter)
+			closers = append(closers, exporter.Stop)
+		}
+	}
 	
-	// Configure program options based on config
-	var options []tea.ProgramOption
+	// Configure program options based on config. main handles SIGINT and
+	// SIGTERM itself, so the history is saved before the program quits
+	options := []tea.ProgramOption{tea.WithoutSignalHandler()}
 	
 	if !config.NoAltScreen {
 		options = append(options, tea.WithAltScreen())
@@ -101,10 +507,21 @@ func createProgram(config *Config) *tea.Program {
 		options = append(options, tea.WithMouseCellMotion())
 	}
 	
+	// Frames are written at most fps times a second whatever the interval;
+	// updates in between only replace the pending frame
+	if config.FPS > 0 {
+		options = append(options, tea.WithFPS(config.FPS))
+	}
+	
 	// Add input handling for better responsiveness
 	options = append(options, tea.WithInput(os.Stdin))
 	
-	return tea.NewProgram(model, options...)
+	closeSinks := func() {
+		for _, close := range closers {
+			close()
+		}
+	}
+	return tea.NewProgram(model, options...), closeSinks
 }
 
 // gracefulShutdown handles cleanup operations
@@ -121,6 +538,15 @@ func gracefulShutdown(logFile *os.File, program *tea.Program) {
 }
 
 func main() {
+	// Subcommands come before the monitor's own flags
+	if len(os.Args) > 1 && os.Args[1] == bugReportCommand {
+		if err := runBugReport(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	
 	// Parse command-line arguments
 	config := parseFlags()
 	
@@ -136,6 +562,88 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error setting up logging: %v\n", err)
 		os.Exit(1)
 	}
+	if warning := loggingWarning(config); warning != "" {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+	
+	// Load the config file; only an explicitly given file has to exist
+	settings, err := loadSettings(config.ConfigPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	config.Settings = settings
+	config.FirstRun = isFirstRun(config.ConfigPath)
+
+	// Panel scripts are parsed up front so syntax errors are reported before the UI starts
+	scripts, err := services.LoadScripts(appconfig.ScriptsDir(settingsPath(config.ConfigPath)))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading scripts: %v\n", err)
+		os.Exit(1)
+	}
+	config.Scripts = scripts
+	
+	// A missing log file is most likely a typo; rotation is handled once running
+	if config.TailFile != "" {
+		if _, err := os.Stat(config.TailFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	
+	if _, err := config.Settings.WithProfile(config.Profile); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -profile: %v\n", err)
+		os.Exit(1)
+	}
+	
+	// Validate the startup view up front so typos are reported instead of ignored
+	if _, err := startView(applyTail(applyProfile(ui.NewMainModel().ApplyConfig(config.Settings), config), config), config); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	
+	if err := checkFPS(config.FPS); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	
+	if _, err := models.ParseSizeUnits(config.Units); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -units: %v\n", err)
+		os.Exit(1)
+	}
+	
+	if _, err := models.ParseLocale(config.Locale); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -locale: %v\n", err)
+		os.Exit(1)
+	}
+	
+	// Validate OTLP settings up front, the TUI hides errors logged later
+	if config.OTLP {
+		if _, err := services.OTLPConfigFromEnv(os.Getenv); err != nil {
+			fmt.Fprintf(os.Stderr, "Error configuring OTLP export: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	
+	// A snapshot renders a single frame instead of starting the UI
+	if config.Snapshot != "" {
+		applyBackground(config.Settings)
+		if err := writeSnapshot(config, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	
+	// Start the profiler before the UI so a port in use is reported
+	if config.Pprof != "" {
+		_, stopPprof, err := startPprof(config.Pprof)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer stopPprof()
+	}
 	
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -147,7 +655,10 @@ func main() {
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	
 	// Create the Bubble Tea program
-	program := createProgram(config)
+	applyBackground(config.Settings)
+	program, closeSinks := createProgram(config)
+	stopControlSignals := watchControlSignals(program)
+	defer stopControlSignals()
 	
 	// Channel to receive program result
 	resultChan := make(chan error, 1)
@@ -158,7 +669,10 @@ func main() {
 			log.Printf("Starting %s with update interval: %v", AppName, config.UpdateInterval)
 		}
 		
-		_, err := program.Run()
+		final, err := program.Run()
+		if err == nil {
+			saveHistory(final, config)
+		}
 		resultChan <- err
 	}()
 	
@@ -168,6 +682,7 @@ func main() {
 		// Program completed normally
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
+			closeSinks()
 			gracefulShutdown(logFile, program)
 			os.Exit(1)
 		}
@@ -185,15 +700,11 @@ func main() {
 		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer shutdownCancel()
 		
-		// Wait for graceful shutdown or timeout
-		done := make(chan struct{})
-		go func() {
-			gracefulShutdown(logFile, program)
-			close(done)
-		}()
-		
+		// Quit the program and wait for it to save the history of its final
+		// model, the sinks are closed once below
+		program.Quit()
 		select {
-		case <-done:
+		case <-resultChan:
 			if config.Debug {
 				log.Println("Graceful shutdown completed")
 			}
@@ -203,6 +714,7 @@ func main() {
 	}
 	
 	// Final cleanup
+	closeSinks()
 	gracefulShutdown(logFile, program)
 	
 	if config.Debug {
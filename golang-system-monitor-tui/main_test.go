@@ -2,10 +2,20 @@ package main
 
 import (
 	"context"
+	"io"
+	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	appconfig "golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+	"golang-system-monitor-tui/ui"
 )
 
 func TestParseFlags(t *testing.T) {
@@ -119,6 +129,30 @@ func TestSetupLogging(t *testing.T) {
 	}
 }
 
+func TestSetupLogging_DiscardsWithoutLogFile(t *testing.T) {
+	defer log.SetOutput(os.Stderr)
+	for _, config := range []Config{{}, {Debug: true}} {
+		log.SetOutput(os.Stderr)
+		if _, err := setupLogging(&config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if log.Writer() != io.Discard {
+			t.Errorf("Expected logs discarded without -log (debug %v), got %v", config.Debug, log.Writer())
+		}
+	}
+}
+
+func TestLoggingWarning(t *testing.T) {
+	if warning := loggingWarning(&Config{Debug: true}); !strings.Contains(warning, "-log") {
+		t.Errorf("Expected a warning for -debug without -log, got %q", warning)
+	}
+	for _, config := range []Config{{}, {Debug: true, LogFile: "debug.log"}, {LogFile: "monitor.log"}} {
+		if warning := loggingWarning(&config); warning != "" {
+			t.Errorf("Expected no warning for %+v, got %q", config, warning)
+		}
+	}
+}
+
 func TestCreateProgram(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -144,7 +178,7 @@ func TestCreateProgram(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			program := createProgram(&tt.config)
+			program, _ := createProgram(&tt.config)
 			if program == nil {
 				t.Error("Expected program but got nil")
 			}
@@ -152,6 +186,152 @@ func TestCreateProgram(t *testing.T) {
 	}
 }
 
+func TestQueueConfig(t *testing.T) {
+	config := queueConfig(&Config{QueueSize: 50, QueuePolicy: "drop-newest", SpoolPath: "/tmp/spool", SpoolLimit: 20})
+	if config.Capacity != 50 || config.Policy != services.DropNewest || config.SpoolPath != "/tmp/spool" || config.SpoolLimit != 20 {
+		t.Errorf("Unexpected queue config: %+v", config)
+	}
+
+	config = queueConfig(&Config{QueueSize: 50, QueuePolicy: "bogus"})
+	if config.Policy != services.DropOldest {
+		t.Errorf("Expected fallback to drop-oldest, got %v", config.Policy)
+	}
+}
+
+func TestStartView(t *testing.T) {
+	model, err := startView(ui.NewMainModel(), &Config{Focus: "network", Zoom: true})
+	if err != nil {
+		t.Fatalf("startView failed: %v", err)
+	}
+	if model.GetFocusedComponent() != ui.FocusNetwork || !model.IsZoomed() {
+		t.Error("Expected the network panel focused and zoomed")
+	}
+
+	model, err = startView(ui.NewMainModel(), &Config{Page: "alerts"})
+	if err != nil || !strings.Contains(model.View(), "Alerts (") {
+		t.Errorf("Expected to start on the alerts page, got %v", err)
+	}
+
+	model, err = startView(ui.NewMainModel(), &Config{Page: "storage", Zoom: true})
+	if err != nil || model.GetActiveTab() != 1 || model.GetFocusedComponent() != ui.FocusDisk {
+		t.Errorf("Expected the storage tab with the disk panel focused, got tab %d (%v)", model.GetActiveTab(), err)
+	}
+
+	if _, err := startView(ui.NewMainModel(), &Config{Focus: "gpu"}); err == nil {
+		t.Error("Expected error for an unknown panel")
+	}
+	if _, err := startView(ui.NewMainModel(), &Config{Page: "nonexistent"}); err == nil {
+		t.Error("Expected error for an unknown page")
+	}
+}
+
+func TestApplyBarMode(t *testing.T) {
+	model := applyBarMode(ui.NewMainModel(), &Config{})
+	if model.GetBarMode() != ui.BarBlocks {
+		t.Errorf("Expected block bars by default, got %v", model.GetBarMode())
+	}
+
+	model = applyBarMode(ui.NewMainModel(), &Config{Braille: true})
+	if model.GetBarMode() != ui.BarBraille {
+		t.Errorf("Expected braille bars, got %v", model.GetBarMode())
+	}
+
+	model = applyBarMode(ui.NewMainModel(), &Config{Braille: true, ASCII: true})
+	if model.GetBarMode() != ui.BarASCII {
+		t.Errorf("Expected -ascii to win over -braille, got %v", model.GetBarMode())
+	}
+}
+
+func TestApplyUnits(t *testing.T) {
+	if model := applyUnits(ui.NewMainModel(), &Config{Units: "iec"}); model.GetUnits() != models.IECUnits {
+		t.Errorf("Expected iec units by default, got %v", model.GetUnits())
+	}
+	if model := applyUnits(ui.NewMainModel(), &Config{Units: "si"}); model.GetUnits() != models.SIUnits {
+		t.Errorf("Expected -units si to select powers of 1000, got %v", model.GetUnits())
+	}
+}
+
+func TestApplyLocale(t *testing.T) {
+	model := ui.NewMainModel().SetLocale(models.DefaultLocale())
+	if got := applyLocale(model, &Config{}).GetLocale(); got.Decimal != "." {
+		t.Errorf("Expected the locale to be kept without -locale, got %+v", got)
+	}
+	if got := applyLocale(model, &Config{Locale: "de_DE"}).GetLocale(); got.Decimal != "," || got.Thousands != "." {
+		t.Errorf("Expected -locale de_DE to use a decimal comma, got %+v", got)
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	settings := appconfig.Default()
+	settings.Profiles = map[string]appconfig.Profile{"server": {Interval: "5s"}}
+	model := ui.NewMainModelWithConfig(time.Second).ApplyConfig(settings)
+
+	if got := applyProfile(model, &Config{}); got.GetProfile() != "" || got.GetUpdateInterval() != time.Second {
+		t.Errorf("Expected no profile without -profile, got %q at %v", got.GetProfile(), got.GetUpdateInterval())
+	}
+	if got := applyProfile(model, &Config{Profile: "server"}); got.GetProfile() != "server" || got.GetUpdateInterval() != 5*time.Second {
+		t.Errorf("Expected -profile server at its interval, got %q at %v", got.GetProfile(), got.GetUpdateInterval())
+	}
+	config := &Config{Profile: "server", UpdateInterval: 2 * time.Second, IntervalSet: true}
+	if got := applyProfile(model, config); got.GetUpdateInterval() != 2*time.Second {
+		t.Errorf("Expected an explicit -interval to win, got %v", got.GetUpdateInterval())
+	}
+}
+
+func TestApplyRateUnit(t *testing.T) {
+	if model := applyRateUnit(ui.NewMainModel(), &Config{}); model.GetRateUnit() != models.BytesPerSecond {
+		t.Errorf("Expected bytes per second by default, got %v", model.GetRateUnit())
+	}
+	if model := applyRateUnit(ui.NewMainModel(), &Config{Bits: true}); model.GetRateUnit() != models.BitsPerSecond {
+		t.Errorf("Expected -bits to select bits per second, got %v", model.GetRateUnit())
+	}
+}
+
+func TestApplyTail(t *testing.T) {
+	if model := applyTail(ui.NewMainModel(), &Config{}); len(model.GetTabNames()) != 4 {
+		t.Errorf("Expected the default tabs without -tail, got %v", model.GetTabNames())
+	}
+
+	model := applyTail(ui.NewMainModel(), &Config{TailFile: "/var/log/syslog"})
+	if model.GetLogModel().GetPath() != "/var/log/syslog" {
+		t.Errorf("Expected the log panel to follow the file, got %q", model.GetLogModel().GetPath())
+	}
+	model, err := startView(model, &Config{Page: "logs", Focus: "log"})
+	if err != nil || model.GetFocusedComponent() != ui.FocusLog {
+		t.Errorf("Expected the Logs tab with the log panel focused, got %v (%v)", model.GetFocusedComponent(), err)
+	}
+}
+
+func TestApplyHistory(t *testing.T) {
+	if model := applyHistory(ui.NewMainModel(), &Config{}); len(model.GetCPUModel().GetTotalHistory()) != 0 {
+		t.Errorf("Expected no history without -history-file, got %v", model.GetCPUModel().GetTotalHistory())
+	}
+
+	path := filepath.Join(t.TempDir(), "history.json")
+	config := &Config{HistoryFile: path}
+	model := applyHistory(ui.NewMainModel(), config)
+	updated, _ := model.Update(ui.CPUUpdateMsg{Usage: []float64{10, 30}, Total: 20, Cores: 2})
+	saveHistory(updated, config)
+
+	model = applyHistory(ui.NewMainModel(), config)
+	if got := model.GetCPUModel().GetTotalHistory(); len(got) != 1 || got[0] != 20 {
+		t.Errorf("Expected the history saved on exit to be restored, got %v", got)
+	}
+}
+
+func TestApplyBackground(t *testing.T) {
+	defer lipgloss.SetHasDarkBackground(true)
+
+	applyBackground(appconfig.Config{Background: "light"})
+	if lipgloss.HasDarkBackground() {
+		t.Error("Expected a light background from the config file")
+	}
+	applyBackground(appconfig.Config{Background: "dark"})
+	if !lipgloss.HasDarkBackground() {
+		t.Error("Expected a dark background from the config file")
+	}
+}
+
 func TestGracefulShutdown(t *testing.T) {
 	t.Run("with log file", func(t *testing.T) {
 		// Create a temporary log file
@@ -245,7 +425,7 @@ func TestApplicationLifecycle(t *testing.T) {
 		}()
 
 		// Create program
-		program := createProgram(config)
+		program, _ := createProgram(config)
 		if program == nil {
 			t.Fatal("Failed to create program")
 		}
@@ -272,7 +452,7 @@ func TestApplicationLifecycle(t *testing.T) {
 
 		for i, config := range configs {
 			t.Run(string(rune('A'+i)), func(t *testing.T) {
-				program := createProgram(&config)
+				program, _ := createProgram(&config)
 				if program == nil {
 					t.Error("Failed to create program with valid config")
 				}
@@ -292,7 +472,7 @@ func BenchmarkCreateProgram(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		program := createProgram(config)
+		program, _ := createProgram(config)
 		program.Kill()
 	}
 }
@@ -324,4 +504,116 @@ func BenchmarkSetupLogging(b *testing.B) {
 			logFile.Close()
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestIsFirstRun(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	if !isFirstRun("") {
+		t.Error("Expected a first run without a config file")
+	}
+	if isFirstRun(filepath.Join(dir, "other.json")) {
+		t.Error("Expected no first run with -config")
+	}
+	if err := appconfig.Save(appconfig.DefaultPath(), appconfig.Default()); err != nil {
+		t.Fatal(err)
+	}
+	if isFirstRun("") {
+		t.Error("Expected no first run once the config file exists")
+	}
+}
+
+func TestLoadSettings(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	// No config file at the default location is fine
+	if _, err := loadSettings(""); err != nil {
+		t.Errorf("Expected defaults without a config file, got %v", err)
+	}
+
+	// An explicitly given file must exist
+	if _, err := loadSettings("/nonexistent/config.json"); err == nil {
+		t.Error("Expected error for a missing explicit config file")
+	}
+
+	path := t.TempDir() + "/config.json"
+	os.WriteFile(path, []byte(`{"panels": {"cpu": {"focused_border": "#ff00ff"}}}`), 0644)
+	settings, err := loadSettings(path)
+	if err != nil {
+		t.Fatalf("loadSettings failed: %v", err)
+	}
+	if settings.PanelStyle("cpu").FocusedBorder != "#ff00ff" {
+		t.Errorf("Expected cpu override, got %+v", settings.PanelStyle("cpu"))
+	}
+}
+
+func TestWriteSnapshot(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Collects live metrics")
+	}
+	path := t.TempDir() + "/frame.txt"
+	config := &Config{UpdateInterval: time.Second, Snapshot: path, Settings: appconfig.Default()}
+	if err := writeSnapshot(config, os.Stdout); err != nil {
+		t.Fatalf("writeSnapshot failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the snapshot file: %v", err)
+	}
+	if !strings.Contains(string(data), "CPU Usage") || strings.Contains(string(data), "\x1b[") {
+		t.Errorf("Expected a plain-text frame of the grid, got:\n%s", data)
+	}
+}
+
+func TestWriteSnapshot_Demo(t *testing.T) {
+	path := t.TempDir() + "/frame.txt"
+	config := &Config{UpdateInterval: time.Second, Snapshot: path, Demo: true, Settings: appconfig.Default()}
+	if err := writeSnapshot(config, os.Stdout); err != nil {
+		t.Fatalf("writeSnapshot failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the snapshot file: %v", err)
+	}
+	for _, want := range []string{"demo-workstation", "/home", "wlan0"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected the demo machine's %q in the frame, got:\n%s", want, data)
+		}
+	}
+}
+
+func TestWriteSnapshot_Accessible(t *testing.T) {
+	path := t.TempDir() + "/frame.txt"
+	config := &Config{UpdateInterval: time.Second, Snapshot: path, Demo: true, Accessible: true, Settings: appconfig.Default()}
+	if err := writeSnapshot(config, os.Stdout); err != nil {
+		t.Fatalf("writeSnapshot failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the snapshot file: %v", err)
+	}
+	frame := string(data)
+	if !strings.Contains(frame, "CPU total") || !strings.Contains(frame, "Memory") {
+		t.Errorf("Expected panel summaries in the frame, got:\n%s", frame)
+	}
+	if strings.Contains(frame, "╭") || strings.Contains(frame, "█") {
+		t.Errorf("Expected no borders or bars with -accessible, got:\n%s", frame)
+	}
+}
+
+func TestCheckFPS(t *testing.T) {
+	for _, fps := range []int{0, 1, 15, maxFPS} {
+		if err := checkFPS(fps); err != nil {
+			t.Errorf("checkFPS(%d) = %v, want nil", fps, err)
+		}
+	}
+	for _, fps := range []int{-1, maxFPS + 1} {
+		if err := checkFPS(fps); err == nil {
+			t.Errorf("Expected an error for -fps %d", fps)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"strings"
+)
+
+// UnitFamily returns name without its unit suffix, so the devices and
+// interfaces one driver numbers share a family: eth0 and eth1 are eth,
+// vethd3a4f2c and veth91b0e7a are veth, and /dev/loop3 is /dev/loop. The
+// suffix is the trailing run of hex digits when it contains a digit, since
+// container interfaces are named by hashes; names without a digit at the end
+// are their own family.
+func UnitFamily(name string) string {
+	end := len(name)
+	for end > 0 && strings.IndexByte("0123456789abcdef", name[end-1]) >= 0 {
+		end--
+	}
+	if strings.IndexAny(name[end:], "0123456789") < 0 {
+		return name
+	}
+	return name[:end]
+}
+
+// Collapse splits items into rows, in their order. The items of a family with
+// at least min members share the row of the first of them; the others get a
+// row each. family returns the family of an item, "" for one that is never
+// collapsed, and min below 2 leaves every item on its own row.
+func Collapse[T any](items []T, min int, family func(T) string) [][]T {
+	rows := make([][]T, 0, len(items))
+	if min < 2 {
+		for _, item := range items {
+			rows = append(rows, []T{item})
+		}
+		return rows
+	}
+
+	counts := make(map[string]int)
+	for _, item := range items {
+		if name := family(item); name != "" {
+			counts[name]++
+		}
+	}
+	index := make(map[string]int)
+	for _, item := range items {
+		name := family(item)
+		if name == "" || counts[name] < min {
+			rows = append(rows, []T{item})
+			continue
+		}
+		if i, ok := index[name]; ok {
+			rows[i] = append(rows[i], item)
+			continue
+		}
+		index[name] = len(rows)
+		rows = append(rows, []T{item})
+	}
+	return rows
+}
+
+// CommonPrefix returns the longest prefix all names start with
+func CommonPrefix(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	prefix := names[0]
+	for _, name := range names[1:] {
+		for !strings.HasPrefix(name, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// CombineFilesystems returns the combined usage of filesystems, counting each
+// device once since bind mounts of one filesystem report the same usage
+func CombineFilesystems(filesystems []DiskInfo) DiskInfo {
+	var combined DiskInfo
+	seen := make(map[string]bool, len(filesystems))
+	for _, fs := range filesystems {
+		if fs.Device != "" && seen[fs.Device] {
+			continue
+		}
+		seen[fs.Device] = true
+		combined.Total += fs.Total
+		combined.Used += fs.Used
+		combined.Available += fs.Available
+	}
+	if combined.Total > 0 {
+		combined.UsedPercent = float64(combined.Used) / float64(combined.Total) * 100
+	}
+	return combined
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnitFamily(t *testing.T) {
+	for name, want := range map[string]string{
+		"eth0":        "eth",
+		"vethd3a4f2c": "veth",
+		"br-1a2b3c4d": "br-",
+		"/dev/loop12": "/dev/loop",
+		"enp3s0f1":    "enp3s",
+		"Wi-Fi 2":     "Wi-Fi ",
+		"Ethernet":    "Ethernet",
+		"/dev/sda":    "/dev/sda",
+		"":            "",
+	} {
+		if got := UnitFamily(name); got != want {
+			t.Errorf("UnitFamily(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestCollapse(t *testing.T) {
+	names := []string{"eth0", "veth1a", "wlan0", "veth2b", "veth3c", "eth1", "lo"}
+	family := func(name string) string {
+		if name == "lo" {
+			return ""
+		}
+		return UnitFamily(name)
+	}
+
+	got := Collapse(names, 3, family)
+	want := [][]string{{"eth0"}, {"veth1a", "veth2b", "veth3c"}, {"wlan0"}, {"eth1"}, {"lo"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Collapse(3) = %v, want %v", got, want)
+	}
+	if got := Collapse(names, 2, family); len(got) != 4 || !reflect.DeepEqual(got[0], []string{"eth0", "eth1"}) {
+		t.Errorf("Expected eth0 and eth1 on one row from 2 members, got %v", got)
+	}
+	if got := Collapse(names, 0, family); len(got) != len(names) {
+		t.Errorf("Expected a row per item when off, got %v", got)
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	if got := CommonPrefix([]string{"/snap/core/17", "/snap/core20/2", "/snap/firefox/4"}); got != "/snap/" {
+		t.Errorf("CommonPrefix() = %q, want /snap/", got)
+	}
+	if got := CommonPrefix([]string{"eth0", "wlan0"}); got != "" {
+		t.Errorf("CommonPrefix() = %q, want none", got)
+	}
+}
+
+func TestCombineFilesystems(t *testing.T) {
+	combined := CombineFilesystems([]DiskInfo{
+		{Device: "/dev/sda1", Mountpoint: "/srv", Total: 100, Used: 50, Available: 50},
+		{Device: "/dev/sda1", Mountpoint: "/var/www", Total: 100, Used: 50, Available: 50},
+		{Device: "/dev/sdb1", Mountpoint: "/data", Total: 300, Used: 50, Available: 250},
+	})
+	if combined.Total != 400 || combined.Used != 100 || combined.Available != 300 || combined.UsedPercent != 25 {
+		t.Errorf("Expected each device counted once, got %+v", combined)
+	}
+}
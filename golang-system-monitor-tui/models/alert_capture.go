@@ -0,0 +1,31 @@
+package models
+
+import "sort"
+
+// AlertCaptureSize is how many processes are captured when an alert fires
+const AlertCaptureSize = 10
+
+// CapturedProcess is a process as it was when an alert fired
+type CapturedProcess struct {
+	PID        int32   `json:"pid"`
+	Name       string  `json:"name"`
+	CPUPercent float64 `json:"cpu_percent"`
+	RSS        uint64  `json:"rss"` // Resident bytes, 0 when unknown
+}
+
+// TopCapturedProcesses returns the n busiest processes: by resident memory
+// for a Memory alert, by CPU usage otherwise
+func TopCapturedProcesses(processes []CapturedProcess, byMemory bool, n int) []CapturedProcess {
+	top := make([]CapturedProcess, len(processes))
+	copy(top, processes)
+	sort.SliceStable(top, func(i, j int) bool {
+		if byMemory {
+			return top[i].RSS > top[j].RSS
+		}
+		return top[i].CPUPercent > top[j].CPUPercent
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
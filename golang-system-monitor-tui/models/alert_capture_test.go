@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTopCapturedProcesses(t *testing.T) {
+	processes := []CapturedProcess{
+		{PID: 1, Name: "init", CPUPercent: 0.1, RSS: 10},
+		{PID: 2, Name: "postgres", CPUPercent: 40, RSS: 900},
+		{PID: 3, Name: "firefox", CPUPercent: 20, RSS: 2000},
+	}
+	if top := TopCapturedProcesses(processes, false, 2); len(top) != 2 || top[0].Name != "postgres" || top[1].Name != "firefox" {
+		t.Errorf("Expected the busiest by CPU, got %+v", top)
+	}
+	if top := TopCapturedProcesses(processes, true, 5); len(top) != 3 || top[0].Name != "firefox" {
+		t.Errorf("Expected the largest by memory, got %+v", top)
+	}
+	if processes[0].Name != "init" {
+		t.Error("Expected the processes passed in to be left unsorted")
+	}
+}
+
+func TestAlertHistory_AttachProcesses(t *testing.T) {
+	history := NewAlertHistory(2)
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	fired := AlertEvent{Rule: AlertRule{Component: "CPU", Threshold: 90}, Value: 95, Fired: true, Timestamp: at}
+	history.Add(fired)
+	history.Add(AlertEvent{Rule: fired.Rule, Timestamp: at.Add(time.Minute)})
+
+	processes := []CapturedProcess{{PID: 42, Name: "stress", CPUPercent: 99}}
+	if !history.AttachProcesses(fired, processes) {
+		t.Fatal("Expected the fired event to be found")
+	}
+	events := history.Events()
+	if len(events[1].TopProcesses) != 1 || events[1].TopProcesses[0].Name != "stress" || len(events[0].TopProcesses) != 0 {
+		t.Errorf("Expected the processes on the fired event only, got %+v", events)
+	}
+
+	// Events pushed out of the history cannot be attached to
+	history.Add(AlertEvent{Timestamp: at.Add(2 * time.Minute)})
+	if history.AttachProcesses(fired, processes) {
+		t.Error("Expected an event no longer kept to be reported")
+	}
+}
@@ -0,0 +1,290 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AlertRule defines a threshold on a component's usage percentage
+type AlertRule struct {
+	Component string  `json:"component"`         // Component the rule applies to (CPU, Memory, Disk, Files)
+	Threshold float64 `json:"threshold"`         // Fires when usage is at or above this percentage
+	Subject   string  `json:"subject,omitempty"` // Resource the rule is limited to, e.g. a mountpoint; empty for those without a rule of their own
+	Below     bool    `json:"below,omitempty"`   // Fires at or below the threshold instead, for measurements of what is left such as available memory
+}
+
+// AlertEvent represents an alert rule firing or clearing
+type AlertEvent struct {
+	Rule      AlertRule `json:"rule"`
+	Subject   string    `json:"subject"` // Specific resource, e.g. a mountpoint; empty for whole-component rules
+	Value     float64   `json:"value"`
+	Fired     bool      `json:"fired"`          // True when the alert fired, false when it cleared
+	Gone      bool      `json:"gone,omitempty"` // Cleared because the subject went away, e.g. an unmounted filesystem
+	Timestamp time.Time `json:"timestamp"`
+	// Busiest processes when a CPU or Memory alert fired, attached once captured
+	TopProcesses []CapturedProcess `json:"top_processes,omitempty"`
+}
+
+// Same reports whether two events are the same firing or clearing of an
+// alert, whatever was attached to them since
+func (e AlertEvent) Same(other AlertEvent) bool {
+	return e.Rule == other.Rule && e.Subject == other.Subject && e.Fired == other.Fired && e.Timestamp.Equal(other.Timestamp)
+}
+
+// Title returns a short headline for the event
+func (e AlertEvent) Title() string {
+	if e.Fired {
+		return fmt.Sprintf("%s alert", e.Rule.Component)
+	}
+	if e.Gone {
+		return fmt.Sprintf("%s alert cleared", e.Rule.Component)
+	}
+	return fmt.Sprintf("%s recovered", e.Rule.Component)
+}
+
+// Description returns a human-readable summary of the event
+func (e AlertEvent) Description() string {
+	return e.LocalizedDescription(DefaultLocale())
+}
+
+// LocalizedDescription returns the description with numbers formatted for locale
+func (e AlertEvent) LocalizedDescription(locale Locale) string {
+	target := e.Rule.Component
+	if e.Subject != "" {
+		target += " " + e.Subject
+	}
+	value, threshold := locale.FormatPercent(e.Value, 1), locale.FormatPercent(e.Rule.Threshold, 0)
+	if e.Gone {
+		return fmt.Sprintf("%s went away (threshold %s)", target, threshold)
+	}
+	if e.Rule.Below {
+		if e.Fired {
+			return fmt.Sprintf("%s down to %s available (threshold %s)", target, value, threshold)
+		}
+		return fmt.Sprintf("%s back up to %s available (threshold %s)", target, value, threshold)
+	}
+	if e.Fired {
+		return fmt.Sprintf("%s at %s (threshold %s)", target, value, threshold)
+	}
+	return fmt.Sprintf("%s back to %s (threshold %s)", target, value, threshold)
+}
+
+// AlertHysteresis is how many points below its threshold a measurement must
+// drop before a firing alert clears, so values hovering around the threshold
+// don't fire and clear on every update
+const AlertHysteresis = 5.0
+
+// DefaultAlertRules returns the built-in alert rules
+func DefaultAlertRules() []AlertRule {
+	return []AlertRule{
+		{Component: "Memory", Threshold: 95},
+		{Component: "Disk", Threshold: 95},
+		{Component: "Files", Threshold: 95},
+	}
+}
+
+// AlertManager evaluates alert rules and reports state transitions
+type AlertManager struct {
+	mu     sync.Mutex
+	rules  []AlertRule
+	active map[string]bool // Keyed by component and subject
+}
+
+// NewAlertManager creates a new alert manager for the given rules
+func NewAlertManager(rules []AlertRule) *AlertManager {
+	return &AlertManager{
+		rules:  rules,
+		active: make(map[string]bool),
+	}
+}
+
+// Evaluate checks a measurement against every matching rule and returns the
+// alerts that fired or cleared as a result. Alerts that stay active produce no
+// events, and an active alert only clears below its threshold minus
+// AlertHysteresis, or above it plus AlertHysteresis for rules firing below.
+func (a *AlertManager) Evaluate(component, subject string, value float64, now time.Time) []AlertEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// A rule for the subject replaces the component's rules for it
+	specific := false
+	for _, rule := range a.rules {
+		if rule.Component == component && rule.Subject != "" && rule.Subject == subject {
+			specific = true
+			break
+		}
+	}
+
+	var events []AlertEvent
+	for _, rule := range a.rules {
+		if rule.Component != component || (rule.Subject != "" && rule.Subject != subject) || (rule.Subject == "" && specific) {
+			continue
+		}
+
+		key := component + "|" + subject + "|" + rule.thresholdKey()
+		wasActive := a.active[key]
+		isActive := rule.reached(value, 0)
+		if wasActive {
+			isActive = rule.reached(value, AlertHysteresis)
+		}
+
+		if isActive == wasActive {
+			continue
+		}
+
+		if isActive {
+			a.active[key] = true
+		} else {
+			delete(a.active, key)
+		}
+
+		events = append(events, AlertEvent{
+			Rule:      rule,
+			Subject:   subject,
+			Value:     value,
+			Fired:     isActive,
+			Timestamp: now,
+		})
+	}
+	return events
+}
+
+// Forget clears the alerts of a subject that went away, such as an unmounted
+// filesystem, and returns a clearing event for each one that was firing.
+// Without it they would stay active, since the subject is never measured
+// again.
+func (a *AlertManager) Forget(component, subject string, now time.Time) []AlertEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var events []AlertEvent
+	for _, rule := range a.rules {
+		if rule.Component != component || (rule.Subject != "" && rule.Subject != subject) {
+			continue
+		}
+		key := component + "|" + subject + "|" + rule.thresholdKey()
+		if !a.active[key] {
+			continue
+		}
+		delete(a.active, key)
+		events = append(events, AlertEvent{
+			Rule:      rule,
+			Subject:   subject,
+			Gone:      true,
+			Timestamp: now,
+		})
+	}
+	return events
+}
+
+// ActiveCount returns the number of currently firing alerts
+func (a *AlertManager) ActiveCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.active)
+}
+
+// GetRules returns the configured alert rules
+func (a *AlertManager) GetRules() []AlertRule {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.rules
+}
+
+// SetRules replaces the alert rules. Alerts firing under a rule that is
+// still there keep firing; the others are dropped without clearing.
+func (a *AlertManager) SetRules(rules []AlertRule) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	kept := make(map[string]bool)
+	for _, rule := range rules {
+		prefix := rule.Component + "|"
+		suffix := "|" + rule.thresholdKey()
+		for key := range a.active {
+			subject, ok := strings.CutPrefix(key, prefix)
+			if !ok {
+				continue
+			}
+			if subject, ok = strings.CutSuffix(subject, suffix); ok && (rule.Subject == "" || rule.Subject == subject) {
+				kept[key] = true
+			}
+		}
+	}
+	a.rules = rules
+	a.active = kept
+}
+
+// thresholdKey identifies the threshold of a rule in the keys of active alerts
+func (r AlertRule) thresholdKey() string {
+	if r.Below {
+		return fmt.Sprintf("<%.2f", r.Threshold)
+	}
+	return fmt.Sprintf("%.2f", r.Threshold)
+}
+
+// reached reports whether value is at the threshold of a rule, or beyond it
+// in the direction the rule fires in, with margin points of slack
+func (r AlertRule) reached(value, margin float64) bool {
+	if r.Below {
+		return value <= r.Threshold+margin
+	}
+	return value >= r.Threshold-margin
+}
+
+// AlertHistory keeps the most recent alert events in a fixed-size ring
+type AlertHistory struct {
+	mu     sync.Mutex
+	events Ring[AlertEvent]
+}
+
+// NewAlertHistory creates an alert history holding up to capacity events
+func NewAlertHistory(capacity int) *AlertHistory {
+	if capacity <= 0 {
+		capacity = 100
+	}
+	return &AlertHistory{events: NewRing[AlertEvent](capacity)}
+}
+
+// Add records an event, overwriting the oldest one when the ring is full
+func (h *AlertHistory) Add(event AlertEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = h.events.Push(event)
+}
+
+// Events returns the recorded events, newest first
+func (h *AlertHistory) Events() []AlertEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := make([]AlertEvent, 0, h.events.Len())
+	for _, event := range h.events.Backward() {
+		result = append(result, event)
+	}
+	return result
+}
+
+// AttachProcesses attaches the processes captured when event fired to the
+// recorded event, reporting false when the event is no longer kept
+func (h *AlertHistory) AttachProcesses(event AlertEvent, processes []CapturedProcess) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, recorded := range h.events.Backward() {
+		if recorded.Same(event) {
+			recorded.TopProcesses = processes
+			h.events.Set(i, recorded)
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of recorded events
+func (h *AlertHistory) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.events.Len()
+}
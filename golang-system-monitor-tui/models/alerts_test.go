@@ -0,0 +1,231 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultAlertRules(t *testing.T) {
+	rules := DefaultAlertRules()
+	if len(rules) != 3 {
+		t.Fatalf("Expected 3 default rules, got %d", len(rules))
+	}
+	for _, rule := range rules {
+		if rule.Threshold != 95 {
+			t.Errorf("Expected default threshold 95, got %f for %s", rule.Threshold, rule.Component)
+		}
+	}
+}
+
+func TestAlertManager_Evaluate(t *testing.T) {
+	manager := NewAlertManager([]AlertRule{{Component: "Disk", Threshold: 95}})
+	now := time.Now()
+
+	if events := manager.Evaluate("Disk", "/", 50, now); len(events) != 0 {
+		t.Errorf("Expected no events below threshold, got %v", events)
+	}
+
+	events := manager.Evaluate("Disk", "/", 96.5, now)
+	if len(events) != 1 || !events[0].Fired {
+		t.Fatalf("Expected a fired event, got %v", events)
+	}
+	if events[0].Subject != "/" || events[0].Value != 96.5 {
+		t.Errorf("Unexpected event contents: %+v", events[0])
+	}
+	if manager.ActiveCount() != 1 {
+		t.Errorf("Expected 1 active alert, got %d", manager.ActiveCount())
+	}
+
+	// Staying above the threshold must not re-fire
+	if events := manager.Evaluate("Disk", "/", 97, now); len(events) != 0 {
+		t.Errorf("Expected no repeated events while active, got %v", events)
+	}
+
+	// A different subject is tracked independently
+	if events := manager.Evaluate("Disk", "/data", 99, now); len(events) != 1 {
+		t.Errorf("Expected independent alert for /data, got %v", events)
+	}
+
+	events = manager.Evaluate("Disk", "/", 80, now)
+	if len(events) != 1 || events[0].Fired {
+		t.Fatalf("Expected a cleared event, got %v", events)
+	}
+	if manager.ActiveCount() != 1 {
+		t.Errorf("Expected 1 active alert after clearing /, got %d", manager.ActiveCount())
+	}
+}
+
+func TestAlertManager_Flapping(t *testing.T) {
+	manager := NewAlertManager([]AlertRule{{Component: "Memory", Threshold: 95}})
+	now := time.Now()
+
+	// Usage hovering around the threshold fires once, not on every crossing
+	var fired, cleared int
+	for _, value := range []float64{94.8, 95.1, 94.9, 95.3, 93, 95.0, 91} {
+		for _, event := range manager.Evaluate("Memory", "", value, now) {
+			if event.Fired {
+				fired++
+			} else {
+				cleared++
+			}
+		}
+	}
+	if fired != 1 || cleared != 0 {
+		t.Errorf("Expected 1 fired and no cleared events while flapping, got %d fired and %d cleared", fired, cleared)
+	}
+
+	events := manager.Evaluate("Memory", "", 89.9, now)
+	if len(events) != 1 || events[0].Fired {
+		t.Fatalf("Expected the alert to clear below the hysteresis band, got %v", events)
+	}
+	if events := manager.Evaluate("Memory", "", 94, now); len(events) != 0 {
+		t.Errorf("Expected a cleared alert to fire only at the threshold again, got %v", events)
+	}
+}
+
+func TestAlertManager_IgnoresOtherComponents(t *testing.T) {
+	manager := NewAlertManager(DefaultAlertRules())
+	if events := manager.Evaluate("CPU", "", 100, time.Now()); len(events) != 0 {
+		t.Errorf("Expected no CPU events without a CPU rule, got %v", events)
+	}
+}
+
+func TestAlertManager_SubjectRules(t *testing.T) {
+	manager := NewAlertManager([]AlertRule{
+		{Component: "Disk", Threshold: 95},
+		{Component: "Disk", Subject: "/data", Threshold: 97},
+	})
+	now := time.Now()
+
+	// /data fires at its own threshold only
+	if events := manager.Evaluate("Disk", "/data", 96, now); len(events) != 0 {
+		t.Errorf("Expected /data below its own threshold to stay quiet, got %v", events)
+	}
+	if events := manager.Evaluate("Disk", "/data", 97, now); len(events) != 1 || events[0].Rule.Threshold != 97 {
+		t.Errorf("Expected /data to fire at 97%%, got %v", events)
+	}
+	// Other mountpoints keep the component rule
+	if events := manager.Evaluate("Disk", "/", 95, now); len(events) != 1 || events[0].Rule.Threshold != 95 {
+		t.Errorf("Expected / to fire at 95%%, got %v", events)
+	}
+}
+
+func TestAlertManager_BelowRules(t *testing.T) {
+	manager := NewAlertManager([]AlertRule{{Component: "Memory", Threshold: 10, Below: true}})
+	now := time.Now()
+
+	if events := manager.Evaluate("Memory", "", 12, now); len(events) != 0 {
+		t.Errorf("Expected no events above the threshold, got %v", events)
+	}
+	events := manager.Evaluate("Memory", "", 8, now)
+	if len(events) != 1 || !events[0].Fired {
+		t.Fatalf("Expected the alert to fire at 8%% available, got %v", events)
+	}
+	if got := events[0].Description(); got != "Memory down to 8.0% available (threshold 10%)" {
+		t.Errorf("Unexpected description %q", got)
+	}
+	// Clearing needs AlertHysteresis points of headroom
+	if events := manager.Evaluate("Memory", "", 14, now); len(events) != 0 {
+		t.Errorf("Expected the alert to keep firing within the hysteresis, got %v", events)
+	}
+	if events := manager.Evaluate("Memory", "", 16, now); len(events) != 1 || events[0].Fired {
+		t.Errorf("Expected the alert to clear at 16%% available, got %v", events)
+	}
+}
+
+func TestAlertManager_Forget(t *testing.T) {
+	manager := NewAlertManager(DefaultAlertRules())
+	now := time.Now()
+	manager.Evaluate("Disk", "/mnt/usb", 97, now)
+	manager.Evaluate("Disk", "/", 97, now)
+
+	events := manager.Forget("Disk", "/mnt/usb", now)
+	if len(events) != 1 || events[0].Fired || !events[0].Gone || events[0].Subject != "/mnt/usb" {
+		t.Fatalf("Expected a clearing event for /mnt/usb, got %v", events)
+	}
+	if got := events[0].Description(); got != "Disk /mnt/usb went away (threshold 95%)" {
+		t.Errorf("Unexpected description %q", got)
+	}
+	if count := manager.ActiveCount(); count != 1 {
+		t.Errorf("Expected only / to keep firing, got %d active", count)
+	}
+	if events := manager.Forget("Disk", "/mnt/usb", now); len(events) != 0 {
+		t.Errorf("Expected nothing to clear twice, got %v", events)
+	}
+}
+
+func TestAlertManager_SetRules(t *testing.T) {
+	manager := NewAlertManager([]AlertRule{{Component: "Disk", Threshold: 95}, {Component: "Memory", Threshold: 95}})
+	now := time.Now()
+	manager.Evaluate("Disk", "/", 96, now)
+	manager.Evaluate("Memory", "", 96, now)
+
+	manager.SetRules([]AlertRule{{Component: "Disk", Threshold: 95}, {Component: "Memory", Threshold: 98}})
+	if count := manager.ActiveCount(); count != 1 {
+		t.Errorf("Expected only the disk alert to keep firing, got %d", count)
+	}
+	if events := manager.Evaluate("Disk", "/", 96, now); len(events) != 0 {
+		t.Errorf("Expected the kept alert not to fire again, got %v", events)
+	}
+	if len(manager.GetRules()) != 2 || manager.GetRules()[1].Threshold != 98 {
+		t.Errorf("Expected the new rules, got %v", manager.GetRules())
+	}
+}
+
+func TestAlertEvent_Description(t *testing.T) {
+	fired := AlertEvent{Rule: AlertRule{Component: "Disk", Threshold: 95}, Subject: "/", Value: 96.25, Fired: true}
+	if fired.Title() != "Disk alert" {
+		t.Errorf("Unexpected title %q", fired.Title())
+	}
+	if !strings.Contains(fired.Description(), "Disk / at 96.2%") {
+		t.Errorf("Unexpected description %q", fired.Description())
+	}
+
+	cleared := AlertEvent{Rule: AlertRule{Component: "Memory", Threshold: 95}, Value: 60}
+	if cleared.Title() != "Memory recovered" {
+		t.Errorf("Unexpected title %q", cleared.Title())
+	}
+	if !strings.Contains(cleared.Description(), "back to 60.0%") {
+		t.Errorf("Unexpected description %q", cleared.Description())
+	}
+
+	german, _ := ParseLocale("de_DE")
+	if got := fired.LocalizedDescription(german); got != "Disk / at 96,2% (threshold 95%)" {
+		t.Errorf("Unexpected localized description %q", got)
+	}
+}
+
+func TestAlertHistory(t *testing.T) {
+	history := NewAlertHistory(3)
+	if history.Len() != 0 || len(history.Events()) != 0 {
+		t.Fatal("Expected empty history")
+	}
+
+	for i := 1; i <= 5; i++ {
+		history.Add(AlertEvent{Value: float64(i)})
+	}
+
+	if history.Len() != 3 {
+		t.Fatalf("Expected history capped at 3, got %d", history.Len())
+	}
+
+	events := history.Events()
+	want := []float64{5, 4, 3}
+	for i, event := range events {
+		if event.Value != want[i] {
+			t.Errorf("events[%d].Value = %f, want %f", i, event.Value, want[i])
+		}
+	}
+}
+
+func TestAlertHistory_PartiallyFilled(t *testing.T) {
+	history := NewAlertHistory(0)
+	history.Add(AlertEvent{Value: 1})
+	history.Add(AlertEvent{Value: 2})
+
+	events := history.Events()
+	if len(events) != 2 || events[0].Value != 2 || events[1].Value != 1 {
+		t.Errorf("Expected newest-first order, got %v", events)
+	}
+}
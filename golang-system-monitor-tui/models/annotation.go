@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// MaxAnnotations is how many annotations are kept, the oldest are dropped
+// beyond it
+const MaxAnnotations = 100
+
+// Annotation is a named mark on the timeline, such as "deploy" or "backup
+// started", shown on the history graphs at the time it was made
+type Annotation struct {
+	Name      string    `json:"name"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// AddAnnotation returns annotations with one more at the end, keeping the
+// newest MaxAnnotations. The slice passed in is not modified.
+func AddAnnotation(annotations []Annotation, annotation Annotation) []Annotation {
+	if len(annotations) >= MaxAnnotations {
+		annotations = annotations[len(annotations)-MaxAnnotations+1:]
+	}
+	added := make([]Annotation, 0, len(annotations)+1)
+	added = append(added, annotations...)
+	return append(added, annotation)
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestAddAnnotation(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var annotations []Annotation
+	for i := 0; i < MaxAnnotations+5; i++ {
+		annotations = AddAnnotation(annotations, Annotation{Name: fmt.Sprintf("mark %d", i), Timestamp: start.Add(time.Duration(i) * time.Minute)})
+	}
+	if len(annotations) != MaxAnnotations || annotations[0].Name != "mark 5" || annotations[MaxAnnotations-1].Name != fmt.Sprintf("mark %d", MaxAnnotations+4) {
+		t.Errorf("Expected the newest %d annotations, got %d from %q", MaxAnnotations, len(annotations), annotations[0].Name)
+	}
+
+	// Earlier slices are left as they were
+	before := annotations[:2]
+	after := AddAnnotation(before, Annotation{Name: "deploy"})
+	if annotations[2].Name != "mark 7" || len(after) != 3 {
+		t.Errorf("Expected the slice passed in to be left alone, got %q", annotations[2].Name)
+	}
+}
@@ -0,0 +1,61 @@
+package models
+
+import "math"
+
+// DefaultAnomalySigma is how many standard deviations from its baseline a
+// metric has to be to count as unusual
+const DefaultAnomalySigma = 3.0
+
+const (
+	// BaselineWarmup is the number of samples a baseline needs before it
+	// reports deviations
+	BaselineWarmup = 30
+	// BaselineWindow is the number of samples a baseline roughly remembers;
+	// older samples fade out, so the baseline follows slow changes
+	BaselineWindow = 300
+)
+
+// Baseline learns the usual level of a metric as a rolling mean and
+// variance: exact over the first BaselineWindow samples, exponentially
+// weighted after. It is a plain value: Add returns the updated baseline.
+type Baseline struct {
+	Count    int     `json:"count"`
+	Mean     float64 `json:"mean"`
+	Variance float64 `json:"variance"`
+}
+
+// Add includes a value in the baseline
+func (b Baseline) Add(value float64) Baseline {
+	b.Count++
+	alpha := 1 / float64(min(b.Count, BaselineWindow))
+	diff := value - b.Mean
+	b.Mean += alpha * diff
+	b.Variance = (1 - alpha) * (b.Variance + alpha*diff*diff)
+	return b
+}
+
+// StdDev returns the standard deviation of the baseline
+func (b Baseline) StdDev() float64 {
+	return math.Sqrt(b.Variance)
+}
+
+// Deviation returns how many standard deviations value is above (positive)
+// or below (negative) the mean, 0 while the baseline warms up. The standard
+// deviation is taken to be at least floor, so a metric that barely moved
+// doesn't make every small change unusual.
+func (b Baseline) Deviation(value, floor float64) float64 {
+	if b.Count < BaselineWarmup {
+		return 0
+	}
+	sigma := math.Max(b.StdDev(), floor)
+	if sigma == 0 {
+		return 0
+	}
+	return (value - b.Mean) / sigma
+}
+
+// Unusual reports whether a deviation, in standard deviations, exceeds sigma;
+// a sigma of 0 turns the detection off
+func Unusual(deviation, sigma float64) bool {
+	return sigma > 0 && math.Abs(deviation) >= sigma
+}
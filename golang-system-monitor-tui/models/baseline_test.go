@@ -0,0 +1,53 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBaseline_Add(t *testing.T) {
+	var b Baseline
+	for _, value := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		b = b.Add(value)
+	}
+	// Exact population statistics before the window fills
+	if b.Count != 8 || b.Mean != 5 || math.Abs(b.StdDev()-2) > 1e-9 {
+		t.Errorf("Expected mean 5 and deviation 2, got %+v (σ %v)", b, b.StdDev())
+	}
+
+	// Past the window old samples fade out
+	for i := 0; i < 5*BaselineWindow; i++ {
+		b = b.Add(50)
+	}
+	if math.Abs(b.Mean-50) > 0.1 {
+		t.Errorf("Expected the baseline to follow the new level, got mean %v", b.Mean)
+	}
+}
+
+func TestBaseline_Deviation(t *testing.T) {
+	var b Baseline
+	for i := 0; i < BaselineWarmup-1; i++ {
+		b = b.Add(float64(10 + i%2*2)) // 10, 12, 10, ...: mean 11, σ 1
+	}
+	if d := b.Deviation(100, 0); d != 0 {
+		t.Errorf("Expected no deviation while warming up, got %v", d)
+	}
+
+	b = b.Add(12)
+	if d := b.Deviation(15, 0); math.Abs(d-4) > 0.01 {
+		t.Errorf("Expected 15 to be 4σ above, got %v", d)
+	}
+	if d := b.Deviation(7, 0); math.Abs(d+4) > 0.01 {
+		t.Errorf("Expected 7 to be 4σ below, got %v", d)
+	}
+	// The floor keeps small changes of a steady metric usual
+	if d := b.Deviation(15, 5); math.Abs(d-0.8) > 0.01 {
+		t.Errorf("Expected the floor to scale the deviation to 0.8σ, got %v", d)
+	}
+}
+
+func TestUnusual(t *testing.T) {
+	if !Unusual(-3.5, 3) || Unusual(2.9, 3) || Unusual(10, 0) {
+		t.Error("Expected deviations beyond ±σ to be unusual, and none with σ 0")
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// bootTimeTolerance absorbs the jitter of boot times derived from the uptime
+const bootTimeTolerance = 5 * time.Second
+
+// BootRecord is persisted between runs to notice reboots that happened while
+// the monitor was not running
+type BootRecord struct {
+	BootTime time.Time `json:"boot_time"`
+	LastSeen time.Time `json:"last_seen"` // When the monitor last saw this boot running
+}
+
+// Rebooted reports whether bootTime belongs to another boot than the record.
+// An empty record, from a first run, never reports a reboot.
+func (r BootRecord) Rebooted(bootTime time.Time) bool {
+	if r.BootTime.IsZero() || bootTime.IsZero() {
+		return false
+	}
+	diff := bootTime.Sub(r.BootTime)
+	return diff > bootTimeTolerance || diff < -bootTimeTolerance
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBootRecord_Rebooted(t *testing.T) {
+	boot := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	record := BootRecord{BootTime: boot, LastSeen: boot.Add(time.Hour)}
+
+	tests := []struct {
+		name     string
+		record   BootRecord
+		bootTime time.Time
+		want     bool
+	}{
+		{"same boot", record, boot, false},
+		{"jitter", record, boot.Add(time.Second), false},
+		{"later boot", record, boot.Add(2 * time.Hour), true},
+		{"clock moved back", record, boot.Add(-time.Hour), true},
+		{"first run", BootRecord{}, boot, false},
+		{"unknown boot time", record, time.Time{}, false},
+	}
+	for _, test := range tests {
+		if got := test.record.Rebooted(test.bootTime); got != test.want {
+			t.Errorf("%s: Rebooted = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
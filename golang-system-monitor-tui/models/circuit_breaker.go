@@ -0,0 +1,109 @@
+package models
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // Calls go through
+	CircuitOpen                         // Calls are rejected until the cooldown has passed
+	CircuitHalfOpen                     // A single probe call is going through
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker stops calls to a subsystem that keeps failing. It opens
+// after threshold failures in a row and rejects calls for cooldown; then one
+// probe call is let through, closing the circuit on success and opening it
+// for another cooldown on failure. It is safe for concurrent use.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     CircuitState
+	failures  int       // Consecutive failures while closed
+	openedAt  time.Time // When the circuit last opened
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow reports whether a call may be made at now. Once the cooldown of an
+// open circuit has passed, the first caller gets the probe and the circuit
+// turns half-open until its result is recorded.
+func (b *CircuitBreaker) Allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		return true
+	case CircuitHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the circuit
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = CircuitClosed
+	b.failures = 0
+}
+
+// RecordFailure records a failed call at now and reports whether it opened
+// the circuit, which a failed probe does not count as
+func (b *CircuitBreaker) RecordFailure(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitHalfOpen:
+		b.state = CircuitOpen
+		b.openedAt = now
+		return false
+	case CircuitOpen:
+		return false
+	}
+	b.failures++
+	if b.failures < b.threshold {
+		return false
+	}
+	b.state = CircuitOpen
+	b.openedAt = now
+	return true
+}
+
+// State returns the state of the circuit
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// ProbeAt returns when an open circuit lets the next probe through
+func (b *CircuitBreaker) ProbeAt() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.openedAt.Add(b.cooldown)
+}
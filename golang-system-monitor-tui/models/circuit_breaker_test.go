@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := NewCircuitBreaker(3, time.Minute)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if breaker.State() != CircuitClosed || !breaker.Allow(now) {
+		t.Fatal("Expected a new breaker to be closed")
+	}
+
+	// Failures below the threshold leave it closed, a success resets the count
+	breaker.RecordFailure(now)
+	breaker.RecordFailure(now)
+	breaker.RecordSuccess()
+	if breaker.RecordFailure(now) || breaker.RecordFailure(now) || breaker.State() != CircuitClosed {
+		t.Fatal("Expected the success to reset the failure count")
+	}
+	if !breaker.RecordFailure(now) || breaker.State() != CircuitOpen {
+		t.Fatalf("Expected the third failure in a row to open the circuit, got %v", breaker.State())
+	}
+	if breaker.Allow(now.Add(59 * time.Second)) {
+		t.Error("Expected calls to be rejected during the cooldown")
+	}
+	if got := breaker.ProbeAt(); !got.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected the probe a minute later, got %v", got)
+	}
+
+	// One probe after the cooldown
+	later := now.Add(time.Minute)
+	if !breaker.Allow(later) || breaker.State() != CircuitHalfOpen || breaker.Allow(later) {
+		t.Fatalf("Expected a single probe, got state %v", breaker.State())
+	}
+	if breaker.RecordFailure(later) || breaker.State() != CircuitOpen || breaker.Allow(later.Add(time.Second)) {
+		t.Errorf("Expected the failed probe to reopen the circuit for a cooldown, got %v", breaker.State())
+	}
+
+	breaker.Allow(later.Add(time.Minute))
+	breaker.RecordSuccess()
+	if breaker.State() != CircuitClosed || !breaker.Allow(later) {
+		t.Errorf("Expected the successful probe to close the circuit, got %v", breaker.State())
+	}
+	if CircuitHalfOpen.String() != "half-open" {
+		t.Errorf("Unexpected state name %q", CircuitHalfOpen)
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock_After(t *testing.T) {
+	start := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	late := clock.After(2 * time.Second)
+	early := clock.After(time.Second)
+	if clock.Waiters() != 2 {
+		t.Fatalf("Expected 2 waiters, got %d", clock.Waiters())
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case at := <-early:
+		t.Fatalf("Expected nothing before the second passed, got %v", at)
+	default:
+	}
+
+	clock.Advance(time.Second)
+	if at := <-early; !at.Equal(start.Add(time.Second)) {
+		t.Errorf("Expected the due time, got %v", at)
+	}
+	if clock.Waiters() != 1 {
+		t.Errorf("Expected the later waiter to keep waiting, got %d waiters", clock.Waiters())
+	}
+	if !clock.Now().Equal(start.Add(1500 * time.Millisecond)) {
+		t.Errorf("Expected the clock at 1.5s, got %v", clock.Now())
+	}
+
+	clock.Advance(time.Hour)
+	if at := <-late; !at.Equal(start.Add(2 * time.Second)) {
+		t.Errorf("Expected the due time, got %v", at)
+	}
+
+	if at := <-clock.After(0); !at.Equal(clock.Now()) {
+		t.Errorf("Expected an immediate tick at the current time, got %v", at)
+	}
+}
+
+func TestFakeClock_BlockUntil(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC))
+	ticks := make(chan time.Time)
+	go func() {
+		ticks <- <-clock.After(time.Minute)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	if at := <-ticks; !at.Equal(clock.Now()) {
+		t.Errorf("Expected the goroutine to wake at %v, got %v", clock.Now(), at)
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	if now := SystemClock.Now(); now.Before(before) {
+		t.Errorf("Expected the wall clock, got %v before %v", now, before)
+	}
+	if at := <-SystemClock.After(time.Millisecond); at.Before(before) {
+		t.Errorf("Expected a tick after %v, got %v", before, at)
+	}
+}
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ContainerInfo represents a container and its runtime metadata
+type ContainerInfo struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Image        string            `json:"image"`
+	State        string            `json:"state"`  // running, exited, paused, ...
+	Health       string            `json:"health"` // healthy, unhealthy, starting, or empty without a health check
+	RestartCount int               `json:"restart_count"`
+	StartedAt    time.Time         `json:"started_at"`
+	Labels       map[string]string `json:"labels"`
+	CPUPercent   float64           `json:"cpu_percent"`  // CPU usage since the previous collection, 100% per core
+	MemoryUsage  uint64            `json:"memory_usage"` // Memory in use excluding page cache, in bytes
+}
+
+// Labels used by orchestrators to tie containers to a multi-service application
+const (
+	LabelComposeProject = "com.docker.compose.project"
+	LabelStackNamespace = "com.docker.stack.namespace"
+	LabelPodName        = "io.kubernetes.pod.name"
+	LabelPodNamespace   = "io.kubernetes.pod.namespace"
+)
+
+// ContainerGroup is a set of containers belonging to one compose project, swarm stack or pod
+type ContainerGroup struct {
+	Name       string          `json:"name"`
+	Kind       string          `json:"kind"` // compose, stack or pod
+	Containers []ContainerInfo `json:"containers"`
+}
+
+// Key returns a unique identifier for the group
+func (g ContainerGroup) Key() string {
+	return g.Kind + "/" + g.Name
+}
+
+// CPUPercent returns the combined CPU usage of the group's containers
+func (g ContainerGroup) CPUPercent() float64 {
+	var total float64
+	for _, container := range g.Containers {
+		total += container.CPUPercent
+	}
+	return total
+}
+
+// MemoryUsage returns the combined memory usage of the group's containers
+func (g ContainerGroup) MemoryUsage() uint64 {
+	var total uint64
+	for _, container := range g.Containers {
+		total += container.MemoryUsage
+	}
+	return total
+}
+
+// RestartCount returns the combined restart count of the group's containers
+func (g ContainerGroup) RestartCount() int {
+	total := 0
+	for _, container := range g.Containers {
+		total += container.RestartCount
+	}
+	return total
+}
+
+// Running returns the number of running containers in the group
+func (g ContainerGroup) Running() int {
+	running := 0
+	for _, container := range g.Containers {
+		if container.State == "running" {
+			running++
+		}
+	}
+	return running
+}
+
+// Unhealthy returns the number of containers failing their health check
+func (g ContainerGroup) Unhealthy() int {
+	unhealthy := 0
+	for _, container := range g.Containers {
+		if container.Health == "unhealthy" {
+			unhealthy++
+		}
+	}
+	return unhealthy
+}
+
+// Group returns the kind and name of the application the container belongs to,
+// or empty strings for a standalone container
+func (c ContainerInfo) Group() (string, string) {
+	switch {
+	case c.Labels[LabelComposeProject] != "":
+		return "compose", c.Labels[LabelComposeProject]
+	case c.Labels[LabelStackNamespace] != "":
+		return "stack", c.Labels[LabelStackNamespace]
+	case c.Labels[LabelPodName] != "":
+		if namespace := c.Labels[LabelPodNamespace]; namespace != "" {
+			return "pod", namespace + "/" + c.Labels[LabelPodName]
+		}
+		return "pod", c.Labels[LabelPodName]
+	default:
+		return "", ""
+	}
+}
+
+// GroupContainers splits containers into application groups sorted by name and
+// standalone containers in their original order
+func GroupContainers(containers []ContainerInfo) ([]ContainerGroup, []ContainerInfo) {
+	var groups []ContainerGroup
+	var standalone []ContainerInfo
+	index := make(map[string]int)
+
+	for _, container := range containers {
+		kind, name := container.Group()
+		if kind == "" {
+			standalone = append(standalone, container)
+			continue
+		}
+
+		key := kind + "/" + name
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, ContainerGroup{Name: name, Kind: kind})
+		}
+		groups[i].Containers = append(groups[i].Containers, container)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups, standalone
+}
+
+// ImageName returns the image reference without its tag
+func (c ContainerInfo) ImageName() string {
+	name, _ := splitImageRef(c.Image)
+	return name
+}
+
+// ImageTag returns the image tag, defaulting to "latest" when none is given
+func (c ContainerInfo) ImageTag() string {
+	_, tag := splitImageRef(c.Image)
+	return tag
+}
+
+// Uptime returns how long the container has been running, zero when it is not running
+func (c ContainerInfo) Uptime(now time.Time) time.Duration {
+	if c.State != "running" || c.StartedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(c.StartedAt)
+}
+
+// splitImageRef splits "registry:5000/repo/name:tag@digest" into name and tag
+func splitImageRef(ref string) (string, string) {
+	if at := strings.Index(ref, "@"); at >= 0 {
+		ref = ref[:at]
+	}
+	// A colon after the last slash separates the tag, earlier ones belong to a registry port
+	slash := strings.LastIndex(ref, "/")
+	if colon := strings.LastIndex(ref, ":"); colon > slash {
+		return ref[:colon], ref[colon+1:]
+	}
+	return ref, "latest"
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContainerInfo_ImageRef(t *testing.T) {
+	tests := []struct {
+		image    string
+		wantName string
+		wantTag  string
+	}{
+		{"nginx:1.25", "nginx", "1.25"},
+		{"postgres", "postgres", "latest"},
+		{"registry.local:5000/team/api:v2", "registry.local:5000/team/api", "v2"},
+		{"registry.local:5000/team/api", "registry.local:5000/team/api", "latest"},
+		{"redis:7@sha256:abcd", "redis", "7"},
+	}
+
+	for _, tt := range tests {
+		c := ContainerInfo{Image: tt.image}
+		if c.ImageName() != tt.wantName || c.ImageTag() != tt.wantTag {
+			t.Errorf("%s: got %s/%s, want %s/%s", tt.image, c.ImageName(), c.ImageTag(), tt.wantName, tt.wantTag)
+		}
+	}
+}
+
+func TestContainerInfo_Uptime(t *testing.T) {
+	now := time.Now()
+	running := ContainerInfo{State: "running", StartedAt: now.Add(-2 * time.Hour)}
+	if running.Uptime(now) != 2*time.Hour {
+		t.Errorf("Expected 2h uptime, got %v", running.Uptime(now))
+	}
+
+	exited := ContainerInfo{State: "exited", StartedAt: now.Add(-time.Hour)}
+	if exited.Uptime(now) != 0 {
+		t.Errorf("Expected no uptime for exited container, got %v", exited.Uptime(now))
+	}
+}
+
+func TestGroupContainers(t *testing.T) {
+	containers := []ContainerInfo{
+		{Name: "web", State: "running", CPUPercent: 12.5, MemoryUsage: 100, Labels: map[string]string{LabelComposeProject: "shop"}},
+		{Name: "standalone", State: "running"},
+		{Name: "api", State: "running", CPUPercent: 3, MemoryUsage: 50, Labels: map[string]string{LabelStackNamespace: "billing"}},
+		{Name: "db", State: "exited", Health: "unhealthy", RestartCount: 4, CPUPercent: 7.5, MemoryUsage: 200, Labels: map[string]string{LabelComposeProject: "shop"}},
+		{Name: "k8s_app", State: "running", Labels: map[string]string{LabelPodName: "app-7f9", LabelPodNamespace: "default"}},
+	}
+
+	groups, standalone := GroupContainers(containers)
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
+	}
+	if len(standalone) != 1 || standalone[0].Name != "standalone" {
+		t.Errorf("Expected one standalone container, got %+v", standalone)
+	}
+
+	// Groups are sorted by name
+	if groups[0].Name != "billing" || groups[0].Kind != "stack" {
+		t.Errorf("Expected billing stack first, got %+v", groups[0])
+	}
+	if groups[1].Name != "default/app-7f9" || groups[1].Kind != "pod" {
+		t.Errorf("Expected namespaced pod group, got %+v", groups[1])
+	}
+
+	shop := groups[2]
+	if shop.Key() != "compose/shop" || len(shop.Containers) != 2 {
+		t.Fatalf("Expected shop compose group with 2 containers, got %+v", shop)
+	}
+	if shop.CPUPercent() != 20 || shop.MemoryUsage() != 300 {
+		t.Errorf("Expected aggregated 20%% CPU and 300 bytes, got %.1f%% and %d", shop.CPUPercent(), shop.MemoryUsage())
+	}
+	if shop.Running() != 1 || shop.Unhealthy() != 1 || shop.RestartCount() != 4 {
+		t.Errorf("Unexpected shop status totals: running=%d unhealthy=%d restarts=%d", shop.Running(), shop.Unhealthy(), shop.RestartCount())
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+// CPUTimes splits the CPU time of a sample by what it was spent on, in
+// percent of the time of all cores. Idle time is left out, so the parts add
+// up to about the total usage plus the iowait share.
+type CPUTimes struct {
+	User   float64 `json:"user"`   // Running processes, niced ones included
+	System float64 `json:"system"` // Running the kernel on behalf of processes
+	IOWait float64 `json:"iowait"` // Idle with disk I/O outstanding, a sign of I/O-bound load
+	Steal  float64 `json:"steal"`  // Taken by the hypervisor to run other guests, on virtual machines
+	IRQ    float64 `json:"irq"`    // Serving hardware and software interrupts
+}
+
+// Known reports whether the collector split the CPU time; it does not on
+// platforms without the counters, and on the first sample of some
+func (t CPUTimes) Known() bool {
+	return t != CPUTimes{}
+}
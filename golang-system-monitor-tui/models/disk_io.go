@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// DiskIO is the throughput of the physical disks combined: bytes read and
+// written since boot and how fast they grew since the sample before
+type DiskIO struct {
+	ReadBytes  uint64    `json:"read_bytes"`
+	WriteBytes uint64    `json:"write_bytes"`
+	ReadRate   float64   `json:"read_rate"`  // Bytes per second, 0 on the first sample
+	WriteRate  float64   `json:"write_rate"` // Bytes per second, 0 on the first sample
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// TotalRate returns the bytes read and written per second
+func (d DiskIO) TotalRate() float64 {
+	return d.ReadRate + d.WriteRate
+}
@@ -0,0 +1,23 @@
+package models
+
+// UsageThresholds holds the usage percentages from which a resource is shown
+// as a warning and as critical
+type UsageThresholds struct {
+	Warning  float64 `json:"warning"`
+	Critical float64 `json:"critical"`
+}
+
+// DefaultDiskThresholds returns the thresholds of the filesystems without
+// their own: yellow from 70% and red from 90%
+func DefaultDiskThresholds() UsageThresholds {
+	return UsageThresholds{Warning: 70, Critical: 90}
+}
+
+// DiskThresholdsFor returns the thresholds of the filesystem mounted on
+// mountpoint, the defaults when it has none of its own
+func DiskThresholdsFor(thresholds map[string]UsageThresholds, mountpoint string) UsageThresholds {
+	if own, ok := thresholds[mountpoint]; ok {
+		return own
+	}
+	return DefaultDiskThresholds()
+}
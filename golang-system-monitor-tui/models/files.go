@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// FileDescriptorWarning is the share of a file descriptor limit, in percent,
+// from which the usage is highlighted
+const FileDescriptorWarning = 80.0
+
+// FileDescriptors is the system-wide usage of file handles, from
+// /proc/sys/fs/file-nr on Linux
+type FileDescriptors struct {
+	Allocated uint64    `json:"allocated"` // Handles in use
+	Max       uint64    `json:"max"`       // Limit of the kernel, fs.file-max
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// UsedPercent returns the share of the limit in use, 0 without a limit
+func (f FileDescriptors) UsedPercent() float64 {
+	if f.Max == 0 {
+		return 0
+	}
+	return float64(f.Allocated) / float64(f.Max) * 100
+}
+
+// ProcessFiles is the number of files a process has open and its limit
+type ProcessFiles struct {
+	PID   int32  `json:"pid"`
+	Open  uint64 `json:"open"`  // Open file descriptors
+	Limit uint64 `json:"limit"` // Soft RLIMIT_NOFILE, 0 when unknown
+}
+
+// UsedPercent returns the share of the limit in use, 0 when the limit is unknown
+func (p ProcessFiles) UsedPercent() float64 {
+	if p.Limit == 0 {
+		return 0
+	}
+	return float64(p.Open) / float64(p.Limit) * 100
+}
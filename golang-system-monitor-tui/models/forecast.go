@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// MinForecastSpan is how long a filesystem has to be watched before its
+// growth is extrapolated, shorter spans are dominated by noise
+const MinForecastSpan = time.Minute
+
+// DiskSample is the used space of a filesystem at one point in time, the
+// baseline a forecast extrapolates from
+type DiskSample struct {
+	Used uint64
+	At   time.Time
+}
+
+// TimeToFull estimates when disk runs out of space by extrapolating its
+// growth since the earlier sample. It returns false while the filesystem is
+// not growing or has not been watched for MinForecastSpan.
+func TimeToFull(since DiskSample, disk DiskInfo, now time.Time) (time.Duration, bool) {
+	elapsed := now.Sub(since.At)
+	if elapsed < MinForecastSpan || disk.Used <= since.Used {
+		return 0, false
+	}
+	free := disk.Available
+	if free == 0 && disk.Total > disk.Used {
+		free = disk.Total - disk.Used
+	}
+
+	perSecond := float64(disk.Used-since.Used) / elapsed.Seconds()
+	return time.Duration(float64(free) / perSecond * float64(time.Second)), true
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeToFull(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	since := DiskSample{Used: 400, At: start}
+
+	tests := []struct {
+		name   string
+		disk   DiskInfo
+		now    time.Time
+		want   time.Duration
+		wantOK bool
+	}{
+		{"growing", DiskInfo{Total: 1000, Used: 460, Available: 540}, start.Add(time.Hour), 9 * time.Hour, true},
+		{"reserved blocks", DiskInfo{Total: 1000, Used: 460, Available: 240}, start.Add(time.Hour), 4 * time.Hour, true},
+		{"no available field", DiskInfo{Total: 1000, Used: 460}, start.Add(time.Hour), 9 * time.Hour, true},
+		{"unchanged", DiskInfo{Total: 1000, Used: 400, Available: 600}, start.Add(time.Hour), 0, false},
+		{"shrinking", DiskInfo{Total: 1000, Used: 300, Available: 700}, start.Add(time.Hour), 0, false},
+		{"too soon", DiskInfo{Total: 1000, Used: 460, Available: 540}, start.Add(30 * time.Second), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := TimeToFull(since, tt.disk, tt.now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Expected %v (%v), got %v (%v)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
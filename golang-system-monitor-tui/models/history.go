@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// HistoryRecord is the metric history persisted between runs, so graphs keep
+// their context when the monitor is restarted. Series are oldest first.
+type HistoryRecord struct {
+	SavedAt  time.Time      `json:"saved_at"`
+	CPUTotal []float64      `json:"cpu_total,omitempty"` // Overall CPU usage
+	CPUCores [][]float64    `json:"cpu_cores,omitempty"` // Usage per core
+	CPULong  []TierSnapshot `json:"cpu_long,omitempty"`  // Overall CPU usage at decreasing resolution, for the longer graph ranges
+}
+
+// Fresh reports whether the record was saved at most maxAge before now.
+// Older history would join the live graphs across a gap long enough to
+// mislead, so it is better dropped. An empty record is never fresh.
+func (r HistoryRecord) Fresh(now time.Time, maxAge time.Duration) bool {
+	if r.SavedAt.IsZero() {
+		return false
+	}
+	age := now.Sub(r.SavedAt)
+	return age >= 0 && age <= maxAge
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistoryRecord_Fresh(t *testing.T) {
+	saved := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	record := HistoryRecord{SavedAt: saved, CPUTotal: []float64{10}}
+
+	tests := []struct {
+		name   string
+		record HistoryRecord
+		now    time.Time
+		want   bool
+	}{
+		{"just saved", record, saved, true},
+		{"within the limit", record, saved.Add(5 * time.Minute), true},
+		{"at the limit", record, saved.Add(10 * time.Minute), true},
+		{"too old", record, saved.Add(11 * time.Minute), false},
+		{"saved in the future", record, saved.Add(-time.Minute), false},
+		{"never saved", HistoryRecord{}, saved, false},
+	}
+	for _, test := range tests {
+		if got := test.record.Fresh(test.now, 10*time.Minute); got != test.want {
+			t.Errorf("%s: Fresh = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
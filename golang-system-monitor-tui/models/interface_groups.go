@@ -0,0 +1,40 @@
+package models
+
+import "path"
+
+// InterfaceGroup gathers the network interfaces whose names match one of its
+// patterns into a single row of the Network panel with their combined rates
+type InterfaceGroup struct {
+	Name     string   // Row title, e.g. VPN
+	Patterns []string // Interface name globs such as wg* or tailscale*
+}
+
+// Matches reports whether the interface name matches a pattern of the group
+func (g InterfaceGroup) Matches(name string) bool {
+	for _, pattern := range g.Patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultInterfaceGroups returns the VPN group of the WireGuard, OpenVPN,
+// Tailscale, ZeroTier, PPP and IPsec interfaces, and the utun tunnels macOS
+// creates for its own services
+func DefaultInterfaceGroups() []InterfaceGroup {
+	return []InterfaceGroup{{
+		Name:     "VPN",
+		Patterns: []string{"wg*", "tun*", "tap*", "utun*", "tailscale*", "zt*", "ppp*", "ipsec*", "nordlynx", "proton*"},
+	}}
+}
+
+// GroupOf returns the first group whose patterns match the interface name
+func GroupOf(groups []InterfaceGroup, name string) (InterfaceGroup, bool) {
+	for _, group := range groups {
+		if group.Matches(name) {
+			return group, true
+		}
+	}
+	return InterfaceGroup{}, false
+}
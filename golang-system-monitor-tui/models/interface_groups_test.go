@@ -0,0 +1,30 @@
+package models
+
+import "testing"
+
+func TestInterfaceGroup_Matches(t *testing.T) {
+	vpn := DefaultInterfaceGroups()[0]
+	for _, name := range []string{"wg0", "tun1", "tailscale0", "utun3", "nordlynx"} {
+		if !vpn.Matches(name) {
+			t.Errorf("Expected %s in the VPN group", name)
+		}
+	}
+	for _, name := range []string{"eth0", "wlan0", "en0", "docker0"} {
+		if vpn.Matches(name) {
+			t.Errorf("Expected %s outside the VPN group", name)
+		}
+	}
+}
+
+func TestGroupOf(t *testing.T) {
+	groups := []InterfaceGroup{
+		{Name: "Containers", Patterns: []string{"docker*", "veth*"}},
+		{Name: "Bridges", Patterns: []string{"docker0", "br-*"}},
+	}
+	if group, ok := GroupOf(groups, "docker0"); !ok || group.Name != "Containers" {
+		t.Errorf("Expected the first matching group, got %+v (%v)", group, ok)
+	}
+	if _, ok := GroupOf(groups, "eth0"); ok {
+		t.Error("Expected eth0 in no group")
+	}
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -13,6 +15,80 @@ type SystemCollector interface {
 	CalculateNetworkRates(previous, current []NetworkInfo) map[string]NetworkStats
 }
 
+// Collector interface abstracts one source of metrics collected on every tick.
+// Collect returns the gathered data, e.g. CPUInfo or []SensorInfo; Name
+// identifies the collector in the registry and its errors, e.g. "CPU".
+type Collector interface {
+	Name() string
+	Collect() (interface{}, error)
+}
+
+// SamplingCollector is a Collector that spends part of Collect waiting for a
+// sampling window, e.g. CPU usage measured over a second, rather than working
+type SamplingCollector interface {
+	Collector
+	SampleWindow() time.Duration
+}
+
+// CPUSampler is implemented by system collectors whose CollectCPU waits for
+// a sampling window
+type CPUSampler interface {
+	CPUSampleWindow() time.Duration
+}
+
+// ProcessCollector interface abstracts per-process information gathering
+type ProcessCollector interface {
+	CollectTopProcesses(n int) ([]ProcessInfo, error)
+}
+
+// ProcessMemoryCollector interface abstracts per-process memory breakdowns
+type ProcessMemoryCollector interface {
+	CollectProcessMemory(pid int32) (ProcessMemory, error)
+}
+
+// ProcessFilesCollector interface abstracts per-process open file counts
+type ProcessFilesCollector interface {
+	CollectProcessFiles(pid int32) (ProcessFiles, error)
+}
+
+// ProcessDetailCollector interface abstracts the detail of a single process,
+// read on demand
+type ProcessDetailCollector interface {
+	CollectProcessDetail(pid int32) (ProcessDetail, error)
+}
+
+// SensorCollector interface abstracts temperature sensor gathering
+type SensorCollector interface {
+	CollectSensors() ([]SensorInfo, error)
+}
+
+// HostCollector interface abstracts host identity gathering
+type HostCollector interface {
+	CollectHost() (HostInfo, error)
+}
+
+// ContainerCollector interface abstracts container runtime queries
+type ContainerCollector interface {
+	CollectContainers() ([]ContainerInfo, error)
+	ContainerLogs(id string, lines int) ([]string, error)
+}
+
+// Notifier interface abstracts delivery of alert events to the outside world
+type Notifier interface {
+	Notify(event AlertEvent) error
+}
+
+// SinkReporter interface is implemented by output sinks that report their delivery health
+type SinkReporter interface {
+	SinkStatus() SinkStatus
+}
+
+// SnapshotExporter interface abstracts pushing resource snapshots to external backends.
+// Record must not block; exporters deliver on their own schedule.
+type SnapshotExporter interface {
+	Record(snapshot Snapshot)
+}
+
 // ResourceModel interface for consistent component behavior
 type ResourceModel interface {
 	Update(tea.Msg) (ResourceModel, tea.Cmd)
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// KernelStats is a sample of the kernel's cumulative activity counters and
+// its entropy pool, from /proc/stat and /proc/sys/kernel/random on Linux
+type KernelStats struct {
+	ContextSwitches uint64 // Context switches since boot
+	Interrupts      uint64 // Interrupts serviced since boot
+	Forks           uint64 // Processes and threads created since boot
+	Entropy         int    // Bits available in the entropy pool, -1 where unknown
+	Timestamp       time.Time
+}
+
+// KernelRates is how fast the kernel counters grew between two samples, per second
+type KernelRates struct {
+	ContextSwitches float64
+	Interrupts      float64
+	Forks           float64
+}
+
+// Rates returns the growth of the counters from previous to k per second,
+// using CounterRate so a reset counter reads 0
+func (k KernelStats) Rates(previous KernelStats) KernelRates {
+	elapsed := Elapsed(previous.Timestamp, k.Timestamp)
+	return KernelRates{
+		ContextSwitches: CounterRate(previous.ContextSwitches, k.ContextSwitches, elapsed),
+		Interrupts:      CounterRate(previous.Interrupts, k.Interrupts, elapsed),
+		Forks:           CounterRate(previous.Forks, k.Forks, elapsed),
+	}
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale holds the number and clock conventions used to render values
+type Locale struct {
+	Name      string // Locale name as given, e.g. "de_DE.UTF-8"
+	Decimal   string // Decimal separator
+	Thousands string // Thousands separator, empty for no grouping
+	Clock24   bool   // Whether times use a 24-hour clock
+}
+
+// DefaultLocale returns the C locale: a decimal point, no grouping and a 24-hour clock
+func DefaultLocale() Locale {
+	return Locale{Name: "C", Decimal: ".", Clock24: true}
+}
+
+// localePattern matches POSIX ("de_DE.UTF-8@euro") and BCP 47 ("de-DE") locale names
+var localePattern = regexp.MustCompile(`^([a-zA-Z]{2,3})(?:[_-]([a-zA-Z0-9]{2,3}))?(?:\.[^@]*)?(?:@.*)?$`)
+
+// Languages writing a decimal comma, with their thousands separator
+var decimalCommaLanguages = map[string]string{
+	"bg": " ", "ca": ".", "cs": " ", "da": ".", "de": ".", "el": ".", "es": ".",
+	"et": " ", "fi": " ", "fr": " ", "hr": ".", "hu": " ", "id": ".", "it": ".",
+	"lt": " ", "lv": " ", "nb": " ", "nl": ".", "nn": " ", "no": " ", "pl": " ",
+	"pt": ".", "ro": ".", "ru": " ", "sk": " ", "sl": ".", "sv": " ", "tr": ".",
+	"uk": " ", "vi": ".",
+}
+
+// Territories whose conventions differ from their language's
+var territoryNumbers = map[string][2]string{
+	"CH": {".", "'"}, // de_CH, fr_CH and it_CH
+	"MX": {".", ","},
+}
+
+// Territories that use a 12-hour clock
+var twelveHourTerritories = map[string]bool{
+	"US": true, "CA": true, "AU": true, "NZ": true, "IN": true,
+	"PH": true, "PK": true, "EG": true, "SA": true,
+}
+
+// ParseLocale parses a locale name such as "en_US.UTF-8", "de-DE" or "C".
+// Languages without known conventions use a decimal point and comma grouping.
+func ParseLocale(name string) (Locale, error) {
+	switch name {
+	case "", "C", "POSIX", "C.UTF-8", "C.utf8":
+		locale := DefaultLocale()
+		if name != "" {
+			locale.Name = name
+		}
+		return locale, nil
+	}
+
+	match := localePattern.FindStringSubmatch(name)
+	if match == nil {
+		return DefaultLocale(), fmt.Errorf("invalid locale %q (want a name like en_US or de_DE.UTF-8)", name)
+	}
+	language, territory := strings.ToLower(match[1]), strings.ToUpper(match[2])
+
+	locale := Locale{Name: name, Decimal: ".", Thousands: ",", Clock24: !twelveHourTerritories[territory]}
+	if thousands, ok := decimalCommaLanguages[language]; ok {
+		locale.Decimal, locale.Thousands = ",", thousands
+	}
+	if numbers, ok := territoryNumbers[territory]; ok {
+		locale.Decimal, locale.Thousands = numbers[0], numbers[1]
+	}
+	return locale, nil
+}
+
+// LocaleFromEnv returns the locale of the environment, following the POSIX
+// precedence of LC_ALL, then LC_NUMERIC or LC_TIME, then LANG. Unparseable
+// values fall back to the C locale.
+func LocaleFromEnv(getenv func(string) string) Locale {
+	lookup := func(category string) Locale {
+		for _, key := range []string{"LC_ALL", category, "LANG"} {
+			if value := getenv(key); value != "" {
+				locale, _ := ParseLocale(value)
+				return locale
+			}
+		}
+		return DefaultLocale()
+	}
+
+	locale := lookup("LC_NUMERIC")
+	locale.Clock24 = lookup("LC_TIME").Clock24
+	return locale
+}
+
+// FormatFloat formats value with precision decimals, grouping the integer part
+func (l Locale) FormatFloat(value float64, precision int) string {
+	text := strconv.FormatFloat(value, 'f', precision, 64)
+
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(text, ".")
+
+	result := sign + l.group(integer)
+	if hasFraction {
+		result += l.decimal() + fraction
+	}
+	return result
+}
+
+// FormatInt formats an integer with grouping
+func (l Locale) FormatInt(value int64) string {
+	if value < 0 {
+		return "-" + l.group(strconv.FormatInt(-value, 10))
+	}
+	return l.group(strconv.FormatInt(value, 10))
+}
+
+// FormatPercent formats a percentage with precision decimals followed by "%"
+func (l Locale) FormatPercent(value float64, precision int) string {
+	return l.FormatFloat(value, precision) + "%"
+}
+
+// FormatTime formats the time of day, e.g. "15:04:05" or "03:04:05 PM". The
+// 12-hour form is zero-padded so columns stay aligned.
+func (l Locale) FormatTime(t time.Time) string {
+	if l.Clock24 {
+		return t.Format("15:04:05")
+	}
+	return t.Format("03:04:05 PM")
+}
+
+// decimal returns the decimal separator, a point when unset
+func (l Locale) decimal() string {
+	if l.Decimal == "" {
+		return "."
+	}
+	return l.Decimal
+}
+
+// group inserts the thousands separator into a string of digits
+func (l Locale) group(digits string) string {
+	if l.Thousands == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(l.Thousands)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		name      string
+		decimal   string
+		thousands string
+		clock24   bool
+	}{
+		{"", ".", "", true},
+		{"C", ".", "", true},
+		{"POSIX", ".", "", true},
+		{"en_US.UTF-8", ".", ",", false},
+		{"en_GB.UTF-8", ".", ",", true},
+		{"de_DE.UTF-8@euro", ",", ".", true},
+		{"de-CH", ".", "'", true},
+		{"fr_FR", ",", " ", true},
+		{"es_MX.utf8", ".", ",", true},
+		{"pt_BR", ",", ".", true},
+		{"ja", ".", ",", true},
+	}
+
+	for _, test := range tests {
+		locale, err := ParseLocale(test.name)
+		if err != nil {
+			t.Errorf("ParseLocale(%q) returned error: %v", test.name, err)
+			continue
+		}
+		if locale.Decimal != test.decimal || locale.Thousands != test.thousands || locale.Clock24 != test.clock24 {
+			t.Errorf("ParseLocale(%q) = %+v, want decimal %q, thousands %q, 24h %v",
+				test.name, locale, test.decimal, test.thousands, test.clock24)
+		}
+	}
+
+	for _, name := range []string{"english", "en_US_POSIX", "1234"} {
+		if _, err := ParseLocale(name); err == nil {
+			t.Errorf("ParseLocale(%q) should fail", name)
+		}
+	}
+}
+
+func TestLocaleFromEnv(t *testing.T) {
+	env := func(values map[string]string) func(string) string {
+		return func(key string) string { return values[key] }
+	}
+
+	locale := LocaleFromEnv(env(map[string]string{"LANG": "en_US.UTF-8", "LC_NUMERIC": "de_DE.UTF-8"}))
+	if locale.Decimal != "," || locale.Clock24 {
+		t.Errorf("Expected German numbers with a US clock, got %+v", locale)
+	}
+
+	locale = LocaleFromEnv(env(map[string]string{"LANG": "en_US.UTF-8", "LC_NUMERIC": "de_DE", "LC_ALL": "fr_FR"}))
+	if locale.Thousands != " " || !locale.Clock24 {
+		t.Errorf("Expected LC_ALL to override every category, got %+v", locale)
+	}
+
+	locale = LocaleFromEnv(env(map[string]string{"LANG": "not a locale!"}))
+	if locale != DefaultLocale() {
+		t.Errorf("Expected the C locale for an invalid LANG, got %+v", locale)
+	}
+
+	if locale := LocaleFromEnv(env(nil)); locale != DefaultLocale() {
+		t.Errorf("Expected the C locale without environment, got %+v", locale)
+	}
+}
+
+func TestLocale_FormatFloat(t *testing.T) {
+	german, _ := ParseLocale("de_DE")
+	english, _ := ParseLocale("en_US")
+	swiss, _ := ParseLocale("de_CH")
+
+	tests := []struct {
+		locale    Locale
+		value     float64
+		precision int
+		expected  string
+	}{
+		{DefaultLocale(), 1234567.891, 1, "1234567.9"},
+		{english, 1234567.891, 1, "1,234,567.9"},
+		{german, 1234567.891, 2, "1.234.567,89"},
+		{swiss, 1234.5, 1, "1'234.5"},
+		{german, 42.25, 1, "42,2"},
+		{german, -1234, 0, "-1.234"},
+		{english, 999, 0, "999"},
+		{english, 100000, 0, "100,000"},
+	}
+
+	for _, test := range tests {
+		if got := test.locale.FormatFloat(test.value, test.precision); got != test.expected {
+			t.Errorf("%s.FormatFloat(%v, %d) = %q, want %q", test.locale.Name, test.value, test.precision, got, test.expected)
+		}
+	}
+
+	if got := german.FormatPercent(87.55, 1); got != "87,5%" && got != "87,6%" {
+		t.Errorf("Expected German percentage, got %q", got)
+	}
+	if got := english.FormatInt(-1234567); got != "-1,234,567" {
+		t.Errorf("Expected grouped negative integer, got %q", got)
+	}
+}
+
+func TestLocale_FormatTime(t *testing.T) {
+	at := time.Date(2024, time.January, 1, 15, 4, 5, 0, time.UTC)
+
+	if got := DefaultLocale().FormatTime(at); got != "15:04:05" {
+		t.Errorf("Expected 24-hour time, got %q", got)
+	}
+	english, _ := ParseLocale("en_US")
+	if got := english.FormatTime(at); got != "03:04:05 PM" {
+		t.Errorf("Expected 12-hour time, got %q", got)
+	}
+}
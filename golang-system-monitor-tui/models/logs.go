@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// LogTail holds the lines appended to a followed log file since the last read
+type LogTail struct {
+	Path      string
+	Lines     []string
+	Reset     bool   // The file was truncated or replaced; earlier lines belong to the old file
+	Error     string // Why the file could not be read; empty on success
+	Timestamp time.Time
+}
+
+// LogLevel is the severity of a log line as far as it can be told from its text
+type LogLevel int
+
+const (
+	LogLevelInfo LogLevel = iota
+	LogLevelWarning
+	LogLevelError
+)
+
+// logLevelWords maps words found in log lines to their severity, most severe first
+var logLevelWords = []struct {
+	word  string
+	level LogLevel
+}{
+	{"error", LogLevelError},
+	{"fatal", LogLevelError},
+	{"panic", LogLevelError},
+	{"crit", LogLevelError},
+	{"emerg", LogLevelError},
+	{"fail", LogLevelError},
+	{"warn", LogLevelWarning},
+}
+
+// ParseLogLevel returns the severity of a log line: error for lines mentioning
+// ERROR, FATAL, PANIC, CRIT(ICAL), EMERG or a failure, warning for WARN(ING)
+func ParseLogLevel(line string) LogLevel {
+	lower := strings.ToLower(line)
+	for _, entry := range logLevelWords {
+		if strings.Contains(lower, entry.word) {
+			return entry.level
+		}
+	}
+	return LogLevelInfo
+}
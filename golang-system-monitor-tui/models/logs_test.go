@@ -0,0 +1,22 @@
+package models
+
+import "testing"
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		line string
+		want LogLevel
+	}{
+		{"Jan 1 00:00:00 host kernel: ERROR: disk failure", LogLevelError},
+		{"level=fatal msg=\"out of memory\"", LogLevelError},
+		{"systemd[1]: Failed to start nginx.service", LogLevelError},
+		{"[WARNING] low disk space", LogLevelWarning},
+		{"W0101 warn: retrying", LogLevelWarning},
+		{"sshd[42]: Accepted publickey for me", LogLevelInfo},
+	}
+	for _, test := range tests {
+		if got := ParseLogLevel(test.line); got != test.want {
+			t.Errorf("ParseLogLevel(%q) = %v, want %v", test.line, got, test.want)
+		}
+	}
+}
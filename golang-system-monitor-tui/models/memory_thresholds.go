@@ -0,0 +1,95 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MemoryBasis is the share of RAM the Memory panel is colored and alerted by
+type MemoryBasis int
+
+const (
+	// MemoryBasisUsed compares the used share of RAM, which rises as long as
+	// the page cache grows
+	MemoryBasisUsed MemoryBasis = iota
+	// MemoryBasisAvailable compares the available share, which counts the
+	// page cache the kernel can drop as free, so cache-heavy systems do not
+	// look full
+	MemoryBasisAvailable
+)
+
+// memoryBasisNames are the names of the memory bases, by value
+var memoryBasisNames = []string{"used", "available"}
+
+// ParseMemoryBasis parses "used" or "available"; empty selects used
+func ParseMemoryBasis(name string) (MemoryBasis, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "used":
+		return MemoryBasisUsed, nil
+	case "available":
+		return MemoryBasisAvailable, nil
+	default:
+		return MemoryBasisUsed, fmt.Errorf("unknown memory basis %q (want %s)", name, strings.Join(memoryBasisNames, " or "))
+	}
+}
+
+// String returns the name ParseMemoryBasis accepts
+func (b MemoryBasis) String() string {
+	if b < 0 || int(b) >= len(memoryBasisNames) {
+		return fmt.Sprintf("MemoryBasis(%d)", int(b))
+	}
+	return memoryBasisNames[b]
+}
+
+// MemoryThresholds decides when memory is shown as a warning and as critical.
+// With the used basis memory is past a threshold at or above it, with the
+// available basis at or below it.
+type MemoryThresholds struct {
+	Basis    MemoryBasis
+	Warning  float64 // Percent of RAM used or available
+	Critical float64 // Percent of RAM used or available
+}
+
+// DefaultMemoryThresholds returns the thresholds of a basis: yellow from 70%
+// and red from 90% used, or yellow from 20% and red from 10% available
+func DefaultMemoryThresholds(basis MemoryBasis) MemoryThresholds {
+	if basis == MemoryBasisAvailable {
+		return MemoryThresholds{Basis: basis, Warning: 20, Critical: 10}
+	}
+	return MemoryThresholds{Basis: basis, Warning: 70, Critical: 90}
+}
+
+// Percent returns the share of RAM the thresholds compare, used or available
+func (t MemoryThresholds) Percent(info MemoryInfo) float64 {
+	if t.Basis == MemoryBasisAvailable {
+		if info.Total == 0 {
+			return 0
+		}
+		return float64(info.Available) / float64(info.Total) * 100
+	}
+	return info.UsedPercent()
+}
+
+// IsWarning reports whether percent is past the warning threshold
+func (t MemoryThresholds) IsWarning(percent float64) bool {
+	return t.past(percent, t.Warning)
+}
+
+// IsCritical reports whether percent is past the critical threshold
+func (t MemoryThresholds) IsCritical(percent float64) bool {
+	return t.past(percent, t.Critical)
+}
+
+// past reports whether percent is at threshold or beyond it in the direction
+// of the basis
+func (t MemoryThresholds) past(percent, threshold float64) bool {
+	if t.Basis == MemoryBasisAvailable {
+		return percent <= threshold
+	}
+	return percent >= threshold
+}
+
+// AlertRule returns the Memory alert rule firing at the critical threshold
+func (t MemoryThresholds) AlertRule() AlertRule {
+	return AlertRule{Component: "Memory", Threshold: t.Critical, Below: t.Basis == MemoryBasisAvailable}
+}
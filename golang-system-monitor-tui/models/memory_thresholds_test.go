@@ -0,0 +1,36 @@
+package models
+
+import "testing"
+
+func TestParseMemoryBasis(t *testing.T) {
+	for name, want := range map[string]MemoryBasis{"": MemoryBasisUsed, "used": MemoryBasisUsed, "Available": MemoryBasisAvailable} {
+		if got, err := ParseMemoryBasis(name); err != nil || got != want {
+			t.Errorf("ParseMemoryBasis(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseMemoryBasis("free"); err == nil {
+		t.Error("Expected an error for an unknown basis")
+	}
+}
+
+func TestMemoryThresholds(t *testing.T) {
+	// A cache-heavy system: most of RAM is used, but most of that is cache
+	info := MemoryInfo{Total: 100, Used: 92, Available: 60}
+
+	used := DefaultMemoryThresholds(MemoryBasisUsed)
+	if percent := used.Percent(info); percent != 92 || !used.IsCritical(percent) {
+		t.Errorf("Expected 92%% used to be critical, got %.1f", percent)
+	}
+
+	available := DefaultMemoryThresholds(MemoryBasisAvailable)
+	percent := available.Percent(info)
+	if percent != 60 || available.IsWarning(percent) {
+		t.Errorf("Expected 60%% available to be fine, got %.1f", percent)
+	}
+	if !available.IsWarning(15) || available.IsCritical(15) || !available.IsCritical(10) {
+		t.Error("Expected warning from 20% and critical from 10% available")
+	}
+	if rule := available.AlertRule(); rule != (AlertRule{Component: "Memory", Threshold: 10, Below: true}) {
+		t.Errorf("Unexpected alert rule %+v", rule)
+	}
+}
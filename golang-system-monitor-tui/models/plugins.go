@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PluginOutput is what a plugin executable prints to stdout on every run, as
+// a single JSON object:
+//
+//	{
+//	  "title": "PostgreSQL",
+//	  "metrics": [
+//	    {"label": "connections", "value": 42, "max": 100},
+//	    {"label": "replication lag", "value": 1.5, "unit": "s"},
+//	    {"label": "role", "text": "primary"}
+//	  ]
+//	}
+//
+// The name, error and timestamp are filled in by the collector.
+type PluginOutput struct {
+	Name      string         `json:"-"`               // Configured plugin name
+	Title     string         `json:"title,omitempty"` // Panel title, the plugin name when empty
+	Metrics   []PluginMetric `json:"metrics"`
+	Error     string         `json:"-"` // Why the last run failed; empty on success
+	Timestamp time.Time      `json:"-"`
+}
+
+// PluginMetric is one line of a plugin panel: a gauge when Max is set, the
+// text when Text is set, and the value with its unit otherwise
+type PluginMetric struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value,omitempty"`
+	Max   float64 `json:"max,omitempty"`  // Full scale of a gauge
+	Unit  string  `json:"unit,omitempty"` // Appended to the value, e.g. "ms"
+	Text  string  `json:"text,omitempty"` // Shown instead of the value
+}
+
+// IsGauge reports whether the metric is rendered as a gauge
+func (p PluginMetric) IsGauge() bool {
+	return p.Max > 0 && p.Text == ""
+}
+
+// Percent returns the value as a percentage of Max, clamped to 0-100
+func (p PluginMetric) Percent() float64 {
+	if p.Max <= 0 {
+		return 0
+	}
+	percent := p.Value / p.Max * 100
+	if percent < 0 {
+		return 0
+	}
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
+
+// ParsePluginOutput decodes and validates the stdout of a plugin run
+func ParsePluginOutput(data []byte) (PluginOutput, error) {
+	var output PluginOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return PluginOutput{}, fmt.Errorf("invalid plugin output: %w", err)
+	}
+	for i, metric := range output.Metrics {
+		if metric.Label == "" {
+			return PluginOutput{}, fmt.Errorf("invalid plugin output: metrics[%d]: label is required", i)
+		}
+	}
+	return output, nil
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePluginOutput(t *testing.T) {
+	output, err := ParsePluginOutput([]byte(`{
+		"title": "PostgreSQL",
+		"metrics": [
+			{"label": "connections", "value": 42, "max": 100},
+			{"label": "lag", "value": 1.5, "unit": "s"},
+			{"label": "role", "text": "primary"}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParsePluginOutput failed: %v", err)
+	}
+	if output.Title != "PostgreSQL" || len(output.Metrics) != 3 {
+		t.Fatalf("Unexpected output %+v", output)
+	}
+
+	connections, lag, role := output.Metrics[0], output.Metrics[1], output.Metrics[2]
+	if !connections.IsGauge() || connections.Percent() != 42 {
+		t.Errorf("Expected a 42%% gauge, got %+v", connections)
+	}
+	if lag.IsGauge() || lag.Unit != "s" {
+		t.Errorf("Expected a plain value in seconds, got %+v", lag)
+	}
+	if role.IsGauge() || role.Text != "primary" {
+		t.Errorf("Expected a text metric, got %+v", role)
+	}
+
+	if (PluginMetric{Value: 150, Max: 100}).Percent() != 100 {
+		t.Error("Expected gauges to be clamped at 100%")
+	}
+}
+
+func TestParsePluginOutput_Invalid(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`connections=42`, "invalid plugin output"},
+		{`{"metrics": [{"value": 1}]}`, "label is required"},
+	}
+	for _, test := range tests {
+		if _, err := ParsePluginOutput([]byte(test.data)); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: expected error containing %q, got %v", test.data, test.want, err)
+		}
+	}
+}
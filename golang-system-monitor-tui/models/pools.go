@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// StoragePool is the health of a ZFS pool or a btrfs filesystem
+type StoragePool struct {
+	Name          string    `json:"name"`            // Pool name for ZFS, mountpoint for btrfs
+	Kind          string    `json:"kind"`            // zfs or btrfs
+	State         string    `json:"state"`           // ZFS health such as ONLINE or DEGRADED; OK or ERRORS for btrfs
+	Healthy       bool      `json:"healthy"`         // Whether State needs no attention
+	Errors        uint64    `json:"errors"`          // Device I/O and checksum errors counted by btrfs
+	Scrub         string    `json:"scrub"`           // Last or running scrub, e.g. "repaired 0B with 0 errors"; empty when none ran
+	ScrubProgress float64   `json:"scrub_progress"`  // Percent done of a running scrub, -1 when none runs
+	CompressRatio float64   `json:"compress_ratio"`  // Logical over physical size, 0 when unknown
+	Error         string    `json:"error,omitempty"` // Why the status could not be read, e.g. btrfs needing root
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// Scrubbing reports whether a scrub of the pool is running
+func (p StoragePool) Scrubbing() bool {
+	return p.ScrubProgress >= 0
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// CPUPower is a sample of the per-cluster activity and power draw of an Apple
+// Silicon CPU, as reported by powermetrics on macOS
+type CPUPower struct {
+	Clusters     []CPUCluster `json:"clusters"`
+	CPUWatts     float64      `json:"cpu_watts"`
+	GPUWatts     float64      `json:"gpu_watts"`
+	ANEWatts     float64      `json:"ane_watts"`       // Apple Neural Engine
+	PackageWatts float64      `json:"package_watts"`   // Combined power of the package
+	Error        string       `json:"error,omitempty"` // Why the last sample failed; empty on success
+	Timestamp    time.Time    `json:"timestamp"`
+}
+
+// CPUCluster is a cluster of efficiency or performance cores
+type CPUCluster struct {
+	Name      string  `json:"name"` // Cluster name such as "E" or "P0"
+	Cores     int     `json:"cores"`
+	Frequency float64 `json:"frequency"` // Active frequency in MHz
+	Active    float64 `json:"active"`    // Active residency percentage
+}
+
+// IsEfficiency reports whether the cluster holds efficiency cores
+func (c CPUCluster) IsEfficiency() bool {
+	return strings.HasPrefix(c.Name, "E")
+}
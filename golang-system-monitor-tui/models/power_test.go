@@ -0,0 +1,11 @@
+package models
+
+import "testing"
+
+func TestCPUCluster_IsEfficiency(t *testing.T) {
+	for name, want := range map[string]bool{"E": true, "E0": true, "P0": false, "P1": false} {
+		if got := (CPUCluster{Name: name}).IsEfficiency(); got != want {
+			t.Errorf("IsEfficiency(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+// ProcessDetail is what the process detail page shows about one process. The
+// parts are read one by one when the page opens; a part that could not be
+// read, e.g. the environment of another user's process, is left empty and
+// named in Errors.
+type ProcessDetail struct {
+	PID       int32             `json:"pid"`
+	Name      string            `json:"name"`
+	Cmdline   string            `json:"cmdline"`
+	Environ   []string          `json:"environ,omitempty"`    // KEY=value pairs
+	OpenFiles []string          `json:"open_files,omitempty"` // Paths, by descriptor
+	Threads   int32             `json:"threads"`
+	IO        ProcessIO         `json:"io"`
+	Maps      []ProcessMapping  `json:"maps,omitempty"`   // Mappings grouped by path, most resident first
+	MapCount  int               `json:"map_count"`        // Number of mappings before grouping
+	Errors    map[string]string `json:"errors,omitempty"` // Part that could not be read to why
+}
+
+// ProcessIO counts the reads and writes of a process since it started
+type ProcessIO struct {
+	ReadCount  uint64 `json:"read_count"`
+	WriteCount uint64 `json:"write_count"`
+	ReadBytes  uint64 `json:"read_bytes"`
+	WriteBytes uint64 `json:"write_bytes"`
+}
+
+// ProcessMapping sums the memory mappings of a process backed by one file,
+// or by none for anonymous memory
+type ProcessMapping struct {
+	Path string `json:"path"` // e.g. /usr/lib/libc.so.6, [heap], or empty for anonymous mappings
+	Size uint64 `json:"size"` // Mapped bytes
+	RSS  uint64 `json:"rss"`  // Resident bytes
+}
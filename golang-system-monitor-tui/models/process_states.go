@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ProcessStates counts the processes in states that point at trouble.
+// Zombies have exited but were not reaped by their parent; processes in
+// uninterruptible sleep (D state) wait on I/O, usually a slow or hung disk
+// or network filesystem.
+type ProcessStates struct {
+	Total           int       `json:"total"`
+	Zombie          int       `json:"zombie"`
+	Uninterruptible int       `json:"uninterruptible"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// ProcessStateThresholds are the counts above which the status bar warns
+type ProcessStateThresholds struct {
+	Zombie          int `json:"zombie"`
+	Uninterruptible int `json:"uninterruptible"`
+}
+
+// DefaultProcessStateThresholds returns the default warning counts. A zombie
+// or two and short D-state waits are normal on a busy system.
+func DefaultProcessStateThresholds() ProcessStateThresholds {
+	return ProcessStateThresholds{Zombie: 5, Uninterruptible: 3}
+}
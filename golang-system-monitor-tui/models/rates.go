@@ -0,0 +1,187 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// CounterSample is one reading of a set of cumulative counters, such as the
+// byte counters of a network interface or a disk. Samples with the same key
+// taken at different times are compared to derive per-second rates.
+type CounterSample[K comparable] interface {
+	CounterKey() K
+	SampledAt() time.Time
+}
+
+// Elapsed returns the time from previous to current, 0 when current is not
+// later. Readings of time.Now carry the monotonic clock, which Sub uses when
+// both times have one, so an NTP step or a clock set by hand between two
+// samples neither reverses nor stretches the interval; times decoded from
+// JSON only have the wall clock to go by.
+func Elapsed(previous, current time.Time) time.Duration {
+	elapsed := current.Sub(previous)
+	if elapsed < 0 {
+		return 0
+	}
+	return elapsed
+}
+
+// CounterRate returns how fast a cumulative counter grew from previous to
+// current over elapsed, in units per second. A counter that went backwards
+// wrapped around or was reset (a driver reload, a device re-plugged), so the
+// real increase is unknown and the rate is reported as 0 rather than as a
+// huge bogus spike. A non-positive elapsed time also yields 0.
+func CounterRate(previous, current uint64, elapsed time.Duration) float64 {
+	return RolloverZero.Rate(previous, current, elapsed, 0)
+}
+
+// RolloverStrategy decides the rate of a counter that went backwards between
+// two samples
+type RolloverStrategy int
+
+const (
+	// RolloverZero reports 0, the real increase being unknown
+	RolloverZero RolloverStrategy = iota
+	// RolloverWrap assumes the counter wrapped once: at 2^32 when the previous
+	// value fit in 32 bits, at 2^64 otherwise. It suits NICs with 32-bit
+	// counters, which wrap within a minute at gigabit speeds; a counter that
+	// was reset shows as a spike instead.
+	RolloverWrap
+	// RolloverHold repeats the rate of the previous interval
+	RolloverHold
+)
+
+// rolloverNames are the names of the strategies, by value
+var rolloverNames = []string{"zero", "wrap", "hold"}
+
+// ParseRolloverStrategy parses "zero", "wrap" or "hold"; empty selects zero
+func ParseRolloverStrategy(name string) (RolloverStrategy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return RolloverZero, nil
+	}
+	for strategy, strategyName := range rolloverNames {
+		if name == strategyName {
+			return RolloverStrategy(strategy), nil
+		}
+	}
+	return RolloverZero, fmt.Errorf("unknown rollover strategy %q (want zero, wrap or hold)", name)
+}
+
+// String returns the name of the strategy
+func (s RolloverStrategy) String() string {
+	if s < 0 || int(s) >= len(rolloverNames) {
+		return fmt.Sprintf("RolloverStrategy(%d)", int(s))
+	}
+	return rolloverNames[s]
+}
+
+// Rate returns how fast a counter grew from previous to current over
+// elapsed, like CounterRate, applying the strategy when it went backwards.
+// last is the rate of the previous interval, which RolloverHold repeats.
+func (s RolloverStrategy) Rate(previous, current uint64, elapsed time.Duration, last float64) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	if current >= previous {
+		return float64(current-previous) / elapsed.Seconds()
+	}
+	switch s {
+	case RolloverWrap:
+		if previous <= math.MaxUint32 {
+			return float64(current+(math.MaxUint32-previous)+1) / elapsed.Seconds()
+		}
+		// Unsigned subtraction wraps at 2^64 by itself
+		return float64(current-previous) / elapsed.Seconds()
+	case RolloverHold:
+		return last
+	}
+	return 0
+}
+
+// CounterRates pairs every current sample with the previous sample of the
+// same key and calls rate with both and the time between them. Keys missing
+// from previous, and pairs whose timestamps did not move forward, are left
+// out of the result.
+func CounterRates[S CounterSample[K], K comparable, R any](previous, current []S, rate func(prev, curr S, elapsed time.Duration) R) map[K]R {
+	rates := make(map[K]R, len(current))
+
+	prevMap := make(map[K]S, len(previous))
+	for _, prev := range previous {
+		prevMap[prev.CounterKey()] = prev
+	}
+
+	for _, curr := range current {
+		prev, exists := prevMap[curr.CounterKey()]
+		if !exists {
+			continue
+		}
+		elapsed := Elapsed(prev.SampledAt(), curr.SampledAt())
+		if elapsed == 0 {
+			continue
+		}
+		rates[curr.CounterKey()] = rate(prev, curr, elapsed)
+	}
+
+	return rates
+}
+
+// CounterKey identifies a network sample by its interface name
+func (n NetworkInfo) CounterKey() string {
+	return n.Interface
+}
+
+// SampledAt returns when the network counters were read
+func (n NetworkInfo) SampledAt() time.Time {
+	return n.Timestamp
+}
+
+// CalculateNetworkRates calculates per-interface transfer rates between two
+// network measurements
+func CalculateNetworkRates(previous, current []NetworkInfo) map[string]NetworkStats {
+	return RolloverZero.NetworkRates(previous, current, nil)
+}
+
+// NetworkRates calculates per-interface transfer rates between two network
+// measurements, applying the strategy to counters that went backwards. last
+// holds the rates of the previous interval, which RolloverHold repeats.
+func (s RolloverStrategy) NetworkRates(previous, current []NetworkInfo, last map[string]NetworkStats) map[string]NetworkStats {
+	return CounterRates(previous, current, func(prev, curr NetworkInfo, elapsed time.Duration) NetworkStats {
+		held := last[curr.Interface]
+		return NetworkStats{
+			SendRate: s.Rate(prev.BytesSent, curr.BytesSent, elapsed, held.SendRate),
+			RecvRate: s.Rate(prev.BytesRecv, curr.BytesRecv, elapsed, held.RecvRate),
+		}
+	})
+}
+
+// RateUnit selects whether transfer rates are displayed in bytes or bits per
+// second. Rates are always stored in bytes per second.
+type RateUnit int
+
+const (
+	BytesPerSecond RateUnit = iota
+	BitsPerSecond
+)
+
+// ParseRateUnit parses "bytes" or "bits"
+func ParseRateUnit(name string) (RateUnit, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "bytes":
+		return BytesPerSecond, nil
+	case "bits":
+		return BitsPerSecond, nil
+	default:
+		return BytesPerSecond, fmt.Errorf("unknown rate unit %q (want bytes or bits)", name)
+	}
+}
+
+// Toggle returns the other unit
+func (u RateUnit) Toggle() RateUnit {
+	if u == BitsPerSecond {
+		return BytesPerSecond
+	}
+	return BitsPerSecond
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCounterRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous uint64
+		current  uint64
+		elapsed  time.Duration
+		want     float64
+	}{
+		{"growth", 1000, 3000, 2 * time.Second, 1000},
+		{"unchanged", 500, 500, time.Second, 0},
+		{"sub-second", 0, 100, 500 * time.Millisecond, 200},
+		{"rollover", 4294967295, 1000, time.Second, 0},
+		{"reset", 1000, 0, time.Second, 0},
+		{"no elapsed time", 1000, 2000, 0, 0},
+		{"clock went backwards", 1000, 2000, -time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CounterRate(tt.previous, tt.current, tt.elapsed); got != tt.want {
+				t.Errorf("Expected %f, got %f", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestElapsed(t *testing.T) {
+	now := time.Now()
+	if got := Elapsed(now, now.Add(1500*time.Millisecond)); got != 1500*time.Millisecond {
+		t.Errorf("Expected 1.5s, got %v", got)
+	}
+	// Without a monotonic reading a clock set back shows as time going backwards
+	if got := Elapsed(now, now.Add(-time.Hour).Round(0)); got != 0 {
+		t.Errorf("Expected 0 for a clock set back, got %v", got)
+	}
+}
+
+func TestCalculateNetworkRates(t *testing.T) {
+	base := time.Now()
+	previous := []NetworkInfo{
+		{Interface: "eth0", BytesSent: 1000, BytesRecv: 2000, Timestamp: base},
+		{Interface: "wlan0", BytesSent: 5000, BytesRecv: 5000, Timestamp: base},
+		{Interface: "lo", BytesSent: 100, BytesRecv: 100, Timestamp: base},
+	}
+	current := []NetworkInfo{
+		{Interface: "eth0", BytesSent: 3000, BytesRecv: 6000, Timestamp: base.Add(2 * time.Second)},
+		{Interface: "wlan0", BytesSent: 10, BytesRecv: 7000, Timestamp: base.Add(2 * time.Second)},
+		{Interface: "lo", BytesSent: 200, BytesRecv: 200, Timestamp: base},
+		{Interface: "tun0", BytesSent: 100, BytesRecv: 100, Timestamp: base.Add(2 * time.Second)},
+	}
+
+	rates := CalculateNetworkRates(previous, current)
+
+	if got := rates["eth0"]; got.SendRate != 1000 || got.RecvRate != 2000 {
+		t.Errorf("Expected eth0 at 1000/2000 B/s, got %+v", got)
+	}
+	if got := rates["wlan0"]; got.SendRate != 0 || got.RecvRate != 1000 {
+		t.Errorf("Expected the rolled-over wlan0 send counter to read 0, got %+v", got)
+	}
+	if _, exists := rates["lo"]; exists {
+		t.Error("Expected no rate for a sample taken at the same time")
+	}
+	if _, exists := rates["tun0"]; exists {
+		t.Error("Expected no rate for an interface without a previous sample")
+	}
+	if len(rates) != 2 {
+		t.Errorf("Expected 2 rates, got %d", len(rates))
+	}
+}
+
+type diskSample struct {
+	device string
+	reads  uint64
+	at     time.Time
+}
+
+func (d diskSample) CounterKey() string   { return d.device }
+func (d diskSample) SampledAt() time.Time { return d.at }
+
+func TestCounterRates_OtherSamples(t *testing.T) {
+	base := time.Now()
+	previous := []diskSample{{"sda", 100, base}, {"sdb", 50, base}}
+	current := []diskSample{{"sda", 400, base.Add(3 * time.Second)}, {"sdb", 20, base.Add(time.Second)}}
+
+	rates := CounterRates(previous, current, func(prev, curr diskSample, elapsed time.Duration) float64 {
+		return CounterRate(prev.reads, curr.reads, elapsed)
+	})
+
+	if rates["sda"] != 100 {
+		t.Errorf("Expected 100 reads/s on sda, got %f", rates["sda"])
+	}
+	if rate, exists := rates["sdb"]; !exists || rate != 0 {
+		t.Errorf("Expected a 0 rate for the reset sdb counter, got %f (present: %v)", rate, exists)
+	}
+}
+
+func TestRolloverStrategy_Rate(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy RolloverStrategy
+		previous uint64
+		current  uint64
+		want     float64
+	}{
+		{"zero growth", RolloverZero, 1000, 3000, 2000},
+		{"zero wrap", RolloverZero, math.MaxUint32 - 99, 900, 0},
+		{"32-bit wrap", RolloverWrap, math.MaxUint32 - 99, 900, 1000},
+		{"64-bit wrap", RolloverWrap, math.MaxUint64 - 99, 900, 1000},
+		{"wrap growth", RolloverWrap, 1000, 3000, 2000},
+		{"hold wrap", RolloverHold, math.MaxUint32 - 99, 900, 42},
+		{"hold growth", RolloverHold, 1000, 3000, 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.strategy.Rate(tt.previous, tt.current, time.Second, 42); got != tt.want {
+				t.Errorf("Expected %f, got %f", tt.want, got)
+			}
+		})
+	}
+	if got := RolloverWrap.Rate(1000, 2000, 0, 42); got != 0 {
+		t.Errorf("Expected 0 without elapsed time, got %f", got)
+	}
+}
+
+func TestRolloverStrategy_NetworkRates(t *testing.T) {
+	base := time.Now()
+	previous := []NetworkInfo{{Interface: "eth0", BytesSent: 5000, BytesRecv: 1000, Timestamp: base}}
+	current := []NetworkInfo{{Interface: "eth0", BytesSent: 100, BytesRecv: 3000, Timestamp: base.Add(time.Second)}}
+	last := map[string]NetworkStats{"eth0": {SendRate: 700, RecvRate: 900}}
+
+	rates := RolloverHold.NetworkRates(previous, current, last)
+	if want := (NetworkStats{SendRate: 700, RecvRate: 2000}); rates["eth0"] != want {
+		t.Errorf("Expected the send rate held at %+v, got %+v", want, rates["eth0"])
+	}
+	// Without a previous rate there is nothing to hold
+	if rates := RolloverHold.NetworkRates(previous, current, nil); rates["eth0"].SendRate != 0 {
+		t.Errorf("Expected 0 without a previous rate, got %+v", rates["eth0"])
+	}
+}
+
+func TestParseRolloverStrategy(t *testing.T) {
+	for name, want := range map[string]RolloverStrategy{"": RolloverZero, "zero": RolloverZero, "Wrap": RolloverWrap, "hold": RolloverHold} {
+		if strategy, err := ParseRolloverStrategy(name); err != nil || strategy != want {
+			t.Errorf("ParseRolloverStrategy(%q) = %v, %v", name, strategy, err)
+		}
+	}
+	if _, err := ParseRolloverStrategy("clamp"); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+	if RolloverWrap.String() != "wrap" {
+		t.Errorf("Expected wrap, got %s", RolloverWrap)
+	}
+}
+
+func TestParseRateUnit(t *testing.T) {
+	for name, want := range map[string]RateUnit{"": BytesPerSecond, "bytes": BytesPerSecond, "Bits": BitsPerSecond} {
+		if unit, err := ParseRateUnit(name); err != nil || unit != want {
+			t.Errorf("ParseRateUnit(%q) = %v, %v", name, unit, err)
+		}
+	}
+	if _, err := ParseRateUnit("baud"); err == nil {
+		t.Error("Expected an error for an unknown unit")
+	}
+	if BytesPerSecond.Toggle() != BitsPerSecond || BitsPerSecond.Toggle() != BytesPerSecond {
+		t.Error("Expected Toggle to switch between bytes and bits")
+	}
+}
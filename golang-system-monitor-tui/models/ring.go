@@ -0,0 +1,111 @@
+package models
+
+import "iter"
+
+// Ring is a fixed-size history of values: once full, pushing a value drops
+// the oldest one. Appending and reading are O(1) without copying the kept
+// values. Like a slice, a Ring is a small header over shared storage; Push
+// returns the updated ring, and earlier copies keep seeing their own values
+// until the storage wraps around to overwrite them.
+type Ring[T any] struct {
+	values []T
+	start  int // Index of the oldest value
+	size   int // Number of values kept
+}
+
+// NewRing creates an empty ring keeping up to capacity values
+func NewRing[T any](capacity int) Ring[T] {
+	return Ring[T]{values: make([]T, max(capacity, 0))}
+}
+
+// Push appends value, dropping the oldest value when the ring is full, and
+// returns the updated ring
+func (r Ring[T]) Push(value T) Ring[T] {
+	if len(r.values) == 0 {
+		return r
+	}
+	if r.size < len(r.values) {
+		r.values[(r.start+r.size)%len(r.values)] = value
+		r.size++
+		return r
+	}
+	r.values[r.start] = value
+	r.start = (r.start + 1) % len(r.values)
+	return r
+}
+
+// Len returns the number of values kept
+func (r Ring[T]) Len() int {
+	return r.size
+}
+
+// Cap returns the number of values the ring keeps at most
+func (r Ring[T]) Cap() int {
+	return len(r.values)
+}
+
+// At returns the i-th value, oldest first; it panics when i is out of range
+func (r Ring[T]) At(i int) T {
+	if i < 0 || i >= r.size {
+		panic("ring index out of range")
+	}
+	return r.values[(r.start+i)%len(r.values)]
+}
+
+// Set replaces the i-th value, oldest first; it panics when i is out of
+// range. Copies sharing the storage see the new value.
+func (r Ring[T]) Set(i int, value T) {
+	if i < 0 || i >= r.size {
+		panic("ring index out of range")
+	}
+	r.values[(r.start+i)%len(r.values)] = value
+}
+
+// Last returns the newest value, and false when the ring is empty
+func (r Ring[T]) Last() (T, bool) {
+	if r.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.At(r.size - 1), true
+}
+
+// All iterates over the values with their index, oldest first
+func (r Ring[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < r.size; i++ {
+			if !yield(i, r.values[(r.start+i)%len(r.values)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward iterates over the values with their index, newest first
+func (r Ring[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := r.size - 1; i >= 0; i-- {
+			if !yield(i, r.values[(r.start+i)%len(r.values)]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a copy of the values, oldest first
+func (r Ring[T]) Values() []T {
+	return r.AppendTo(make([]T, 0, r.size))
+}
+
+// AppendTo appends the values, oldest first, to dst and returns it
+func (r Ring[T]) AppendTo(dst []T) []T {
+	if r.size == 0 {
+		return dst
+	}
+	end := r.start + r.size
+	if end <= len(r.values) {
+		return append(dst, r.values[r.start:end]...)
+	}
+	dst = append(dst, r.values[r.start:]...)
+	return append(dst, r.values[:end-len(r.values)]...)
+}
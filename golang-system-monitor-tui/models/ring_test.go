@@ -0,0 +1,86 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRing_Push(t *testing.T) {
+	ring := NewRing[int](3)
+	if ring.Len() != 0 || ring.Cap() != 3 {
+		t.Fatalf("Expected an empty ring of 3, got %d of %d", ring.Len(), ring.Cap())
+	}
+	if _, ok := ring.Last(); ok {
+		t.Error("Expected no last value in an empty ring")
+	}
+
+	for i := 1; i <= 5; i++ {
+		ring = ring.Push(i)
+	}
+	if got := ring.Values(); !reflect.DeepEqual(got, []int{3, 4, 5}) {
+		t.Errorf("Expected the newest 3 values, got %v", got)
+	}
+	if ring.At(0) != 3 || ring.At(2) != 5 {
+		t.Errorf("Expected oldest first, got %d and %d", ring.At(0), ring.At(2))
+	}
+	if last, ok := ring.Last(); !ok || last != 5 {
+		t.Errorf("Expected 5 as the last value, got %d", last)
+	}
+}
+
+func TestRing_Iteration(t *testing.T) {
+	ring := NewRing[string](4)
+	for _, value := range []string{"a", "b", "c", "d", "e", "f"} {
+		ring = ring.Push(value)
+	}
+
+	var forward, backward []string
+	for i, value := range ring.All() {
+		if value != ring.At(i) {
+			t.Errorf("Expected index %d to hold %q, got %q", i, ring.At(i), value)
+		}
+		forward = append(forward, value)
+	}
+	for _, value := range ring.Backward() {
+		backward = append(backward, value)
+		if len(backward) == 2 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(forward, []string{"c", "d", "e", "f"}) {
+		t.Errorf("Expected oldest first, got %v", forward)
+	}
+	if !reflect.DeepEqual(backward, []string{"f", "e"}) {
+		t.Errorf("Expected newest first until the break, got %v", backward)
+	}
+	if got := ring.AppendTo([]string{"x"}); !reflect.DeepEqual(got, []string{"x", "c", "d", "e", "f"}) {
+		t.Errorf("Expected the values appended in order, got %v", got)
+	}
+}
+
+func TestRing_Copies(t *testing.T) {
+	earlier := NewRing[int](4).Push(1).Push(2)
+	later := earlier.Push(3)
+	if got := earlier.Values(); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("Expected the earlier copy to keep its values, got %v", got)
+	}
+	if got := later.Values(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Expected the pushed value, got %v", got)
+	}
+}
+
+func TestRing_ZeroCapacity(t *testing.T) {
+	var ring Ring[int]
+	ring = ring.Push(1)
+	if ring.Len() != 0 || len(ring.Values()) != 0 {
+		t.Errorf("Expected a ring without capacity to keep nothing, got %v", ring.Values())
+	}
+}
+
+func TestRing_Set(t *testing.T) {
+	ring := NewRing[int](3).Push(1).Push(2).Push(3).Push(4)
+	ring.Set(0, 20)
+	if got := ring.Values(); !reflect.DeepEqual(got, []int{20, 3, 4}) {
+		t.Errorf("Expected the oldest value replaced, got %v", got)
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// SelfStats is the resource usage of the monitor process itself
+type SelfStats struct {
+	CPUPercent float64       // CPU usage since the previous sample, of one core
+	RSS        uint64        // Resident set size in bytes
+	Goroutines int           // Running goroutines
+	HeapAlloc  uint64        // Bytes of allocated heap objects
+	HeapSys    uint64        // Bytes of heap memory obtained from the OS
+	NumGC      uint32        // Completed GC cycles
+	LastPause  time.Duration // Stop-the-world pause of the last GC cycle
+	TotalPause time.Duration // Cumulative stop-the-world pause of all GC cycles
+	Error      string        // Why the process statistics could not be read; empty on success
+	Timestamp  time.Time
+}
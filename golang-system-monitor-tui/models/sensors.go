@@ -0,0 +1,178 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SensorKind categorizes where a temperature reading comes from
+type SensorKind int
+
+const (
+	SensorCPU SensorKind = iota
+	SensorGPU
+	SensorNVMe
+	SensorChassis
+	SensorOther
+)
+
+// String returns a short human-readable label for the sensor kind
+func (k SensorKind) String() string {
+	switch k {
+	case SensorCPU:
+		return "CPU"
+	case SensorGPU:
+		return "GPU"
+	case SensorNVMe:
+		return "NVMe"
+	case SensorChassis:
+		return "Chassis"
+	default:
+		return "Other"
+	}
+}
+
+// SensorInfo represents a single temperature sensor reading in Celsius
+type SensorInfo struct {
+	Key         string     `json:"key"`
+	Kind        SensorKind `json:"kind"`
+	Temperature float64    `json:"temperature"`
+	High        float64    `json:"high"`     // Hardware reported high threshold, 0 if unknown
+	Critical    float64    `json:"critical"` // Hardware reported critical threshold, 0 if unknown
+}
+
+// SensorThresholds holds the warning and critical temperatures for a sensor
+type SensorThresholds struct {
+	Warning  float64 `json:"warning"`
+	Critical float64 `json:"critical"`
+}
+
+// DefaultSensorThresholds returns fallback thresholds per sensor kind, used when
+// the hardware does not report its own limits
+func DefaultSensorThresholds() map[SensorKind]SensorThresholds {
+	return map[SensorKind]SensorThresholds{
+		SensorCPU:     {Warning: 80, Critical: 95},
+		SensorGPU:     {Warning: 85, Critical: 95},
+		SensorNVMe:    {Warning: 70, Critical: 80},
+		SensorChassis: {Warning: 50, Critical: 65},
+		SensorOther:   {Warning: 80, Critical: 95},
+	}
+}
+
+// Thresholds returns the effective thresholds for the sensor. The configured
+// thresholds of its kind win; a kind without them uses the limits reported by
+// the hardware over the per-kind defaults.
+func (s SensorInfo) Thresholds(configured map[SensorKind]SensorThresholds) SensorThresholds {
+	if thresholds, exists := configured[s.Kind]; exists {
+		return thresholds
+	}
+	thresholds := DefaultSensorThresholds()[s.Kind]
+	if s.High > 0 {
+		thresholds.Warning = s.High
+	}
+	if s.Critical > 0 {
+		thresholds.Critical = s.Critical
+	}
+	return thresholds
+}
+
+// ClassifySensor derives the sensor kind from a platform sensor key
+func ClassifySensor(key string) SensorKind {
+	k := strings.ToLower(key)
+	switch {
+	case strings.Contains(k, "nvme"):
+		return SensorNVMe
+	case strings.Contains(k, "gpu") || strings.Contains(k, "amdgpu") ||
+		strings.Contains(k, "nouveau") || strings.Contains(k, "radeon") ||
+		strings.Contains(k, "nvidia"):
+		return SensorGPU
+	case strings.Contains(k, "coretemp") || strings.Contains(k, "k10temp") ||
+		strings.Contains(k, "k8temp") || strings.Contains(k, "zenpower") ||
+		strings.Contains(k, "cpu") || strings.Contains(k, "package") ||
+		strings.Contains(k, "tctl") || strings.Contains(k, "tdie"):
+		return SensorCPU
+	case strings.Contains(k, "acpitz") || strings.Contains(k, "pch") ||
+		strings.Contains(k, "chassis") || strings.Contains(k, "systin") ||
+		strings.Contains(k, "ambient") || strings.Contains(k, "board"):
+		return SensorChassis
+	default:
+		return SensorOther
+	}
+}
+
+// HottestSensor returns the sensor with the highest temperature
+func HottestSensor(sensors []SensorInfo) (SensorInfo, bool) {
+	if len(sensors) == 0 {
+		return SensorInfo{}, false
+	}
+	hottest := sensors[0]
+	for _, sensor := range sensors[1:] {
+		if sensor.Temperature > hottest.Temperature {
+			hottest = sensor
+		}
+	}
+	return hottest, true
+}
+
+// TemperatureUnit selects how temperatures are displayed and configured.
+// Readings and thresholds are always stored in Celsius.
+type TemperatureUnit int
+
+const (
+	Celsius TemperatureUnit = iota
+	Fahrenheit
+)
+
+// ParseTemperatureUnit parses "celsius"/"c" or "fahrenheit"/"f"
+func ParseTemperatureUnit(name string) (TemperatureUnit, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "celsius", "c":
+		return Celsius, nil
+	case "fahrenheit", "f":
+		return Fahrenheit, nil
+	default:
+		return Celsius, fmt.Errorf("unknown temperature unit %q (want celsius or fahrenheit)", name)
+	}
+}
+
+// Symbol returns the unit symbol, e.g. "°C"
+func (u TemperatureUnit) Symbol() string {
+	if u == Fahrenheit {
+		return "°F"
+	}
+	return "°C"
+}
+
+// FromCelsius converts a Celsius temperature to this unit
+func (u TemperatureUnit) FromCelsius(celsius float64) float64 {
+	if u == Fahrenheit {
+		return celsius*9/5 + 32
+	}
+	return celsius
+}
+
+// ToCelsius converts a temperature in this unit to Celsius
+func (u TemperatureUnit) ToCelsius(value float64) float64 {
+	if u == Fahrenheit {
+		return (value - 32) * 5 / 9
+	}
+	return value
+}
+
+// Toggle returns the other unit
+func (u TemperatureUnit) Toggle() TemperatureUnit {
+	if u == Fahrenheit {
+		return Celsius
+	}
+	return Fahrenheit
+}
+
+// ParseSensorKind parses a lowercase sensor kind name such as "cpu" or "nvme"
+func ParseSensorKind(name string) (SensorKind, bool) {
+	for kind := SensorCPU; kind <= SensorOther; kind++ {
+		if strings.EqualFold(kind.String(), name) {
+			return kind, true
+		}
+	}
+	return SensorOther, false
+}
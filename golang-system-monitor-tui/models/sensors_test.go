@@ -0,0 +1,130 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestClassifySensor(t *testing.T) {
+	tests := []struct {
+		key  string
+		want SensorKind
+	}{
+		{"coretemp_package_id_0", SensorCPU},
+		{"k10temp_tctl", SensorCPU},
+		{"amdgpu_edge", SensorGPU},
+		{"nvidia_gpu0", SensorGPU},
+		{"nvme_composite", SensorNVMe},
+		{"acpitz", SensorChassis},
+		{"iwlwifi_1", SensorOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := ClassifySensor(tt.key); got != tt.want {
+				t.Errorf("ClassifySensor(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSensorKind_String(t *testing.T) {
+	if SensorGPU.String() != "GPU" {
+		t.Errorf("Expected GPU label, got %q", SensorGPU.String())
+	}
+	if SensorKind(99).String() != "Other" {
+		t.Errorf("Expected unknown kinds to be labelled Other, got %q", SensorKind(99).String())
+	}
+}
+
+func TestSensorInfo_Thresholds(t *testing.T) {
+	defaults := DefaultSensorThresholds()
+	configured := map[SensorKind]SensorThresholds{SensorCPU: {Warning: 70, Critical: 90}}
+
+	t.Run("kind defaults", func(t *testing.T) {
+		sensor := SensorInfo{Key: "nvme_composite", Kind: SensorNVMe, Temperature: 40}
+		got := sensor.Thresholds(configured)
+		if got != defaults[SensorNVMe] {
+			t.Errorf("Expected NVMe defaults %v, got %v", defaults[SensorNVMe], got)
+		}
+	})
+
+	t.Run("hardware limits beat defaults", func(t *testing.T) {
+		sensor := SensorInfo{Key: "amdgpu_edge", Kind: SensorGPU, Temperature: 40, High: 84, Critical: 100}
+		got := sensor.Thresholds(configured)
+		if got.Warning != 84 || got.Critical != 100 {
+			t.Errorf("Expected hardware thresholds 84/100, got %v", got)
+		}
+	})
+
+	t.Run("configured kind beats hardware limits", func(t *testing.T) {
+		sensor := SensorInfo{Key: "coretemp", Kind: SensorCPU, Temperature: 40, High: 84, Critical: 100}
+		got := sensor.Thresholds(configured)
+		if got != configured[SensorCPU] {
+			t.Errorf("Expected configured thresholds %v, got %v", configured[SensorCPU], got)
+		}
+	})
+
+	t.Run("missing kind falls back", func(t *testing.T) {
+		sensor := SensorInfo{Kind: SensorGPU}
+		got := sensor.Thresholds(nil)
+		if got != defaults[SensorGPU] {
+			t.Errorf("Expected built-in GPU defaults, got %v", got)
+		}
+	})
+}
+
+func TestHottestSensor(t *testing.T) {
+	if _, ok := HottestSensor(nil); ok {
+		t.Error("Expected no hottest sensor for empty input")
+	}
+
+	sensors := []SensorInfo{
+		{Key: "cpu", Kind: SensorCPU, Temperature: 55},
+		{Key: "gpu", Kind: SensorGPU, Temperature: 71},
+		{Key: "nvme", Kind: SensorNVMe, Temperature: 44},
+	}
+	hottest, ok := HottestSensor(sensors)
+	if !ok {
+		t.Fatal("Expected a hottest sensor")
+	}
+	if hottest.Key != "gpu" {
+		t.Errorf("Expected gpu to be hottest, got %s", hottest.Key)
+	}
+}
+
+func TestTemperatureUnit(t *testing.T) {
+	if unit, err := ParseTemperatureUnit("Fahrenheit"); err != nil || unit != Fahrenheit {
+		t.Errorf("Expected Fahrenheit, got %v (%v)", unit, err)
+	}
+	if unit, err := ParseTemperatureUnit(""); err != nil || unit != Celsius {
+		t.Errorf("Expected Celsius by default, got %v (%v)", unit, err)
+	}
+	if _, err := ParseTemperatureUnit("kelvin"); err == nil {
+		t.Error("Expected error for unsupported unit")
+	}
+
+	if got := Fahrenheit.FromCelsius(100); got != 212 {
+		t.Errorf("Expected 212°F, got %.1f", got)
+	}
+	if got := Fahrenheit.ToCelsius(176); got != 80 {
+		t.Errorf("Expected 80°C, got %.1f", got)
+	}
+	if got := Celsius.FromCelsius(42); got != 42 {
+		t.Errorf("Expected Celsius to be unchanged, got %.1f", got)
+	}
+	if Celsius.Toggle() != Fahrenheit || Fahrenheit.Toggle() != Celsius {
+		t.Error("Expected Toggle to switch units")
+	}
+	if Fahrenheit.Symbol() != "°F" || Celsius.Symbol() != "°C" {
+		t.Error("Unexpected unit symbols")
+	}
+}
+
+func TestParseSensorKind(t *testing.T) {
+	if kind, ok := ParseSensorKind("nvme"); !ok || kind != SensorNVMe {
+		t.Errorf("Expected NVMe, got %v", kind)
+	}
+	if _, ok := ParseSensorKind("psu"); ok {
+		t.Error("Expected unknown kind to be rejected")
+	}
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"sync"
+	"time"
+)
+
+// SinkStatus reports the delivery health of an output sink such as a webhook or exporter
+type SinkStatus struct {
+	Name         string    `json:"name"`
+	Delivered    int       `json:"delivered"`    // Successful deliveries
+	Errors       int       `json:"errors"`       // Failed deliveries
+	QueueDepth   int       `json:"queue_depth"`  // Deliveries waiting or in flight
+	Dropped      int       `json:"dropped"`      // Events discarded because the queue was full
+	LastSuccess  time.Time `json:"last_success"` // Zero until the first successful delivery
+	LastError    time.Time `json:"last_error"`   // Zero until the first failed delivery
+	LastErrorMsg string    `json:"last_error_msg"`
+}
+
+// Healthy returns whether the most recent delivery succeeded, or none has failed yet
+func (s SinkStatus) Healthy() bool {
+	return s.LastError.IsZero() || s.LastSuccess.After(s.LastError)
+}
+
+// SinkTracker records delivery outcomes for a sink and is safe for concurrent use
+type SinkTracker struct {
+	mu     sync.Mutex
+	status SinkStatus
+}
+
+// NewSinkTracker creates a tracker for the named sink
+func NewSinkTracker(name string) *SinkTracker {
+	return &SinkTracker{
+		status: SinkStatus{Name: name},
+	}
+}
+
+// Enqueue records a delivery entering the queue
+func (t *SinkTracker) Enqueue() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.QueueDepth++
+}
+
+// Dequeue records a delivery leaving the queue, whatever its outcome
+func (t *SinkTracker) Dequeue() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.status.QueueDepth > 0 {
+		t.status.QueueDepth--
+	}
+}
+
+// RecordSuccess records a successful delivery
+func (t *SinkTracker) RecordSuccess(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.Delivered++
+	t.status.LastSuccess = now
+}
+
+// RecordError records a failed delivery
+func (t *SinkTracker) RecordError(now time.Time, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.Errors++
+	t.status.LastError = now
+	if err != nil {
+		t.status.LastErrorMsg = err.Error()
+	}
+}
+
+// Status returns a snapshot of the sink's delivery health
+func (t *SinkTracker) Status() SinkStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSinkTracker(t *testing.T) {
+	tracker := NewSinkTracker("webhook")
+	now := time.Now()
+
+	status := tracker.Status()
+	if status.Name != "webhook" || !status.Healthy() {
+		t.Errorf("Expected healthy sink before any delivery, got %+v", status)
+	}
+
+	tracker.Enqueue()
+	tracker.Enqueue()
+	if depth := tracker.Status().QueueDepth; depth != 2 {
+		t.Errorf("Expected queue depth 2, got %d", depth)
+	}
+
+	tracker.RecordSuccess(now)
+	tracker.Dequeue()
+	tracker.RecordError(now.Add(time.Second), errors.New("connection refused"))
+	tracker.Dequeue()
+	tracker.Dequeue() // Never goes negative
+
+	status = tracker.Status()
+	if status.Delivered != 1 || status.Errors != 1 || status.QueueDepth != 0 {
+		t.Errorf("Unexpected counters: %+v", status)
+	}
+	if status.Healthy() || status.LastErrorMsg != "connection refused" {
+		t.Errorf("Expected unhealthy sink after a failed delivery, got %+v", status)
+	}
+
+	tracker.RecordSuccess(now.Add(2 * time.Second))
+	if !tracker.Status().Healthy() {
+		t.Error("Expected sink to recover after a successful delivery")
+	}
+}
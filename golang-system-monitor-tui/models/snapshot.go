@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Snapshot is a point-in-time view of all monitored resources, handed to exporters
+type Snapshot struct {
+	Timestamp   time.Time               `json:"timestamp"`
+	CPU         CPUInfo                 `json:"cpu"`
+	Memory      MemoryInfo              `json:"memory"`
+	Disks       []DiskInfo              `json:"disks"`
+	Network     []NetworkInfo           `json:"network"`
+	Rates       map[string]NetworkStats `json:"rates"`                 // Transfer rates keyed by interface name
+	Annotations []Annotation            `json:"annotations,omitempty"` // Marks on the timeline, saved by the snapshot export only
+}
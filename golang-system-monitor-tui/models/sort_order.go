@@ -0,0 +1,162 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DiskSort orders the filesystems of the Disk panel
+type DiskSort int
+
+const (
+	// DiskSortMounted keeps the order the filesystems were first seen in
+	DiskSortMounted DiskSort = iota
+	// DiskSortMountpoint orders by mountpoint, alphabetically
+	DiskSortMountpoint
+	// DiskSortUsage puts the fullest filesystems first
+	DiskSortUsage
+	// DiskSortSize puts the largest filesystems first
+	DiskSortSize
+)
+
+// diskSortNames are the names of the disk orders, by value
+var diskSortNames = []string{"mounted", "mountpoint", "usage", "size"}
+
+// ParseDiskSort parses "mounted", "mountpoint", "usage" or "size"; empty
+// selects mounted
+func ParseDiskSort(name string) (DiskSort, error) {
+	order, err := parseSortName(diskSortNames, name)
+	if err != nil {
+		return DiskSortMounted, fmt.Errorf("unknown disk order %q (want %s)", name, strings.Join(diskSortNames, ", "))
+	}
+	return DiskSort(order), nil
+}
+
+// String returns the name ParseDiskSort accepts
+func (s DiskSort) String() string {
+	if s < 0 || int(s) >= len(diskSortNames) {
+		return fmt.Sprintf("DiskSort(%d)", int(s))
+	}
+	return diskSortNames[s]
+}
+
+// Next returns the order after s, wrapping around to the first
+func (s DiskSort) Next() DiskSort {
+	return DiskSort((int(s) + 1) % len(diskSortNames))
+}
+
+// Sort orders filesystems in place. Ties keep their order, so rows of equal
+// values do not swap places from one refresh to the next.
+func (s DiskSort) Sort(filesystems []DiskInfo) {
+	switch s {
+	case DiskSortMountpoint:
+		sort.SliceStable(filesystems, func(i, j int) bool {
+			return filesystems[i].Mountpoint < filesystems[j].Mountpoint
+		})
+	case DiskSortUsage:
+		sort.SliceStable(filesystems, func(i, j int) bool {
+			return filesystems[i].UsedPercent > filesystems[j].UsedPercent
+		})
+	case DiskSortSize:
+		sort.SliceStable(filesystems, func(i, j int) bool {
+			return filesystems[i].Total > filesystems[j].Total
+		})
+	}
+}
+
+// InterfaceSort orders the interfaces of the Network panel
+type InterfaceSort int
+
+const (
+	// InterfaceSortSystem keeps the order the system lists the interfaces in
+	InterfaceSortSystem InterfaceSort = iota
+	// InterfaceSortName orders by interface name, alphabetically
+	InterfaceSortName
+	// InterfaceSortThroughput puts the busiest interfaces first, by bytes
+	// sent and received per second
+	InterfaceSortThroughput
+)
+
+// interfaceSortNames are the names of the interface orders, by value
+var interfaceSortNames = []string{"system", "name", "throughput"}
+
+// ParseInterfaceSort parses "system", "name" or "throughput"; empty selects
+// system
+func ParseInterfaceSort(name string) (InterfaceSort, error) {
+	order, err := parseSortName(interfaceSortNames, name)
+	if err != nil {
+		return InterfaceSortSystem, fmt.Errorf("unknown interface order %q (want %s)", name, strings.Join(interfaceSortNames, ", "))
+	}
+	return InterfaceSort(order), nil
+}
+
+// String returns the name ParseInterfaceSort accepts
+func (s InterfaceSort) String() string {
+	if s < 0 || int(s) >= len(interfaceSortNames) {
+		return fmt.Sprintf("InterfaceSort(%d)", int(s))
+	}
+	return interfaceSortNames[s]
+}
+
+// Next returns the order after s, wrapping around to the first
+func (s InterfaceSort) Next() InterfaceSort {
+	return InterfaceSort((int(s) + 1) % len(interfaceSortNames))
+}
+
+// Sort orders interfaces in place, by their rates for throughput. Ties keep
+// their order.
+func (s InterfaceSort) Sort(interfaces []NetworkInfo, rates map[string]NetworkStats) {
+	switch s {
+	case InterfaceSortName:
+		sort.SliceStable(interfaces, func(i, j int) bool {
+			return interfaces[i].Interface < interfaces[j].Interface
+		})
+	case InterfaceSortThroughput:
+		throughput := func(name string) float64 {
+			return rates[name].SendRate + rates[name].RecvRate
+		}
+		sort.SliceStable(interfaces, func(i, j int) bool {
+			return throughput(interfaces[i].Interface) > throughput(interfaces[j].Interface)
+		})
+	}
+}
+
+// parseSortName returns the index of name among names; empty selects the
+// first
+func parseSortName(names []string, name string) (int, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return 0, nil
+	}
+	for i, candidate := range names {
+		if name == candidate {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown order %q", name)
+}
+
+// PinFirst returns items with those whose key is pinned moved to the front,
+// in the order of pinned, and the others after them in their order
+func PinFirst[T any](items []T, pinned []string, key func(T) string) []T {
+	if len(pinned) == 0 {
+		return items
+	}
+	rank := make(map[string]int, len(pinned))
+	for i, name := range pinned {
+		if _, ok := rank[name]; !ok {
+			rank[name] = i
+		}
+	}
+	ordered := append([]T(nil), items...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ri, iPinned := rank[key(ordered[i])]
+		rj, jPinned := rank[key(ordered[j])]
+		if iPinned && jPinned {
+			return ri < rj
+		}
+		return iPinned && !jPinned
+	})
+	return ordered
+}
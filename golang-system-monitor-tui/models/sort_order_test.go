@@ -0,0 +1,95 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiskSort_Sort(t *testing.T) {
+	filesystems := []DiskInfo{
+		{Mountpoint: "/home", Total: 500, UsedPercent: 40},
+		{Mountpoint: "/", Total: 100, UsedPercent: 80},
+		{Mountpoint: "/boot", Total: 1, UsedPercent: 40},
+	}
+	tests := map[DiskSort][]string{
+		DiskSortMounted:    {"/home", "/", "/boot"},
+		DiskSortMountpoint: {"/", "/boot", "/home"},
+		DiskSortUsage:      {"/", "/home", "/boot"}, // /home and /boot tie and keep their order
+		DiskSortSize:       {"/home", "/", "/boot"},
+	}
+	for order, want := range tests {
+		sorted := append([]DiskInfo(nil), filesystems...)
+		order.Sort(sorted)
+		var got []string
+		for _, fs := range sorted {
+			got = append(got, fs.Mountpoint)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: expected %v, got %v", order, want, got)
+		}
+	}
+}
+
+func TestInterfaceSort_Sort(t *testing.T) {
+	interfaces := []NetworkInfo{{Interface: "wlan0"}, {Interface: "eth0"}, {Interface: "usb0"}}
+	rates := map[string]NetworkStats{"eth0": {SendRate: 10, RecvRate: 20}, "usb0": {RecvRate: 500}}
+	tests := map[InterfaceSort][]string{
+		InterfaceSortSystem:     {"wlan0", "eth0", "usb0"},
+		InterfaceSortName:       {"eth0", "usb0", "wlan0"},
+		InterfaceSortThroughput: {"usb0", "eth0", "wlan0"},
+	}
+	for order, want := range tests {
+		sorted := append([]NetworkInfo(nil), interfaces...)
+		order.Sort(sorted, rates)
+		var got []string
+		for _, iface := range sorted {
+			got = append(got, iface.Interface)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: expected %v, got %v", order, want, got)
+		}
+	}
+}
+
+func TestParseSortOrders(t *testing.T) {
+	if order, err := ParseDiskSort("Usage"); err != nil || order != DiskSortUsage {
+		t.Errorf("ParseDiskSort(Usage) = %v, %v", order, err)
+	}
+	if order, err := ParseDiskSort(""); err != nil || order != DiskSortMounted {
+		t.Errorf("ParseDiskSort() = %v, %v", order, err)
+	}
+	if _, err := ParseDiskSort("inode"); err == nil {
+		t.Error("Expected an error for an unknown disk order")
+	}
+	if order, err := ParseInterfaceSort("throughput"); err != nil || order != InterfaceSortThroughput {
+		t.Errorf("ParseInterfaceSort(throughput) = %v, %v", order, err)
+	}
+	if _, err := ParseInterfaceSort("speed"); err == nil {
+		t.Error("Expected an error for an unknown interface order")
+	}
+}
+
+func TestSortOrders_Next(t *testing.T) {
+	if DiskSortSize.Next() != DiskSortMounted || DiskSortMounted.Next() != DiskSortMountpoint {
+		t.Error("Expected the disk orders to cycle")
+	}
+	if InterfaceSortThroughput.Next() != InterfaceSortSystem || InterfaceSortSystem.Next() != InterfaceSortName {
+		t.Error("Expected the interface orders to cycle")
+	}
+}
+
+func TestPinFirst(t *testing.T) {
+	names := []string{"/", "/boot", "/home", "/data", "/var"}
+	identity := func(name string) string { return name }
+
+	got := PinFirst(names, []string{"/data", "/missing", "/boot", "/data"}, identity)
+	if want := []string{"/data", "/boot", "/", "/home", "/var"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PinFirst() = %v, want %v", got, want)
+	}
+	if names[1] != "/boot" {
+		t.Error("Expected PinFirst to leave its input alone")
+	}
+	if got := PinFirst(names, nil, identity); !reflect.DeepEqual(got, names) {
+		t.Errorf("Expected no pins to keep the order, got %v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package models
+
+// RunningStats tracks the peak and running average of a metric over the
+// session. It is a plain value: Add returns the updated statistics.
+type RunningStats struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	Max   float64 `json:"max"`
+}
+
+// Add includes a value in the statistics
+func (s RunningStats) Add(value float64) RunningStats {
+	s.Count++
+	// Incremental mean, exact enough for days of samples without a growing sum
+	s.Mean += (value - s.Mean) / float64(s.Count)
+	if s.Count == 1 || value > s.Max {
+		s.Max = value
+	}
+	return s
+}
+
+// RateStats tracks the running statistics of the transfer rates of a network
+// interface
+type RateStats struct {
+	Send RunningStats `json:"send"`
+	Recv RunningStats `json:"recv"`
+}
+
+// Add includes a rate sample in the statistics
+func (s RateStats) Add(rate NetworkStats) RateStats {
+	s.Send = s.Send.Add(rate.SendRate)
+	s.Recv = s.Recv.Add(rate.RecvRate)
+	return s
+}
+
+// TrafficTotals counts the bytes an interface transferred over the session.
+// It is a plain value: Add returns the updated totals.
+type TrafficTotals struct {
+	Sent uint64 `json:"sent"`
+	Recv uint64 `json:"recv"`
+}
+
+// Add includes the bytes transferred between two readings of an interface's
+// counters. A counter that went backwards wrapped or was reset, its unknown
+// increase is left out like in CounterRate.
+func (t TrafficTotals) Add(previous, current NetworkInfo) TrafficTotals {
+	if current.BytesSent >= previous.BytesSent {
+		t.Sent += current.BytesSent - previous.BytesSent
+	}
+	if current.BytesRecv >= previous.BytesRecv {
+		t.Recv += current.BytesRecv - previous.BytesRecv
+	}
+	return t
+}
+
+// UsedPercent returns the share of RAM in use, 0 before the total is known
+func (m MemoryInfo) UsedPercent() float64 {
+	if m.Total == 0 {
+		return 0
+	}
+	return float64(m.Used) / float64(m.Total) * 100
+}
+
+// Used returns the number of huge pages mapped by processes
+func (h HugePages) Used() uint64 {
+	if h.Free > h.Total {
+		return 0
+	}
+	return h.Total - h.Free
+}
+
+// UsedPercent returns the share of the pool mapped by processes, 0 without a pool
+func (h HugePages) UsedPercent() float64 {
+	if h.Total == 0 {
+		return 0
+	}
+	return float64(h.Used()) / float64(h.Total) * 100
+}
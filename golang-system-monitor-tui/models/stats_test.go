@@ -0,0 +1,72 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRunningStats_Add(t *testing.T) {
+	var stats RunningStats
+	for _, value := range []float64{40, 10, 97, 25} {
+		stats = stats.Add(value)
+	}
+	if stats.Count != 4 || stats.Max != 97 || math.Abs(stats.Mean-43) > 1e-9 {
+		t.Errorf("Expected 4 values averaging 43 with a peak of 97, got %+v", stats)
+	}
+
+	// A negative first value is the peak until a higher one arrives
+	stats = RunningStats{}.Add(-5)
+	if stats.Max != -5 || stats.Mean != -5 {
+		t.Errorf("Expected the first value as peak and mean, got %+v", stats)
+	}
+}
+
+func TestRunningStats_Stable(t *testing.T) {
+	var stats RunningStats
+	for i := 0; i < 1_000_000; i++ {
+		stats = stats.Add(float64(i % 100))
+	}
+	if math.Abs(stats.Mean-49.5) > 1e-6 || stats.Max != 99 {
+		t.Errorf("Expected a mean of 49.5 after a million samples, got %+v", stats)
+	}
+}
+
+func TestRateStats_Add(t *testing.T) {
+	stats := RateStats{}.
+		Add(NetworkStats{SendRate: 100, RecvRate: 1000}).
+		Add(NetworkStats{SendRate: 300, RecvRate: 0})
+	if stats.Send.Mean != 200 || stats.Send.Max != 300 {
+		t.Errorf("Expected send averaging 200 with a peak of 300, got %+v", stats.Send)
+	}
+	if stats.Recv.Mean != 500 || stats.Recv.Max != 1000 {
+		t.Errorf("Expected receive averaging 500 with a peak of 1000, got %+v", stats.Recv)
+	}
+}
+
+func TestTrafficTotals_Add(t *testing.T) {
+	var totals TrafficTotals
+	totals = totals.Add(NetworkInfo{BytesSent: 100, BytesRecv: 1000}, NetworkInfo{BytesSent: 150, BytesRecv: 3000})
+	totals = totals.Add(NetworkInfo{BytesSent: 150, BytesRecv: 3000}, NetworkInfo{BytesSent: 200, BytesRecv: 10})
+	if totals.Sent != 100 || totals.Recv != 2000 {
+		t.Errorf("Expected 100 sent and 2000 received without the reset, got %+v", totals)
+	}
+}
+
+func TestMemoryInfo_UsedPercent(t *testing.T) {
+	if got := (MemoryInfo{Total: 200, Used: 50}).UsedPercent(); got != 25 {
+		t.Errorf("Expected 25%%, got %f", got)
+	}
+	if got := (MemoryInfo{}).UsedPercent(); got != 0 {
+		t.Errorf("Expected 0%% before the total is known, got %f", got)
+	}
+}
+
+func TestHugePages_UsedPercent(t *testing.T) {
+	pages := HugePages{Total: 1024, Free: 256, Reserved: 64, Size: 2 << 20}
+	if pages.Used() != 768 || pages.UsedPercent() != 75 {
+		t.Errorf("Expected 768 pages and 75%% used, got %d and %f", pages.Used(), pages.UsedPercent())
+	}
+	if got := (HugePages{}).UsedPercent(); got != 0 {
+		t.Errorf("Expected 0%% without a pool, got %f", got)
+	}
+}
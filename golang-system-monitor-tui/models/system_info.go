@@ -9,6 +9,8 @@ type CPUInfo struct {
 	Cores     int       `json:"cores"`
 	Usage     []float64 `json:"usage"`     // Per-core usage percentages
 	Total     float64   `json:"total"`     // Overall usage percentage
+	Limit     float64   `json:"limit,omitempty"` // cgroup CPU quota in cores, 0 without a limit
+	Times     CPUTimes  `json:"times"`     // Overall time split into user, system, iowait, steal and interrupts
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -18,9 +20,23 @@ type MemoryInfo struct {
 	Used      uint64    `json:"used"`
 	Available uint64    `json:"available"`
 	Swap      SwapInfo  `json:"swap"`
+	Tmpfs     []DiskInfo `json:"tmpfs,omitempty"` // RAM-backed filesystems such as /dev/shm, not listed under disks
+	Limit     uint64    `json:"limit,omitempty"`      // cgroup memory limit, 0 without a limit
+	LimitUsed uint64    `json:"limit_used,omitempty"` // Memory charged to the cgroup
+	HugePages HugePages `json:"hugepages"` // Pool of huge pages reserved at boot or by sysctl, Linux only
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// HugePages is the pool of explicitly allocated huge pages. Databases and
+// virtual machines map their memory from it; pages in the pool are taken
+// from RAM whether they are used or not.
+type HugePages struct {
+	Total    uint64 `json:"total"`    // Pages in the pool
+	Free     uint64 `json:"free"`     // Pages not mapped by any process
+	Reserved uint64 `json:"reserved"` // Free pages promised to a mapping but not faulted in yet
+	Size     uint64 `json:"size"`     // Bytes per page, e.g. 2 MiB
+}
+
 // SwapInfo represents swap memory information
 type SwapInfo struct {
 	Total uint64 `json:"total"`
@@ -53,4 +69,42 @@ type NetworkInfo struct {
 type NetworkStats struct {
 	SendRate float64 `json:"send_rate"` // Bytes per second
 	RecvRate float64 `json:"recv_rate"` // Bytes per second
-}
\ No newline at end of file
+}
+
+// ProcessMemory breaks down the memory of a single process. RSS counts every
+// resident page, including pages shared with other processes; PSS divides
+// shared pages among their users and USS counts only private pages.
+type ProcessMemory struct {
+	PID      int32  `json:"pid"`
+	RSS      uint64 `json:"rss"`      // Resident set size in bytes
+	PSS      uint64 `json:"pss"`      // Proportional set size in bytes
+	USS      uint64 `json:"uss"`      // Unique set size in bytes
+	Swap     uint64 `json:"swap"`     // Swapped out bytes
+	Detailed bool   `json:"detailed"` // Whether PSS and USS are available, only on Linux
+}
+
+// HostInfo identifies the monitored machine
+type HostInfo struct {
+	Hostname        string    `json:"hostname"`
+	BootTime        time.Time `json:"boot_time"`
+	OS              string    `json:"os,omitempty"`               // e.g. linux
+	Platform        string    `json:"platform,omitempty"`         // Distribution, e.g. ubuntu
+	PlatformVersion string    `json:"platform_version,omitempty"` // e.g. 24.04
+	KernelVersion   string    `json:"kernel_version,omitempty"`
+	KernelArch      string    `json:"kernel_arch,omitempty"` // e.g. x86_64
+}
+
+// Uptime returns how long the host has been up at now, zero when the boot time is unknown
+func (h HostInfo) Uptime(now time.Time) time.Duration {
+	if h.BootTime.IsZero() || now.Before(h.BootTime) {
+		return 0
+	}
+	return now.Sub(h.BootTime)
+}
+
+// ProcessInfo represents resource usage of a single process
+type ProcessInfo struct {
+	PID        int32   `json:"pid"`
+	Name       string  `json:"name"`
+	CPUPercent float64 `json:"cpu_percent"` // Usage since the previous sample
+}
@@ -288,4 +288,16 @@ func TestMemoryInfo_JSONSerialization(t *testing.T) {
 	if deserialized.Swap.Total != original.Swap.Total {
 		t.Errorf("Swap total mismatch: got %d, want %d", deserialized.Swap.Total, original.Swap.Total)
 	}
-}
\ No newline at end of file
+}
+
+func TestHostInfo_Uptime(t *testing.T) {
+	now := time.Now()
+	host := HostInfo{Hostname: "box", BootTime: now.Add(-26 * time.Hour)}
+	if host.Uptime(now) != 26*time.Hour {
+		t.Errorf("Expected 26h uptime, got %v", host.Uptime(now))
+	}
+
+	if (HostInfo{}).Uptime(now) != 0 {
+		t.Error("Expected no uptime without a boot time")
+	}
+}
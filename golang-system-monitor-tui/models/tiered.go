@@ -0,0 +1,207 @@
+package models
+
+import "time"
+
+// Tier is one resolution of a TieredHistory: samples averaged over Step and
+// kept for Span
+type Tier struct {
+	Step time.Duration
+	Span time.Duration
+}
+
+// DefaultTiers keeps 1s samples for 10 minutes, 10s averages for 2 hours and
+// 1m averages for a day, about 2,800 values in all
+var DefaultTiers = []Tier{
+	{Step: time.Second, Span: 10 * time.Minute},
+	{Step: 10 * time.Second, Span: 2 * time.Hour},
+	{Step: time.Minute, Span: 24 * time.Hour},
+}
+
+// TieredHistory keeps a series at several resolutions, like an RRD: recent
+// values in detail and older ones as coarser averages, in bounded memory.
+// Like Ring, it is a small header over shared storage and Push returns the
+// updated history.
+type TieredHistory struct {
+	tiers []tierValues
+}
+
+// tierValues holds the averages of one tier and the step being averaged
+type tierValues struct {
+	Tier
+	values Ring[float64]
+	bucket time.Time // Start of the step being averaged
+	sum    float64
+	count  int
+}
+
+// NewTieredHistory creates an empty history with the given tiers, finest first
+func NewTieredHistory(tiers []Tier) TieredHistory {
+	history := TieredHistory{tiers: make([]tierValues, 0, len(tiers))}
+	for _, tier := range tiers {
+		if tier.Step <= 0 || tier.Span < tier.Step {
+			continue
+		}
+		history.tiers = append(history.tiers, tierValues{
+			Tier:   tier,
+			values: NewRing[float64](int(tier.Span / tier.Step)),
+		})
+	}
+	return history
+}
+
+// Push adds a sample taken at the given time and returns the updated history.
+// Samples within the same step of a tier are averaged; the average is stored
+// once a sample of a later step arrives. Steps are measured on the monotonic
+// clock of the samples when they have one, so a clock change does not cut a
+// step short or stretch it.
+func (h TieredHistory) Push(at time.Time, value float64) TieredHistory {
+	tiers := make([]tierValues, len(h.tiers))
+	copy(tiers, h.tiers)
+	for i := range tiers {
+		tier := &tiers[i]
+		if elapsed := at.Sub(tier.bucket); tier.count > 0 && (elapsed >= tier.Step || elapsed < 0) {
+			tier.values = tier.values.Push(tier.sum / float64(tier.count))
+			tier.sum, tier.count = 0, 0
+		}
+		if tier.count == 0 {
+			tier.bucket = stepStart(at, tier.Step)
+		}
+		tier.sum += value
+		tier.count++
+	}
+	h.tiers = tiers
+	return h
+}
+
+// stepStart returns the start of the step at falls in. Unlike Truncate, it
+// keeps the monotonic clock reading of at for measuring the step.
+func stepStart(at time.Time, step time.Duration) time.Time {
+	return at.Add(-at.Sub(at.Truncate(step)))
+}
+
+// Window returns the values of the last span, oldest first, from the finest
+// tier that keeps that long, or the coarsest tier when none does. The average
+// of the step in progress comes last.
+func (h TieredHistory) Window(span time.Duration) []float64 {
+	tier, ok := h.tierFor(span)
+	if !ok {
+		return nil
+	}
+	values := make([]float64, 0, tier.values.Len()+1)
+	values = tier.values.AppendTo(values)
+	if tier.count > 0 {
+		values = append(values, tier.sum/float64(tier.count))
+	}
+	if points := h.Points(span); len(values) > points {
+		values = values[len(values)-points:]
+	}
+	return values
+}
+
+// Points returns how many values a window of span holds once the history
+// reaches that far back, at the resolution Window uses
+func (h TieredHistory) Points(span time.Duration) int {
+	tier, ok := h.tierFor(span)
+	if !ok {
+		return 0
+	}
+	return int((span + tier.Step - 1) / tier.Step)
+}
+
+// tierFor returns the finest tier keeping span, or the coarsest one
+func (h TieredHistory) tierFor(span time.Duration) (tierValues, bool) {
+	if len(h.tiers) == 0 || span <= 0 {
+		return tierValues{}, false
+	}
+	for _, tier := range h.tiers {
+		if tier.Span >= span {
+			return tier, true
+		}
+	}
+	return h.tiers[len(h.tiers)-1], true
+}
+
+// TierSnapshot is the saved state of one tier of a TieredHistory
+type TierSnapshot struct {
+	Step   time.Duration `json:"step"`
+	Values []float64     `json:"values,omitempty"` // Stored averages, oldest first
+	Bucket time.Time     `json:"bucket"`           // Start of the step being averaged
+	Sum    float64       `json:"sum,omitempty"`    // Sum of the samples of that step
+	Count  int           `json:"count,omitempty"`  // Number of samples of that step
+}
+
+// Snapshot returns the state of every tier, finest first, for saving
+func (h TieredHistory) Snapshot() []TierSnapshot {
+	snapshots := make([]TierSnapshot, len(h.tiers))
+	for i, tier := range h.tiers {
+		snapshots[i] = TierSnapshot{
+			Step:   tier.Step,
+			Values: tier.values.Values(),
+			Bucket: tier.bucket,
+			Sum:    tier.sum,
+			Count:  tier.count,
+		}
+	}
+	return snapshots
+}
+
+// RestoreTieredHistory creates a history with the given tiers and fills each
+// from the snapshot with the same step. Tiers without one start empty, so a
+// snapshot taken with other tiers restores what still fits.
+func RestoreTieredHistory(tiers []Tier, snapshots []TierSnapshot) TieredHistory {
+	history := NewTieredHistory(tiers)
+	for i := range history.tiers {
+		tier := &history.tiers[i]
+		for _, snapshot := range snapshots {
+			if snapshot.Step != tier.Step {
+				continue
+			}
+			for _, value := range snapshot.Values {
+				tier.values = tier.values.Push(value)
+			}
+			tier.bucket, tier.sum, tier.count = snapshot.Bucket, snapshot.Sum, snapshot.Count
+			break
+		}
+	}
+	return history
+}
+
+// Span returns how far back the history reaches at its coarsest tier
+func (h TieredHistory) Span() time.Duration {
+	if len(h.tiers) == 0 {
+		return 0
+	}
+	return h.tiers[len(h.tiers)-1].Span
+}
+
+// Resample maps the newest values of a window of slots values onto n points,
+// so the window always spans the same width: groups of slots are averaged
+// when there are more slots than points, and slots repeated when there are
+// fewer. Only the points reached by values are returned, oldest first; the
+// window before the first value is left out, as it was not sampled.
+func Resample(values []float64, slots, n int) []float64 {
+	if n <= 0 || slots <= 0 {
+		return nil
+	}
+	if len(values) > slots {
+		values = values[len(values)-slots:]
+	}
+	first := slots - len(values) // Slot of the oldest value
+	points := make([]float64, 0, n)
+	for i := 0; i < n; i++ {
+		start, end := i*slots/n, (i+1)*slots/n
+		if end == start {
+			end++
+		}
+		if end <= first {
+			continue
+		}
+		start = max(start, first)
+		var sum float64
+		for _, value := range values[start-first : end-first] {
+			sum += value
+		}
+		points = append(points, sum/float64(end-start))
+	}
+	return points
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testTiers = []Tier{
+	{Step: time.Second, Span: 5 * time.Second},
+	{Step: 5 * time.Second, Span: 30 * time.Second},
+}
+
+func TestTieredHistory_Push(t *testing.T) {
+	start := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	history := NewTieredHistory(testTiers)
+	for i := 0; i < 12; i++ {
+		history = history.Push(start.Add(time.Duration(i)*time.Second), float64(i))
+	}
+
+	// The finest tier keeps the last 5 seconds, plus the second in progress
+	if got := history.Window(5 * time.Second); !reflect.DeepEqual(got, []float64{7, 8, 9, 10, 11}) {
+		t.Errorf("Expected the last 5 samples, got %v", got)
+	}
+	// Longer windows come from the 5s averages
+	if got := history.Window(30 * time.Second); !reflect.DeepEqual(got, []float64{2, 7, 10.5}) {
+		t.Errorf("Expected 5s averages, got %v", got)
+	}
+	// Windows beyond the coarsest tier get all it has
+	if got := history.Window(time.Hour); !reflect.DeepEqual(got, []float64{2, 7, 10.5}) {
+		t.Errorf("Expected the coarsest tier, got %v", got)
+	}
+	if history.Span() != 30*time.Second {
+		t.Errorf("Expected a 30s span, got %v", history.Span())
+	}
+}
+
+func TestTieredHistory_AveragesWithinStep(t *testing.T) {
+	start := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	history := NewTieredHistory(testTiers)
+	for i, value := range []float64{10, 20, 30, 40} {
+		history = history.Push(start.Add(time.Duration(i)*500*time.Millisecond), value)
+	}
+	if got := history.Window(5 * time.Second); !reflect.DeepEqual(got, []float64{15, 35}) {
+		t.Errorf("Expected samples within a second averaged, got %v", got)
+	}
+}
+
+func TestTieredHistory_MonotonicSteps(t *testing.T) {
+	history := NewTieredHistory(testTiers).Push(time.Now(), 1)
+	// Time.String shows the monotonic clock reading as "m=±<seconds>"
+	for _, tier := range history.Snapshot() {
+		if !strings.Contains(tier.Bucket.String(), " m=") {
+			t.Errorf("Expected the %v step to keep the monotonic clock, got %v", tier.Step, tier.Bucket)
+		}
+		if wall := tier.Bucket.Round(0); !wall.Equal(wall.Truncate(tier.Step)) {
+			t.Errorf("Expected the %v step to start on a step boundary, got %v", tier.Step, tier.Bucket)
+		}
+	}
+}
+
+func TestTieredHistory_Bounded(t *testing.T) {
+	start := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	history := NewTieredHistory(testTiers)
+	for i := 0; i < 1000; i++ {
+		history = history.Push(start.Add(time.Duration(i)*time.Second), 1)
+	}
+	if got := len(history.Window(time.Hour)); got > 7 {
+		t.Errorf("Expected at most 6 stored averages and one in progress, got %d", got)
+	}
+}
+
+func TestTieredHistory_CopiesAreIndependent(t *testing.T) {
+	start := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	before := NewTieredHistory(testTiers).Push(start, 10)
+	after := before.Push(start.Add(time.Second), 20)
+
+	if got := before.Window(5 * time.Second); !reflect.DeepEqual(got, []float64{10}) {
+		t.Errorf("Expected the earlier copy unchanged, got %v", got)
+	}
+	if got := after.Window(5 * time.Second); !reflect.DeepEqual(got, []float64{10, 20}) {
+		t.Errorf("Expected both samples in the later copy, got %v", got)
+	}
+}
+
+func TestTieredHistory_Empty(t *testing.T) {
+	var history TieredHistory
+	if got := history.Push(time.Now(), 1).Window(time.Minute); got != nil {
+		t.Errorf("Expected no values without tiers, got %v", got)
+	}
+	if got := NewTieredHistory(testTiers).Window(time.Minute); len(got) != 0 {
+		t.Errorf("Expected no values before the first sample, got %v", got)
+	}
+}
+
+func TestTieredHistory_Points(t *testing.T) {
+	history := NewTieredHistory(testTiers)
+	tests := map[time.Duration]int{
+		5 * time.Second:  5,
+		10 * time.Second: 2,
+		12 * time.Second: 3,
+		time.Hour:        720,
+		0:                0,
+	}
+	for span, want := range tests {
+		if got := history.Points(span); got != want {
+			t.Errorf("Points(%v) = %d, want %d", span, got, want)
+		}
+	}
+}
+
+func TestTieredHistory_SnapshotRoundTrip(t *testing.T) {
+	start := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	history := NewTieredHistory(testTiers)
+	for i := 0; i < 12; i++ {
+		history = history.Push(start.Add(time.Duration(i)*time.Second), float64(i))
+	}
+
+	restored := RestoreTieredHistory(testTiers, history.Snapshot())
+	for _, span := range []time.Duration{5 * time.Second, 30 * time.Second} {
+		if got, want := restored.Window(span), history.Window(span); !reflect.DeepEqual(got, want) {
+			t.Errorf("Window(%v) = %v after restoring, want %v", span, got, want)
+		}
+	}
+
+	// The step in progress carries on across the restore
+	next := start.Add(12 * time.Second)
+	if got, want := restored.Push(next, 12).Window(30*time.Second), history.Push(next, 12).Window(30*time.Second); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the restored history to continue like the original, got %v, want %v", got, want)
+	}
+}
+
+func TestRestoreTieredHistory_OtherTiers(t *testing.T) {
+	start := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	history := NewTieredHistory(testTiers)
+	for i := 0; i < 12; i++ {
+		history = history.Push(start.Add(time.Duration(i)*time.Second), float64(i))
+	}
+
+	// Only the 5s tier matches, the 1m tier starts empty
+	tiers := []Tier{{Step: 5 * time.Second, Span: time.Minute}, {Step: time.Minute, Span: time.Hour}}
+	restored := RestoreTieredHistory(tiers, history.Snapshot())
+	if got := restored.Window(time.Minute); !reflect.DeepEqual(got, []float64{2, 7, 10.5}) {
+		t.Errorf("Expected the matching tier restored, got %v", got)
+	}
+	if got := restored.Window(time.Hour); len(got) != 0 {
+		t.Errorf("Expected the tier without a snapshot empty, got %v", got)
+	}
+}
+
+func TestResample(t *testing.T) {
+	values := []float64{1, 3, 5, 7, 9, 11}
+	tests := []struct {
+		name   string
+		values []float64
+		slots  int
+		n      int
+		want   []float64
+	}{
+		{"averaged in pairs", values, 6, 3, []float64{2, 6, 10}},
+		{"uneven groups", values, 6, 4, []float64{1, 4, 7, 10}},
+		{"stretched", []float64{1, 2}, 2, 4, []float64{1, 1, 2, 2}},
+		{"partial window", []float64{4, 8}, 4, 2, []float64{6}},
+		{"partial stretched window", []float64{5}, 2, 4, []float64{5, 5}},
+		{"more values than slots", values, 2, 2, []float64{9, 11}},
+		{"no points", values, 6, 0, nil},
+	}
+	for _, test := range tests {
+		if got := Resample(test.values, test.slots, test.n); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Resample = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SizeUnits selects the multiples sizes and byte rates are shown in
+type SizeUnits int
+
+const (
+	// IECUnits are powers of 1024 with binary suffixes: KiB, MiB, GiB
+	IECUnits SizeUnits = iota
+	// SIUnits are powers of 1000 with decimal suffixes: KB, MB, GB
+	SIUnits
+)
+
+// ParseSizeUnits parses "iec" (powers of 1024) or "si" (powers of 1000)
+func ParseSizeUnits(name string) (SizeUnits, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "iec":
+		return IECUnits, nil
+	case "si":
+		return SIUnits, nil
+	default:
+		return IECUnits, fmt.Errorf("unknown units %q (want si or iec)", name)
+	}
+}
+
+// Base returns the factor between successive multiples, 1024 or 1000
+func (u SizeUnits) Base() float64 {
+	if u == SIUnits {
+		return 1000
+	}
+	return 1024
+}
+
+// String returns the name ParseSizeUnits accepts
+func (u SizeUnits) String() string {
+	if u == SIUnits {
+		return "si"
+	}
+	return "iec"
+}
@@ -0,0 +1,14 @@
+package models
+
+import "testing"
+
+func TestParseSizeUnits(t *testing.T) {
+	for name, want := range map[string]SizeUnits{"": IECUnits, "iec": IECUnits, "SI": SIUnits} {
+		if units, err := ParseSizeUnits(name); err != nil || units != want {
+			t.Errorf("ParseSizeUnits(%q) = %v, %v", name, units, err)
+		}
+	}
+	if _, err := ParseSizeUnits("metric"); err == nil {
+		t.Error("Expected an error for unknown units")
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// WeakSignalPercent is the signal quality, in percent, below which a wireless
+// link is highlighted
+const WeakSignalPercent = 40
+
+// WirelessLink is the association of a wireless interface with an access
+// point, as reported by iw, airport or netsh
+type WirelessLink struct {
+	Interface     string    `json:"interface"`
+	Connected     bool      `json:"connected"`
+	SSID          string    `json:"ssid,omitempty"`
+	SignalDBm     int       `json:"signal_dbm"`     // Received signal strength, e.g. -52
+	SignalPercent int       `json:"signal_percent"` // Signal quality from 0 to 100
+	LinkRate      float64   `json:"link_rate"`      // Transmit bitrate in Mbit/s, 0 when unknown
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// Weak reports whether a connected link has a weak signal
+func (w WirelessLink) Weak() bool {
+	return w.Connected && w.SignalPercent < WeakSignalPercent
+}
+
+// SignalPercentFromDBm maps a signal strength to a quality from 0 to 100 the
+// way NetworkManager and Windows do: -100 dBm and below is 0, -50 dBm and
+// above is 100, linear in between
+func SignalPercentFromDBm(dbm int) int {
+	switch {
+	case dbm <= -100:
+		return 0
+	case dbm >= -50:
+		return 100
+	default:
+		return 2 * (dbm + 100)
+	}
+}
+
+// SignalDBmFromPercent is the inverse of SignalPercentFromDBm, for platforms
+// such as Windows that only report the quality
+func SignalDBmFromPercent(percent int) int {
+	return percent/2 - 100
+}
@@ -0,0 +1,24 @@
+package models
+
+import "testing"
+
+func TestSignalPercentFromDBm(t *testing.T) {
+	tests := map[int]int{-30: 100, -50: 100, -52: 96, -75: 50, -100: 0, -110: 0}
+	for dbm, want := range tests {
+		if got := SignalPercentFromDBm(dbm); got != want {
+			t.Errorf("SignalPercentFromDBm(%d) = %d, want %d", dbm, got, want)
+		}
+	}
+	if got := SignalDBmFromPercent(96); got != -52 {
+		t.Errorf("SignalDBmFromPercent(96) = %d, want -52", got)
+	}
+}
+
+func TestWirelessLink_Weak(t *testing.T) {
+	if !(WirelessLink{Connected: true, SignalPercent: 30}).Weak() {
+		t.Error("Expected 30% to be weak")
+	}
+	if (WirelessLink{SignalPercent: 0}).Weak() {
+		t.Error("Expected a disconnected link not to be weak")
+	}
+}
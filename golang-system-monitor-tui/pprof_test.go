@@ -0,0 +1,48 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestStartPprof(t *testing.T) {
+	addr, stop, err := startPprof("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("startPprof failed: %v", err)
+	}
+	defer stop()
+
+	resp, err := http.Get("http://" + addr.String() + "/debug/pprof/goroutine?debug=1")
+	if err != nil {
+		t.Fatalf("Expected the profiler to answer: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "goroutine profile") {
+		t.Errorf("Expected a goroutine profile, got %d:\n%s", resp.StatusCode, body)
+	}
+
+	// Nothing but the profiler is served
+	resp, err = http.Get("http://" + addr.String() + "/")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 outside /debug/pprof/, got %d", resp.StatusCode)
+	}
+}
+
+func TestStartPprof_PortInUse(t *testing.T) {
+	addr, stop, err := startPprof("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("startPprof failed: %v", err)
+	}
+	defer stop()
+
+	if _, _, err := startPprof(addr.String()); err == nil || !strings.Contains(err.Error(), "pprof") {
+		t.Errorf("Expected a pprof error for a port in use, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	MaxSlowdown    = 8  // Bound on how many times longer than configured the refresh interval gets
+	SaturatedCPU   = 95 // System CPU percentage at which refreshes are slowed
+	RelaxedCPU     = 80 // System CPU percentage below which slowed refreshes speed up again
+	RecoveryCycles = 5  // Relaxed cycles in a row before the interval is halved again
+)
+
+// AdaptiveRefresh stretches the refresh interval while the monitor would add
+// to a load problem: when a cycle's collection and rendering takes longer
+// than the interval, or the system CPU is saturated, the interval doubles, up
+// to MaxSlowdown times the configured one. After RecoveryCycles relaxed cycles
+// in a row it is halved again, back to the configured interval. It is safe
+// for concurrent use.
+type AdaptiveRefresh struct {
+	mu       sync.Mutex
+	slowdown time.Duration // Factor applied to the configured interval, 1 when not slowed
+	collect  time.Duration // Longest collection since the last cycle ended
+	render   time.Duration // Longest render since the last cycle ended
+	relaxed  int           // Relaxed cycles in a row while slowed
+}
+
+// NewAdaptiveRefresh creates an adaptive refresh at the configured interval
+func NewAdaptiveRefresh() *AdaptiveRefresh {
+	return &AdaptiveRefresh{slowdown: 1}
+}
+
+// RecordCollect records how long a collection took. Collectors run
+// concurrently, so the longest one bounds the cycle.
+func (a *AdaptiveRefresh) RecordCollect(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.collect = max(a.collect, d)
+}
+
+// RecordRender records how long rendering the view took
+func (a *AdaptiveRefresh) RecordRender(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.render = max(a.render, d)
+}
+
+// Adjust ends a cycle at the given system CPU percentage and returns the
+// interval until the next one, for the configured interval base
+func (a *AdaptiveRefresh) Adjust(base time.Duration, cpuPercent float64) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	interval := base * a.slowdown
+	cycle := a.collect + a.render
+	a.collect, a.render = 0, 0
+	switch {
+	case cycle > interval || cpuPercent >= SaturatedCPU:
+		a.slowdown = min(a.slowdown*2, MaxSlowdown)
+		a.relaxed = 0
+	case a.slowdown > 1 && cycle < interval/2 && cpuPercent < RelaxedCPU:
+		a.relaxed++
+		if a.relaxed >= RecoveryCycles {
+			a.slowdown /= 2
+			a.relaxed = 0
+		}
+	default:
+		a.relaxed = 0
+	}
+	return base * a.slowdown
+}
+
+// Interval returns the current refresh interval for the configured interval base
+func (a *AdaptiveRefresh) Interval(base time.Duration) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return base * a.slowdown
+}
+
+// Slowed reports whether refreshes are slowed below the configured interval
+func (a *AdaptiveRefresh) Slowed() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.slowdown > 1
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdaptiveRefresh_SlowCycles(t *testing.T) {
+	refresh := NewAdaptiveRefresh()
+	refresh.RecordCollect(200 * time.Millisecond)
+	refresh.RecordRender(50 * time.Millisecond)
+	if interval := refresh.Adjust(time.Second, 30); interval != time.Second || refresh.Slowed() {
+		t.Fatalf("Expected the configured interval for a quick cycle, got %v", interval)
+	}
+
+	// Collectors run concurrently, the longest one and the render make the cycle
+	refresh.RecordCollect(300 * time.Millisecond)
+	refresh.RecordCollect(900 * time.Millisecond)
+	refresh.RecordRender(200 * time.Millisecond)
+	if interval := refresh.Adjust(time.Second, 30); interval != 2*time.Second || !refresh.Slowed() {
+		t.Errorf("Expected the interval doubled, got %v", interval)
+	}
+
+	// The slowdown is bounded
+	for i := 0; i < 10; i++ {
+		refresh.RecordCollect(time.Minute)
+		refresh.Adjust(time.Second, 30)
+	}
+	if interval := refresh.Interval(time.Second); interval != MaxSlowdown*time.Second {
+		t.Errorf("Expected at most %v, got %v", MaxSlowdown*time.Second, interval)
+	}
+}
+
+func TestAdaptiveRefresh_SaturatedCPU(t *testing.T) {
+	refresh := NewAdaptiveRefresh()
+	if interval := refresh.Adjust(time.Second, SaturatedCPU); interval != 2*time.Second {
+		t.Errorf("Expected a saturated CPU to slow refreshes, got %v", interval)
+	}
+	if interval := refresh.Adjust(time.Second, SaturatedCPU+1); interval != 4*time.Second {
+		t.Errorf("Expected the interval doubled again, got %v", interval)
+	}
+}
+
+func TestAdaptiveRefresh_Recovery(t *testing.T) {
+	refresh := NewAdaptiveRefresh()
+	refresh.Adjust(time.Second, 100)
+	refresh.Adjust(time.Second, 100)
+
+	// Busy but not saturated cycles keep the interval
+	for i := 0; i < RecoveryCycles*2; i++ {
+		refresh.Adjust(time.Second, RelaxedCPU+5)
+	}
+	if interval := refresh.Interval(time.Second); interval != 4*time.Second {
+		t.Fatalf("Expected the interval kept, got %v", interval)
+	}
+
+	for i := 1; i < RecoveryCycles; i++ {
+		refresh.Adjust(time.Second, 10)
+	}
+	if interval := refresh.Interval(time.Second); interval != 4*time.Second {
+		t.Errorf("Expected the interval kept until %d relaxed cycles, got %v", RecoveryCycles, interval)
+	}
+	if interval := refresh.Adjust(time.Second, 10); interval != 2*time.Second {
+		t.Errorf("Expected the interval halved, got %v", interval)
+	}
+	for i := 0; i < RecoveryCycles; i++ {
+		refresh.Adjust(time.Second, 10)
+	}
+	if refresh.Slowed() || refresh.Interval(time.Second) != time.Second {
+		t.Errorf("Expected the configured interval again, got %v", refresh.Interval(time.Second))
+	}
+	for i := 0; i < RecoveryCycles; i++ {
+		refresh.Adjust(time.Second, 10)
+	}
+	if refresh.Interval(time.Second) != time.Second {
+		t.Errorf("Expected never to go below the configured interval, got %v", refresh.Interval(time.Second))
+	}
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// AlertRecorder writes every snapshot to a JSON Lines file while an alert is
+// firing and for a while after the last one cleared, so the data around an
+// incident is kept without recording all the time. Each incident gets its
+// own alert-recording-<time>.jsonl file. It receives alerts as a
+// models.Notifier and snapshots as a models.SnapshotExporter; writes happen
+// on its own goroutine once Start is called.
+type AlertRecorder struct {
+	dir     string
+	after   time.Duration
+	tracker *models.SinkTracker
+
+	mu        sync.Mutex
+	firing    map[string]bool  // Rule and subject of the alerts firing now
+	recording bool             // Whether snapshots are written
+	name      string           // File of the current incident
+	stopAt    time.Time        // End of recording once no alert fires; zero while one does
+	pending   []recordingEntry // Entries waiting to be written
+
+	writing  sync.Mutex // Held while the incident file is written or closed
+	file     *os.File   // Open incident file
+	fileName string
+	wake     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// recordingEntry is a snapshot to write to an incident file, or the end of
+// the incident
+type recordingEntry struct {
+	name     string
+	snapshot models.Snapshot
+	end      bool
+}
+
+// NewAlertRecorder creates a recorder writing incident files to dir, the
+// working directory when empty, and recording for after past the last
+// cleared alert; call Start to begin writing
+func NewAlertRecorder(dir string, after time.Duration) *AlertRecorder {
+	return &AlertRecorder{
+		dir:     dir,
+		after:   after,
+		tracker: models.NewSinkTracker("alert-recording"),
+		firing:  make(map[string]bool),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+	}
+}
+
+// Notify starts recording when an alert fires and schedules its end when the
+// last firing alert clears
+func (r *AlertRecorder) Notify(event models.AlertEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := event.Rule.Component + "\x00" + event.Subject
+	if event.Fired {
+		r.firing[key] = true
+		if !r.recording {
+			r.recording = true
+			r.name = "alert-recording-" + event.Timestamp.Format("20060102-150405") + ".jsonl"
+		}
+		r.stopAt = time.Time{}
+		return nil
+	}
+
+	delete(r.firing, key)
+	if r.recording && len(r.firing) == 0 {
+		r.stopAt = event.Timestamp.Add(r.after)
+	}
+	return nil
+}
+
+// Record queues the snapshot while recording, and ends the incident with the
+// first snapshot past the end of recording
+func (r *AlertRecorder) Record(snapshot models.Snapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recording {
+		return
+	}
+
+	if !r.stopAt.IsZero() && !snapshot.Timestamp.Before(r.stopAt) {
+		r.recording, r.stopAt = false, time.Time{}
+		r.pending = append(r.pending, recordingEntry{name: r.name, end: true})
+	} else {
+		r.pending = append(r.pending, recordingEntry{name: r.name, snapshot: snapshot})
+		r.tracker.Enqueue()
+	}
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// IsRecording reports whether snapshots are being written
+func (r *AlertRecorder) IsRecording() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.recording
+}
+
+// Start writes queued snapshots as they arrive until Stop is called
+func (r *AlertRecorder) Start() {
+	go func() {
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-r.wake:
+				r.Flush()
+			}
+		}
+	}()
+}
+
+// Stop stops the writer after writing what is queued, and closes the
+// incident file
+func (r *AlertRecorder) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		r.Flush()
+		r.writing.Lock()
+		defer r.writing.Unlock()
+		r.closeFile()
+	})
+}
+
+// Flush writes the queued snapshots
+func (r *AlertRecorder) Flush() {
+	r.mu.Lock()
+	entries := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	r.writing.Lock()
+	defer r.writing.Unlock()
+	for _, entry := range entries {
+		if entry.end {
+			if entry.name == r.fileName {
+				r.closeFile()
+			}
+			continue
+		}
+		err := r.write(entry)
+		r.tracker.Dequeue()
+		if err != nil {
+			r.tracker.RecordError(time.Now(), err)
+			continue
+		}
+		r.tracker.RecordSuccess(time.Now())
+	}
+}
+
+// SinkStatus returns the recorder's write health
+func (r *AlertRecorder) SinkStatus() models.SinkStatus {
+	return r.tracker.Status()
+}
+
+// write appends a snapshot to its incident file, opening the file first
+func (r *AlertRecorder) write(entry recordingEntry) error {
+	if entry.name != r.fileName {
+		r.closeFile()
+		path := filepath.Join(r.dir, entry.name)
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open alert recording: %w", err)
+		}
+		log.Printf("Recording snapshots to %s until alerts clear", path)
+		r.file, r.fileName = file, entry.name
+	}
+
+	data, err := json.Marshal(entry.snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if _, err := r.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write alert recording: %w", err)
+	}
+	return nil
+}
+
+// closeFile closes the incident file, if one is open
+func (r *AlertRecorder) closeFile() {
+	if r.file == nil {
+		return
+	}
+	if err := r.file.Close(); err != nil {
+		log.Printf("Failed to close alert recording %s: %v", r.fileName, err)
+	}
+	r.file, r.fileName = nil, ""
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// readRecording returns the snapshots of an incident file
+func readRecording(t *testing.T, path string) []models.Snapshot {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Expected the recording %s: %v", path, err)
+	}
+	defer file.Close()
+
+	var snapshots []models.Snapshot
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var snapshot models.Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			t.Fatalf("Expected a snapshot per line, got %q: %v", scanner.Text(), err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+func TestAlertRecorder(t *testing.T) {
+	dir := t.TempDir()
+	recorder := NewAlertRecorder(dir, 2*time.Minute)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	record := func(offset time.Duration) {
+		recorder.Record(models.Snapshot{Timestamp: start.Add(offset), CPU: models.CPUInfo{Total: float64(offset / time.Second)}})
+	}
+	cpu := models.AlertRule{Component: "CPU", Threshold: 90}
+	disk := models.AlertRule{Component: "Disk", Threshold: 90}
+
+	// Nothing is recorded before an alert fires
+	record(0)
+	if recorder.IsRecording() {
+		t.Fatal("Expected no recording without alerts")
+	}
+
+	recorder.Notify(models.AlertEvent{Rule: cpu, Fired: true, Timestamp: start.Add(time.Second)})
+	recorder.Notify(models.AlertEvent{Rule: disk, Subject: "/", Fired: true, Timestamp: start.Add(time.Second)})
+	record(time.Second)
+	// Recording goes on while another alert fires
+	recorder.Notify(models.AlertEvent{Rule: cpu, Timestamp: start.Add(2 * time.Second)})
+	record(2 * time.Second)
+	// and for two minutes after the last one cleared
+	recorder.Notify(models.AlertEvent{Rule: disk, Subject: "/", Timestamp: start.Add(3 * time.Second)})
+	record(time.Minute)
+	record(3*time.Second + 2*time.Minute)
+	if recorder.IsRecording() {
+		t.Error("Expected the recording to end two minutes after the last alert cleared")
+	}
+	record(3 * time.Minute)
+	recorder.Flush()
+
+	snapshots := readRecording(t, filepath.Join(dir, "alert-recording-20240101-120001.jsonl"))
+	if len(snapshots) != 3 || snapshots[0].CPU.Total != 1 || snapshots[2].CPU.Total != 60 {
+		t.Errorf("Expected the snapshots at 1s, 2s and 60s, got %+v", snapshots)
+	}
+	if status := recorder.SinkStatus(); status.Delivered != 3 || status.QueueDepth != 0 {
+		t.Errorf("Expected 3 delivered snapshots, got %+v", status)
+	}
+
+	// The next incident gets its own file
+	recorder.Notify(models.AlertEvent{Rule: cpu, Fired: true, Timestamp: start.Add(time.Hour)})
+	record(time.Hour)
+	recorder.Stop()
+	if snapshots := readRecording(t, filepath.Join(dir, "alert-recording-20240101-130000.jsonl")); len(snapshots) != 1 {
+		t.Errorf("Expected one snapshot in the second recording, got %d", len(snapshots))
+	}
+}
+
+func TestAlertRecorder_WriteError(t *testing.T) {
+	recorder := NewAlertRecorder(filepath.Join(t.TempDir(), "missing"), time.Minute)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	recorder.Notify(models.AlertEvent{Rule: models.AlertRule{Component: "CPU"}, Fired: true, Timestamp: now})
+	recorder.Record(models.Snapshot{Timestamp: now})
+	recorder.Flush()
+
+	if status := recorder.SinkStatus(); status.Errors != 1 || status.Healthy() {
+		t.Errorf("Expected a failed write in the status, got %+v", status)
+	}
+}
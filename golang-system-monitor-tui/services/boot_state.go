@@ -0,0 +1,50 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+)
+
+// LoadBootRecord reads the boot record persisted at path. A missing file, as
+// on the first run, yields an empty record.
+func LoadBootRecord(path string) (models.BootRecord, error) {
+	var record models.BootRecord
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return record, nil
+		}
+		return record, fmt.Errorf("failed to read boot record: %w", err)
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return models.BootRecord{}, fmt.Errorf("failed to parse boot record %s: %w", path, err)
+	}
+	return record, nil
+}
+
+// SaveBootRecord writes the boot record to path, creating its directory. The
+// file is replaced atomically so a crash never leaves a partial record.
+func SaveBootRecord(path string, record models.BootRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode boot record: %w", err)
+	}
+	if err := writeStateFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write boot record: %w", err)
+	}
+	return nil
+}
+
+// writeStateFile replaces the file at path with data atomically, creating
+// the directory first
+func writeStateFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return config.WriteFileAtomic(path, data, perm)
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestBootRecord_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "boot.json")
+
+	record, err := LoadBootRecord(path)
+	if err != nil || !record.BootTime.IsZero() {
+		t.Fatalf("Expected an empty record before the first run, got %+v (%v)", record, err)
+	}
+
+	boot := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	want := models.BootRecord{BootTime: boot, LastSeen: boot.Add(time.Hour)}
+	if err := SaveBootRecord(path, want); err != nil {
+		t.Fatalf("SaveBootRecord failed: %v", err)
+	}
+	record, err = LoadBootRecord(path)
+	if err != nil || !record.BootTime.Equal(want.BootTime) || !record.LastSeen.Equal(want.LastSeen) {
+		t.Errorf("Expected %+v back, got %+v (%v)", want, record, err)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBootRecord(path); err == nil {
+		t.Error("Expected an error for a corrupt record")
+	}
+}
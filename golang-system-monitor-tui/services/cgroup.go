@@ -0,0 +1,97 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang-system-monitor-tui/models"
+)
+
+// cgroupRoot is the mount point of the cgroup filesystem. Inside a container
+// with its own cgroup namespace it shows the container's own cgroup.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cgroupLimits are the memory and CPU limits of the cgroup the monitor runs
+// in. Zero values mean no limit was found.
+type cgroupLimits struct {
+	memoryLimit uint64  // Memory limit in bytes
+	memoryUsed  uint64  // Memory charged to the cgroup in bytes
+	cpuLimit    float64 // CPU quota in cores
+}
+
+// readCgroupLimits reads the limits of the cgroup mounted at root, trying the
+// unified cgroup v2 hierarchy before the v1 memory and cpu controllers.
+// Missing or unreadable files are treated as no limit.
+func readCgroupLimits(root string) cgroupLimits {
+	var limits cgroupLimits
+
+	if limit, ok := readCgroupUint(filepath.Join(root, "memory.max")); ok {
+		limits.memoryLimit = limit
+		limits.memoryUsed, _ = readCgroupUint(filepath.Join(root, "memory.current"))
+	} else if limit, ok := readCgroupUint(filepath.Join(root, "memory", "memory.limit_in_bytes")); ok {
+		limits.memoryLimit = limit
+		limits.memoryUsed, _ = readCgroupUint(filepath.Join(root, "memory", "memory.usage_in_bytes"))
+	}
+
+	if data, err := os.ReadFile(filepath.Join(root, "cpu.max")); err == nil {
+		// "<quota> <period>", quota being "max" without a limit
+		if fields := strings.Fields(string(data)); len(fields) == 2 {
+			limits.cpuLimit = cpuQuota(fields[0], fields[1])
+		}
+	} else {
+		quota, errQuota := os.ReadFile(filepath.Join(root, "cpu", "cpu.cfs_quota_us"))
+		period, errPeriod := os.ReadFile(filepath.Join(root, "cpu", "cpu.cfs_period_us"))
+		if errQuota == nil && errPeriod == nil {
+			limits.cpuLimit = cpuQuota(strings.TrimSpace(string(quota)), strings.TrimSpace(string(period)))
+		}
+	}
+	return limits
+}
+
+// readCgroupUint reads a cgroup file holding a byte count. "max" means no
+// limit and reports false.
+func readCgroupUint(path string) (uint64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// cpuQuota converts a CFS quota and period in microseconds to cores. A quota
+// of "max" or -1 means no limit and yields 0.
+func cpuQuota(quota, period string) float64 {
+	q, err := strconv.ParseFloat(quota, 64)
+	if err != nil || q <= 0 {
+		return 0
+	}
+	p, err := strconv.ParseFloat(period, 64)
+	if err != nil || p <= 0 {
+		return 0
+	}
+	return q / p
+}
+
+// applyMemory sets the cgroup limit on memory, when it is below the host's
+// memory; the v1 controller reports a huge number without a limit
+func (l cgroupLimits) applyMemory(memory *models.MemoryInfo) {
+	if l.memoryLimit == 0 || l.memoryLimit >= memory.Total {
+		return
+	}
+	memory.Limit = l.memoryLimit
+	memory.LimitUsed = l.memoryUsed
+}
+
+// applyCPU sets the cgroup CPU quota on cpu, when it is below the core count
+func (l cgroupLimits) applyCPU(cpu *models.CPUInfo) {
+	if l.cpuLimit == 0 || l.cpuLimit >= float64(cpu.Cores) {
+		return
+	}
+	cpu.Limit = l.cpuLimit
+}
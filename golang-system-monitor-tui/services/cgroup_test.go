@@ -0,0 +1,97 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+// writeCgroupFiles creates the given files below a temporary cgroup root
+func writeCgroupFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, contents := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestReadCgroupLimits_V2(t *testing.T) {
+	root := writeCgroupFiles(t, map[string]string{
+		"memory.max":     "4294967296\n",
+		"memory.current": "1073741824\n",
+		"cpu.max":        "150000 100000\n",
+	})
+	limits := readCgroupLimits(root)
+	if limits.memoryLimit != 4<<30 || limits.memoryUsed != 1<<30 {
+		t.Errorf("Expected a 4 GiB limit with 1 GiB used, got %+v", limits)
+	}
+	if limits.cpuLimit != 1.5 {
+		t.Errorf("Expected a quota of 1.5 cores, got %v", limits.cpuLimit)
+	}
+
+	unlimited := readCgroupLimits(writeCgroupFiles(t, map[string]string{
+		"memory.max": "max\n",
+		"cpu.max":    "max 100000\n",
+	}))
+	if unlimited != (cgroupLimits{}) {
+		t.Errorf("Expected no limits for max, got %+v", unlimited)
+	}
+}
+
+func TestReadCgroupLimits_V1(t *testing.T) {
+	root := writeCgroupFiles(t, map[string]string{
+		"memory/memory.limit_in_bytes": "2147483648\n",
+		"memory/memory.usage_in_bytes": "536870912\n",
+		"cpu/cpu.cfs_quota_us":         "200000\n",
+		"cpu/cpu.cfs_period_us":        "100000\n",
+	})
+	limits := readCgroupLimits(root)
+	if limits.memoryLimit != 2<<30 || limits.memoryUsed != 512<<20 || limits.cpuLimit != 2 {
+		t.Errorf("Expected the v1 controller limits, got %+v", limits)
+	}
+
+	unlimited := readCgroupLimits(writeCgroupFiles(t, map[string]string{
+		"cpu/cpu.cfs_quota_us":  "-1\n",
+		"cpu/cpu.cfs_period_us": "100000\n",
+	}))
+	if unlimited.cpuLimit != 0 {
+		t.Errorf("Expected no CPU limit for a quota of -1, got %v", unlimited.cpuLimit)
+	}
+
+	if limits := readCgroupLimits(filepath.Join(t.TempDir(), "missing")); limits != (cgroupLimits{}) {
+		t.Errorf("Expected no limits without a cgroup filesystem, got %+v", limits)
+	}
+}
+
+func TestCgroupLimits_Apply(t *testing.T) {
+	limits := cgroupLimits{memoryLimit: 4 << 30, memoryUsed: 1 << 30, cpuLimit: 2}
+
+	memory := models.MemoryInfo{Total: 8 << 30}
+	limits.applyMemory(&memory)
+	if memory.Limit != 4<<30 || memory.LimitUsed != 1<<30 {
+		t.Errorf("Expected the limit below the host memory, got %+v", memory)
+	}
+	cpu := models.CPUInfo{Cores: 8}
+	limits.applyCPU(&cpu)
+	if cpu.Limit != 2 {
+		t.Errorf("Expected a limit of 2 cores, got %v", cpu.Limit)
+	}
+
+	// Limits above the host's resources, such as v1's huge default, are no limit
+	memory = models.MemoryInfo{Total: 2 << 30}
+	limits.applyMemory(&memory)
+	cpu = models.CPUInfo{Cores: 2}
+	limits.applyCPU(&cpu)
+	if memory.Limit != 0 || cpu.Limit != 0 {
+		t.Errorf("Expected no limits at or above the host's resources, got %d and %v", memory.Limit, cpu.Limit)
+	}
+}
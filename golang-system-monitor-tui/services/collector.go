@@ -2,6 +2,8 @@ package services
 
 import (
 	"log"
+	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,15 +15,22 @@ import (
 	"golang-system-monitor-tui/models"
 )
 
+// cpuSampleInterval is the window CPU usage is measured over
+const cpuSampleInterval = time.Second
+
 // GopsutilCollector implements SystemCollector using gopsutil library
 type GopsutilCollector struct{
 	errorHandler *models.ErrorHandler
+	cgroupRoot   string // cgroup filesystem read for container limits
+	clock        models.Clock // Clock the samples are timestamped with
 }
 
 // NewGopsutilCollector creates a new instance of GopsutilCollector
 func NewGopsutilCollector() *GopsutilCollector {
 	return &GopsutilCollector{
 		errorHandler: models.NewErrorHandler(log.Default()),
+		cgroupRoot:   cgroupRoot,
+		clock:        models.SystemClock,
 	}
 }
 
@@ -29,13 +38,31 @@ func NewGopsutilCollector() *GopsutilCollector {
 func NewGopsutilCollectorWithErrorHandler(errorHandler *models.ErrorHandler) *GopsutilCollector {
 	return &GopsutilCollector{
 		errorHandler: errorHandler,
+		cgroupRoot:   cgroupRoot,
+		clock:        models.SystemClock,
 	}
 }
 
+// SetClock replaces the clock samples are timestamped with, so rates can be
+// tested over exact intervals
+func (g *GopsutilCollector) SetClock(clock models.Clock) {
+	g.clock = clock
+}
+
+// CPUSampleWindow returns how long CollectCPU waits for its per-core and
+// total samples
+func (g *GopsutilCollector) CPUSampleWindow() time.Duration {
+	return 2 * cpuSampleInterval
+}
+
 // CollectCPU gathers CPU usage information including per-core and total usage
 func (g *GopsutilCollector) CollectCPU() (models.CPUInfo, error) {
+	// Overall time counters around the per-core sample, split into user,
+	// system, iowait, steal and interrupts over the same window
+	before, beforeErr := cpu.Times(false)
+
 	// Get per-core CPU usage percentages
-	perCoreUsage, err := cpu.Percent(time.Second, true)
+	perCoreUsage, err := cpu.Percent(cpuSampleInterval, true)
 	if err != nil {
 		// Categorize the error based on its content
 		if g.isPermissionError(err) {
@@ -46,8 +73,13 @@ func (g *GopsutilCollector) CollectCPU() (models.CPUInfo, error) {
 		return models.CPUInfo{}, models.CreateSystemError(models.SystemAccessError, "CPU", "Failed to collect per-core CPU usage", err)
 	}
 
+	var times models.CPUTimes
+	if after, afterErr := cpu.Times(false); beforeErr == nil && afterErr == nil && len(before) > 0 && len(after) > 0 {
+		times = cpuTimesBetween(before[0], after[0])
+	}
+
 	// Get total CPU usage percentage
-	totalUsage, err := cpu.Percent(time.Second, false)
+	totalUsage, err := cpu.Percent(cpuSampleInterval, false)
 	if err != nil {
 		// If we have per-core data but total fails, calculate total from per-core
 		if len(perCoreUsage) > 0 {
@@ -57,12 +89,15 @@ func (g *GopsutilCollector) CollectCPU() (models.CPUInfo, error) {
 			}
 			total := sum / float64(len(perCoreUsage))
 			
-			return models.CPUInfo{
+			info := models.CPUInfo{
 				Cores:     len(perCoreUsage),
 				Usage:     perCoreUsage,
 				Total:     total,
-				Timestamp: time.Now(),
-			}, nil
+				Times:     times,
+				Timestamp: g.clock.Now(),
+			}
+			readCgroupLimits(g.cgroupRoot).applyCPU(&info)
+			return info, nil
 		}
 		
 		// Categorize the error
@@ -77,12 +112,41 @@ func (g *GopsutilCollector) CollectCPU() (models.CPUInfo, error) {
 		total = totalUsage[0]
 	}
 
-	return models.CPUInfo{
+	info := models.CPUInfo{
 		Cores:     len(perCoreUsage),
 		Usage:     perCoreUsage,
 		Total:     total,
-		Timestamp: time.Now(),
-	}, nil
+		Times:     times,
+		Timestamp: g.clock.Now(),
+	}
+	// Inside a container the quota, not the host's cores, bounds the usage
+	readCgroupLimits(g.cgroupRoot).applyCPU(&info)
+	return info, nil
+}
+
+// cpuTimesBetween returns the share of each kind of CPU time between two
+// readings of the cumulative counters. Guest time is already part of user
+// time on Linux, so it is not counted again.
+func cpuTimesBetween(before, after cpu.TimesStat) models.CPUTimes {
+	user := (after.User - before.User) + (after.Nice - before.Nice)
+	system := after.System - before.System
+	iowait := after.Iowait - before.Iowait
+	steal := after.Steal - before.Steal
+	irq := (after.Irq - before.Irq) + (after.Softirq - before.Softirq)
+	total := user + system + iowait + steal + irq + (after.Idle - before.Idle)
+	if total <= 0 {
+		return models.CPUTimes{}
+	}
+	share := func(delta float64) float64 {
+		return max(delta, 0) / total * 100
+	}
+	return models.CPUTimes{
+		User:   share(user),
+		System: share(system),
+		IOWait: share(iowait),
+		Steal:  share(steal),
+		IRQ:    share(irq),
+	}
 }
 
 // CollectMemory gathers memory usage information including RAM and swap
@@ -104,7 +168,7 @@ func (g *GopsutilCollector) CollectMemory() (models.MemoryInfo, error) {
 	if err != nil {
 		// If we have VM stats but swap fails, return VM stats with empty swap
 		if vmStat != nil {
-			return models.MemoryInfo{
+			info := models.MemoryInfo{
 				Total:     vmStat.Total,
 				Used:      vmStat.Used,
 				Available: vmStat.Available,
@@ -113,8 +177,12 @@ func (g *GopsutilCollector) CollectMemory() (models.MemoryInfo, error) {
 					Used:  0,
 					Free:  0,
 				},
-				Timestamp: time.Now(),
-			}, nil
+				Tmpfs:     g.collectTmpfs(),
+				HugePages: hugePages(vmStat),
+				Timestamp: g.clock.Now(),
+			}
+			readCgroupLimits(g.cgroupRoot).applyMemory(&info)
+			return info, nil
 		}
 		
 		// Categorize the error
@@ -124,7 +192,7 @@ func (g *GopsutilCollector) CollectMemory() (models.MemoryInfo, error) {
 		return models.MemoryInfo{}, models.CreateSystemError(models.SystemAccessError, "Memory", "Failed to collect swap memory statistics", err)
 	}
 
-	return models.MemoryInfo{
+	info := models.MemoryInfo{
 		Total:     vmStat.Total,
 		Used:      vmStat.Used,
 		Available: vmStat.Available,
@@ -133,8 +201,67 @@ func (g *GopsutilCollector) CollectMemory() (models.MemoryInfo, error) {
 			Used:  swapStat.Used,
 			Free:  swapStat.Free,
 		},
-		Timestamp: time.Now(),
-	}, nil
+		Tmpfs:     g.collectTmpfs(),
+		HugePages: hugePages(vmStat),
+		Timestamp: g.clock.Now(),
+	}
+	// Inside a container the cgroup limit, not the host's RAM, is what runs out
+	readCgroupLimits(g.cgroupRoot).applyMemory(&info)
+	return info, nil
+}
+
+// hugePages returns the huge page pool of the memory statistics, which
+// gopsutil fills in from /proc/meminfo on Linux only
+func hugePages(vmStat *mem.VirtualMemoryStat) models.HugePages {
+	return models.HugePages{
+		Total:    vmStat.HugePagesTotal,
+		Free:     vmStat.HugePagesFree,
+		Reserved: vmStat.HugePagesRsvd,
+		Size:     vmStat.HugePageSize,
+	}
+}
+
+// collectTmpfs gathers the usage of tmpfs mounts such as /dev/shm. Their
+// contents live in RAM, so they are reported with memory instead of disks.
+// Failures are not fatal to memory collection and yield no mounts.
+func (g *GopsutilCollector) collectTmpfs() []models.DiskInfo {
+	// Virtual filesystems are only listed with all partitions
+	partitions, err := disk.Partitions(true)
+	if err != nil {
+		return nil
+	}
+
+	var mounts []models.DiskInfo
+	seen := make(map[string]bool)
+	for _, partition := range partitions {
+		if partition.Fstype != "tmpfs" || seen[partition.Mountpoint] {
+			continue
+		}
+		seen[partition.Mountpoint] = true
+
+		usage, err := disk.Usage(partition.Mountpoint)
+		if err != nil || usage.Total == 0 {
+			continue
+		}
+		mounts = append(mounts, models.DiskInfo{
+			Device:      partition.Device,
+			Mountpoint:  partition.Mountpoint,
+			Filesystem:  partition.Fstype,
+			Total:       usage.Total,
+			Used:        usage.Used,
+			Available:   usage.Free,
+			UsedPercent: usage.UsedPercent,
+		})
+	}
+
+	// Shared memory first, then the fullest mounts
+	sort.SliceStable(mounts, func(i, j int) bool {
+		if (mounts[i].Mountpoint == "/dev/shm") != (mounts[j].Mountpoint == "/dev/shm") {
+			return mounts[i].Mountpoint == "/dev/shm"
+		}
+		return mounts[i].Used > mounts[j].Used
+	})
+	return mounts
 }
 
 // CollectDisk gathers disk usage information for all mounted filesystems
@@ -157,11 +284,7 @@ func (g *GopsutilCollector) CollectDisk() ([]models.DiskInfo, error) {
 
 	for _, partition := range partitions {
 		// Skip special filesystems that are not real storage devices
-		if partition.Fstype == "proc" || partition.Fstype == "sysfs" || 
-		   partition.Fstype == "devtmpfs" || partition.Fstype == "tmpfs" ||
-		   partition.Fstype == "devpts" || partition.Fstype == "cgroup" ||
-		   partition.Fstype == "cgroup2" || partition.Fstype == "pstore" ||
-		   partition.Fstype == "bpf" || partition.Fstype == "tracefs" {
+		if isPseudoFilesystem(partition.Fstype) {
 			continue
 		}
 
@@ -215,7 +338,9 @@ func (g *GopsutilCollector) CollectNetwork() ([]models.NetworkInfo, error) {
 	netStats, err := net.IOCounters(true)
 	if err != nil {
 		// Categorize the error
-		if g.isPermissionError(err) {
+		if g.isNotImplementedError(err) {
+			return nil, models.CreateSystemError(models.SystemAccessError, "Network", "Network statistics are not supported on "+runtime.GOOS, err)
+		} else if g.isPermissionError(err) {
 			return nil, models.CreateSystemError(models.PermissionError, "Network", "Permission denied accessing network interface statistics", err)
 		} else if g.isTemporaryError(err) {
 			return nil, models.CreateSystemError(models.TemporaryError, "Network", "Temporary error collecting network data", err)
@@ -224,17 +349,16 @@ func (g *GopsutilCollector) CollectNetwork() ([]models.NetworkInfo, error) {
 	}
 
 	var networkInfos []models.NetworkInfo
-	timestamp := time.Now()
+	timestamp := g.clock.Now()
 
 	for _, stat := range netStats {
-		// Skip loopback interfaces for cleaner output (different names on different platforms)
-		if stat.Name == "lo" || stat.Name == "Loopback" || 
-		   stat.Name == "Loopback Pseudo-Interface 1" {
+		// Skip loopback and other pseudo-interfaces for cleaner output (different names on different platforms)
+		if isPseudoInterface(interfaceName(stat.Name)) {
 			continue
 		}
 
 		networkInfo := models.NetworkInfo{
-			Interface:   stat.Name,
+			Interface:   interfaceName(stat.Name),
 			BytesSent:   stat.BytesSent,
 			BytesRecv:   stat.BytesRecv,
 			PacketsSent: stat.PacketsSent,
@@ -244,6 +368,13 @@ func (g *GopsutilCollector) CollectNetwork() ([]models.NetworkInfo, error) {
 		networkInfos = append(networkInfos, networkInfo)
 	}
 
+	// OpenBSD's counters are gathered in a map, keep the panel from reshuffling
+	if runtime.GOOS == "openbsd" {
+		sort.Slice(networkInfos, func(i, j int) bool {
+			return networkInfos[i].Interface < networkInfos[j].Interface
+		})
+	}
+
 	// Check if we have any network interfaces
 	if len(networkInfos) == 0 {
 		return nil, models.CreateSystemError(models.SystemAccessError, "Network", "No accessible network interfaces found", nil)
@@ -254,44 +385,7 @@ func (g *GopsutilCollector) CollectNetwork() ([]models.NetworkInfo, error) {
 
 // CalculateNetworkRates calculates transfer rates between two network measurements
 func (g *GopsutilCollector) CalculateNetworkRates(previous, current []models.NetworkInfo) map[string]models.NetworkStats {
-	rates := make(map[string]models.NetworkStats)
-	
-	// Create a map of previous measurements for quick lookup
-	prevMap := make(map[string]models.NetworkInfo)
-	for _, prev := range previous {
-		prevMap[prev.Interface] = prev
-	}
-	
-	for _, curr := range current {
-		if prev, exists := prevMap[curr.Interface]; exists {
-			timeDiff := curr.Timestamp.Sub(prev.Timestamp).Seconds()
-			if timeDiff > 0 {
-				var sendRate, recvRate float64
-				
-				// Handle counter rollover by checking if current < previous
-				if curr.BytesSent >= prev.BytesSent {
-					sendRate = float64(curr.BytesSent-prev.BytesSent) / timeDiff
-				} else {
-					// Counter rollover detected, set rate to 0
-					sendRate = 0
-				}
-				
-				if curr.BytesRecv >= prev.BytesRecv {
-					recvRate = float64(curr.BytesRecv-prev.BytesRecv) / timeDiff
-				} else {
-					// Counter rollover detected, set rate to 0
-					recvRate = 0
-				}
-				
-				rates[curr.Interface] = models.NetworkStats{
-					SendRate: sendRate,
-					RecvRate: recvRate,
-				}
-			}
-		}
-	}
-	
-	return rates
+	return models.CalculateNetworkRates(previous, current)
 }
 
 // isPermissionError checks if an error is related to permissions
@@ -309,4 +403,10 @@ func (g *GopsutilCollector) isTemporaryError(err error) bool {
 		   strings.Contains(errStr, "temporary") ||
 		   strings.Contains(errStr, "try again") ||
 		   strings.Contains(errStr, "resource temporarily unavailable")
-}
\ No newline at end of file
+}
+
+// isNotImplementedError checks if gopsutil lacks support for the platform,
+// such as network counters on NetBSD
+func (g *GopsutilCollector) isNotImplementedError(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "not implemented")
+}
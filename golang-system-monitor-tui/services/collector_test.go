@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/shirou/gopsutil/v3/cpu"
+
 	"golang-system-monitor-tui/models"
 )
 
@@ -70,6 +72,20 @@ func TestGopsutilCollector_CollectCPU(t *testing.T) {
 	}
 }
 
+func TestCPUTimesBetween(t *testing.T) {
+	before := cpu.TimesStat{User: 100, Nice: 10, System: 50, Idle: 800, Iowait: 20, Irq: 5, Softirq: 5, Steal: 10}
+	after := cpu.TimesStat{User: 130, Nice: 20, System: 60, Idle: 825, Iowait: 30, Irq: 7, Softirq: 8, Steal: 20}
+
+	// 100 seconds passed: 40 user, 10 system, 25 idle, 10 iowait, 5 irq and 10 steal
+	want := models.CPUTimes{User: 40, System: 10, IOWait: 10, Steal: 10, IRQ: 5}
+	if got := cpuTimesBetween(before, after); got != want {
+		t.Errorf("cpuTimesBetween() = %+v, want %+v", got, want)
+	}
+	if got := cpuTimesBetween(after, after); got.Known() {
+		t.Errorf("Expected no split without time passing, got %+v", got)
+	}
+}
+
 func TestGopsutilCollector_CollectMemory(t *testing.T) {
 	collector := NewGopsutilCollector()
 	
@@ -102,6 +118,37 @@ func TestGopsutilCollector_CollectMemory(t *testing.T) {
 	}
 }
 
+func TestGopsutilCollector_CollectMemory_Tmpfs(t *testing.T) {
+	collector := NewGopsutilCollector()
+
+	memInfo, err := collector.CollectMemory()
+	if err != nil {
+		t.Fatalf("CollectMemory failed: %v", err)
+	}
+	for i, mount := range memInfo.Tmpfs {
+		if mount.Filesystem != "tmpfs" {
+			t.Errorf("Expected only tmpfs mounts, got %s on %s", mount.Filesystem, mount.Mountpoint)
+		}
+		if mount.Used > mount.Total {
+			t.Errorf("Used tmpfs space (%d) cannot exceed total (%d) on %s", mount.Used, mount.Total, mount.Mountpoint)
+		}
+		if mount.Mountpoint == "/dev/shm" && i != 0 {
+			t.Error("Expected /dev/shm to be listed first")
+		}
+	}
+
+	// tmpfs consumes RAM, so it is never listed as a disk
+	diskInfos, err := collector.CollectDisk()
+	if err != nil {
+		t.Fatalf("CollectDisk failed: %v", err)
+	}
+	for _, disk := range diskInfos {
+		if disk.Filesystem == "tmpfs" {
+			t.Errorf("Expected tmpfs mount %s to be excluded from disks", disk.Mountpoint)
+		}
+	}
+}
+
 func TestGopsutilCollector_CollectDisk(t *testing.T) {
 	collector := NewGopsutilCollector()
 	
@@ -171,6 +218,41 @@ func TestGopsutilCollector_CollectNetwork(t *testing.T) {
 	}
 }
 
+// TestGopsutilCollector_SetClock tests that samples are stamped by the
+// collector's clock, so rates are taken over exact intervals
+func TestGopsutilCollector_SetClock(t *testing.T) {
+	collector := NewGopsutilCollector()
+	clock := models.NewFakeClock(time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC))
+	collector.SetClock(clock)
+
+	first, err := collector.CollectNetwork()
+	if err != nil {
+		t.Fatalf("CollectNetwork failed: %v", err)
+	}
+	if len(first) == 0 {
+		t.Skip("No network interfaces found, skipping clock test")
+	}
+	clock.Advance(2 * time.Second)
+	second, err := collector.CollectNetwork()
+	if err != nil {
+		t.Fatalf("CollectNetwork failed: %v", err)
+	}
+
+	for _, netInfo := range first {
+		if !netInfo.Timestamp.Equal(clock.Now().Add(-2 * time.Second)) {
+			t.Errorf("Expected %s stamped by the fake clock, got %v", netInfo.Interface, netInfo.Timestamp)
+		}
+	}
+	for _, netInfo := range second {
+		if !netInfo.Timestamp.Equal(clock.Now()) {
+			t.Errorf("Expected %s stamped after advancing, got %v", netInfo.Interface, netInfo.Timestamp)
+		}
+	}
+	if rates := collector.CalculateNetworkRates(first, second); len(rates) == 0 {
+		t.Error("Expected rates over the advanced interval")
+	}
+}
+
 // TestGopsutilCollector_ImplementsInterface verifies that GopsutilCollector implements SystemCollector
 func TestGopsutilCollector_ImplementsInterface(t *testing.T) {
 	var _ models.SystemCollector = (*GopsutilCollector)(nil)
@@ -375,6 +457,8 @@ func TestGopsutilCollector_IntegrationTest_FullCycle(t *testing.T) {
 		}
 	}
 	
+	clock := models.NewFakeClock(time.Now())
+	collector.SetClock(clock)
 	networkInfos, err := collector.CollectNetwork()
 	if err != nil {
 		t.Errorf("Network collection failed: %v", err)
@@ -387,8 +471,8 @@ func TestGopsutilCollector_IntegrationTest_FullCycle(t *testing.T) {
 	
 	// Test network rate calculation with real data
 	if len(networkInfos) > 0 {
-		// Wait a bit and collect again for rate calculation
-		time.Sleep(100 * time.Millisecond)
+		// Advance the clock and collect again for rate calculation
+		clock.Advance(time.Second)
 		
 		networkInfos2, err := collector.CollectNetwork()
 		if err != nil {
@@ -431,9 +515,7 @@ func TestGopsutilCollector_ErrorRecovery(t *testing.T) {
 		if err != nil {
 			t.Logf("Network collection attempt %d failed (may be expected): %v", i+1, err)
 		}
-		
-		// Small delay between attempts
-		time.Sleep(10 * time.Millisecond)
+
 	}
 }
 
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+const (
+	MaxBackoff       = time.Minute     // Bound on the delay between retries of a failing collector
+	BreakerThreshold = 5               // Failures in a row that pause a collector
+	BreakerCooldown  = 5 * time.Minute // How long a paused collector waits for its next probe
+)
+
+// componentHealth is the failure state of one collector
+type componentHealth struct {
+	failures int                    // Consecutive failed collections
+	retryAt  time.Time              // Collections are skipped until then
+	breaker  *models.CircuitBreaker // Pauses the collector after BreakerThreshold failures
+}
+
+// DegradationManager tracks consecutive failures per collector and backs off
+// the collection of failing ones: after n failures in a row the collector
+// waits interval×2^(n-1), at most MaxBackoff, before it is retried, and it is
+// back on every tick after its first success. After BreakerThreshold failures
+// its circuit breaker opens and only a probe every BreakerCooldown is let
+// through, so a hopelessly failing subsystem stops churning. It is safe for
+// concurrent use.
+type DegradationManager struct {
+	mu       sync.Mutex
+	interval time.Duration
+	health   map[string]*componentHealth
+}
+
+// NewDegradationManager creates a manager for collectors run every interval
+func NewDegradationManager(interval time.Duration) *DegradationManager {
+	return &DegradationManager{
+		interval: interval,
+		health:   make(map[string]*componentHealth),
+	}
+}
+
+// SetInterval changes the interval backoff delays are multiples of
+func (d *DegradationManager) SetInterval(interval time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.interval = interval
+}
+
+// Failure records a failed collection at now and returns the delay until the
+// collector is retried
+func (d *DegradationManager) Failure(name string, now time.Time) time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	health, ok := d.health[name]
+	if !ok {
+		health = &componentHealth{breaker: models.NewCircuitBreaker(BreakerThreshold, BreakerCooldown)}
+		d.health[name] = health
+	}
+	health.failures++
+	health.breaker.RecordFailure(now)
+	if health.breaker.State() == models.CircuitOpen {
+		health.retryAt = health.breaker.ProbeAt()
+		return health.retryAt.Sub(now)
+	}
+
+	delay := d.interval
+	for i := 1; i < health.failures && delay < MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, MaxBackoff)
+	health.retryAt = now.Add(delay)
+	return delay
+}
+
+// Success records a successful collection, ending the back-off
+func (d *DegradationManager) Success(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.health, name)
+}
+
+// Due reports whether the collector should run at now. For a paused
+// collector, the first call after the cooldown claims the probe.
+func (d *DegradationManager) Due(name string, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	health, ok := d.health[name]
+	if !ok {
+		return true
+	}
+	if health.breaker.State() != models.CircuitClosed {
+		return health.breaker.Allow(now)
+	}
+	return !now.Before(health.retryAt)
+}
+
+// Failures returns the number of consecutive failed collections
+func (d *DegradationManager) Failures(name string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if health, ok := d.health[name]; ok {
+		return health.failures
+	}
+	return 0
+}
+
+// State returns the state of the collector's circuit breaker
+func (d *DegradationManager) State(name string) models.CircuitState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if health, ok := d.health[name]; ok {
+		return health.breaker.State()
+	}
+	return models.CircuitClosed
+}
+
+// RetryIn returns the time left at now until the failing collector is
+// retried, or 0 when it runs on the next tick
+func (d *DegradationManager) RetryIn(name string, now time.Time) time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	health, ok := d.health[name]
+	if !ok || !health.retryAt.After(now) {
+		return 0
+	}
+	return health.retryAt.Sub(now)
+}
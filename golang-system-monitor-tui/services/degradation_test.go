@@ -0,0 +1,88 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestDegradationManager_Backoff(t *testing.T) {
+	manager := NewDegradationManager(time.Second)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if !manager.Due("CPU", now) || manager.RetryIn("CPU", now) != 0 {
+		t.Fatal("Expected a healthy collector to be due")
+	}
+
+	// The delay doubles with every failure in a row
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second} {
+		if delay := manager.Failure("CPU", now); delay != want {
+			t.Errorf("Failure %d: expected a delay of %v, got %v", i+1, want, delay)
+		}
+	}
+	if manager.Failures("CPU") != 4 {
+		t.Errorf("Expected 4 failures, got %d", manager.Failures("CPU"))
+	}
+	if manager.Due("CPU", now.Add(7*time.Second)) || !manager.Due("CPU", now.Add(8*time.Second)) {
+		t.Error("Expected the collector to be skipped until the delay has passed")
+	}
+	if retry := manager.RetryIn("CPU", now.Add(3*time.Second)); retry != 5*time.Second {
+		t.Errorf("Expected a retry in 5s, got %v", retry)
+	}
+	if !manager.Due("Memory", now) {
+		t.Error("Expected other collectors to be unaffected")
+	}
+
+	// One success recovers the collector
+	manager.Success("CPU")
+	if !manager.Due("CPU", now) || manager.Failures("CPU") != 0 {
+		t.Error("Expected the collector to be due again after a success")
+	}
+	if delay := manager.Failure("CPU", now); delay != time.Second {
+		t.Errorf("Expected the back-off to start over, got %v", delay)
+	}
+}
+
+func TestDegradationManager_MaxBackoff(t *testing.T) {
+	manager := NewDegradationManager(20 * time.Second)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 1; i < BreakerThreshold; i++ {
+		manager.Failure("Disk", now)
+	}
+	if retry := manager.RetryIn("Disk", now); retry != MaxBackoff {
+		t.Errorf("Expected the delay to stop at %v, got %v", MaxBackoff, retry)
+	}
+}
+
+func TestDegradationManager_CircuitBreaker(t *testing.T) {
+	manager := NewDegradationManager(time.Second)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 1; i < BreakerThreshold; i++ {
+		manager.Failure("Sensors", now)
+	}
+	if manager.State("Sensors") != models.CircuitClosed {
+		t.Fatalf("Expected the circuit closed below the threshold, got %v", manager.State("Sensors"))
+	}
+
+	// The last failure pauses the collector until the probe
+	if delay := manager.Failure("Sensors", now); delay != BreakerCooldown || manager.State("Sensors") != models.CircuitOpen {
+		t.Fatalf("Expected a pause of %v, got %v in state %v", BreakerCooldown, delay, manager.State("Sensors"))
+	}
+	if manager.Due("Sensors", now.Add(BreakerCooldown-time.Second)) {
+		t.Error("Expected no collection while the circuit is open")
+	}
+	if !manager.Due("Sensors", now.Add(BreakerCooldown)) || manager.Due("Sensors", now.Add(BreakerCooldown)) {
+		t.Error("Expected a single probe after the cooldown")
+	}
+
+	// A failed probe pauses it again, a successful one closes the circuit
+	probe := now.Add(BreakerCooldown)
+	if delay := manager.Failure("Sensors", probe); delay != BreakerCooldown || manager.State("Sensors") != models.CircuitOpen {
+		t.Errorf("Expected the failed probe to reopen the circuit, got %v in state %v", delay, manager.State("Sensors"))
+	}
+	manager.Due("Sensors", probe.Add(BreakerCooldown))
+	manager.Success("Sensors")
+	if manager.State("Sensors") != models.CircuitClosed || !manager.Due("Sensors", probe) {
+		t.Error("Expected the successful probe to close the circuit")
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang-system-monitor-tui/models"
+)
+
+// DesktopNotifier delivers alert events as native desktop notifications
+// using notify-send, osascript or a PowerShell balloon tip depending on the platform
+type DesktopNotifier struct {
+	goos string
+}
+
+// NewDesktopNotifier creates a desktop notifier for the current platform
+func NewDesktopNotifier() *DesktopNotifier {
+	return &DesktopNotifier{
+		goos: runtime.GOOS,
+	}
+}
+
+// Notify shows a desktop notification for the alert event
+func (d *DesktopNotifier) Notify(event models.AlertEvent) error {
+	name, args, err := desktopNotifyCommand(d.goos, event)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(name, args...)
+	if desktopNotifyDetached(d.goos) {
+		// The balloon tip only shows while PowerShell runs, so it is left
+		// running and reaped in the background instead of holding up the
+		// notifiers after this one
+		if err := cmd.Start(); err != nil {
+			return models.CreateSystemError(models.SystemAccessError, "Notifier",
+				fmt.Sprintf("%s failed to start", name), err)
+		}
+		go cmd.Wait()
+		return nil
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return models.CreateSystemError(models.SystemAccessError, "Notifier",
+			fmt.Sprintf("%s failed: %s", name, strings.TrimSpace(string(output))), err)
+	}
+	return nil
+}
+
+// desktopNotifyDetached reports whether the notification command keeps
+// running while the notification is shown, rather than exiting once posted
+func desktopNotifyDetached(goos string) bool {
+	return goos == "windows"
+}
+
+// desktopNotifyCommand builds the platform specific notification command
+func desktopNotifyCommand(goos string, event models.AlertEvent) (string, []string, error) {
+	title := "System Monitor: " + event.Title()
+	message := event.Description()
+
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s",
+			appleScriptQuote(message), appleScriptQuote(title))
+		return "osascript", []string{"-e", script}, nil
+
+	case "windows":
+		script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms; `+
+			`$n = New-Object System.Windows.Forms.NotifyIcon; `+
+			`$n.Icon = [System.Drawing.SystemIcons]::Warning; $n.Visible = $true; `+
+			`$n.ShowBalloonTip(10000, %s, %s, 'Warning'); Start-Sleep -Seconds 10; $n.Dispose()`,
+			powerShellQuote(title), powerShellQuote(message))
+		return "powershell", []string{"-NoProfile", "-Command", script}, nil
+
+	case "linux", "freebsd", "openbsd", "netbsd":
+		urgency := "normal"
+		if event.Fired {
+			urgency = "critical"
+		}
+		return "notify-send", []string{"-u", urgency, "-a", "System Monitor", title, message}, nil
+
+	default:
+		return "", nil, models.CreateSystemError(models.SystemAccessError, "Notifier",
+			"Desktop notifications are not supported on "+goos, nil)
+	}
+}
+
+// appleScriptQuote quotes a string literal for AppleScript
+func appleScriptQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// powerShellQuote quotes a string literal for PowerShell
+func powerShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
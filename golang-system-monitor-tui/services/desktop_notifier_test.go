@@ -0,0 +1,77 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestDesktopNotifyCommand(t *testing.T) {
+	event := models.AlertEvent{
+		Rule:    models.AlertRule{Component: "Disk", Threshold: 95},
+		Subject: `/mnt/"quoted"`,
+		Value:   97.5,
+		Fired:   true,
+	}
+
+	tests := []struct {
+		goos     string
+		wantName string
+		contains string
+	}{
+		{"linux", "notify-send", "critical"},
+		{"darwin", "osascript", `\"quoted\"`},
+		{"windows", "powershell", "ShowBalloonTip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, args, err := desktopNotifyCommand(tt.goos, event)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if name != tt.wantName {
+				t.Errorf("Expected command %s, got %s", tt.wantName, name)
+			}
+			if !strings.Contains(strings.Join(args, " "), tt.contains) {
+				t.Errorf("Expected args to contain %q, got %v", tt.contains, args)
+			}
+		})
+	}
+}
+
+func TestDesktopNotifyCommand_ClearedUrgency(t *testing.T) {
+	event := models.AlertEvent{Rule: models.AlertRule{Component: "Memory", Threshold: 95}, Value: 50}
+
+	_, args, err := desktopNotifyCommand("linux", event)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if args[1] != "normal" {
+		t.Errorf("Expected normal urgency for cleared alerts, got %s", args[1])
+	}
+}
+
+func TestDesktopNotifyDetached(t *testing.T) {
+	for goos, want := range map[string]bool{"windows": true, "linux": false, "darwin": false} {
+		if got := desktopNotifyDetached(goos); got != want {
+			t.Errorf("desktopNotifyDetached(%s) = %v, want %v", goos, got, want)
+		}
+	}
+}
+
+func TestDesktopNotifyCommand_Unsupported(t *testing.T) {
+	if _, _, err := desktopNotifyCommand("plan9", models.AlertEvent{}); err == nil {
+		t.Error("Expected error for unsupported platform")
+	}
+}
+
+func TestQuoting(t *testing.T) {
+	if got := powerShellQuote("it's"); got != "'it''s'" {
+		t.Errorf("Unexpected PowerShell quoting: %s", got)
+	}
+	if got := appleScriptQuote(`a"b`); got != `"a\"b"` {
+		t.Errorf("Unexpected AppleScript quoting: %s", got)
+	}
+}
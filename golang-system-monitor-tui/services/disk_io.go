@@ -0,0 +1,102 @@
+package services
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+
+	"golang-system-monitor-tui/models"
+)
+
+// DiskIOCollectorName is the registry name of the disk throughput collector
+const DiskIOCollectorName = "Disk I/O"
+
+// virtualDiskPrefixes name block devices whose I/O is counted again on the
+// disks below them (device mapper, software RAID) or never reaches a disk
+var virtualDiskPrefixes = []string{"loop", "ram", "zram", "dm-", "md"}
+
+// DiskIOCollector reports how fast the physical disks are read and written,
+// from the cumulative counters of the operating system
+type DiskIOCollector struct {
+	counters func() (map[string]disk.IOCountersStat, error)
+	now      func() time.Time
+
+	mu       sync.Mutex
+	previous models.DiskIO
+}
+
+// NewDiskIOCollector returns a collector of the disk throughput, or nil when
+// the platform has no disk counters
+func NewDiskIOCollector() *DiskIOCollector {
+	if _, err := disk.IOCounters(); err != nil {
+		return nil
+	}
+	return &DiskIOCollector{counters: func() (map[string]disk.IOCountersStat, error) { return disk.IOCounters() }, now: time.Now}
+}
+
+// Name returns the registry name of the disk throughput collector
+func (d *DiskIOCollector) Name() string {
+	return DiskIOCollectorName
+}
+
+// Collect returns the models.DiskIO of the physical disks combined, with
+// rates against the previous collection
+func (d *DiskIOCollector) Collect() (interface{}, error) {
+	counters, err := d.counters()
+	if err != nil {
+		return nil, models.CreateSystemError(models.SystemAccessError, DiskIOCollectorName, "Failed to read disk counters", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	current := sumDiskCounters(counters)
+	current.Timestamp = d.now()
+	if !d.previous.Timestamp.IsZero() {
+		elapsed := models.Elapsed(d.previous.Timestamp, current.Timestamp)
+		current.ReadRate = models.CounterRate(d.previous.ReadBytes, current.ReadBytes, elapsed)
+		current.WriteRate = models.CounterRate(d.previous.WriteBytes, current.WriteBytes, elapsed)
+	}
+	d.previous = current
+	return current, nil
+}
+
+// sumDiskCounters adds up the bytes of the whole disks, leaving out
+// partitions, which Linux counts again below their disk, and virtual devices
+func sumDiskCounters(counters map[string]disk.IOCountersStat) models.DiskIO {
+	var total models.DiskIO
+	for name, counter := range counters {
+		if isVirtualDisk(name) || isPartition(name, counters) {
+			continue
+		}
+		total.ReadBytes += counter.ReadBytes
+		total.WriteBytes += counter.WriteBytes
+	}
+	return total
+}
+
+// isVirtualDisk reports whether a block device is virtual
+func isVirtualDisk(name string) bool {
+	for _, prefix := range virtualDiskPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPartition reports whether name is a partition of another device listed,
+// e.g. sda1 of sda or nvme0n1p2 of nvme0n1
+func isPartition(name string, counters map[string]disk.IOCountersStat) bool {
+	for parent := range counters {
+		if parent == name || !strings.HasPrefix(name, parent) {
+			continue
+		}
+		suffix := strings.TrimPrefix(strings.TrimPrefix(name, parent), "p")
+		if suffix != "" && suffix[0] >= '0' && suffix[0] <= '9' {
+			return true
+		}
+	}
+	return false
+}
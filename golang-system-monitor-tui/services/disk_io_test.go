@@ -0,0 +1,60 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestSumDiskCounters(t *testing.T) {
+	counters := map[string]disk.IOCountersStat{
+		"sda":       {ReadBytes: 1000, WriteBytes: 2000},
+		"sda1":      {ReadBytes: 900, WriteBytes: 1900},
+		"nvme0n1":   {ReadBytes: 300, WriteBytes: 400},
+		"nvme0n1p2": {ReadBytes: 300, WriteBytes: 400},
+		"dm-0":      {ReadBytes: 900, WriteBytes: 1900},
+		"loop0":     {ReadBytes: 50},
+		"disk0":     {ReadBytes: 7, WriteBytes: 8},
+	}
+	got := sumDiskCounters(counters)
+	if got.ReadBytes != 1307 || got.WriteBytes != 2408 {
+		t.Errorf("Expected the whole disks only, got %+v", got)
+	}
+}
+
+func TestDiskIOCollector_Collect(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	read := uint64(1000)
+	collector := &DiskIOCollector{
+		counters: func() (map[string]disk.IOCountersStat, error) {
+			return map[string]disk.IOCountersStat{"sda": {ReadBytes: read, WriteBytes: 500}}, nil
+		},
+		now: func() time.Time { return now },
+	}
+	if collector.Name() != DiskIOCollectorName {
+		t.Errorf("Expected the name %q, got %q", DiskIOCollectorName, collector.Name())
+	}
+
+	data, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if io := data.(models.DiskIO); io.ReadBytes != 1000 || io.TotalRate() != 0 {
+		t.Errorf("Expected no rates on the first sample, got %+v", io)
+	}
+
+	now, read = now.Add(2*time.Second), 5000
+	data, _ = collector.Collect()
+	if io := data.(models.DiskIO); io.ReadRate != 2000 || io.WriteRate != 0 {
+		t.Errorf("Expected 2000 B/s read, got %+v", io)
+	}
+
+	collector.counters = func() (map[string]disk.IOCountersStat, error) { return nil, errors.New("no /proc/diskstats") }
+	if _, err := collector.Collect(); err == nil {
+		t.Error("Expected an error when the counters cannot be read")
+	}
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// ContainersCollectorName is the name container collections are backed off
+// under when they fail, matching the component of their errors
+const ContainersCollectorName = "Containers"
+
+// defaultDockerSocket is the Docker Engine API socket used when DOCKER_HOST is unset
+const defaultDockerSocket = "/var/run/docker.sock"
+
+// DockerCollector implements ContainerCollector against the Docker Engine API
+type DockerCollector struct {
+	client  *http.Client
+	baseURL string
+	running sync.Mutex // Held while containers are collected, a slow daemon is not queried twice
+	mu      sync.Mutex
+	prevCPU map[string]dockerCPUSample // Previous CPU counters per container for usage deltas
+	last    []models.ContainerInfo     // Result of the last finished collection
+	lastErr error                      // Error of the last finished collection
+}
+
+// dockerCPUSample holds the cumulative CPU counters of one stats reading
+type dockerCPUSample struct {
+	container uint64
+	system    uint64
+}
+
+// NewDockerCollector creates a collector talking to the local Docker daemon,
+// honoring a unix:// DOCKER_HOST
+func NewDockerCollector() *DockerCollector {
+	socket := defaultDockerSocket
+	if host := os.Getenv("DOCKER_HOST"); strings.HasPrefix(host, "unix://") {
+		socket = strings.TrimPrefix(host, "unix://")
+	}
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		},
+	}
+
+	return &DockerCollector{
+		client:  &http.Client{Transport: transport, Timeout: 5 * time.Second},
+		baseURL: "http://docker",
+	}
+}
+
+// dockerContainerSummary mirrors the fields used from GET /containers/json
+type dockerContainerSummary struct {
+	ID     string            `json:"Id"`
+	Names  []string          `json:"Names"`
+	Image  string            `json:"Image"`
+	State  string            `json:"State"`
+	Labels map[string]string `json:"Labels"`
+}
+
+// dockerContainerInspect mirrors the fields used from GET /containers/{id}/json
+type dockerContainerInspect struct {
+	RestartCount int `json:"RestartCount"`
+	State        struct {
+		StartedAt string `json:"StartedAt"`
+		Health    *struct {
+			Status string `json:"Status"`
+		} `json:"Health"`
+	} `json:"State"`
+}
+
+// dockerContainerStats mirrors the fields used from GET /containers/{id}/stats
+type dockerContainerStats struct {
+	CPUStats struct {
+		CPUUsage struct {
+			TotalUsage uint64 `json:"total_usage"`
+		} `json:"cpu_usage"`
+		SystemUsage uint64 `json:"system_cpu_usage"`
+		OnlineCPUs  int    `json:"online_cpus"`
+	} `json:"cpu_stats"`
+	MemoryStats struct {
+		Usage uint64            `json:"usage"`
+		Stats map[string]uint64 `json:"stats"`
+	} `json:"memory_stats"`
+}
+
+// CollectContainers lists all containers with image, uptime, restart and health
+// metadata. While an earlier collection is still waiting for the daemon, its
+// last result is returned instead, so collections don't pile up.
+func (d *DockerCollector) CollectContainers() ([]models.ContainerInfo, error) {
+	if !d.running.TryLock() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return d.last, d.lastErr
+	}
+	defer d.running.Unlock()
+
+	containers, err := d.collectContainers()
+	d.mu.Lock()
+	d.last, d.lastErr = containers, err
+	d.mu.Unlock()
+	return containers, err
+}
+
+// collectContainers queries the daemon for the containers and their stats
+func (d *DockerCollector) collectContainers() ([]models.ContainerInfo, error) {
+	var summaries []dockerContainerSummary
+	if err := d.getJSON("/containers/json?all=1", &summaries); err != nil {
+		return nil, models.CreateSystemError(models.SystemAccessError, "Containers", "Failed to list containers", err)
+	}
+
+	containers := make([]models.ContainerInfo, 0, len(summaries))
+	for _, summary := range summaries {
+		container := models.ContainerInfo{
+			ID:     summary.ID,
+			Name:   containerName(summary),
+			Image:  summary.Image,
+			State:  summary.State,
+			Labels: summary.Labels,
+		}
+
+		// Restart count and health are only available from the inspect endpoint
+		var inspect dockerContainerInspect
+		if err := d.getJSON("/containers/"+url.PathEscape(summary.ID)+"/json", &inspect); err == nil {
+			container.RestartCount = inspect.RestartCount
+			if inspect.State.Health != nil {
+				container.Health = inspect.State.Health.Status
+			}
+			if startedAt, err := time.Parse(time.RFC3339Nano, inspect.State.StartedAt); err == nil {
+				container.StartedAt = startedAt
+			}
+		}
+
+		if container.State == "running" {
+			d.collectStats(&container)
+		}
+
+		containers = append(containers, container)
+	}
+
+	d.pruneStats(containers)
+	return containers, nil
+}
+
+// collectStats fills in CPU and memory usage of a running container. A one-shot
+// stats reading carries no previous sample, so CPU usage is computed against the
+// counters seen on the previous collection and stays zero on the first one.
+func (d *DockerCollector) collectStats(container *models.ContainerInfo) {
+	var stats dockerContainerStats
+	if err := d.getJSON("/containers/"+url.PathEscape(container.ID)+"/stats?stream=false&one-shot=true", &stats); err != nil {
+		return
+	}
+
+	// Match docker stats: page cache is reclaimable and not counted as usage
+	cache := stats.MemoryStats.Stats["inactive_file"] // cgroup v2
+	if cache == 0 {
+		cache = stats.MemoryStats.Stats["total_inactive_file"] // cgroup v1
+	}
+	if cache < stats.MemoryStats.Usage {
+		container.MemoryUsage = stats.MemoryStats.Usage - cache
+	}
+
+	sample := dockerCPUSample{
+		container: stats.CPUStats.CPUUsage.TotalUsage,
+		system:    stats.CPUStats.SystemUsage,
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.prevCPU == nil {
+		d.prevCPU = make(map[string]dockerCPUSample)
+	}
+	if prev, ok := d.prevCPU[container.ID]; ok && sample.system > prev.system && sample.container >= prev.container {
+		cpus := stats.CPUStats.OnlineCPUs
+		if cpus == 0 {
+			cpus = 1
+		}
+		cpuDelta := float64(sample.container - prev.container)
+		systemDelta := float64(sample.system - prev.system)
+		container.CPUPercent = cpuDelta / systemDelta * float64(cpus) * 100
+	}
+	d.prevCPU[container.ID] = sample
+}
+
+// pruneStats drops CPU samples of containers that no longer exist
+func (d *DockerCollector) pruneStats(containers []models.ContainerInfo) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	present := make(map[string]bool, len(containers))
+	for _, container := range containers {
+		present[container.ID] = true
+	}
+	for id := range d.prevCPU {
+		if !present[id] {
+			delete(d.prevCPU, id)
+		}
+	}
+}
+
+// ContainerLogs fetches the last lines of a container's combined stdout and stderr
+func (d *DockerCollector) ContainerLogs(id string, lines int) ([]string, error) {
+	path := fmt.Sprintf("/containers/%s/logs?stdout=1&stderr=1&tail=%d", url.PathEscape(id), lines)
+	resp, err := d.client.Get(d.baseURL + path)
+	if err != nil {
+		return nil, models.CreateSystemError(models.SystemAccessError, "Containers", "Failed to fetch container logs", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, models.CreateSystemError(models.DataCollectionError, "Containers",
+			fmt.Sprintf("Docker returned status %d for container logs", resp.StatusCode), nil)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, models.CreateSystemError(models.DataCollectionError, "Containers", "Failed to read container logs", err)
+	}
+
+	text := strings.TrimRight(demuxDockerLogs(raw), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// getJSON performs a GET request and decodes the JSON response
+func (d *DockerCollector) getJSON(path string, target interface{}) error {
+	resp, err := d.client.Get(d.baseURL + path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("docker returned status %d for %s", resp.StatusCode, path)
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// containerName returns the primary container name without the leading slash
+func containerName(summary dockerContainerSummary) string {
+	if len(summary.Names) == 0 {
+		if len(summary.ID) > 12 {
+			return summary.ID[:12]
+		}
+		return summary.ID
+	}
+	return strings.TrimPrefix(summary.Names[0], "/")
+}
+
+// demuxDockerLogs strips the 8-byte stream headers Docker adds to logs of
+// containers without a TTY; TTY logs are returned unchanged
+func demuxDockerLogs(raw []byte) string {
+	var out strings.Builder
+	for len(raw) >= 8 {
+		stream := raw[0]
+		if stream > 2 || raw[1] != 0 || raw[2] != 0 || raw[3] != 0 {
+			// Not a multiplexed frame, treat the remainder as plain text
+			break
+		}
+		size := int(binary.BigEndian.Uint32(raw[4:8]))
+		if 8+size > len(raw) {
+			size = len(raw) - 8
+		}
+		out.Write(raw[8 : 8+size])
+		raw = raw[8+size:]
+	}
+	out.Write(raw)
+	return out.String()
+}
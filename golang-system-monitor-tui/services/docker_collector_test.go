@@ -0,0 +1,181 @@
+package services
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newTestDockerCollector(handler http.Handler) (*DockerCollector, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	return &DockerCollector{client: server.Client(), baseURL: server.URL}, server
+}
+
+func TestDockerCollector_CollectContainers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"Id": "abc123", "Names": ["/web"], "Image": "nginx:1.25", "State": "running", "Labels": {"com.docker.compose.project": "shop"}},
+			{"Id": "def456", "Names": ["/db"], "Image": "postgres", "State": "exited"}
+		]`))
+	})
+	mux.HandleFunc("/containers/abc123/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"RestartCount": 3, "State": {"StartedAt": "2024-01-15T10:00:00.123456789Z", "Health": {"Status": "healthy"}}}`))
+	})
+	mux.HandleFunc("/containers/def456/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"RestartCount": 0, "State": {"StartedAt": "0001-01-01T00:00:00Z"}}`))
+	})
+
+	collector, server := newTestDockerCollector(mux)
+	defer server.Close()
+
+	containers, err := collector.CollectContainers()
+	if err != nil {
+		t.Fatalf("CollectContainers failed: %v", err)
+	}
+	if len(containers) != 2 {
+		t.Fatalf("Expected 2 containers, got %d", len(containers))
+	}
+
+	web := containers[0]
+	if web.Name != "web" || web.ImageName() != "nginx" || web.ImageTag() != "1.25" {
+		t.Errorf("Unexpected web container metadata: %+v", web)
+	}
+	if web.RestartCount != 3 || web.Health != "healthy" {
+		t.Errorf("Expected restart count 3 and healthy status, got %+v", web)
+	}
+	if web.StartedAt.IsZero() {
+		t.Error("Expected start time to be parsed")
+	}
+	if web.Labels["com.docker.compose.project"] != "shop" {
+		t.Errorf("Expected labels to be carried over, got %v", web.Labels)
+	}
+
+	if containers[1].Health != "" {
+		t.Errorf("Expected empty health without a health check, got %q", containers[1].Health)
+	}
+}
+
+func TestDockerCollector_Unavailable(t *testing.T) {
+	collector, server := newTestDockerCollector(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if _, err := collector.CollectContainers(); err == nil {
+		t.Error("Expected error when the daemon fails")
+	}
+}
+
+func TestDockerCollector_SlowDaemon(t *testing.T) {
+	var requests atomic.Int32
+	entered, release := make(chan struct{}), make(chan struct{})
+	collector, server := newTestDockerCollector(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/containers/json" {
+			http.NotFound(w, r)
+			return
+		}
+		if requests.Add(1) == 2 {
+			close(entered)
+			<-release
+		}
+		w.Write([]byte(`[{"Id": "abc123", "Names": ["/web"], "Image": "nginx", "State": "exited"}]`))
+	}))
+	defer server.Close()
+
+	if _, err := collector.CollectContainers(); err != nil {
+		t.Fatalf("CollectContainers failed: %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		collector.CollectContainers()
+		close(done)
+	}()
+	<-entered
+
+	// The daemon is still answering the second collection, a third one
+	// returns the last result without another request
+	containers, err := collector.CollectContainers()
+	if err != nil || len(containers) != 1 || containers[0].Name != "web" {
+		t.Errorf("Expected the last result while a collection runs, got %v, %v", containers, err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected 2 requests to the daemon, got %d", requests.Load())
+	}
+	close(release)
+	<-done
+}
+
+func TestDockerCollector_Stats(t *testing.T) {
+	var totalUsage, systemUsage uint64 = 1000, 100000
+	mux := http.NewServeMux()
+	mux.HandleFunc("/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"Id": "abc123", "Names": ["/web"], "Image": "nginx", "State": "running"}]`))
+	})
+	mux.HandleFunc("/containers/abc123/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"RestartCount": 0, "State": {}}`))
+	})
+	mux.HandleFunc("/containers/abc123/stats", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("stream") != "false" {
+			t.Errorf("Expected a single stats reading, got stream=%s", r.URL.Query().Get("stream"))
+		}
+		fmt.Fprintf(w, `{"cpu_stats": {"cpu_usage": {"total_usage": %d}, "system_cpu_usage": %d, "online_cpus": 4},
+			"memory_stats": {"usage": 5000, "stats": {"inactive_file": 1000}}}`, totalUsage, systemUsage)
+	})
+
+	collector, server := newTestDockerCollector(mux)
+	defer server.Close()
+
+	containers, err := collector.CollectContainers()
+	if err != nil {
+		t.Fatalf("CollectContainers failed: %v", err)
+	}
+	if containers[0].MemoryUsage != 4000 {
+		t.Errorf("Expected 4000 bytes excluding page cache, got %d", containers[0].MemoryUsage)
+	}
+	if containers[0].CPUPercent != 0 {
+		t.Errorf("Expected no CPU usage without a previous sample, got %.1f", containers[0].CPUPercent)
+	}
+
+	// 500 of 10000 system ticks on 4 CPUs is 20%
+	totalUsage, systemUsage = 1500, 110000
+	containers, _ = collector.CollectContainers()
+	if containers[0].CPUPercent < 19.99 || containers[0].CPUPercent > 20.01 {
+		t.Errorf("Expected 20%% CPU usage, got %.2f", containers[0].CPUPercent)
+	}
+}
+
+func TestDockerCollector_ContainerLogs(t *testing.T) {
+	frame := func(stream byte, text string) []byte {
+		header := make([]byte, 8)
+		header[0] = stream
+		binary.BigEndian.PutUint32(header[4:], uint32(len(text)))
+		return append(header, text...)
+	}
+
+	collector, server := newTestDockerCollector(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tail") != "20" {
+			t.Errorf("Expected tail=20, got %s", r.URL.Query().Get("tail"))
+		}
+		w.Write(frame(1, "starting\n"))
+		w.Write(frame(2, "warning: slow\n"))
+	}))
+	defer server.Close()
+
+	lines, err := collector.ContainerLogs("abc123", 20)
+	if err != nil {
+		t.Fatalf("ContainerLogs failed: %v", err)
+	}
+	if len(lines) != 2 || lines[0] != "starting" || lines[1] != "warning: slow" {
+		t.Errorf("Unexpected log lines: %q", lines)
+	}
+}
+
+func TestDemuxDockerLogs_TTY(t *testing.T) {
+	if got := demuxDockerLogs([]byte("plain tty output\n")); got != "plain tty output\n" {
+		t.Errorf("Expected TTY logs unchanged, got %q", got)
+	}
+}
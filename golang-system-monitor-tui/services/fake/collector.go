@@ -0,0 +1,185 @@
+// Package fake provides a deterministic models.SystemCollector with scenario
+// builders, for tests, demos and replays that must not depend on the host.
+package fake
+
+import (
+	"sync"
+
+	"golang-system-monitor-tui/models"
+)
+
+const (
+	gib = 1024 * 1024 * 1024
+
+	// Components, as reported in collection errors and accepted by Fail
+	CPU     = "CPU"
+	Memory  = "Memory"
+	Disk    = "Disk"
+	Network = "Network"
+)
+
+// Collector returns a fixed baseline machine, 4 cores, 16GB of memory, one
+// 500GB disk and one interface, reshaped by its scenarios. Every collection
+// of a metric counts as one sample of it, so values only depend on how often
+// each metric was collected. Collector is safe for concurrent use.
+type Collector struct {
+	mu        sync.Mutex
+	clock     models.Clock
+	scenarios []Scenario
+	calls     map[string]int // Collections per component
+	failing   string         // Component whose collection fails
+}
+
+// NewCollector creates a collector applying the scenarios in order
+func NewCollector(scenarios ...Scenario) *Collector {
+	return &Collector{
+		clock:     models.SystemClock,
+		scenarios: scenarios,
+		calls:     make(map[string]int),
+	}
+}
+
+// SetClock replaces the clock samples are timestamped with
+func (c *Collector) SetClock(clock models.Clock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clock = clock
+}
+
+// Fail makes collections of the component (CPU, Memory, Disk or Network)
+// fail with a system access error until Recover is called
+func (c *Collector) Fail(component string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failing = component
+}
+
+// Recover makes every collection succeed again
+func (c *Collector) Recover() {
+	c.Fail("")
+}
+
+// CallCounts returns how often CPU, memory, disk and network were collected
+func (c *Collector) CallCounts() (cpu, memory, disk, network int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[CPU], c.calls[Memory], c.calls[Disk], c.calls[Network]
+}
+
+// sample counts a collection of the component and returns its sample
+// number, from 1, or the simulated failure
+func (c *Collector) sample(component string) (int, error) {
+	c.calls[component]++
+	if c.failing == component {
+		return 0, models.CreateSystemError(models.SystemAccessError, component, "Fake "+component+" error", nil)
+	}
+	return c.calls[component], nil
+}
+
+// CollectCPU returns the CPU sample
+func (c *Collector) CollectCPU() (models.CPUInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, err := c.sample(CPU)
+	if err != nil {
+		return models.CPUInfo{}, err
+	}
+
+	cpu := models.CPUInfo{
+		Cores:     4,
+		Usage:     []float64{25.0, 50.0, 75.0, 90.0},
+		Total:     60.0,
+		Timestamp: c.clock.Now(),
+	}
+	for _, scenario := range c.scenarios {
+		if scenario.CPU != nil {
+			scenario.CPU(n, &cpu)
+		}
+	}
+	return cpu, nil
+}
+
+// CollectMemory returns the memory sample
+func (c *Collector) CollectMemory() (models.MemoryInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, err := c.sample(Memory)
+	if err != nil {
+		return models.MemoryInfo{}, err
+	}
+
+	memory := models.MemoryInfo{
+		Total:     16 * gib,
+		Used:      8 * gib,
+		Available: 8 * gib,
+		Swap: models.SwapInfo{
+			Total: 4 * gib,
+			Used:  1 * gib,
+			Free:  3 * gib,
+		},
+		Timestamp: c.clock.Now(),
+	}
+	for _, scenario := range c.scenarios {
+		if scenario.Memory != nil {
+			scenario.Memory(n, &memory)
+		}
+	}
+	return memory, nil
+}
+
+// CollectDisk returns the disk sample
+func (c *Collector) CollectDisk() ([]models.DiskInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, err := c.sample(Disk)
+	if err != nil {
+		return nil, err
+	}
+
+	disks := []models.DiskInfo{{
+		Device:      "/dev/sda1",
+		Mountpoint:  "/",
+		Filesystem:  "ext4",
+		Total:       500 * gib,
+		Used:        300 * gib,
+		Available:   200 * gib,
+		UsedPercent: 60.0,
+	}}
+	for _, scenario := range c.scenarios {
+		if scenario.Disk != nil {
+			disks = scenario.Disk(n, disks)
+		}
+	}
+	return disks, nil
+}
+
+// CollectNetwork returns the network sample. The baseline interface eth0
+// sends 1000 and receives 2000 bytes per sample.
+func (c *Collector) CollectNetwork() ([]models.NetworkInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, err := c.sample(Network)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces := []models.NetworkInfo{{
+		Interface:   "eth0",
+		BytesSent:   uint64(n * 1000),
+		BytesRecv:   uint64(n * 2000),
+		PacketsSent: uint64(n * 10),
+		PacketsRecv: uint64(n * 20),
+		Timestamp:   c.clock.Now(),
+	}}
+	for _, scenario := range c.scenarios {
+		if scenario.Network != nil {
+			interfaces = scenario.Network(n, interfaces)
+		}
+	}
+	return interfaces, nil
+}
+
+// CalculateNetworkRates calculates transfer rates between two samples
+func (c *Collector) CalculateNetworkRates(previous, current []models.NetworkInfo) map[string]models.NetworkStats {
+	return models.CalculateNetworkRates(previous, current)
+}
@@ -0,0 +1,78 @@
+package fake
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestCollector_Baseline(t *testing.T) {
+	clock := models.NewFakeClock(time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC))
+	collector := NewCollector()
+	collector.SetClock(clock)
+
+	cpu, err := collector.CollectCPU()
+	if err != nil || cpu.Cores != 4 || cpu.Total != 60 || !cpu.Timestamp.Equal(clock.Now()) {
+		t.Errorf("Unexpected CPU sample %+v (%v)", cpu, err)
+	}
+	memory, err := collector.CollectMemory()
+	if err != nil || memory.Total != 16*gib || memory.Used != 8*gib || memory.Swap.Used != gib {
+		t.Errorf("Unexpected memory sample %+v (%v)", memory, err)
+	}
+	disks, err := collector.CollectDisk()
+	if err != nil || len(disks) != 1 || disks[0].Mountpoint != "/" || disks[0].UsedPercent != 60 {
+		t.Errorf("Unexpected disk sample %+v (%v)", disks, err)
+	}
+
+	first, _ := collector.CollectNetwork()
+	clock.Advance(time.Second)
+	second, _ := collector.CollectNetwork()
+	rates := collector.CalculateNetworkRates(first, second)
+	if rates["eth0"].SendRate != 1000 || rates["eth0"].RecvRate != 2000 {
+		t.Errorf("Expected 1000 and 2000 bytes per second, got %+v", rates["eth0"])
+	}
+
+	if cpu, memory, disk, network := collector.CallCounts(); cpu != 1 || memory != 1 || disk != 1 || network != 2 {
+		t.Errorf("Unexpected call counts %d, %d, %d, %d", cpu, memory, disk, network)
+	}
+}
+
+func TestCollector_Fail(t *testing.T) {
+	collector := NewCollector()
+	collector.Fail(Disk)
+
+	if _, err := collector.CollectDisk(); err == nil || !strings.Contains(err.Error(), "Fake Disk error") {
+		t.Errorf("Expected the simulated disk error, got %v", err)
+	}
+	if _, err := collector.CollectCPU(); err != nil {
+		t.Errorf("Expected the other components to keep working, got %v", err)
+	}
+
+	collector.Recover()
+	if _, err := collector.CollectDisk(); err != nil {
+		t.Errorf("Expected the disk to recover, got %v", err)
+	}
+	if _, _, disk, _ := collector.CallCounts(); disk != 2 {
+		t.Errorf("Expected failed collections to count, got %d", disk)
+	}
+}
+
+func TestCollector_Concurrent(t *testing.T) {
+	collector := NewCollector(SpikyCPU(2))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			collector.CollectCPU()
+			collector.CollectNetwork()
+		}()
+	}
+	wg.Wait()
+	if cpu, _, _, network := collector.CallCounts(); cpu != 8 || network != 8 {
+		t.Errorf("Expected 8 collections each, got %d and %d", cpu, network)
+	}
+}
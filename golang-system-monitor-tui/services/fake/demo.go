@@ -0,0 +1,226 @@
+package fake
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// demoProcess is one of the processes the demo machine runs
+type demoProcess struct {
+	pid    int32
+	name   string
+	cpu    float64 // Mean CPU usage in percent
+	rss    uint64
+	shared float64 // Share of RSS shared with other processes
+}
+
+var demoProcesses = []demoProcess{
+	{pid: 1, name: "systemd", cpu: 0.2, rss: 12 << 20, shared: 0.6},
+	{pid: 812, name: "postgres", cpu: 18, rss: 1200 << 20, shared: 0.7},
+	{pid: 1034, name: "nginx", cpu: 6, rss: 96 << 20, shared: 0.5},
+	{pid: 1420, name: "node", cpu: 32, rss: 640 << 20, shared: 0.2},
+	{pid: 2211, name: "redis-server", cpu: 4, rss: 310 << 20, shared: 0.1},
+	{pid: 3107, name: "firefox", cpu: 24, rss: 2100 << 20, shared: 0.4},
+	{pid: 3560, name: "code", cpu: 12, rss: 900 << 20, shared: 0.35},
+	{pid: 4096, name: "dockerd", cpu: 2, rss: 140 << 20, shared: 0.3},
+	{pid: 5120, name: "go", cpu: 45, rss: 420 << 20, shared: 0.15},
+	{pid: 6001, name: "sshd", cpu: 0.1, rss: 8 << 20, shared: 0.5},
+}
+
+// Demo is a Collector of a busy, good-looking workstation for screenshots,
+// docs and machines where collection is restricted: 8 cores running waves
+// of load, breathing memory, three disks, wired and wireless traffic, warm
+// sensors and a handful of familiar processes. Values are smooth and vary
+// per sample yet are fully deterministic.
+type Demo struct {
+	*Collector
+
+	mu        sync.Mutex
+	processes int       // Process samples taken
+	sensors   int       // Sensor samples taken
+	booted    time.Time // Boot time reported by CollectHost
+}
+
+// NewDemo creates the demo collector
+func NewDemo() *Demo {
+	return &Demo{
+		Collector: NewCollector(DemoScenario()),
+		booted:    time.Now().Add(-(3*24*time.Hour + 7*time.Hour + 42*time.Minute)),
+	}
+}
+
+// DemoScenario reshapes the baseline machine into the demo workstation
+func DemoScenario() Scenario {
+	var (
+		mu         sync.Mutex
+		last       int
+		sent, recv [2]uint64
+	)
+	return Scenario{
+		Name: "demo",
+		CPU: func(n int, cpu *models.CPUInfo) {
+			cpu.Cores = 8
+			cpu.Usage = make([]float64, cpu.Cores)
+			total := 0.0
+			for i := range cpu.Usage {
+				base := 15 + 55*wave(n, 40+float64(i)*7, float64(i))
+				cpu.Usage[i] = clamp(base+12*jitter(n, i), 1, 100)
+				total += cpu.Usage[i]
+			}
+			cpu.Total = total / float64(cpu.Cores)
+			// Mostly user time, with bursts of I/O wait and a little steal
+			iowait := 6 * wave(n, 55, 1)
+			cpu.Times = models.CPUTimes{
+				User:   cpu.Total * 0.72,
+				System: cpu.Total * 0.2,
+				IOWait: iowait,
+				Steal:  cpu.Total * 0.03,
+				IRQ:    cpu.Total * 0.05,
+			}
+		},
+		Memory: func(n int, memory *models.MemoryInfo) {
+			memory.Total = 32 * gib
+			memory.Used = uint64((0.45 + 0.2*wave(n, 90, 0) + 0.02*jitter(n, 0)) * float64(memory.Total))
+			memory.Available = memory.Total - memory.Used
+			memory.Swap.Total = 8 * gib
+			memory.Swap.Used = uint64((0.05 + 0.1*wave(n, 300, 1)) * float64(memory.Swap.Total))
+			memory.Swap.Free = memory.Swap.Total - memory.Swap.Used
+		},
+		Disk: func(n int, _ []models.DiskInfo) []models.DiskInfo {
+			return []models.DiskInfo{
+				demoDisk("/dev/nvme0n1p2", "/", "ext4", 512*gib, 0.58+0.01*wave(n, 900, 1)),
+				demoDisk("/dev/nvme0n1p3", "/home", "ext4", 1024*gib, 0.71+0.02*wave(n, 600, 0)),
+				demoDisk("/dev/sdb1", "/data", "xfs", 4096*gib, 0.86+0.04*wave(n, 1200, 2)),
+			}
+		},
+		Network: func(n int, interfaces []models.NetworkInfo) []models.NetworkInfo {
+			mu.Lock()
+			defer mu.Unlock()
+			// Counters add up each sample's rate, so rates follow the waves
+			for ; last < n; last++ {
+				k := last + 1
+				sent[0] += uint64(2e6 * (0.2 + wave(k, 30, 0) + 0.3*jitter(k, 10)))
+				recv[0] += uint64(12e6 * (0.2 + wave(k, 45, 1) + 0.3*jitter(k, 11)))
+				sent[1] += uint64(150e3 * (0.3 + wave(k, 20, 2) + 0.5*jitter(k, 12)))
+				recv[1] += uint64(900e3 * (0.3 + wave(k, 25, 3) + 0.5*jitter(k, 13)))
+			}
+			timestamp := interfaces[0].Timestamp
+			names := []string{"eth0", "wlan0"}
+			interfaces = make([]models.NetworkInfo, len(names))
+			for i, name := range names {
+				interfaces[i] = models.NetworkInfo{
+					Interface:   name,
+					BytesSent:   sent[i],
+					BytesRecv:   recv[i],
+					PacketsSent: sent[i] / 1200,
+					PacketsRecv: recv[i] / 1200,
+					Timestamp:   timestamp,
+				}
+			}
+			return interfaces
+		},
+	}
+}
+
+// CollectSensors returns warm CPU, GPU and NVMe temperatures that follow the load
+func (d *Demo) CollectSensors() ([]models.SensorInfo, error) {
+	d.mu.Lock()
+	d.sensors++
+	n := d.sensors
+	d.mu.Unlock()
+	return []models.SensorInfo{
+		{Key: "coretemp_package_id_0", Kind: models.SensorCPU, Temperature: 52 + 18*wave(n, 40, 0), High: 90, Critical: 100},
+		{Key: "amdgpu_edge", Kind: models.SensorGPU, Temperature: 45 + 10*wave(n, 70, 1), High: 95, Critical: 105},
+		{Key: "nvme_composite", Kind: models.SensorNVMe, Temperature: 38 + 6*wave(n, 120, 2), High: 80, Critical: 85},
+	}, nil
+}
+
+// CollectHost returns the demo machine, up for a little over three days
+func (d *Demo) CollectHost() (models.HostInfo, error) {
+	return models.HostInfo{
+		Hostname:        "demo-workstation",
+		BootTime:        d.booted,
+		OS:              "linux",
+		Platform:        "ubuntu",
+		PlatformVersion: "24.04",
+		KernelVersion:   "6.8.0-45-generic",
+		KernelArch:      "x86_64",
+	}, nil
+}
+
+// CollectTopProcesses returns the n busiest demo processes
+func (d *Demo) CollectTopProcesses(n int) ([]models.ProcessInfo, error) {
+	d.mu.Lock()
+	d.processes++
+	sample := d.processes
+	d.mu.Unlock()
+
+	processes := make([]models.ProcessInfo, len(demoProcesses))
+	for i, proc := range demoProcesses {
+		processes[i] = models.ProcessInfo{
+			PID:        proc.pid,
+			Name:       proc.name,
+			CPUPercent: math.Max(0, proc.cpu*(0.5+wave(sample, 15+float64(i)*4, float64(i))+0.3*jitter(sample, 20+i))),
+		}
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].CPUPercent > processes[j].CPUPercent
+	})
+	if n < len(processes) {
+		processes = processes[:n]
+	}
+	return processes, nil
+}
+
+// CollectProcessMemory returns the memory breakdown of a demo process
+func (d *Demo) CollectProcessMemory(pid int32) (models.ProcessMemory, error) {
+	for _, proc := range demoProcesses {
+		if proc.pid != pid {
+			continue
+		}
+		shared := uint64(float64(proc.rss) * proc.shared)
+		return models.ProcessMemory{
+			PID:      pid,
+			RSS:      proc.rss,
+			PSS:      proc.rss - shared/2,
+			USS:      proc.rss - shared,
+			Swap:     proc.rss / 50,
+			Detailed: true,
+		}, nil
+	}
+	return models.ProcessMemory{}, models.CreateSystemError(models.SystemAccessError, "Process", "no such demo process", nil)
+}
+
+// demoDisk returns a disk filled to the given fraction
+func demoDisk(device, mountpoint, filesystem string, total uint64, fill float64) models.DiskInfo {
+	used := uint64(clamp(fill, 0, 1) * float64(total))
+	return models.DiskInfo{
+		Device:      device,
+		Mountpoint:  mountpoint,
+		Filesystem:  filesystem,
+		Total:       total,
+		Used:        used,
+		Available:   total - used,
+		UsedPercent: float64(used) / float64(total) * 100,
+	}
+}
+
+// wave rises and falls between 0 and 1 over period samples
+func wave(n int, period, phase float64) float64 {
+	return 0.5 + 0.5*math.Sin(2*math.Pi*float64(n)/period+phase)
+}
+
+// jitter returns repeatable noise between -0.5 and 0.5 for sample n of a series
+func jitter(n, series int) float64 {
+	x := math.Sin(float64(n)*12.9898+float64(series)*78.233) * 43758.5453
+	return x - math.Floor(x) - 0.5
+}
+
+// clamp limits v to [lo, hi]
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(hi, math.Max(lo, v))
+}
@@ -0,0 +1,105 @@
+package fake
+
+import (
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestDemo_ValuesFluctuateWithinBounds(t *testing.T) {
+	demo := NewDemo()
+	seen := make(map[float64]bool)
+	for i := 0; i < 50; i++ {
+		cpu, err := demo.CollectCPU()
+		if err != nil {
+			t.Fatalf("CollectCPU failed: %v", err)
+		}
+		if cpu.Cores != 8 || len(cpu.Usage) != 8 {
+			t.Fatalf("Expected 8 cores, got %d with %d usages", cpu.Cores, len(cpu.Usage))
+		}
+		for _, usage := range cpu.Usage {
+			if usage < 0 || usage > 100 {
+				t.Fatalf("Expected core usage within 0-100%%, got %v", usage)
+			}
+		}
+		seen[cpu.Total] = true
+
+		memory, _ := demo.CollectMemory()
+		if memory.Used+memory.Available != memory.Total || memory.Swap.Used+memory.Swap.Free != memory.Swap.Total {
+			t.Fatalf("Expected consistent memory, got %+v", memory)
+		}
+		disks, _ := demo.CollectDisk()
+		for _, disk := range disks {
+			if disk.Used+disk.Available != disk.Total || disk.UsedPercent > 100 {
+				t.Fatalf("Expected consistent disk, got %+v", disk)
+			}
+		}
+	}
+	if len(seen) < 40 {
+		t.Errorf("Expected the CPU total to keep changing, got %d distinct values in 50 samples", len(seen))
+	}
+}
+
+func TestDemo_Deterministic(t *testing.T) {
+	first, second := NewDemo(), NewDemo()
+	for i := 0; i < 10; i++ {
+		a, _ := first.CollectCPU()
+		b, _ := second.CollectCPU()
+		if a.Total != b.Total {
+			t.Fatalf("Expected identical samples, got %v and %v at sample %d", a.Total, b.Total, i+1)
+		}
+	}
+}
+
+func TestDemo_NetworkCountersGrow(t *testing.T) {
+	clock := models.NewFakeClock(time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC))
+	demo := NewDemo()
+	demo.SetClock(clock)
+
+	previous, _ := demo.CollectNetwork()
+	if len(previous) != 2 {
+		t.Fatalf("Expected eth0 and wlan0, got %+v", previous)
+	}
+	for i := 0; i < 20; i++ {
+		clock.Advance(time.Second)
+		current, _ := demo.CollectNetwork()
+		for name, rate := range demo.CalculateNetworkRates(previous, current) {
+			if rate.SendRate <= 0 || rate.RecvRate <= 0 {
+				t.Fatalf("Expected traffic on %s at every sample, got %+v", name, rate)
+			}
+		}
+		previous = current
+	}
+}
+
+func TestDemo_Capabilities(t *testing.T) {
+	var demo models.SystemCollector = NewDemo()
+	if _, ok := demo.(models.SensorCollector); !ok {
+		t.Error("Expected the demo to report sensors")
+	}
+	if _, ok := demo.(models.HostCollector); !ok {
+		t.Error("Expected the demo to report a host")
+	}
+	processes, ok := demo.(models.ProcessMemoryCollector)
+	if !ok {
+		t.Fatal("Expected the demo to report process memory")
+	}
+
+	top, err := demo.(models.ProcessCollector).CollectTopProcesses(5)
+	if err != nil || len(top) != 5 {
+		t.Fatalf("Expected 5 processes, got %d, %v", len(top), err)
+	}
+	for i := 1; i < len(top); i++ {
+		if top[i].CPUPercent > top[i-1].CPUPercent {
+			t.Errorf("Expected the busiest processes first, got %+v", top)
+		}
+	}
+	memory, err := processes.CollectProcessMemory(top[0].PID)
+	if err != nil || !memory.Detailed || memory.USS > memory.PSS || memory.PSS > memory.RSS {
+		t.Errorf("Expected USS <= PSS <= RSS, got %+v, %v", memory, err)
+	}
+	if _, err := processes.CollectProcessMemory(99999); err == nil {
+		t.Error("Expected an error for an unknown process")
+	}
+}
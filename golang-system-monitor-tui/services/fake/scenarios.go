@@ -0,0 +1,104 @@
+package fake
+
+import (
+	"fmt"
+	"sort"
+
+	"golang-system-monitor-tui/models"
+)
+
+// Scenario reshapes the baseline samples of a Collector. Each hook gets the
+// sample number n of its metric, from 1; nil hooks leave a metric alone.
+type Scenario struct {
+	Name    string
+	CPU     func(n int, cpu *models.CPUInfo)
+	Memory  func(n int, memory *models.MemoryInfo)
+	Disk    func(n int, disks []models.DiskInfo) []models.DiskInfo
+	Network func(n int, interfaces []models.NetworkInfo) []models.NetworkInfo
+}
+
+// SpikyCPU idles the cores at 5% and pins them all to 100% on every
+// period-th sample
+func SpikyCPU(period int) Scenario {
+	if period < 1 {
+		period = 1
+	}
+	return Scenario{
+		Name: "spiky-cpu",
+		CPU: func(n int, cpu *models.CPUInfo) {
+			usage := 5.0
+			if n%period == 0 {
+				usage = 100
+			}
+			for i := range cpu.Usage {
+				cpu.Usage[i] = usage
+			}
+			cpu.Total = usage
+		},
+	}
+}
+
+// FillingDisk writes perSample bytes to the first disk on every sample
+// until it is full
+func FillingDisk(perSample uint64) Scenario {
+	return Scenario{
+		Name: "filling-disk",
+		Disk: func(n int, disks []models.DiskInfo) []models.DiskInfo {
+			if len(disks) == 0 {
+				return disks
+			}
+			disk := &disks[0]
+			disk.Used = min(disk.Used+uint64(n)*perSample, disk.Total)
+			disk.Available = disk.Total - disk.Used
+			disk.UsedPercent = float64(disk.Used) / float64(disk.Total) * 100
+			return disks
+		},
+	}
+}
+
+// SaturatedNIC moves bytesPerSample in each direction over the first
+// interface on every sample, as on a link running at its line rate
+func SaturatedNIC(bytesPerSample uint64) Scenario {
+	return Scenario{
+		Name: "saturated-nic",
+		Network: func(n int, interfaces []models.NetworkInfo) []models.NetworkInfo {
+			if len(interfaces) == 0 {
+				return interfaces
+			}
+			iface := &interfaces[0]
+			iface.BytesSent = uint64(n) * bytesPerSample
+			iface.BytesRecv = uint64(n) * bytesPerSample
+			iface.PacketsSent = iface.BytesSent / 1500
+			iface.PacketsRecv = iface.BytesRecv / 1500
+			return interfaces
+		},
+	}
+}
+
+// scenarios builds the named scenarios with defaults suited to a one
+// second refresh
+var scenarios = map[string]func() Scenario{
+	"demo":          DemoScenario,
+	"spiky-cpu":     func() Scenario { return SpikyCPU(5) },
+	"filling-disk":  func() Scenario { return FillingDisk(1 << 30) },
+	"saturated-nic": func() Scenario { return SaturatedNIC(125_000_000) }, // 1 Gbit/s
+}
+
+// ScenarioNames lists the scenarios ParseScenario accepts
+func ScenarioNames() []string {
+	names := make([]string, 0, len(scenarios))
+	for name := range scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseScenario returns the named scenario with its defaults
+func ParseScenario(name string) (Scenario, error) {
+	build, ok := scenarios[name]
+	if !ok {
+		return Scenario{}, fmt.Errorf("unknown scenario %q (want one of %v)", name, ScenarioNames())
+	}
+	return build(), nil
+}
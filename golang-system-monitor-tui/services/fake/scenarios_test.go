@@ -0,0 +1,77 @@
+package fake
+
+import (
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestSpikyCPU(t *testing.T) {
+	collector := NewCollector(SpikyCPU(3))
+	var totals []float64
+	for i := 0; i < 6; i++ {
+		cpu, _ := collector.CollectCPU()
+		totals = append(totals, cpu.Total)
+		if cpu.Usage[0] != cpu.Total {
+			t.Errorf("Expected every core at the total, got %v", cpu.Usage)
+		}
+	}
+	want := []float64{5, 5, 100, 5, 5, 100}
+	for i := range want {
+		if totals[i] != want[i] {
+			t.Fatalf("Expected spikes on every third sample %v, got %v", want, totals)
+		}
+	}
+}
+
+func TestFillingDisk(t *testing.T) {
+	collector := NewCollector(FillingDisk(100 * gib))
+	first, _ := collector.CollectDisk()
+	if first[0].Used != 400*gib || first[0].Available != 100*gib || first[0].UsedPercent != 80 {
+		t.Errorf("Expected 100GB more used after one sample, got %+v", first[0])
+	}
+
+	// Full after the second sample, and stays full
+	for i := 0; i < 3; i++ {
+		disks, _ := collector.CollectDisk()
+		if disks[0].Used != disks[0].Total || disks[0].Available != 0 || disks[0].UsedPercent != 100 {
+			t.Errorf("Expected the disk full, got %+v", disks[0])
+		}
+	}
+}
+
+func TestSaturatedNIC(t *testing.T) {
+	clock := models.NewFakeClock(time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC))
+	collector := NewCollector(SaturatedNIC(125_000_000))
+	collector.SetClock(clock)
+
+	first, _ := collector.CollectNetwork()
+	clock.Advance(time.Second)
+	second, _ := collector.CollectNetwork()
+	rate := collector.CalculateNetworkRates(first, second)["eth0"]
+	if rate.SendRate != 125_000_000 || rate.RecvRate != 125_000_000 {
+		t.Errorf("Expected 1 Gbit/s both ways, got %+v", rate)
+	}
+}
+
+func TestScenariosCombine(t *testing.T) {
+	collector := NewCollector(SpikyCPU(1), FillingDisk(gib))
+	cpu, _ := collector.CollectCPU()
+	disks, _ := collector.CollectDisk()
+	if cpu.Total != 100 || disks[0].Used != 301*gib {
+		t.Errorf("Expected both scenarios applied, got CPU %v and disk %d", cpu.Total, disks[0].Used)
+	}
+}
+
+func TestParseScenario(t *testing.T) {
+	for _, name := range ScenarioNames() {
+		scenario, err := ParseScenario(name)
+		if err != nil || scenario.Name != name {
+			t.Errorf("ParseScenario(%q) = %q, %v", name, scenario.Name, err)
+		}
+	}
+	if _, err := ParseScenario("meltdown"); err == nil {
+		t.Error("Expected error for an unknown scenario")
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+
+	"golang-system-monitor-tui/models"
+)
+
+// FilesCollectorName is the registry name of the file descriptor collector
+const FilesCollectorName = "Files"
+
+// fileNrPath holds the allocated, free and maximum file handles of the kernel
+const fileNrPath = "/proc/sys/fs/file-nr"
+
+// FilesCollector reads the system-wide file handle usage from file-nr
+type FilesCollector struct {
+	path string
+}
+
+// NewFilesCollector returns a collector of the system-wide file handle
+// usage, or nil where the kernel does not report it, which is everywhere but
+// Linux
+func NewFilesCollector() *FilesCollector {
+	if _, err := os.Stat(fileNrPath); err != nil {
+		return nil
+	}
+	return &FilesCollector{path: fileNrPath}
+}
+
+// Name returns the registry name of the file descriptor collector
+func (f *FilesCollector) Name() string {
+	return FilesCollectorName
+}
+
+// Collect returns the models.FileDescriptors read from file-nr
+func (f *FilesCollector) Collect() (interface{}, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, models.CreateSystemError(models.SystemAccessError, FilesCollectorName, "Failed to read "+f.path, err)
+	}
+	files, err := parseFileNr(string(data))
+	if err != nil {
+		return nil, models.CreateSystemError(models.DataCollectionError, FilesCollectorName, "Failed to parse "+f.path, err)
+	}
+	files.Timestamp = time.Now()
+	return files, nil
+}
+
+// parseFileNr parses the three numbers of file-nr: allocated handles, free
+// allocated handles (always 0 since Linux 2.6) and the maximum
+func parseFileNr(data string) (models.FileDescriptors, error) {
+	fields := strings.Fields(data)
+	if len(fields) != 3 {
+		return models.FileDescriptors{}, fmt.Errorf("want 3 fields, got %q", data)
+	}
+	values := make([]uint64, 3)
+	for i, field := range fields {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return models.FileDescriptors{}, err
+		}
+		values[i] = value
+	}
+
+	allocated := values[0]
+	if values[1] < allocated {
+		allocated -= values[1]
+	}
+	return models.FileDescriptors{Allocated: allocated, Max: values[2]}, nil
+}
+
+// CollectProcessFiles returns the number of file descriptors a process has
+// open and its soft RLIMIT_NOFILE; the limit is 0 where it cannot be read
+func (g *GopsutilProcessCollector) CollectProcessFiles(pid int32) (models.ProcessFiles, error) {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return models.ProcessFiles{}, models.CreateSystemError(models.SystemAccessError, "Process", fmt.Sprintf("Process %d not found", pid), err)
+	}
+	open, err := p.NumFDs()
+	if err != nil {
+		return models.ProcessFiles{}, models.CreateSystemError(models.PermissionError, "Process", fmt.Sprintf("Failed to read open files of process %d", pid), err)
+	}
+
+	files := models.ProcessFiles{PID: pid, Open: uint64(open)}
+	if limits, err := p.Rlimit(); err == nil {
+		for _, limit := range limits {
+			if limit.Resource == process.RLIMIT_NOFILE {
+				files.Limit = limit.Soft
+			}
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestParseFileNr(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    models.FileDescriptors
+		wantErr bool
+	}{
+		{"9024\t0\t9223372036854775807\n", models.FileDescriptors{Allocated: 9024, Max: 9223372036854775807}, false},
+		// Kernels before 2.6 count freed handles as allocated
+		{"1000 200 8192", models.FileDescriptors{Allocated: 800, Max: 8192}, false},
+		{"1000 0", models.FileDescriptors{}, true},
+		{"a 0 8192", models.FileDescriptors{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseFileNr(tt.data)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseFileNr(%q) = %+v, %v, want %+v", tt.data, got, err, tt.want)
+		}
+	}
+}
+
+func TestFilesCollector_Collect(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file-nr")
+	if err := os.WriteFile(path, []byte("9000\t0\t10000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	collector := &FilesCollector{path: path}
+	if collector.Name() != FilesCollectorName {
+		t.Errorf("Expected the name %q, got %q", FilesCollectorName, collector.Name())
+	}
+
+	data, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	files := data.(models.FileDescriptors)
+	if files.UsedPercent() != 90 || files.Timestamp.IsZero() {
+		t.Errorf("Expected 90%% used with a timestamp, got %+v", files)
+	}
+
+	collector.path = filepath.Join(t.TempDir(), "missing")
+	if _, err := collector.Collect(); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestGopsutilProcessCollector_CollectProcessFiles(t *testing.T) {
+	if _, err := os.Stat("/proc/self/fd"); err != nil {
+		t.Skip("Open files need /proc")
+	}
+	files, err := NewGopsutilProcessCollector().CollectProcessFiles(int32(os.Getpid()))
+	if err != nil {
+		t.Fatalf("CollectProcessFiles failed: %v", err)
+	}
+	if files.Open == 0 || files.Limit == 0 {
+		t.Errorf("Expected open files and a limit for the test process, got %+v", files)
+	}
+}
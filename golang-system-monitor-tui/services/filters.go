@@ -0,0 +1,54 @@
+package services
+
+import (
+	"strings"
+)
+
+// pseudoFilesystems are the filesystem types that don't hold storage: kernel
+// interfaces, device nodes, and on FreeBSD, where gopsutil lists every mount
+// even when asked for physical devices only, loopback and Linux emulation
+// mounts. tmpfs and OpenBSD's mfs live in RAM and are shown with memory.
+var pseudoFilesystems = map[string]bool{
+	// Linux
+	"proc": true, "sysfs": true, "devtmpfs": true, "tmpfs": true, "devpts": true,
+	"cgroup": true, "cgroup2": true, "pstore": true, "bpf": true, "tracefs": true,
+	// FreeBSD
+	"devfs": true, "fdescfs": true, "procfs": true, "linprocfs": true,
+	"linsysfs": true, "nullfs": true, "mqueuefs": true, "autofs": true,
+	// OpenBSD
+	"mfs": true, "kernfs": true,
+}
+
+// isPseudoFilesystem reports whether the disk panel leaves out mounts of fstype
+func isPseudoFilesystem(fstype string) bool {
+	return pseudoFilesystems[fstype]
+}
+
+// pseudoInterfaces are the prefixes of network interfaces that carry no
+// traffic of their own: loopback, and the BSD packet filter log and sync,
+// IPsec and USB bus interfaces listed by netstat
+var pseudoInterfaces = []string{"lo", "pflog", "pfsync", "enc", "usbus", "ipfw"}
+
+// isPseudoInterface reports whether the network panel leaves out the
+// interface name, a pseudoInterfaces prefix followed by an optional unit
+// number such as lo0 or pflog0
+func isPseudoInterface(name string) bool {
+	// Windows names its loopback adapter
+	if name == "Loopback" || name == "Loopback Pseudo-Interface 1" {
+		return true
+	}
+	for _, prefix := range pseudoInterfaces {
+		unit, ok := strings.CutPrefix(name, prefix)
+		if ok && strings.Trim(unit, "0123456789") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// interfaceName returns the name of a network interface without the "*"
+// FreeBSD's netstat appends to interfaces that are down, so the interface
+// keeps its name, and its rate history, when it goes down and up again
+func interfaceName(name string) string {
+	return strings.TrimSuffix(name, "*")
+}
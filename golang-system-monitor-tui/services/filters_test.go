@@ -0,0 +1,49 @@
+package services
+
+import "testing"
+
+func TestIsPseudoFilesystem(t *testing.T) {
+	tests := map[string]bool{
+		// Storage
+		"ext4": false, "xfs": false, "btrfs": false, "apfs": false,
+		"ufs": false, "zfs": false, "ffs": false, "msdosfs": false, "nfs": false,
+		// Linux
+		"proc": true, "sysfs": true, "tmpfs": true, "cgroup2": true,
+		// FreeBSD
+		"devfs": true, "fdescfs": true, "procfs": true, "linprocfs": true, "nullfs": true,
+		// OpenBSD
+		"mfs": true,
+	}
+	for fstype, want := range tests {
+		if got := isPseudoFilesystem(fstype); got != want {
+			t.Errorf("isPseudoFilesystem(%q) = %v, want %v", fstype, got, want)
+		}
+	}
+}
+
+func TestIsPseudoInterface(t *testing.T) {
+	tests := map[string]bool{
+		// Linux and Windows loopback
+		"lo": true, "Loopback Pseudo-Interface 1": true,
+		// BSD and macOS
+		"lo0": true, "lo1": true, "pflog0": true, "pfsync0": true, "enc0": true,
+		"usbus0": true, "ipfw0": true,
+		// Real interfaces with similar names
+		"eth0": false, "em0": false, "enp3s0": false, "en0": false, "lowpan0": false,
+		"igb0": false, "re0": false, "wlan0": false, "vio0": false, "encrypted": false,
+	}
+	for name, want := range tests {
+		if got := isPseudoInterface(name); got != want {
+			t.Errorf("isPseudoInterface(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestInterfaceName(t *testing.T) {
+	if got := interfaceName("em1*"); got != "em1" {
+		t.Errorf("Expected the down marker removed, got %q", got)
+	}
+	if got := interfaceName("em0"); got != "em0" {
+		t.Errorf("Expected the name unchanged, got %q", got)
+	}
+}
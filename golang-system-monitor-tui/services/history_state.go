@@ -0,0 +1,39 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"golang-system-monitor-tui/models"
+)
+
+// LoadHistory reads the metric history persisted at path. A missing file, as
+// on the first run, yields an empty record.
+func LoadHistory(path string) (models.HistoryRecord, error) {
+	var record models.HistoryRecord
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return record, nil
+		}
+		return record, fmt.Errorf("failed to read history: %w", err)
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return models.HistoryRecord{}, fmt.Errorf("failed to parse history %s: %w", path, err)
+	}
+	return record, nil
+}
+
+// SaveHistory writes the metric history to path, creating its directory. The
+// file is replaced atomically so a crash never leaves a partial history.
+func SaveHistory(path string, record models.HistoryRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+	if err := writeStateFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestHistory_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "history.json")
+
+	record, err := LoadHistory(path)
+	if err != nil || !record.SavedAt.IsZero() || record.CPUTotal != nil {
+		t.Fatalf("Expected an empty record before the first run, got %+v (%v)", record, err)
+	}
+
+	want := models.HistoryRecord{
+		SavedAt:  time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC),
+		CPUTotal: []float64{10, 20.5, 30},
+		CPUCores: [][]float64{{5, 15, 25}, {15, 26, 35}},
+		CPULong: []models.TierSnapshot{
+			{Step: time.Second, Values: []float64{10, 20.5}, Bucket: time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC), Sum: 30, Count: 1},
+			{Step: time.Minute, Values: []float64{15}},
+		},
+	}
+	if err := SaveHistory(path, want); err != nil {
+		t.Fatalf("SaveHistory failed: %v", err)
+	}
+	record, err = LoadHistory(path)
+	if err != nil || !record.SavedAt.Equal(want.SavedAt) {
+		t.Fatalf("Expected the save time %v back, got %+v (%v)", want.SavedAt, record, err)
+	}
+	if !reflect.DeepEqual(record.CPUTotal, want.CPUTotal) || !reflect.DeepEqual(record.CPUCores, want.CPUCores) || !reflect.DeepEqual(record.CPULong, want.CPULong) {
+		t.Errorf("Expected %+v back, got %+v", want, record)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("Expected no temporary file left behind, got %v", entries)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadHistory(path); err == nil {
+		t.Error("Expected an error for a corrupt history")
+	}
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+
+	"golang-system-monitor-tui/models"
+)
+
+// CollectHost gathers the hostname, boot time, platform and kernel of the machine
+func (g *GopsutilCollector) CollectHost() (models.HostInfo, error) {
+	info, err := host.Info()
+	if err != nil {
+		if g.isPermissionError(err) {
+			return models.HostInfo{}, models.CreateSystemError(models.PermissionError, "Host", "Permission denied accessing host information", err)
+		}
+		return models.HostInfo{}, models.CreateSystemError(models.SystemAccessError, "Host", "Failed to collect host information", err)
+	}
+
+	result := models.HostInfo{
+		Hostname:        info.Hostname,
+		OS:              info.OS,
+		Platform:        info.Platform,
+		PlatformVersion: info.PlatformVersion,
+		KernelVersion:   info.KernelVersion,
+		KernelArch:      info.KernelArch,
+	}
+	if info.BootTime > 0 {
+		result.BootTime = time.Unix(int64(info.BootTime), 0)
+	}
+	return result, nil
+}
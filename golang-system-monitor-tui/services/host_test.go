@@ -0,0 +1,25 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGopsutilCollector_CollectHost(t *testing.T) {
+	collector := NewGopsutilCollector()
+
+	info, err := collector.CollectHost()
+	if err != nil {
+		t.Skipf("Host information not available: %v", err)
+	}
+
+	if info.Hostname == "" {
+		t.Error("Expected a hostname")
+	}
+	if info.OS == "" {
+		t.Error("Expected an operating system")
+	}
+	if !info.BootTime.IsZero() && info.BootTime.After(time.Now()) {
+		t.Errorf("Expected boot time in the past, got %v", info.BootTime)
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// KernelCollectorName is the registry name of the kernel counter collector
+const KernelCollectorName = "Kernel"
+
+// KernelCollector reads the context switch, interrupt and fork counters of
+// /proc/stat and the available entropy of the kernel's random pool
+type KernelCollector struct {
+	statPath    string
+	entropyPath string
+}
+
+// NewKernelCollector returns a collector of the kernel counters, or nil
+// without a Linux proc filesystem
+func NewKernelCollector() *KernelCollector {
+	statPath := filepath.Join(procRoot, "stat")
+	if _, err := os.Stat(statPath); err != nil {
+		return nil
+	}
+	return &KernelCollector{
+		statPath:    statPath,
+		entropyPath: filepath.Join(procRoot, "sys", "kernel", "random", "entropy_avail"),
+	}
+}
+
+// Name returns the registry name of the kernel counter collector
+func (k *KernelCollector) Name() string {
+	return KernelCollectorName
+}
+
+// Collect returns the models.KernelStats. The entropy is -1 when it cannot
+// be read, which does not fail the sample.
+func (k *KernelCollector) Collect() (interface{}, error) {
+	file, err := os.Open(k.statPath)
+	if err != nil {
+		return nil, models.CreateSystemError(models.SystemAccessError, KernelCollectorName, "Failed to read "+k.statPath, err)
+	}
+	defer file.Close()
+
+	stats, err := parseProcStat(file)
+	if err != nil {
+		return nil, models.CreateSystemError(models.DataCollectionError, KernelCollectorName, "Failed to parse "+k.statPath, err)
+	}
+	stats.Entropy = -1
+	if data, err := os.ReadFile(k.entropyPath); err == nil {
+		if entropy, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+			stats.Entropy = entropy
+		}
+	}
+	stats.Timestamp = time.Now()
+	return stats, nil
+}
+
+// parseProcStat reads the ctxt, processes and total intr counters of /proc/stat
+func parseProcStat(r io.Reader) (models.KernelStats, error) {
+	var stats models.KernelStats
+	found := 0
+
+	scanner := bufio.NewScanner(r)
+	// The intr line lists every interrupt number and gets long
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		var counter *uint64
+		switch fields[0] {
+		case "ctxt":
+			counter = &stats.ContextSwitches
+		case "intr":
+			counter = &stats.Interrupts
+		case "processes":
+			counter = &stats.Forks
+		default:
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return models.KernelStats{}, fmt.Errorf("%s: %w", fields[0], err)
+		}
+		*counter = value
+		found++
+	}
+	if err := scanner.Err(); err != nil {
+		return models.KernelStats{}, err
+	}
+	if found < 3 {
+		return models.KernelStats{}, fmt.Errorf("missing ctxt, intr or processes line")
+	}
+	return stats, nil
+}
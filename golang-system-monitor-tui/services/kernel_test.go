@@ -0,0 +1,65 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+const sampleProcStat = `cpu  2255 34 2290 22625563 6290 127 456 0 0 0
+cpu0 1132 34 1441 11311718 3675 127 438 0 0 0
+intr 114930548 113199788 3 0 5 263 0 4 [... 500 more]
+ctxt 1990473
+btime 1062191376
+processes 2915
+procs_running 1
+procs_blocked 0
+`
+
+func TestParseProcStat(t *testing.T) {
+	stats, err := parseProcStat(strings.NewReader(sampleProcStat))
+	if err != nil {
+		t.Fatalf("parseProcStat failed: %v", err)
+	}
+	want := models.KernelStats{ContextSwitches: 1990473, Interrupts: 114930548, Forks: 2915}
+	if stats != want {
+		t.Errorf("parseProcStat() = %+v, want %+v", stats, want)
+	}
+
+	if _, err := parseProcStat(strings.NewReader("cpu 1 2 3\nctxt 10\n")); err == nil {
+		t.Error("Expected an error without the intr and processes lines")
+	}
+	if _, err := parseProcStat(strings.NewReader("ctxt x\nintr 1\nprocesses 2\n")); err == nil {
+		t.Error("Expected an error for a malformed counter")
+	}
+}
+
+func TestKernelCollector_Collect(t *testing.T) {
+	dir := t.TempDir()
+	collector := &KernelCollector{statPath: filepath.Join(dir, "stat"), entropyPath: filepath.Join(dir, "entropy_avail")}
+	if err := os.WriteFile(collector.statPath, []byte(sampleProcStat), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if collector.Name() != KernelCollectorName {
+		t.Errorf("Expected the name %q, got %q", KernelCollectorName, collector.Name())
+	}
+
+	data, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if stats := data.(models.KernelStats); stats.Entropy != -1 || stats.Timestamp.IsZero() {
+		t.Errorf("Expected an unknown entropy and a timestamp, got %+v", stats)
+	}
+
+	if err := os.WriteFile(collector.entropyPath, []byte("256\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = collector.Collect()
+	if stats := data.(models.KernelStats); stats.Entropy != 256 {
+		t.Errorf("Expected 256 bits of entropy, got %d", stats.Entropy)
+	}
+}
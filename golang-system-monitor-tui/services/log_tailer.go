@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// LogCollectorName is the registry name of the log file tailer
+const LogCollectorName = "Log"
+
+const (
+	initialTailBytes = 64 << 10 // History read when the tailer starts, like tail's last lines
+	maxTailRead      = 1 << 20  // Bound on the bytes read per collection; older output is skipped
+)
+
+// LogTailer follows a log file like tail -F: every collection returns the
+// lines appended since the previous one, and a truncated, rotated or
+// recreated file is read again from the start. Read failures are reported in
+// the Error field of the result, so the log panel can show them.
+type LogTailer struct {
+	path    string
+	mu      sync.Mutex
+	file    os.FileInfo // File followed so far; nil before the first read
+	offset  int64       // Bytes of the file consumed
+	partial string      // Last line, not yet terminated by a newline
+}
+
+// NewLogTailer creates a tailer following the file at path
+func NewLogTailer(path string) *LogTailer {
+	return &LogTailer{path: path}
+}
+
+// Name returns the registry name of the tailer
+func (t *LogTailer) Name() string {
+	return LogCollectorName
+}
+
+// Path returns the followed file
+func (t *LogTailer) Path() string {
+	return t.path
+}
+
+// Collect returns the models.LogTail of the lines appended since the last call
+func (t *LogTailer) Collect() (interface{}, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tail := models.LogTail{Path: t.path, Timestamp: time.Now()}
+	info, err := os.Stat(t.path)
+	if err != nil {
+		tail.Error = err.Error()
+		return tail, nil
+	}
+
+	switch {
+	case t.file == nil:
+		// Start with the end of the file for context
+		t.offset = max(info.Size()-initialTailBytes, 0)
+		t.partial = ""
+	case !os.SameFile(t.file, info) || info.Size() < t.offset:
+		t.offset = 0
+		t.partial = ""
+		tail.Reset = true
+	}
+	skipFirst := t.file == nil && t.offset > 0
+	t.file = info
+
+	if info.Size() == t.offset {
+		return tail, nil
+	}
+	if info.Size()-t.offset > maxTailRead {
+		t.offset = info.Size() - maxTailRead
+		t.partial = ""
+		skipFirst = true
+	}
+
+	data, err := t.read(t.offset)
+	if err != nil {
+		tail.Error = err.Error()
+		return tail, nil
+	}
+	t.offset += int64(len(data))
+
+	text := t.partial + string(data)
+	lines := strings.Split(text, "\n")
+	// The last element is empty after a trailing newline, or an unfinished line
+	t.partial = lines[len(lines)-1]
+	lines = lines[:len(lines)-1]
+	if skipFirst && len(lines) > 0 {
+		// Reading started in the middle of a line
+		lines = lines[1:]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	tail.Lines = lines
+	return tail, nil
+}
+
+// read returns the contents of the file from offset, at most maxTailRead bytes
+func (t *LogTailer) read(offset int64) ([]byte, error) {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek log file: %w", err)
+	}
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(file, maxTailRead)); err != nil {
+		return nil, fmt.Errorf("failed to read log file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+// collectTail collects from the tailer, failing the test on a read error
+func collectTail(t *testing.T, tailer *LogTailer) models.LogTail {
+	t.Helper()
+	data, err := tailer.Collect()
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	tail := data.(models.LogTail)
+	if tail.Error != "" {
+		t.Fatalf("Unexpected read error: %s", tail.Error)
+	}
+	return tail
+}
+
+func appendFile(t *testing.T, path, text string) {
+	t.Helper()
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(text); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLogTailer_Follow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "one\ntwo\n")
+
+	tailer := NewLogTailer(path)
+	if tailer.Name() != LogCollectorName {
+		t.Errorf("Unexpected collector name %q", tailer.Name())
+	}
+	if tail := collectTail(t, tailer); !reflect.DeepEqual(tail.Lines, []string{"one", "two"}) || tail.Path != path {
+		t.Errorf("Expected the existing lines first, got %+v", tail)
+	}
+	if tail := collectTail(t, tailer); len(tail.Lines) != 0 {
+		t.Errorf("Expected no lines without new output, got %q", tail.Lines)
+	}
+
+	// Unfinished lines are held back until their newline arrives
+	appendFile(t, path, "three\r\nfo")
+	if tail := collectTail(t, tailer); !reflect.DeepEqual(tail.Lines, []string{"three"}) {
+		t.Errorf("Expected only the finished line, got %q", tail.Lines)
+	}
+	appendFile(t, path, "ur\n")
+	if tail := collectTail(t, tailer); !reflect.DeepEqual(tail.Lines, []string{"four"}) {
+		t.Errorf("Expected the completed line, got %q", tail.Lines)
+	}
+
+	// Truncation, as by logrotate's copytruncate, restarts from the beginning
+	if err := os.WriteFile(path, []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if tail := collectTail(t, tailer); !tail.Reset || !reflect.DeepEqual(tail.Lines, []string{"new"}) {
+		t.Errorf("Expected a reset with the new contents, got %+v", tail)
+	}
+
+	// So does a rotated file replaced by a new one
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "rotated line, longer than before\n")
+	if tail := collectTail(t, tailer); !tail.Reset || !reflect.DeepEqual(tail.Lines, []string{"rotated line, longer than before"}) {
+		t.Errorf("Expected a reset with the new file, got %+v", tail)
+	}
+}
+
+func TestLogTailer_StartsNearTheEnd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "big.log")
+	line := strings.Repeat("x", 99) + "\n"
+	appendFile(t, path, strings.Repeat(line, 2*initialTailBytes/len(line))+"last\n")
+
+	tail := collectTail(t, NewLogTailer(path))
+	if len(tail.Lines) == 0 || len(tail.Lines) > initialTailBytes/len(line)+1 || tail.Lines[len(tail.Lines)-1] != "last" {
+		t.Fatalf("Expected only the end of the file, got %d lines", len(tail.Lines))
+	}
+	for _, got := range tail.Lines[:len(tail.Lines)-1] {
+		if got != strings.TrimSuffix(line, "\n") {
+			t.Fatalf("Expected whole lines only, got %q", got)
+		}
+	}
+}
+
+func TestLogTailer_MissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.log")
+	tailer := NewLogTailer(path)
+	data, err := tailer.Collect()
+	if err != nil {
+		t.Fatalf("Expected the failure in the result, got %v", err)
+	}
+	if tail := data.(models.LogTail); tail.Error == "" {
+		t.Error("Expected an error for a missing file")
+	}
+
+	// The file is picked up once it appears, from its beginning
+	appendFile(t, path, "hello\n")
+	if tail := collectTail(t, tailer); !reflect.DeepEqual(tail.Lines, []string{"hello"}) {
+		t.Errorf("Expected the new file to be read, got %q", tail.Lines)
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// WebhookFormat selects the JSON payload shape sent to a webhook
+type WebhookFormat int
+
+const (
+	WebhookGeneric WebhookFormat = iota
+	WebhookSlack
+	WebhookDiscord
+)
+
+// WebhookPayload is the generic JSON body posted for an alert event
+type WebhookPayload struct {
+	Component string    `json:"component"`
+	Subject   string    `json:"subject,omitempty"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Fired     bool      `json:"fired"`
+	Hostname  string    `json:"hostname"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WebhookNotifier posts alert events to a Slack, Discord or generic JSON webhook,
+// retrying failed deliveries with exponential backoff
+type WebhookNotifier struct {
+	url         string
+	format      WebhookFormat
+	hostname    string
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	sleep       func(time.Duration)
+	tracker     *models.SinkTracker
+}
+
+// NewWebhookNotifier creates a webhook notifier, detecting the payload format from the URL
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &WebhookNotifier{
+		url:         url,
+		format:      DetectWebhookFormat(url),
+		hostname:    hostname,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: 4,
+		backoff:     time.Second,
+		sleep:       time.Sleep,
+		tracker:     models.NewSinkTracker("webhook"),
+	}
+}
+
+// DetectWebhookFormat infers the webhook flavor from well-known URL hosts
+func DetectWebhookFormat(url string) WebhookFormat {
+	switch {
+	case strings.Contains(url, "hooks.slack.com"):
+		return WebhookSlack
+	case strings.Contains(url, "discord.com/api/webhooks") || strings.Contains(url, "discordapp.com/api/webhooks"):
+		return WebhookDiscord
+	default:
+		return WebhookGeneric
+	}
+}
+
+// Notify posts the alert event to the webhook, retrying with backoff on failure
+func (w *WebhookNotifier) Notify(event models.AlertEvent) error {
+	w.tracker.Enqueue()
+	defer w.tracker.Dequeue()
+
+	body, err := w.buildPayload(event)
+	if err != nil {
+		w.tracker.RecordError(time.Now(), err)
+		return models.CreateSystemError(models.DataCollectionError, "Notifier", "Failed to encode webhook payload", err)
+	}
+
+	var lastErr error
+	delay := w.backoff
+	for attempt := 1; attempt <= w.maxAttempts; attempt++ {
+		lastErr = w.post(body)
+		if lastErr == nil {
+			w.tracker.RecordSuccess(time.Now())
+			return nil
+		}
+		if attempt < w.maxAttempts {
+			w.sleep(delay)
+			delay *= 2
+		}
+	}
+
+	w.tracker.RecordError(time.Now(), lastErr)
+	return models.CreateSystemError(models.TemporaryError, "Notifier",
+		fmt.Sprintf("Webhook delivery failed after %d attempts", w.maxAttempts), lastErr)
+}
+
+// SinkStatus returns the webhook's delivery health
+func (w *WebhookNotifier) SinkStatus() models.SinkStatus {
+	return w.tracker.Status()
+}
+
+// post sends a single webhook request
+func (w *WebhookNotifier) post(body []byte) error {
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// buildPayload encodes the event in the webhook's expected format
+func (w *WebhookNotifier) buildPayload(event models.AlertEvent) ([]byte, error) {
+	message := fmt.Sprintf("[%s] %s: %s", w.hostname, event.Title(), event.Description())
+
+	switch w.format {
+	case WebhookSlack:
+		return json.Marshal(map[string]string{"text": message})
+	case WebhookDiscord:
+		return json.Marshal(map[string]string{"content": message})
+	default:
+		return json.Marshal(WebhookPayload{
+			Component: event.Rule.Component,
+			Subject:   event.Subject,
+			Value:     event.Value,
+			Threshold: event.Rule.Threshold,
+			Fired:     event.Fired,
+			Hostname:  w.hostname,
+			Message:   message,
+			Timestamp: event.Timestamp,
+		})
+	}
+}
+
+// LogNotifier persists alert events to a logger, typically the application log file
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier creates a notifier writing alert events to the given logger
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	return &LogNotifier{
+		logger: logger,
+	}
+}
+
+// Notify writes the alert event as a single log line
+func (l *LogNotifier) Notify(event models.AlertEvent) error {
+	state := "CLEARED"
+	if event.Fired {
+		state = "FIRED"
+	}
+	l.logger.Printf("ALERT %s %s", state, event.Description())
+	return nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func testAlertEvent() models.AlertEvent {
+	return models.AlertEvent{
+		Rule:      models.AlertRule{Component: "Disk", Threshold: 95},
+		Subject:   "/",
+		Value:     97.1,
+		Fired:     true,
+		Timestamp: time.Now(),
+	}
+}
+
+func TestDetectWebhookFormat(t *testing.T) {
+	tests := []struct {
+		url  string
+		want WebhookFormat
+	}{
+		{"https://hooks.slack.com/services/T000/B000/XXX", WebhookSlack},
+		{"https://discord.com/api/webhooks/123/abc", WebhookDiscord},
+		{"https://example.com/alerts", WebhookGeneric},
+	}
+
+	for _, tt := range tests {
+		if got := DetectWebhookFormat(tt.url); got != tt.want {
+			t.Errorf("DetectWebhookFormat(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookNotifier_GenericPayload(t *testing.T) {
+	var received WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected JSON content type, got %s", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL)
+	if err := notifier.Notify(testAlertEvent()); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	if received.Component != "Disk" || received.Subject != "/" {
+		t.Errorf("Unexpected component/subject: %+v", received)
+	}
+	if received.Value != 97.1 || received.Threshold != 95 || !received.Fired {
+		t.Errorf("Unexpected value/threshold: %+v", received)
+	}
+	if received.Hostname == "" {
+		t.Error("Expected hostname in payload")
+	}
+}
+
+func TestWebhookNotifier_SlackPayload(t *testing.T) {
+	notifier := NewWebhookNotifier("https://hooks.slack.com/services/x")
+
+	body, err := notifier.buildPayload(testAlertEvent())
+	if err != nil {
+		t.Fatalf("buildPayload failed: %v", err)
+	}
+	if !strings.HasPrefix(string(body), `{"text":`) {
+		t.Errorf("Expected Slack text payload, got %s", body)
+	}
+}
+
+func TestWebhookNotifier_RetryWithBackoff(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	notifier := NewWebhookNotifier(server.URL)
+	notifier.sleep = func(d time.Duration) { delays = append(delays, d) }
+
+	if err := notifier.Notify(testAlertEvent()); err != nil {
+		t.Fatalf("Expected delivery to succeed after retries, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if len(delays) != 2 || delays[0] != time.Second || delays[1] != 2*time.Second {
+		t.Errorf("Expected exponential backoff of 1s, 2s, got %v", delays)
+	}
+
+	status := notifier.SinkStatus()
+	if status.Delivered != 1 || status.Errors != 0 || status.LastSuccess.IsZero() || status.QueueDepth != 0 {
+		t.Errorf("Expected one successful delivery, got %+v", status)
+	}
+}
+
+func TestWebhookNotifier_GivesUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL)
+	notifier.sleep = func(time.Duration) {}
+
+	err := notifier.Notify(testAlertEvent())
+	if err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if sysErr, ok := err.(models.SystemError); !ok || sysErr.Type != models.TemporaryError {
+		t.Errorf("Expected temporary SystemError, got %v", err)
+	}
+
+	status := notifier.SinkStatus()
+	if status.Errors != 1 || status.Healthy() || !strings.Contains(status.LastErrorMsg, "500") {
+		t.Errorf("Expected failed delivery to be recorded, got %+v", status)
+	}
+}
+
+func TestLogNotifier(t *testing.T) {
+	var buf strings.Builder
+	notifier := NewLogNotifier(log.New(&buf, "", 0))
+
+	if err := notifier.Notify(testAlertEvent()); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	cleared := testAlertEvent()
+	cleared.Fired = false
+	cleared.Value = 80
+	notifier.Notify(cleared)
+
+	output := buf.String()
+	if !strings.Contains(output, "ALERT FIRED Disk / at 97.1%") {
+		t.Errorf("Expected fired alert line, got %q", output)
+	}
+	if !strings.Contains(output, "ALERT CLEARED Disk / back to 80.0%") {
+		t.Errorf("Expected cleared alert line, got %q", output)
+	}
+}
@@ -0,0 +1,431 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+
+	"golang-system-monitor-tui/models"
+)
+
+// otlpScopeName identifies this application as the instrumentation scope
+const otlpScopeName = "golang-system-monitor-tui"
+
+// OTLPConfig configures the OpenTelemetry metrics exporter
+type OTLPConfig struct {
+	Endpoint           string            // Full URL of the OTLP/HTTP metrics endpoint
+	Headers            map[string]string // Extra request headers, e.g. authentication
+	ServiceName        string            // service.name resource attribute
+	ResourceAttributes map[string]string // Additional resource attributes
+	Interval           time.Duration     // How often the latest snapshot is exported
+	Timeout            time.Duration     // Request timeout
+}
+
+// OTLPConfigFromEnv reads the exporter configuration from the standard OTEL_*
+// environment variables. Only the http/json protocol is supported.
+func OTLPConfigFromEnv(getenv func(string) string) (OTLPConfig, error) {
+	config := OTLPConfig{
+		Endpoint:           "http://localhost:4318/v1/metrics",
+		Headers:            map[string]string{},
+		ServiceName:        otlpScopeName,
+		ResourceAttributes: map[string]string{},
+		Interval:           60 * time.Second,
+		Timeout:            10 * time.Second,
+	}
+
+	// Signal-specific variables take precedence over the generic ones
+	lookup := func(generic, specific string) string {
+		if value := getenv(specific); value != "" {
+			return value
+		}
+		return getenv(generic)
+	}
+
+	if endpoint := getenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"); endpoint != "" {
+		config.Endpoint = endpoint
+	} else if endpoint := getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		config.Endpoint = strings.TrimRight(endpoint, "/") + "/v1/metrics"
+	}
+	if _, err := url.ParseRequestURI(config.Endpoint); err != nil {
+		return config, fmt.Errorf("invalid OTLP endpoint %q: %w", config.Endpoint, err)
+	}
+
+	if protocol := lookup("OTEL_EXPORTER_OTLP_PROTOCOL", "OTEL_EXPORTER_OTLP_METRICS_PROTOCOL"); protocol != "" && protocol != "http/json" {
+		return config, fmt.Errorf("unsupported OTLP protocol %q, only http/json is supported", protocol)
+	}
+
+	headers, err := parseOTelKeyValues(lookup("OTEL_EXPORTER_OTLP_HEADERS", "OTEL_EXPORTER_OTLP_METRICS_HEADERS"))
+	if err != nil {
+		return config, fmt.Errorf("invalid OTLP headers: %w", err)
+	}
+	config.Headers = headers
+
+	attributes, err := parseOTelKeyValues(getenv("OTEL_RESOURCE_ATTRIBUTES"))
+	if err != nil {
+		return config, fmt.Errorf("invalid OTEL_RESOURCE_ATTRIBUTES: %w", err)
+	}
+	config.ResourceAttributes = attributes
+	if name := attributes["service.name"]; name != "" {
+		config.ServiceName = name
+	}
+	if name := getenv("OTEL_SERVICE_NAME"); name != "" {
+		config.ServiceName = name
+	}
+
+	if interval := getenv("OTEL_METRIC_EXPORT_INTERVAL"); interval != "" {
+		ms, err := strconv.Atoi(interval)
+		if err != nil || ms <= 0 {
+			return config, fmt.Errorf("invalid OTEL_METRIC_EXPORT_INTERVAL %q", interval)
+		}
+		config.Interval = time.Duration(ms) * time.Millisecond
+	}
+	if timeout := lookup("OTEL_EXPORTER_OTLP_TIMEOUT", "OTEL_EXPORTER_OTLP_METRICS_TIMEOUT"); timeout != "" {
+		ms, err := strconv.Atoi(timeout)
+		if err != nil || ms <= 0 {
+			return config, fmt.Errorf("invalid OTLP timeout %q", timeout)
+		}
+		config.Timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	return config, nil
+}
+
+// parseOTelKeyValues parses the "key1=value1,key2=value2" format with URL-encoded values
+func parseOTelKeyValues(raw string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("malformed pair %q", pair)
+		}
+		decoded, err := url.QueryUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("malformed value for %q: %w", key, err)
+		}
+		values[strings.TrimSpace(key)] = decoded
+	}
+	return values, nil
+}
+
+// OTLPExporter periodically emits the latest snapshot as OpenTelemetry metrics over
+// OTLP/HTTP JSON. Only the newest snapshot is kept, so a slow backend skips
+// intervals instead of buffering them.
+type OTLPExporter struct {
+	config   OTLPConfig
+	client   *http.Client
+	hostname string
+	started  time.Time // Start of the cumulative counters, i.e. boot time
+	tracker  *models.SinkTracker
+	mu       sync.Mutex
+	latest   *models.Snapshot // Newest snapshot not yet exported
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOTLPExporter creates an OTLP exporter; call Start to begin exporting
+func NewOTLPExporter(config OTLPConfig) *OTLPExporter {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	// Interface byte counters reset at boot; fall back to now if it is unknown
+	started := time.Now()
+	if boot, err := host.BootTime(); err == nil && boot > 0 {
+		started = time.Unix(int64(boot), 0)
+	}
+
+	return &OTLPExporter{
+		config:   config,
+		client:   &http.Client{Timeout: config.Timeout},
+		hostname: hostname,
+		started:  started,
+		tracker:  models.NewSinkTracker("otlp"),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Record stores the snapshot for the next export
+func (o *OTLPExporter) Record(snapshot models.Snapshot) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.latest == nil {
+		o.tracker.Enqueue()
+	}
+	o.latest = &snapshot
+}
+
+// Start exports the latest snapshot every interval until Stop is called
+func (o *OTLPExporter) Start() {
+	go func() {
+		ticker := time.NewTicker(o.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-o.stop:
+				return
+			case <-ticker.C:
+				o.Flush()
+			}
+		}
+	}()
+}
+
+// Stop stops the export loop after a final flush
+func (o *OTLPExporter) Stop() {
+	o.stopOnce.Do(func() {
+		close(o.stop)
+		o.Flush()
+	})
+}
+
+// Flush exports the pending snapshot, if any
+func (o *OTLPExporter) Flush() error {
+	o.mu.Lock()
+	snapshot := o.latest
+	o.latest = nil
+	o.mu.Unlock()
+
+	if snapshot == nil {
+		return nil
+	}
+	defer o.tracker.Dequeue()
+
+	if err := o.export(*snapshot); err != nil {
+		o.tracker.RecordError(time.Now(), err)
+		return models.CreateSystemError(models.TemporaryError, "Exporter", "OTLP export failed", err)
+	}
+	o.tracker.RecordSuccess(time.Now())
+	return nil
+}
+
+// SinkStatus returns the exporter's delivery health
+func (o *OTLPExporter) SinkStatus() models.SinkStatus {
+	return o.tracker.Status()
+}
+
+// export posts a single snapshot to the collector
+func (o *OTLPExporter) export(snapshot models.Snapshot) error {
+	body, err := json.Marshal(o.buildRequest(snapshot))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, o.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range o.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// OTLP/HTTP JSON wire types. 64-bit integers are encoded as strings per the spec.
+type (
+	otlpExportRequest struct {
+		ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+	}
+	otlpResourceMetrics struct {
+		Resource     otlpResource       `json:"resource"`
+		ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeMetrics struct {
+		Scope   otlpScope    `json:"scope"`
+		Metrics []otlpMetric `json:"metrics"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpMetric struct {
+		Name        string     `json:"name"`
+		Description string     `json:"description,omitempty"`
+		Unit        string     `json:"unit,omitempty"`
+		Gauge       *otlpGauge `json:"gauge,omitempty"`
+		Sum         *otlpSum   `json:"sum,omitempty"`
+	}
+	otlpGauge struct {
+		DataPoints []otlpDataPoint `json:"dataPoints"`
+	}
+	otlpSum struct {
+		DataPoints             []otlpDataPoint `json:"dataPoints"`
+		AggregationTemporality int             `json:"aggregationTemporality"`
+		IsMonotonic            bool            `json:"isMonotonic"`
+	}
+	otlpDataPoint struct {
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano,omitempty"`
+		TimeUnixNano      string         `json:"timeUnixNano"`
+		AsDouble          *float64       `json:"asDouble,omitempty"`
+		AsInt             string         `json:"asInt,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue *string `json:"stringValue,omitempty"`
+		IntValue    string  `json:"intValue,omitempty"`
+	}
+)
+
+// otlpCumulative is AGGREGATION_TEMPORALITY_CUMULATIVE
+const otlpCumulative = 2
+
+// otlpString builds a string attribute
+func otlpString(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpValue{StringValue: &value}}
+}
+
+// otlpInt builds an integer attribute
+func otlpInt(key string, value int) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpValue{IntValue: strconv.Itoa(value)}}
+}
+
+// buildRequest converts a snapshot into an OTLP export request using the
+// OpenTelemetry system metrics semantic conventions
+func (o *OTLPExporter) buildRequest(snapshot models.Snapshot) otlpExportRequest {
+	ts := strconv.FormatInt(snapshot.Timestamp.UnixNano(), 10)
+	double := func(value float64, attrs ...otlpKeyValue) otlpDataPoint {
+		return otlpDataPoint{Attributes: attrs, TimeUnixNano: ts, AsDouble: &value}
+	}
+	integer := func(value uint64, attrs ...otlpKeyValue) otlpDataPoint {
+		return otlpDataPoint{Attributes: attrs, TimeUnixNano: ts, AsInt: strconv.FormatUint(value, 10)}
+	}
+	gauge := func(name, unit, description string, points []otlpDataPoint) otlpMetric {
+		return otlpMetric{Name: name, Unit: unit, Description: description, Gauge: &otlpGauge{DataPoints: points}}
+	}
+	// counter reports a monotonic cumulative sum counted from o.started
+	start := strconv.FormatInt(o.started.UnixNano(), 10)
+	counter := func(name, unit, description string, points []otlpDataPoint) otlpMetric {
+		for i := range points {
+			points[i].StartTimeUnixNano = start
+		}
+		return otlpMetric{Name: name, Unit: unit, Description: description, Sum: &otlpSum{
+			DataPoints:             points,
+			AggregationTemporality: otlpCumulative,
+			IsMonotonic:            true,
+		}}
+	}
+
+	var metrics []otlpMetric
+
+	// CPU utilization per logical core, as a 0-1 ratio
+	var cpuPoints []otlpDataPoint
+	for i, usage := range snapshot.CPU.Usage {
+		cpuPoints = append(cpuPoints, double(usage/100, otlpInt("cpu.logical_number", i)))
+	}
+	if len(cpuPoints) > 0 {
+		metrics = append(metrics, gauge("system.cpu.utilization", "1", "CPU utilization per logical core", cpuPoints))
+	}
+
+	memory := snapshot.Memory
+	if memory.Total > 0 {
+		metrics = append(metrics,
+			gauge("system.memory.usage", "By", "Memory in use by state", []otlpDataPoint{
+				integer(memory.Used, otlpString("system.memory.state", "used")),
+				integer(memory.Available, otlpString("system.memory.state", "free")),
+			}),
+			gauge("system.memory.utilization", "1", "Fraction of memory in use", []otlpDataPoint{
+				double(float64(memory.Used)/float64(memory.Total), otlpString("system.memory.state", "used")),
+			}))
+	}
+	if memory.Swap.Total > 0 {
+		metrics = append(metrics, gauge("system.paging.usage", "By", "Swap in use by state", []otlpDataPoint{
+			integer(memory.Swap.Used, otlpString("system.paging.state", "used")),
+			integer(memory.Swap.Free, otlpString("system.paging.state", "free")),
+		}))
+	}
+
+	var fsUsage, fsUtilization []otlpDataPoint
+	for _, disk := range snapshot.Disks {
+		attrs := []otlpKeyValue{
+			otlpString("system.device", disk.Device),
+			otlpString("system.filesystem.mountpoint", disk.Mountpoint),
+			otlpString("system.filesystem.type", disk.Filesystem),
+		}
+		fsUsage = append(fsUsage,
+			integer(disk.Used, append(attrs, otlpString("system.filesystem.state", "used"))...),
+			integer(disk.Available, append(attrs, otlpString("system.filesystem.state", "free"))...))
+		fsUtilization = append(fsUtilization, double(disk.UsedPercent/100, attrs...))
+	}
+	if len(fsUsage) > 0 {
+		metrics = append(metrics,
+			gauge("system.filesystem.usage", "By", "Filesystem space by state", fsUsage),
+			gauge("system.filesystem.utilization", "1", "Fraction of filesystem space in use", fsUtilization))
+	}
+
+	var netIO, netRate []otlpDataPoint
+	for _, iface := range snapshot.Network {
+		name := otlpString("network.interface.name", iface.Interface)
+		netIO = append(netIO,
+			integer(iface.BytesSent, name, otlpString("network.io.direction", "transmit")),
+			integer(iface.BytesRecv, name, otlpString("network.io.direction", "receive")))
+		if rate, ok := snapshot.Rates[iface.Interface]; ok {
+			netRate = append(netRate,
+				double(rate.SendRate, name, otlpString("network.io.direction", "transmit")),
+				double(rate.RecvRate, name, otlpString("network.io.direction", "receive")))
+		}
+	}
+	if len(netIO) > 0 {
+		metrics = append(metrics, counter("system.network.io", "By", "Bytes transferred since boot", netIO))
+	}
+	if len(netRate) > 0 {
+		metrics = append(metrics, gauge("system.network.io.rate", "By/s", "Current transfer rate", netRate))
+	}
+
+	// Configured resource attributes win over the detected host name
+	attributes := map[string]string{"host.name": o.hostname}
+	for key, value := range o.config.ResourceAttributes {
+		attributes[key] = value
+	}
+	delete(attributes, "service.name")
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resource := []otlpKeyValue{otlpString("service.name", o.config.ServiceName)}
+	for _, key := range keys {
+		resource = append(resource, otlpString(key, attributes[key]))
+	}
+
+	return otlpExportRequest{
+		ResourceMetrics: []otlpResourceMetrics{{
+			Resource: otlpResource{Attributes: resource},
+			ScopeMetrics: []otlpScopeMetrics{{
+				Scope:   otlpScope{Name: otlpScopeName},
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func testSnapshot() models.Snapshot {
+	return models.Snapshot{
+		Timestamp: time.Unix(1700000000, 0),
+		CPU:       models.CPUInfo{Cores: 2, Usage: []float64{50, 25}, Total: 37.5},
+		Memory:    models.MemoryInfo{Total: 1000, Used: 600, Available: 400},
+		Disks:     []models.DiskInfo{{Device: "/dev/sda1", Mountpoint: "/", Filesystem: "ext4", Used: 70, Available: 30, UsedPercent: 70}},
+		Network:   []models.NetworkInfo{{Interface: "eth0", BytesSent: 10, BytesRecv: 20}},
+		Rates:     map[string]models.NetworkStats{"eth0": {SendRate: 1, RecvRate: 2}},
+	}
+}
+
+func envFrom(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestOTLPConfigFromEnv_Defaults(t *testing.T) {
+	config, err := OTLPConfigFromEnv(envFrom(nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Endpoint != "http://localhost:4318/v1/metrics" {
+		t.Errorf("Unexpected default endpoint %q", config.Endpoint)
+	}
+	if config.Interval != time.Minute || config.Timeout != 10*time.Second {
+		t.Errorf("Unexpected default interval/timeout: %v/%v", config.Interval, config.Timeout)
+	}
+	if config.ServiceName != otlpScopeName {
+		t.Errorf("Unexpected default service name %q", config.ServiceName)
+	}
+}
+
+func TestOTLPConfigFromEnv(t *testing.T) {
+	config, err := OTLPConfigFromEnv(envFrom(map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT":         "https://otel.example.com:4318/",
+		"OTEL_EXPORTER_OTLP_HEADERS":          "api-key=secret%3D1,x-team=infra",
+		"OTEL_EXPORTER_OTLP_METRICS_TIMEOUT":  "2500",
+		"OTEL_METRIC_EXPORT_INTERVAL":         "15000",
+		"OTEL_RESOURCE_ATTRIBUTES":            "deployment.environment=prod,service.name=from-attrs",
+		"OTEL_SERVICE_NAME":                   "edge-monitor",
+		"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/json",
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Endpoint != "https://otel.example.com:4318/v1/metrics" {
+		t.Errorf("Expected signal path appended to base endpoint, got %q", config.Endpoint)
+	}
+	if config.Headers["api-key"] != "secret=1" || config.Headers["x-team"] != "infra" {
+		t.Errorf("Unexpected headers %v", config.Headers)
+	}
+	if config.Timeout != 2500*time.Millisecond || config.Interval != 15*time.Second {
+		t.Errorf("Unexpected timeout/interval %v/%v", config.Timeout, config.Interval)
+	}
+	if config.ServiceName != "edge-monitor" || config.ResourceAttributes["deployment.environment"] != "prod" {
+		t.Errorf("Unexpected resource config: %q %v", config.ServiceName, config.ResourceAttributes)
+	}
+
+	// The metrics-specific endpoint is used verbatim
+	config, _ = OTLPConfigFromEnv(envFrom(map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT":         "http://ignored:4318",
+		"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT": "http://collector:4318/custom",
+	}))
+	if config.Endpoint != "http://collector:4318/custom" {
+		t.Errorf("Expected metrics endpoint verbatim, got %q", config.Endpoint)
+	}
+}
+
+func TestOTLPConfigFromEnv_Errors(t *testing.T) {
+	for _, env := range []map[string]string{
+		{"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc"},
+		{"OTEL_METRIC_EXPORT_INTERVAL": "soon"},
+		{"OTEL_EXPORTER_OTLP_HEADERS": "novalue"},
+		{"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT": "not a url"},
+	} {
+		if _, err := OTLPConfigFromEnv(envFrom(env)); err == nil {
+			t.Errorf("Expected error for %v", env)
+		}
+	}
+}
+
+func TestOTLPExporter_Flush(t *testing.T) {
+	var request map[string]interface{}
+	var authHeader string
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		authHeader = r.Header.Get("api-key")
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected JSON content type, got %s", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	config, _ := OTLPConfigFromEnv(envFrom(map[string]string{
+		"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT": server.URL + "/v1/metrics",
+		"OTEL_EXPORTER_OTLP_HEADERS":          "api-key=secret",
+	}))
+	exporter := NewOTLPExporter(config)
+
+	// Nothing recorded, nothing sent
+	if err := exporter.Flush(); err != nil || requests != 0 {
+		t.Fatalf("Expected no export without a snapshot, got %d requests (%v)", requests, err)
+	}
+
+	exporter.Record(testSnapshot())
+	exporter.Record(testSnapshot())
+	if depth := exporter.SinkStatus().QueueDepth; depth != 1 {
+		t.Errorf("Expected only the latest snapshot to be pending, got depth %d", depth)
+	}
+	if err := exporter.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if requests != 1 || authHeader != "secret" {
+		t.Errorf("Expected one authenticated request, got %d (%q)", requests, authHeader)
+	}
+
+	body, _ := json.Marshal(request)
+	for _, want := range []string{
+		`"system.cpu.utilization"`, `"asDouble":0.5`, `"cpu.logical_number"`,
+		`"system.memory.usage"`, `"asInt":"600"`,
+		`"system.filesystem.utilization"`, `"system.filesystem.mountpoint"`,
+		`"system.network.io"`, `"network.io.direction"`,
+		`"timeUnixNano":"1700000000000000000"`,
+		`"service.name"`, `"host.name"`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("Expected %s in export request, got %s", want, body)
+		}
+	}
+
+	status := exporter.SinkStatus()
+	if status.Name != "otlp" || status.Delivered != 1 || status.QueueDepth != 0 {
+		t.Errorf("Unexpected sink status %+v", status)
+	}
+}
+
+func TestOTLPExporter_FlushError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(OTLPConfig{Endpoint: server.URL, Timeout: time.Second})
+	exporter.Record(testSnapshot())
+
+	if err := exporter.Flush(); err == nil {
+		t.Fatal("Expected error from rejected export")
+	}
+	if status := exporter.SinkStatus(); status.Errors != 1 || status.Healthy() {
+		t.Errorf("Expected failed export to be recorded, got %+v", status)
+	}
+}
+
+func TestOTLPExporter_NetworkIOIsCumulativeSum(t *testing.T) {
+	exporter := NewOTLPExporter(OTLPConfig{Endpoint: "http://localhost:4318/v1/metrics"})
+	exporter.started = time.Unix(1600000000, 0)
+
+	found := false
+	request := exporter.buildRequest(testSnapshot())
+	for _, metric := range request.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		switch metric.Name {
+		case "system.network.io":
+			if metric.Gauge != nil || metric.Sum == nil {
+				t.Fatalf("Expected network I/O as a sum, got %+v", metric)
+			}
+			if !metric.Sum.IsMonotonic || metric.Sum.AggregationTemporality != otlpCumulative {
+				t.Errorf("Expected a monotonic cumulative sum, got %+v", metric.Sum)
+			}
+			for _, point := range metric.Sum.DataPoints {
+				if point.StartTimeUnixNano != "1600000000000000000" {
+					t.Errorf("Expected the counter to start at boot, got %s", point.StartTimeUnixNano)
+				}
+			}
+			found = true
+		case "system.network.io.rate":
+			if metric.Gauge == nil || metric.Sum != nil {
+				t.Errorf("Expected the transfer rate to stay a gauge, got %+v", metric)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("Expected a system.network.io metric")
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// PluginCollectorPrefix prefixes the registry names of plugin collectors so
+// they can't clash with the built-in collectors
+const PluginCollectorPrefix = "plugin:"
+
+const (
+	maxPluginOutput = 1 << 20                // Bound on the stdout read from a plugin run
+	maxPluginStderr = 4 << 10                // Bound on the stderr kept for the error message
+	pluginWaitDelay = 100 * time.Millisecond // Wait for output after a timed-out plugin is killed
+)
+
+// PluginCollector runs an external plugin executable on every collection and
+// parses the JSON it prints to stdout. Failures are reported in the Error
+// field of the output, so the plugin panel can show them.
+type PluginCollector struct {
+	name    string
+	command []string
+	timeout time.Duration
+	running sync.Mutex // Held while the plugin runs, slow plugins are not started twice
+	mu      sync.Mutex
+	last    models.PluginOutput // Result of the last finished run
+}
+
+// NewPluginCollector creates a collector running command, the executable
+// followed by its arguments, for at most timeout
+func NewPluginCollector(name string, command []string, timeout time.Duration) *PluginCollector {
+	return &PluginCollector{
+		name:    name,
+		command: command,
+		timeout: timeout,
+		last:    models.PluginOutput{Name: name},
+	}
+}
+
+// Name returns the registry name of the plugin collector
+func (p *PluginCollector) Name() string {
+	return PluginCollectorPrefix + p.name
+}
+
+// Collect runs the plugin and returns its models.PluginOutput. While an
+// earlier run is still going, the last result is returned instead.
+func (p *PluginCollector) Collect() (interface{}, error) {
+	if !p.running.TryLock() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.last, nil
+	}
+	defer p.running.Unlock()
+
+	output := p.run()
+	p.mu.Lock()
+	p.last = output
+	p.mu.Unlock()
+	return output, nil
+}
+
+// run executes the plugin once
+func (p *PluginCollector) run() models.PluginOutput {
+	failed := func(format string, args ...interface{}) models.PluginOutput {
+		return models.PluginOutput{Name: p.name, Error: fmt.Sprintf(format, args...), Timestamp: time.Now()}
+	}
+	if len(p.command) == 0 {
+		return failed("no command configured")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	stdout := &cappedBuffer{limit: maxPluginOutput}
+	stderr := &cappedBuffer{limit: maxPluginStderr}
+	cmd := exec.CommandContext(ctx, p.command[0], p.command[1:]...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// Children of a killed plugin may hold its output open, stop waiting for them
+	cmd.WaitDelay = pluginWaitDelay
+
+	err := cmd.Run()
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return failed("timed out after %v", p.timeout)
+	case err != nil:
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if message := firstLine(stderr.String()); message != "" {
+				return failed("exited with status %d: %s", exitErr.ExitCode(), message)
+			}
+			return failed("exited with status %d", exitErr.ExitCode())
+		}
+		return failed("%v", err)
+	case stdout.truncated:
+		return failed("output exceeds %d bytes", maxPluginOutput)
+	}
+
+	output, err := models.ParsePluginOutput(stdout.Bytes())
+	if err != nil {
+		return failed("%v", err)
+	}
+	output.Name = p.name
+	output.Timestamp = time.Now()
+	return output
+}
+
+// firstLine returns the first non-empty line of text
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// cappedBuffer keeps the first limit bytes written to it and drops the rest
+type cappedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// Write implements io.Writer, never failing so the plugin isn't killed by a broken pipe
+func (c *cappedBuffer) Write(data []byte) (int, error) {
+	if room := c.limit - c.Len(); len(data) > room {
+		c.truncated = true
+		if room > 0 {
+			c.Buffer.Write(data[:room])
+		}
+		return len(data), nil
+	}
+	return c.Buffer.Write(data)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// collectPlugin runs a shell script as a plugin and returns its output
+func collectPlugin(t *testing.T, script string, timeout time.Duration) models.PluginOutput {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugin scripts need a POSIX shell")
+	}
+	collector := NewPluginCollector("db", []string{"sh", "-c", script}, timeout)
+	if collector.Name() != "plugin:db" {
+		t.Errorf("Expected the prefixed registry name, got %q", collector.Name())
+	}
+	data, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	return data.(models.PluginOutput)
+}
+
+func TestPluginCollector(t *testing.T) {
+	output := collectPlugin(t, `echo '{"title": "Database", "metrics": [{"label": "connections", "value": 12, "max": 50}]}'`, time.Second)
+	if output.Error != "" {
+		t.Fatalf("Unexpected plugin error %q", output.Error)
+	}
+	if output.Name != "db" || output.Title != "Database" || len(output.Metrics) != 1 || output.Metrics[0].Value != 12 {
+		t.Errorf("Unexpected plugin output %+v", output)
+	}
+	if output.Timestamp.IsZero() {
+		t.Error("Expected a timestamp")
+	}
+}
+
+func TestPluginCollector_Failures(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"exit status", `echo "connection refused" >&2; exit 3`, "exited with status 3: connection refused"},
+		{"noisy stderr", `echo "connection refused" >&2; head -c 1000000 /dev/zero >&2; exit 3`, "exited with status 3: connection refused"},
+		{"invalid JSON", `echo "connections=12"`, "invalid plugin output"},
+		{"timeout", `sleep 5`, "timed out after 100ms"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := collectPlugin(t, test.script, 100*time.Millisecond)
+			if !strings.Contains(output.Error, test.want) || output.Name != "db" {
+				t.Errorf("Expected error containing %q, got %+v", test.want, output)
+			}
+		})
+	}
+
+	collector := NewPluginCollector("missing", []string{"/nonexistent/plugin"}, time.Second)
+	data, _ := collector.Collect()
+	if output := data.(models.PluginOutput); output.Error == "" {
+		t.Error("Expected an error for a missing executable")
+	}
+}
+
+func TestCappedBuffer(t *testing.T) {
+	buffer := &cappedBuffer{limit: 4}
+	if n, err := buffer.Write([]byte("abcdef")); n != 6 || err != nil {
+		t.Errorf("Expected the write to succeed, got %d, %v", n, err)
+	}
+	if buffer.String() != "abcd" || !buffer.truncated {
+		t.Errorf("Expected the first 4 bytes and truncation, got %q", buffer.String())
+	}
+}
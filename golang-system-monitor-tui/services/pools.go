@@ -0,0 +1,318 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// PoolsCollectorName is the registry name of the storage pool collector
+const PoolsCollectorName = "Pools"
+
+const (
+	poolsTimeout  = 5 * time.Second  // Bounds a single zpool, zfs or btrfs run
+	poolsInterval = 30 * time.Second // Pool health changes slowly, the commands run this often at most
+)
+
+// PoolsCollector reports the health, scrub progress and compression ratio of
+// ZFS pools, and the error counters and scrub progress of btrfs filesystems,
+// by running the zpool, zfs and btrfs commands. Results are reused for
+// poolsInterval between runs.
+type PoolsCollector struct {
+	zfs        bool   // Whether zpool is installed
+	btrfs      bool   // Whether btrfs is installed
+	mountsPath string // Mount table the btrfs filesystems are found in
+	run        func(name string, args ...string) ([]byte, error)
+	now        func() time.Time
+
+	mu     sync.Mutex
+	last   []models.StoragePool
+	lastAt time.Time
+}
+
+// NewPoolsCollector returns a collector of ZFS and btrfs health, or nil when
+// neither zpool nor btrfs is installed
+func NewPoolsCollector() *PoolsCollector {
+	_, zfsErr := exec.LookPath("zpool")
+	_, btrfsErr := exec.LookPath("btrfs")
+	if zfsErr != nil && btrfsErr != nil {
+		return nil
+	}
+	return &PoolsCollector{
+		zfs:        zfsErr == nil,
+		btrfs:      btrfsErr == nil,
+		mountsPath: "/proc/mounts",
+		run:        runPoolCommand,
+		now:        time.Now,
+	}
+}
+
+// Name returns the registry name of the storage pool collector
+func (p *PoolsCollector) Name() string {
+	return PoolsCollectorName
+}
+
+// Collect returns the []models.StoragePool of all ZFS pools and btrfs
+// filesystems, the last result while it is younger than poolsInterval
+func (p *PoolsCollector) Collect() (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.last != nil && p.now().Sub(p.lastAt) < poolsInterval {
+		return p.last, nil
+	}
+
+	pools := []models.StoragePool{}
+	if p.zfs {
+		zfsPools, err := p.collectZFS()
+		if err != nil {
+			return nil, models.CreateSystemError(models.SystemAccessError, PoolsCollectorName, "Failed to list ZFS pools", err)
+		}
+		pools = append(pools, zfsPools...)
+	}
+	if p.btrfs {
+		pools = append(pools, p.collectBtrfs()...)
+	}
+
+	now := p.now()
+	for i := range pools {
+		pools[i].Timestamp = now
+	}
+	p.last, p.lastAt = pools, now
+	return pools, nil
+}
+
+// collectZFS lists the ZFS pools with their health, scrub and compression
+func (p *PoolsCollector) collectZFS() ([]models.StoragePool, error) {
+	output, err := p.run("zpool", "list", "-H", "-o", "name,health")
+	if err != nil {
+		return nil, err
+	}
+	pools := parseZpoolList(string(output))
+	if len(pools) == 0 {
+		return pools, nil
+	}
+
+	names := make([]string, len(pools))
+	for i, pool := range pools {
+		names[i] = pool.Name
+	}
+	ratios := map[string]float64{}
+	if output, err := p.run("zfs", append([]string{"get", "-H", "-o", "name,value", "compressratio"}, names...)...); err == nil {
+		ratios = parseZfsCompressRatio(string(output))
+	}
+	for i := range pools {
+		pools[i].CompressRatio = ratios[pools[i].Name]
+		if output, err := p.run("zpool", "status", pools[i].Name); err == nil {
+			pools[i].Scrub, pools[i].ScrubProgress = parseZpoolScrub(string(output))
+		}
+	}
+	return pools, nil
+}
+
+// collectBtrfs reads the error counters and scrub status of every mounted
+// btrfs filesystem. Both need root; without it the pool carries the error.
+func (p *PoolsCollector) collectBtrfs() []models.StoragePool {
+	var pools []models.StoragePool
+	for _, mountpoint := range btrfsMountpoints(p.mountsPath) {
+		pool := models.StoragePool{Name: mountpoint, Kind: "btrfs", State: "OK", Healthy: true, ScrubProgress: -1}
+		output, err := p.run("btrfs", "device", "stats", mountpoint)
+		if err != nil && len(output) == 0 {
+			pool.State, pool.Error = "unknown", firstLine(err.Error())
+			pools = append(pools, pool)
+			continue
+		}
+		// btrfs exits non-zero when a counter is set, the output is still complete
+		if pool.Errors = parseBtrfsDeviceStats(string(output)); pool.Errors > 0 {
+			pool.State, pool.Healthy = "ERRORS", false
+		}
+		if output, err := p.run("btrfs", "scrub", "status", mountpoint); err == nil {
+			pool.Scrub, pool.ScrubProgress = parseBtrfsScrub(string(output))
+		}
+		pools = append(pools, pool)
+	}
+	return pools
+}
+
+// runPoolCommand runs a pool command, killing it after poolsTimeout so a
+// pool stuck in I/O can't hold up the collector
+func runPoolCommand(name string, args ...string) ([]byte, error) {
+	return runCommand(poolsTimeout, name, args...)
+}
+
+// runCommand runs a command for its standard output, killing it after
+// timeout. A failure carries the first line of standard error.
+func runCommand(timeout time.Duration, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = pluginWaitDelay
+	output, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return output, fmt.Errorf("%s: %s", name, firstLine(string(exitErr.Stderr)))
+	}
+	return output, err
+}
+
+// btrfsMountpoints returns the first mountpoint of every btrfs filesystem in
+// a mount table; subvolumes of one filesystem share its device
+func btrfsMountpoints(mountsPath string) []string {
+	file, err := os.Open(mountsPath)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var mountpoints []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[2] != "btrfs" || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		mountpoints = append(mountpoints, fields[1])
+	}
+	return mountpoints
+}
+
+// parseZpoolList parses `zpool list -H -o name,health`, one tab-separated
+// pool per line
+func parseZpoolList(output string) []models.StoragePool {
+	var pools []models.StoragePool
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		pools = append(pools, models.StoragePool{
+			Name:          fields[0],
+			Kind:          "zfs",
+			State:         fields[1],
+			Healthy:       fields[1] == "ONLINE",
+			ScrubProgress: -1,
+		})
+	}
+	return pools
+}
+
+// parseZfsCompressRatio parses `zfs get -H -o name,value compressratio`,
+// whose values look like 1.52x
+func parseZfsCompressRatio(output string) map[string]float64 {
+	ratios := map[string]float64{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if ratio, err := strconv.ParseFloat(strings.TrimSuffix(fields[1], "x"), 64); err == nil {
+			ratios[fields[0]] = ratio
+		}
+	}
+	return ratios
+}
+
+// parseZpoolScrub reads the scan section of `zpool status`:
+//
+//	scan: scrub in progress since Sun Oct 13 00:24:01 2024
+//	      1.23T scanned at 1.2G/s, 800G issued at 900M/s, 2.5T total
+//	      0B repaired, 31.25% done, 00:30:00 to go
+//
+// or, once finished, "scan: scrub repaired 0B in 01:02:03 with 0 errors on ...".
+// It returns the scrub summary and the percent done, -1 when none runs.
+func parseZpoolScrub(output string) (string, float64) {
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "scan:") {
+			continue
+		}
+		scan := strings.TrimSpace(strings.TrimPrefix(line, "scan:"))
+		switch {
+		case strings.HasPrefix(scan, "scrub in progress"):
+			for _, next := range lines[i+1:] {
+				for _, part := range strings.Split(next, ",") {
+					part = strings.TrimSpace(part)
+					if done, ok := strings.CutSuffix(part, "% done"); ok {
+						if progress, err := strconv.ParseFloat(done, 64); err == nil {
+							return "in progress", progress
+						}
+					}
+				}
+			}
+			return "in progress", 0
+		case strings.HasPrefix(scan, "scrub repaired"):
+			summary := strings.TrimPrefix(scan, "scrub ")
+			// Drop the duration and the date: "repaired 0B in 01:02:03 with 0 errors on ..."
+			if before, after, ok := strings.Cut(summary, " in "); ok {
+				if _, found, ok := strings.Cut(after, " with "); ok {
+					summary = before + " with " + found
+				}
+			}
+			if before, _, ok := strings.Cut(summary, " on "); ok {
+				summary = before
+			}
+			return summary, -1
+		case strings.HasPrefix(scan, "scrub canceled"):
+			return "canceled", -1
+		}
+		return "", -1
+	}
+	return "", -1
+}
+
+// parseBtrfsDeviceStats sums the counters of `btrfs device stats`, lines
+// such as "[/dev/sda1].write_io_errs    0"
+func parseBtrfsDeviceStats(output string) uint64 {
+	var total uint64
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.HasPrefix(fields[0], "[") {
+			continue
+		}
+		if count, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			total += count
+		}
+	}
+	return total
+}
+
+// parseBtrfsScrub reads the Status, Bytes scrubbed and Error summary lines of
+// `btrfs scrub status`. It returns the scrub summary and the percent done,
+// -1 when none runs.
+func parseBtrfsScrub(output string) (string, float64) {
+	values := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	switch values["Status"] {
+	case "running":
+		progress := 0.0
+		// e.g. "25.00GiB  (25.00%)"
+		if _, percent, ok := strings.Cut(values["Bytes scrubbed"], "("); ok {
+			if value, err := strconv.ParseFloat(strings.TrimSuffix(percent, "%)"), 64); err == nil {
+				progress = value
+			}
+		}
+		return "in progress", progress
+	case "finished":
+		return values["Error summary"], -1
+	case "aborted", "interrupted":
+		return values["Status"], -1
+	}
+	return "", -1
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+const zpoolStatusScrubbing = `  pool: tank
+ state: ONLINE
+  scan: scrub in progress since Sun Oct 13 00:24:01 2024
+	1.23T scanned at 1.20G/s, 800G issued at 900M/s, 2.50T total
+	0B repaired, 31.25% done, 00:30:00 to go
+config:
+
+	NAME        STATE     READ WRITE CKSUM
+	tank        ONLINE       0     0     0
+`
+
+const zpoolStatusScrubbed = `  pool: backup
+ state: DEGRADED
+  scan: scrub repaired 0B in 01:02:03 with 0 errors on Sun Oct 13 01:26:04 2024
+config:
+`
+
+const btrfsScrubRunning = `UUID:             8f5d2a34-1c1b-4c6e-9a0e-3f2c4b9d7e11
+Scrub started:    Sun Oct 13 00:24:01 2024
+Status:           running
+Duration:         0:10:00
+Bytes scrubbed:   25.00GiB  (25.00%)
+Rate:             42.67MiB/s
+Error summary:    no errors found
+`
+
+func TestParseZpoolList(t *testing.T) {
+	got := parseZpoolList("tank\tONLINE\nbackup\tDEGRADED\n\n")
+	want := []models.StoragePool{
+		{Name: "tank", Kind: "zfs", State: "ONLINE", Healthy: true, ScrubProgress: -1},
+		{Name: "backup", Kind: "zfs", State: "DEGRADED", ScrubProgress: -1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseZpoolList() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseZfsCompressRatio(t *testing.T) {
+	got := parseZfsCompressRatio("tank\t1.52x\nbackup\t-\n")
+	if len(got) != 1 || got["tank"] != 1.52 {
+		t.Errorf("parseZfsCompressRatio() = %v, want tank at 1.52", got)
+	}
+}
+
+func TestParseZpoolScrub(t *testing.T) {
+	tests := []struct {
+		output   string
+		summary  string
+		progress float64
+	}{
+		{zpoolStatusScrubbing, "in progress", 31.25},
+		{zpoolStatusScrubbed, "repaired 0B with 0 errors", -1},
+		{"  scan: scrub canceled on Sun Oct 13 00:30:00 2024\n", "canceled", -1},
+		{"  scan: none requested\n", "", -1},
+		{"  pool: tank\n", "", -1},
+	}
+	for _, tt := range tests {
+		summary, progress := parseZpoolScrub(tt.output)
+		if summary != tt.summary || progress != tt.progress {
+			t.Errorf("parseZpoolScrub(%q) = %q, %v, want %q, %v", tt.output, summary, progress, tt.summary, tt.progress)
+		}
+	}
+}
+
+func TestParseBtrfsDeviceStats(t *testing.T) {
+	output := `[/dev/sda1].write_io_errs    0
+[/dev/sda1].read_io_errs     2
+[/dev/sda1].flush_io_errs    0
+[/dev/sda1].corruption_errs  1
+[/dev/sda1].generation_errs  0
+`
+	if got := parseBtrfsDeviceStats(output); got != 3 {
+		t.Errorf("parseBtrfsDeviceStats() = %d, want 3", got)
+	}
+}
+
+func TestParseBtrfsScrub(t *testing.T) {
+	tests := []struct {
+		output   string
+		summary  string
+		progress float64
+	}{
+		{btrfsScrubRunning, "in progress", 25},
+		{"Status:           finished\nError summary:    no errors found\n", "no errors found", -1},
+		{"Status:           aborted\n", "aborted", -1},
+		{"\tno stats available\n", "", -1},
+	}
+	for _, tt := range tests {
+		summary, progress := parseBtrfsScrub(tt.output)
+		if summary != tt.summary || progress != tt.progress {
+			t.Errorf("parseBtrfsScrub(%q) = %q, %v, want %q, %v", tt.output, summary, progress, tt.summary, tt.progress)
+		}
+	}
+}
+
+func TestBtrfsMountpoints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mounts")
+	mounts := `/dev/sda1 / btrfs rw,subvol=/@ 0 0
+/dev/sda1 /home btrfs rw,subvol=/@home 0 0
+/dev/sdb1 /data ext4 rw 0 0
+/dev/sdc1 /backup btrfs rw 0 0
+`
+	if err := os.WriteFile(path, []byte(mounts), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := btrfsMountpoints(path), []string{"/", "/backup"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("btrfsMountpoints() = %v, want %v", got, want)
+	}
+	if got := btrfsMountpoints(filepath.Join(t.TempDir(), "missing")); got != nil {
+		t.Errorf("Expected no mountpoints without a mount table, got %v", got)
+	}
+}
+
+func TestPoolsCollector_Collect(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mounts")
+	if err := os.WriteFile(path, []byte("/dev/sda1 / btrfs rw 0 0\n/dev/sdc1 /backup btrfs rw 0 0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	runs := 0
+	now := time.Date(2024, 10, 13, 0, 40, 0, 0, time.UTC)
+	collector := &PoolsCollector{
+		zfs:        true,
+		btrfs:      true,
+		mountsPath: path,
+		now:        func() time.Time { return now },
+		run: func(name string, args ...string) ([]byte, error) {
+			runs++
+			switch command := name + " " + strings.Join(args, " "); command {
+			case "zpool list -H -o name,health":
+				return []byte("tank\tONLINE\n"), nil
+			case "zfs get -H -o name,value compressratio tank":
+				return []byte("tank\t1.52x\n"), nil
+			case "zpool status tank":
+				return []byte(zpoolStatusScrubbing), nil
+			case "btrfs device stats /":
+				// Set counters make btrfs exit non-zero
+				return []byte("[/dev/sda1].read_io_errs 2\n"), errors.New("exit status 64")
+			case "btrfs scrub status /":
+				return []byte("Status: finished\nError summary: no errors found\n"), nil
+			case "btrfs device stats /backup":
+				return nil, errors.New("btrfs: ERROR: getting device info for /backup failed: Operation not permitted")
+			default:
+				t.Fatalf("Unexpected command %q", command)
+				return nil, nil
+			}
+		},
+	}
+	if collector.Name() != PoolsCollectorName {
+		t.Errorf("Expected the name %q, got %q", PoolsCollectorName, collector.Name())
+	}
+
+	data, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	want := []models.StoragePool{
+		{Name: "tank", Kind: "zfs", State: "ONLINE", Healthy: true, Scrub: "in progress", ScrubProgress: 31.25, CompressRatio: 1.52, Timestamp: now},
+		{Name: "/", Kind: "btrfs", State: "ERRORS", Errors: 2, Scrub: "no errors found", ScrubProgress: -1, Timestamp: now},
+		{Name: "/backup", Kind: "btrfs", State: "unknown", Healthy: true, ScrubProgress: -1,
+			Error: "btrfs: ERROR: getting device info for /backup failed: Operation not permitted", Timestamp: now},
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Collect() = %+v, want %+v", data, want)
+	}
+
+	// The commands run again only after poolsInterval
+	ran := runs
+	collector.Collect()
+	if runs != ran {
+		t.Errorf("Expected the cached pools within %v, got %d more runs", poolsInterval, runs-ran)
+	}
+	now = now.Add(poolsInterval)
+	collector.Collect()
+	if runs == ran {
+		t.Errorf("Expected the commands to run again after %v", poolsInterval)
+	}
+}
+
+func TestPoolsCollector_CollectZpoolFailure(t *testing.T) {
+	collector := &PoolsCollector{
+		zfs: true,
+		now: time.Now,
+		run: func(name string, args ...string) ([]byte, error) {
+			return nil, errors.New("zpool: The ZFS modules are not loaded")
+		},
+	}
+	if _, err := collector.Collect(); err == nil {
+		t.Error("Expected an error when zpool list fails")
+	}
+}
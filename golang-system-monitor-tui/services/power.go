@@ -0,0 +1,204 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// PowerCollectorName is the registry name of the CPU power collector
+const PowerCollectorName = "Power"
+
+// powerTimeout bounds a powermetrics run, which samples for a second
+const powerTimeout = 5 * time.Second
+
+// PowerCollector samples the core clusters and power draw of an Apple Silicon
+// CPU with powermetrics. Failures, such as powermetrics not being run as
+// root, are returned as errors so the collector is backed off, and are also
+// reported in the Error field of the result.
+type PowerCollector struct {
+	command []string
+	running sync.Mutex // Held while powermetrics runs, slow samples are not started twice
+	mu      sync.Mutex
+	last    models.CPUPower // Result of the last finished run
+	lastErr error           // Error of the last finished run
+}
+
+// Name returns the registry name of the power collector
+func (p *PowerCollector) Name() string {
+	return PowerCollectorName
+}
+
+// Collect runs powermetrics and returns its models.CPUPower. While an earlier
+// run is still going, the last result is returned instead.
+func (p *PowerCollector) Collect() (interface{}, error) {
+	if !p.running.TryLock() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.last, p.lastErr
+	}
+	defer p.running.Unlock()
+
+	power, err := p.run()
+	p.mu.Lock()
+	p.last, p.lastErr = power, err
+	p.mu.Unlock()
+	return power, err
+}
+
+// run executes powermetrics once
+func (p *PowerCollector) run() (models.CPUPower, error) {
+	failed := func(errorType models.ErrorType, original error, format string, args ...interface{}) (models.CPUPower, error) {
+		message := fmt.Sprintf(format, args...)
+		return models.CPUPower{Error: message, Timestamp: time.Now()},
+			models.CreateSystemError(errorType, PowerCollectorName, message, original)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), powerTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.command[0], p.command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = pluginWaitDelay
+
+	err := cmd.Run()
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return failed(models.TemporaryError, ctx.Err(), "powermetrics timed out after %v", powerTimeout)
+	case err != nil:
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			message := firstLine(stderr.String())
+			if message == "" {
+				message = fmt.Sprintf("powermetrics exited with status %d", exitErr.ExitCode())
+			}
+			// powermetrics refuses to run without root
+			if strings.Contains(message, "superuser") {
+				return failed(models.PermissionError, err, "%s", message)
+			}
+			return failed(models.SystemAccessError, err, "%s", message)
+		}
+		return failed(models.SystemAccessError, err, "%v", err)
+	}
+
+	power, err := parsePowermetrics(stdout.Bytes())
+	if err != nil {
+		return failed(models.DataCollectionError, err, "%v", err)
+	}
+	power.Timestamp = time.Now()
+	return power, nil
+}
+
+// parsePowermetrics reads the text output of the cpu_power sampler:
+//
+//	E-Cluster HW active frequency: 1035 MHz
+//	E-Cluster HW active residency:  20.47% (600 MHz:   0% ...)
+//	CPU 0 frequency: 1283 MHz
+//	...
+//	CPU Power: 95 mW
+//	Combined Power (CPU + GPU + ANE): 101 mW
+//
+// The CPU lines following a cluster are counted as its cores.
+func parsePowermetrics(output []byte) (models.CPUPower, error) {
+	var power models.CPUPower
+	found := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		if name, field, ok := strings.Cut(key, "-Cluster HW active "); ok {
+			if len(power.Clusters) == 0 || power.Clusters[len(power.Clusters)-1].Name != name {
+				power.Clusters = append(power.Clusters, models.CPUCluster{Name: name})
+			}
+			cluster := &power.Clusters[len(power.Clusters)-1]
+			switch field {
+			case "frequency":
+				cluster.Frequency = leadingNumber(value)
+			case "residency":
+				cluster.Active = leadingNumber(value)
+			}
+			found = true
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(key, "CPU ") && strings.HasSuffix(key, " frequency"):
+			if len(power.Clusters) > 0 {
+				power.Clusters[len(power.Clusters)-1].Cores++
+			}
+		case strings.HasSuffix(key, " Power") || strings.HasPrefix(key, "Combined Power"):
+			watts, ok := powerWatts(value)
+			if !ok {
+				continue
+			}
+			switch {
+			case key == "CPU Power":
+				power.CPUWatts = watts
+			case key == "GPU Power":
+				power.GPUWatts = watts
+			case key == "ANE Power":
+				power.ANEWatts = watts
+			case key == "Package Power" || strings.HasPrefix(key, "Combined Power"):
+				power.PackageWatts = watts
+			default:
+				continue
+			}
+			found = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return models.CPUPower{}, err
+	}
+	if !found {
+		return models.CPUPower{}, fmt.Errorf("no CPU power samples in powermetrics output")
+	}
+	return power, nil
+}
+
+// powerWatts parses a power reading such as "95 mW" or "1.2 W"
+func powerWatts(value string) (float64, bool) {
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, false
+	}
+	switch fields[1] {
+	case "mW":
+		return number / 1000, true
+	case "W":
+		return number, true
+	}
+	return 0, false
+}
+
+// leadingNumber parses the number at the start of value, such as 20.47 in
+// "20.47% (600 MHz: 0%)", or 0 when there is none
+func leadingNumber(value string) float64 {
+	end := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end < 0 {
+		end = len(value)
+	}
+	number, _ := strconv.ParseFloat(value[:end], 64)
+	return number
+}
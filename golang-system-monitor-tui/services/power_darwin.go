@@ -0,0 +1,17 @@
+//go:build darwin
+
+package services
+
+import "runtime"
+
+// NewPowerCollector creates a collector sampling the CPU clusters and power
+// draw once per run. powermetrics only reports clusters on Apple Silicon, so
+// nil is returned on Intel Macs.
+func NewPowerCollector() *PowerCollector {
+	if runtime.GOARCH != "arm64" {
+		return nil
+	}
+	return &PowerCollector{
+		command: []string{"powermetrics", "--samplers", "cpu_power", "-i", "1000", "-n", "1"},
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !darwin
+
+package services
+
+// NewPowerCollector returns nil: CPU cluster and power metrics come from
+// powermetrics, which only exists on macOS
+func NewPowerCollector() *PowerCollector {
+	return nil
+}
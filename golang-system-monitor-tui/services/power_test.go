@@ -0,0 +1,99 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+const samplePowermetrics = `Machine model: MacBookPro18,3
+OS version: 23A344
+
+*** Sampled system activity (Mon Oct 16 10:00:00 2023 +0200) (1003.21ms elapsed) ***
+
+**** Processor usage ****
+
+E-Cluster HW active frequency: 1035 MHz
+E-Cluster HW active residency:  20.47% (600 MHz:   0% 972 MHz:  83% 1332 MHz:  10%)
+E-Cluster idle residency:  79.53%
+CPU 0 frequency: 1283 MHz
+CPU 0 active residency:  11.67% (600 MHz:   0% 972 MHz:  68%)
+CPU 1 frequency: 1250 MHz
+CPU 1 active residency:   9.75% (600 MHz:   0% 972 MHz:  72%)
+
+P0-Cluster HW active frequency: 663 MHz
+P0-Cluster HW active residency:   2.96% (600 MHz:  95% 828 MHz:   0%)
+P0-Cluster idle residency:  97.04%
+CPU 2 frequency: 2600 MHz
+CPU 2 active residency:   2.12% (600 MHz:   0%)
+CPU 3 frequency: 2550 MHz
+CPU 3 active residency:   0.98% (600 MHz:   0%)
+CPU 4 frequency: 2500 MHz
+CPU 4 active residency:   0.52% (600 MHz:   0%)
+
+CPU Power: 95 mW
+GPU Power: 6 mW
+ANE Power: 0 mW
+Combined Power (CPU + GPU + ANE): 1101 mW
+`
+
+func TestParsePowermetrics(t *testing.T) {
+	power, err := parsePowermetrics([]byte(samplePowermetrics))
+	if err != nil {
+		t.Fatalf("parsePowermetrics failed: %v", err)
+	}
+	if len(power.Clusters) != 2 {
+		t.Fatalf("Expected an efficiency and a performance cluster, got %+v", power.Clusters)
+	}
+	e, p := power.Clusters[0], power.Clusters[1]
+	if e.Name != "E" || e.Cores != 2 || e.Frequency != 1035 || e.Active != 20.47 {
+		t.Errorf("Unexpected efficiency cluster %+v", e)
+	}
+	if p.Name != "P0" || p.Cores != 3 || p.Frequency != 663 || p.Active != 2.96 {
+		t.Errorf("Unexpected performance cluster %+v", p)
+	}
+	if power.CPUWatts != 0.095 || power.GPUWatts != 0.006 || power.ANEWatts != 0 || power.PackageWatts != 1.101 {
+		t.Errorf("Unexpected power readings %+v", power)
+	}
+
+	// Older releases report the package power on its own line
+	power, err = parsePowermetrics([]byte("E-Cluster Power: 20 mW\nPackage Power: 2.5 W\n"))
+	if err != nil || power.PackageWatts != 2.5 || len(power.Clusters) != 0 {
+		t.Errorf("Expected only the package power, got %+v (%v)", power, err)
+	}
+
+	if _, err := parsePowermetrics([]byte("powermetrics: no samplers\n")); err == nil {
+		t.Error("Expected an error without power samples")
+	}
+}
+
+func TestPowerCollector_Collect(t *testing.T) {
+	collector := &PowerCollector{command: []string{"sh", "-c", "printf 'CPU Power: 500 mW\\n'"}}
+	if collector.Name() != PowerCollectorName {
+		t.Errorf("Unexpected collector name %q", collector.Name())
+	}
+	data, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if power := data.(models.CPUPower); power.Error != "" || power.CPUWatts != 0.5 || power.Timestamp.IsZero() {
+		t.Errorf("Expected a 0.5 W sample, got %+v", power)
+	}
+
+	// Failures, such as running without root, are returned so the collector
+	// is backed off, and are part of the result
+	collector = &PowerCollector{command: []string{"sh", "-c", "echo 'powermetrics must be invoked as the superuser' >&2; exit 1"}}
+	data, err = collector.Collect()
+	if sysErr, ok := err.(models.SystemError); !ok || sysErr.Type != models.PermissionError || sysErr.Component != PowerCollectorName {
+		t.Errorf("Expected a permission error, got %v", err)
+	}
+	if power := data.(models.CPUPower); !strings.Contains(power.Error, "superuser") {
+		t.Errorf("Expected the stderr message as the error, got %+v", power)
+	}
+
+	collector = &PowerCollector{command: []string{"powermetrics-missing-binary"}}
+	if _, err := collector.Collect(); err == nil {
+		t.Error("Expected an error when powermetrics cannot be run")
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/shirou/gopsutil/v3/process"
+
+	"golang-system-monitor-tui/models"
+)
+
+// GopsutilProcessCollector implements ProcessCollector using gopsutil library.
+// Process handles are cached between calls so CPU usage is measured over the
+// interval since the previous collection rather than over the process lifetime.
+type GopsutilProcessCollector struct {
+	mu           sync.Mutex
+	processes    map[int32]*process.Process
+	errorHandler *models.ErrorHandler
+}
+
+// NewGopsutilProcessCollector creates a new instance of GopsutilProcessCollector
+func NewGopsutilProcessCollector() *GopsutilProcessCollector {
+	return &GopsutilProcessCollector{
+		processes:    make(map[int32]*process.Process),
+		errorHandler: models.NewErrorHandler(log.Default()),
+	}
+}
+
+// CollectTopProcesses returns the n processes with the highest CPU usage since the previous call.
+// The first call only establishes a baseline, so every process reports 0%.
+func (g *GopsutilProcessCollector) CollectTopProcesses(n int) ([]models.ProcessInfo, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, models.CreateSystemError(models.SystemAccessError, "Process", "Failed to list processes", err)
+	}
+
+	seen := make(map[int32]*process.Process, len(procs))
+	var infos []models.ProcessInfo
+
+	for _, p := range procs {
+		// Reuse the cached handle so Percent(0) measures since the last sample
+		if cached, exists := g.processes[p.Pid]; exists {
+			p = cached
+		}
+		seen[p.Pid] = p
+
+		percent, err := p.Percent(0)
+		if err != nil {
+			// Processes routinely exit or deny access between listing and sampling
+			continue
+		}
+
+		name, err := p.Name()
+		if err != nil {
+			continue
+		}
+
+		infos = append(infos, models.ProcessInfo{
+			PID:        p.Pid,
+			Name:       name,
+			CPUPercent: percent,
+		})
+	}
+
+	// Drop handles for processes that have exited
+	g.processes = seen
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].CPUPercent == infos[j].CPUPercent {
+			return infos[i].PID < infos[j].PID
+		}
+		return infos[i].CPUPercent > infos[j].CPUPercent
+	})
+
+	if n > 0 && len(infos) > n {
+		infos = infos[:n]
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+)
+
+func TestNewGopsutilProcessCollector(t *testing.T) {
+	collector := NewGopsutilProcessCollector()
+	if collector == nil {
+		t.Fatal("NewGopsutilProcessCollector should return a non-nil collector")
+	}
+
+	if collector.errorHandler == nil {
+		t.Error("Expected error handler to be initialized")
+	}
+
+	if collector.processes == nil {
+		t.Error("Expected process cache to be initialized")
+	}
+}
+
+func TestGopsutilProcessCollector_CollectTopProcesses(t *testing.T) {
+	collector := NewGopsutilProcessCollector()
+
+	// First call establishes the baseline
+	if _, err := collector.CollectTopProcesses(3); err != nil {
+		t.Fatalf("CollectTopProcesses failed: %v", err)
+	}
+
+	if len(collector.processes) == 0 {
+		t.Error("Expected process handles to be cached after first collection")
+	}
+
+	processes, err := collector.CollectTopProcesses(3)
+	if err != nil {
+		t.Fatalf("CollectTopProcesses failed: %v", err)
+	}
+
+	if len(processes) > 3 {
+		t.Errorf("Expected at most 3 processes, got %d", len(processes))
+	}
+
+	for i, proc := range processes {
+		if proc.PID <= 0 {
+			t.Errorf("Process %d has invalid PID %d", i, proc.PID)
+		}
+		if proc.CPUPercent < 0 {
+			t.Errorf("Process %d has negative CPU usage %f", i, proc.CPUPercent)
+		}
+		if i > 0 && proc.CPUPercent > processes[i-1].CPUPercent {
+			t.Errorf("Expected processes sorted by CPU usage descending, got %v", processes)
+		}
+	}
+}
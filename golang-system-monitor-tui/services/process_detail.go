@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/process"
+
+	"golang-system-monitor-tui/models"
+)
+
+// CollectProcessDetail reads the command line, environment, open files,
+// thread count, IO counters and memory mappings of a process. Only a missing
+// process is an error; parts that cannot be read are named in the detail's
+// Errors.
+func (g *GopsutilProcessCollector) CollectProcessDetail(pid int32) (models.ProcessDetail, error) {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return models.ProcessDetail{}, models.CreateSystemError(models.SystemAccessError, "Process", fmt.Sprintf("Process %d not found", pid), err)
+	}
+
+	detail := models.ProcessDetail{PID: pid, Errors: make(map[string]string)}
+	failed := func(part string, err error) bool {
+		if err != nil {
+			detail.Errors[part] = err.Error()
+		}
+		return err != nil
+	}
+
+	if name, err := p.Name(); !failed("name", err) {
+		detail.Name = name
+	}
+	if cmdline, err := p.Cmdline(); !failed("command line", err) {
+		detail.Cmdline = cmdline
+	}
+	if environ, err := p.Environ(); !failed("environment", err) {
+		detail.Environ = environ
+	}
+	if files, err := p.OpenFiles(); !failed("open files", err) {
+		sort.Slice(files, func(i, j int) bool { return files[i].Fd < files[j].Fd })
+		for _, file := range files {
+			detail.OpenFiles = append(detail.OpenFiles, file.Path)
+		}
+	}
+	if threads, err := p.NumThreads(); !failed("threads", err) {
+		detail.Threads = threads
+	}
+	if counters, err := p.IOCounters(); !failed("IO", err) {
+		detail.IO = models.ProcessIO{ReadCount: counters.ReadCount, WriteCount: counters.WriteCount,
+			ReadBytes: counters.ReadBytes, WriteBytes: counters.WriteBytes}
+	}
+	if file, err := os.Open(filepath.Join(procRoot, strconv.Itoa(int(pid)), "smaps")); !failed("memory maps", err) {
+		defer file.Close()
+		if maps, count, err := parseSmaps(file); !failed("memory maps", err) {
+			detail.Maps, detail.MapCount = maps, count
+		}
+	}
+	return detail, nil
+}
+
+// parseSmaps sums the size and resident memory of the mappings in an smaps
+// file by backing path, most resident first, and counts the mappings
+func parseSmaps(r io.Reader) ([]models.ProcessMapping, int, error) {
+	byPath := make(map[string]*models.ProcessMapping)
+	var current *models.ProcessMapping
+	count := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		// A mapping starts with its address range, e.g. 7f12a000-7f12b000
+		if !strings.HasSuffix(fields[0], ":") && strings.Contains(fields[0], "-") {
+			path := ""
+			if len(fields) >= 6 {
+				path = strings.Join(fields[5:], " ")
+			}
+			if byPath[path] == nil {
+				byPath[path] = &models.ProcessMapping{Path: path}
+			}
+			current = byPath[path]
+			count++
+			continue
+		}
+		if current == nil || len(fields) < 2 {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "Size:":
+			current.Size += kb * 1024
+		case "Rss:":
+			current.RSS += kb * 1024
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	maps := make([]models.ProcessMapping, 0, len(byPath))
+	for _, mapping := range byPath {
+		maps = append(maps, *mapping)
+	}
+	sort.Slice(maps, func(i, j int) bool {
+		if maps[i].RSS != maps[j].RSS {
+			return maps[i].RSS > maps[j].RSS
+		}
+		return maps[i].Path < maps[j].Path
+	})
+	return maps, count, nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+const sampleSmaps = `55d0c6a00000-55d0c6a28000 r--p 00000000 08:01 1234 /usr/bin/bash
+Size:                160 kB
+Rss:                 160 kB
+55d0c6a28000-55d0c6b00000 r-xp 00028000 08:01 1234 /usr/bin/bash
+Size:                864 kB
+Rss:                 800 kB
+55d0c7e00000-55d0c8000000 rw-p 00000000 00:00 0 [heap]
+Size:               2048 kB
+Rss:                1500 kB
+7f12a0000000-7f12a0100000 rw-p 00000000 00:00 0
+Size:               1024 kB
+Rss:                  12 kB
+VmFlags: rd wr mr mw me ac sd
+`
+
+func TestParseSmaps(t *testing.T) {
+	maps, count, err := parseSmaps(strings.NewReader(sampleSmaps))
+	if err != nil {
+		t.Fatalf("parseSmaps failed: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("Expected 4 mappings, got %d", count)
+	}
+	want := []models.ProcessMapping{
+		{Path: "[heap]", Size: 2048 * 1024, RSS: 1500 * 1024},
+		{Path: "/usr/bin/bash", Size: 1024 * 1024, RSS: 960 * 1024},
+		{Path: "", Size: 1024 * 1024, RSS: 12 * 1024},
+	}
+	if len(maps) != len(want) {
+		t.Fatalf("Expected %d grouped mappings, got %+v", len(want), maps)
+	}
+	for i := range want {
+		if maps[i] != want[i] {
+			t.Errorf("Mapping %d = %+v, want %+v", i, maps[i], want[i])
+		}
+	}
+}
+
+func TestGopsutilProcessCollector_CollectProcessDetail(t *testing.T) {
+	if _, err := os.Stat("/proc/self/smaps"); err != nil {
+		t.Skip("Process details need /proc")
+	}
+	detail, err := NewGopsutilProcessCollector().CollectProcessDetail(int32(os.Getpid()))
+	if err != nil {
+		t.Fatalf("CollectProcessDetail failed: %v", err)
+	}
+	if detail.Cmdline == "" || detail.Threads == 0 || detail.MapCount == 0 || len(detail.Maps) == 0 {
+		t.Errorf("Expected the command line, threads and mappings of the test process, got %+v", detail)
+	}
+	if _, err := NewGopsutilProcessCollector().CollectProcessDetail(1 << 30); err == nil {
+		t.Error("Expected an error for a missing process")
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/process"
+
+	"golang-system-monitor-tui/models"
+)
+
+// procRoot is the mount point of the Linux proc filesystem
+const procRoot = "/proc"
+
+// CollectProcessMemory returns the RSS, PSS, USS and swap usage of a process.
+// PSS and USS come from /proc/<pid>/smaps_rollup on Linux; elsewhere, or when
+// the file is unreadable, only RSS and swap from gopsutil are reported.
+func (g *GopsutilProcessCollector) CollectProcessMemory(pid int32) (models.ProcessMemory, error) {
+	if file, err := os.Open(filepath.Join(procRoot, strconv.Itoa(int(pid)), "smaps_rollup")); err == nil {
+		defer file.Close()
+		if memory, err := parseSmapsRollup(file); err == nil {
+			memory.PID = pid
+			return memory, nil
+		}
+	}
+
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return models.ProcessMemory{}, models.CreateSystemError(models.SystemAccessError, "Process", fmt.Sprintf("Process %d not found", pid), err)
+	}
+	info, err := p.MemoryInfo()
+	if err != nil {
+		return models.ProcessMemory{}, models.CreateSystemError(models.PermissionError, "Process", fmt.Sprintf("Failed to read memory of process %d", pid), err)
+	}
+	return models.ProcessMemory{PID: pid, RSS: info.RSS, Swap: info.Swap}, nil
+}
+
+// parseSmapsRollup sums the memory counters of an smaps_rollup file, whose
+// values are given in kB
+func parseSmapsRollup(r io.Reader) (models.ProcessMemory, error) {
+	var memory models.ProcessMemory
+	found := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || !strings.HasSuffix(fields[0], ":") {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		bytes := kb * 1024
+
+		switch strings.TrimSuffix(fields[0], ":") {
+		case "Rss":
+			memory.RSS = bytes
+		case "Pss":
+			memory.PSS = bytes
+			found = true
+		case "Private_Clean", "Private_Dirty", "Private_Hugetlb":
+			memory.USS += bytes
+		case "Swap":
+			memory.Swap = bytes
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return models.ProcessMemory{}, err
+	}
+	if !found {
+		return models.ProcessMemory{}, fmt.Errorf("no Pss entry in smaps_rollup")
+	}
+
+	memory.Detailed = true
+	return memory, nil
+}
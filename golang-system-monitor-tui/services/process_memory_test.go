@@ -0,0 +1,61 @@
+package services
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseSmapsRollup(t *testing.T) {
+	rollup := `55d0c8a3e000-7ffd4a5f7000 ---p 00000000 00:00 0                          [rollup]
+Rss:               10240 kB
+Pss:                4096 kB
+Pss_Anon:           2048 kB
+Shared_Clean:       6144 kB
+Shared_Dirty:          0 kB
+Private_Clean:      1024 kB
+Private_Dirty:      2048 kB
+Private_Hugetlb:       0 kB
+Swap:                512 kB
+SwapPss:             256 kB
+`
+	memory, err := parseSmapsRollup(strings.NewReader(rollup))
+	if err != nil {
+		t.Fatalf("parseSmapsRollup failed: %v", err)
+	}
+
+	if memory.RSS != 10240*1024 || memory.PSS != 4096*1024 {
+		t.Errorf("Expected RSS 10 MiB and PSS 4 MiB, got %d and %d", memory.RSS, memory.PSS)
+	}
+	if memory.USS != 3072*1024 {
+		t.Errorf("Expected USS from the private pages, got %d", memory.USS)
+	}
+	if memory.Swap != 512*1024 || !memory.Detailed {
+		t.Errorf("Expected 512 KiB swap with details, got %+v", memory)
+	}
+
+	if _, err := parseSmapsRollup(strings.NewReader("Rss: 12 kB\n")); err == nil {
+		t.Error("Expected error without a Pss entry")
+	}
+}
+
+func TestGopsutilProcessCollector_CollectProcessMemory(t *testing.T) {
+	collector := NewGopsutilProcessCollector()
+
+	memory, err := collector.CollectProcessMemory(int32(os.Getpid()))
+	if err != nil {
+		t.Skipf("Process memory not available: %v", err)
+	}
+
+	if memory.RSS == 0 {
+		t.Error("Expected the test process to have resident memory")
+	}
+	if runtime.GOOS == "linux" && memory.Detailed && (memory.PSS == 0 || memory.USS > memory.RSS) {
+		t.Errorf("Expected a consistent breakdown, got %+v", memory)
+	}
+
+	if _, err := collector.CollectProcessMemory(-1); err == nil {
+		t.Error("Expected error for a nonexistent process")
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// ProcessStatesCollectorName is the registry name of the process state collector
+const ProcessStatesCollectorName = "ProcessStates"
+
+// ProcessStatesCollector counts zombie and uninterruptible processes from
+// the state field of /proc/<pid>/stat
+type ProcessStatesCollector struct {
+	root string
+}
+
+// NewProcessStatesCollector returns a collector of the zombie and
+// uninterruptible process counts, or nil without a Linux proc filesystem
+func NewProcessStatesCollector() *ProcessStatesCollector {
+	if _, err := os.Stat(filepath.Join(procRoot, "self", "stat")); err != nil {
+		return nil
+	}
+	return &ProcessStatesCollector{root: procRoot}
+}
+
+// Name returns the registry name of the process state collector
+func (p *ProcessStatesCollector) Name() string {
+	return ProcessStatesCollectorName
+}
+
+// Collect returns the models.ProcessStates of all processes. Processes that
+// exit while they are read are skipped.
+func (p *ProcessStatesCollector) Collect() (interface{}, error) {
+	entries, err := os.ReadDir(p.root)
+	if err != nil {
+		return nil, models.CreateSystemError(models.SystemAccessError, ProcessStatesCollectorName, "Failed to list "+p.root, err)
+	}
+
+	states := models.ProcessStates{Timestamp: time.Now()}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.TrimLeft(entry.Name(), "0123456789") != "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(p.root, entry.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+		state, err := parseStatState(string(data))
+		if err != nil {
+			continue
+		}
+		states.Total++
+		switch state {
+		case 'Z':
+			states.Zombie++
+		case 'D':
+			states.Uninterruptible++
+		}
+	}
+	return states, nil
+}
+
+// parseStatState returns the state letter of a /proc/<pid>/stat line. The
+// command name before it is in parentheses and may hold spaces and ")".
+func parseStatState(stat string) (byte, error) {
+	end := strings.LastIndexByte(stat, ')')
+	if end < 0 || end+2 >= len(stat) {
+		return 0, fmt.Errorf("no state in %q", stat)
+	}
+	return stat[end+2], nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestParseStatState(t *testing.T) {
+	tests := []struct {
+		stat string
+		want byte
+	}{
+		{"1 (systemd) S 0 1 1 0 -1", 'S'},
+		{"42 (kworker/0:1) D 2 0 0 0 -1", 'D'},
+		// Command names may hold spaces and parentheses
+		{"77 (my (odd) cmd) Z 1 77 77 0 -1", 'Z'},
+	}
+	for _, tt := range tests {
+		if got, err := parseStatState(tt.stat); err != nil || got != tt.want {
+			t.Errorf("parseStatState(%q) = %c, %v, want %c", tt.stat, got, err, tt.want)
+		}
+	}
+	if _, err := parseStatState("42 (truncated"); err == nil {
+		t.Error("Expected an error without a state")
+	}
+}
+
+func TestProcessStatesCollector_Collect(t *testing.T) {
+	root := t.TempDir()
+	stats := map[string]string{
+		"1":    "1 (init) S 0",
+		"10":   "10 (defunct) Z 1",
+		"11":   "11 (defunct) Z 1",
+		"20":   "20 (dd) D 1",
+		"30":   "30 (bash) R 1",
+		"self": "30 (bash) R 1",
+	}
+	for dir, stat := range stats {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, dir, "stat"), []byte(stat), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	collector := &ProcessStatesCollector{root: root}
+	if collector.Name() != ProcessStatesCollectorName {
+		t.Errorf("Expected the name %q, got %q", ProcessStatesCollectorName, collector.Name())
+	}
+	data, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	states := data.(models.ProcessStates)
+	if states.Total != 5 || states.Zombie != 2 || states.Uninterruptible != 1 {
+		t.Errorf("Expected 5 processes, 2 zombies and 1 in D state, got %+v", states)
+	}
+}
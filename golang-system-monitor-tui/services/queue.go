@@ -0,0 +1,395 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// DropPolicy decides which event is discarded when an export queue is full
+type DropPolicy int
+
+const (
+	DropOldest DropPolicy = iota // Discard the oldest queued event to make room
+	DropNewest                   // Discard the incoming event
+)
+
+// ParseDropPolicy parses "drop-oldest" or "drop-newest"
+func ParseDropPolicy(name string) (DropPolicy, error) {
+	switch strings.ToLower(name) {
+	case "drop-oldest", "oldest":
+		return DropOldest, nil
+	case "drop-newest", "newest":
+		return DropNewest, nil
+	default:
+		return DropOldest, fmt.Errorf("unknown drop policy %q (want drop-oldest or drop-newest)", name)
+	}
+}
+
+// QueueConfig configures a bounded export queue
+type QueueConfig struct {
+	Capacity   int           // Maximum events held in memory
+	Policy     DropPolicy    // What to discard when memory and spool are both unavailable
+	SpoolPath  string        // Optional file that absorbs overflow instead of dropping it
+	SpoolLimit int           // Maximum events kept in the spool file
+	RetryDelay time.Duration // Pause before a failed delivery is retried
+}
+
+// DefaultSpoolLimit is the number of events a spool file holds when no limit
+// is configured, a few megabytes of JSON
+const DefaultSpoolLimit = 10000
+
+// DefaultRetryDelay is how long the worker waits before retrying an event the
+// sink failed to take
+const DefaultRetryDelay = 5 * time.Second
+
+// DefaultQueueConfig returns the default export queue configuration
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		Capacity:   100,
+		Policy:     DropOldest,
+		SpoolLimit: DefaultSpoolLimit,
+		RetryDelay: DefaultRetryDelay,
+	}
+}
+
+// QueuedNotifier decouples a push-based sink from the collection loop. Notify never
+// blocks: events go into a bounded queue drained by a single worker, and overflow is
+// spooled to disk or dropped according to the configured policy. Spooled events
+// are always older than the queued ones, so they are delivered first and every
+// event goes out in the order it was notified.
+type QueuedNotifier struct {
+	next     models.Notifier
+	config   QueueConfig
+	mu       sync.Mutex
+	head     []models.AlertEvent // Events read back from the spool, delivered before the rest
+	queue    []models.AlertEvent
+	spooled  int                // Events waiting in the spool file, after head and before queue
+	inflight *models.AlertEvent // Event handed to the sink and not delivered yet
+	dropped  int
+	wake     chan struct{}
+	done     chan struct{}
+	closed   bool
+}
+
+// NewQueuedNotifier wraps next in a bounded queue and starts its delivery worker
+func NewQueuedNotifier(next models.Notifier, config QueueConfig) *QueuedNotifier {
+	if config.Capacity <= 0 {
+		config.Capacity = DefaultQueueConfig().Capacity
+	}
+	if config.SpoolLimit <= 0 {
+		config.SpoolLimit = DefaultSpoolLimit
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultRetryDelay
+	}
+
+	q := &QueuedNotifier{
+		next:   next,
+		config: config,
+		queue:  make([]models.AlertEvent, 0, config.Capacity),
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	// Events spooled by a previous run are delivered once the worker starts
+	if config.SpoolPath != "" {
+		q.spooled = countSpooledEvents(config.SpoolPath)
+		if q.spooled > 0 {
+			q.signal()
+		}
+	}
+
+	go q.run()
+	return q
+}
+
+// Notify queues the event for delivery without blocking
+func (q *QueuedNotifier) Notify(event models.AlertEvent) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return models.CreateSystemError(models.TemporaryError, "Notifier", "Export queue is closed", nil)
+	}
+
+	defer q.signal()
+
+	if len(q.queue) < q.config.Capacity {
+		q.queue = append(q.queue, event)
+		return nil
+	}
+
+	// Queue full: move it to the spool with the new event when configured, the
+	// spool keeps the oldest events and is drained first
+	if q.config.SpoolPath != "" && q.spooled+len(q.queue)+1 <= q.config.SpoolLimit {
+		overflow := append(append(make([]models.AlertEvent, 0, len(q.queue)+1), q.queue...), event)
+		if err := appendSpool(q.config.SpoolPath, overflow); err == nil {
+			q.spooled += len(overflow)
+			q.queue = q.queue[:0]
+			return nil
+		}
+	}
+
+	q.dropped++
+	if q.config.Policy == DropOldest {
+		copy(q.queue, q.queue[1:])
+		q.queue[len(q.queue)-1] = event
+		return models.CreateSystemError(models.TemporaryError, "Notifier", "Export queue full, dropped oldest event", nil)
+	}
+	return models.CreateSystemError(models.TemporaryError, "Notifier", "Export queue full, dropped new event", nil)
+}
+
+// SinkStatus returns the wrapped sink's health with the queue's depth and drop count
+func (q *QueuedNotifier) SinkStatus() models.SinkStatus {
+	var status models.SinkStatus
+	if reporter, ok := q.next.(models.SinkReporter); ok {
+		status = reporter.SinkStatus()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	status.QueueDepth += len(q.head) + len(q.queue) + q.spooled
+	status.Dropped += q.dropped
+	return status
+}
+
+// Close stops the delivery worker. Events not delivered yet, the one the sink
+// is still working on included, are written to the spool when one is
+// configured, so the next run delivers them, and discarded otherwise. An
+// event the sink takes after all is then delivered twice rather than never.
+func (q *QueuedNotifier) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	close(q.done)
+
+	if q.inflight != nil {
+		q.head = append([]models.AlertEvent{*q.inflight}, q.head...)
+		q.inflight = nil
+	}
+	pending := len(q.head) + len(q.queue)
+	switch {
+	case pending == 0:
+	case q.config.SpoolPath == "":
+		log.Printf("Discarding %d undelivered alerts", pending)
+	default:
+		if err := q.spoolPending(); err != nil {
+			log.Printf("Failed to spool undelivered alerts: %v", err)
+		}
+	}
+	q.head, q.queue = nil, nil
+}
+
+// spoolPending rewrites the spool with every undelivered event in order,
+// trimmed to the spool limit by the drop policy. Callers must hold q.mu.
+func (q *QueuedNotifier) spoolPending() error {
+	spooled, err := readSpool(q.config.SpoolPath)
+	if err != nil {
+		return err
+	}
+	events := make([]models.AlertEvent, 0, len(q.head)+len(spooled)+len(q.queue))
+	events = append(events, q.head...)
+	events = append(events, spooled...)
+	events = append(events, q.queue...)
+
+	if excess := len(events) - q.config.SpoolLimit; excess > 0 {
+		q.dropped += excess
+		if q.config.Policy == DropOldest {
+			events = events[excess:]
+		} else {
+			events = events[:q.config.SpoolLimit]
+		}
+	}
+	if err := writeSpool(q.config.SpoolPath, events); err != nil {
+		return err
+	}
+	q.spooled = len(events)
+	return nil
+}
+
+// signal wakes the worker without blocking
+func (q *QueuedNotifier) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run delivers queued events one at a time until the queue is closed
+func (q *QueuedNotifier) run() {
+	for {
+		select {
+		case <-q.done:
+			return
+		case <-q.wake:
+		}
+
+		for {
+			event, ok := q.pop()
+			if !ok {
+				break
+			}
+			err := q.next.Notify(event)
+			if q.settle(err) {
+				log.Printf("Failed to deliver queued alert, retrying in %v: %v", q.config.RetryDelay, err)
+				select {
+				case <-q.done:
+					return
+				case <-time.After(q.config.RetryDelay):
+				}
+				continue
+			}
+
+			select {
+			case <-q.done:
+				return
+			default:
+			}
+		}
+	}
+}
+
+// pop returns the oldest undelivered event and marks it in flight: the
+// spooled ones first, read back a batch at a time, then the queue
+func (q *QueuedNotifier) pop() (models.AlertEvent, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return models.AlertEvent{}, false
+	}
+	if len(q.head) == 0 && q.spooled > 0 {
+		q.refillFromSpool()
+	}
+
+	var event models.AlertEvent
+	switch {
+	case len(q.head) > 0:
+		event, q.head = q.head[0], q.head[1:]
+	case len(q.queue) > 0:
+		event, q.queue = q.queue[0], q.queue[1:]
+	default:
+		return models.AlertEvent{}, false
+	}
+	q.inflight = &event
+	return event, true
+}
+
+// settle ends the delivery of the event in flight with the sink's result. A
+// failed event goes back to the head of the queue, to be retried before
+// any other, unless Close already spooled it. Reports whether to retry.
+func (q *QueuedNotifier) settle(err error) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	event := q.inflight
+	q.inflight = nil
+	if err == nil || event == nil {
+		return false
+	}
+	q.head = append([]models.AlertEvent{*event}, q.head...)
+	return true
+}
+
+// refillFromSpool moves up to capacity events from the spool file into head,
+// rewriting the file with the remainder. Callers must hold q.mu.
+func (q *QueuedNotifier) refillFromSpool() {
+	events, err := readSpool(q.config.SpoolPath)
+	if err != nil {
+		log.Printf("Failed to read export spool: %v", err)
+		q.spooled = 0
+		return
+	}
+
+	n := min(len(events), q.config.Capacity)
+	q.head = append(q.head, events[:n]...)
+
+	rest := events[n:]
+	if err := writeSpool(q.config.SpoolPath, rest); err != nil {
+		log.Printf("Failed to rewrite export spool: %v", err)
+		q.dropped += len(rest)
+		os.Remove(q.config.SpoolPath)
+		rest = nil
+	}
+	q.spooled = len(rest)
+}
+
+// appendSpool appends events to the spool file as JSON lines
+func appendSpool(path string, events []models.AlertEvent) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSpool replaces the spool file with events, removing it when there are
+// none. The file is replaced atomically so a crash never loses the spool.
+func writeSpool(path string, events []models.AlertEvent) error {
+	if len(events) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return writeStateFile(path, buf.Bytes(), 0600)
+}
+
+// readSpool reads all events from the spool file, skipping corrupt lines
+func readSpool(path string) ([]models.AlertEvent, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var events []models.AlertEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event models.AlertEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err == nil {
+			events = append(events, event)
+		}
+	}
+	return events, scanner.Err()
+}
+
+// countSpooledEvents returns the number of events left in a spool file
+func countSpooledEvents(path string) int {
+	events, err := readSpool(path)
+	if err != nil {
+		return 0
+	}
+	return len(events)
+}
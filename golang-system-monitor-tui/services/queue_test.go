@@ -0,0 +1,355 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// blockingNotifier records events and blocks each delivery until released
+type blockingNotifier struct {
+	mu      sync.Mutex
+	events  []models.AlertEvent
+	release chan struct{}
+}
+
+func newBlockingNotifier() *blockingNotifier {
+	return &blockingNotifier{release: make(chan struct{})}
+}
+
+func (b *blockingNotifier) Notify(event models.AlertEvent) error {
+	<-b.release
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *blockingNotifier) delivered() []models.AlertEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]models.AlertEvent(nil), b.events...)
+}
+
+func queueEvent(value float64) models.AlertEvent {
+	return models.AlertEvent{Rule: models.AlertRule{Component: "Memory", Threshold: 95}, Value: value, Fired: true}
+}
+
+// waitFor polls until cond holds or the test times out
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for queued deliveries")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestParseDropPolicy(t *testing.T) {
+	if policy, err := ParseDropPolicy("drop-newest"); err != nil || policy != DropNewest {
+		t.Errorf("Expected DropNewest, got %v (%v)", policy, err)
+	}
+	if policy, err := ParseDropPolicy("drop-oldest"); err != nil || policy != DropOldest {
+		t.Errorf("Expected DropOldest, got %v (%v)", policy, err)
+	}
+	if _, err := ParseDropPolicy("drop-random"); err == nil {
+		t.Error("Expected error for unknown policy")
+	}
+}
+
+func TestQueuedNotifier_DoesNotBlock(t *testing.T) {
+	next := newBlockingNotifier()
+	queue := NewQueuedNotifier(next, QueueConfig{Capacity: 10})
+	defer queue.Close()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			queue.Notify(queueEvent(float64(i)))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Notify blocked on a stalled sink")
+	}
+
+	close(next.release)
+	waitFor(t, func() bool { return len(next.delivered()) == 5 })
+	if events := next.delivered(); events[0].Value != 0 || events[4].Value != 4 {
+		t.Errorf("Expected events delivered in order, got %+v", events)
+	}
+}
+
+func TestQueuedNotifier_DropPolicies(t *testing.T) {
+	tests := []struct {
+		policy DropPolicy
+		want   []float64
+	}{
+		{DropOldest, []float64{0, 3, 4}},
+		{DropNewest, []float64{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		next := newBlockingNotifier()
+		queue := NewQueuedNotifier(next, QueueConfig{Capacity: 2, Policy: tt.policy})
+
+		// The worker picks up the first event and blocks on it, leaving room for two more
+		queue.Notify(queueEvent(0))
+		waitFor(t, func() bool {
+			queue.mu.Lock()
+			defer queue.mu.Unlock()
+			return len(queue.queue) == 0
+		})
+		for i := 1; i <= 4; i++ {
+			queue.Notify(queueEvent(float64(i)))
+		}
+
+		if status := queue.SinkStatus(); status.Dropped != 2 || status.QueueDepth != 2 {
+			t.Errorf("policy %v: expected 2 dropped and depth 2, got %+v", tt.policy, status)
+		}
+
+		close(next.release)
+		waitFor(t, func() bool { return len(next.delivered()) == 3 })
+		for i, event := range next.delivered() {
+			if event.Value != tt.want[i] {
+				t.Errorf("policy %v: expected values %v, got %+v", tt.policy, tt.want, next.delivered())
+				break
+			}
+		}
+		queue.Close()
+	}
+}
+
+func TestQueuedNotifier_Spool(t *testing.T) {
+	spool := filepath.Join(t.TempDir(), "alerts.spool")
+	next := newBlockingNotifier()
+	queue := NewQueuedNotifier(next, QueueConfig{Capacity: 1, SpoolPath: spool})
+
+	queue.Notify(queueEvent(0))
+	waitFor(t, func() bool {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		return len(queue.queue) == 0
+	})
+	for i := 1; i <= 4; i++ {
+		if err := queue.Notify(queueEvent(float64(i))); err != nil {
+			t.Fatalf("Expected overflow to be spooled, got %v", err)
+		}
+	}
+
+	status := queue.SinkStatus()
+	if status.Dropped != 0 || status.QueueDepth != 4 {
+		t.Errorf("Expected 1 queued and 3 spooled events, got %+v", status)
+	}
+
+	close(next.release)
+	waitFor(t, func() bool { return len(next.delivered()) == 5 })
+	for i, event := range next.delivered() {
+		if event.Value != float64(i) {
+			t.Errorf("Expected spooled events delivered in order, got %+v", next.delivered())
+			break
+		}
+	}
+	queue.Close()
+}
+
+func TestQueuedNotifier_ReplaysSpoolOnStart(t *testing.T) {
+	spool := filepath.Join(t.TempDir(), "alerts.spool")
+	if err := appendSpool(spool, []models.AlertEvent{queueEvent(1), queueEvent(2)}); err != nil {
+		t.Fatalf("appendSpool failed: %v", err)
+	}
+
+	next := newBlockingNotifier()
+	close(next.release)
+	queue := NewQueuedNotifier(next, QueueConfig{Capacity: 10, SpoolPath: spool})
+	defer queue.Close()
+
+	waitFor(t, func() bool { return len(next.delivered()) == 2 })
+	if events, _ := readSpool(spool); len(events) != 0 {
+		t.Errorf("Expected spool to be drained, got %d events", len(events))
+	}
+}
+
+func TestQueuedNotifier_SpoolDeliveredBeforeQueue(t *testing.T) {
+	spool := filepath.Join(t.TempDir(), "alerts.spool")
+	if err := appendSpool(spool, []models.AlertEvent{queueEvent(1), queueEvent(2)}); err != nil {
+		t.Fatalf("appendSpool failed: %v", err)
+	}
+
+	// The worker reads one spooled event back and blocks on it
+	next := newBlockingNotifier()
+	queue := NewQueuedNotifier(next, QueueConfig{Capacity: 1, SpoolPath: spool})
+	defer queue.Close()
+	waitFor(t, func() bool {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		return len(queue.head) == 0 && queue.spooled == 1
+	})
+
+	// A new event waits for the rest of the spool
+	queue.Notify(queueEvent(3))
+	close(next.release)
+	waitFor(t, func() bool { return len(next.delivered()) == 3 })
+	for i, event := range next.delivered() {
+		if event.Value != float64(i+1) {
+			t.Errorf("Expected the spool drained before the queue, got %+v", next.delivered())
+			break
+		}
+	}
+}
+
+func TestQueuedNotifier_CloseSpoolsPending(t *testing.T) {
+	spool := filepath.Join(t.TempDir(), "alerts.spool")
+	next := newBlockingNotifier()
+	defer close(next.release)
+	queue := NewQueuedNotifier(next, QueueConfig{Capacity: 10, SpoolPath: spool})
+
+	queue.Notify(queueEvent(0))
+	waitFor(t, func() bool {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		return len(queue.queue) == 0
+	})
+	for i := 1; i <= 3; i++ {
+		queue.Notify(queueEvent(float64(i)))
+	}
+	queue.Close()
+
+	// The event being delivered is spooled ahead of the rest, the sink may
+	// never finish it
+	events, err := readSpool(spool)
+	if err != nil || len(events) != 4 || events[0].Value != 0 || events[3].Value != 3 {
+		t.Fatalf("Expected the 4 undelivered events spooled in order, got %+v (%v)", events, err)
+	}
+	if err := queue.Notify(queueEvent(4)); err == nil {
+		t.Error("Expected a closed queue to refuse events")
+	}
+
+	// The next run delivers them
+	restarted := newBlockingNotifier()
+	close(restarted.release)
+	replay := NewQueuedNotifier(restarted, QueueConfig{Capacity: 10, SpoolPath: spool})
+	defer replay.Close()
+	waitFor(t, func() bool { return len(restarted.delivered()) == 4 })
+}
+
+// flakyNotifier fails the first failures deliveries and records the rest
+type flakyNotifier struct {
+	mu       sync.Mutex
+	failures int
+	events   []models.AlertEvent
+}
+
+func (f *flakyNotifier) Notify(event models.AlertEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return models.CreateSystemError(models.TemporaryError, "Notifier", "endpoint unreachable", nil)
+	}
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *flakyNotifier) delivered() []models.AlertEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]models.AlertEvent(nil), f.events...)
+}
+
+func TestQueuedNotifier_RetriesFailedDelivery(t *testing.T) {
+	next := &flakyNotifier{failures: 2}
+	queue := NewQueuedNotifier(next, QueueConfig{Capacity: 10, RetryDelay: time.Millisecond})
+	defer queue.Close()
+
+	queue.Notify(queueEvent(1))
+	queue.Notify(queueEvent(2))
+
+	// The failed event is retried before the one queued behind it
+	waitFor(t, func() bool { return len(next.delivered()) == 2 })
+	if events := next.delivered(); events[0].Value != 1 || events[1].Value != 2 {
+		t.Errorf("Expected the failed event delivered first, got %+v", events)
+	}
+}
+
+func TestQueuedNotifier_CloseSpoolsFailedDelivery(t *testing.T) {
+	spool := filepath.Join(t.TempDir(), "alerts.spool")
+	next := &flakyNotifier{failures: 1}
+	queue := NewQueuedNotifier(next, QueueConfig{Capacity: 10, SpoolPath: spool, RetryDelay: time.Hour})
+
+	queue.Notify(queueEvent(1))
+	waitFor(t, func() bool {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		return len(queue.head) == 1
+	})
+	queue.Close()
+
+	if events, err := readSpool(spool); err != nil || len(events) != 1 || events[0].Value != 1 {
+		t.Errorf("Expected the failed event spooled for the next run, got %+v (%v)", events, err)
+	}
+}
+
+func TestQueuedNotifier_SpoolLimit(t *testing.T) {
+	spool := filepath.Join(t.TempDir(), "alerts.spool")
+	next := newBlockingNotifier()
+	defer close(next.release)
+	queue := NewQueuedNotifier(next, QueueConfig{Capacity: 1, Policy: DropNewest, SpoolPath: spool, SpoolLimit: 2})
+	defer queue.Close()
+
+	queue.Notify(queueEvent(0))
+	waitFor(t, func() bool {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		return len(queue.queue) == 0
+	})
+	for i := 1; i <= 3; i++ {
+		if err := queue.Notify(queueEvent(float64(i))); err != nil {
+			t.Fatalf("Expected event %d to be kept, got %v", i, err)
+		}
+	}
+	if err := queue.Notify(queueEvent(4)); err == nil {
+		t.Error("Expected an event dropped once the spool is full")
+	}
+
+	if events, _ := readSpool(spool); len(events) != 2 {
+		t.Errorf("Expected the spool capped at 2 events, got %d", len(events))
+	}
+	if status := queue.SinkStatus(); status.Dropped != 1 || status.QueueDepth != 3 {
+		t.Errorf("Expected 1 dropped and 3 waiting, got %+v", status)
+	}
+}
+
+func TestWriteSpool_Private(t *testing.T) {
+	spool := filepath.Join(t.TempDir(), "alerts.spool")
+	if err := writeSpool(spool, []models.AlertEvent{queueEvent(1)}); err != nil {
+		t.Fatalf("writeSpool failed: %v", err)
+	}
+	// Alerts may carry process names and hosts, like the appended spool
+	info, err := os.Stat(spool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the rewritten spool to keep mode 0600, got %v", info.Mode().Perm())
+	}
+}
+
+func TestQueuedNotifier_SinkStatus(t *testing.T) {
+	webhook := NewWebhookNotifier("http://127.0.0.1:0")
+	queue := NewQueuedNotifier(webhook, DefaultQueueConfig())
+	defer queue.Close()
+
+	if status := queue.SinkStatus(); status.Name != "webhook" {
+		t.Errorf("Expected wrapped sink name, got %+v", status)
+	}
+}
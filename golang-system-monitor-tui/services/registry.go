@@ -0,0 +1,155 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// Names of the collectors registered by RegisterSystemCollectors, matching
+// the component names of their errors
+const (
+	CPUCollectorName     = "CPU"
+	MemoryCollectorName  = "Memory"
+	DiskCollectorName    = "Disk"
+	NetworkCollectorName = "Network"
+	SensorsCollectorName = "Sensors"
+)
+
+// funcCollector adapts a function to the Collector interface
+type funcCollector struct {
+	name    string
+	collect func() (interface{}, error)
+}
+
+// NewCollectorFunc returns a collector that calls collect
+func NewCollectorFunc(name string, collect func() (interface{}, error)) models.Collector {
+	return funcCollector{name: name, collect: collect}
+}
+
+// Name returns the collector name
+func (f funcCollector) Name() string {
+	return f.name
+}
+
+// Collect calls the collector function
+func (f funcCollector) Collect() (interface{}, error) {
+	return f.collect()
+}
+
+// samplingCollector is a funcCollector waiting for a sampling window
+type samplingCollector struct {
+	funcCollector
+	window time.Duration
+}
+
+// SampleWindow returns how long a collection waits for its samples
+func (s samplingCollector) SampleWindow() time.Duration {
+	return s.window
+}
+
+// CollectorRegistry holds the collectors run on every tick, in registration
+// order. It is safe for concurrent use.
+type CollectorRegistry struct {
+	mu         sync.RWMutex
+	collectors []models.Collector
+}
+
+// NewCollectorRegistry creates an empty collector registry
+func NewCollectorRegistry() *CollectorRegistry {
+	return &CollectorRegistry{}
+}
+
+// Register adds a collector; names must be unique
+func (r *CollectorRegistry) Register(collector models.Collector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.indexOf(collector.Name()) >= 0 {
+		return fmt.Errorf("collector %q is already registered", collector.Name())
+	}
+	r.collectors = append(r.collectors, collector)
+	return nil
+}
+
+// Replace swaps the collector with the same name in place, or adds it
+func (r *CollectorRegistry) Replace(collector models.Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i := r.indexOf(collector.Name()); i >= 0 {
+		r.collectors[i] = collector
+		return
+	}
+	r.collectors = append(r.collectors, collector)
+}
+
+// Unregister removes the named collector and reports whether it was registered
+func (r *CollectorRegistry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.indexOf(name)
+	if i < 0 {
+		return false
+	}
+	r.collectors = append(r.collectors[:i:i], r.collectors[i+1:]...)
+	return true
+}
+
+// Lookup returns the named collector
+func (r *CollectorRegistry) Lookup(name string) (models.Collector, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if i := r.indexOf(name); i >= 0 {
+		return r.collectors[i], true
+	}
+	return nil, false
+}
+
+// Collectors returns a copy of the registered collectors in registration order
+func (r *CollectorRegistry) Collectors() []models.Collector {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]models.Collector(nil), r.collectors...)
+}
+
+// indexOf returns the position of the named collector, or -1; callers hold the lock
+func (r *CollectorRegistry) indexOf(name string) int {
+	for i, collector := range r.collectors {
+		if collector.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// RegisterSystemCollectors registers the CPU, memory, disk and network
+// collectors of system, and its temperature sensors when it supports them,
+// replacing earlier registrations of the same names
+func RegisterSystemCollectors(registry *CollectorRegistry, system models.SystemCollector) {
+	cpu := funcCollector{name: CPUCollectorName, collect: func() (interface{}, error) {
+		return system.CollectCPU()
+	}}
+	if sampler, ok := system.(models.CPUSampler); ok {
+		registry.Replace(samplingCollector{funcCollector: cpu, window: sampler.CPUSampleWindow()})
+	} else {
+		registry.Replace(cpu)
+	}
+	registry.Replace(NewCollectorFunc(MemoryCollectorName, func() (interface{}, error) {
+		return system.CollectMemory()
+	}))
+	registry.Replace(NewCollectorFunc(DiskCollectorName, func() (interface{}, error) {
+		return system.CollectDisk()
+	}))
+	registry.Replace(NewCollectorFunc(NetworkCollectorName, func() (interface{}, error) {
+		return system.CollectNetwork()
+	}))
+
+	if sensors, ok := system.(models.SensorCollector); ok {
+		registry.Replace(NewCollectorFunc(SensorsCollectorName, func() (interface{}, error) {
+			return sensors.CollectSensors()
+		}))
+	} else {
+		registry.Unregister(SensorsCollectorName)
+	}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// registryNames returns the names of the registered collectors in order
+func registryNames(registry *CollectorRegistry) []string {
+	var names []string
+	for _, collector := range registry.Collectors() {
+		names = append(names, collector.Name())
+	}
+	return names
+}
+
+func TestCollectorRegistry(t *testing.T) {
+	registry := NewCollectorRegistry()
+	gpu := NewCollectorFunc("GPU", func() (interface{}, error) { return 42, nil })
+
+	if err := registry.Register(gpu); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := registry.Register(NewCollectorFunc("GPU", nil)); err == nil {
+		t.Error("Expected an error for a duplicate name")
+	}
+	if err := registry.Register(NewCollectorFunc("Fans", func() (interface{}, error) { return nil, errors.New("no fans") })); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	collector, ok := registry.Lookup("GPU")
+	if !ok {
+		t.Fatal("Expected to find the GPU collector")
+	}
+	if data, err := collector.Collect(); err != nil || data != 42 {
+		t.Errorf("Expected 42 from the GPU collector, got %v (%v)", data, err)
+	}
+
+	// Replacing keeps the position
+	registry.Replace(NewCollectorFunc("GPU", func() (interface{}, error) { return 7, nil }))
+	if names := registryNames(registry); !reflect.DeepEqual(names, []string{"GPU", "Fans"}) {
+		t.Errorf("Expected GPU then Fans, got %v", names)
+	}
+	if collector, _ := registry.Lookup("GPU"); collector != nil {
+		if data, _ := collector.Collect(); data != 7 {
+			t.Errorf("Expected the replaced GPU collector, got %v", data)
+		}
+	}
+
+	if !registry.Unregister("GPU") || registry.Unregister("GPU") {
+		t.Error("Expected Unregister to remove the collector once")
+	}
+	if names := registryNames(registry); !reflect.DeepEqual(names, []string{"Fans"}) {
+		t.Errorf("Expected only Fans, got %v", names)
+	}
+}
+
+// systemOnlyCollector is a system collector without sensor support
+type systemOnlyCollector struct {
+	models.SystemCollector
+}
+
+func TestRegisterSystemCollectors(t *testing.T) {
+	registry := NewCollectorRegistry()
+	if err := registry.Register(NewCollectorFunc("GPU", func() (interface{}, error) { return nil, nil })); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	RegisterSystemCollectors(registry, NewGopsutilCollector())
+	want := []string{"GPU", CPUCollectorName, MemoryCollectorName, DiskCollectorName, NetworkCollectorName, SensorsCollectorName}
+	if names := registryNames(registry); !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected %v, got %v", want, names)
+	}
+
+	collector, _ := registry.Lookup(MemoryCollectorName)
+	data, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("Memory collector failed: %v", err)
+	}
+	if memory, ok := data.(models.MemoryInfo); !ok || memory.Total == 0 {
+		t.Errorf("Expected MemoryInfo from the memory collector, got %T", data)
+	}
+
+	// Swapping in a collector without sensors drops the sensor collector
+	RegisterSystemCollectors(registry, systemOnlyCollector{NewGopsutilCollector()})
+	if _, ok := registry.Lookup(SensorsCollectorName); ok {
+		t.Error("Expected the sensor collector to be unregistered")
+	}
+	if len(registry.Collectors()) != 5 {
+		t.Errorf("Expected the system collectors to be replaced in place, got %v", registryNames(registry))
+	}
+}
+
+// samplingSystem is a system collector whose CPU collection waits for samples
+type samplingSystem struct {
+	models.SystemCollector
+}
+
+// CPUSampleWindow returns a two second window
+func (samplingSystem) CPUSampleWindow() time.Duration {
+	return 2 * time.Second
+}
+
+func TestRegisterSystemCollectors_SampleWindow(t *testing.T) {
+	registry := NewCollectorRegistry()
+	RegisterSystemCollectors(registry, samplingSystem{NewGopsutilCollector()})
+	collector, _ := registry.Lookup(CPUCollectorName)
+	sampling, ok := collector.(models.SamplingCollector)
+	if !ok || sampling.SampleWindow() != 2*time.Second {
+		t.Fatalf("Expected the CPU collector to report its sampling window, got %T", collector)
+	}
+	if memory, _ := registry.Lookup(MemoryCollectorName); memory == nil {
+		t.Fatal("Expected a memory collector")
+	} else if _, ok := memory.(models.SamplingCollector); ok {
+		t.Error("Expected the memory collector not to sample")
+	}
+	if window := NewGopsutilCollector().CPUSampleWindow(); window != 2*cpuSampleInterval {
+		t.Errorf("Expected both CPU samples in the window, got %v", window)
+	}
+}
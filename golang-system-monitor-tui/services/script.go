@@ -0,0 +1,367 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+
+	"golang-system-monitor-tui/models"
+)
+
+// ScriptExtension is the file extension of panel scripts
+const ScriptExtension = ".star"
+
+// maxScriptSteps bounds the work of one panel run, so a runaway loop is
+// reported in the panel instead of pinning a core
+const maxScriptSteps = 1_000_000
+
+// Script is a Starlark panel script that derives metrics from the latest
+// snapshot and from local files, rendered like plugin output. For example:
+//
+//	# App latency, rewritten by the app every second
+//	title = "App latency"
+//
+//	def panel(s):
+//	    p99 = float(last("/var/run/app/latency").split()[1])
+//	    return [
+//	        metric("p99", p99, unit = "ms"),
+//	        gauge("CPU headroom", 100 - s.cpu.total, 100, unit = "%"),
+//	        text("version", read("/etc/app/version")),
+//	    ]
+//
+// The file is run once when loaded; panel is then called on every tick with
+// the snapshot of scriptSnapshot and returns a list of metric, gauge and
+// text values. The optional title global names the panel.
+type Script struct {
+	Name  string
+	title string
+	panel starlark.Callable
+}
+
+// Constructors of the values built by metric and gauge, and by text
+const (
+	scriptMetricKind = starlark.String("metric")
+	scriptTextKind   = starlark.String("text")
+)
+
+// scriptBuiltins are the functions scripts can call besides the Starlark
+// built-ins such as float, min and max
+var scriptBuiltins = starlark.StringDict{
+	"read":   starlark.NewBuiltin("read", scriptRead),
+	"last":   starlark.NewBuiltin("last", scriptLast),
+	"env":    starlark.NewBuiltin("env", scriptEnv),
+	"round":  starlark.NewBuiltin("round", scriptRound),
+	"metric": starlark.NewBuiltin("metric", scriptMetric),
+	"gauge":  starlark.NewBuiltin("gauge", scriptMetric),
+	"text":   starlark.NewBuiltin("text", scriptText),
+}
+
+// ParseScript compiles the source of the named script and runs its top
+// level, which defines panel and title
+func ParseScript(name, source string) (*Script, error) {
+	filename := name + ScriptExtension
+	thread := newScriptThread(name)
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, filename, source, scriptBuiltins)
+	if err != nil {
+		return nil, scriptError(filename, err)
+	}
+
+	script := &Script{Name: name}
+	panel, ok := globals["panel"].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%s: no panel(snapshot) function", filename)
+	}
+	script.panel = panel
+	if title, ok := globals["title"]; ok {
+		text, ok := starlark.AsString(title)
+		if !ok {
+			return nil, fmt.Errorf("%s: title must be a string, got %s", filename, title.Type())
+		}
+		script.title = text
+	}
+	return script, nil
+}
+
+// LoadScripts loads the panel scripts in dir, in file name order. A missing
+// directory has no scripts.
+func LoadScripts(dir string) ([]*Script, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+ScriptExtension))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	scripts := make([]*Script, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read script: %w", err)
+		}
+		script, err := ParseScript(strings.TrimSuffix(filepath.Base(path), ScriptExtension), string(data))
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, script)
+	}
+	return scripts, nil
+}
+
+// Run calls the script's panel function with a snapshot. Like plugin
+// output, a failure is reported in the Error field rather than returned.
+// Loaded scripts are frozen, so runs may overlap.
+func (s *Script) Run(snapshot models.Snapshot) models.PluginOutput {
+	output := models.PluginOutput{Name: s.Name, Title: s.title, Timestamp: time.Now()}
+	fail := func(err error) models.PluginOutput {
+		return models.PluginOutput{Name: s.Name, Title: s.title, Error: scriptError(s.Name+ScriptExtension, err).Error(), Timestamp: output.Timestamp}
+	}
+
+	result, err := starlark.Call(newScriptThread(s.Name), s.panel, starlark.Tuple{scriptSnapshot(snapshot)}, nil)
+	if err != nil {
+		return fail(err)
+	}
+	items, ok := result.(starlark.Iterable)
+	if !ok {
+		return fail(fmt.Errorf("panel must return a list of metrics, got %s", result.Type()))
+	}
+	iter := items.Iterate()
+	defer iter.Done()
+	var item starlark.Value
+	for iter.Next(&item) {
+		metric, err := scriptPluginMetric(item)
+		if err != nil {
+			return fail(err)
+		}
+		output.Metrics = append(output.Metrics, metric)
+	}
+	return output
+}
+
+// newScriptThread returns a thread with the step limit, sending print to the log
+func newScriptThread(name string) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: name,
+		Print: func(_ *starlark.Thread, msg string) {
+			log.Printf("Script %s: %s", name, msg)
+		},
+	}
+	thread.SetMaxExecutionSteps(maxScriptSteps)
+	return thread
+}
+
+// scriptError prefixes a runtime error with the innermost script line
+func scriptError(filename string, err error) error {
+	evalErr, ok := err.(*starlark.EvalError)
+	if !ok {
+		return err
+	}
+	for i := len(evalErr.CallStack) - 1; i >= 0; i-- {
+		if pos := evalErr.CallStack[i].Pos; pos.Filename() == filename {
+			return fmt.Errorf("%s:%d: %s", filename, pos.Line, evalErr.Msg)
+		}
+	}
+	return fmt.Errorf("%s: %s", filename, evalErr.Msg)
+}
+
+// scriptSnapshot exposes the snapshot to scripts as s.cpu.total,
+// s.memory.percent, s.network.rx_rate and so on
+func scriptSnapshot(snapshot models.Snapshot) *starlarkstruct.Struct {
+	memory := snapshot.Memory
+
+	// The fullest filesystem stands for the disks
+	diskPercent := 0.0
+	for _, disk := range snapshot.Disks {
+		diskPercent = math.Max(diskPercent, disk.UsedPercent)
+	}
+
+	var recv, sent float64
+	for _, rates := range snapshot.Rates {
+		recv += rates.RecvRate
+		sent += rates.SendRate
+	}
+
+	group := func(fields starlark.StringDict) *starlarkstruct.Struct {
+		return starlarkstruct.FromStringDict(starlarkstruct.Default, fields)
+	}
+	return group(starlark.StringDict{
+		"cpu": group(starlark.StringDict{
+			"total": starlark.Float(snapshot.CPU.Total),
+			"cores": starlark.MakeInt(snapshot.CPU.Cores),
+		}),
+		"memory": group(starlark.StringDict{
+			"total":     starlark.MakeUint64(memory.Total),
+			"used":      starlark.MakeUint64(memory.Used),
+			"available": starlark.MakeUint64(memory.Available),
+			"percent":   starlark.Float(percentOf(memory.Used, memory.Total)),
+		}),
+		"swap": group(starlark.StringDict{
+			"total":   starlark.MakeUint64(memory.Swap.Total),
+			"used":    starlark.MakeUint64(memory.Swap.Used),
+			"percent": starlark.Float(percentOf(memory.Swap.Used, memory.Swap.Total)),
+		}),
+		"disk": group(starlark.StringDict{
+			"percent": starlark.Float(diskPercent),
+		}),
+		"network": group(starlark.StringDict{
+			"rx_rate": starlark.Float(recv),
+			"tx_rate": starlark.Float(sent),
+		}),
+	})
+}
+
+// percentOf returns used as a percentage of total, 0 for an empty total
+func percentOf(used, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(used) / float64(total) * 100
+}
+
+// scriptPluginMetric converts a value built by metric, gauge or text
+func scriptPluginMetric(value starlark.Value) (models.PluginMetric, error) {
+	item, ok := value.(*starlarkstruct.Struct)
+	if !ok || (item.Constructor() != scriptMetricKind && item.Constructor() != scriptTextKind) {
+		return models.PluginMetric{}, fmt.Errorf("panel must return metric, gauge or text values, got %s", value.Type())
+	}
+	var metric models.PluginMetric
+	for _, field := range []struct {
+		name string
+		dest interface{}
+	}{{"label", &metric.Label}, {"text", &metric.Text}, {"unit", &metric.Unit}, {"value", &metric.Value}, {"max", &metric.Max}} {
+		attr, err := item.Attr(field.name)
+		if err != nil || attr == nil {
+			continue
+		}
+		switch dest := field.dest.(type) {
+		case *string:
+			*dest, _ = starlark.AsString(attr)
+		case *float64:
+			*dest, _ = starlark.AsFloat(attr)
+		}
+	}
+	return metric, nil
+}
+
+// scriptMetric implements metric(label, value, unit="") and
+// gauge(label, value, max, unit="")
+func scriptMetric(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var label, unit string
+	var value, max starlark.Value
+	var err error
+	if fn.Name() == "gauge" {
+		err = starlark.UnpackArgs(fn.Name(), args, kwargs, "label", &label, "value", &value, "max", &max, "unit?", &unit)
+	} else {
+		err = starlark.UnpackArgs(fn.Name(), args, kwargs, "label", &label, "value", &value, "unit?", &unit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := starlark.StringDict{"label": starlark.String(label), "unit": starlark.String(unit)}
+	number, ok := starlark.AsFloat(value)
+	if !ok {
+		return nil, fmt.Errorf("%s: value must be a number, got %s", fn.Name(), value.Type())
+	}
+	fields["value"] = starlark.Float(number)
+	if max != nil {
+		full, ok := starlark.AsFloat(max)
+		if !ok || full <= 0 {
+			return nil, fmt.Errorf("%s: max must be a positive number, got %s", fn.Name(), max)
+		}
+		fields["max"] = starlark.Float(full)
+	}
+	return starlarkstruct.FromStringDict(scriptMetricKind, fields), nil
+}
+
+// scriptText implements text(label, text); text is converted with str
+func scriptText(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var label string
+	var value starlark.Value
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "label", &label, "text", &value); err != nil {
+		return nil, err
+	}
+	text, ok := starlark.AsString(value)
+	if !ok {
+		text = value.String()
+	}
+	return starlarkstruct.FromStringDict(scriptTextKind, starlark.StringDict{
+		"label": starlark.String(label),
+		"text":  starlark.String(text),
+	}), nil
+}
+
+// scriptRead implements read(path): the trimmed contents of a file
+func scriptRead(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var path string
+	if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 1, &path); err != nil {
+		return nil, err
+	}
+	contents, err := readScriptFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn.Name(), err)
+	}
+	return starlark.String(contents), nil
+}
+
+// scriptLast implements last(path): the last non-empty line of a file, such as a log
+func scriptLast(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var path string
+	if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 1, &path); err != nil {
+		return nil, err
+	}
+	contents, err := readScriptFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn.Name(), err)
+	}
+	return starlark.String(contents[strings.LastIndex(contents, "\n")+1:]), nil
+}
+
+// scriptEnv implements env(name): an environment variable, "" when unset
+func scriptEnv(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name string
+	if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 1, &name); err != nil {
+		return nil, err
+	}
+	return starlark.String(os.Getenv(name)), nil
+}
+
+// scriptRound implements round(x, digits=0), which Starlark lacks
+func scriptRound(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x starlark.Value
+	digits := 0
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "x", &x, "digits?", &digits); err != nil {
+		return nil, err
+	}
+	n, ok := starlark.AsFloat(x)
+	if !ok {
+		return nil, fmt.Errorf("%s: x must be a number, got %s", fn.Name(), x.Type())
+	}
+	scale := math.Pow(10, float64(digits))
+	return starlark.Float(math.Round(n*scale) / scale), nil
+}
+
+// readScriptFile reads a file for a script, capped like plugin output
+func readScriptFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxPluginOutput))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
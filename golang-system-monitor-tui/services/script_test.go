@@ -0,0 +1,141 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestScript_Run(t *testing.T) {
+	dir := t.TempDir()
+	latency := filepath.Join(dir, "latency")
+	if err := os.WriteFile(latency, []byte("p50 12\np99 187.5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	source := `# App latency
+title = "App " + "latency"
+
+def panel(s):
+    p99 = float(last("` + latency + `").split()[1])
+    return [
+        metric("p99", p99, unit = "ms"),
+        metric("p99 (s)", round(p99 / 1000, 2)),
+        gauge("CPU headroom", 100 - s.cpu.total, 100, "%"),
+        gauge("memory", s.memory.percent, 100),
+        text("status", "cores: %d" % s.cpu.cores),  # trailing comment
+        metric("fastest", min(p99, 50, -(-40))),
+    ]
+`
+	script, err := ParseScript("latency", source)
+	if err != nil {
+		t.Fatalf("ParseScript failed: %v", err)
+	}
+	snapshot := models.Snapshot{
+		CPU:    models.CPUInfo{Cores: 4, Total: 25},
+		Memory: models.MemoryInfo{Total: 200, Used: 50},
+	}
+	output := script.Run(snapshot)
+	if output.Error != "" {
+		t.Fatalf("Unexpected error: %s", output.Error)
+	}
+	if output.Name != "latency" || output.Title != "App latency" || output.Timestamp.IsZero() {
+		t.Errorf("Unexpected output header %+v", output)
+	}
+	want := []models.PluginMetric{
+		{Label: "p99", Value: 187.5, Unit: "ms"},
+		{Label: "p99 (s)", Value: 0.19},
+		{Label: "CPU headroom", Value: 75, Max: 100, Unit: "%"},
+		{Label: "memory", Value: 25, Max: 100},
+		{Label: "status", Text: "cores: 4"},
+		{Label: "fastest", Value: 40},
+	}
+	if len(output.Metrics) != len(want) {
+		t.Fatalf("Expected %d metrics, got %+v", len(want), output.Metrics)
+	}
+	for i, metric := range output.Metrics {
+		if metric != want[i] {
+			t.Errorf("Metric %d: expected %+v, got %+v", i, want[i], metric)
+		}
+	}
+}
+
+func TestScript_RunErrors(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`[metric("x", read("/nonexistent/file"))]`, "test.star:2: read: open /nonexistent/file"},
+		{`[metric("x", 1 / 0)]`, "test.star:2: floating-point division by zero"},
+		{`[metric("x", float("fast"))]`, `invalid float literal`},
+		{`[metric("x", "1")]`, "metric: value must be a number, got string"},
+		{`[gauge("x", 1, 0)]`, "gauge: max must be a positive number"},
+		{`[metric("x")]`, "metric: missing argument for value"},
+		{`"x"`, "panel must return a list of metrics, got string"},
+		{`[1]`, "panel must return metric, gauge or text values, got int"},
+		{`[metric("x", i) for i in range(10000000)]`, "too many steps"},
+	}
+	for _, test := range tests {
+		script, err := ParseScript("test", "def panel(s):\n    return "+test.body+"\n")
+		if err != nil {
+			t.Fatalf("ParseScript(%q) failed: %v", test.body, err)
+		}
+		output := script.Run(models.Snapshot{})
+		if !strings.Contains(output.Error, test.want) || len(output.Metrics) != 0 {
+			t.Errorf("Run(%q): expected error %q, got %+v", test.body, test.want, output)
+		}
+	}
+}
+
+func TestParseScript_Errors(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"\ndef panel(s)\n    return []", "test.star:3:1: got newline, want ':'"},
+		{"def panel(s):\n    return nope", `test.star:2:12: undefined: nope`},
+		{"title = 1\ndef panel(s):\n    return []", "test.star: title must be a string, got int"},
+		{"title = \"x\"", "test.star: no panel(snapshot) function"},
+		{"x = read(\"/nonexistent/file\")\ndef panel(s):\n    return []", "test.star:1: read: open"},
+	}
+	for _, test := range tests {
+		if _, err := ParseScript("test", test.source); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("ParseScript(%q): expected error %q, got %v", test.source, test.want, err)
+		}
+	}
+}
+
+func TestLoadScripts(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"b.star":    "def panel(s):\n    return [metric(\"b\", 2)]",
+		"a.star":    "def panel(s):\n    return [metric(\"a\", 1)]",
+		"notes.txt": "not a script",
+	}
+	for name, source := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scripts, err := LoadScripts(dir)
+	if err != nil {
+		t.Fatalf("LoadScripts failed: %v", err)
+	}
+	if len(scripts) != 2 || scripts[0].Name != "a" || scripts[1].Name != "b" {
+		t.Errorf("Expected scripts a and b in order, got %+v", scripts)
+	}
+
+	if scripts, err := LoadScripts(filepath.Join(dir, "missing")); err != nil || len(scripts) != 0 {
+		t.Errorf("Expected no scripts in a missing directory, got %v (%v)", scripts, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "c.star"), []byte("def panel(s)"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadScripts(dir); err == nil || !strings.Contains(err.Error(), "c.star:1:") {
+		t.Errorf("Expected the syntax error with its location, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+
+	"golang-system-monitor-tui/models"
+)
+
+// SelfCollectorName is the registry name of the collector of the monitor's own usage
+const SelfCollectorName = "Self"
+
+// SelfCollector samples the CPU, memory, goroutines and GC statistics of the
+// monitor process. The process handle is kept between calls so CPU usage is
+// measured over the interval since the previous collection. Failures to read
+// the process statistics are reported in the Error field of the result.
+type SelfCollector struct {
+	mu      sync.Mutex
+	process *process.Process
+}
+
+// NewSelfCollector creates a collector of the current process
+func NewSelfCollector() *SelfCollector {
+	return &SelfCollector{}
+}
+
+// Name returns the registry name of the self collector
+func (s *SelfCollector) Name() string {
+	return SelfCollectorName
+}
+
+// Collect returns the models.SelfStats of the current process. The first
+// call only establishes the CPU baseline and reports 0%.
+func (s *SelfCollector) Collect() (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	stats := models.SelfStats{
+		Goroutines: runtime.NumGoroutine(),
+		HeapAlloc:  memStats.HeapAlloc,
+		HeapSys:    memStats.HeapSys,
+		NumGC:      memStats.NumGC,
+		TotalPause: time.Duration(memStats.PauseTotalNs),
+		Timestamp:  time.Now(),
+	}
+	if memStats.NumGC > 0 {
+		stats.LastPause = time.Duration(memStats.PauseNs[(memStats.NumGC+255)%256])
+	}
+
+	if s.process == nil {
+		proc, err := process.NewProcess(int32(os.Getpid()))
+		if err != nil {
+			stats.Error = "Failed to open the monitor process: " + err.Error()
+			return stats, nil
+		}
+		s.process = proc
+	}
+	percent, err := s.process.Percent(0)
+	if err != nil {
+		stats.Error = "Failed to read the CPU usage: " + err.Error()
+		return stats, nil
+	}
+	stats.CPUPercent = percent
+	memory, err := s.process.MemoryInfo()
+	if err != nil {
+		stats.Error = "Failed to read the memory usage: " + err.Error()
+		return stats, nil
+	}
+	stats.RSS = memory.RSS
+	return stats, nil
+}
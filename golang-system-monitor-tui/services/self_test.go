@@ -0,0 +1,40 @@
+package services
+
+import (
+	"runtime"
+	"testing"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestSelfCollector_Collect(t *testing.T) {
+	collector := NewSelfCollector()
+	if collector.Name() != SelfCollectorName {
+		t.Errorf("Expected name %q, got %q", SelfCollectorName, collector.Name())
+	}
+
+	runtime.GC()
+	data, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	stats, ok := data.(models.SelfStats)
+	if !ok {
+		t.Fatalf("Expected models.SelfStats, got %T", data)
+	}
+	if stats.Error != "" {
+		t.Skipf("Process statistics unavailable: %s", stats.Error)
+	}
+	if stats.Goroutines < 1 || stats.HeapAlloc == 0 || stats.HeapSys < stats.HeapAlloc {
+		t.Errorf("Unexpected runtime statistics: %+v", stats)
+	}
+	if stats.NumGC == 0 || stats.TotalPause < stats.LastPause {
+		t.Errorf("Expected the forced GC cycle counted, got %+v", stats)
+	}
+	if stats.RSS == 0 {
+		t.Error("Expected a resident set size")
+	}
+	if stats.CPUPercent != 0 {
+		t.Errorf("Expected the first sample to report 0%%, got %v", stats.CPUPercent)
+	}
+}
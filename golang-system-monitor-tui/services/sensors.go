@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+
+	"golang-system-monitor-tui/models"
+)
+
+// nvidiaTimeout bounds an nvidia-smi run, which hangs with a stuck driver
+const nvidiaTimeout = 2 * time.Second
+
+// CollectSensors gathers temperature readings from CPU, GPU, NVMe and chassis sensors
+func (g *GopsutilCollector) CollectSensors() ([]models.SensorInfo, error) {
+	temps, err := host.SensorsTemperatures()
+
+	var sensors []models.SensorInfo
+	for _, temp := range temps {
+		// Skip disconnected or uninitialized sensors
+		if temp.Temperature <= 0 {
+			continue
+		}
+		sensors = append(sensors, models.SensorInfo{
+			Key:         temp.SensorKey,
+			Kind:        models.ClassifySensor(temp.SensorKey),
+			Temperature: temp.Temperature,
+			High:        temp.High,
+			Critical:    temp.Critical,
+		})
+	}
+
+	// Proprietary GPU drivers don't expose hwmon sensors, ask the vendor tool instead
+	sensors = append(sensors, collectNvidiaSensors()...)
+
+	// gopsutil reports unreadable sensors as warnings alongside partial results
+	if len(sensors) > 0 {
+		return sensors, nil
+	}
+
+	if err != nil {
+		if g.isPermissionError(err) {
+			return nil, models.CreateSystemError(models.PermissionError, "Sensors", "Permission denied accessing temperature sensors", err)
+		}
+		return nil, models.CreateSystemError(models.SystemAccessError, "Sensors", "Failed to collect temperature sensors", err)
+	}
+
+	return nil, models.CreateSystemError(models.SystemAccessError, "Sensors", "No temperature sensors found", nil)
+}
+
+// collectNvidiaSensors queries nvidia-smi for GPU temperatures when it is installed
+func collectNvidiaSensors() []models.SensorInfo {
+	path, err := exec.LookPath("nvidia-smi")
+	if err != nil {
+		return nil
+	}
+
+	return queryNvidiaSensors(path, nvidiaTimeout)
+}
+
+// queryNvidiaSensors runs nvidia-smi at path, killing it after timeout so a
+// hung driver can't hold up the sensors collector
+func queryNvidiaSensors(path string, timeout time.Duration) []models.SensorInfo {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path, "--query-gpu=index,name,temperature.gpu", "--format=csv,noheader,nounits")
+	cmd.WaitDelay = pluginWaitDelay
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	return parseNvidiaSensors(string(output))
+}
+
+// parseNvidiaSensors parses nvidia-smi CSV output of index, name and temperature
+func parseNvidiaSensors(output string) []models.SensorInfo {
+	var sensors []models.SensorInfo
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 3 {
+			continue
+		}
+		temperature, err := strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
+		if err != nil {
+			continue
+		}
+		sensors = append(sensors, models.SensorInfo{
+			Key:         "nvidia_gpu" + strings.TrimSpace(fields[0]) + " " + strings.TrimSpace(fields[1]),
+			Kind:        models.SensorGPU,
+			Temperature: temperature,
+		})
+	}
+	return sensors
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestGopsutilCollector_CollectSensors(t *testing.T) {
+	collector := NewGopsutilCollector()
+
+	sensors, err := collector.CollectSensors()
+	if err != nil {
+		// Many virtual machines and containers expose no sensors at all
+		if sysErr, ok := err.(models.SystemError); ok && sysErr.Component != "Sensors" {
+			t.Errorf("Expected Sensors component on error, got %s", sysErr.Component)
+		}
+		t.Skipf("No temperature sensors available: %v", err)
+	}
+
+	for _, sensor := range sensors {
+		if sensor.Temperature <= 0 {
+			t.Errorf("Sensor %s reported non-positive temperature %f", sensor.Key, sensor.Temperature)
+		}
+	}
+}
+
+func TestParseNvidiaSensors(t *testing.T) {
+	output := "0, NVIDIA GeForce RTX 3080, 64\n1, NVIDIA A100, 41\nbogus line\n2, Broken, N/A\n"
+
+	sensors := parseNvidiaSensors(output)
+	if len(sensors) != 2 {
+		t.Fatalf("Expected 2 GPU sensors, got %d", len(sensors))
+	}
+
+	if sensors[0].Kind != models.SensorGPU {
+		t.Errorf("Expected GPU kind, got %v", sensors[0].Kind)
+	}
+	if sensors[0].Temperature != 64 {
+		t.Errorf("Expected 64°C, got %f", sensors[0].Temperature)
+	}
+	if sensors[1].Key != "nvidia_gpu1 NVIDIA A100" {
+		t.Errorf("Unexpected sensor key %q", sensors[1].Key)
+	}
+}
+
+func TestQueryNvidiaSensors_Timeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The fake nvidia-smi needs a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "nvidia-smi")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexec sleep 10\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if sensors := queryNvidiaSensors(path, 50*time.Millisecond); sensors != nil {
+		t.Errorf("Expected no sensors from a hung nvidia-smi, got %v", sensors)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the hung nvidia-smi killed after the timeout, took %v", elapsed)
+	}
+}
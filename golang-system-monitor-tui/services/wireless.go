@@ -0,0 +1,307 @@
+package services
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// WirelessCollectorName is the registry name of the wireless link collector
+const WirelessCollectorName = "Wireless"
+
+const (
+	wirelessTimeout  = 3 * time.Second // Bounds a single iw, airport or netsh run
+	wirelessInterval = 5 * time.Second // The commands run this often at most
+)
+
+// airportPath is the airport tool of macOS, removed in macOS 14.4
+const airportPath = "/System/Library/PrivateFrameworks/Apple80211.framework/Versions/Current/Resources/airport"
+
+// airportInterface is the built-in Wi-Fi interface of a Mac; airport does not
+// name the interface it reports
+const airportInterface = "en0"
+
+// WirelessBackend reads the wireless links of a platform with its own tool
+type WirelessBackend interface {
+	// Available reports whether the tool of the backend is installed
+	Available() bool
+	// Links returns the link of every wireless interface, connected or not
+	Links() ([]models.WirelessLink, error)
+}
+
+// commandFunc runs a command and returns its standard output
+type commandFunc func(name string, args ...string) ([]byte, error)
+
+// runWirelessCommand runs a wireless tool, killing it after wirelessTimeout
+func runWirelessCommand(name string, args ...string) ([]byte, error) {
+	return runCommand(wirelessTimeout, name, args...)
+}
+
+// wirelessBackendFor returns the backend of a platform, or nil where there is
+// none
+func wirelessBackendFor(goos string) WirelessBackend {
+	switch goos {
+	case "linux":
+		return iwBackend{run: runWirelessCommand}
+	case "darwin":
+		return airportBackend{run: runWirelessCommand}
+	case "windows":
+		return netshBackend{run: runWirelessCommand}
+	}
+	return nil
+}
+
+// WirelessCollector reports the SSID, signal strength and link rate of the
+// wireless interfaces. Results are reused for wirelessInterval between runs.
+type WirelessCollector struct {
+	backend WirelessBackend
+	now     func() time.Time
+
+	mu     sync.Mutex
+	last   []models.WirelessLink
+	lastAt time.Time
+}
+
+// NewWirelessCollector returns a collector of the wireless links, or nil when
+// the tool of the platform (iw, airport or netsh) is not installed
+func NewWirelessCollector() *WirelessCollector {
+	backend := wirelessBackendFor(runtime.GOOS)
+	if backend == nil || !backend.Available() {
+		return nil
+	}
+	return NewWirelessCollectorWithBackend(backend)
+}
+
+// NewWirelessCollectorWithBackend returns a collector reading the wireless
+// links from backend
+func NewWirelessCollectorWithBackend(backend WirelessBackend) *WirelessCollector {
+	return &WirelessCollector{backend: backend, now: time.Now}
+}
+
+// Name returns the registry name of the wireless link collector
+func (w *WirelessCollector) Name() string {
+	return WirelessCollectorName
+}
+
+// Collect returns the []models.WirelessLink of the wireless interfaces, the
+// last result while it is younger than wirelessInterval
+func (w *WirelessCollector) Collect() (interface{}, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.last != nil && w.now().Sub(w.lastAt) < wirelessInterval {
+		return w.last, nil
+	}
+
+	links, err := w.backend.Links()
+	if err != nil {
+		return nil, models.CreateSystemError(models.SystemAccessError, WirelessCollectorName, "Failed to read wireless links", err)
+	}
+	now := w.now()
+	for i := range links {
+		links[i].Timestamp = now
+	}
+	if links == nil {
+		links = []models.WirelessLink{}
+	}
+	w.last, w.lastAt = links, now
+	return links, nil
+}
+
+// iwBackend reads the wireless links of Linux with iw
+type iwBackend struct {
+	run commandFunc
+}
+
+// Available reports whether iw is installed
+func (b iwBackend) Available() bool {
+	_, err := exec.LookPath("iw")
+	return err == nil
+}
+
+// Links lists the wireless interfaces with `iw dev` and reads the link of
+// each with `iw dev <interface> link`
+func (b iwBackend) Links() ([]models.WirelessLink, error) {
+	output, err := b.run("iw", "dev")
+	if err != nil {
+		return nil, err
+	}
+	var links []models.WirelessLink
+	for _, name := range parseIwDev(string(output)) {
+		link := models.WirelessLink{Interface: name}
+		if output, err := b.run("iw", "dev", name, "link"); err == nil {
+			link = parseIwLink(name, string(output))
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// parseIwDev returns the interface names of `iw dev`, lines such as
+// "Interface wlan0"
+func parseIwDev(output string) []string {
+	var names []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "Interface" {
+			names = append(names, fields[1])
+		}
+	}
+	return names
+}
+
+// parseIwLink parses `iw dev <interface> link`:
+//
+//	Connected to aa:bb:cc:dd:ee:ff (on wlan0)
+//		SSID: HomeNet
+//		signal: -52 dBm
+//		tx bitrate: 866.7 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 2
+//
+// or "Not connected." when the interface is not associated
+func parseIwLink(name, output string) models.WirelessLink {
+	link := models.WirelessLink{Interface: name}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "Connected to") {
+			link.Connected = true
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "SSID":
+			link.SSID = value
+		case "signal":
+			if dbm, err := strconv.Atoi(strings.TrimSuffix(value, " dBm")); err == nil {
+				link.SignalDBm, link.SignalPercent = dbm, models.SignalPercentFromDBm(dbm)
+			}
+		case "tx bitrate":
+			if fields := strings.Fields(value); len(fields) > 0 {
+				link.LinkRate, _ = strconv.ParseFloat(fields[0], 64)
+			}
+		}
+	}
+	return link
+}
+
+// airportBackend reads the Wi-Fi link of macOS with airport
+type airportBackend struct {
+	run commandFunc
+}
+
+// Available reports whether airport exists, which it does before macOS 14.4
+func (b airportBackend) Available() bool {
+	_, err := os.Stat(airportPath)
+	return err == nil
+}
+
+// Links reads the link of the built-in Wi-Fi interface with `airport -I`
+func (b airportBackend) Links() ([]models.WirelessLink, error) {
+	output, err := b.run(airportPath, "-I")
+	if err != nil {
+		return nil, err
+	}
+	return []models.WirelessLink{parseAirport(string(output))}, nil
+}
+
+// parseAirport parses `airport -I`, "key: value" lines such as
+// "agrCtlRSSI: -55", "lastTxRate: 867" and "SSID: HomeNet". A Mac with
+// Wi-Fi switched off reports "AirPort: Off" instead.
+func parseAirport(output string) models.WirelessLink {
+	link := models.WirelessLink{Interface: airportInterface}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "SSID":
+			link.SSID = value
+		case "state":
+			link.Connected = value == "running"
+		case "agrCtlRSSI":
+			if dbm, err := strconv.Atoi(value); err == nil {
+				link.SignalDBm, link.SignalPercent = dbm, models.SignalPercentFromDBm(dbm)
+			}
+		case "lastTxRate":
+			link.LinkRate, _ = strconv.ParseFloat(value, 64)
+		}
+	}
+	return link
+}
+
+// netshBackend reads the wireless links of Windows with netsh
+type netshBackend struct {
+	run commandFunc
+}
+
+// Available reports whether netsh is installed
+func (b netshBackend) Available() bool {
+	_, err := exec.LookPath("netsh")
+	return err == nil
+}
+
+// Links reads the links of all wireless interfaces with
+// `netsh wlan show interfaces`
+func (b netshBackend) Links() ([]models.WirelessLink, error) {
+	output, err := b.run("netsh", "wlan", "show", "interfaces")
+	if err != nil {
+		return nil, err
+	}
+	return parseNetsh(string(output)), nil
+}
+
+// parseNetsh parses `netsh wlan show interfaces`, a block of "Key : value"
+// lines per interface starting with its Name:
+//
+//	Name                   : Wi-Fi
+//	State                  : connected
+//	SSID                   : HomeNet
+//	Transmit rate (Mbps)   : 866.7
+//	Signal                 : 92%
+//
+// Windows only reports the signal quality, the strength is derived from it.
+func parseNetsh(output string) []models.WirelessLink {
+	var links []models.WirelessLink
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if key == "Name" {
+			links = append(links, models.WirelessLink{Interface: value})
+			continue
+		}
+		if len(links) == 0 {
+			continue
+		}
+		link := &links[len(links)-1]
+		switch key {
+		case "State":
+			link.Connected = value == "connected"
+		case "SSID":
+			link.SSID = value
+		case "Signal":
+			if percent, err := strconv.Atoi(strings.TrimSuffix(value, "%")); err == nil {
+				link.SignalPercent, link.SignalDBm = percent, models.SignalDBmFromPercent(percent)
+			}
+		case "Transmit rate (Mbps)":
+			link.LinkRate, _ = strconv.ParseFloat(value, 64)
+		}
+	}
+	return links
+}
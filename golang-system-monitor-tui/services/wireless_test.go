@@ -0,0 +1,158 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+const iwLinkConnected = `Connected to aa:bb:cc:dd:ee:ff (on wlan0)
+	SSID: Home Net
+	freq: 5180
+	RX: 123456 bytes (789 packets)
+	TX: 65432 bytes (321 packets)
+	signal: -52 dBm
+	rx bitrate: 780.0 MBit/s VHT-MCS 8 80MHz short GI VHT-NSS 2
+	tx bitrate: 866.7 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 2
+`
+
+func TestParseIwDev(t *testing.T) {
+	output := `phy#0
+	Interface wlan0
+		ifindex 3
+		type managed
+	Interface wlan1
+		type monitor
+`
+	if got, want := parseIwDev(output), []string{"wlan0", "wlan1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseIwDev() = %v, want %v", got, want)
+	}
+}
+
+func TestParseIwLink(t *testing.T) {
+	want := models.WirelessLink{Interface: "wlan0", Connected: true, SSID: "Home Net", SignalDBm: -52, SignalPercent: 96, LinkRate: 866.7}
+	if got := parseIwLink("wlan0", iwLinkConnected); got != want {
+		t.Errorf("parseIwLink() = %+v, want %+v", got, want)
+	}
+	if got := parseIwLink("wlan1", "Not connected.\n"); got != (models.WirelessLink{Interface: "wlan1"}) {
+		t.Errorf("Expected a disconnected link, got %+v", got)
+	}
+}
+
+func TestParseAirport(t *testing.T) {
+	output := `     agrCtlRSSI: -67
+     agrExtRSSI: 0
+    agrCtlNoise: -92
+          state: running
+        op mode: station
+     lastTxRate: 585
+        maxRate: 867
+          BSSID: aa:bb:cc:dd:ee:ff
+           SSID: Office
+        channel: 36,80
+`
+	want := models.WirelessLink{Interface: "en0", Connected: true, SSID: "Office", SignalDBm: -67, SignalPercent: 66, LinkRate: 585}
+	if got := parseAirport(output); got != want {
+		t.Errorf("parseAirport() = %+v, want %+v", got, want)
+	}
+	if got := parseAirport("AirPort: Off\n"); got.Connected {
+		t.Errorf("Expected Wi-Fi switched off to be disconnected, got %+v", got)
+	}
+}
+
+func TestParseNetsh(t *testing.T) {
+	output := "\r\nThere are 2 interfaces on the system: \r\n\r\n" +
+		"    Name                   : Wi-Fi\r\n" +
+		"    Description            : Intel(R) Wi-Fi 6 AX201 160MHz\r\n" +
+		"    State                  : connected\r\n" +
+		"    SSID                   : HomeNet\r\n" +
+		"    BSSID                  : aa:bb:cc:dd:ee:ff\r\n" +
+		"    Receive rate (Mbps)    : 1201\r\n" +
+		"    Transmit rate (Mbps)   : 866.7\r\n" +
+		"    Signal                 : 30%\r\n\r\n" +
+		"    Name                   : Wi-Fi 2\r\n" +
+		"    State                  : disconnected\r\n"
+	want := []models.WirelessLink{
+		{Interface: "Wi-Fi", Connected: true, SSID: "HomeNet", SignalDBm: -85, SignalPercent: 30, LinkRate: 866.7},
+		{Interface: "Wi-Fi 2"},
+	}
+	if got := parseNetsh(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseNetsh() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWirelessBackendFor(t *testing.T) {
+	for goos, want := range map[string]WirelessBackend{
+		"linux":   iwBackend{},
+		"darwin":  airportBackend{},
+		"windows": netshBackend{},
+	} {
+		if got := wirelessBackendFor(goos); reflect.TypeOf(got) != reflect.TypeOf(want) {
+			t.Errorf("wirelessBackendFor(%q) = %T, want %T", goos, got, want)
+		}
+	}
+	if got := wirelessBackendFor("plan9"); got != nil {
+		t.Errorf("Expected no backend on plan9, got %T", got)
+	}
+}
+
+func TestWirelessCollector_Collect(t *testing.T) {
+	runs := 0
+	backend := iwBackend{run: func(name string, args ...string) ([]byte, error) {
+		runs++
+		switch command := name + " " + strings.Join(args, " "); command {
+		case "iw dev":
+			return []byte("phy#0\n\tInterface wlan0\n\tInterface wlan1\n"), nil
+		case "iw dev wlan0 link":
+			return []byte(iwLinkConnected), nil
+		case "iw dev wlan1 link":
+			return nil, errors.New("command failed: No such device (-19)")
+		default:
+			t.Fatalf("Unexpected command %q", command)
+			return nil, nil
+		}
+	}}
+	now := time.Date(2024, 10, 13, 12, 0, 0, 0, time.UTC)
+	collector := NewWirelessCollectorWithBackend(backend)
+	collector.now = func() time.Time { return now }
+	if collector.Name() != WirelessCollectorName {
+		t.Errorf("Expected the name %q, got %q", WirelessCollectorName, collector.Name())
+	}
+
+	data, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	want := []models.WirelessLink{
+		{Interface: "wlan0", Connected: true, SSID: "Home Net", SignalDBm: -52, SignalPercent: 96, LinkRate: 866.7, Timestamp: now},
+		{Interface: "wlan1", Timestamp: now},
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Collect() = %+v, want %+v", data, want)
+	}
+
+	// iw runs again only after wirelessInterval
+	ran := runs
+	collector.Collect()
+	if runs != ran {
+		t.Errorf("Expected the cached links within %v, got %d more runs", wirelessInterval, runs-ran)
+	}
+	now = now.Add(wirelessInterval)
+	collector.Collect()
+	if runs == ran {
+		t.Errorf("Expected iw to run again after %v", wirelessInterval)
+	}
+}
+
+func TestWirelessCollector_CollectFailure(t *testing.T) {
+	collector := NewWirelessCollectorWithBackend(netshBackend{run: func(name string, args ...string) ([]byte, error) {
+		return nil, errors.New("netsh: The Wireless AutoConfig Service (wlansvc) is not running.")
+	}})
+	if _, err := collector.Collect(); err == nil {
+		t.Error("Expected an error when netsh fails")
+	}
+}
@@ -0,0 +1,50 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/ui"
+)
+
+// watchControlSignals forwards SIGUSR1 and SIGUSR2 to the program as
+// controlMessage messages, so a running instance can be inspected without
+// keyboard access (`kill -USR1 <pid>`), until the returned function is called
+func watchControlSignals(program *tea.Program) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-signals:
+				if msg := controlMessage(sig); msg != nil {
+					program.Send(msg)
+				}
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// controlMessage returns the message of a control signal: SIGUSR1 logs a
+// snapshot of the metrics, SIGUSR2 toggles the debug overlay
+func controlMessage(sig os.Signal) tea.Msg {
+	switch sig {
+	case syscall.SIGUSR1:
+		return ui.LogSnapshotMsg{}
+	case syscall.SIGUSR2:
+		return ui.ToggleDebugMsg{}
+	}
+	return nil
+}
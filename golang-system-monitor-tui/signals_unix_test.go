@@ -0,0 +1,22 @@
+//go:build !windows
+
+package main
+
+import (
+	"syscall"
+	"testing"
+
+	"golang-system-monitor-tui/ui"
+)
+
+func TestControlMessage(t *testing.T) {
+	if _, ok := controlMessage(syscall.SIGUSR1).(ui.LogSnapshotMsg); !ok {
+		t.Error("Expected SIGUSR1 to log a snapshot")
+	}
+	if _, ok := controlMessage(syscall.SIGUSR2).(ui.ToggleDebugMsg); !ok {
+		t.Error("Expected SIGUSR2 to toggle the debug overlay")
+	}
+	if msg := controlMessage(syscall.SIGHUP); msg != nil {
+		t.Errorf("Expected no message for SIGHUP, got %T", msg)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// watchControlSignals does nothing on Windows, which has no SIGUSR1 and
+// SIGUSR2
+func watchControlSignals(program *tea.Program) func() {
+	return func() {}
+}
@@ -0,0 +1,262 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"golang-system-monitor-tui/models"
+)
+
+// SetAccessible switches the screen reader mode on or off. It replaces the
+// panels, bars, graphs and borders of the grid with a short sentence or two
+// per panel, and shows the help screen without a box.
+func (m MainModel) SetAccessible(enabled bool) MainModel {
+	m.accessible = enabled
+	return m
+}
+
+// IsAccessible reports whether the screen reader mode is on
+func (m MainModel) IsAccessible() bool {
+	return m.accessible
+}
+
+// renderAccessible renders the header, a summary of every panel of the
+// active tab and the key hints as plain lines, wrapped to the terminal width
+func (m MainModel) renderAccessible() string {
+	lines := []string{m.headerTitle()}
+	if notice := m.renderNotice(m.now()); notice != "" {
+		lines = append(lines, notice)
+	}
+	for _, panel := range m.panels {
+		summary := m.panelSummary(panel)
+		if panel == m.focused && len(summary) > 0 {
+			summary[0] += ", focused"
+		}
+		lines = append(lines, summary...)
+	}
+
+	// Lines wrap instead of being cut, so every hint is kept
+	var hints []string
+	contextual, global := m.footerHints()
+	for _, hint := range append(contextual, global...) {
+		if len(hint.Keys) > 0 {
+			hints = append(hints, hint.String())
+		}
+	}
+	switch {
+	case m.commanding:
+		lines = append(lines, "Command: "+m.prompt.Value())
+	case m.filtering:
+		filter, _ := m.panelFilter(m.focused)
+		lines = append(lines, "Filter "+m.focused.PanelName()+": "+filter)
+	default:
+		lines = append(lines, "Keys: "+strings.Join(hints, ", "))
+	}
+
+	wrapped := ansi.Wrap(strings.Join(lines, "\n"), m.width, "")
+	// Lines past the bottom are dropped, keeping the key hints
+	if rows := strings.Split(wrapped, "\n"); len(rows) > m.height && m.height > 1 {
+		wrapped = strings.Join(append(rows[:m.height-1], rows[len(rows)-1]), "\n")
+	}
+	return wrapped
+}
+
+// panelSummary describes the values of a grid panel in words, e.g. "CPU total
+// 43 percent, core 3 highest at 91 percent"
+func (m MainModel) panelSummary(panel FocusedComponent) []string {
+	switch panel {
+	case FocusCPU:
+		return []string{m.cpuSummary()}
+	case FocusMemory:
+		return []string{m.memorySummary()}
+	case FocusDisk:
+		return m.diskSummary()
+	case FocusNetwork:
+		return []string{m.networkSummary()}
+	case FocusSensors:
+		return []string{m.sensorsSummary()}
+	case FocusAlerts:
+		return []string{m.alertsSummary()}
+	case FocusLog:
+		return []string{m.logSummary()}
+	case FocusSelf:
+		return []string{m.selfSummary()}
+	case FocusKernel:
+		return []string{m.kernelSummary()}
+	default:
+		return nil
+	}
+}
+
+// percentText spells out a percentage, e.g. "43 percent"
+func (m MainModel) percentText(value float64) string {
+	return m.styleManager.Locale().FormatFloat(value, 0) + " percent"
+}
+
+func (m MainModel) cpuSummary() string {
+	if message := m.cpu.GetErrorMessage(); message != "" {
+		return "CPU unavailable: " + message
+	}
+	usage := m.cpu.GetUsage()
+	if len(usage) == 0 {
+		return "CPU collecting"
+	}
+
+	busiest := 0
+	for i, value := range usage {
+		if value > usage[busiest] {
+			busiest = i
+		}
+	}
+	summary := fmt.Sprintf("CPU total %s, core %d highest at %s", m.percentText(m.cpu.GetTotal()), busiest+1, m.percentText(usage[busiest]))
+	if top := m.cpu.GetTopProcesses(); len(top) > 0 {
+		summary += fmt.Sprintf(", busiest process %s at %s", top[0].Name, m.percentText(top[0].CPUPercent))
+	}
+	return summary
+}
+
+func (m MainModel) memorySummary() string {
+	if message := m.memory.GetErrorMessage(); message != "" {
+		return "Memory unavailable: " + message
+	}
+	if m.memory.GetTotal() == 0 {
+		return "Memory collecting"
+	}
+
+	summary := fmt.Sprintf("Memory %s used, %s of %s, %s available",
+		m.percentText(m.memory.GetUsagePercent()),
+		m.styleManager.FormatBytes(m.memory.GetUsed()),
+		m.styleManager.FormatBytes(m.memory.GetTotal()),
+		m.styleManager.FormatBytes(m.memory.GetAvailable()))
+	if m.memory.GetSwap().Total > 0 {
+		summary += ", swap " + m.percentText(m.memory.GetSwapUsagePercent()) + " used"
+	}
+	if huge := m.memory.GetHugePages(); huge.Total > 0 {
+		summary += ", huge pages " + m.percentText(huge.UsedPercent()) + " used"
+	}
+	return summary
+}
+
+func (m MainModel) diskSummary() []string {
+	if message := m.disk.GetErrorMessage(); message != "" {
+		return []string{"Disk unavailable: " + message}
+	}
+	filesystems := m.disk.GetVisibleFilesystems()
+	if len(filesystems) == 0 {
+		return []string{"Disk: no filesystems"}
+	}
+
+	lines := make([]string, 0, len(m.disk.GetPools())+len(filesystems))
+	for _, pool := range m.disk.GetPools() {
+		lines = append(lines, fmt.Sprintf("Pool %s %s %s", pool.Name, pool.Kind, strings.ToLower(pool.State)))
+	}
+	for _, fs := range filesystems {
+		lines = append(lines, fmt.Sprintf("Disk %s %s used, %s free of %s", fs.Mountpoint, m.percentText(fs.UsedPercent),
+			m.styleManager.FormatBytes(fs.Available), m.styleManager.FormatBytes(fs.Total)))
+	}
+	return lines
+}
+
+func (m MainModel) networkSummary() string {
+	if message := m.network.GetErrorMessage(); message != "" {
+		return "Network unavailable: " + message
+	}
+	if len(m.network.GetInterfaces()) == 0 {
+		return "Network: no interfaces"
+	}
+
+	summary := fmt.Sprintf("Network receiving %s, sending %s",
+		m.network.formatRate(m.network.GetTotalRecvRate()), m.network.formatRate(m.network.GetTotalSendRate()))
+	rates := m.network.GetRates()
+	names := make([]string, 0, len(rates))
+	for name := range rates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	busiest, busiestRate := "", 0.0
+	for _, name := range names {
+		if total := rates[name].RecvRate + rates[name].SendRate; total > busiestRate {
+			busiest, busiestRate = name, total
+		}
+	}
+	if busiest != "" {
+		summary += ", busiest interface " + busiest
+	}
+	for _, iface := range m.network.GetInterfaces() {
+		if link, ok := m.network.GetWireless(iface.Interface); ok && link.Connected {
+			summary += fmt.Sprintf(", Wi-Fi %s signal %s", link.SSID, m.percentText(float64(link.SignalPercent)))
+		}
+	}
+	return summary
+}
+
+func (m MainModel) sensorsSummary() string {
+	hottest, ok := m.sensors.GetHottest()
+	if !ok {
+		return "Temperatures: no sensors"
+	}
+
+	unit := m.sensors.GetUnit()
+	summary := fmt.Sprintf("Temperatures: hottest %s at %s %s", hottest.Kind.String(),
+		m.styleManager.Locale().FormatFloat(unit.FromCelsius(hottest.Temperature), 0), unitName(unit))
+	if m.sensors.IsOverThreshold(hottest) {
+		summary += ", over its warning threshold"
+	}
+	return summary
+}
+
+func (m MainModel) alertsSummary() string {
+	active := 0
+	if m.alerts != nil {
+		active = m.alerts.ActiveCount()
+	}
+	summary := fmt.Sprintf("Alerts: %d active", active)
+	if events := m.alertsPanel.GetEvents(); len(events) > 0 {
+		summary += ", latest " + events[0].LocalizedDescription(m.styleManager.Locale())
+	}
+	return summary
+}
+
+func (m MainModel) logSummary() string {
+	lines := m.logView.GetLines()
+	if len(lines) == 0 {
+		return "Log " + m.logView.GetPath() + ": no lines yet"
+	}
+	return "Log " + m.logView.GetPath() + ", last line: " + lines[len(lines)-1]
+}
+
+func (m MainModel) selfSummary() string {
+	stats := m.self.GetStats()
+	if stats.Error != "" {
+		return "Monitor process unavailable: " + stats.Error
+	}
+	if stats.Timestamp.IsZero() {
+		return "Monitor process collecting"
+	}
+	return fmt.Sprintf("Monitor process CPU %s, memory %s", m.percentText(stats.CPUPercent), m.styleManager.FormatBytes(stats.RSS))
+}
+
+func (m MainModel) kernelSummary() string {
+	rates, ok := m.kernel.GetRates()
+	if !ok {
+		return "Kernel collecting"
+	}
+	locale := m.styleManager.Locale()
+	summary := fmt.Sprintf("Kernel %s context switches, %s interrupts and %s forks per second",
+		locale.FormatFloat(rates.ContextSwitches, 0), locale.FormatFloat(rates.Interrupts, 0), locale.FormatFloat(rates.Forks, 0))
+	if entropy := m.kernel.GetStats().Entropy; entropy >= 0 {
+		summary += fmt.Sprintf(", %s bits of entropy", locale.FormatInt(int64(entropy)))
+	}
+	return summary
+}
+
+// unitName spells out a temperature unit for the summaries
+func unitName(unit models.TemperatureUnit) string {
+	if unit == models.Fahrenheit {
+		return "degrees Fahrenheit"
+	}
+	return "degrees Celsius"
+}
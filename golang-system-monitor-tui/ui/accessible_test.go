@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestMainModel_AccessibleMode(t *testing.T) {
+	model := NewMainModel().SetAccessible(true)
+	if !model.IsAccessible() {
+		t.Fatal("Expected the screen reader mode to be on")
+	}
+	msgs := []tea.Msg{
+		tea.WindowSizeMsg{Width: 60, Height: 20},
+		CPUUpdateMsg{Cores: 4, Usage: []float64{20, 35, 91, 26}, Total: 43},
+		MemoryUpdateMsg{Total: 1024, Used: 512, Available: 256, Swap: models.SwapInfo{Total: 100, Used: 10}},
+		DiskUpdateMsg{{Mountpoint: "/", Total: 1000, Used: 850, Available: 150, UsedPercent: 85}},
+		NetworkUpdateMsg{{Interface: "eth0"}},
+	}
+	var updated tea.Model = model
+	for _, msg := range msgs {
+		updated, _ = updated.Update(msg)
+	}
+	model = updated.(MainModel)
+
+	view := stripStyles(model.View())
+	for _, want := range []string{
+		"CPU total 43 percent, core 3 highest at 91 percent, focused",
+		"Memory 50 percent used, 512B of 1.0KiB, 256B available, swap 10 percent used",
+		"Disk / 85 percent used, 150B free of 1000B",
+		"Network receiving 0B/s, sending 0B/s",
+		"Keys: ",
+	} {
+		if !strings.Contains(strings.ReplaceAll(view, "\n", " "), want) {
+			t.Errorf("Expected the summaries to contain %q, got:\n%s", want, view)
+		}
+	}
+	for _, glyph := range []string{"╭", "┌", "│", "█", "░"} {
+		if strings.Contains(view, glyph) {
+			t.Errorf("Expected no box-drawing or bar glyphs, found %q in:\n%s", glyph, view)
+		}
+	}
+	for i, line := range strings.Split(view, "\n") {
+		if lipgloss.Width(line) > 60 {
+			t.Errorf("Line %d overflows 60 columns: %q", i, line)
+		}
+	}
+
+	// The help screen drops its box
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if help := stripStyles(updated.(MainModel).View()); !strings.Contains(help, "Keyboard Shortcuts") || strings.Contains(help, "╭") {
+		t.Errorf("Expected the help screen without a box, got:\n%s", help)
+	}
+}
+
+func TestMainModel_AccessibleModeFitsHeight(t *testing.T) {
+	model := NewMainModel().SetAccessible(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 30, Height: 4})
+	model = updated.(MainModel)
+
+	lines := strings.Split(stripStyles(model.View()), "\n")
+	if len(lines) > 4 {
+		t.Errorf("Expected at most 4 lines, got %d: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[len(lines)-1], "help") {
+		t.Errorf("Expected the key hints to stay on the last line, got %q", lines[len(lines)-1])
+	}
+}
+
+func TestPanelSummaries(t *testing.T) {
+	model := NewMainModel()
+	tests := []struct {
+		panel FocusedComponent
+		want  string
+	}{
+		{FocusCPU, "CPU collecting"},
+		{FocusMemory, "Memory collecting"},
+		{FocusDisk, "Disk: no filesystems"},
+		{FocusNetwork, "Network: no interfaces"},
+		{FocusSensors, "Temperatures: no sensors"},
+		{FocusAlerts, "Alerts: 0 active"},
+		{FocusSelf, "Monitor process collecting"},
+	}
+	for _, tt := range tests {
+		if got := model.panelSummary(tt.panel); len(got) != 1 || got[0] != tt.want {
+			t.Errorf("panelSummary(%v) = %q, want %q", tt.panel, got, tt.want)
+		}
+	}
+
+	updated, _ := model.Update(SensorsUpdateMsg{{Key: "coretemp", Kind: models.SensorCPU, Temperature: 99}})
+	if got := updated.(MainModel).sensorsSummary(); got != "Temperatures: hottest CPU at 99 degrees Celsius, over its warning threshold" {
+		t.Errorf("Unexpected sensor summary %q", got)
+	}
+}
@@ -0,0 +1,21 @@
+package ui
+
+import (
+	"fmt"
+
+	"golang-system-monitor-tui/models"
+)
+
+// collapsedLabel names a collapsed row by the names of its members, their
+// common prefix with a star when they differ, and their count, e.g.
+// "veth* (24)"
+func collapsedLabel(names []string) string {
+	label := models.CommonPrefix(names)
+	for _, name := range names {
+		if name != label {
+			label += "*"
+			break
+		}
+	}
+	return fmt.Sprintf("%s (%d)", label, len(names))
+}
@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+)
+
+// AlertsUpdateMsg carries the alert history, newest first, and the number of active alerts
+type AlertsUpdateMsg struct {
+	Events []models.AlertEvent
+	Active int
+}
+
+// AlertCaptureMsg carries the busiest processes sampled when an alert fired
+type AlertCaptureMsg struct {
+	Event     models.AlertEvent
+	Processes []models.CapturedProcess
+}
+
+// AlertsModel represents the alert history component
+type AlertsModel struct {
+	events       []models.AlertEvent // Alert history, newest first
+	active       int                 // Number of currently firing alerts
+	width        int                 // Component width for rendering
+	height       int                 // Component height for rendering
+	styleManager *StyleManager       // Style manager for consistent styling
+	filter       string              // Alert description filter typed with /
+	selected     int                 // Index of the selected alert among the visible ones
+	selectable   bool                // Whether the selection is shown, on the alerts page only
+	showCapture  bool                // Whether the processes captured for the selected alert are shown
+}
+
+// NewAlertsModel creates a new alerts model instance
+func NewAlertsModel() AlertsModel {
+	return AlertsModel{
+		events:       []models.AlertEvent{},
+		width:        60,
+		height:       10,
+		styleManager: NewStyleManager(),
+	}
+}
+
+// Init initializes the alerts model
+func (m AlertsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages and updates the alerts model state
+func (m AlertsModel) Update(msg tea.Msg) (AlertsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case AlertsUpdateMsg:
+		// Keep the selection on the same alert as newer ones are added above it
+		selected, hadSelection := m.Selected()
+		m.events = msg.Events
+		m.active = msg.Active
+		if hadSelection {
+			for i, event := range m.GetVisibleEvents() {
+				if event.Same(selected) {
+					m.selected = i
+					break
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// View renders the alerts model, or the processes captured for the selected
+// alert when they are shown
+func (m AlertsModel) View() string {
+	if m.showCapture {
+		return m.renderCapture()
+	}
+	var sections []string
+
+	// Header
+	events := m.GetVisibleEvents()
+	header := renderFilteredHeader(m.styleManager, fmt.Sprintf("Alerts (%d active)", m.active), m.filter, len(events), len(m.events))
+	sections = append(sections, header)
+
+	if len(m.events) == 0 {
+		sections = append(sections, m.styleManager.RenderMutedText("No alerts have fired this session"))
+		for len(sections) < m.height {
+			sections = append(sections, "")
+		}
+		return strings.Join(sections, "\n")
+	}
+
+	if len(events) == 0 {
+		sections = append(sections, m.styleManager.RenderMutedText("No alerts match the filter"))
+	}
+
+	// The list scrolls to the selection
+	first := 0
+	if rows := m.height - len(sections); rows > 0 && m.selected >= rows {
+		first = m.selected - rows + 1
+	}
+	for i := first; i < len(events); i++ {
+		event := events[i]
+		if m.height > 0 && len(sections) >= m.height {
+			break
+		}
+
+		state := "CLEARED"
+		if event.Fired {
+			state = "FIRED"
+		}
+		line := fmt.Sprintf("%s  %-7s  %s", m.styleManager.Locale().FormatTime(event.Timestamp), state, event.LocalizedDescription(m.styleManager.Locale()))
+		if m.selectable {
+			marker := "  "
+			if i == m.selected {
+				marker = "▶ "
+			}
+			line = marker + line
+		}
+
+		var captured string
+		if len(event.TopProcesses) > 0 {
+			captured = m.styleManager.RenderMutedText("  [top processes]")
+		}
+		if event.Fired {
+			sections = append(sections, m.styleManager.RenderCriticalText(line)+captured)
+		} else {
+			sections = append(sections, m.styleManager.RenderMutedText(line)+captured)
+		}
+	}
+
+	// Add spacing if we have fewer lines than available height
+	for len(sections) < m.height {
+		sections = append(sections, "")
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+// renderCapture renders the processes captured when the selected alert fired
+func (m AlertsModel) renderCapture() string {
+	event, ok := m.Selected()
+	if !ok {
+		return m.styleManager.RenderPlaceholder("Alert", "No alert selected")
+	}
+	locale := m.styleManager.Locale()
+	sections := []string{
+		m.styleManager.RenderHeader("Top processes at " + locale.FormatTime(event.Timestamp)),
+		m.styleManager.RenderMutedText(truncate(event.LocalizedDescription(locale), m.width-2)),
+	}
+	if len(event.TopProcesses) == 0 {
+		sections = append(sections, m.styleManager.RenderMutedText("No processes were captured for this alert"))
+	} else {
+		table := m.styleManager.NewTable(m.width-2,
+			TableColumn{Title: "PID", Width: 7, Right: true},
+			TableColumn{Title: "NAME", Width: 20},
+			TableColumn{Title: "CPU", Width: 6, Right: true},
+			TableColumn{Title: "RSS", Width: 9, Right: true})
+		sections = append(sections, table.Header())
+		for _, proc := range event.TopProcesses {
+			rss := "-"
+			if proc.RSS > 0 {
+				rss = m.styleManager.FormatBytes(proc.RSS)
+			}
+			sections = append(sections, table.Row(strconv.Itoa(int(proc.PID)), proc.Name, locale.FormatPercent(proc.CPUPercent, 1), rss))
+		}
+	}
+	for len(sections) < m.height {
+		sections = append(sections, "")
+	}
+	return strings.Join(sections, "\n")
+}
+
+// MoveSelection moves the selection by delta alerts, clamped to the list
+func (m AlertsModel) MoveSelection(delta int) AlertsModel {
+	m.selected = max(0, min(m.selected+delta, len(m.GetVisibleEvents())-1))
+	return m
+}
+
+// Selected returns the selected alert, false when no alert is listed
+func (m AlertsModel) Selected() (models.AlertEvent, bool) {
+	events := m.GetVisibleEvents()
+	if m.selected < 0 || m.selected >= len(events) {
+		return models.AlertEvent{}, false
+	}
+	return events[m.selected], true
+}
+
+// SetSelectable shows the selection, for the alerts page
+func (m AlertsModel) SetSelectable(selectable bool) AlertsModel {
+	m.selectable = selectable
+	return m
+}
+
+// SetShowCapture shows the processes captured for the selected alert, or
+// goes back to the list
+func (m AlertsModel) SetShowCapture(show bool) AlertsModel {
+	m.showCapture = show
+	return m
+}
+
+// IsShowingCapture reports whether the captured processes are shown
+func (m AlertsModel) IsShowingCapture() bool {
+	return m.showCapture
+}
+
+// SetSize sets the component dimensions
+func (m AlertsModel) SetSize(width, height int) AlertsModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m AlertsModel) SetStyleManager(styleManager *StyleManager) AlertsModel {
+	m.styleManager = styleManager
+	return m
+}
+
+// SetFilter shows only alerts whose description contains filter
+func (m AlertsModel) SetFilter(filter string) AlertsModel {
+	m.filter = filter
+	m.selected = 0
+	return m
+}
+
+// GetFilter returns the alert description filter
+func (m AlertsModel) GetFilter() string {
+	return m.filter
+}
+
+// GetVisibleEvents returns the alert history entries that pass the filter
+func (m AlertsModel) GetVisibleEvents() []models.AlertEvent {
+	if m.filter == "" {
+		return m.events
+	}
+	var visible []models.AlertEvent
+	for _, event := range m.events {
+		if matchesFilter(event.LocalizedDescription(m.styleManager.Locale()), m.filter) {
+			visible = append(visible, event)
+		}
+	}
+	return visible
+}
+
+// GetEvents returns the alert history, newest first
+func (m AlertsModel) GetEvents() []models.AlertEvent {
+	return m.events
+}
+
+// GetActiveCount returns the number of currently firing alerts
+func (m AlertsModel) GetActiveCount() int {
+	return m.active
+}
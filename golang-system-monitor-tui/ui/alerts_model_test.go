@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestNewAlertsModel(t *testing.T) {
+	model := NewAlertsModel()
+
+	if len(model.GetEvents()) != 0 {
+		t.Errorf("Expected no events, got %v", model.GetEvents())
+	}
+	if model.styleManager == nil {
+		t.Error("Expected style manager to be initialized")
+	}
+	if !strings.Contains(model.View(), "No alerts have fired") {
+		t.Error("Expected empty-state message in view")
+	}
+}
+
+func TestAlertsModel_View(t *testing.T) {
+	model := NewAlertsModel().SetSize(80, 10)
+
+	rule := models.AlertRule{Component: "Disk", Threshold: 95}
+	fired := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local)
+	events := []models.AlertEvent{
+		{Rule: rule, Subject: "/", Value: 80, Fired: false, Timestamp: fired.Add(time.Minute)},
+		{Rule: rule, Subject: "/", Value: 97.5, Fired: true, Timestamp: fired},
+	}
+	model, cmd := model.Update(AlertsUpdateMsg{Events: events, Active: 0})
+	if cmd != nil {
+		t.Errorf("Expected Update() to return nil cmd, got %v", cmd)
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "Alerts (0 active)") {
+		t.Error("Expected header with active count")
+	}
+	if !strings.Contains(view, "10:00:00") || !strings.Contains(view, "FIRED") {
+		t.Error("Expected fired event with timestamp")
+	}
+	if strings.Index(view, "CLEARED") > strings.Index(view, "FIRED") {
+		t.Error("Expected newest event to be listed first")
+	}
+}
+
+func TestAlertsModel_ViewLocale(t *testing.T) {
+	styleManager := NewStyleManager()
+	locale, _ := models.ParseLocale("de_DE")
+	locale.Clock24 = false
+	styleManager.SetLocale(locale)
+	model := NewAlertsModel().SetStyleManager(styleManager).SetSize(80, 10)
+
+	rule := models.AlertRule{Component: "Disk", Threshold: 95}
+	event := models.AlertEvent{Rule: rule, Subject: "/", Value: 97.5, Fired: true,
+		Timestamp: time.Date(2024, 1, 1, 15, 30, 0, 0, time.Local)}
+	model, _ = model.Update(AlertsUpdateMsg{Events: []models.AlertEvent{event}, Active: 1})
+
+	view := model.View()
+	if !strings.Contains(view, "03:30:00 PM") || !strings.Contains(view, "at 97,5%") {
+		t.Errorf("Expected localized time and value, got:\n%s", view)
+	}
+}
+
+func TestAlertsModel_ViewTruncatesToHeight(t *testing.T) {
+	model := NewAlertsModel().SetSize(80, 3)
+
+	var events []models.AlertEvent
+	for i := 0; i < 10; i++ {
+		events = append(events, models.AlertEvent{Rule: models.AlertRule{Component: "Memory", Threshold: 95}, Value: 96, Fired: true})
+	}
+	model, _ = model.Update(AlertsUpdateMsg{Events: events, Active: 1})
+
+	if lines := strings.Split(model.View(), "\n"); len(lines) != 3 {
+		t.Errorf("Expected view to fit in 3 lines, got %d", len(lines))
+	}
+}
+
+// findMsg runs a command tree synchronously, expanding batches, and returns
+// the first message of type T
+func findMsg[T tea.Msg](cmd tea.Cmd) (T, bool) {
+	var zero T
+	if cmd == nil {
+		return zero, false
+	}
+	switch msg := cmd().(type) {
+	case T:
+		return msg, true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if found, ok := findMsg[T](c); ok {
+				return found, true
+			}
+		}
+	}
+	return zero, false
+}
+
+func TestMainModel_AlertCapture(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true).SetProcessCollector(fake.NewDemo())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model = updated.(MainModel)
+
+	// A Memory alert captures the largest processes
+	updated, cmd := model.Update(MemoryUpdateMsg(models.MemoryInfo{Total: 100, Used: 97, Available: 3}))
+	model = updated.(MainModel)
+	capture, ok := findMsg[AlertCaptureMsg](cmd)
+	if !ok {
+		t.Fatal("Expected the firing alert to capture the top processes")
+	}
+	if len(capture.Processes) != models.AlertCaptureSize || capture.Processes[0].Name != "firefox" || capture.Processes[0].RSS != 2100<<20 {
+		t.Errorf("Expected the processes by memory, firefox first, got %+v", capture.Processes)
+	}
+	updated, _ = model.Update(capture)
+	model = updated.(MainModel)
+
+	press := func(keys ...tea.KeyMsg) {
+		for _, key := range keys {
+			updated, _ := model.Update(key)
+			model = updated.(MainModel)
+		}
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "▶ 12:00:00  FIRED") || !strings.Contains(view, "[top processes]") {
+		t.Errorf("Expected the selected alert with its capture, got:\n%s", view)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	view = stripStyles(model.View())
+	for _, want := range []string{"Top processes at 12:00:00", "firefox", "2.1GiB", "esc: back"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q on the capture, got:\n%s", want, view)
+		}
+	}
+
+	// Escape goes back to the list, then closes the page
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if !model.showAlerts || model.alertsPanel.IsShowingCapture() {
+		t.Error("Expected Esc to go back to the alert list")
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.showAlerts {
+		t.Error("Expected Esc to close the alert history")
+	}
+}
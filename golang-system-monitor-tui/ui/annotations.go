@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+// annotationCells returns the cell of a graph of width cells spanning span up
+// to end where each annotation made in that span falls, with the annotations
+// placed, in the same order
+func annotationCells(annotations []models.Annotation, end time.Time, span time.Duration, width int) ([]int, []models.Annotation) {
+	if span <= 0 || width <= 0 {
+		return nil, nil
+	}
+	start := end.Add(-span)
+	var cells []int
+	var placed []models.Annotation
+	for _, annotation := range annotations {
+		if annotation.Timestamp.Before(start) || annotation.Timestamp.After(end) {
+			continue
+		}
+		cell := int(int64(annotation.Timestamp.Sub(start)) * int64(width) / int64(span))
+		cells = append(cells, min(cell, width-1))
+		placed = append(placed, annotation)
+	}
+	return cells, placed
+}
+
+// renderAnnotationLabels names the annotations marked on a graph with their
+// times, e.g. "Marks: deploy 12:00:00 • backup started 12:05:00", truncated to
+// width
+func renderAnnotationLabels(locale models.Locale, annotations []models.Annotation, width int) string {
+	if len(annotations) == 0 {
+		return ""
+	}
+	parts := make([]string, len(annotations))
+	for i, annotation := range annotations {
+		parts[i] = annotation.Name + " " + locale.FormatTime(annotation.Timestamp)
+	}
+	return truncate("Marks: "+strings.Join(parts, " • "), width)
+}
+
+// annotate marks the timeline now with a named annotation, shown on the
+// history graphs and written to exported snapshots
+func (m MainModel) annotate(name string) MainModel {
+	annotation := models.Annotation{Name: name, Timestamp: m.now()}
+	m.annotations = models.AddAnnotation(m.annotations, annotation)
+	m.cpu = m.cpu.SetAnnotations(m.annotations)
+	return m.setCommandNotice("Marked "+name+" at "+m.styleManager.Locale().FormatTime(annotation.Timestamp), false)
+}
+
+// GetAnnotations returns the annotations made on the timeline, oldest first
+func (m MainModel) GetAnnotations() []models.Annotation {
+	return m.annotations
+}
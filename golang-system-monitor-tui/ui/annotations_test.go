@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestAnnotationCells(t *testing.T) {
+	end := DeterministicTime
+	annotations := []models.Annotation{
+		{Name: "too old", Timestamp: end.Add(-2 * time.Minute)},
+		{Name: "deploy", Timestamp: end.Add(-time.Minute)},
+		{Name: "backup", Timestamp: end.Add(-30 * time.Second)},
+		{Name: "now", Timestamp: end},
+	}
+	cells, placed := annotationCells(annotations, end, time.Minute, 20)
+	if want := []int{0, 10, 19}; !reflect.DeepEqual(cells, want) {
+		t.Errorf("annotationCells() = %v, want %v", cells, want)
+	}
+	if len(placed) != 3 || placed[0].Name != "deploy" {
+		t.Errorf("Expected the annotations within the minute, got %+v", placed)
+	}
+	if cells, _ := annotationCells(annotations, end, 0, 20); cells != nil {
+		t.Errorf("Expected no marks on a graph of the last samples, got %v", cells)
+	}
+}
+
+func TestRenderMarkedGraph(t *testing.T) {
+	sm := NewStyleManager()
+	graph := stripStyles(sm.RenderMarkedGraph([]float64{50, 50, 50}, 6, []int{1, 4}))
+	if graph != " │ ▄│▄" {
+		t.Errorf("Expected markers in the padding and over the values, got %q", graph)
+	}
+	sm.SetBarMode(BarASCII)
+	if graph := stripStyles(sm.RenderMarkedGraph([]float64{50}, 2, []int{1})); graph != " |" {
+		t.Errorf("Expected an ASCII marker, got %q", graph)
+	}
+}
+
+func TestCPUModel_Annotations(t *testing.T) {
+	model := NewCPUModel().SetSize(60, 12)
+	for i := 0; i < 30; i++ {
+		model, _ = model.Update(CPUUpdateMsg{Usage: []float64{50}, Total: 50, Cores: 1, Timestamp: DeterministicTime.Add(time.Duration(i) * time.Second)})
+	}
+	model = model.SetAnnotations([]models.Annotation{{Name: "deploy", Timestamp: DeterministicTime.Add(20 * time.Second)}})
+
+	view := stripStyles(model.View())
+	trend := ""
+	for _, line := range strings.Split(view, "\n") {
+		if strings.HasPrefix(line, "Trend: ") {
+			trend = line
+		}
+	}
+	if strings.Count(trend, "│") != 1 {
+		t.Errorf("Expected one marker on the trend, got %q", trend)
+	}
+	if !strings.Contains(view, "Marks: deploy 12:00:20") {
+		t.Errorf("Expected the annotation named below the trend, got:\n%s", view)
+	}
+}
+
+func TestMainModel_Annotate(t *testing.T) {
+	dir := t.TempDir()
+	model := NewMainModel().SetDeterministic(true).SetScreenshotDir(dir)
+
+	// m opens the prompt with the command typed
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	model = updated.(MainModel)
+	if !model.commanding || model.prompt.Value() != "annotate " {
+		t.Fatalf("Expected m to open the prompt for an annotation, got %q", model.prompt.Value())
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("backup started")})
+	updated, _ = updated.(MainModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(MainModel)
+
+	want := []models.Annotation{{Name: "backup started", Timestamp: DeterministicTime}}
+	if got := model.GetAnnotations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAnnotations() = %+v, want %+v", got, want)
+	}
+	if notice := stripStyles(model.renderNotice(model.now())); notice != "Marked backup started at 12:00:00" {
+		t.Errorf("Expected a confirmation, got %q", notice)
+	}
+
+	model, _ = typeCommand(t, model, "annotate")
+	if len(model.GetAnnotations()) != 1 || !strings.Contains(model.renderNotice(model.now()), "Usage: :annotate name") {
+		t.Error("Expected an annotation without a name to be rejected")
+	}
+
+	// The snapshot export carries the annotations
+	result := model.exportSnapshotCmd()().(ScreenshotMsg)
+	data, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatalf("Expected the snapshot file: %v", err)
+	}
+	var snapshot models.Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil || len(snapshot.Annotations) != 1 || snapshot.Annotations[0].Name != "backup started" {
+		t.Errorf("Expected the annotation in the snapshot, got %+v (%v)", snapshot.Annotations, err)
+	}
+}
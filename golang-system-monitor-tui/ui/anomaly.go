@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"math"
+
+	"golang-system-monitor-tui/models"
+)
+
+// Smallest standard deviations the baselines are taken to have, so a metric
+// that barely moved, such as an idle CPU, is not unusual at every blip
+const (
+	cpuBaselineFloor     = 5.0       // Percentage points
+	memoryBaselineFloor  = 2.0       // Percentage points
+	networkBaselineFloor = 64 * 1024 // Bytes per second
+)
+
+// renderUnusualMarker renders the subtle marker appended to a panel header
+// while its metric deviates more than sigma standard deviations from its
+// baseline, e.g. "unusual +4.2σ", or "unusual eth0 +4.2σ" naming the subject;
+// empty otherwise
+func renderUnusualMarker(styleManager *StyleManager, subject string, deviation, sigma float64) string {
+	if !models.Unusual(deviation, sigma) {
+		return ""
+	}
+	marker := "unusual "
+	if subject != "" {
+		marker += subject + " "
+	}
+	if deviation < 0 {
+		marker += "-"
+	} else {
+		marker += "+"
+	}
+	return " " + styleManager.RenderMutedText(marker+styleManager.Locale().FormatFloat(math.Abs(deviation), 1)+"σ")
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxStyledTexts bounds the number of cached styled texts; the cache is
+// emptied when it is full, e.g. after many resizes
+const maxStyledTexts = 1024
+
+// renderBuffer holds the line slice and join space of a panel view. Buffers
+// are pooled, so each frame reuses the memory grown by earlier frames.
+type renderBuffer struct {
+	lines  []string
+	joined []byte
+}
+
+// renderBuffers pools the buffers of panel views
+var renderBuffers = sync.Pool{
+	New: func() interface{} {
+		return &renderBuffer{lines: make([]string, 0, 32)}
+	},
+}
+
+// getRenderBuffer takes a buffer from the pool; release it when done
+func getRenderBuffer() *renderBuffer {
+	return renderBuffers.Get().(*renderBuffer)
+}
+
+// release returns the buffer to the pool, dropping its lines
+func (b *renderBuffer) release() {
+	clear(b.lines)
+	b.lines = b.lines[:0]
+	renderBuffers.Put(b)
+}
+
+// join pads lines with empty ones to height and joins them with newlines. The
+// lines are kept as the buffer's slice, so it grows only once.
+func (b *renderBuffer) join(lines []string, height int) string {
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	b.lines = lines
+
+	b.joined = b.joined[:0]
+	for i, line := range lines {
+		if i > 0 {
+			b.joined = append(b.joined, '\n')
+		}
+		b.joined = append(b.joined, line...)
+	}
+	return string(b.joined)
+}
+
+// styledTexts caches texts drawn the same way every frame, such as progress
+// bars and panel titles. The key holds everything the styled text depends
+// on: the color profile, the terminal background, the style and the text.
+var styledTexts = struct {
+	sync.Mutex
+	texts map[string]string
+	key   []byte
+}{texts: make(map[string]string)}
+
+// renderStyled returns the text built by appendText in color, bold if asked,
+// rendering it only when it is not cached
+func renderStyled(color lipgloss.AdaptiveColor, bold bool, appendText func([]byte) []byte) string {
+	styledTexts.Lock()
+	defer styledTexts.Unlock()
+
+	key := append(styledTexts.key[:0], byte(lipgloss.ColorProfile()))
+	if lipgloss.HasDarkBackground() {
+		key = append(key, 'd')
+	} else {
+		key = append(key, 'l')
+	}
+	if bold {
+		key = append(key, 'b')
+	} else {
+		key = append(key, 'n')
+	}
+	key = append(key, color.Light...)
+	key = append(key, 0)
+	key = append(key, color.Dark...)
+	key = append(key, 0)
+	start := len(key)
+	key = appendText(key)
+	styledTexts.key = key
+
+	// Looking up string(key) does not allocate
+	if styled, ok := styledTexts.texts[string(key)]; ok {
+		return styled
+	}
+	styled := lipgloss.NewStyle().Bold(bold).Foreground(color).Render(string(key[start:]))
+	if len(styledTexts.texts) >= maxStyledTexts {
+		clear(styledTexts.texts)
+	}
+	styledTexts.texts[string(key)] = styled
+	return styled
+}
+
+// appendRepeat appends count copies of glyph to buf
+func appendRepeat(buf []byte, glyph string, count int) []byte {
+	for i := 0; i < count; i++ {
+		buf = append(buf, glyph...)
+	}
+	return buf
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestRenderBuffer_Join(t *testing.T) {
+	buf := getRenderBuffer()
+	lines := append(buf.lines, "a", "b")
+	if got := buf.join(lines, 4); got != "a\nb\n\n" {
+		t.Errorf("Expected the lines padded to 4, got %q", got)
+	}
+	if got := buf.join(append(buf.lines[:0], "c", "d", "e"), 2); got != "c\nd\ne" {
+		t.Errorf("Expected lines beyond the height kept, got %q", got)
+	}
+	buf.release()
+
+	if buf := getRenderBuffer(); len(buf.lines) != 0 {
+		t.Errorf("Expected pooled buffers to be empty, got %q", buf.lines)
+	}
+}
+
+func TestRenderStyled(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	defer lipgloss.SetColorProfile(profile)
+
+	color := lipgloss.AdaptiveColor{Light: "1", Dark: "1"}
+	text := func(buf []byte) []byte { return append(buf, "####"...) }
+
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	colored := renderStyled(color, false, text)
+	if want := lipgloss.NewStyle().Foreground(color).Render("####"); colored != want {
+		t.Errorf("Expected %q, got %q", want, colored)
+	}
+	if again := renderStyled(color, false, text); again != colored {
+		t.Errorf("Expected the cached text, got %q", again)
+	}
+	if bold := renderStyled(color, true, text); bold == colored {
+		t.Error("Expected bold text cached separately")
+	}
+	if other := renderStyled(lipgloss.AdaptiveColor{Light: "2", Dark: "2"}, false, text); other == colored {
+		t.Error("Expected other colors cached separately")
+	}
+
+	// Switching the color profile must not return texts styled for the old one
+	lipgloss.SetColorProfile(termenv.Ascii)
+	if plain := renderStyled(color, false, text); plain != "####" {
+		t.Errorf("Expected plain text without colors, got %q", plain)
+	}
+}
+
+func TestRenderProgressBar_Cached(t *testing.T) {
+	sm := NewStyleManager()
+	first := stripStyles(sm.RenderProgressBar(50, 10, false))
+	if first != strings.Repeat("█", 5)+strings.Repeat("░", 5) {
+		t.Errorf("Unexpected bar %q", first)
+	}
+
+	// Glyphs are part of the cache key
+	sm.barFilled, sm.barEmpty = "=", " "
+	if bar := stripStyles(sm.RenderProgressBar(50, 10, false)); bar != "=====     " {
+		t.Errorf("Expected the new glyphs, got %q", bar)
+	}
+	sm.SetBarMode(BarASCII)
+	if bar := stripStyles(sm.RenderProgressBar(30, 10, false)); bar != "###-------" {
+		t.Errorf("Expected an ASCII bar, got %q", bar)
+	}
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+)
+
+// CollectedMsg carries the result of a custom collector whose data no panel
+// renders; MainModel keeps the latest result per collector
+type CollectedMsg struct {
+	Name string
+	Data interface{}
+}
+
+// SnapshotMsg carries the results of the collectors run on one tick, so the
+// panels are updated together in a single Update and View pass
+type SnapshotMsg struct {
+	Msgs    []tea.Msg
+	pending <-chan tea.Msg // Results of collectors still running at the deadline
+	late    int            // Number of results still to come on pending
+}
+
+// newSystemRegistry creates a registry holding the collectors of system
+func newSystemRegistry(system models.SystemCollector) *services.CollectorRegistry {
+	registry := services.NewCollectorRegistry()
+	services.RegisterSystemCollectors(registry, system)
+	return registry
+}
+
+// collectedMsg wraps the result of a collector in the update message of the
+// panel showing that kind of data
+func collectedMsg(name string, data interface{}) tea.Msg {
+	switch data := data.(type) {
+	case models.CPUInfo:
+		return CPUUpdateMsg(data)
+	case models.MemoryInfo:
+		return MemoryUpdateMsg(data)
+	case []models.DiskInfo:
+		return DiskUpdateMsg(data)
+	case []models.NetworkInfo:
+		return NetworkUpdateMsg(data)
+	case []models.SensorInfo:
+		return SensorsUpdateMsg(data)
+	case []models.ContainerInfo:
+		return ContainersUpdateMsg(data)
+	case models.PluginOutput:
+		return PluginUpdateMsg(data)
+	case models.LogTail:
+		return LogUpdateMsg(data)
+	case models.CPUPower:
+		return PowerUpdateMsg(data)
+	case models.ProcessStates:
+		return ProcessStatesUpdateMsg(data)
+	case models.FileDescriptors:
+		return FilesUpdateMsg(data)
+	case []models.WirelessLink:
+		return WirelessUpdateMsg(data)
+	case []models.StoragePool:
+		return PoolsUpdateMsg(data)
+	case models.DiskIO:
+		return DiskIOUpdateMsg(data)
+	case models.KernelStats:
+		return KernelUpdateMsg(data)
+	case models.SelfStats:
+		return SelfUpdateMsg(data)
+	default:
+		return CollectedMsg{Name: name, Data: data}
+	}
+}
+
+// collectCmd creates a command running collector in a goroutine
+func (m MainModel) collectCmd(collector models.Collector) tea.Cmd {
+	degradation, debug, adaptive := m.degradation, m.debug, m.adaptive
+	return m.recordCollect(func() tea.Msg {
+		start := time.Now()
+		data, err := collector.Collect()
+		duration := time.Since(start)
+		debug.recordCollect(collector.Name(), duration)
+		if sampling, ok := collector.(models.SamplingCollector); ok {
+			// Waiting for samples does not load the system
+			duration -= sampling.SampleWindow()
+		}
+		adaptive.RecordCollect(duration)
+		if err != nil {
+			return CollectFailedMsg{Name: collector.Name(), Err: err}
+		}
+		degradation.Success(collector.Name())
+		return collectedMsg(collector.Name(), data)
+	})
+}
+
+// snapshotCmd creates a command running cmds concurrently and returning their
+// results in order as a SnapshotMsg. Results not ready within deadline don't
+// hold up the others, they follow as messages of their own.
+func snapshotCmd(cmds []tea.Cmd, deadline time.Duration) tea.Cmd {
+	switch len(cmds) {
+	case 0:
+		return nil
+	case 1:
+		return cmds[0]
+	}
+	return func() tea.Msg {
+		type result struct {
+			index int
+			msg   tea.Msg
+		}
+		results := make(chan result, len(cmds))
+		for i, cmd := range cmds {
+			go func() {
+				results <- result{i, cmd()}
+			}()
+		}
+
+		msgs := make([]tea.Msg, len(cmds))
+		timeout := time.After(deadline)
+		received := 0
+	collect:
+		for received < len(cmds) {
+			select {
+			case r := <-results:
+				msgs[r.index] = r.msg
+				received++
+			case <-timeout:
+				break collect
+			}
+		}
+
+		snapshot := SnapshotMsg{late: len(cmds) - received}
+		for _, msg := range msgs {
+			if msg != nil {
+				snapshot.Msgs = append(snapshot.Msgs, msg)
+			}
+		}
+		if snapshot.late > 0 {
+			pending := make(chan tea.Msg, snapshot.late)
+			go func() {
+				for range snapshot.late {
+					pending <- (<-results).msg
+				}
+			}()
+			snapshot.pending = pending
+		}
+		return snapshot
+	}
+}
+
+// applySnapshot updates the model with every result of a snapshot and waits
+// for the late ones
+func (m MainModel) applySnapshot(msg SnapshotMsg) (MainModel, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, inner := range msg.Msgs {
+		updated, cmd := m.Update(inner)
+		m = updated.(MainModel)
+		cmds = append(cmds, cmd)
+	}
+	for range msg.late {
+		pending := msg.pending
+		cmds = append(cmds, func() tea.Msg {
+			return <-pending
+		})
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// collectNamedCmd creates a command running the named collector, or nil when
+// it is not registered
+func (m MainModel) collectNamedCmd(name string) tea.Cmd {
+	collector, ok := m.registry.Lookup(name)
+	if !ok {
+		return nil
+	}
+	return m.collectCmd(collector)
+}
+
+// RegisterCollector adds a collector that runs on every tick. Results of the
+// kinds the panels show update them; others are available from GetCollected.
+func (m MainModel) RegisterCollector(collector models.Collector) error {
+	return m.registry.Register(collector)
+}
+
+// GetRegistry returns the registry of collectors run on every tick
+func (m MainModel) GetRegistry() *services.CollectorRegistry {
+	return m.registry
+}
+
+// GetCollected returns the latest result of the named custom collector
+func (m MainModel) GetCollected(name string) (interface{}, bool) {
+	data, ok := m.collected[name]
+	return data, ok
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestMainModel_RegisterCollector(t *testing.T) {
+	model := NewMainModel().SetCollector(fake.NewCollector())
+
+	gpu := services.NewCollectorFunc("GPU", func() (interface{}, error) {
+		return map[string]float64{"utilization": 35}, nil
+	})
+	if err := model.RegisterCollector(gpu); err != nil {
+		t.Fatalf("RegisterCollector failed: %v", err)
+	}
+	// A custom collector may also feed a panel by returning its data kind
+	swap := services.NewCollectorFunc("Memory (cgroup)", func() (interface{}, error) {
+		return models.MemoryInfo{Total: 100, Used: 25}, nil
+	})
+	if err := model.RegisterCollector(swap); err != nil {
+		t.Fatalf("RegisterCollector failed: %v", err)
+	}
+
+	batch, ok := model.collectAllDataCmd()().(tea.BatchMsg)
+	if !ok || len(batch) != 6 {
+		t.Fatalf("Expected a batch with the 4 system and 2 custom collectors, got %d", len(batch))
+	}
+	var current tea.Model = model
+	for _, cmd := range batch {
+		current, _ = current.Update(cmd())
+	}
+	model = current.(MainModel)
+
+	data, ok := model.GetCollected("GPU")
+	if !ok || data.(map[string]float64)["utilization"] != 35 {
+		t.Errorf("Expected the GPU result to be kept, got %v", data)
+	}
+	if model.memory.GetTotal() != 100 {
+		t.Errorf("Expected the last memory result to update the memory panel, got %d", model.memory.GetTotal())
+	}
+	if model.cpu.GetTotal() != 60 {
+		t.Errorf("Expected the mock CPU data, got %v", model.cpu.GetTotal())
+	}
+}
+
+func TestMainModel_TickSnapshot(t *testing.T) {
+	model := NewMainModel().SetCollector(fake.NewCollector())
+
+	// All results of a tick arrive in one message, in registry order
+	snapshot, ok := model.collectDueCmd()().(SnapshotMsg)
+	if !ok || len(snapshot.Msgs) != 4 || snapshot.late != 0 {
+		t.Fatalf("Expected a snapshot of the 4 system collectors, got %#v", snapshot)
+	}
+	if _, ok := snapshot.Msgs[0].(CPUUpdateMsg); !ok {
+		t.Errorf("Expected the CPU result first, got %T", snapshot.Msgs[0])
+	}
+	updated, _ := model.Update(snapshot)
+	model = updated.(MainModel)
+	if model.cpu.GetTotal() != 60 || model.memory.GetTotal() == 0 || len(model.disk.GetFilesystems()) == 0 {
+		t.Error("Expected a single update to fill every panel")
+	}
+}
+
+func TestSnapshotCmd_LateResult(t *testing.T) {
+	release := make(chan struct{})
+	fast := func() tea.Msg { return MemoryUpdateMsg(models.MemoryInfo{Total: 100}) }
+	slow := func() tea.Msg {
+		<-release
+		return CPUUpdateMsg(models.CPUInfo{Total: 42})
+	}
+
+	// A slow collector doesn't hold up the snapshot, its result follows
+	snapshot := snapshotCmd([]tea.Cmd{slow, fast}, 10*time.Millisecond)().(SnapshotMsg)
+	if len(snapshot.Msgs) != 1 || snapshot.late != 1 {
+		t.Fatalf("Expected the fast result and one late one, got %#v", snapshot)
+	}
+	model, cmd := NewMainModel().applySnapshot(snapshot)
+	if model.memory.GetTotal() != 100 || cmd == nil {
+		t.Fatalf("Expected the memory panel updated and a command waiting for the CPU")
+	}
+	close(release)
+	for _, msg := range cmdMsgs(cmd) {
+		updated, _ := model.Update(msg)
+		model = updated.(MainModel)
+	}
+	if model.cpu.GetTotal() != 42 {
+		t.Errorf("Expected the late CPU result to be applied, got %v", model.cpu.GetTotal())
+	}
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ColorSupport is how many colors the terminal can show
+type ColorSupport int
+
+const (
+	Colors256 ColorSupport = iota // 256 colors or more
+	Colors8                       // Only the 8 basic ANSI colors
+)
+
+// String returns a short description of the color support
+func (c ColorSupport) String() string {
+	if c == Colors8 {
+		return "8 colors"
+	}
+	return "256 colors"
+}
+
+// colorTerms are TERM values, or parts of them, of terminals with at least
+// 256 colors
+var colorTerms = []string{"256color", "truecolor", "24bit", "direct", "kitty", "alacritty", "wezterm", "ghostty", "foot", "iterm"}
+
+// DetectColorSupport reads the color capability of the terminal from
+// COLORTERM, TERM and TERM_PROGRAM. Terminals that announce neither 256 nor
+// true colors are taken to show only the 8 basic colors.
+func DetectColorSupport(getenv func(string) string) ColorSupport {
+	if getenv("COLORTERM") != "" || getenv("TERM_PROGRAM") != "" {
+		return Colors256
+	}
+	term := strings.ToLower(getenv("TERM"))
+	for _, colorTerm := range colorTerms {
+		if strings.Contains(term, colorTerm) {
+			return Colors256
+		}
+	}
+	return Colors8
+}
+
+// BasicColorScheme returns the color scheme for terminals with only the 8
+// basic colors. Bright gray and the 256-color shades of the default scheme
+// show up black or not at all there.
+func BasicColorScheme() ColorScheme {
+	return ColorScheme{
+		Normal:     lipgloss.AdaptiveColor{Dark: "2", Light: "2"}, // Green
+		Warning:    lipgloss.AdaptiveColor{Dark: "3", Light: "3"}, // Yellow
+		Critical:   lipgloss.AdaptiveColor{Dark: "1", Light: "1"}, // Red
+		Header:     lipgloss.AdaptiveColor{Dark: "6", Light: "4"}, // Cyan, blue on light
+		Focused:    lipgloss.AdaptiveColor{Dark: "6", Light: "4"}, // Cyan, blue on light
+		Unfocused:  lipgloss.AdaptiveColor{Dark: "7", Light: "0"}, // White, black on light
+		Text:       lipgloss.AdaptiveColor{Dark: "7", Light: "0"}, // White, black on light
+		Muted:      lipgloss.AdaptiveColor{Dark: "7", Light: "0"}, // White, black on light
+		Background: lipgloss.AdaptiveColor{Dark: "0", Light: "7"}, // Black, white on light
+	}
+}
+
+// SetColorSupport switches s and its panels to the basic color scheme on
+// terminals with 8 colors, or back to the default scheme. Colors set in the
+// config file are kept.
+func (s *StyleManager) SetColorSupport(support ColorSupport) {
+	from, to := DefaultColorScheme(), BasicColorScheme()
+	if support == Colors256 {
+		from, to = to, from
+	}
+	s.colorSupport = support
+	s.colors = replaceColors(s.colors, from, to)
+	s.version = nextRenderVersion()
+	for _, panel := range s.panels {
+		panel.colorSupport = support
+		panel.colors = replaceColors(panel.colors, from, to)
+		panel.version = nextRenderVersion()
+	}
+}
+
+// ColorSupport returns the color capability the colors were chosen for
+func (s *StyleManager) ColorSupport() ColorSupport {
+	return s.colorSupport
+}
+
+// replaceColors returns colors with every color still at its value in from
+// replaced by the one in to
+func replaceColors(colors, from, to ColorScheme) ColorScheme {
+	replace := func(color *lipgloss.AdaptiveColor, from, to lipgloss.AdaptiveColor) {
+		if *color == from {
+			*color = to
+		}
+	}
+	replace(&colors.Normal, from.Normal, to.Normal)
+	replace(&colors.Warning, from.Warning, to.Warning)
+	replace(&colors.Critical, from.Critical, to.Critical)
+	replace(&colors.Header, from.Header, to.Header)
+	replace(&colors.Focused, from.Focused, to.Focused)
+	replace(&colors.Unfocused, from.Unfocused, to.Unfocused)
+	replace(&colors.Text, from.Text, to.Text)
+	replace(&colors.Muted, from.Muted, to.Muted)
+	replace(&colors.Background, from.Background, to.Background)
+	return colors
+}
+
+// colorSupportNote is shown in the footer on terminals with 8 colors until
+// the first key press
+const colorSupportNote = "8-color terminal, using basic colors (set COLORTERM=truecolor or a *-256color TERM for more)"
+
+// SetColorSupport picks the color scheme for the terminal's color capability;
+// on 8-color terminals the footer says so once
+func (m MainModel) SetColorSupport(support ColorSupport) MainModel {
+	m.styleManager.SetColorSupport(support)
+	m.colorNote = support == Colors8
+	return m
+}
+
+// GetColorSupport returns the color capability the colors were chosen for
+func (m MainModel) GetColorSupport() ColorSupport {
+	return m.styleManager.ColorSupport()
+}
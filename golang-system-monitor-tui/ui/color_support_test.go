@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/config"
+)
+
+func TestDetectColorSupport(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want ColorSupport
+	}{
+		{map[string]string{"TERM": "xterm-256color"}, Colors256},
+		{map[string]string{"TERM": "screen-256color"}, Colors256},
+		{map[string]string{"TERM": "xterm-kitty"}, Colors256},
+		{map[string]string{"TERM": "xterm", "COLORTERM": "truecolor"}, Colors256},
+		{map[string]string{"TERM": "xterm", "TERM_PROGRAM": "Apple_Terminal"}, Colors256},
+		{map[string]string{"TERM": "xterm"}, Colors8},
+		{map[string]string{"TERM": "linux"}, Colors8},
+		{map[string]string{"TERM": "vt100"}, Colors8},
+		{map[string]string{}, Colors8},
+	}
+	for _, tt := range tests {
+		getenv := func(key string) string { return tt.env[key] }
+		if got := DetectColorSupport(getenv); got != tt.want {
+			t.Errorf("DetectColorSupport(%v) = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestStyleManager_SetColorSupport(t *testing.T) {
+	settings := config.Default()
+	settings.Style.Critical = "#ff0000"
+	settings.Panels["cpu"] = config.Style{Normal: "#00ff00"}
+	styles := NewStyleManager()
+	styles.ApplyConfig(settings)
+
+	styles.SetColorSupport(Colors8)
+	if styles.colors.Muted != BasicColorScheme().Muted {
+		t.Errorf("Expected the basic muted color, got %+v", styles.colors.Muted)
+	}
+	if styles.colors.Critical != fixedColor("#ff0000") {
+		t.Errorf("Expected the configured critical color to be kept, got %+v", styles.colors.Critical)
+	}
+	cpu := styles.ForPanel("cpu")
+	if cpu.ColorSupport() != Colors8 || cpu.colors.Normal != fixedColor("#00ff00") || cpu.colors.Unfocused != BasicColorScheme().Unfocused {
+		t.Errorf("Expected the panel to switch too and keep its override, got %+v", cpu.colors)
+	}
+
+	styles.SetColorSupport(Colors256)
+	if styles.colors.Muted != DefaultColorScheme().Muted || styles.colors.Critical != fixedColor("#ff0000") {
+		t.Errorf("Expected the default scheme back, got %+v", styles.colors)
+	}
+}
+
+func TestMainModel_ColorSupportNote(t *testing.T) {
+	model := NewMainModel().SetColorSupport(Colors8)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 200, Height: 30})
+	model = updated.(MainModel)
+	if model.GetColorSupport() != Colors8 {
+		t.Errorf("Expected 8 colors, got %v", model.GetColorSupport())
+	}
+
+	footer := stripStyles(model.renderFooter())
+	if !strings.Contains(footer, "8-color terminal") || !strings.Contains(footer, "quit") {
+		t.Errorf("Expected the note next to the global hints, got %q", footer)
+	}
+	narrow, _ := model.Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	if footer := narrow.(MainModel).renderFooter(); lipgloss.Width(footer) > 60 || !strings.Contains(stripStyles(footer), "8-color") {
+		t.Errorf("Expected the note cut to the width, got %q", stripStyles(footer))
+	}
+
+	// The first key press removes the note for good
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	model = updated.(MainModel)
+	if footer := stripStyles(model.renderFooter()); strings.Contains(footer, "8-color terminal") {
+		t.Errorf("Expected a key press to remove the note, got %q", footer)
+	}
+	if !model.zoomed {
+		t.Error("Expected the key to still do its job")
+	}
+
+	if footer := stripStyles(NewMainModel().SetColorSupport(Colors256).renderFooter()); strings.Contains(footer, "8-color") {
+		t.Errorf("Expected no note with 256 colors, got %q", footer)
+	}
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// minCommandInterval is the shortest refresh interval :interval accepts
+const minCommandInterval = 100 * time.Millisecond
+
+// commandNotice is the result of the last command, shown on the notice line
+type commandNotice struct {
+	text string
+	err  bool
+	at   time.Time
+}
+
+// paletteCommands lists the commands of the command prompt with their usage,
+// in the order the help screen shows them
+var paletteCommands = []struct {
+	usage, desc string
+}{
+	{":interval 500ms", "Change the refresh interval"},
+	{":theme dark|light", "Switch colors for a dark or light background"},
+	{":filter eth", "Filter the focused list, :filter alone clears it"},
+	{":profile server", "Switch to a profile of the config file, :profile alone leaves it"},
+	{":annotate deploy", "Mark the timeline now, shown on the graphs and in exports"},
+	{":pin /home", "Pin a row to the top of the focused Disk or Network panel, :unpin releases it"},
+	{":quit, :q", "Quit application"},
+}
+
+// handleCommandKey edits the command prompt; Enter runs the command and
+// Escape, or Backspace on an empty prompt, closes it without running it
+func (m MainModel) handleCommandKey(msg tea.KeyMsg) (MainModel, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEnter:
+		m.commanding = false
+		return m.runCommand(m.prompt.Value())
+	case msg.Type == tea.KeyEsc, msg.Type == tea.KeyBackspace && m.prompt.Value() == "":
+		m.commanding = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	return m, cmd
+}
+
+// runCommand runs one line typed into the command prompt, reporting the
+// outcome on the notice line
+func (m MainModel) runCommand(line string) (MainModel, tea.Cmd) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return m, nil
+	}
+	name, args := fields[0], fields[1:]
+
+	switch name {
+	case "q", "quit":
+		return m, tea.Quit
+
+	case "interval":
+		if len(args) != 1 {
+			return m.setCommandNotice("Usage: :interval 500ms", true), nil
+		}
+		interval, err := time.ParseDuration(args[0])
+		if err != nil || interval < minCommandInterval {
+			return m.setCommandNotice(fmt.Sprintf("Invalid interval %q, want a duration of at least %v", args[0], minCommandInterval), true), nil
+		}
+		m = m.SetUpdateInterval(interval)
+		m.baseInterval = interval
+		return m.setCommandNotice("Refreshing every "+interval.String(), false), nil
+
+	case "theme":
+		if len(args) != 1 || (args[0] != "dark" && args[0] != "light") {
+			return m.setCommandNotice("Usage: :theme dark|light", true), nil
+		}
+		m.styleManager.SetDarkBackground(args[0] == "dark")
+		return m.setCommandNotice("Using the "+args[0]+" theme", false), nil
+
+	case "filter":
+		if _, ok := m.panelFilter(m.focused); !ok {
+			return m.setCommandNotice("The "+m.focused.PanelName()+" panel cannot be filtered", true), nil
+		}
+		m, _ = m.setPanelFilter(m.focused, strings.Join(args, " "))
+		return m, nil
+
+	case "profile":
+		if len(args) > 1 {
+			return m.setCommandNotice("Usage: :profile [name]", true), nil
+		}
+		return m.switchProfile(strings.Join(args, ""))
+
+	case "annotate":
+		if len(args) == 0 {
+			return m.setCommandNotice("Usage: :annotate name", true), nil
+		}
+		return m.annotate(strings.Join(args, " ")), nil
+
+	case "pin", "unpin":
+		if len(args) == 0 {
+			return m.setCommandNotice("Usage: :"+name+" mountpoint|interface", true), nil
+		}
+		return m.pin(strings.Join(args, " "), name == "pin")
+
+	default:
+		return m.setCommandNotice("Unknown command: "+name, true), nil
+	}
+}
+
+// switchProfile switches to the named profile and reports the outcome on
+// the notice line
+func (m MainModel) switchProfile(name string) (MainModel, tea.Cmd) {
+	m, err := m.SetProfile(name)
+	switch {
+	case err != nil:
+		return m.setCommandNotice(err.Error(), true), nil
+	case name == "":
+		return m.setCommandNotice("Using the config file without a profile", false), nil
+	default:
+		return m.setCommandNotice("Using the "+name+" profile", false), nil
+	}
+}
+
+// setCommandNotice shows the outcome of a command on the notice line
+func (m MainModel) setCommandNotice(text string, err bool) MainModel {
+	m.commandNotice = commandNotice{text: text, err: err, at: m.now()}
+	return m
+}
+
+// renderCommandNotice renders the outcome of the last command for a few
+// seconds after it ran
+func (m MainModel) renderCommandNotice(now time.Time) string {
+	notice := m.commandNotice
+	if notice.at.IsZero() || now.Sub(notice.at) > screenshotNoticeDuration {
+		return ""
+	}
+	if notice.err {
+		return m.styleManager.RenderCriticalText(notice.text)
+	}
+	return m.styleManager.RenderMutedText(notice.text)
+}
+
+// commandHelp renders the commands for the help screen
+func commandHelp() []string {
+	lines := []string{"Commands (type : to open the prompt):"}
+	for _, command := range paletteCommands {
+		lines = append(lines, fmt.Sprintf("  %-18s%s", command.usage, command.desc))
+	}
+	return lines
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeCommand opens the command prompt, types line and presses Enter
+func typeCommand(t *testing.T, model MainModel, line string) (MainModel, tea.Cmd) {
+	t.Helper()
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(":")})
+	model = updated.(MainModel)
+	if !model.commanding {
+		t.Fatal("Expected : to open the command prompt")
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(line)})
+	model = updated.(MainModel)
+	if footer := stripStyles(model.renderFooter()); !strings.Contains(footer, ":"+line) {
+		t.Errorf("Expected the prompt to show %q, got %q", line, footer)
+	}
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return updated.(MainModel), cmd
+}
+
+func TestMainModel_CommandInterval(t *testing.T) {
+	model, _ := typeCommand(t, NewMainModel(), "interval 500ms")
+	if model.commanding {
+		t.Error("Expected Enter to close the prompt")
+	}
+	if model.GetUpdateInterval() != 500*time.Millisecond {
+		t.Errorf("Expected a 500ms interval, got %v", model.GetUpdateInterval())
+	}
+	if notice := stripStyles(model.renderNotice(model.now())); notice != "Refreshing every 500ms" {
+		t.Errorf("Expected a confirmation, got %q", notice)
+	}
+
+	model, _ = typeCommand(t, model, "interval 1ms")
+	if model.GetUpdateInterval() != 500*time.Millisecond || !strings.Contains(model.renderNotice(model.now()), "Invalid interval") {
+		t.Error("Expected a too short interval to be rejected")
+	}
+}
+
+func TestMainModel_CommandFilterAndQuit(t *testing.T) {
+	model := NewMainModel()
+	model.focused = FocusNetwork
+	model, _ = typeCommand(t, model, "filter eth")
+	if filter, _ := model.panelFilter(FocusNetwork); filter != "eth" {
+		t.Errorf("Expected the network filter to be eth, got %q", filter)
+	}
+
+	model, _ = typeCommand(t, model, "frobnicate")
+	if notice := stripStyles(model.renderNotice(model.now())); notice != "Unknown command: frobnicate" {
+		t.Errorf("Expected an unknown command to be reported, got %q", notice)
+	}
+
+	if _, cmd := typeCommand(t, model, "q"); cmd == nil {
+		t.Fatal("Expected :q to quit")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected :q to quit")
+	}
+}
+
+func TestMainModel_CommandEscape(t *testing.T) {
+	updated, _ := NewMainModel().Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(":")})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("quit")})
+	updated, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model := updated.(MainModel); model.commanding || cmd != nil {
+		t.Error("Expected Escape to close the prompt without running the command")
+	}
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderCompact renders one line per metric for terminals too small for
+// panels: a title, gauges for CPU, memory, swap and the fullest filesystem,
+// network throughput, the hottest sensor and active alerts. Lines that don't
+// fit the height are left out.
+func (m MainModel) renderCompact() string {
+	width := m.width
+	title := m.styleManager.RenderHeader(truncate(m.headerTitle(), width))
+
+	var lines []string
+	if m.cpu.GetCores() > 0 {
+		lines = append(lines, m.styleManager.RenderGauge("CPU", m.cpu.GetTotal(), width))
+	}
+	if m.memory.GetTotal() > 0 {
+		lines = append(lines, m.styleManager.RenderGauge("Mem", m.memory.GetUsagePercent(), width))
+		if m.memory.GetSwap().Total > 0 {
+			lines = append(lines, m.styleManager.RenderGauge("Swap", m.memory.GetSwapUsagePercent(), width))
+		}
+	}
+	if fullest, ok := fullestFilesystem(m.disk.GetFilesystems()); ok {
+		lines = append(lines, m.styleManager.RenderGauge("Disk", fullest.UsedPercent, width))
+	}
+	if len(m.network.GetInterfaces()) > 0 {
+		lines = append(lines, truncate(fmt.Sprintf("Net  ↓%s ↑%s",
+			m.network.formatRate(m.network.GetTotalRecvRate()),
+			m.network.formatRate(m.network.GetTotalSendRate())), width))
+	}
+	if hottest, ok := m.sensors.GetHottest(); ok {
+		unit := m.sensors.GetUnit()
+		temperature := m.styleManager.Locale().FormatFloat(unit.FromCelsius(hottest.Temperature), 0)
+		line := truncate(fmt.Sprintf("Temp %s %s%s", hottest.Kind.String(), temperature, unit.Symbol()), width)
+		if m.sensors.IsOverThreshold(hottest) {
+			line = m.styleManager.RenderCriticalText(line)
+		}
+		lines = append(lines, line)
+	}
+	if m.alerts != nil && m.alerts.ActiveCount() > 0 {
+		lines = append(lines, m.styleManager.RenderCriticalText(truncate(fmt.Sprintf("%d active alerts", m.alerts.ActiveCount()), width)))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, m.styleManager.RenderMutedText("Collecting..."))
+	}
+
+	// The title and footer always take a line each
+	if rows := m.height - 2; len(lines) > rows {
+		lines = lines[:rows]
+	}
+
+	footer := m.styleManager.RenderApplicationFooter(FitHints(nil, []KeyHint{
+		NewKeyHint("quit", m.keys.Quit),
+		NewKeyHint("refresh", m.keys.Refresh),
+	}, width))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	content = lipgloss.PlaceVertical(m.height-2, lipgloss.Top, content)
+	return lipgloss.JoinVertical(lipgloss.Left, title, content, footer)
+}
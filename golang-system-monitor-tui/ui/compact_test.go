@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestMainModel_CompactMode(t *testing.T) {
+	model := NewMainModel()
+	msgs := []tea.Msg{
+		tea.WindowSizeMsg{Width: 40, Height: 12},
+		CPUUpdateMsg{Cores: 2, Usage: []float64{40, 60}, Total: 50},
+		MemoryUpdateMsg{Total: 1000, Used: 250, Available: 750, Swap: models.SwapInfo{Total: 100, Used: 10}},
+		DiskUpdateMsg{{Mountpoint: "/", Total: 100, Used: 92, UsedPercent: 92}},
+		NetworkUpdateMsg{{Interface: "eth0"}},
+	}
+	var updated tea.Model = model
+	for _, msg := range msgs {
+		updated, _ = updated.Update(msg)
+	}
+	model = updated.(MainModel)
+
+	view := model.View()
+	lines := strings.Split(view, "\n")
+	if len(lines) != 12 {
+		t.Errorf("Expected the compact view to fill 12 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if lipgloss.Width(line) > 40 {
+			t.Errorf("Line %d overflows 40 columns: %q", i, line)
+		}
+	}
+	for _, want := range []string{"CPU", "50%", "Mem", "Swap", "Disk", "92%", "Net  ↓0B/s ↑0B/s", "q: quit"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected compact view to contain %q", want)
+		}
+	}
+	if strings.Contains(view, "╭") || strings.Contains(view, "┌") {
+		t.Error("Expected no panel borders in compact mode")
+	}
+
+	// Gauges are dropped when the height runs out
+	updated, _ = model.Update(tea.WindowSizeMsg{Width: 40, Height: 5})
+	view = updated.(MainModel).View()
+	if strings.Contains(view, "Net") || !strings.Contains(view, "Mem") {
+		t.Errorf("Expected only the first gauges at height 5, got:\n%s", view)
+	}
+
+	// Below the absolute minimum only a message is shown
+	updated, _ = model.Update(tea.WindowSizeMsg{Width: 18, Height: 4})
+	view = updated.(MainModel).View()
+	if !strings.Contains(view, "Terminal too") || !strings.Contains(view, "18x4") {
+		t.Errorf("Expected a terminal too small message, got:\n%s", view)
+	}
+}
+
+func TestStyleManager_CompactThresholds(t *testing.T) {
+	sm := NewStyleManager()
+	tests := []struct {
+		width, height     int
+		compact, tooSmall bool
+	}{
+		{80, 24, false, false},
+		{50, 15, false, false},
+		{49, 24, true, false},
+		{80, 14, true, false},
+		{20, 5, true, false},
+		{19, 5, true, true},
+		{20, 4, true, true},
+	}
+	for _, test := range tests {
+		sm.SetDimensions(test.width, test.height)
+		if sm.IsCompactTerminal() != test.compact || sm.IsTooSmall() != test.tooSmall {
+			t.Errorf("Terminal %dx%d: expected compact=%v tooSmall=%v, got %v %v",
+				test.width, test.height, test.compact, test.tooSmall, sm.IsCompactTerminal(), sm.IsTooSmall())
+		}
+	}
+}
+
+func TestStyleManager_RenderGauge(t *testing.T) {
+	sm := NewStyleManager()
+	for _, width := range []int{20, 35, 49} {
+		gauge := sm.RenderGauge("CPU", 45, width)
+		if lipgloss.Width(gauge) != width {
+			t.Errorf("Expected gauge of width %d, got %d: %q", width, lipgloss.Width(gauge), gauge)
+		}
+		if !strings.Contains(gauge, "CPU ") || !strings.HasSuffix(gauge, " 45%") {
+			t.Errorf("Expected gauge label and percentage, got %q", gauge)
+		}
+	}
+}
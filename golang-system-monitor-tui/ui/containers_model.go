@@ -0,0 +1,429 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+)
+
+// ContainersUpdateMsg represents a containers update message
+type ContainersUpdateMsg []models.ContainerInfo
+
+// ContainerLogsMsg carries the log tail fetched for a container detail view
+type ContainerLogsMsg struct {
+	ID    string
+	Lines []string
+	Err   error
+}
+
+// containerRow is one line of the containers list, either a group header or a container
+type containerRow struct {
+	group     *models.ContainerGroup // Set for group header rows
+	container *models.ContainerInfo  // Set for container rows
+	nested    bool                   // Whether the container is listed under a group
+}
+
+// key identifies the row across updates
+func (r containerRow) key() string {
+	if r.group != nil {
+		return "group:" + r.group.Key()
+	}
+	return r.container.ID
+}
+
+// ContainersModel represents the containers monitoring component
+type ContainersModel struct {
+	containers   []models.ContainerInfo  // Current containers
+	groups       []models.ContainerGroup // Compose projects, swarm stacks and pods
+	standalone   []models.ContainerInfo  // Containers not belonging to any group
+	expanded     map[string]bool         // Expanded groups by key, groups start collapsed
+	rows         []containerRow          // Visible rows in display order
+	selected     int                     // Index of the selected row
+	showDetail   bool                    // Whether the detail view is open
+	logs         []string                // Log tail of the container shown in the detail view
+	logsFor      string                  // Container ID the logs belong to
+	logsError    string                  // Error fetching the log tail
+	lastUpdate   time.Time               // Last update timestamp
+	width        int                     // Component width for rendering
+	height       int                     // Component height for rendering
+	styleManager *StyleManager           // Style manager for consistent styling
+	hasError     bool                    // Whether the component has an error
+	errorMessage string                  // Current error message
+	lastError    time.Time               // Timestamp of last error
+	now          func() time.Time        // Clock for uptimes, frozen in deterministic render mode
+}
+
+// NewContainersModel creates a new containers model instance
+func NewContainersModel() ContainersModel {
+	return ContainersModel{
+		containers:   []models.ContainerInfo{},
+		expanded:     make(map[string]bool),
+		lastUpdate:   time.Now(),
+		width:        60,
+		height:       10,
+		styleManager: NewStyleManager(),
+		now:          time.Now,
+	}
+}
+
+// Init initializes the containers model
+func (m ContainersModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages and updates the containers model state
+func (m ContainersModel) Update(msg tea.Msg) (ContainersModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ContainersUpdateMsg:
+		// Clear any previous errors on successful update
+		m.hasError = false
+		m.errorMessage = ""
+
+		m.containers = []models.ContainerInfo(msg)
+		m.groups, m.standalone = models.GroupContainers(m.containers)
+		m = m.rebuildRows()
+		m.lastUpdate = time.Now()
+
+	case ContainerLogsMsg:
+		m.logsFor = msg.ID
+		m.logs = msg.Lines
+		m.logsError = ""
+		if msg.Err != nil {
+			m.logsError = msg.Err.Error()
+		}
+
+	case models.ErrorMsg:
+		// Handle error messages for Containers component
+		if msg.Component == "Containers" {
+			m.hasError = true
+			m.errorMessage = msg.Message
+			m.lastError = msg.Timestamp
+		}
+	}
+	return m, nil
+}
+
+// View renders the containers model
+func (m ContainersModel) View() string {
+	if m.showDetail {
+		if container, ok := m.Selected(); ok {
+			return m.renderDetail(container)
+		}
+	}
+
+	var sections []string
+
+	// Header
+	header := m.styleManager.RenderHeader("Containers")
+	sections = append(sections, header)
+
+	// Handle error state
+	if m.hasError {
+		sections = append(sections, m.styleManager.RenderErrorText("Error: "+m.errorMessage))
+		sections = append(sections, m.styleManager.RenderMutedText("Container runtime unavailable"))
+		for len(sections) < m.height {
+			sections = append(sections, "")
+		}
+		return strings.Join(sections, "\n")
+	}
+
+	// Handle loading state
+	if len(m.containers) == 0 {
+		return m.styleManager.RenderPlaceholder("Containers", "No containers found")
+	}
+
+	columns := fmt.Sprintf("  %-18s %-24s %6s %8s %-8s %-4s %s", "NAME", "IMAGE", "CPU", "MEM", "UPTIME", "RST", "HEALTH")
+	sections = append(sections, m.styleManager.RenderMutedText(columns))
+
+	now := m.now()
+	for i, row := range m.rows {
+		var line string
+		if row.group != nil {
+			line = m.renderGroupRow(*row.group)
+		} else {
+			line = m.renderContainerRow(*row.container, row.nested, now)
+		}
+
+		switch {
+		case i == m.selected:
+			sections = append(sections, m.styleManager.RenderHighlightText("▶ "+line))
+		case row.group != nil:
+			sections = append(sections, "  "+m.styleGroup(line, *row.group))
+		default:
+			sections = append(sections, "  "+m.styleByStatus(line, *row.container))
+		}
+	}
+
+	// Add spacing if we have fewer lines than available height
+	for len(sections) < m.height {
+		sections = append(sections, "")
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+// renderGroupRow renders a group header with aggregated totals of its containers
+func (m ContainersModel) renderGroupRow(group models.ContainerGroup) string {
+	marker := "▸"
+	if m.expanded[group.Key()] {
+		marker = "▾"
+	}
+
+	health := "-"
+	if unhealthy := group.Unhealthy(); unhealthy > 0 {
+		health = fmt.Sprintf("%d unhealthy", unhealthy)
+	}
+
+	return fmt.Sprintf("%s %s %6s %8s %-8s %-4d %s",
+		fit(marker+" "+group.Name, 18),
+		fit(fmt.Sprintf("%s, %d/%d running", group.Kind, group.Running(), len(group.Containers)), 24),
+		m.styleManager.Locale().FormatPercent(group.CPUPercent(), 1),
+		m.styleManager.FormatBytes(group.MemoryUsage()),
+		"",
+		group.RestartCount(),
+		health)
+}
+
+// renderContainerRow renders a single container, indented when listed under a group
+func (m ContainersModel) renderContainerRow(container models.ContainerInfo, nested bool, now time.Time) string {
+	name := container.Name
+	if nested {
+		name = "  " + name
+	}
+
+	cpu, memory := "-", "-"
+	if container.State == "running" {
+		cpu = m.styleManager.Locale().FormatPercent(container.CPUPercent, 1)
+		memory = m.styleManager.FormatBytes(container.MemoryUsage)
+	}
+
+	return fmt.Sprintf("%s %s %6s %8s %-8s %-4d %s",
+		fit(name, 18),
+		fit(container.ImageName()+":"+container.ImageTag(), 24),
+		cpu,
+		memory,
+		m.formatUptime(container, now),
+		container.RestartCount,
+		m.formatHealth(container))
+}
+
+// renderDetail renders metadata and the recent log tail of a single container
+func (m ContainersModel) renderDetail(container models.ContainerInfo) string {
+	var sections []string
+
+	sections = append(sections, m.styleManager.RenderHeader("Container "+container.Name))
+	sections = append(sections, fmt.Sprintf("Image:    %s", container.ImageName()))
+	sections = append(sections, fmt.Sprintf("Tag:      %s", container.ImageTag()))
+	sections = append(sections, fmt.Sprintf("State:    %s", container.State))
+	sections = append(sections, fmt.Sprintf("Uptime:   %s", m.formatUptime(container, m.now())))
+	sections = append(sections, fmt.Sprintf("Restarts: %d", container.RestartCount))
+	sections = append(sections, fmt.Sprintf("Health:   %s", m.formatHealth(container)))
+	sections = append(sections, "")
+	sections = append(sections, m.styleManager.RenderHighlightText("Recent logs"))
+
+	switch {
+	case m.logsFor != container.ID:
+		sections = append(sections, m.styleManager.RenderMutedText("Loading logs..."))
+	case m.logsError != "":
+		sections = append(sections, m.styleManager.RenderErrorText("Error: "+m.logsError))
+	case len(m.logs) == 0:
+		sections = append(sections, m.styleManager.RenderMutedText("No log output"))
+	default:
+		// Show as many of the most recent lines as fit
+		logs := m.logs
+		if available := m.height - len(sections); available > 0 && len(logs) > available {
+			logs = logs[len(logs)-available:]
+		}
+		for _, line := range logs {
+			sections = append(sections, truncate(line, m.width))
+		}
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+// formatUptime renders a compact uptime or the container state when not running
+func (m ContainersModel) formatUptime(container models.ContainerInfo, now time.Time) string {
+	uptime := container.Uptime(now)
+	if uptime == 0 {
+		return container.State
+	}
+
+	switch {
+	case uptime >= 24*time.Hour:
+		return fmt.Sprintf("%dd%dh", int(uptime.Hours())/24, int(uptime.Hours())%24)
+	case uptime >= time.Hour:
+		return fmt.Sprintf("%dh%dm", int(uptime.Hours()), int(uptime.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(uptime.Minutes()))
+	}
+}
+
+// formatHealth renders the health check status
+func (m ContainersModel) formatHealth(container models.ContainerInfo) string {
+	if container.Health == "" {
+		return "-"
+	}
+	return container.Health
+}
+
+// styleByStatus colors a container row by its state and health
+func (m ContainersModel) styleByStatus(line string, container models.ContainerInfo) string {
+	switch {
+	case container.Health == "unhealthy":
+		return m.styleManager.RenderCriticalText(line)
+	case container.State != "running" || container.Health == "starting":
+		return m.styleManager.RenderMutedText(line)
+	default:
+		return line
+	}
+}
+
+// styleGroup colors a group row by the state of its containers
+func (m ContainersModel) styleGroup(line string, group models.ContainerGroup) string {
+	switch {
+	case group.Unhealthy() > 0:
+		return m.styleManager.RenderCriticalText(line)
+	case group.Running() == 0:
+		return m.styleManager.RenderMutedText(line)
+	default:
+		return line
+	}
+}
+
+// SetSize sets the component dimensions
+func (m ContainersModel) SetSize(width, height int) ContainersModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m ContainersModel) SetStyleManager(styleManager *StyleManager) ContainersModel {
+	m.styleManager = styleManager
+	return m
+}
+
+// SetClock sets the clock used to compute container uptimes
+func (m ContainersModel) SetClock(now func() time.Time) ContainersModel {
+	m.now = now
+	return m
+}
+
+// rebuildRows lays out the visible rows, keeping the selection on the same row
+func (m ContainersModel) rebuildRows() ContainersModel {
+	selectedKey := ""
+	if m.selected >= 0 && m.selected < len(m.rows) {
+		selectedKey = m.rows[m.selected].key()
+	}
+
+	rows := make([]containerRow, 0, len(m.containers)+len(m.groups))
+	for i := range m.groups {
+		group := &m.groups[i]
+		rows = append(rows, containerRow{group: group})
+		if m.expanded[group.Key()] {
+			for j := range group.Containers {
+				rows = append(rows, containerRow{container: &group.Containers[j], nested: true})
+			}
+		}
+	}
+	for i := range m.standalone {
+		rows = append(rows, containerRow{container: &m.standalone[i]})
+	}
+	m.rows = rows
+
+	m.selected = 0
+	for i, row := range m.rows {
+		if row.key() == selectedKey {
+			m.selected = i
+			break
+		}
+	}
+	return m
+}
+
+// MoveSelection moves the selected row by delta rows
+func (m ContainersModel) MoveSelection(delta int) ContainersModel {
+	m.selected += delta
+	if m.selected >= len(m.rows) {
+		m.selected = len(m.rows) - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+	return m
+}
+
+// Selected returns the currently selected container, false when a group is selected
+func (m ContainersModel) Selected() (models.ContainerInfo, bool) {
+	if m.selected < 0 || m.selected >= len(m.rows) || m.rows[m.selected].container == nil {
+		return models.ContainerInfo{}, false
+	}
+	return *m.rows[m.selected].container, true
+}
+
+// SelectedGroup returns the currently selected group, false when a container is selected
+func (m ContainersModel) SelectedGroup() (models.ContainerGroup, bool) {
+	if m.selected < 0 || m.selected >= len(m.rows) || m.rows[m.selected].group == nil {
+		return models.ContainerGroup{}, false
+	}
+	return *m.rows[m.selected].group, true
+}
+
+// ToggleGroup collapses or expands the selected group
+func (m ContainersModel) ToggleGroup() ContainersModel {
+	group, ok := m.SelectedGroup()
+	if !ok {
+		return m
+	}
+
+	// Copy the map so earlier model values keep their own state
+	expanded := make(map[string]bool, len(m.expanded)+1)
+	for key, value := range m.expanded {
+		expanded[key] = value
+	}
+	expanded[group.Key()] = !expanded[group.Key()]
+	m.expanded = expanded
+
+	return m.rebuildRows()
+}
+
+// IsExpanded returns whether the group with the given key is expanded
+func (m ContainersModel) IsExpanded(key string) bool {
+	return m.expanded[key]
+}
+
+// SetShowDetail opens or closes the detail view of the selected container
+func (m ContainersModel) SetShowDetail(show bool) ContainersModel {
+	m.showDetail = show
+	if !show {
+		m.logs = nil
+		m.logsFor = ""
+		m.logsError = ""
+	}
+	return m
+}
+
+// IsShowingDetail returns whether the detail view is open
+func (m ContainersModel) IsShowingDetail() bool {
+	return m.showDetail
+}
+
+// GetGroups returns the current container groups
+func (m ContainersModel) GetGroups() []models.ContainerGroup {
+	return m.groups
+}
+
+// GetContainers returns the current containers
+func (m ContainersModel) GetContainers() []models.ContainerInfo {
+	return m.containers
+}
+
+// HasError returns whether the component has an error
+func (m ContainersModel) HasError() bool {
+	return m.hasError
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func testContainers() []models.ContainerInfo {
+	now := time.Now()
+	return []models.ContainerInfo{
+		{ID: "a1", Name: "web", Image: "nginx:1.25", State: "running", Health: "healthy", RestartCount: 2, StartedAt: now.Add(-3 * time.Hour)},
+		{ID: "b2", Name: "db", Image: "postgres:16", State: "running", Health: "unhealthy", StartedAt: now.Add(-26 * time.Hour)},
+		{ID: "c3", Name: "job", Image: "busybox", State: "exited"},
+	}
+}
+
+func TestNewContainersModel(t *testing.T) {
+	model := NewContainersModel()
+
+	if len(model.containers) != 0 {
+		t.Errorf("Expected no containers, got %v", model.containers)
+	}
+	if model.styleManager == nil {
+		t.Error("Expected style manager to be initialized")
+	}
+	if _, ok := model.Selected(); ok {
+		t.Error("Expected no selection without containers")
+	}
+}
+
+func TestContainersModel_View(t *testing.T) {
+	model := NewContainersModel().SetSize(80, 10)
+
+	if !strings.Contains(model.View(), "No containers found") {
+		t.Error("Expected placeholder without containers")
+	}
+
+	model, _ = model.Update(ContainersUpdateMsg(testContainers()))
+	view := model.View()
+
+	for _, want := range []string{"web", "nginx:1.25", "3h0m", "healthy", "postgres:16", "1d2h", "busybox:latest", "exited"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q, got: %s", want, view)
+		}
+	}
+}
+
+func TestContainersModel_Selection(t *testing.T) {
+	model := NewContainersModel()
+	model, _ = model.Update(ContainersUpdateMsg(testContainers()))
+
+	model = model.MoveSelection(1)
+	if selected, _ := model.Selected(); selected.Name != "db" {
+		t.Errorf("Expected db to be selected, got %s", selected.Name)
+	}
+
+	model = model.MoveSelection(10)
+	if selected, _ := model.Selected(); selected.Name != "job" {
+		t.Errorf("Expected selection clamped to last container, got %s", selected.Name)
+	}
+
+	model = model.MoveSelection(-10)
+	if selected, _ := model.Selected(); selected.Name != "web" {
+		t.Errorf("Expected selection clamped to first container, got %s", selected.Name)
+	}
+
+	// Selection follows the container when the list is reordered
+	model = model.MoveSelection(1)
+	reordered := testContainers()
+	reordered[0], reordered[1] = reordered[1], reordered[0]
+	model, _ = model.Update(ContainersUpdateMsg(reordered))
+	if selected, _ := model.Selected(); selected.Name != "db" {
+		t.Errorf("Expected selection to follow db, got %s", selected.Name)
+	}
+}
+
+func TestContainersModel_DetailView(t *testing.T) {
+	model := NewContainersModel().SetSize(80, 20)
+	model, _ = model.Update(ContainersUpdateMsg(testContainers()))
+	model = model.SetShowDetail(true)
+
+	view := model.View()
+	if !strings.Contains(view, "Container web") || !strings.Contains(view, "Restarts: 2") {
+		t.Errorf("Expected detail view for web, got: %s", view)
+	}
+	if !strings.Contains(view, "Loading logs...") {
+		t.Error("Expected loading message before logs arrive")
+	}
+
+	model, _ = model.Update(ContainerLogsMsg{ID: "a1", Lines: []string{"GET / 200", "GET /health 200"}})
+	view = model.View()
+	if !strings.Contains(view, "GET /health 200") {
+		t.Errorf("Expected log lines in detail view, got: %s", view)
+	}
+
+	model, _ = model.Update(ContainerLogsMsg{ID: "a1", Err: errors.New("no such container")})
+	if !strings.Contains(model.View(), "no such container") {
+		t.Error("Expected log error in detail view")
+	}
+
+	model = model.SetShowDetail(false)
+	if model.IsShowingDetail() || model.logs != nil {
+		t.Error("Expected detail state to reset when closed")
+	}
+}
+
+func TestContainersModel_Groups(t *testing.T) {
+	model := NewContainersModel().SetSize(100, 20)
+
+	containers := testContainers()
+	containers[0].Labels = map[string]string{models.LabelComposeProject: "shop"}
+	containers[0].CPUPercent, containers[0].MemoryUsage = 10, 64*1024*1024
+	containers[1].Labels = map[string]string{models.LabelComposeProject: "shop"}
+	containers[1].CPUPercent, containers[1].MemoryUsage = 5.5, 64*1024*1024
+	model, _ = model.Update(ContainersUpdateMsg(containers))
+
+	// Groups start collapsed and come before standalone containers
+	group, ok := model.SelectedGroup()
+	if !ok || group.Name != "shop" {
+		t.Fatalf("Expected shop group to be selected first, got %+v", group)
+	}
+	view := model.View()
+	if !strings.Contains(view, "▸ shop") || !strings.Contains(view, "15.5%") || !strings.Contains(view, "128.0MiB") {
+		t.Errorf("Expected collapsed group with aggregated totals, got: %s", view)
+	}
+	if strings.Contains(view, "nginx:1.25") {
+		t.Error("Expected group members to be hidden while collapsed")
+	}
+
+	model = model.ToggleGroup()
+	if !model.IsExpanded("compose/shop") || !strings.Contains(model.View(), "nginx:1.25") {
+		t.Error("Expected group members after expanding")
+	}
+	if _, ok := model.SelectedGroup(); !ok {
+		t.Error("Expected selection to stay on the group after expanding")
+	}
+
+	model = model.MoveSelection(1)
+	if selected, ok := model.Selected(); !ok || selected.Name != "web" {
+		t.Errorf("Expected web below the expanded group, got %+v", selected)
+	}
+
+	// Expansion state survives a refresh
+	model, _ = model.Update(ContainersUpdateMsg(containers))
+	if selected, _ := model.Selected(); selected.Name != "web" || !model.IsExpanded("compose/shop") {
+		t.Error("Expected selection and expansion to survive a refresh")
+	}
+
+	model = model.MoveSelection(-1).ToggleGroup()
+	if model.IsExpanded("compose/shop") || strings.Contains(model.View(), "nginx:1.25") {
+		t.Error("Expected group to collapse again")
+	}
+}
+
+func TestContainersModel_ErrorHandling(t *testing.T) {
+	model := NewContainersModel()
+	model, _ = model.Update(models.ErrorMsg{Component: "Containers", Message: "docker socket not found"})
+
+	if !model.HasError() {
+		t.Fatal("Expected error state")
+	}
+	if !strings.Contains(model.View(), "docker socket not found") {
+		t.Error("Expected error message in view")
+	}
+}
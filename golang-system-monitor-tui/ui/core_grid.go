@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// coreShades are the heatmap glyphs of a core from idle to fully busy; the
+// color carries the warning and critical levels on top
+var coreShades = []string{"░", "▒", "▓", "█"}
+
+// coreGridLayout returns how many cells fit on a heatmap row of width
+// columns after the label of the first core in the row, and the label width
+func coreGridLayout(cores, width int) (perRow, labelWidth int) {
+	labelWidth = len(fmt.Sprint(cores)) + 1
+	perRow = max((width-labelWidth)/2, 1)
+	return perRow, labelWidth
+}
+
+// coreGridHeight returns the rows of the heatmap of cores at width, the
+// line of the selected core below it not included
+func coreGridHeight(cores, width int) int {
+	perRow, _ := coreGridLayout(cores, width)
+	return (cores + perRow - 1) / perRow
+}
+
+// coreCell returns the glyph of a core at usage percent: a shade in the
+// default bar modes, and the tens digit in ASCII mode so the heatmap reads
+// without colors
+func coreCell(styleManager *StyleManager, usage float64) string {
+	if styleManager.barMode == BarASCII {
+		return fmt.Sprint(min(max(int(usage/10), 0), 9))
+	}
+	return coreShades[min(max(int(usage/25), 0), len(coreShades)-1)]
+}
+
+// renderCoreGrid renders the cores as a heatmap of width columns, a cell per
+// core colored by its usage, each row led by the number of its first core.
+// The selected core, -1 for none, is drawn reversed.
+func renderCoreGrid(styleManager *StyleManager, usage []float64, selected, width int) []string {
+	perRow, labelWidth := coreGridLayout(len(usage), width)
+	lines := make([]string, 0, coreGridHeight(len(usage), width))
+	for start := 0; start < len(usage); start += perRow {
+		var b strings.Builder
+		b.WriteString(styleManager.RenderMutedText(fmt.Sprintf("%*d ", labelWidth-1, start+1)))
+		end := min(start+perRow, len(usage))
+		for i := start; i < end; i++ {
+			style := lipgloss.NewStyle().Foreground(styleManager.GetUsageColor(usage[i]))
+			if i == selected {
+				style = style.Reverse(true)
+			}
+			b.WriteString(style.Render(coreCell(styleManager, usage[i])))
+			if i < end-1 {
+				b.WriteByte(' ')
+			}
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+// busiestCore returns the index of the core with the highest usage, -1
+// without cores
+func busiestCore(usage []float64) int {
+	busiest := -1
+	for i, value := range usage {
+		if busiest < 0 || value > usage[busiest] {
+			busiest = i
+		}
+	}
+	return busiest
+}
+
+// stepCore selects the core delta places away in the heatmap of the focused
+// CPU panel; the cores have no selection while they are a bar each
+func (m MainModel) stepCore(delta int) MainModel {
+	if m.focused == FocusCPU && m.cpu.ShowsCoreGrid() {
+		m.cpu = m.cpu.StepCore(delta)
+	}
+	return m
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/format"
+	"golang-system-monitor-tui/models"
+)
+
+// Smallest peaks the curves of the correlation view are scaled to, so an idle
+// CPU or a trickle of traffic stays flat instead of filling the graph
+const (
+	cpuCorrelationFloor  = 10.0      // Percent
+	rateCorrelationFloor = 64 * 1024 // Bytes per second
+)
+
+// correlationSeries is one curve of the correlation view
+type correlationSeries struct {
+	name   string
+	glyph  rune                   // Point of the curve; ASCII mode uses the first letter of the name
+	color  lipgloss.AdaptiveColor // Color of the points and the legend entry
+	values []float64              // Oldest first, one per sample
+	floor  float64                // Smallest peak the curve is scaled to
+	format func(float64) string   // Formats a value for the legend
+}
+
+// correlationSeries returns the curves of the correlation view from the
+// history rings of the CPU, Disk and Network panels
+func (m MainModel) correlationSeries() []correlationSeries {
+	locale := m.styleManager.Locale()
+	rate := func(value float64) string {
+		return strings.TrimSpace(format.Rate(value, m.network.GetRateUnit(), m.styleManager.Format()))
+	}
+	return []correlationSeries{
+		{name: "CPU", glyph: '●', color: m.styleManager.colors.Header, values: m.cpu.GetTotalHistory(), floor: cpuCorrelationFloor,
+			format: func(value float64) string { return locale.FormatPercent(value, 1) }},
+		{name: "Disk I/O", glyph: '■', color: m.styleManager.colors.Warning, values: m.disk.GetIOHistory(), floor: rateCorrelationFloor, format: rate},
+		{name: "Network", glyph: '▲', color: m.styleManager.colors.Normal, values: m.network.GetTotalRateHistory(), floor: rateCorrelationFloor, format: rate},
+	}
+}
+
+// renderCorrelationChart plots every series on one graph of width columns and
+// height rows, each scaled to its own peak so spikes line up whatever their
+// units. The newest samples are on the right; points of different series
+// landing in the same cell are drawn as one overlap marker.
+func renderCorrelationChart(styleManager *StyleManager, series []correlationSeries, width, height int) []string {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	const empty, overlap = -1, -2
+	grid := make([][]int, height)
+	for row := range grid {
+		grid[row] = make([]int, width)
+		for column := range grid[row] {
+			grid[row][column] = empty
+		}
+	}
+
+	for i, s := range series {
+		points := models.Resample(s.values, ioHistoryLength, width)
+		offset := width - len(points)
+		peak := s.floor
+		for _, value := range points {
+			peak = math.Max(peak, value)
+		}
+		for j, value := range points {
+			// Idle samples are left out so resting curves do not pile up on the bottom row
+			if value <= 0 {
+				continue
+			}
+			row := height - 1 - int(math.Round(value/peak*float64(height-1)))
+			column := offset + j
+			if grid[row][column] == empty {
+				grid[row][column] = i
+			} else if grid[row][column] != i {
+				grid[row][column] = overlap
+			}
+		}
+	}
+
+	overlapGlyph := "◆"
+	if styleManager.barMode == BarASCII {
+		overlapGlyph = "*"
+	}
+	lines := make([]string, height)
+	for row, cells := range grid {
+		var b strings.Builder
+		for _, cell := range cells {
+			switch cell {
+			case empty:
+				b.WriteByte(' ')
+			case overlap:
+				b.WriteString(lipgloss.NewStyle().Foreground(styleManager.colors.Text).Render(overlapGlyph))
+			default:
+				b.WriteString(lipgloss.NewStyle().Foreground(series[cell].color).Render(correlationGlyph(styleManager, series[cell])))
+			}
+		}
+		lines[row] = b.String()
+	}
+	return lines
+}
+
+// correlationGlyph returns the point glyph of a series
+func correlationGlyph(styleManager *StyleManager, s correlationSeries) string {
+	if styleManager.barMode == BarASCII {
+		return strings.ToLower(s.name[:1])
+	}
+	return string(s.glyph)
+}
+
+// renderCorrelationLegend renders a line per series with its glyph, latest
+// value and peak, e.g. "● CPU 42.0%, peak 80.0%"
+func renderCorrelationLegend(styleManager *StyleManager, series []correlationSeries, width int) []string {
+	lines := make([]string, 0, len(series))
+	for _, s := range series {
+		glyph := lipgloss.NewStyle().Foreground(s.color).Render(correlationGlyph(styleManager, s))
+		if len(s.values) == 0 {
+			lines = append(lines, glyph+" "+fmt.Sprintf("%-9s ", s.name)+styleManager.RenderMutedText("no data"))
+			continue
+		}
+		peak := s.values[0]
+		for _, value := range s.values {
+			peak = math.Max(peak, value)
+		}
+		text := fmt.Sprintf("%-9s %s, peak %s", s.name, s.format(s.values[len(s.values)-1]), s.format(peak))
+		lines = append(lines, glyph+" "+truncate(text, width-2))
+	}
+	return lines
+}
+
+// renderCorrelation renders the correlation view across the full screen
+func (m MainModel) renderCorrelation() string {
+	width := m.width - 4
+	height := m.height - 6
+	series := m.correlationSeries()
+
+	contentWidth := width - 2
+	legend := renderCorrelationLegend(m.styleManager, series, contentWidth)
+	lines := []string{
+		m.styleManager.RenderHeader("Correlation"),
+		m.styleManager.RenderMutedText(truncate(fmt.Sprintf("Each curve is scaled to its own peak over the last %d samples", ioHistoryLength), contentWidth)),
+	}
+	lines = append(lines, renderCorrelationChart(m.styleManager, series, contentWidth, height-len(lines)-len(legend))...)
+	lines = append(lines, legend...)
+
+	header := m.styleManager.RenderApplicationHeader(m.headerTitle())
+	panel := m.styleManager.ForPanel("correlation").RenderComponentBorder(strings.Join(lines, "\n"), true, width, height)
+	footer := m.renderFooter()
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderNotice(m.now()), panel, m.renderStatusBar(m.now()), footer)
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenderCorrelationChart(t *testing.T) {
+	sm := NewStyleManager()
+	series := []correlationSeries{
+		{name: "CPU", glyph: 'c', values: []float64{10, 50, 100}, floor: 10},
+		{name: "Network", glyph: 'n', values: []float64{0, 4e6, 8e6}, floor: 1},
+	}
+	// A column per sample of the history, the newest on the right
+	lines := renderCorrelationChart(sm, series, ioHistoryLength, 3)
+	got := make([]string, len(lines))
+	for i, line := range lines {
+		got[i] = strings.TrimPrefix(stripStyles(line), strings.Repeat(" ", ioHistoryLength-3))
+	}
+	// Both curves peak in the last column and meet there; the idle network sample is left out
+	if want := []string{"  ◆", " ◆ ", "c  "}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("renderCorrelationChart() = %q, want %q", got, want)
+	}
+
+	sm.SetBarMode(BarASCII)
+	lines = renderCorrelationChart(sm, series[:1], ioHistoryLength, 3)
+	if got := strings.TrimSpace(stripStyles(lines[0])); got != "c" {
+		t.Errorf("Expected ASCII points named after the series, got %q", got)
+	}
+}
+
+func TestMainModel_Correlation(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model = updated.(MainModel)
+	for i := 0; i < 5; i++ {
+		at := DeterministicTime.Add(time.Duration(i) * time.Second)
+		for _, msg := range []tea.Msg{
+			CPUUpdateMsg{Usage: []float64{float64(20 * i)}, Total: float64(20 * i), Cores: 1, Timestamp: at},
+			DiskIOUpdateMsg{ReadRate: float64(i) * 1024 * 1024, Timestamp: at},
+		} {
+			updated, _ = model.Update(msg)
+			model = updated.(MainModel)
+		}
+	}
+	if got := model.disk.GetIOHistory(); len(got) != 5 || got[4] != 4*1024*1024 {
+		t.Errorf("Expected the disk throughput history, got %v", got)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	model = updated.(MainModel)
+	view := stripStyles(model.View())
+	for _, want := range []string{"Correlation", "CPU       80.0%, peak 80.0%", "Disk I/O  4.0MiB/s, peak 4.0MiB/s", "Network   no data", "g: back"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the correlation view, got:\n%s", want, view)
+		}
+	}
+	if !strings.Contains(view, "◆") {
+		t.Errorf("Expected the CPU and disk peaks to meet, got:\n%s", view)
+	}
+
+	if opened, err := NewMainModel().OpenPage("correlation"); err != nil || !opened.showCorrelation {
+		t.Errorf("Expected -page correlation to open the view, got %v", err)
+	}
+}
+
+func TestNetworkModel_TotalRateHistory(t *testing.T) {
+	model := NewNetworkModel()
+	for i := 0; i < 3; i++ {
+		at := DeterministicTime.Add(time.Duration(i) * time.Second)
+		model, _ = model.Update(NetworkUpdateMsg{
+			{Interface: "eth0", BytesSent: uint64(i) * 1000, BytesRecv: uint64(i) * 3000, Timestamp: at},
+			{Interface: "wlan0", BytesSent: uint64(i) * 500, Timestamp: at},
+		})
+	}
+	if got := model.GetTotalRateHistory(); len(got) != 2 || got[1] != 4500 {
+		t.Errorf("Expected the combined rate of all interfaces, got %v", got)
+	}
+}
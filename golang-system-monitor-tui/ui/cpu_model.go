@@ -13,12 +13,28 @@ import (
 // CPUUpdateMsg represents a CPU update message
 type CPUUpdateMsg models.CPUInfo
 
+// TopProcessesMsg represents the heaviest CPU consumers from the latest process sample
+type TopProcessesMsg []models.ProcessInfo
+
+// PowerUpdateMsg carries a sample of the CPU clusters and power draw on macOS
+type PowerUpdateMsg models.CPUPower
+
 // CPUModel represents the CPU monitoring component
 type CPUModel struct {
 	usage    []float64    // Current per-core usage
-	history  [][]float64  // Historical data for graphs (last 60 seconds)
+	history  []models.Ring[float64] // Historical data for graphs (last 60 seconds)
+	totalHistory models.Ring[float64] // Historical overall usage for the trend graph
+	longHistory models.TieredHistory // Overall usage over the last day at decreasing resolution
+	timeRange time.Duration // Window of the trend graph, 0 for the last maxHistory samples
+	annotations []models.Annotation // Marks on the timeline, shown on the trend graph
 	total    float64      // Overall CPU usage
+	totalStats models.RunningStats // Session average and peak of the overall usage
+	baseline models.Baseline // Usual overall usage, learned from the recent samples
+	deviation float64     // Standard deviations of the overall usage from the baseline before it
+	anomalySigma float64  // Deviation from which the usage is marked unusual, 0 to never mark it
 	cores    int          // Number of CPU cores
+	limit    float64      // cgroup CPU quota in cores, 0 without a limit
+	times    models.CPUTimes // Overall time split into user, system, iowait, steal and interrupts
 	maxHistory int        // Maximum history entries to keep
 	lastUpdate time.Time  // Last update timestamp
 	width    int          // Component width for rendering
@@ -27,20 +43,32 @@ type CPUModel struct {
 	hasError bool         // Whether the component has an error
 	errorMessage string   // Current error message
 	lastError time.Time   // Timestamp of last error
+	retryIn time.Duration // Time until the failing collector is retried
+	topProcesses []models.ProcessInfo // Heaviest CPU consumers from the last process sample
+	power    models.CPUPower // Core clusters and power draw, on Apple Silicon only
+	expanded bool         // Whether the panel fills the screen and shows the clusters
+	selectedCore int      // Core detailed below the heatmap, -1 to follow the busiest
+	version  uint64       // Changes with every change of the rendered state
+	cache    *viewCache   // Last rendered view, shared by copies of the model
 }
 
 // NewCPUModel creates a new CPU model instance
 func NewCPUModel() CPUModel {
 	return CPUModel{
 		usage:        []float64{},
-		history:      [][]float64{},
+		history:      []models.Ring[float64]{},
 		total:        0.0,
 		cores:        0,
 		maxHistory:   60, // Keep 60 seconds of history
+		anomalySigma: models.DefaultAnomalySigma,
+		timeRange:    graphRanges[0],
+		selectedCore: -1,
 		lastUpdate:   time.Now(),
 		width:        40,
 		height:       10,
 		styleManager: NewStyleManager(),
+		version:      nextRenderVersion(),
+		cache:        newViewCache(),
 	}
 }
 
@@ -51,6 +79,7 @@ func (m CPUModel) Init() tea.Cmd {
 
 // Update handles messages and updates the CPU model state
 func (m CPUModel) Update(msg tea.Msg) (CPUModel, tea.Cmd) {
+	m.version = nextRenderVersion()
 	switch msg := msg.(type) {
 	case CPUUpdateMsg:
 		// Clear any previous errors on successful update
@@ -59,32 +88,66 @@ func (m CPUModel) Update(msg tea.Msg) (CPUModel, tea.Cmd) {
 		
 		// Update current usage data
 		m.usage = msg.Usage
+		if m.selectedCore >= len(m.usage) {
+			m.selectedCore = -1
+		}
 		m.total = msg.Total
 		m.cores = msg.Cores
+		m.limit = msg.Limit
+		m.times = msg.Times
 		m.lastUpdate = msg.Timestamp
+		if m.cores > 0 {
+			m.totalStats = m.totalStats.Add(m.total)
+			m.deviation = m.baseline.Deviation(m.total, cpuBaselineFloor)
+			m.baseline = m.baseline.Add(m.total)
+		}
 
 		// Add current usage to history
 		if len(m.usage) > 0 {
-			// Initialize history if needed
-			if len(m.history) == 0 {
-				m.history = make([][]float64, len(m.usage))
+			// Initialize history if needed; history restored from a run on
+			// another number of cores is dropped
+			if len(m.history) != len(m.usage) {
+				m.history = make([]models.Ring[float64], len(m.usage))
 				for i := range m.history {
-					m.history[i] = make([]float64, 0, m.maxHistory)
+					m.history[i] = models.NewRing[float64](m.maxHistory)
 				}
 			}
+			if m.totalHistory.Cap() == 0 {
+				m.totalHistory = models.NewRing[float64](m.maxHistory)
+			}
+			if m.longHistory.Span() == 0 {
+				m.longHistory = models.NewTieredHistory(models.DefaultTiers)
+			}
+			m.longHistory = m.longHistory.Push(m.lastUpdate, m.total)
 
-			// Add current usage to each core's history
+			// Add overall usage to the trend, keeping as much as the per-core history
+			m.totalHistory = m.totalHistory.Push(m.total)
+
+			// Add current usage to each core's history; the rings keep the
+			// last maxHistory entries. Push to a copy of the ring headers,
+			// earlier models share them.
+			history := make([]models.Ring[float64], len(m.history))
+			copy(history, m.history)
 			for i, usage := range m.usage {
-				if i < len(m.history) {
-					m.history[i] = append(m.history[i], usage)
-					// Keep only the last maxHistory entries
-					if len(m.history[i]) > m.maxHistory {
-						m.history[i] = m.history[i][1:]
-					}
+				if i < len(history) {
+					history[i] = history[i].Push(usage)
 				}
 			}
+			m.history = history
 		}
 		
+	case TopProcessesMsg:
+		m.topProcesses = []models.ProcessInfo(msg)
+
+	case PowerUpdateMsg:
+		// A failed sample keeps the last clusters on screen below the error
+		if msg.Error != "" {
+			m.power.Error = msg.Error
+			m.power.Timestamp = msg.Timestamp
+		} else {
+			m.power = models.CPUPower(msg)
+		}
+
 	case models.ErrorMsg:
 		// Handle error messages for CPU component
 		if msg.Component == "CPU" {
@@ -98,26 +161,27 @@ func (m CPUModel) Update(msg tea.Msg) (CPUModel, tea.Cmd) {
 
 // View renders the CPU model
 func (m CPUModel) View() string {
-	var sections []string
-	
-	// Header
-	header := m.styleManager.RenderHeader("CPU Usage")
-	sections = append(sections, header)
+	return m.cache.render(m.version, m.styleManager, m.render)
+}
 
+// render draws the view of the current state
+func (m CPUModel) render() string {
+	buf := getRenderBuffer()
+	defer buf.release()
+	sections := buf.lines
+	
 	// Handle error state
 	if m.hasError {
+		sections = append(sections, m.renderTitle())
 		sections = append(sections, m.styleManager.RenderErrorText("Error: "+m.errorMessage))
-		sections = append(sections, m.styleManager.RenderMutedText("CPU data unavailable"))
+		sections = append(sections, renderUnavailable(m.styleManager, "CPU data unavailable", m.retryIn))
 		
 		// Show fallback display with N/A values
 		sections = append(sections, "Total: N/A")
 		sections = append(sections, "Cores: N/A")
 		
 		// Add spacing
-		for len(sections) < m.height {
-			sections = append(sections, "")
-		}
-		return strings.Join(sections, "\n")
+		return buf.join(sections, m.height)
 	}
 
 	// Handle loading state
@@ -126,37 +190,200 @@ func (m CPUModel) View() string {
 	}
 
 	// Normal display
-	// Total CPU usage
-	barWidth := m.styleManager.GetProgressBarWidth(m.width, 8) // "Total: " = 7 chars + space
-	totalBar := m.styleManager.RenderProgressBar(m.total, barWidth, false)
-	totalLine := fmt.Sprintf("Total: %s %.1f%%", totalBar, m.total)
-	sections = append(sections, totalLine)
+	sections = append(sections, m.renderTop()...)
+
+	// Cores that do not fit a line each are shown as a heatmap with a line
+	// for the selected core below it
+	grid := m.showsCoreGrid(len(sections))
+	coreLines := len(m.usage)
+	if grid {
+		coreLines = coreGridHeight(len(m.usage), m.width) + 1
+	}
+
+	// Trend of the overall usage over the time range, when there is room for
+	// it below the cores, then the session average and peak and the trend's
+	// time axis as far as there is room for them too
+	showTrend := m.totalHistory.Len() > 1 && len(sections)+coreLines+2 <= m.height
+	reserved := 0
+	if showTrend {
+		reserved = 1
+	}
+	// What the time went on, as a bar split by kind and its legend
+	if m.times.Known() && len(sections)+coreLines+reserved+3 <= m.height {
+		segments := cpuTimeSegments(m.styleManager, m.times)
+		barWidth := m.styleManager.GetProgressBarWidth(m.width, 8)
+		sections = append(sections, "Split: "+renderSegmentedBar(m.styleManager, segments, barWidth))
+		sections = append(sections, "       "+renderSegmentLegend(m.styleManager, segments, m.width-7))
+	}
+	if m.totalStats.Count > 1 && len(sections)+coreLines+reserved+2 <= m.height {
+		sections = append(sections, m.styleManager.RenderMutedText("       "+formatPercentStats(m.styleManager.Locale(), m.totalStats)))
+	}
+	if showTrend {
+		graphWidth := m.styleManager.GetProgressBarWidth(m.width, 8)
+		trend := m.totalHistory.Values()
+		if m.timeRange > 0 {
+			trend = models.Resample(m.longHistory.Window(m.timeRange), m.longHistory.Points(m.timeRange), m.styleManager.graphPoints(graphWidth))
+		}
+		marks, marked := annotationCells(m.annotations, m.lastUpdate, m.timeRange, graphWidth)
+		sections = append(sections, "Trend: "+m.styleManager.RenderMarkedGraph(trend, graphWidth, marks))
+		if m.timeRange > 0 && len(sections)+coreLines+2 <= m.height {
+			sections = append(sections, m.styleManager.RenderMutedText("       "+renderTimeAxis(m.timeRange, graphWidth)))
+		}
+		if len(marked) > 0 && len(sections)+coreLines+2 <= m.height {
+			sections = append(sections, m.styleManager.RenderMutedText("       "+renderAnnotationLabels(m.styleManager.Locale(), marked, m.width-7)))
+		}
+	}
+
+	// Top CPU consumers so a spike can be attributed at a glance
+	if topLine := m.renderTopProcesses(); topLine != "" {
+		sections = append(sections, m.styleManager.RenderMutedText(topLine))
+	}
+
+	// Efficiency and performance clusters, when zoomed on Apple Silicon
+	if m.expanded {
+		sections = append(sections, m.renderPower()...)
+	}
 
 	// Per-core usage
-	for i, usage := range m.usage {
-		barWidth := m.styleManager.GetProgressBarWidth(m.width, 10) // "Core X: " = ~9 chars + space
-		coreBar := m.styleManager.RenderProgressBar(usage, barWidth, false)
-		coreLine := fmt.Sprintf("Core %d: %s %.1f%%", i+1, coreBar, usage)
-		sections = append(sections, coreLine)
+	if grid {
+		sections = append(sections, renderCoreGrid(m.styleManager, m.usage, m.GetSelectedCore(), m.width)...)
+		sections = append(sections, m.renderCoreLine(m.GetSelectedCore()))
+	} else {
+		for i := range m.usage {
+			sections = append(sections, m.renderCoreLine(i))
+		}
 	}
 
 	// Add spacing if we have fewer cores than available height
-	for len(sections) < m.height {
-		sections = append(sections, "")
+	return buf.join(sections, m.height)
+}
+
+// renderCoreLine renders the usage bar of a core
+func (m CPUModel) renderCoreLine(core int) string {
+	usage := m.usage[core]
+	barWidth := m.styleManager.GetProgressBarWidth(m.width, 10) // "Core X: " = ~9 chars + space
+	coreBar := m.styleManager.RenderProgressBar(usage, barWidth, false)
+	return fmt.Sprintf("Core %d: %s %s", core+1, coreBar, m.styleManager.Locale().FormatPercent(usage, 1))
+}
+
+// renderTitle renders the header of the panel
+func (m CPUModel) renderTitle() string {
+	return m.styleManager.RenderHeader("CPU Usage") + renderUnusualMarker(m.styleManager, "", m.deviation, m.anomalySigma)
+}
+
+// renderTop renders the lines always shown above the cores: the header, the
+// total and the usage against the container's CPU limit when there is one
+func (m CPUModel) renderTop() []string {
+	barWidth := m.styleManager.GetProgressBarWidth(m.width, 8) // "Total: " = 7 chars + space
+	totalBar := m.styleManager.RenderProgressBar(m.total, barWidth, false)
+	lines := []string{m.renderTitle(), fmt.Sprintf("Total: %s %s", totalBar, m.styleManager.Locale().FormatPercent(m.total, 1))}
+
+	// Usage against the container's CPU quota, which caps it before the host's cores
+	if m.limit > 0 {
+		limitPercent := min(m.total*float64(m.cores)/m.limit, 100)
+		limitBar := m.styleManager.RenderProgressBar(limitPercent, barWidth, false)
+		locale := m.styleManager.Locale()
+		lines = append(lines, fmt.Sprintf("Limit: %s %s", limitBar, locale.FormatPercent(limitPercent, 1)))
+		lines = append(lines, m.styleManager.RenderMutedText(fmt.Sprintf("       %s of %d cores", locale.FormatFloat(m.limit, 1), m.cores)))
 	}
+	return lines
+}
 
-	return strings.Join(sections, "\n")
+// showsCoreGrid reports whether the cores are shown as a heatmap, because
+// a line each does not fit below the top lines of the panel
+func (m CPUModel) showsCoreGrid(top int) bool {
+	return top+len(m.usage) > m.height
 }
 
+// renderTopProcesses builds a single summary line of the busiest processes
+func (m CPUModel) renderTopProcesses() string {
+	var parts []string
+	for _, proc := range m.topProcesses {
+		// Skip idle processes, the first sample after startup reports 0% for everything
+		if proc.CPUPercent <= 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", proc.Name, m.styleManager.Locale().FormatPercent(proc.CPUPercent, 1)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
 
+	return truncate("Top: "+strings.Join(parts, " • "), m.width)
+}
+
+// renderPower renders a gauge per core cluster and the power draw, or nothing
+// before the first sample
+func (m CPUModel) renderPower() []string {
+	var lines []string
+	locale := m.styleManager.Locale()
+	if len(m.power.Clusters) > 0 {
+		var efficiency, performance int
+		for _, cluster := range m.power.Clusters {
+			if cluster.IsEfficiency() {
+				efficiency += cluster.Cores
+			} else {
+				performance += cluster.Cores
+			}
+		}
+		lines = append(lines, m.styleManager.RenderMutedText(fmt.Sprintf("Clusters: %d efficiency, %d performance cores", efficiency, performance)))
+	}
+	for _, cluster := range m.power.Clusters {
+		label := fmt.Sprintf("%s×%d:", cluster.Name, cluster.Cores)
+		barWidth := m.styleManager.GetProgressBarWidth(m.width, 19) // label and " @ 3504 MHz"
+		bar := m.styleManager.RenderProgressBar(cluster.Active, barWidth, false)
+		lines = append(lines, fmt.Sprintf("%-6s %s %s @ %s MHz", label, bar, locale.FormatPercent(cluster.Active, 1), locale.FormatFloat(cluster.Frequency, 0)))
+	}
+	if !m.power.Timestamp.IsZero() && m.power.Error == "" {
+		parts := []string{"CPU " + m.formatWatts(m.power.CPUWatts), "GPU " + m.formatWatts(m.power.GPUWatts), "ANE " + m.formatWatts(m.power.ANEWatts)}
+		if m.power.PackageWatts > 0 {
+			parts = append(parts, "Package "+m.formatWatts(m.power.PackageWatts))
+		}
+		lines = append(lines, m.styleManager.RenderMutedText(truncate("Power: "+strings.Join(parts, " • "), m.width)))
+	}
+	if m.power.Error != "" {
+		lines = append(lines, m.styleManager.RenderErrorText(truncate("Power: "+m.power.Error, m.width)))
+	}
+	return lines
+}
+
+// formatWatts formats a power reading in mW below a watt
+func (m CPUModel) formatWatts(watts float64) string {
+	if watts < 1 {
+		return fmt.Sprintf("%.0f mW", watts*1000)
+	}
+	return m.styleManager.Locale().FormatFloat(watts, 2) + " W"
+}
 
 // SetSize sets the component dimensions
 func (m CPUModel) SetSize(width, height int) CPUModel {
+	if width != m.width || height != m.height {
+		m.version = nextRenderVersion()
+	}
 	m.width = width
 	m.height = height
 	return m
 }
 
+// SetRetryIn sets the time until the failing collector is retried, shown
+// below the error
+func (m CPUModel) SetRetryIn(retryIn time.Duration) CPUModel {
+	if retryIn != m.retryIn {
+		m.version = nextRenderVersion()
+	}
+	m.retryIn = retryIn
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m CPUModel) SetStyleManager(styleManager *StyleManager) CPUModel {
+	if styleManager != m.styleManager {
+		m.version = nextRenderVersion()
+	}
+	m.styleManager = styleManager
+	return m
+}
+
 // GetUsage returns the current CPU usage data
 func (m CPUModel) GetUsage() []float64 {
 	return m.usage
@@ -167,9 +394,169 @@ func (m CPUModel) GetTotal() float64 {
 	return m.total
 }
 
-// GetHistory returns the historical usage data
+// GetTimes returns the split of the overall CPU time, zero when the collector
+// does not split it
+func (m CPUModel) GetTimes() models.CPUTimes {
+	return m.times
+}
+
+// ShowsCoreGrid reports whether the cores are shown as a heatmap rather than
+// a bar each
+func (m CPUModel) ShowsCoreGrid() bool {
+	if m.cores == 0 || m.hasError {
+		return false
+	}
+	return m.showsCoreGrid(len(m.renderTop()))
+}
+
+// GetSelectedCore returns the index of the core detailed below the heatmap:
+// the selected one, or the busiest without a selection
+func (m CPUModel) GetSelectedCore() int {
+	if m.selectedCore >= 0 {
+		return m.selectedCore
+	}
+	return busiestCore(m.usage)
+}
+
+// StepCore selects the core delta places after the detailed one in the
+// heatmap, wrapping around at both ends
+func (m CPUModel) StepCore(delta int) CPUModel {
+	if len(m.usage) == 0 {
+		return m
+	}
+	m.version = nextRenderVersion()
+	core := (m.GetSelectedCore() + delta) % len(m.usage)
+	if core < 0 {
+		core += len(m.usage)
+	}
+	m.selectedCore = core
+	return m
+}
+
+// ClearSelectedCore goes back to detailing the busiest core
+func (m CPUModel) ClearSelectedCore() CPUModel {
+	m.version = nextRenderVersion()
+	m.selectedCore = -1
+	return m
+}
+
+// GetHistory returns a copy of the historical usage data per core, oldest first
 func (m CPUModel) GetHistory() [][]float64 {
-	return m.history
+	history := make([][]float64, len(m.history))
+	for i, ring := range m.history {
+		history[i] = ring.Values()
+	}
+	return history
+}
+
+// GetTotalHistory returns a copy of the historical overall usage, oldest first
+func (m CPUModel) GetTotalHistory() []float64 {
+	return m.totalHistory.Values()
+}
+
+// RestoreHistory replaces the usage history with total and per-core series
+// saved by an earlier run, oldest first, keeping the newest values that fit.
+// The long history is restored from its saved tiers; records saved without
+// them seed it from the total series, taken every step until end.
+func (m CPUModel) RestoreHistory(total []float64, cores [][]float64, long []models.TierSnapshot, end time.Time, step time.Duration) CPUModel {
+	m.version = nextRenderVersion()
+	m.totalHistory = models.NewRing[float64](m.maxHistory)
+	m.longHistory = models.RestoreTieredHistory(models.DefaultTiers, long)
+	for i, usage := range total {
+		m.totalHistory = m.totalHistory.Push(usage)
+		if len(long) == 0 {
+			m.longHistory = m.longHistory.Push(end.Add(-time.Duration(len(total)-1-i)*step), usage)
+		}
+	}
+	m.history = make([]models.Ring[float64], len(cores))
+	for i, series := range cores {
+		m.history[i] = models.NewRing[float64](m.maxHistory)
+		for _, usage := range series {
+			m.history[i] = m.history[i].Push(usage)
+		}
+	}
+	return m
+}
+
+// SetTimeRange sets the window of the trend graph; 0 shows the last samples
+// regardless of when they were taken
+func (m CPUModel) SetTimeRange(timeRange time.Duration) CPUModel {
+	if timeRange != m.timeRange {
+		m.version = nextRenderVersion()
+	}
+	m.timeRange = timeRange
+	return m
+}
+
+// SetAnnotations sets the marks on the timeline shown on the trend graph
+func (m CPUModel) SetAnnotations(annotations []models.Annotation) CPUModel {
+	m.version = nextRenderVersion()
+	m.annotations = annotations
+	return m
+}
+
+// GetTimeRange returns the window of the trend graph
+func (m CPUModel) GetTimeRange() time.Duration {
+	return m.timeRange
+}
+
+// GetLongHistory returns the overall usage over the last span, oldest first,
+// at the finest resolution kept that long
+func (m CPUModel) GetLongHistory(span time.Duration) []float64 {
+	return m.longHistory.Window(span)
+}
+
+// GetLongHistorySnapshot returns the tiers of the long history, for saving
+func (m CPUModel) GetLongHistorySnapshot() []models.TierSnapshot {
+	return m.longHistory.Snapshot()
+}
+
+// SetAnomalySigma sets the deviation from the baseline, in standard
+// deviations, from which the overall usage is marked unusual; 0 never marks it
+func (m CPUModel) SetAnomalySigma(sigma float64) CPUModel {
+	m.version = nextRenderVersion()
+	m.anomalySigma = sigma
+	return m
+}
+
+// GetDeviation returns how many standard deviations the overall usage is
+// from its baseline, 0 while the baseline is learned
+func (m CPUModel) GetDeviation() float64 {
+	return m.deviation
+}
+
+// IsUnusual reports whether the overall usage is marked unusual
+func (m CPUModel) IsUnusual() bool {
+	return models.Unusual(m.deviation, m.anomalySigma)
+}
+
+// GetTotalStats returns the session average and peak of the overall usage
+func (m CPUModel) GetTotalStats() models.RunningStats {
+	return m.totalStats
+}
+
+// GetTopProcesses returns the most recent top CPU consumers
+func (m CPUModel) GetTopProcesses() []models.ProcessInfo {
+	return m.topProcesses
+}
+
+// SetExpanded shows the core clusters and power draw, for the zoomed panel
+func (m CPUModel) SetExpanded(expanded bool) CPUModel {
+	if expanded != m.expanded {
+		m.version = nextRenderVersion()
+	}
+	m.expanded = expanded
+	return m
+}
+
+// GetPower returns the last sample of the core clusters and power draw
+func (m CPUModel) GetPower() models.CPUPower {
+	return m.power
+}
+
+// GetLimit returns the cgroup CPU quota in cores, 0 without a limit
+func (m CPUModel) GetLimit() float64 {
+	return m.limit
 }
 
 // GetCores returns the number of CPU cores
@@ -189,6 +576,7 @@ func (m CPUModel) GetErrorMessage() string {
 
 // ClearError clears the current error state
 func (m CPUModel) ClearError() CPUModel {
+	m.version = nextRenderVersion()
 	m.hasError = false
 	m.errorMessage = ""
 	return m
@@ -196,6 +584,7 @@ func (m CPUModel) ClearError() CPUModel {
 
 // SetError sets an error state for the component
 func (m CPUModel) SetError(message string) CPUModel {
+	m.version = nextRenderVersion()
 	m.hasError = true
 	m.errorMessage = message
 	m.lastError = time.Now()
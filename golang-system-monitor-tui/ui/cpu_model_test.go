@@ -104,12 +104,12 @@ func TestCPUModel_Update_HistoryTracking(t *testing.T) {
 		t.Errorf("Expected history to have 2 cores, got %d", len(model.history))
 	}
 
-	if len(model.history[0]) != 1 || model.history[0][0] != 30.0 {
-		t.Errorf("Expected first core history to contain [30.0], got %v", model.history[0])
+	if model.history[0].Len() != 1 || model.history[0].At(0) != 30.0 {
+		t.Errorf("Expected first core history to contain [30.0], got %v", model.history[0].Values())
 	}
 
-	if len(model.history[1]) != 1 || model.history[1][0] != 40.0 {
-		t.Errorf("Expected second core history to contain [40.0], got %v", model.history[1])
+	if model.history[1].Len() != 1 || model.history[1].At(0) != 40.0 {
+		t.Errorf("Expected second core history to contain [40.0], got %v", model.history[1].Values())
 	}
 
 	// Second update
@@ -122,14 +122,14 @@ func TestCPUModel_Update_HistoryTracking(t *testing.T) {
 	model, _ = model.Update(CPUUpdateMsg(cpuInfo2))
 
 	// Check history accumulation
-	if len(model.history[0]) != 2 {
-		t.Errorf("Expected first core history to have 2 entries, got %d", len(model.history[0]))
+	if model.history[0].Len() != 2 {
+		t.Errorf("Expected first core history to have 2 entries, got %d", model.history[0].Len())
 	}
 
 	expectedHistory0 := []float64{30.0, 35.0}
 	for i, expected := range expectedHistory0 {
-		if model.history[0][i] != expected {
-			t.Errorf("Expected history[0][%d] to be %f, got %f", i, expected, model.history[0][i])
+		if model.history[0].At(i) != expected {
+			t.Errorf("Expected history[0][%d] to be %f, got %f", i, expected, model.history[0].At(i))
 		}
 	}
 }
@@ -150,15 +150,15 @@ func TestCPUModel_Update_HistoryLimit(t *testing.T) {
 	}
 
 	// Check that history is limited
-	if len(model.history[0]) != 3 {
-		t.Errorf("Expected history to be limited to 3 entries, got %d", len(model.history[0]))
+	if model.history[0].Len() != 3 {
+		t.Errorf("Expected history to be limited to 3 entries, got %d", model.history[0].Len())
 	}
 
 	// Check that we kept the most recent entries
 	expectedHistory := []float64{20.0, 30.0, 40.0}
 	for i, expected := range expectedHistory {
-		if model.history[0][i] != expected {
-			t.Errorf("Expected history[0][%d] to be %f, got %f", i, expected, model.history[0][i])
+		if model.history[0].At(i) != expected {
+			t.Errorf("Expected history[0][%d] to be %f, got %f", i, expected, model.history[0].At(i))
 		}
 	}
 }
@@ -237,6 +237,26 @@ func TestCPUModel_View_WithData(t *testing.T) {
 	}
 }
 
+func TestCPUModel_View_CgroupLimit(t *testing.T) {
+	model := NewCPUModel().SetSize(40, 10)
+	model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{
+		Cores:     8,
+		Usage:     []float64{10, 10, 10, 10, 10, 10, 10, 10},
+		Total:     10,
+		Limit:     2,
+		Timestamp: time.Now(),
+	}))
+	if model.GetLimit() != 2 {
+		t.Fatalf("Expected a limit of 2 cores, got %v", model.GetLimit())
+	}
+
+	// 10% of 8 cores is 0.8 cores, 40% of the quota
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "Limit:") || !strings.Contains(view, "40.0%") || !strings.Contains(view, "2.0 of 8 cores") {
+		t.Errorf("Expected the usage against the CPU quota, got:\n%s", view)
+	}
+}
+
 func TestCPUModel_StyleManagerIntegration(t *testing.T) {
 	model := NewCPUModel()
 
@@ -531,4 +551,331 @@ func TestCPUModel_ErrorHandling_ViewWithErrorAndData(t *testing.T) {
 	if strings.Contains(view, "61.9%") {
 		t.Error("Expected view to not show actual CPU percentage when in error state")
 	}
-}
\ No newline at end of file
+}
+
+func TestCPUModel_Times(t *testing.T) {
+	sm := NewStyleManager()
+	sm.SetBarMode(BarASCII)
+	model := NewCPUModel().SetStyleManager(sm).SetSize(60, 10)
+	model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{
+		Cores:     1,
+		Usage:     []float64{70},
+		Total:     70,
+		Times:     models.CPUTimes{User: 40, System: 10, IOWait: 10, Steal: 10, IRQ: 5},
+		Timestamp: time.Now(),
+	}))
+	if model.GetTimes().Steal != 10 {
+		t.Errorf("Expected the time split to be kept, got %+v", model.GetTimes())
+	}
+
+	view := stripStyles(model.View())
+	// 42 bar cells, rounded on the running total: 17 user, 4 system, 4 iowait, 4 steal and 3 interrupts
+	if !strings.Contains(view, "Split: uuuuuuuuuuuuuuuuusssswwwwttttiii----------") {
+		t.Errorf("Expected the split bar, got:\n%s", view)
+	}
+	if !strings.Contains(view, "u=usr 40% s=sys 10% w=io 10% t=st 10% i=irq 5%") {
+		t.Errorf("Expected the split legend, got:\n%s", view)
+	}
+
+	// Collectors that cannot split the time leave the lines out
+	model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{Cores: 1, Usage: []float64{70}, Total: 70, Timestamp: time.Now()}))
+	if view := stripStyles(model.View()); strings.Contains(view, "Split:") {
+		t.Errorf("Expected no split without times, got:\n%s", view)
+	}
+}
+
+func TestCPUModel_CoreGrid(t *testing.T) {
+	sm := NewStyleManager()
+	sm.SetBarMode(BarASCII)
+	usage := make([]float64, 32)
+	for i := range usage {
+		usage[i] = float64(i * 3)
+	}
+	model := NewCPUModel().SetStyleManager(sm).SetSize(40, 10)
+	model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{Cores: 32, Usage: usage, Total: 46.5, Timestamp: time.Now()}))
+	if !model.ShowsCoreGrid() {
+		t.Fatal("Expected 32 cores in 10 lines to be a heatmap")
+	}
+
+	// 18 cells fit a row of 40 columns after the 3-column label
+	view := stripStyles(model.View())
+	for _, want := range []string{
+		" 1 0 0 0 0 1 1 1 2 2 2 3 3 3 3 4 4 4 5",
+		"19 5 5 6 6 6 6 7 7 7 8 8 8 9 9",
+		"Core 32:",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the heatmap, got:\n%s", want, view)
+		}
+	}
+
+	// Without a selection the busiest core is detailed; stepping wraps around
+	if model.GetSelectedCore() != 31 {
+		t.Errorf("Expected the busiest core to be detailed, got %d", model.GetSelectedCore())
+	}
+	model = model.StepCore(1)
+	if model.GetSelectedCore() != 0 || !strings.Contains(stripStyles(model.View()), "Core 1:") {
+		t.Errorf("Expected the first core after the last, got %d", model.GetSelectedCore())
+	}
+	if model = model.ClearSelectedCore(); model.GetSelectedCore() != 31 {
+		t.Errorf("Expected the busiest core again, got %d", model.GetSelectedCore())
+	}
+
+	// A core each when they fit
+	if model.SetSize(40, 40).ShowsCoreGrid() {
+		t.Error("Expected a bar per core when they fit")
+	}
+
+	// The limit lines count towards the top of the panel in both decisions
+	limited, _ := NewCPUModel().SetStyleManager(sm).Update(CPUUpdateMsg(models.CPUInfo{Cores: 6, Usage: usage[:6], Total: 46.5, Limit: 2, Timestamp: time.Now()}))
+	for _, height := range []int{9, 10} {
+		model := limited.SetSize(40, height)
+		lines := strings.Count(stripStyles(model.View()), "Core ")
+		if grid := model.ShowsCoreGrid(); grid != (lines == 1) {
+			t.Errorf("Height %d: ShowsCoreGrid is %v but the view has %d core lines", height, grid, lines)
+		}
+	}
+	if limited.SetSize(40, 9).ShowsCoreGrid() == limited.SetSize(40, 10).ShowsCoreGrid() {
+		t.Error("Expected the heatmap at 9 lines and a bar per core at 10")
+	}
+}
+
+func TestCPUModel_TopProcesses(t *testing.T) {
+	model := NewCPUModel()
+	model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{
+		Cores:     1,
+		Usage:     []float64{80.0},
+		Total:     80.0,
+		Timestamp: time.Now(),
+	}))
+
+	processes := []models.ProcessInfo{
+		{PID: 100, Name: "postgres", CPUPercent: 55.5},
+		{PID: 200, Name: "nginx", CPUPercent: 12.0},
+		{PID: 300, Name: "idle", CPUPercent: 0},
+	}
+	model, cmd := model.Update(TopProcessesMsg(processes))
+	if cmd != nil {
+		t.Errorf("Expected Update() to return nil cmd, got %v", cmd)
+	}
+
+	if len(model.GetTopProcesses()) != 3 {
+		t.Fatalf("Expected 3 top processes, got %d", len(model.GetTopProcesses()))
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "Top:") {
+		t.Errorf("Expected view to contain top processes line, got: %s", view)
+	}
+	if !strings.Contains(view, "postgres 55.5%") {
+		t.Errorf("Expected view to contain busiest process, got: %s", view)
+	}
+	if strings.Contains(view, "idle") {
+		t.Errorf("Expected idle processes to be omitted, got: %s", view)
+	}
+}
+
+func TestCPUModel_TopProcesses_AllIdle(t *testing.T) {
+	model := NewCPUModel()
+	model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{
+		Cores:     1,
+		Usage:     []float64{1.0},
+		Total:     1.0,
+		Timestamp: time.Now(),
+	}))
+	model, _ = model.Update(TopProcessesMsg([]models.ProcessInfo{{PID: 1, Name: "init", CPUPercent: 0}}))
+
+	if strings.Contains(model.View(), "Top:") {
+		t.Error("Expected no top processes line when every process is idle")
+	}
+}
+
+func TestCPUModel_Trend(t *testing.T) {
+	model := NewCPUModel().SetSize(40, 6)
+	update := func(total float64) {
+		model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{
+			Cores:     2,
+			Usage:     []float64{total, total},
+			Total:     total,
+			Timestamp: time.Now(),
+		}))
+	}
+
+	update(20)
+	if strings.Contains(model.View(), "Trend:") {
+		t.Error("Expected no trend before a second sample")
+	}
+
+	update(80)
+	if len(model.GetTotalHistory()) != 2 {
+		t.Errorf("Expected 2 trend samples, got %d", len(model.GetTotalHistory()))
+	}
+	if !strings.Contains(model.View(), "Trend:") {
+		t.Error("Expected a trend line once history exists")
+	}
+
+	// The cores take priority when the panel is short
+	model = model.SetSize(40, 4)
+	if strings.Contains(model.View(), "Trend:") {
+		t.Error("Expected the trend to be dropped when the cores need the space")
+	}
+}
+
+func TestCPUModel_Power(t *testing.T) {
+	model := NewCPUModel().SetSize(60, 20)
+	model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{Cores: 4, Usage: []float64{10, 20, 30, 40}, Total: 25, Timestamp: time.Now()}))
+	model, _ = model.Update(PowerUpdateMsg(models.CPUPower{
+		Clusters: []models.CPUCluster{
+			{Name: "E", Cores: 2, Frequency: 1035, Active: 20.5},
+			{Name: "P0", Cores: 2, Frequency: 3228, Active: 60},
+		},
+		CPUWatts:     0.095,
+		GPUWatts:     0.006,
+		PackageWatts: 1.25,
+		Timestamp:    time.Now(),
+	}))
+
+	// The clusters are only shown when the panel is zoomed
+	if view := stripStyles(model.View()); strings.Contains(view, "Clusters") {
+		t.Errorf("Expected no clusters in the grid, got:\n%s", view)
+	}
+	view := stripStyles(model.SetExpanded(true).View())
+	for _, want := range []string{"Clusters: 2 efficiency, 2 performance cores", "E×2:", "20.5% @ 1035 MHz", "P0×2:", "Power: CPU 95 mW • GPU 6 mW • ANE 0 mW • Package 1.25 W"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the expanded view, got:\n%s", want, view)
+		}
+	}
+
+	// A failed sample keeps the clusters below the error
+	model, _ = model.Update(PowerUpdateMsg(models.CPUPower{Error: "powermetrics must be invoked as the superuser", Timestamp: time.Now()}))
+	view = stripStyles(model.SetExpanded(true).View())
+	if !strings.Contains(view, "Power: powermetrics must be invoked as the superuser") || !strings.Contains(view, "E×2:") {
+		t.Errorf("Expected the error with the last clusters, got:\n%s", view)
+	}
+	if len(model.GetPower().Clusters) != 2 {
+		t.Errorf("Expected the clusters to be kept, got %+v", model.GetPower())
+	}
+}
+
+func BenchmarkCPUModel_View(b *testing.B) {
+	model := NewCPUModel().SetSize(60, 20)
+	model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{
+		Cores:     8,
+		Usage:     []float64{25.5, 30.2, 45.8, 60.1, 15.3, 80.7, 35.4, 50.9},
+		Total:     42.7,
+		Timestamp: time.Now(),
+	}))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		model.render() // View would return the cached frame
+	}
+}
+
+func TestCPUModel_RestoreHistory(t *testing.T) {
+	total := make([]float64, 70)
+	for i := range total {
+		total[i] = float64(i)
+	}
+	model := NewCPUModel().RestoreHistory(total, [][]float64{{1, 2}, {3, 4}, {5, 6}}, nil, DeterministicTime, time.Second)
+
+	if got := model.GetTotalHistory(); len(got) != 60 || got[0] != 10 || got[59] != 69 {
+		t.Errorf("Expected the newest 60 values kept, got %d from %v", len(got), got[0])
+	}
+	if got := model.GetHistory(); len(got) != 3 {
+		t.Errorf("Expected 3 restored cores, got %d", len(got))
+	}
+
+	// A machine with another number of cores starts the core history afresh
+	model, _ = model.Update(CPUUpdateMsg{Usage: []float64{50, 60}, Total: 55, Cores: 2})
+	history := model.GetHistory()
+	if len(history) != 2 || len(history[0]) != 1 || history[1][0] != 60 {
+		t.Errorf("Expected fresh history for 2 cores, got %v", history)
+	}
+	if got := model.GetTotalHistory(); len(got) != 60 || got[59] != 55 {
+		t.Errorf("Expected the total history to continue, got %v", got[len(got)-1])
+	}
+}
+
+func TestCPUModel_LongTrend(t *testing.T) {
+	start := DeterministicTime
+	model := NewCPUModel().SetSize(60, 12)
+	for i := 0; i < 30*60; i++ {
+		total := 10.0
+		if i < 15*60 {
+			total = 90
+		}
+		model, _ = model.Update(CPUUpdateMsg{Usage: []float64{total}, Total: total, Cores: 1, Timestamp: start.Add(time.Duration(i) * time.Second)})
+	}
+
+	if got := len(model.GetLongHistory(time.Minute)); got != 60 {
+		t.Errorf("Expected 60 one-second samples for the last minute, got %d", got)
+	}
+	if got := model.GetLongHistory(2 * time.Hour); len(got) != 180 || got[0] != 90 || got[179] != 10 {
+		t.Errorf("Expected 180 ten-second averages spanning the load drop, got %d", len(got))
+	}
+
+	// The trend spans the time range, with its axis below
+	model = model.SetTimeRange(2 * time.Hour)
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "-2h") || !strings.Contains(view, "now") {
+		t.Errorf("Expected the time axis below the trend, got:\n%s", view)
+	}
+	recent := stripStyles(model.SetTimeRange(0).View())
+	if recent == view || strings.Contains(recent, "now") {
+		t.Errorf("Expected the last samples without an axis, got:\n%s", recent)
+	}
+
+	// The axis is the first line to go when the panel is short
+	if view := stripStyles(model.SetSize(60, 5).View()); !strings.Contains(view, "Trend:") || strings.Contains(view, "now") {
+		t.Errorf("Expected the trend without its axis, got:\n%s", view)
+	}
+}
+
+func TestCPUModel_SessionStats(t *testing.T) {
+	model := NewCPUModel().SetSize(60, 12)
+	for i, total := range []float64{40, 10, 97, 25} {
+		model, _ = model.Update(CPUUpdateMsg{Usage: []float64{total}, Total: total, Cores: 1, Timestamp: DeterministicTime.Add(time.Duration(i) * time.Second)})
+	}
+
+	stats := model.GetTotalStats()
+	if stats.Count != 4 || stats.Mean != 43 || stats.Max != 97 {
+		t.Errorf("Expected 4 samples averaging 43%% with a peak of 97%%, got %+v", stats)
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "avg 43.0%, max 97.0%") {
+		t.Errorf("Expected the average and peak below the total, got:\n%s", view)
+	}
+
+	// The trend keeps its place when there is room for only one of them
+	view := stripStyles(model.SetSize(60, 5).View())
+	if !strings.Contains(view, "Trend:") || strings.Contains(view, "avg") {
+		t.Errorf("Expected the trend without the statistics, got:\n%s", view)
+	}
+}
+
+func TestCPUModel_Unusual(t *testing.T) {
+	model := NewCPUModel().SetSize(60, 12)
+	update := func(total float64) {
+		model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{Cores: 1, Usage: []float64{total}, Total: total, Timestamp: time.Now()}))
+	}
+	// A machine idling around 10%
+	for i := 0; i < models.BaselineWarmup; i++ {
+		update(float64(8 + i%5))
+	}
+	if model.IsUnusual() || strings.Contains(model.View(), "unusual") {
+		t.Fatal("Expected the usual load not to be marked")
+	}
+
+	// 40% is far beyond the 5-point floor of an idle machine
+	update(40)
+	if !model.IsUnusual() || model.GetDeviation() < 5 {
+		t.Errorf("Expected 40%% to be unusual, got %.1fσ", model.GetDeviation())
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "CPU Usage unusual +") {
+		t.Errorf("Expected the unusual marker in the header, got:\n%s", view)
+	}
+	if model.SetAnomalySigma(0).IsUnusual() {
+		t.Error("Expected no marker with the detection off")
+	}
+}
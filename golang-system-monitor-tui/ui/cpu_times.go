@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/models"
+)
+
+// barSegment is one part of a segmented bar
+type barSegment struct {
+	label   string                 // Name in the legend
+	percent float64                // Share of the bar
+	color   lipgloss.AdaptiveColor // Color of the part and its legend entry
+	ascii   string                 // Glyph of the part in ASCII mode, where colors may be missing
+}
+
+// cpuTimeSegments returns the parts of the CPU time split, in the order they
+// fill the bar
+func cpuTimeSegments(styleManager *StyleManager, times models.CPUTimes) []barSegment {
+	colors := styleManager.colors
+	return []barSegment{
+		{label: "usr", percent: times.User, color: colors.Normal, ascii: "u"},
+		{label: "sys", percent: times.System, color: colors.Header, ascii: "s"},
+		{label: "io", percent: times.IOWait, color: colors.Warning, ascii: "w"},
+		{label: "st", percent: times.Steal, color: colors.Critical, ascii: "t"},
+		{label: "irq", percent: times.IRQ, color: colors.Muted, ascii: "i"},
+	}
+}
+
+// renderSegmentedBar renders a bar of width cells filled by the segments one
+// after the other, each in its color. Cell counts are rounded on the running
+// total, so the bar fills as far as the segments add up to.
+func renderSegmentedBar(styleManager *StyleManager, segments []barSegment, width int) string {
+	filled := styleManager.barFilled
+	empty := styleManager.barEmpty
+	if styleManager.barMode == BarASCII {
+		empty = "-"
+	}
+
+	var b strings.Builder
+	sum, cells := 0.0, 0
+	for _, segment := range segments {
+		sum += max(segment.percent, 0)
+		end := min(int(math.Round(sum/100*float64(width))), width)
+		if end <= cells {
+			continue
+		}
+		glyph := filled
+		if styleManager.barMode == BarASCII {
+			glyph = segment.ascii
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(segment.color).Render(strings.Repeat(glyph, end-cells)))
+		cells = end
+	}
+	if cells < width {
+		b.WriteString(lipgloss.NewStyle().Foreground(styleManager.colors.Muted).Render(strings.Repeat(empty, width-cells)))
+	}
+	return b.String()
+}
+
+// renderSegmentLegend renders the label and share of every segment in its
+// color, e.g. "usr 42% sys 9% io 3% st 0% irq 1%", dropping the last ones
+// that do not fit in width
+func renderSegmentLegend(styleManager *StyleManager, segments []barSegment, width int) string {
+	locale := styleManager.Locale()
+	var parts []string
+	used := 0
+	for _, segment := range segments {
+		text := segment.label + " " + locale.FormatPercent(segment.percent, 0)
+		if styleManager.barMode == BarASCII {
+			text = segment.ascii + "=" + text
+		}
+		if used > 0 && used+1+lipgloss.Width(text) > width {
+			break
+		}
+		if used > 0 {
+			used++
+		}
+		used += lipgloss.Width(text)
+		parts = append(parts, lipgloss.NewStyle().Foreground(segment.color).Render(text))
+	}
+	return strings.Join(parts, " ")
+}
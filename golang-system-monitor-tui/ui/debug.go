@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"golang-system-monitor-tui/format"
+)
+
+// ToggleDebugMsg shows or hides the debug overlay, for a running instance
+// without keyboard access
+type ToggleDebugMsg struct{}
+
+// debugOverlayWidth is the outer width of the debug overlay
+const debugOverlayWidth = 40
+
+// debugStats collects the timings shown in the debug overlay. Collectors
+// report their durations from their goroutines, so it is safe for concurrent use.
+type debugStats struct {
+	mu         sync.Mutex
+	durations  map[string]time.Duration // Duration of the last collection per collector
+	lastTick   time.Time                // When the previous tick fired
+	drift      time.Duration            // How much later than the interval the last tick fired
+	dropped    int                      // Ticks missed because cycles took too long
+	mallocs    uint64                   // Heap allocation counters at the last tick,
+	totalAlloc uint64                   // zero when not measured
+	allocs     uint64                   // Heap allocations during the last cycle
+	allocBytes uint64                   // Bytes allocated during the last cycle
+}
+
+// newDebugStats creates empty debug statistics
+func newDebugStats() *debugStats {
+	return &debugStats{durations: make(map[string]time.Duration)}
+}
+
+// recordCollect records how long a collection took
+func (d *debugStats) recordCollect(name string, duration time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.durations[name] = duration
+}
+
+// recordTick records a tick that fired at t. Allocations are only measured
+// when asked, reading the memory statistics briefly stops the world.
+func (d *debugStats) recordTick(t time.Time, interval time.Duration, measureAllocs bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.lastTick.IsZero() {
+		gap := t.Sub(d.lastTick)
+		d.drift = gap - interval
+		// A cycle that took longer than an interval and a half skipped ticks
+		if interval > 0 && gap > interval*3/2 {
+			d.dropped += int(gap/interval) - 1
+		}
+	}
+	d.lastTick = t
+
+	if !measureAllocs {
+		d.mallocs, d.totalAlloc = 0, 0
+		return
+	}
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	if d.mallocs > 0 {
+		d.allocs = stats.Mallocs - d.mallocs
+		d.allocBytes = stats.TotalAlloc - d.totalAlloc
+	}
+	d.mallocs, d.totalAlloc = stats.Mallocs, stats.TotalAlloc
+}
+
+// lines renders the statistics, the collectors in the given order
+func (d *debugStats) lines(collectors []string) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	lines := []string{
+		fmt.Sprintf("Tick drift    %+v", d.drift.Round(time.Millisecond)),
+		fmt.Sprintf("Dropped ticks %d", d.dropped),
+		fmt.Sprintf("Goroutines    %d", runtime.NumGoroutine()),
+		fmt.Sprintf("Allocs/cycle  %d (%s)", d.allocs, format.Bytes(d.allocBytes, format.DefaultOptions())),
+		"Collectors:",
+	}
+	for _, name := range collectors {
+		duration, ok := d.durations[name]
+		value := "-"
+		if ok {
+			value = formatDebugDuration(duration)
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s", fit(name, 14), value))
+	}
+	return lines
+}
+
+// formatDebugDuration formats a collection duration with a precision that fits it
+func formatDebugDuration(d time.Duration) string {
+	switch {
+	case d < time.Millisecond:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	case d < time.Second:
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	default:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	}
+}
+
+// renderDebugOverlay draws the debug statistics in a box over the top right
+// corner of view, below the header
+func (m MainModel) renderDebugOverlay(view string) string {
+	var names []string
+	for _, collector := range m.registry.Collectors() {
+		names = append(names, collector.Name())
+	}
+	lines := append([]string{m.styleManager.RenderHeader("Debug (F12)")}, m.debug.lines(names)...)
+	box := m.styleManager.RenderComponentBorder(strings.Join(lines, "\n"), true, debugOverlayWidth-2, len(lines))
+
+	x := max(m.width-debugOverlayWidth-1, 0)
+	return overlay(view, box, x, 1)
+}
+
+// overlay draws box over base with its top left corner at column x and row y.
+// Lines of box beyond the end of base are dropped.
+func overlay(base, box string, x, y int) string {
+	baseLines := strings.Split(base, "\n")
+	for i, line := range strings.Split(box, "\n") {
+		row := y + i
+		if row >= len(baseLines) {
+			break
+		}
+		under := baseLines[row]
+		left := ansi.Truncate(under, x, "")
+		if width := ansi.StringWidth(left); width < x {
+			left += strings.Repeat(" ", x-width)
+		}
+		right := ansi.TruncateLeft(under, x+ansi.StringWidth(line), "")
+		if strings.Contains(under, "\x1b") {
+			// Keep the styles of the view from bleeding into the box and back
+			left += ansi.ResetStyle
+			line += ansi.ResetStyle
+		}
+		baseLines[row] = left + line + right
+	}
+	return strings.Join(baseLines, "\n")
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestDebugStats_RecordTick(t *testing.T) {
+	stats := newDebugStats()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	stats.recordTick(start, time.Second, false)
+	stats.recordTick(start.Add(1100*time.Millisecond), time.Second, false)
+	if stats.drift != 100*time.Millisecond || stats.dropped != 0 {
+		t.Errorf("Expected 100ms drift and no dropped ticks, got %v and %d", stats.drift, stats.dropped)
+	}
+
+	// A cycle of three and a half seconds skipped two ticks
+	stats.recordTick(start.Add(4600*time.Millisecond), time.Second, false)
+	if stats.dropped != 2 {
+		t.Errorf("Expected 2 dropped ticks, got %d", stats.dropped)
+	}
+}
+
+func TestDebugStats_Lines(t *testing.T) {
+	stats := newDebugStats()
+	stats.recordCollect("CPU", 1500*time.Microsecond)
+	stats.recordCollect("Memory", 200*time.Microsecond)
+
+	lines := strings.Join(stats.lines([]string{"CPU", "Memory", "Disk"}), "\n")
+	for _, want := range []string{"Goroutines", "CPU            1.5ms", "Memory         200µs", "Disk           -"} {
+		if !strings.Contains(lines, want) {
+			t.Errorf("Expected %q in:\n%s", want, lines)
+		}
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	base := "header\n0123456789\nab\n0123456789"
+	box := "XX\nYY\nZZ\nWW"
+
+	got := overlay(base, box, 4, 1)
+	want := "header\n0123XX6789\nab  YY\n0123ZZ6789"
+	if got != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, got)
+	}
+
+	// Styled lines keep their width
+	styled := overlay("\x1b[31m0123456789\x1b[0m", "XX", 4, 0)
+	if width := ansi.StringWidth(styled); width != 10 {
+		t.Errorf("Expected width 10, got %d: %q", width, styled)
+	}
+}
+
+func TestMainModel_DebugOverlay(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MainModel)
+
+	if strings.Contains(stripStyles(model.View()), "Debug (F12)") {
+		t.Fatal("Expected no debug overlay by default")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyF12})
+	model = updated.(MainModel)
+	for _, msg := range cmdMsgs(model.collectAllDataCmd()) {
+		updated, _ = model.Update(msg)
+		model = updated.(MainModel)
+	}
+	view := stripStyles(model.View())
+	for _, want := range []string{"Debug (F12)", "Tick drift", "Collectors:", "CPU", "Memory"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the overlay, got:\n%s", want, view)
+		}
+	}
+	for i, line := range strings.Split(view, "\n") {
+		if width := ansi.StringWidth(line); width > 120 {
+			t.Errorf("Line %d is %d columns wide", i, width)
+		}
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyF12})
+	model = updated.(MainModel)
+	if strings.Contains(stripStyles(model.View()), "Debug (F12)") {
+		t.Error("Expected F12 to hide the overlay")
+	}
+}
+
+func TestMainModel_ToggleDebugMsg(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	updated, _ = updated.(MainModel).Update(ToggleDebugMsg{})
+	if !strings.Contains(stripStyles(updated.(MainModel).View()), "Debug (F12)") {
+		t.Fatal("Expected the message to show the overlay")
+	}
+	updated, _ = updated.(MainModel).Update(ToggleDebugMsg{})
+	if strings.Contains(stripStyles(updated.(MainModel).View()), "Debug (F12)") {
+		t.Error("Expected the message to hide the overlay again")
+	}
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+)
+
+// CollectFailedMsg carries the error of a failed collection, so the failing
+// collector can be backed off
+type CollectFailedMsg struct {
+	Name string
+	Err  error
+}
+
+// Error implements error, failed collections are recorded as errors
+func (msg CollectFailedMsg) Error() string {
+	return msg.Err.Error()
+}
+
+// collectDueCmd creates a command running the registered collectors that are
+// not backed off after failures, delivering their results as one snapshot
+func (m MainModel) collectDueCmd() tea.Cmd {
+	now := m.now()
+	var cmds []tea.Cmd
+	for _, collector := range m.registry.Collectors() {
+		if m.degradation.Due(collector.Name(), now) {
+			cmds = append(cmds, m.collectCmd(collector))
+		}
+	}
+	return snapshotCmd(cmds, m.adaptive.Interval(m.updateInterval))
+}
+
+// handleCollectFailed backs off the failing collector and shows the error in
+// the panel of its component
+func (m MainModel) handleCollectFailed(msg CollectFailedMsg) (MainModel, tea.Cmd) {
+	probe := m.degradation.State(msg.Name) != models.CircuitClosed
+	delay := m.degradation.Failure(msg.Name, m.now())
+	switch {
+	case probe:
+		// The panel already shows why the paused collector fails
+		return m, nil
+	case m.degradation.State(msg.Name) == models.CircuitOpen:
+		log.Printf("Collector %s failed %d times in a row, pausing it for %v: %v", msg.Name, m.degradation.Failures(msg.Name), delay, msg.Err)
+	case m.degradation.Failures(msg.Name) == 1:
+		log.Printf("Collector %s failed, retrying in %v: %v", msg.Name, delay, msg.Err)
+	}
+
+	sysErr, ok := msg.Err.(models.SystemError)
+	if !ok {
+		sysErr = models.CreateSystemError(models.DataCollectionError, msg.Name, msg.Err.Error(), msg.Err)
+	}
+	return m.forwardError(models.ErrorMsg(sysErr))
+}
+
+// applyRetries tells the panels of failing collectors when they are retried
+func (m MainModel) applyRetries(now time.Time) MainModel {
+	m.cpu = m.cpu.SetRetryIn(m.degradation.RetryIn("CPU", now))
+	m.memory = m.memory.SetRetryIn(m.degradation.RetryIn("Memory", now))
+	m.disk = m.disk.SetRetryIn(m.degradation.RetryIn("Disk", now))
+	m.network = m.network.SetRetryIn(m.degradation.RetryIn("Network", now))
+	m.sensors = m.sensors.SetRetryIn(m.degradation.RetryIn("Sensors", now))
+	return m
+}
+
+// renderUnavailable renders the note below a panel's error, counting down to
+// the next attempt while the collector is backed off
+func renderUnavailable(sm *StyleManager, note string, retryIn time.Duration) string {
+	if retryIn > 0 {
+		seconds := int(math.Ceil(retryIn.Seconds()))
+		if seconds < 60 {
+			note += fmt.Sprintf(", retrying in %ds", seconds)
+		} else {
+			note += fmt.Sprintf(", retrying in %dm%02ds", seconds/60, seconds%60)
+		}
+	}
+	return sm.RenderMutedText(note)
+}
+
+// adaptRefresh ends a refresh cycle, slowing refreshes while the monitor's
+// own cycle takes longer than the interval or the system CPU is saturated
+func (m MainModel) adaptRefresh() {
+	before := m.adaptive.Interval(m.updateInterval)
+	after := m.adaptive.Adjust(m.updateInterval, m.cpu.GetTotal())
+	switch {
+	case after > before:
+		log.Printf("High load, slowing refreshes to every %v", after)
+	case after < before:
+		log.Printf("Load relaxed, refreshing every %v", after)
+	}
+}
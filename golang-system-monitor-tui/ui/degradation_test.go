@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestMainModel_CollectorBackoff(t *testing.T) {
+	clock := models.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	collector := fake.NewCollector()
+	model := NewMainModel().SetDeterministic(true).SetClock(clock).SetCollector(collector)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MainModel)
+
+	collect := func() {
+		for _, msg := range cmdMsgs(model.collectDueCmd()) {
+			updated, _ := model.Update(msg)
+			model = updated.(MainModel)
+		}
+	}
+
+	collector.Fail("CPU")
+	collect()
+	clock.Advance(time.Second)
+	collect()
+	if view := stripStyles(model.View()); !strings.Contains(view, "Fake CPU error") || !strings.Contains(view, "CPU data unavailable, retrying in 2s") {
+		t.Fatalf("Expected the error with a countdown, got:\n%s", view)
+	}
+
+	// The failing collector is skipped while backed off, the others keep running
+	cpuCalls, memoryCalls, _, _ := collector.CallCounts()
+	clock.Advance(time.Second)
+	collect()
+	if cpu, memory, _, _ := collector.CallCounts(); cpu != cpuCalls || memory != memoryCalls+1 {
+		t.Errorf("Expected only the healthy collectors to run, got %d CPU and %d memory calls", cpu-cpuCalls, memory-memoryCalls)
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "retrying in 1s") {
+		t.Errorf("Expected the countdown to go down, got:\n%s", view)
+	}
+
+	// Once it is retried and succeeds, the panel recovers
+	collector.Recover()
+	clock.Advance(time.Second)
+	collect()
+	if model.GetCPUModel().HasError() || model.degradation.Failures("CPU") != 0 {
+		t.Errorf("Expected the CPU panel to recover, got:\n%s", stripStyles(model.View()))
+	}
+}
+
+func TestMainModel_CollectorCircuitBreaker(t *testing.T) {
+	clock := models.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	collector := fake.NewCollector()
+	model := NewMainModel().SetDeterministic(true).SetClock(clock).SetCollector(collector)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MainModel)
+
+	collect := func() {
+		for _, msg := range cmdMsgs(model.collectDueCmd()) {
+			updated, _ := model.Update(msg)
+			model = updated.(MainModel)
+		}
+	}
+
+	// Fail until the circuit opens, waiting out every back-off
+	collector.Fail("CPU")
+	for collect(); model.degradation.State("CPU") != models.CircuitOpen; collect() {
+		clock.Advance(model.degradation.RetryIn("CPU", clock.Now()))
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "CPU data unavailable, retrying in 5m00s") {
+		t.Fatalf("Expected the paused collector to count down to the probe, got:\n%s", view)
+	}
+
+	// Paused, the collector is not called at all
+	cpuCalls, _, _, _ := collector.CallCounts()
+	clock.Advance(time.Minute)
+	collect()
+	if cpu, _, _, _ := collector.CallCounts(); cpu != cpuCalls {
+		t.Errorf("Expected no CPU collection while paused, got %d", cpu-cpuCalls)
+	}
+
+	// The failing probe leaves the panel alone
+	lastError := model.GetCPUModel().lastError
+	clock.Advance(services.BreakerCooldown)
+	collect()
+	if cpu, _, _, _ := collector.CallCounts(); cpu != cpuCalls+1 {
+		t.Errorf("Expected a single probe after the cooldown, got %d calls", cpu-cpuCalls)
+	}
+	if !model.GetCPUModel().lastError.Equal(lastError) || model.degradation.State("CPU") != models.CircuitOpen {
+		t.Error("Expected the failed probe to pause the collector again without a new error")
+	}
+}
+
+func TestMainModel_AdaptiveRefresh(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	model = updated.(MainModel)
+
+	tick := func(total float64) {
+		updated, _ := model.Update(CPUUpdateMsg{Cores: 2, Usage: []float64{total, total}, Total: total, Timestamp: time.Now()})
+		model = updated.(MainModel)
+		updated, _ = model.Update(TickMsg(time.Now()))
+		model = updated.(MainModel)
+	}
+
+	tick(40)
+	if bar := stripStyles(model.renderStatusBar(model.now())); strings.Contains(bar, "high load") {
+		t.Fatalf("Expected no indicator at the configured interval, got %q", bar)
+	}
+
+	tick(99)
+	tick(99)
+	if bar := stripStyles(model.renderStatusBar(model.now())); !strings.Contains(bar, "⏱ 4s (high load)") {
+		t.Errorf("Expected the slowed interval in the status bar, got %q", bar)
+	}
+
+	for i := 0; i < 2*services.RecoveryCycles; i++ {
+		tick(10)
+	}
+	if bar := stripStyles(model.renderStatusBar(model.now())); strings.Contains(bar, "high load") {
+		t.Errorf("Expected the indicator gone once the load relaxed, got %q", bar)
+	}
+}
@@ -2,6 +2,8 @@ package ui
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,9 +15,34 @@ import (
 // DiskUpdateMsg represents a disk update message
 type DiskUpdateMsg []models.DiskInfo
 
+// DiskIOUpdateMsg carries the throughput of the physical disks
+type DiskIOUpdateMsg models.DiskIO
+
+// ioHistoryLength is how many throughput samples are kept, as many as the
+// CPU trend keeps
+const ioHistoryLength = 60
+
+// mountFlagDuration is how long a newly mounted filesystem is flagged, and an
+// unmounted one still listed, in the Disk panel
+const mountFlagDuration = 10 * time.Second
+
+// unmountedFS is a filesystem that went away, listed until mountFlagDuration
+// passed
+type unmountedFS struct {
+	mountpoint string
+	at         time.Time
+}
+
+// PoolsUpdateMsg carries the health of the ZFS pools and btrfs filesystems, with -pools
+type PoolsUpdateMsg []models.StoragePool
+
 // DiskModel represents the disk monitoring component
 type DiskModel struct {
 	filesystems []models.DiskInfo // Current filesystem information
+	pools       []models.StoragePool // ZFS pools and btrfs filesystems, listed above the filesystems
+	io          models.DiskIO     // Latest throughput of the physical disks
+	ioHistory   models.Ring[float64] // Bytes read and written per second, for the correlation view
+	resyncIO    bool                 // The rates of the next I/O sample span a gap such as a suspend and are dropped
 	lastUpdate  time.Time         // Last update timestamp
 	width       int               // Component width for rendering
 	height      int               // Component height for rendering
@@ -23,8 +50,28 @@ type DiskModel struct {
 	hasError bool         // Whether the component has an error
 	errorMessage string   // Current error message
 	lastError time.Time   // Timestamp of last error
+	retryIn time.Duration // Time until the failing collector is retried
+	filter   string       // Mountpoint filter typed with /
+	baselines map[string]models.DiskSample // Usage per mountpoint the time-to-full is extrapolated from
+	seen      map[string]int       // Order the mountpoints were first seen in, which the rows keep
+	mountedAt map[string]time.Time // When the filesystems mounted after the first update appeared
+	unmounted []unmountedFS        // Filesystems that went away recently, oldest first
+	removed   []string             // Mountpoints that went away with the last update
+	order     models.DiskSort      // Order of the filesystem rows
+	pinned    []string             // Mountpoints listed first whatever the order, in this order
+	collapse  int                  // Filesystems on similar devices share a row from this many, 0 never
+	expanded  bool                 // Collapsed rows are shown a filesystem each
+	thresholds map[string]models.UsageThresholds // Usage thresholds of the mountpoints with their own
+	horizon  time.Duration    // Forecasts shorter than this are shown as warnings
+	now      func() time.Time // Clock the usage samples are taken with
+	version  uint64       // Changes with every change of the rendered state
+	cache    *viewCache   // Last rendered view, shared by copies of the model
 }
 
+// DefaultDiskFullHorizon is how soon a filesystem has to be estimated to fill
+// up for its forecast to be shown as a warning
+const DefaultDiskFullHorizon = 24 * time.Hour
+
 // NewDiskModel creates a new disk model instance
 func NewDiskModel() DiskModel {
 	return DiskModel{
@@ -33,9 +80,21 @@ func NewDiskModel() DiskModel {
 		width:        50,
 		height:       10,
 		styleManager: NewStyleManager(),
+		baselines:    map[string]models.DiskSample{},
+		horizon:      DefaultDiskFullHorizon,
+		now:          time.Now,
+		version:      nextRenderVersion(),
+		cache:        newViewCache(),
 	}
 }
 
+// Resync drops the rates of the next I/O sample, which span a gap in the
+// samples such as a suspend
+func (m DiskModel) Resync() DiskModel {
+	m.resyncIO = true
+	return m
+}
+
 // Init initializes the disk model
 func (m DiskModel) Init() tea.Cmd {
 	return nil
@@ -43,6 +102,7 @@ func (m DiskModel) Init() tea.Cmd {
 
 // Update handles messages and updates the disk model state
 func (m DiskModel) Update(msg tea.Msg) (DiskModel, tea.Cmd) {
+	m.version = nextRenderVersion()
 	switch msg := msg.(type) {
 	case DiskUpdateMsg:
 		// Clear any previous errors on successful update
@@ -50,8 +110,25 @@ func (m DiskModel) Update(msg tea.Msg) (DiskModel, tea.Cmd) {
 		m.errorMessage = ""
 		
 		// Update filesystem data
-		m.filesystems = []models.DiskInfo(msg)
+		now := m.now()
+		m.baselines = m.updateBaselines([]models.DiskInfo(msg), now)
+		m = m.trackMounts([]models.DiskInfo(msg), now)
 		m.lastUpdate = time.Now()
+
+	case PoolsUpdateMsg:
+		m.pools = []models.StoragePool(msg)
+
+	case DiskIOUpdateMsg:
+		m.io = models.DiskIO(msg)
+		if m.resyncIO {
+			// The collector computed the rates over the gap, they start again with the next sample
+			m.io.ReadRate, m.io.WriteRate, m.resyncIO = 0, 0, false
+			break
+		}
+		if m.ioHistory.Cap() == 0 {
+			m.ioHistory = models.NewRing[float64](ioHistoryLength)
+		}
+		m.ioHistory = m.ioHistory.Push(m.io.TotalRate())
 		
 	case models.ErrorMsg:
 		// Handle error messages for Disk component
@@ -66,26 +143,31 @@ func (m DiskModel) Update(msg tea.Msg) (DiskModel, tea.Cmd) {
 
 // View renders the disk model
 func (m DiskModel) View() string {
-	var sections []string
+	return m.cache.render(m.version, m.styleManager, m.render)
+}
+
+// render draws the view of the current state
+func (m DiskModel) render() string {
+	buf := getRenderBuffer()
+	defer buf.release()
+	sections := buf.lines
 	
 	// Header
-	header := m.styleManager.RenderHeader("Disk Usage")
+	filesystems := m.GetVisibleFilesystems()
+	header := renderFilteredHeader(m.styleManager, "Disk Usage", m.filter, len(filesystems), len(m.filesystems))
 	sections = append(sections, header)
 
 	// Handle error state
 	if m.hasError {
 		sections = append(sections, m.styleManager.RenderErrorText("Error: "+m.errorMessage))
-		sections = append(sections, m.styleManager.RenderMutedText("Disk data unavailable"))
+		sections = append(sections, renderUnavailable(m.styleManager, "Disk data unavailable", m.retryIn))
 		
 		// Show fallback display with N/A values
 		sections = append(sections, "Filesystems: N/A")
 		sections = append(sections, "Usage: N/A")
 		
 		// Add spacing
-		for len(sections) < m.height {
-			sections = append(sections, "")
-		}
-		return strings.Join(sections, "\n")
+		return buf.join(sections, m.height)
 	}
 
 	// Handle loading state
@@ -93,79 +175,436 @@ func (m DiskModel) View() string {
 		return m.styleManager.RenderPlaceholder("Disk Usage", "Loading disk data...")
 	}
 
+	// Render each filesystem: mountpoint, usage bar and percentage, with the
+	// sizes on a detail line under the bar
+	table := m.styleManager.NewTable(m.width-2,
+		TableColumn{Width: 15},
+		TableColumn{Width: 10, Flex: true},
+		TableColumn{Width: 6, Right: true})
+
+	// The total of all filesystems comes first, so a long list does not cut it off
+	if len(m.filesystems) > 1 {
+		sections = append(sections, m.renderTotal(table)...)
+	}
+
+	// Pools come first so a degraded one is not cut off by a long filesystem list
+	for _, pool := range m.pools {
+		sections = append(sections, m.renderPool(pool))
+	}
+
+	if len(filesystems) == 0 {
+		sections = append(sections, m.styleManager.RenderMutedText("No filesystems match the filter"))
+	}
+
 	// Normal display
-	// Render each filesystem
-	for _, fs := range m.filesystems {
-		// Truncate long mountpoints for better display
-		mountpoint := fs.Mountpoint
-		if len(mountpoint) > 15 {
-			mountpoint = mountpoint[:12] + "..."
+	for _, row := range m.collapseRows(filesystems) {
+		if len(row) > 1 {
+			sections = append(sections, m.renderCollapsed(table, row)...)
+			continue
 		}
-		
-		// Create filesystem line with progress bar
-		barWidth := m.styleManager.GetProgressBarWidth(m.width, 18) // 15 chars for mountpoint + 3 for spacing
-		fsBar := m.styleManager.RenderProgressBar(fs.UsedPercent, barWidth, false)
-		
-		fsLine := fmt.Sprintf("%-15s %s %.1f%%", 
-			mountpoint, fsBar, fs.UsedPercent)
+		fs := row[0]
+		fsBar := m.styleManager.RenderThresholdBar(fs.UsedPercent, table.ColumnWidth(1), m.GetThresholds(fs.Mountpoint))
+		fsLine := table.Row(pinLabel(m.styleManager, fs.Mountpoint, m.IsPinned(fs.Mountpoint)), fsBar, m.styleManager.Locale().FormatPercent(fs.UsedPercent, 1))
 		
 		// Apply warning/critical styling if needed
-		if fs.UsedPercent >= 90 {
-			sections = append(sections, m.styleManager.RenderCriticalText(fsLine))
-		} else if fs.UsedPercent >= 70 {
-			sections = append(sections, m.styleManager.RenderWarningText(fsLine))
-		} else {
-			sections = append(sections, fsLine)
-		}
+		sections = append(sections, m.styleUsage(fsLine, fs.UsedPercent, m.GetThresholds(fs.Mountpoint)))
 
 		// Add size details in human-readable format
-		sizeDetails := fmt.Sprintf("%-15s %s / %s", 
-			"", 
-			m.formatBytes(fs.Used), 
-			m.formatBytes(fs.Total))
+		sizeDetails := table.Row("", m.styleManager.FormatBytes(fs.Used)+" / "+m.styleManager.FormatBytes(fs.Total))
+		if full, ok := m.GetTimeToFull(fs.Mountpoint); ok {
+			sizeDetails += " • full in " + formatUptime(full)
+			if full < m.horizon {
+				sections = append(sections, m.styleManager.RenderWarningText(sizeDetails))
+				continue
+			}
+		}
+		if m.IsNewMount(fs.Mountpoint) {
+			sections = append(sections, m.styleManager.RenderHighlightText(sizeDetails+" • new"))
+			continue
+		}
 		sections = append(sections, m.styleManager.RenderMutedText(sizeDetails))
 	}
 
-	// Add spacing if we have fewer lines than available height
-	for len(sections) < m.height {
-		sections = append(sections, "")
+	// Filesystems unmounted a moment ago stay listed, so their rows do not
+	// vanish without a trace
+	for _, mountpoint := range m.GetUnmounted() {
+		if m.filter == "" || matchesFilter(mountpoint, m.filter) {
+			sections = append(sections, m.styleManager.RenderMutedText(table.Row(mountpoint, "unmounted")))
+		}
 	}
 
-	return strings.Join(sections, "\n")
+	// Add spacing if we have fewer lines than available height
+	return buf.join(sections, m.height)
+}
+
+
+
+// renderTotal renders the bold TOTAL row with the combined usage of all
+// filesystems, whatever the filter, and their sizes on the detail line
+func (m DiskModel) renderTotal(table Table) []string {
+	percent := m.GetOverallUsagePercent()
+	bar := m.styleManager.RenderProgressBar(percent, table.ColumnWidth(1), false)
+	details := table.Row("", m.styleManager.FormatBytes(m.GetTotalUsedSpace())+" / "+m.styleManager.FormatBytes(m.GetTotalDiskSpace()))
+	return []string{
+		m.styleManager.RenderBoldText(table.Row("TOTAL", bar, m.styleManager.Locale().FormatPercent(percent, 1))),
+		m.styleManager.RenderMutedText(details),
+	}
 }
 
+// styleUsage colors the row of a filesystem by how full it is
+func (m DiskModel) styleUsage(line string, usedPercent float64, thresholds models.UsageThresholds) string {
+	if usedPercent >= thresholds.Critical {
+		return m.styleManager.RenderCriticalText(line)
+	} else if usedPercent >= thresholds.Warning {
+		return m.styleManager.RenderWarningText(line)
+	}
+	return line
+}
 
+// renderCollapsed renders filesystems on similar devices as one row with
+// their combined usage, e.g. "/dev/loop* (12)", and the mount count on the
+// detail line
+func (m DiskModel) renderCollapsed(table Table, filesystems []models.DiskInfo) []string {
+	devices := make([]string, len(filesystems))
+	for i, fs := range filesystems {
+		devices[i] = fs.Device
+	}
+	combined := models.CombineFilesystems(filesystems)
+	bar := m.styleManager.RenderProgressBar(combined.UsedPercent, table.ColumnWidth(1), false)
+	line := table.Row(collapsedLabel(devices), bar, m.styleManager.Locale().FormatPercent(combined.UsedPercent, 1))
+	details := table.Row("", fmt.Sprintf("%s / %s • %d mounts", m.styleManager.FormatBytes(combined.Used), m.styleManager.FormatBytes(combined.Total), len(filesystems)))
+	return []string{m.styleUsage(line, combined.UsedPercent, models.DefaultDiskThresholds()), m.styleManager.RenderMutedText(details)}
+}
 
-// formatBytes converts bytes to human-readable format (GB/MB/KB)
-func (m DiskModel) formatBytes(bytes uint64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-		TB = GB * 1024
-	)
+// renderPool renders the health of a pool on one line, e.g. "tank zfs
+// ONLINE 1.52x, scrub 31% done", in the critical color when it is unhealthy
+func (m DiskModel) renderPool(pool models.StoragePool) string {
+	parts := []string{pool.State}
+	if pool.Errors > 0 {
+		parts = append(parts, m.styleManager.Locale().FormatInt(int64(pool.Errors))+" errors")
+	}
+	if pool.CompressRatio > 0 {
+		parts = append(parts, m.styleManager.Locale().FormatFloat(pool.CompressRatio, 2)+"x")
+	}
+	switch {
+	case pool.Scrubbing():
+		parts = append(parts, "scrub "+m.styleManager.Locale().FormatPercent(pool.ScrubProgress, 0)+" done")
+	case pool.Scrub != "":
+		parts = append(parts, "scrub "+pool.Scrub)
+	}
+	if pool.Error != "" {
+		parts = append(parts, pool.Error)
+	}
 
+	line := truncate(fmt.Sprintf("%s %s %s", pool.Name, pool.Kind, strings.Join(parts, ", ")), m.width)
 	switch {
-	case bytes >= TB:
-		return fmt.Sprintf("%.1fTB", float64(bytes)/TB)
-	case bytes >= GB:
-		return fmt.Sprintf("%.1fGB", float64(bytes)/GB)
-	case bytes >= MB:
-		return fmt.Sprintf("%.1fMB", float64(bytes)/MB)
-	case bytes >= KB:
-		return fmt.Sprintf("%.1fKB", float64(bytes)/KB)
+	case !pool.Healthy:
+		return m.styleManager.RenderCriticalText(line)
+	case pool.Error != "":
+		return m.styleManager.RenderMutedText(line)
 	default:
-		return fmt.Sprintf("%dB", bytes)
+		return line
+	}
+}
+
+// GetPools returns the ZFS pools and btrfs filesystems
+func (m DiskModel) GetPools() []models.StoragePool {
+	return m.pools
+}
+
+// GetIO returns the latest throughput of the physical disks
+func (m DiskModel) GetIO() models.DiskIO {
+	return m.io
+}
+
+// GetIOHistory returns a copy of the bytes read and written per second,
+// oldest first
+func (m DiskModel) GetIOHistory() []float64 {
+	return m.ioHistory.Values()
+}
+
+// updateBaselines returns the samples forecasts extrapolate from: the first
+// usage seen of each filesystem, restarted when usage drops since freed space
+// makes the earlier growth meaningless
+func (m DiskModel) updateBaselines(filesystems []models.DiskInfo, now time.Time) map[string]models.DiskSample {
+	previous := make(map[string]uint64, len(m.filesystems))
+	for _, fs := range m.filesystems {
+		previous[fs.Mountpoint] = fs.Used
+	}
+
+	baselines := make(map[string]models.DiskSample, len(filesystems))
+	for _, fs := range filesystems {
+		baseline, ok := m.baselines[fs.Mountpoint]
+		if !ok || fs.Used < previous[fs.Mountpoint] {
+			baseline = models.DiskSample{Used: fs.Used, At: now}
+		}
+		baselines[fs.Mountpoint] = baseline
+	}
+	return baselines
+}
+
+// trackMounts stores filesystems in the order their mountpoints were first
+// seen, so a mount or unmount does not reshuffle the rows, and records the
+// filesystems mounted and unmounted since the last update. Those of the first
+// update are not new.
+func (m DiskModel) trackMounts(filesystems []models.DiskInfo, now time.Time) DiskModel {
+	next := 0
+	for _, order := range m.seen {
+		next = max(next, order+1)
+	}
+	seen := make(map[string]int, len(filesystems))
+	mountedAt := make(map[string]time.Time)
+	for _, fs := range filesystems {
+		if _, ok := seen[fs.Mountpoint]; ok {
+			continue
+		}
+		order, ok := m.seen[fs.Mountpoint]
+		if !ok {
+			order, next = next, next+1
+			if m.seen != nil {
+				mountedAt[fs.Mountpoint] = now
+			}
+		} else if at, ok := m.mountedAt[fs.Mountpoint]; ok && now.Sub(at) < mountFlagDuration {
+			mountedAt[fs.Mountpoint] = at
+		}
+		seen[fs.Mountpoint] = order
+	}
+
+	var unmounted []unmountedFS
+	var removed []string
+	for _, fs := range m.unmounted {
+		if _, remounted := seen[fs.mountpoint]; !remounted && now.Sub(fs.at) < mountFlagDuration {
+			unmounted = append(unmounted, fs)
+		}
+	}
+	for _, fs := range m.filesystems {
+		if _, ok := seen[fs.Mountpoint]; !ok {
+			unmounted = append(unmounted, unmountedFS{mountpoint: fs.Mountpoint, at: now})
+			removed = append(removed, fs.Mountpoint)
+		}
+	}
+
+	ordered := make([]models.DiskInfo, len(filesystems))
+	copy(ordered, filesystems)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return seen[ordered[i].Mountpoint] < seen[ordered[j].Mountpoint]
+	})
+	m.filesystems, m.seen, m.mountedAt, m.unmounted, m.removed = ordered, seen, mountedAt, unmounted, removed
+	return m
+}
+
+// IsNewMount reports whether the filesystem at mountpoint was mounted less
+// than mountFlagDuration ago
+func (m DiskModel) IsNewMount(mountpoint string) bool {
+	at, ok := m.mountedAt[mountpoint]
+	return ok && m.now().Sub(at) < mountFlagDuration
+}
+
+// GetUnmounted returns the mountpoints unmounted less than mountFlagDuration
+// ago, oldest first
+func (m DiskModel) GetUnmounted() []string {
+	var mountpoints []string
+	for _, fs := range m.unmounted {
+		if m.now().Sub(fs.at) < mountFlagDuration {
+			mountpoints = append(mountpoints, fs.mountpoint)
+		}
 	}
+	return mountpoints
+}
+
+// GetRemoved returns the mountpoints that went away with the last update
+func (m DiskModel) GetRemoved() []string {
+	return m.removed
+}
+
+// GetTimeToFull returns how soon the filesystem at mountpoint fills up at its
+// growth over the session, false while it is not growing
+func (m DiskModel) GetTimeToFull(mountpoint string) (time.Duration, bool) {
+	baseline, ok := m.baselines[mountpoint]
+	if !ok {
+		return 0, false
+	}
+	for _, fs := range m.filesystems {
+		if fs.Mountpoint == mountpoint {
+			return models.TimeToFull(baseline, fs, m.now())
+		}
+	}
+	return 0, false
+}
+
+// SetClock sets the clock usage samples are taken with
+func (m DiskModel) SetClock(now func() time.Time) DiskModel {
+	m.version = nextRenderVersion()
+	m.now = now
+	return m
+}
+
+// SetForecastHorizon sets how soon a filesystem has to fill up for its
+// forecast to be shown as a warning
+func (m DiskModel) SetForecastHorizon(horizon time.Duration) DiskModel {
+	m.version = nextRenderVersion()
+	m.horizon = horizon
+	return m
 }
 
 // SetSize sets the component dimensions
 func (m DiskModel) SetSize(width, height int) DiskModel {
+	if width != m.width || height != m.height {
+		m.version = nextRenderVersion()
+	}
 	m.width = width
 	m.height = height
 	return m
 }
 
+// SetRetryIn sets the time until the failing collector is retried, shown
+// below the error
+func (m DiskModel) SetRetryIn(retryIn time.Duration) DiskModel {
+	if retryIn != m.retryIn {
+		m.version = nextRenderVersion()
+	}
+	m.retryIn = retryIn
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m DiskModel) SetStyleManager(styleManager *StyleManager) DiskModel {
+	if styleManager != m.styleManager {
+		m.version = nextRenderVersion()
+	}
+	m.styleManager = styleManager
+	return m
+}
+
+// SetFilter shows only filesystems whose mountpoint contains filter
+func (m DiskModel) SetFilter(filter string) DiskModel {
+	if filter != m.filter {
+		m.version = nextRenderVersion()
+	}
+	m.filter = filter
+	return m
+}
+
+// GetFilter returns the mountpoint filter
+func (m DiskModel) GetFilter() string {
+	return m.filter
+}
+
+// GetVisibleFilesystems returns the filesystems that pass the filter, the
+// pinned ones first and the others in the sort order
+func (m DiskModel) GetVisibleFilesystems() []models.DiskInfo {
+	if m.filter == "" && m.order == models.DiskSortMounted && len(m.pinned) == 0 {
+		return m.filesystems
+	}
+	var visible []models.DiskInfo
+	for _, fs := range m.filesystems {
+		if matchesFilter(fs.Mountpoint, m.filter) {
+			visible = append(visible, fs)
+		}
+	}
+	m.order.Sort(visible)
+	return models.PinFirst(visible, m.pinned, func(fs models.DiskInfo) string { return fs.Mountpoint })
+}
+
+// SetPinned lists the filesystems mounted on mountpoints first, in that order
+func (m DiskModel) SetPinned(mountpoints []string) DiskModel {
+	if !slices.Equal(mountpoints, m.pinned) {
+		m.version = nextRenderVersion()
+	}
+	m.pinned = mountpoints
+	return m
+}
+
+// GetPinned returns the pinned mountpoints
+func (m DiskModel) GetPinned() []string {
+	return m.pinned
+}
+
+// IsPinned reports whether the filesystem on mountpoint is pinned
+func (m DiskModel) IsPinned(mountpoint string) bool {
+	return slices.Contains(m.pinned, mountpoint)
+}
+
+// SetCollapse collapses filesystems on similar devices, such as bind mounts
+// of one disk or the loop devices of snaps, into one row once there are at
+// least min of them; 0 keeps every filesystem on its own row
+func (m DiskModel) SetCollapse(min int) DiskModel {
+	if min != m.collapse {
+		m.version = nextRenderVersion()
+	}
+	m.collapse = min
+	return m
+}
+
+// GetCollapse returns the number of similar filesystems that share a row
+func (m DiskModel) GetCollapse() int {
+	return m.collapse
+}
+
+// SetExpanded shows collapsed rows a filesystem each
+func (m DiskModel) SetExpanded(expanded bool) DiskModel {
+	if expanded != m.expanded {
+		m.version = nextRenderVersion()
+	}
+	m.expanded = expanded
+	return m
+}
+
+// IsExpanded reports whether collapsed rows are shown a filesystem each
+func (m DiskModel) IsExpanded() bool {
+	return m.expanded
+}
+
+// GetRows returns the visible filesystems by row, several on a row collapsed
+// into one
+func (m DiskModel) GetRows() [][]models.DiskInfo {
+	return m.collapseRows(m.GetVisibleFilesystems())
+}
+
+// collapseRows puts the filesystems on devices of one family on a shared row,
+// leaving out the pinned ones, and every filesystem on its own row while the
+// rows are expanded
+func (m DiskModel) collapseRows(filesystems []models.DiskInfo) [][]models.DiskInfo {
+	min := m.collapse
+	if m.expanded {
+		min = 0
+	}
+	return models.Collapse(filesystems, min, func(fs models.DiskInfo) string {
+		if m.IsPinned(fs.Mountpoint) {
+			return ""
+		}
+		return models.UnitFamily(fs.Device)
+	})
+}
+
+// SetThresholds sets the usage thresholds of the mountpoints with their own;
+// the others are yellow from 70% and red from 90%
+func (m DiskModel) SetThresholds(thresholds map[string]models.UsageThresholds) DiskModel {
+	m.thresholds = thresholds
+	m.version = nextRenderVersion()
+	return m
+}
+
+// GetThresholds returns the usage thresholds of the filesystem mounted on
+// mountpoint
+func (m DiskModel) GetThresholds(mountpoint string) models.UsageThresholds {
+	return models.DiskThresholdsFor(m.thresholds, mountpoint)
+}
+
+// SetSort sets the order of the filesystem rows
+func (m DiskModel) SetSort(order models.DiskSort) DiskModel {
+	if order != m.order {
+		m.version = nextRenderVersion()
+	}
+	m.order = order
+	return m
+}
+
+// GetSort returns the order of the filesystem rows
+func (m DiskModel) GetSort() models.DiskSort {
+	return m.order
+}
+
 // GetFilesystems returns the current filesystem information
 func (m DiskModel) GetFilesystems() []models.DiskInfo {
 	return m.filesystems
@@ -182,32 +621,34 @@ func (m DiskModel) GetHighUsageFilesystems(threshold float64) []models.DiskInfo
 	return highUsage
 }
 
-// GetCriticalFilesystems returns filesystems with usage >= 90%
+// GetCriticalFilesystems returns filesystems with usage at or above their
+// critical threshold
 func (m DiskModel) GetCriticalFilesystems() []models.DiskInfo {
-	return m.GetHighUsageFilesystems(90.0)
+	var critical []models.DiskInfo
+	for _, fs := range m.filesystems {
+		if fs.UsedPercent >= m.GetThresholds(fs.Mountpoint).Critical {
+			critical = append(critical, fs)
+		}
+	}
+	return critical
 }
 
-// HasCriticalUsage returns true if any filesystem has usage >= 90%
+// HasCriticalUsage returns true if any filesystem is at or above its
+// critical threshold
 func (m DiskModel) HasCriticalUsage() bool {
 	return len(m.GetCriticalFilesystems()) > 0
 }
 
-// GetTotalDiskSpace returns the total disk space across all filesystems
+// GetTotalDiskSpace returns the total disk space across all filesystems,
+// counting the bind mounts of a device once
 func (m DiskModel) GetTotalDiskSpace() uint64 {
-	var total uint64
-	for _, fs := range m.filesystems {
-		total += fs.Total
-	}
-	return total
+	return models.CombineFilesystems(m.filesystems).Total
 }
 
-// GetTotalUsedSpace returns the total used space across all filesystems
+// GetTotalUsedSpace returns the total used space across all filesystems,
+// counting the bind mounts of a device once
 func (m DiskModel) GetTotalUsedSpace() uint64 {
-	var used uint64
-	for _, fs := range m.filesystems {
-		used += fs.Used
-	}
-	return used
+	return models.CombineFilesystems(m.filesystems).Used
 }
 
 // GetOverallUsagePercent returns the overall usage percentage across all filesystems
@@ -231,6 +672,7 @@ func (m DiskModel) GetErrorMessage() string {
 
 // ClearError clears the current error state
 func (m DiskModel) ClearError() DiskModel {
+	m.version = nextRenderVersion()
 	m.hasError = false
 	m.errorMessage = ""
 	return m
@@ -238,6 +680,7 @@ func (m DiskModel) ClearError() DiskModel {
 
 // SetError sets an error state for the component
 func (m DiskModel) SetError(message string) DiskModel {
+	m.version = nextRenderVersion()
 	m.hasError = true
 	m.errorMessage = message
 	m.lastError = time.Now()
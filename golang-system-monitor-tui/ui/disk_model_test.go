@@ -3,6 +3,7 @@ package ui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -135,11 +136,11 @@ func TestDiskModel_View_WithData(t *testing.T) {
 		t.Error("Expected view to contain usage percentage '50.0%'")
 	}
 	
-	if !strings.Contains(view, "476.8MB") {
+	if !strings.Contains(view, "476.8MiB") {
 		t.Error("Expected view to contain formatted used space")
 	}
 	
-	if !strings.Contains(view, "953.7MB") {
+	if !strings.Contains(view, "953.7MiB") {
 		t.Error("Expected view to contain formatted total space")
 	}
 }
@@ -191,17 +192,17 @@ func TestDiskModel_FormatBytes(t *testing.T) {
 	}{
 		{0, "0B"},
 		{512, "512B"},
-		{1024, "1.0KB"},
-		{1536, "1.5KB"},
-		{1048576, "1.0MB"},
-		{1073741824, "1.0GB"},
-		{1099511627776, "1.0TB"},
-		{1536000000000, "1.4TB"},
+		{1024, "1.0KiB"},
+		{1536, "1.5KiB"},
+		{1048576, "1.0MiB"},
+		{1073741824, "1.0GiB"},
+		{1099511627776, "1.0TiB"},
+		{1536000000000, "1.4TiB"},
 	}
 	
 	for _, tt := range tests {
 		t.Run(tt.expected, func(t *testing.T) {
-			result := model.formatBytes(tt.bytes)
+			result := model.styleManager.FormatBytes(tt.bytes)
 			if result != tt.expected {
 				t.Errorf("Expected %s, got %s", tt.expected, result)
 			}
@@ -500,8 +501,270 @@ func BenchmarkDiskModel_View(b *testing.B) {
 	updateMsg := DiskUpdateMsg(diskInfo)
 	model, _ = model.Update(updateMsg)
 	
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		model.View()
+		model.render() // View would return the cached frame
 	}
-}
\ No newline at end of file
+}
+
+func TestDiskModel_Filter(t *testing.T) {
+	model := NewDiskModel()
+	model, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{
+		{Mountpoint: "/", Total: 100, Used: 50, UsedPercent: 50},
+		{Mountpoint: "/home", Total: 100, Used: 20, UsedPercent: 20},
+		{Mountpoint: "/mnt/Backup", Total: 100, Used: 90, UsedPercent: 90},
+	}))
+
+	model = model.SetFilter("backup")
+	if visible := model.GetVisibleFilesystems(); len(visible) != 1 || visible[0].Mountpoint != "/mnt/Backup" {
+		t.Errorf("Expected case-insensitive mountpoint match, got %+v", visible)
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "/backup (1 of 3)") || strings.Contains(view, "/home") {
+		t.Errorf("Expected filtered view with match count, got %s", view)
+	}
+
+	model = model.SetFilter("nfs")
+	if view = model.View(); !strings.Contains(view, "No filesystems match the filter") {
+		t.Errorf("Expected no-match message, got %s", view)
+	}
+
+	// Alerts and totals still cover every filesystem
+	if len(model.GetCriticalFilesystems()) != 1 || len(model.SetFilter("").GetVisibleFilesystems()) != 3 {
+		t.Error("Expected the filter to affect only what is shown")
+	}
+}
+
+func TestDiskModel_Forecast(t *testing.T) {
+	const GB = 1 << 30
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	model := NewDiskModel().SetClock(func() time.Time { return now }).SetSize(60, 10)
+	update := func(used uint64) {
+		model, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{
+			{Mountpoint: "/", Total: 1000 * GB, Used: used * GB, Available: (1000 - used) * GB, UsedPercent: float64(used) / 10},
+		}))
+	}
+
+	update(400)
+	if _, ok := model.GetTimeToFull("/"); ok {
+		t.Error("Expected no forecast from a single sample")
+	}
+
+	// 60GB an hour with 540GB left fills up in 9 hours, within the horizon
+	now = now.Add(time.Hour)
+	update(460)
+	if full, ok := model.GetTimeToFull("/"); !ok || full != 9*time.Hour {
+		t.Fatalf("Expected 9h to full, got %v (%v)", full, ok)
+	}
+	if view := model.View(); !strings.Contains(view, "full in 9h0m") {
+		t.Errorf("Expected the forecast in the view, got:\n%s", view)
+	}
+
+	// Freeing space restarts the trend
+	now = now.Add(time.Hour)
+	update(300)
+	if _, ok := model.GetTimeToFull("/"); ok {
+		t.Error("Expected no forecast after usage dropped")
+	}
+}
+
+func TestDiskModel_MountChanges(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	model := NewDiskModel().SetClock(func() time.Time { return now }).SetSize(60, 20)
+	update := func(mountpoints ...string) []string {
+		t.Helper()
+		filesystems := make([]models.DiskInfo, len(mountpoints))
+		for i, mountpoint := range mountpoints {
+			filesystems[i] = models.DiskInfo{Mountpoint: mountpoint, Total: 100, Used: 10, UsedPercent: 10}
+		}
+		model, _ = model.Update(DiskUpdateMsg(filesystems))
+		var order []string
+		for _, fs := range model.GetFilesystems() {
+			order = append(order, fs.Mountpoint)
+		}
+		return order
+	}
+
+	update("/", "/home", "/boot")
+	if model.IsNewMount("/home") {
+		t.Error("Expected the filesystems of the first update not to be new")
+	}
+
+	// A USB stick mounted between existing mounts goes last, flagged as new
+	if order := update("/", "/media/usb", "/home", "/boot"); strings.Join(order, " ") != "/ /home /boot /media/usb" {
+		t.Errorf("Expected the rows to keep their order, got %v", order)
+	}
+	if !model.IsNewMount("/media/usb") {
+		t.Error("Expected /media/usb to be new")
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "• new") {
+		t.Errorf("Expected the new mount flagged, got:\n%s", view)
+	}
+
+	// Unmounting lists the mount for a moment, then prunes it
+	now = now.Add(time.Second)
+	if order := update("/", "/media/usb", "/boot"); strings.Join(order, " ") != "/ /boot /media/usb" {
+		t.Errorf("Expected the rows to keep their order, got %v", order)
+	}
+	if unmounted := model.GetUnmounted(); len(unmounted) != 1 || unmounted[0] != "/home" {
+		t.Errorf("Expected /home to be unmounted, got %v", unmounted)
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "unmounted") {
+		t.Errorf("Expected the unmounted filesystem listed, got:\n%s", view)
+	}
+
+	now = now.Add(mountFlagDuration)
+	update("/", "/media/usb", "/boot")
+	if model.IsNewMount("/media/usb") || len(model.GetUnmounted()) != 0 {
+		t.Errorf("Expected the flags to expire after %v", mountFlagDuration)
+	}
+	if view := stripStyles(model.View()); strings.Contains(view, "unmounted") || strings.Contains(view, "• new") {
+		t.Errorf("Expected no flags after %v, got:\n%s", mountFlagDuration, view)
+	}
+
+	// Mounted again, /home comes back last
+	if order := update("/", "/home", "/media/usb", "/boot"); strings.Join(order, " ") != "/ /boot /media/usb /home" {
+		t.Errorf("Expected a remounted filesystem to go last, got %v", order)
+	}
+}
+
+func TestDiskModel_Sort(t *testing.T) {
+	model := NewDiskModel().SetSize(60, 20)
+	model, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{
+		{Mountpoint: "/home", Total: 500, UsedPercent: 40},
+		{Mountpoint: "/", Total: 100, UsedPercent: 80},
+	}))
+
+	model = model.SetSort(models.DiskSortUsage)
+	view := stripStyles(model.View())
+	if strings.Index(view, "/home") < strings.Index(view, "/ ") {
+		t.Errorf("Expected the fullest filesystem first, got:\n%s", view)
+	}
+	if model.GetFilesystems()[0].Mountpoint != "/home" {
+		t.Error("Expected the stored filesystems to keep their order")
+	}
+}
+
+func TestDiskModel_Pinned(t *testing.T) {
+	model := NewDiskModel().SetSize(60, 20).SetSort(models.DiskSortUsage)
+	model, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{
+		{Mountpoint: "/", Total: 100, UsedPercent: 80},
+		{Mountpoint: "/home", Total: 500, UsedPercent: 40},
+		{Mountpoint: "/data", Total: 900, UsedPercent: 10},
+	}))
+
+	model = model.SetPinned([]string{"/data", "/home"})
+	visible := model.GetVisibleFilesystems()
+	if len(visible) != 3 || visible[0].Mountpoint != "/data" || visible[1].Mountpoint != "/home" || visible[2].Mountpoint != "/" {
+		t.Errorf("Expected the pinned filesystems first in pin order, got %+v", visible)
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "★ /data") || strings.Contains(view, "★ / ") {
+		t.Errorf("Expected only the pinned rows marked, got:\n%s", view)
+	}
+
+	// Pinned filesystems still have to pass the filter
+	model = model.SetFilter("home")
+	if visible := model.GetVisibleFilesystems(); len(visible) != 1 || visible[0].Mountpoint != "/home" {
+		t.Errorf("Expected the filter to apply to pinned filesystems, got %+v", visible)
+	}
+}
+
+func TestDiskModel_Collapse(t *testing.T) {
+	model := NewDiskModel().SetSize(60, 20).SetCollapse(3)
+	model, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{
+		{Device: "/dev/sda1", Mountpoint: "/", Total: 100, Used: 80, UsedPercent: 80},
+		{Device: "/dev/loop0", Mountpoint: "/snap/core/17", Total: 10, Used: 10, UsedPercent: 100},
+		{Device: "/dev/loop1", Mountpoint: "/snap/firefox/4", Total: 20, Used: 20, UsedPercent: 100},
+		{Device: "/dev/loop2", Mountpoint: "/snap/gtk/9", Total: 30, Used: 30, UsedPercent: 100},
+	}))
+
+	rows := model.GetRows()
+	if len(rows) != 2 || len(rows[1]) != 3 {
+		t.Fatalf("Expected the loop devices on one row, got %+v", rows)
+	}
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "/dev/loop* (3)") || !strings.Contains(view, "3 mounts") || strings.Contains(view, "/snap/gtk/9") {
+		t.Errorf("Expected one summary row for the snaps, got:\n%s", view)
+	}
+
+	model = model.SetExpanded(true)
+	if rows := model.GetRows(); len(rows) != 4 {
+		t.Errorf("Expected a row per filesystem when expanded, got %d", len(rows))
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "/snap/gtk/9") {
+		t.Errorf("Expected the snaps listed when expanded, got:\n%s", view)
+	}
+
+	// A pinned filesystem keeps its own row
+	model = model.SetExpanded(false).SetPinned([]string{"/snap/gtk/9"})
+	if rows := model.GetRows(); len(rows) != 4 {
+		t.Errorf("Expected the family below 3 members once one is pinned, got %+v", rows)
+	}
+}
+
+func TestDiskModel_TotalRow(t *testing.T) {
+	model := NewDiskModel().SetSize(60, 20)
+	model, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{
+		{Device: "/dev/sda1", Mountpoint: "/", Total: 1 << 30, Used: 1 << 29, UsedPercent: 50},
+		{Device: "/dev/sda1", Mountpoint: "/srv", Total: 1 << 30, Used: 1 << 29, UsedPercent: 50},
+		{Device: "/dev/sdb1", Mountpoint: "/data", Total: 3 << 30, Used: 1 << 29, UsedPercent: 16.7},
+	}))
+
+	// The bind mount of /dev/sda1 on /srv is counted once
+	if model.GetTotalDiskSpace() != 4<<30 || model.GetTotalUsedSpace() != 1<<30 {
+		t.Errorf("Expected 1.0GiB of 4.0GiB used, got %d of %d", model.GetTotalUsedSpace(), model.GetTotalDiskSpace())
+	}
+	view := stripStyles(model.View())
+	lines := strings.Split(view, "\n")
+	if !strings.HasPrefix(lines[1], "TOTAL") || !strings.Contains(lines[1], "25.0%") || !strings.Contains(lines[2], "1.0GiB / 4.0GiB") {
+		t.Errorf("Expected the TOTAL row under the header, got:\n%s", view)
+	}
+
+	// A single filesystem needs no total
+	model, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{{Device: "/dev/sda1", Mountpoint: "/", Total: 100, Used: 50, UsedPercent: 50}}))
+	if view := stripStyles(model.View()); strings.Contains(view, "TOTAL") {
+		t.Errorf("Expected no TOTAL row for one filesystem, got:\n%s", view)
+	}
+}
+
+func TestDiskModel_Thresholds(t *testing.T) {
+	model := NewDiskModel().SetThresholds(map[string]models.UsageThresholds{"/data": {Warning: 95, Critical: 97}})
+	model, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{
+		{Mountpoint: "/", UsedPercent: 92},
+		{Mountpoint: "/data", UsedPercent: 96},
+	}))
+
+	if got := model.GetThresholds("/"); got != models.DefaultDiskThresholds() {
+		t.Errorf("Expected the default thresholds for /, got %+v", got)
+	}
+	critical := model.GetCriticalFilesystems()
+	if len(critical) != 1 || critical[0].Mountpoint != "/" {
+		t.Errorf("Expected only / past its critical threshold, got %+v", critical)
+	}
+}
+
+func TestDiskModel_Pools(t *testing.T) {
+	model := NewDiskModel().SetSize(70, 12)
+	model, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{
+		{Mountpoint: "/", Total: 100, Used: 50, UsedPercent: 50},
+	}))
+	model, _ = model.Update(PoolsUpdateMsg([]models.StoragePool{
+		{Name: "tank", Kind: "zfs", State: "ONLINE", Healthy: true, Scrub: "in progress", ScrubProgress: 31.25, CompressRatio: 1.52},
+		{Name: "/data", Kind: "btrfs", State: "ERRORS", Errors: 3, Scrub: "no errors found", ScrubProgress: -1},
+	}))
+
+	if len(model.GetPools()) != 2 {
+		t.Fatalf("Expected 2 pools, got %d", len(model.GetPools()))
+	}
+	view := stripStyles(model.View())
+	for _, want := range []string{"tank zfs ONLINE, 1.52x, scrub 31% done", "/data btrfs ERRORS, 3 errors, scrub no errors found"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the view, got:\n%s", want, view)
+		}
+	}
+	if strings.Index(view, "tank") > strings.Index(view, "50") {
+		t.Errorf("Expected the pools above the filesystems, got:\n%s", view)
+	}
+}
@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// matchesFilter reports whether value contains filter, ignoring case; an empty
+// filter matches everything
+func matchesFilter(value, filter string) bool {
+	return filter == "" || strings.Contains(strings.ToLower(value), strings.ToLower(filter))
+}
+
+// renderFilteredHeader renders a panel title followed by the active filter and
+// how many of the panel's entries it lets through
+func renderFilteredHeader(styleManager *StyleManager, title, filter string, shown, total int) string {
+	header := styleManager.RenderHeader(title)
+	if filter == "" {
+		return header
+	}
+	return header + " " + styleManager.RenderMutedText(fmt.Sprintf("/%s (%d of %d)", filter, shown, total))
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BarMode selects the glyphs used for progress bars and graphs
+type BarMode int
+
+const (
+	BarBlocks  BarMode = iota // Block glyphs; bar glyphs can be overridden in the config file
+	BarBraille                // Braille dots, twice the horizontal resolution
+	BarASCII                  // Plain ASCII for terminals without Unicode support
+)
+
+// ParseBarMode parses "blocks", "braille" or "ascii"
+func ParseBarMode(name string) (BarMode, error) {
+	switch strings.ToLower(name) {
+	case "", "blocks":
+		return BarBlocks, nil
+	case "braille":
+		return BarBraille, nil
+	case "ascii":
+		return BarASCII, nil
+	default:
+		return BarBlocks, fmt.Errorf("unknown bar mode %q (want blocks, braille or ascii)", name)
+	}
+}
+
+// String returns the bar mode name
+func (b BarMode) String() string {
+	switch b {
+	case BarBraille:
+		return "braille"
+	case BarASCII:
+		return "ascii"
+	default:
+		return "blocks"
+	}
+}
+
+// Graph levels from empty to full, one sample per cell
+var (
+	blockLevels = []rune(" ▁▂▃▄▅▆▇█")
+	asciiLevels = []rune(" .:-=+*#")
+)
+
+// Braille dots of the left and right column of a cell, bottom to top
+var (
+	brailleLeft  = []rune{0x40, 0x04, 0x02, 0x01}
+	brailleRight = []rune{0x80, 0x20, 0x10, 0x08}
+)
+
+const brailleBlank = 0x2800
+
+// appendBrailleBar appends a bar of width cells with two steps per cell to buf
+func appendBrailleBar(buf []byte, percentage float64, width int) []byte {
+	steps := int(percentage / 100.0 * float64(2*width))
+	if steps < 0 {
+		steps = 0
+	}
+	if steps > 2*width {
+		steps = 2 * width
+	}
+
+	for i := 0; i < width; i++ {
+		cell := rune(brailleBlank) | brailleLeft[0] | brailleRight[0] // The bottom row marks the track
+		if steps > 2*i {
+			cell |= brailleLeft[1] | brailleLeft[2] | brailleLeft[3]
+		}
+		if steps > 2*i+1 {
+			cell |= brailleRight[1] | brailleRight[2] | brailleRight[3]
+		}
+		buf = utf8.AppendRune(buf, cell)
+	}
+	return buf
+}
+
+// RenderGraph renders the most recent values (0-100) as a one-line graph of
+// width cells, oldest on the left. Braille fits two values into each cell.
+// Every cell is colored by its usage level.
+func (s *StyleManager) RenderGraph(values []float64, width int) string {
+	return s.RenderMarkedGraph(values, width, nil)
+}
+
+// RenderMarkedGraph renders a graph like RenderGraph with a vertical marker
+// in place of each of the marked cells, counted from the left edge
+func (s *StyleManager) RenderMarkedGraph(values []float64, width int, marks []int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	perCell := s.graphPoints(1)
+	if max := s.graphPoints(width); len(values) > max {
+		values = values[len(values)-max:]
+	}
+
+	// Pad on the left so the newest value is always in the last cell
+	padding := width - (len(values)+perCell-1)/perCell
+	marked := make(map[int]bool, len(marks))
+	for _, mark := range marks {
+		marked[mark] = true
+	}
+	marker := lipgloss.NewStyle().Foreground(s.colors.Header).Render(string(s.graphMarker()))
+
+	var b strings.Builder
+	for i := 0; i < padding; i++ {
+		if marked[i] {
+			b.WriteString(marker)
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	if len(values)%perCell != 0 {
+		values = append([]float64{0}, values...)
+	}
+
+	for i := 0; i < len(values); i += perCell {
+		if marked[padding+i/perCell] {
+			b.WriteString(marker)
+			continue
+		}
+		cell := values[i : i+perCell]
+		peak := cell[len(cell)-1]
+		for _, value := range cell {
+			peak = math.Max(peak, value)
+		}
+		glyph := s.graphCell(cell)
+		b.WriteString(lipgloss.NewStyle().Foreground(s.GetUsageColor(peak)).Render(string(glyph)))
+	}
+	return b.String()
+}
+
+// graphPoints returns how many values a graph of width cells shows: braille
+// cells hold two
+func (s *StyleManager) graphPoints(width int) int {
+	if s.barMode == BarBraille {
+		return width * 2
+	}
+	return width
+}
+
+// graphMarker returns the glyph of a marker on a graph
+func (s *StyleManager) graphMarker() rune {
+	if s.barMode == BarASCII {
+		return '|'
+	}
+	return '│'
+}
+
+// graphCell returns the glyph for one cell of values
+func (s *StyleManager) graphCell(values []float64) rune {
+	switch s.barMode {
+	case BarBraille:
+		cell := rune(brailleBlank)
+		for column, dots := range [][]rune{brailleLeft, brailleRight} {
+			for i := 0; i < graphLevel(values[column], len(dots)); i++ {
+				cell |= dots[i]
+			}
+		}
+		return cell
+	case BarASCII:
+		return asciiLevels[graphLevel(values[0], len(asciiLevels)-1)]
+	default:
+		return blockLevels[graphLevel(values[0], len(blockLevels)-1)]
+	}
+}
+
+// graphLevel maps a percentage onto levels steps, showing any non-zero value
+func graphLevel(value float64, levels int) int {
+	level := int(math.Round(value / 100.0 * float64(levels)))
+	if level == 0 && value > 0 {
+		level = 1
+	}
+	if level > levels {
+		level = levels
+	}
+	if level < 0 {
+		level = 0
+	}
+	return level
+}
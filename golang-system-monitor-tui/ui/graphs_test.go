@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/config"
+)
+
+func TestParseBarMode(t *testing.T) {
+	for _, name := range config.BarModes {
+		mode, err := ParseBarMode(name)
+		if err != nil {
+			t.Errorf("ParseBarMode(%q) returned error: %v", name, err)
+		}
+		if mode.String() != name {
+			t.Errorf("Expected %q to round-trip, got %q", name, mode.String())
+		}
+	}
+	if _, err := ParseBarMode("dots"); err == nil {
+		t.Error("Expected error for an unknown bar mode")
+	}
+}
+
+func TestRenderProgressBar_Modes(t *testing.T) {
+	sm := NewStyleManager()
+
+	sm.SetBarMode(BarBraille)
+	// 45% of 10 cells is 9 half-cell steps: four full cells and a left half
+	if bar := sm.RenderProgressBar(45, 10, false); !strings.Contains(bar, "⣿⣿⣿⣿⣇⣀⣀⣀⣀⣀") {
+		t.Errorf("Expected a half-filled braille cell, got %q", bar)
+	}
+	if bar := sm.RenderProgressBar(100, 4, false); !strings.Contains(bar, "⣿⣿⣿⣿") {
+		t.Errorf("Expected a full braille bar, got %q", bar)
+	}
+
+	sm.SetBarMode(BarASCII)
+	if bar := sm.RenderProgressBar(50, 8, false); !strings.Contains(bar, "####----") {
+		t.Errorf("Expected an ASCII bar, got %q", bar)
+	}
+	if border := sm.RenderComponentBorder("x", false, 5, 1); !strings.Contains(border, "+") || strings.Contains(border, "╭") {
+		t.Errorf("Expected an ASCII border, got %q", border)
+	}
+}
+
+func TestRenderGraph(t *testing.T) {
+	values := []float64{0, 10, 30, 50, 70, 90, 100, 5, 60}
+
+	tests := []struct {
+		mode BarMode
+		want string
+	}{
+		{BarBlocks, "▄▆▇█▁▅"},
+		{BarASCII, "=+*#.="},
+		{BarBraille, "⠀⣀⣴⣿⣠"},
+	}
+	for _, test := range tests {
+		sm := NewStyleManager()
+		sm.SetBarMode(test.mode)
+		graph := sm.RenderGraph(values, 6)
+		if lipgloss.Width(graph) != 6 {
+			t.Errorf("%v: expected a graph of width 6, got %d: %q", test.mode, lipgloss.Width(graph), graph)
+		}
+		if !strings.Contains(stripStyles(graph), test.want) {
+			t.Errorf("%v: expected newest values %q, got %q", test.mode, test.want, stripStyles(graph))
+		}
+	}
+
+	sm := NewStyleManager()
+	if graph := sm.RenderGraph([]float64{50}, 4); !strings.HasPrefix(graph, "   ") {
+		t.Errorf("Expected short histories to be right-aligned, got %q", graph)
+	}
+	if sm.RenderGraph(values, 0) != "" {
+		t.Error("Expected no graph without width")
+	}
+}
+
+func TestApplyConfig_BarMode(t *testing.T) {
+	sm := NewStyleManager()
+	sm.ApplyConfig(config.Config{
+		Style:  config.Style{Bars: "braille"},
+		Panels: map[string]config.Style{"disk": {Bars: "ascii"}},
+	})
+	if sm.GetBarMode() != BarBraille || sm.ForPanel("disk").GetBarMode() != BarASCII {
+		t.Errorf("Expected braille with ASCII disk bars, got %v and %v", sm.GetBarMode(), sm.ForPanel("disk").GetBarMode())
+	}
+
+	// The command-line flags override every panel
+	sm.SetBarMode(BarBlocks)
+	if sm.ForPanel("disk").GetBarMode() != BarBlocks {
+		t.Error("Expected SetBarMode to update the panel style managers")
+	}
+}
+
+// stripStyles removes the color escapes of rendered text
+func stripStyles(text string) string {
+	return ansiPattern.ReplaceAllString(text, "")
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"log"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+)
+
+const (
+	// historySaveInterval is how often the metric history is saved while
+	// running, bounding how much of it a crash loses
+	historySaveInterval = time.Minute
+	// historyMaxAge is the age of the oldest saved history still restored
+	historyMaxAge = 10 * time.Minute
+)
+
+// SetHistoryFile sets the file the metric history is saved to while running,
+// so a restart can restore it; empty disables saving
+func (m MainModel) SetHistoryFile(path string) MainModel {
+	m.historyPath = path
+	m.historySavedAt = m.now()
+	return m
+}
+
+// History returns the metric history shown in the graphs, for saving
+func (m MainModel) History() models.HistoryRecord {
+	return models.HistoryRecord{
+		SavedAt:  m.now(),
+		CPUTotal: m.cpu.GetTotalHistory(),
+		CPUCores: m.cpu.GetHistory(),
+		CPULong:  m.cpu.GetLongHistorySnapshot(),
+	}
+}
+
+// RestoreHistory fills the graphs with history saved by an earlier run.
+// History saved more than historyMaxAge ago is ignored.
+func (m MainModel) RestoreHistory(record models.HistoryRecord) MainModel {
+	if !record.Fresh(m.now(), historyMaxAge) {
+		return m
+	}
+	m.cpu = m.cpu.RestoreHistory(record.CPUTotal, record.CPUCores, record.CPULong, record.SavedAt, m.updateInterval)
+	return m
+}
+
+// saveHistoryCmd saves the current metric history to the history file
+func (m MainModel) saveHistoryCmd() tea.Cmd {
+	path, record := m.historyPath, m.History()
+	return func() tea.Msg {
+		if err := services.SaveHistory(path, record); err != nil {
+			log.Printf("Saving history: %v", err)
+		}
+		return nil
+	}
+}
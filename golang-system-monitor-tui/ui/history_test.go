@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestMainModel_HistoryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+
+	model := NewMainModel().SetDeterministic(true).SetHistoryFile(path)
+	for _, total := range []float64{10, 20, 30} {
+		updated, _ := model.Update(CPUUpdateMsg{Usage: []float64{total - 5, total + 5}, Total: total, Cores: 2})
+		model = updated.(MainModel)
+	}
+	if msg := model.saveHistoryCmd()(); msg != nil {
+		t.Errorf("Expected no message from saving the history, got %#v", msg)
+	}
+
+	record, err := services.LoadHistory(path)
+	if err != nil || !record.SavedAt.Equal(DeterministicTime) {
+		t.Fatalf("Expected history saved at %v, got %+v (%v)", DeterministicTime, record, err)
+	}
+	if !reflect.DeepEqual(record.CPUTotal, []float64{10, 20, 30}) {
+		t.Errorf("Expected the total usage history, got %v", record.CPUTotal)
+	}
+
+	restored := NewMainModel().SetDeterministic(true).RestoreHistory(record)
+	if got := restored.GetCPUModel().GetTotalHistory(); !reflect.DeepEqual(got, []float64{10, 20, 30}) {
+		t.Errorf("Expected the saved total history restored, got %v", got)
+	}
+	if got := restored.GetCPUModel().GetHistory(); !reflect.DeepEqual(got, [][]float64{{5, 15, 25}, {15, 25, 35}}) {
+		t.Errorf("Expected the saved core history restored, got %v", got)
+	}
+
+	// Live samples continue the restored series
+	updated, _ := restored.Update(CPUUpdateMsg{Usage: []float64{35, 45}, Total: 40, Cores: 2})
+	if got := updated.(MainModel).GetCPUModel().GetTotalHistory(); !reflect.DeepEqual(got, []float64{10, 20, 30, 40}) {
+		t.Errorf("Expected the live sample appended to the restored history, got %v", got)
+	}
+}
+
+func TestMainModel_HistoryKeepsLongRanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+
+	// Three hours of samples, far more than the recent history keeps
+	model := NewMainModel().SetDeterministic(true).SetHistoryFile(path)
+	start := DeterministicTime.Add(-3 * time.Hour)
+	for i := 0; i < 3*60; i++ {
+		total := float64(i % 100)
+		updated, _ := model.Update(CPUUpdateMsg{Usage: []float64{total}, Total: total, Cores: 1, Timestamp: start.Add(time.Duration(i) * time.Minute)})
+		model = updated.(MainModel)
+	}
+	model.saveHistoryCmd()()
+
+	record, err := services.LoadHistory(path)
+	if err != nil {
+		t.Fatalf("LoadHistory failed: %v", err)
+	}
+	restored := NewMainModel().SetDeterministic(true).RestoreHistory(record)
+	for _, span := range []time.Duration{time.Hour, 24 * time.Hour} {
+		want := model.GetCPUModel().GetLongHistory(span)
+		got := restored.GetCPUModel().GetLongHistory(span)
+		if len(got) <= model.GetCPUModel().maxHistory || !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected the %v range restored with %d values, got %d", span, len(want), len(got))
+		}
+	}
+}
+
+func TestMainModel_RestoreStaleHistory(t *testing.T) {
+	record := models.HistoryRecord{
+		SavedAt:  DeterministicTime.Add(-historyMaxAge - time.Minute),
+		CPUTotal: []float64{10, 20},
+	}
+	model := NewMainModel().SetDeterministic(true).RestoreHistory(record)
+	if got := model.GetCPUModel().GetTotalHistory(); len(got) != 0 {
+		t.Errorf("Expected stale history to be ignored, got %v", got)
+	}
+}
+
+func TestMainModel_HistorySavedPeriodically(t *testing.T) {
+	clock := models.NewFakeClock(DeterministicTime)
+	model := NewMainModel().SetClock(clock).SetCollector(fake.NewCollector())
+	model = model.SetHistoryFile(filepath.Join(t.TempDir(), "history.json"))
+
+	updated, _ := model.Update(TickMsg(clock.Now()))
+	if saved := updated.(MainModel).historySavedAt; !saved.Equal(DeterministicTime) {
+		t.Errorf("Expected no save before the interval passed, last save at %v", saved)
+	}
+
+	clock.Advance(historySaveInterval)
+	updated, _ = updated.(MainModel).Update(TickMsg(clock.Now()))
+	if saved := updated.(MainModel).historySavedAt; !saved.Equal(clock.Now()) {
+		t.Errorf("Expected the history saved after %v, last save at %v", historySaveInterval, saved)
+	}
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+)
+
+// KernelUpdateMsg carries a sample of the kernel counters, on Linux only
+type KernelUpdateMsg models.KernelStats
+
+// KernelModel represents the kernel mini-panel: context switches, interrupts
+// and forks per second, and the available entropy
+type KernelModel struct {
+	stats        models.KernelStats // Latest sample, zero until the first one
+	rates        models.KernelRates // Growth since the previous sample
+	hasRates     bool               // Whether two samples were seen yet
+	resync       bool               // The next sample starts a new baseline instead of computing rates
+	width        int                // Component width for rendering
+	height       int                // Component height for rendering
+	styleManager *StyleManager      // Style manager for consistent styling
+}
+
+// NewKernelModel creates a new kernel model instance
+func NewKernelModel() KernelModel {
+	return KernelModel{
+		width:        30,
+		height:       10,
+		styleManager: NewStyleManager(),
+	}
+}
+
+// Resync makes the next sample a new baseline for the rates, after a gap in
+// the samples such as a suspend
+func (m KernelModel) Resync() KernelModel {
+	m.resync = true
+	return m
+}
+
+// Init initializes the kernel model
+func (m KernelModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages and updates the kernel model state
+func (m KernelModel) Update(msg tea.Msg) (KernelModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case KernelUpdateMsg:
+		stats := models.KernelStats(msg)
+		if m.resync {
+			m.rates, m.hasRates, m.resync = models.KernelRates{}, false, false
+		} else if !m.stats.Timestamp.IsZero() {
+			m.rates, m.hasRates = stats.Rates(m.stats), true
+		}
+		m.stats = stats
+	}
+	return m, nil
+}
+
+// View renders the kernel model
+func (m KernelModel) View() string {
+	if m.stats.Timestamp.IsZero() {
+		return m.styleManager.RenderPlaceholder("Kernel", "Loading kernel counters...")
+	}
+
+	sections := []string{
+		m.styleManager.RenderHeader("Kernel"),
+		"Context switches: " + m.formatRate(m.rates.ContextSwitches),
+		"Interrupts:       " + m.formatRate(m.rates.Interrupts),
+		"Forks:            " + m.formatRate(m.rates.Forks),
+	}
+	entropy := "unknown"
+	if m.stats.Entropy >= 0 {
+		entropy = m.styleManager.Locale().FormatInt(int64(m.stats.Entropy)) + " bits"
+	}
+	sections = append(sections, "Entropy:          "+entropy)
+
+	// Add spacing if we have fewer lines than available height
+	for len(sections) < m.height {
+		sections = append(sections, "")
+	}
+	return strings.Join(sections, "\n")
+}
+
+// formatRate renders a per-second rate, a dash until two samples were seen
+func (m KernelModel) formatRate(rate float64) string {
+	if !m.hasRates {
+		return "-"
+	}
+	return m.styleManager.Locale().FormatFloat(rate, 0) + "/s"
+}
+
+// SetSize sets the component dimensions
+func (m KernelModel) SetSize(width, height int) KernelModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m KernelModel) SetStyleManager(styleManager *StyleManager) KernelModel {
+	m.styleManager = styleManager
+	return m
+}
+
+// GetStats returns the latest sample of the kernel counters
+func (m KernelModel) GetStats() models.KernelStats {
+	return m.stats
+}
+
+// GetRates returns the counter rates between the last two samples, false
+// before the second sample
+func (m KernelModel) GetRates() (models.KernelRates, bool) {
+	return m.rates, m.hasRates
+}
+
+// GetKernelModel returns the kernel model
+func (m MainModel) GetKernelModel() KernelModel {
+	return m.kernel
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+)
+
+func TestKernelModel_View(t *testing.T) {
+	model := NewKernelModel().SetSize(40, 6)
+	if !strings.Contains(model.View(), "Loading kernel counters") {
+		t.Error("Expected a placeholder before the first sample")
+	}
+
+	model, _ = model.Update(KernelUpdateMsg{ContextSwitches: 1000, Interrupts: 500, Forks: 10, Entropy: -1, Timestamp: DeterministicTime})
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "Context switches: -") || !strings.Contains(view, "Entropy:          unknown") {
+		t.Errorf("Expected dashes until the second sample, got:\n%s", view)
+	}
+
+	model, _ = model.Update(KernelUpdateMsg{ContextSwitches: 5000, Interrupts: 2500, Forks: 30, Entropy: 256, Timestamp: DeterministicTime.Add(2 * time.Second)})
+	view = stripStyles(model.View())
+	for _, want := range []string{"Context switches: 2000/s", "Interrupts:       1000/s", "Forks:            10/s", "Entropy:          256 bits"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q, got:\n%s", want, view)
+		}
+	}
+	if lines := strings.Split(view, "\n"); len(lines) != 6 {
+		t.Errorf("Expected the view to fill 6 lines, got %d", len(lines))
+	}
+
+	// A counter reset reads 0 rather than a bogus spike
+	model, _ = model.Update(KernelUpdateMsg{ContextSwitches: 10, Interrupts: 3000, Forks: 31, Timestamp: DeterministicTime.Add(3 * time.Second)})
+	if rates, _ := model.GetRates(); rates.ContextSwitches != 0 || rates.Interrupts != 500 {
+		t.Errorf("Expected the reset counter to read 0, got %+v", rates)
+	}
+}
+
+func TestMainModel_KernelPanel(t *testing.T) {
+	cfg := config.Default()
+	cfg.Layout.Panels = []string{"cpu", "kernel"}
+	model := NewMainModel().ApplyConfig(cfg)
+	var updated tea.Model = model
+	for _, msg := range []tea.Msg{
+		tea.WindowSizeMsg{Width: 120, Height: 30},
+		collectedMsg("Kernel", models.KernelStats{ContextSwitches: 100, Timestamp: DeterministicTime}),
+		collectedMsg("Kernel", models.KernelStats{ContextSwitches: 400, Timestamp: DeterministicTime.Add(time.Second)}),
+	} {
+		updated, _ = updated.Update(msg)
+	}
+	model = updated.(MainModel)
+
+	if view := stripStyles(model.View()); !strings.Contains(view, "Context switches: 300/s") {
+		t.Errorf("Expected the kernel panel in the grid, got:\n%s", view)
+	}
+	if got := model.panelSummary(FocusKernel); len(got) != 1 || !strings.HasPrefix(got[0], "Kernel 300 context switches") {
+		t.Errorf("Unexpected kernel summary %q", got)
+	}
+}
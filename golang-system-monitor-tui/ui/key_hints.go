@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// footerSeparator joins hints in the application footer
+const footerSeparator = " • "
+
+// keyLabels maps key names to the shorter labels shown in hints
+var keyLabels = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+}
+
+// KeyHint is a footer hint built from one or more key bindings of the keymap,
+// so the footer always shows the keys that are actually bound
+type KeyHint struct {
+	Keys []string // Primary key of each binding, e.g. "ctrl+left"
+	Desc string   // Short description of the action
+}
+
+// NewKeyHint creates a hint from the primary key of each binding; bindings
+// without keys are skipped
+func NewKeyHint(desc string, bindings ...[]string) KeyHint {
+	hint := KeyHint{Desc: desc}
+	for _, keys := range bindings {
+		if len(keys) > 0 {
+			hint.Keys = append(hint.Keys, keys[0])
+		}
+	}
+	return hint
+}
+
+// String renders the hint as "key: description", e.g. "↑/↓: select"
+func (h KeyHint) String() string {
+	labels := make([]string, len(h.Keys))
+	for i, key := range h.Keys {
+		labels[i] = keyLabel(key)
+	}
+	return strings.Join(labels, "/") + ": " + h.Desc
+}
+
+// keyLabel shortens arrow key names, keeping modifiers ("ctrl+left" becomes "ctrl+←")
+func keyLabel(key string) string {
+	prefix := ""
+	if i := strings.LastIndex(key, "+"); i >= 0 && i < len(key)-1 {
+		prefix, key = key[:i+1], key[i+1:]
+	}
+	if label, ok := keyLabels[key]; ok {
+		key = label
+	}
+	return prefix + key
+}
+
+// FitHints renders hints in order, dropping hints from the end of contextual
+// until the footer fits into width; global hints are always kept
+func FitHints(contextual, global []KeyHint, width int) []string {
+	render := func(hints []KeyHint) []string {
+		shortcuts := make([]string, 0, len(hints))
+		for _, hint := range hints {
+			if len(hint.Keys) > 0 {
+				shortcuts = append(shortcuts, hint.String())
+			}
+		}
+		return shortcuts
+	}
+
+	for n := len(contextual); n > 0; n-- {
+		shortcuts := render(append(contextual[:n:n], global...))
+		if lipgloss.Width(strings.Join(shortcuts, footerSeparator)) <= width {
+			return shortcuts
+		}
+	}
+	return render(global)
+}
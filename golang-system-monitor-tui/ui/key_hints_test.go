@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeyHintString(t *testing.T) {
+	tests := []struct {
+		hint KeyHint
+		want string
+	}{
+		{NewKeyHint("quit", []string{"q", "ctrl+c"}), "q: quit"},
+		{NewKeyHint("select", []string{"up", "k"}, []string{"down", "j"}), "↑/↓: select"},
+		{NewKeyHint("resize", []string{"ctrl+left"}, []string{"ctrl+right"}), "ctrl+←/ctrl+→: resize"},
+		{NewKeyHint("add", []string{"+"}), "+: add"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.hint.String(); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestFitHints(t *testing.T) {
+	contextual := []KeyHint{NewKeyHint("refresh", []string{"r"}), NewKeyHint("temps", []string{"t"})}
+	global := []KeyHint{NewKeyHint("quit", []string{"q"})}
+
+	if got := FitHints(contextual, global, 80); !reflect.DeepEqual(got, []string{"r: refresh", "t: temps", "q: quit"}) {
+		t.Errorf("Expected all hints on a wide footer, got %v", got)
+	}
+
+	// Contextual hints are dropped from the end, global hints stay
+	if got := FitHints(contextual, global, 24); !reflect.DeepEqual(got, []string{"r: refresh", "q: quit"}) {
+		t.Errorf("Expected the last contextual hint dropped, got %v", got)
+	}
+	if got := FitHints(contextual, global, 5); !reflect.DeepEqual(got, []string{"q: quit"}) {
+		t.Errorf("Expected only global hints, got %v", got)
+	}
+
+	// Unbound actions are not hinted
+	unbound := []KeyHint{NewKeyHint("sort", nil)}
+	if got := FitHints(unbound, global, 80); strings.Contains(strings.Join(got, " "), "sort") {
+		t.Errorf("Expected unbound hint to be skipped, got %v", got)
+	}
+}
@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+)
+
+// maxLogLines is the number of followed log lines kept for the log panel
+const maxLogLines = 1000
+
+// LogUpdateMsg carries the lines appended to the followed log file
+type LogUpdateMsg models.LogTail
+
+// logLine is a followed log line with its severity; markers note that the
+// file was truncated or replaced
+type logLine struct {
+	text   string
+	level  models.LogLevel
+	marker bool
+}
+
+// LogModel represents the log file panel: the newest lines of the followed
+// file, errors and warnings highlighted
+type LogModel struct {
+	path         string        // Followed file
+	lines        []logLine     // Newest last, at most maxLogLines
+	width        int           // Component width for rendering
+	height       int           // Component height for rendering
+	styleManager *StyleManager // Style manager for consistent styling
+	filter       string        // Line filter typed with /
+	hasError     bool          // Whether the file could not be read
+	errorMessage string        // Why the file could not be read
+	lastError    time.Time     // Timestamp of the last read failure
+}
+
+// NewLogModel creates a new log model instance
+func NewLogModel() LogModel {
+	return LogModel{
+		width:        60,
+		height:       10,
+		styleManager: NewStyleManager(),
+	}
+}
+
+// Init initializes the log model
+func (m LogModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages and updates the log model state
+func (m LogModel) Update(msg tea.Msg) (LogModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case LogUpdateMsg:
+		m.path = msg.Path
+		if msg.Error != "" {
+			m.hasError = true
+			m.errorMessage = msg.Error
+			m.lastError = msg.Timestamp
+			return m, nil
+		}
+		m.hasError = false
+		m.errorMessage = ""
+
+		// Append to a copy, earlier models share the backing array
+		lines := make([]logLine, len(m.lines), len(m.lines)+len(msg.Lines)+1)
+		copy(lines, m.lines)
+		if msg.Reset && len(lines) > 0 {
+			lines = append(lines, logLine{text: "-- " + filepath.Base(msg.Path) + " was truncated or replaced --", marker: true})
+		}
+		for _, text := range msg.Lines {
+			lines = append(lines, logLine{text: text, level: models.ParseLogLevel(text)})
+		}
+		if len(lines) > maxLogLines {
+			lines = lines[len(lines)-maxLogLines:]
+		}
+		m.lines = lines
+	}
+	return m, nil
+}
+
+// View renders the log model
+func (m LogModel) View() string {
+	title := "Log"
+	if m.path != "" {
+		title = "Log: " + filepath.Base(m.path)
+	}
+	if m.path == "" && !m.hasError {
+		return m.styleManager.RenderPlaceholder(title, "No log file followed, start with -tail <file>")
+	}
+
+	visible := m.visibleLines()
+	sections := []string{renderFilteredHeader(m.styleManager, title, m.filter, len(visible), len(m.lines))}
+	if m.hasError {
+		sections = append(sections, m.styleManager.RenderErrorText(truncate("Error: "+m.errorMessage, m.width)))
+	}
+	switch {
+	case len(m.lines) == 0 && !m.hasError:
+		sections = append(sections, m.styleManager.RenderMutedText("Waiting for new lines..."))
+	case len(visible) == 0 && len(m.lines) > 0:
+		sections = append(sections, m.styleManager.RenderMutedText("No lines match the filter"))
+	}
+
+	// Follow the end of the file: the newest lines that fit, oldest first
+	room := max(m.height-len(sections), 0)
+	if len(visible) > room {
+		visible = visible[len(visible)-room:]
+	}
+	for _, line := range visible {
+		text := truncate(strings.ReplaceAll(line.text, "\t", "    "), m.width)
+		switch {
+		case line.marker:
+			text = m.styleManager.RenderMutedText(text)
+		case line.level == models.LogLevelError:
+			text = m.styleManager.RenderCriticalText(text)
+		case line.level == models.LogLevelWarning:
+			text = m.styleManager.RenderWarningText(text)
+		}
+		sections = append(sections, text)
+	}
+
+	// Add spacing if we have fewer lines than available height
+	for len(sections) < m.height {
+		sections = append(sections, "")
+	}
+	return strings.Join(sections, "\n")
+}
+
+// visibleLines returns the kept lines that match the filter, oldest first
+func (m LogModel) visibleLines() []logLine {
+	if m.filter == "" {
+		return m.lines
+	}
+	var lines []logLine
+	for _, line := range m.lines {
+		if !line.marker && matchesFilter(line.text, m.filter) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// SetSize sets the component dimensions
+func (m LogModel) SetSize(width, height int) LogModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m LogModel) SetStyleManager(styleManager *StyleManager) LogModel {
+	m.styleManager = styleManager
+	return m
+}
+
+// SetPath sets the followed file shown in the title before its first lines arrive
+func (m LogModel) SetPath(path string) LogModel {
+	m.path = path
+	return m
+}
+
+// GetPath returns the followed file
+func (m LogModel) GetPath() string {
+	return m.path
+}
+
+// GetLines returns the text of the kept lines, oldest first
+func (m LogModel) GetLines() []string {
+	lines := make([]string, 0, len(m.lines))
+	for _, line := range m.lines {
+		lines = append(lines, line.text)
+	}
+	return lines
+}
+
+// SetFilter shows only lines containing filter
+func (m LogModel) SetFilter(filter string) LogModel {
+	m.filter = filter
+	return m
+}
+
+// GetFilter returns the line filter
+func (m LogModel) GetFilter() string {
+	return m.filter
+}
+
+// HasError returns whether the followed file could not be read
+func (m LogModel) HasError() bool {
+	return m.hasError
+}
+
+// logTabPanels are the panels of the tab added for a followed log file, to
+// correlate its lines with resource spikes
+var logTabPanels = []FocusedComponent{FocusCPU, FocusMemory, FocusNetwork, FocusLog}
+
+// SetLogFile follows the log file at path in the log panel. Unless a tab
+// already shows the log panel, a Logs tab is added next to the resources.
+func (m MainModel) SetLogFile(path string) MainModel {
+	m.logPath = path
+	m.registry.Replace(services.NewLogTailer(path))
+	m.logView = m.logView.SetPath(path)
+	return m.addLogTab()
+}
+
+// addLogTab adds the Logs tab when a log file is followed and no tab shows
+// the log panel yet
+func (m MainModel) addLogTab() MainModel {
+	if m.logPath == "" || len(m.tabs) >= config.MaxTabs {
+		return m
+	}
+	for _, tab := range m.tabs {
+		for _, panel := range tab.panels {
+			if panel == FocusLog {
+				return m
+			}
+		}
+	}
+	m.tabs = append(m.tabs, gridTab{name: "Logs", panels: logTabPanels, columns: 2})
+	return m
+}
+
+// GetLogModel returns the log model
+func (m MainModel) GetLogModel() LogModel {
+	return m.logView
+}
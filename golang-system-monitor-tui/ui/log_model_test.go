@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestLogModel_View(t *testing.T) {
+	NewMainModel().SetDeterministic(true) // Pins a color profile
+	model := NewLogModel().SetSize(60, 5)
+	if view := stripStyles(model.View()); !strings.Contains(view, "No log file followed") {
+		t.Errorf("Expected a placeholder without a log file, got:\n%s", view)
+	}
+
+	model = model.SetPath("/var/log/syslog")
+	if view := stripStyles(model.View()); !strings.Contains(view, "Log: syslog") || !strings.Contains(view, "Waiting for new lines") {
+		t.Errorf("Expected the file name and a waiting note, got:\n%s", view)
+	}
+
+	model, _ = model.Update(LogUpdateMsg{Path: "/var/log/syslog", Lines: []string{
+		"sshd[1]: session opened",
+		"kernel: ERROR: I/O error on sda",
+		"app: WARN slow request",
+		"cron[2]: job done",
+		"app: request served",
+	}})
+	view := model.View()
+	lines := strings.Split(view, "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected the view to fill 5 lines, got %d", len(lines))
+	}
+	// The newest lines that fit follow the header
+	if plain := stripStyles(view); strings.Contains(plain, "session opened") || !strings.Contains(lines[4], "request served") {
+		t.Errorf("Expected the newest 4 lines, got:\n%s", plain)
+	}
+	sm := NewStyleManager()
+	if !strings.Contains(view, sm.RenderCriticalText("kernel: ERROR: I/O error on sda")) || !strings.Contains(view, sm.RenderWarningText("app: WARN slow request")) {
+		t.Errorf("Expected errors and warnings highlighted, got %q", view)
+	}
+
+	model = model.SetFilter("app")
+	if plain := stripStyles(model.View()); !strings.Contains(plain, "/app (2 of 5)") || strings.Contains(plain, "cron") {
+		t.Errorf("Expected only matching lines, got:\n%s", plain)
+	}
+	model = model.SetFilter("nothing")
+	if plain := stripStyles(model.View()); !strings.Contains(plain, "No lines match the filter") {
+		t.Errorf("Expected a note when nothing matches, got:\n%s", plain)
+	}
+}
+
+func TestLogModel_Update(t *testing.T) {
+	model := NewLogModel().SetSize(60, 10)
+	model, _ = model.Update(LogUpdateMsg{Path: "/tmp/app.log", Lines: []string{"one"}})
+
+	// Read failures keep the lines already shown
+	model, _ = model.Update(LogUpdateMsg{Path: "/tmp/app.log", Error: "permission denied", Timestamp: time.Now()})
+	if !model.HasError() || !strings.Contains(stripStyles(model.View()), "Error: permission denied") || len(model.GetLines()) != 1 {
+		t.Errorf("Expected the error above the kept lines, got:\n%s", stripStyles(model.View()))
+	}
+
+	model, _ = model.Update(LogUpdateMsg{Path: "/tmp/app.log", Lines: []string{"fresh"}, Reset: true})
+	if model.HasError() || !strings.Contains(stripStyles(model.View()), "app.log was truncated or replaced") {
+		t.Errorf("Expected a marker for the replaced file, got:\n%s", stripStyles(model.View()))
+	}
+
+	// Only the newest lines are kept
+	many := make([]string, maxLogLines+10)
+	for i := range many {
+		many[i] = fmt.Sprintf("line %d", i)
+	}
+	model, _ = model.Update(LogUpdateMsg{Path: "/tmp/app.log", Lines: many})
+	if lines := model.GetLines(); len(lines) != maxLogLines || lines[len(lines)-1] != many[len(many)-1] {
+		t.Errorf("Expected the newest %d lines, got %d", maxLogLines, len(lines))
+	}
+}
+
+func TestMainModel_SetLogFile(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector())
+	model = model.SetLogFile("/var/log/app.log")
+	if _, ok := model.GetRegistry().Lookup(services.LogCollectorName); !ok {
+		t.Fatal("Expected the log tailer to be registered")
+	}
+	names := model.GetTabNames()
+	if names[len(names)-1] != "Logs" {
+		t.Fatalf("Expected a Logs tab, got %v", names)
+	}
+
+	// The tab survives a config change, and is not added twice
+	model = model.ApplyConfig(model.settings).SetLogFile("/var/log/app.log")
+	if got := model.GetTabNames(); len(got) != len(names) {
+		t.Errorf("Expected the tabs to stay %v, got %v", names, got)
+	}
+
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	updated, _ = updated.(MainModel).Update(collectedMsg(services.LogCollectorName, models.LogTail{Path: "/var/log/app.log", Lines: []string{"app: ERROR timeout"}}))
+	updated, _ = updated.(MainModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(fmt.Sprint(len(names)))})
+	view := stripStyles(updated.(MainModel).View())
+	for _, want := range []string{"CPU Usage", "Log: app.log", "app: ERROR timeout"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q on the Logs tab, got:\n%s", want, view)
+		}
+	}
+
+	// The log panel can be filtered like the other lists
+	model = updated.(MainModel).SetFocusedComponent(FocusLog)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "timeout" {
+		updated, _ = updated.(MainModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if filter := updated.(MainModel).GetLogModel().GetFilter(); filter != "timeout" {
+		t.Errorf("Expected the typed filter on the log panel, got %q", filter)
+	}
+}
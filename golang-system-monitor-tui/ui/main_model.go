@@ -1,12 +1,18 @@
 package ui
 
 import (
+	"fmt"
+	"log"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	
+	"golang-system-monitor-tui/config"
 	"golang-system-monitor-tui/models"
 	"golang-system-monitor-tui/services"
 )
@@ -19,8 +25,92 @@ const (
 	FocusMemory
 	FocusDisk
 	FocusNetwork
+	FocusSensors
+	FocusAlerts
+	FocusLog
+	FocusSelf
+	FocusKernel
 )
 
+// focusPanels maps grid components to their panel names in the config file
+var focusPanels = map[FocusedComponent]string{
+	FocusCPU:     "cpu",
+	FocusMemory:  "memory",
+	FocusDisk:    "disk",
+	FocusNetwork: "network",
+	FocusSensors: "sensors",
+	FocusAlerts:  "alerts",
+	FocusLog:     "log",
+	FocusSelf:    "self",
+	FocusKernel:  "kernel",
+}
+
+// PanelName returns the config file name of the component's panel
+func (f FocusedComponent) PanelName() string {
+	return focusPanels[f]
+}
+
+// ParseFocusedComponent returns the grid component with the given panel name
+func ParseFocusedComponent(name string) (FocusedComponent, bool) {
+	for focus, panel := range focusPanels {
+		if panel == name {
+			return focus, true
+		}
+	}
+	return FocusCPU, false
+}
+
+// Pages lists the full-screen views that can be opened on top of the grid
+var Pages = []string{"sensors", "containers", "alerts", "plugins", "processes", "correlation", "help"}
+
+// IsPage reports whether name is one of Pages
+func IsPage(name string) bool {
+	for _, page := range Pages {
+		if page == name {
+			return true
+		}
+	}
+	return false
+}
+
+// gridTab is a page of grid components selected with the tab page keys
+type gridTab struct {
+	name    string
+	panels  []FocusedComponent
+	columns int
+}
+
+// newGridTabs builds the Overview tab from the layout followed by the configured tabs
+func newGridTabs(cfg config.Config) []gridTab {
+	panels, columns := cfg.Layout.Grid()
+	tabs := []gridTab{{name: "Overview", panels: gridPanels(panels), columns: columns}}
+	for _, tab := range cfg.TabList() {
+		panels, columns := tab.Grid()
+		tabs = append(tabs, gridTab{name: tab.Name, panels: gridPanels(panels), columns: columns})
+	}
+	return tabs
+}
+
+// tabIndex returns the tab selected by a tab page key, 1-9 or F1-F9
+func tabIndex(key string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimPrefix(key, "f"))
+	if err != nil || n < 1 || n > 9 {
+		return 0, false
+	}
+	return n - 1, true
+}
+
+// gridPanels converts panel names to grid components, skipping unknown names
+func gridPanels(names []string) []FocusedComponent {
+	panels := make([]FocusedComponent, 0, len(names))
+	for _, name := range names {
+		if focus, ok := ParseFocusedComponent(name); ok {
+			panels = append(panels, focus)
+		}
+	}
+	return panels
+}
+
 // KeyMap defines the keyboard shortcuts
 type KeyMap struct {
 	Up       []string
@@ -32,6 +122,37 @@ type KeyMap struct {
 	Quit     []string
 	Refresh  []string
 	Help     []string
+	Sensors  []string
+	Containers []string
+	Alerts   []string
+	Plugins  []string
+	Processes []string
+	Correlation []string
+	Units    []string
+	RateUnit []string
+	Sort     []string
+	Expand   []string
+	Zoom     []string
+	TabPages []string
+	Filter   []string
+	Screenshot     []string
+	ScreenshotANSI []string
+	ExportSnapshot []string
+	Annotate       []string
+	ShrinkColumn []string
+	GrowColumn   []string
+	ShrinkRow    []string
+	GrowRow      []string
+	Select   []string
+	Back     []string
+	Debug    []string
+	RangeShorter []string
+	RangeLonger  []string
+	PrevCore     []string
+	NextCore     []string
+	Command      []string
+	Palette      []string
+	Environ      []string
 }
 
 // DefaultKeyMap returns the default key mappings
@@ -46,27 +167,147 @@ func DefaultKeyMap() KeyMap {
 		Quit:     []string{"q", "ctrl+c"},
 		Refresh:  []string{"r"},
 		Help:     []string{"?", "h"},
+		Sensors:  []string{"t"},
+		Containers: []string{"c"},
+		Alerts:   []string{"a"},
+		Plugins:  []string{"p"},
+		Processes: []string{"P"},
+		Correlation: []string{"g"},
+		Units:    []string{"u"},
+		RateUnit: []string{"b"},
+		Sort:     []string{"o"},
+		Expand:   []string{"x"},
+		Zoom:     []string{"z"},
+		Filter:   []string{"/"},
+		Screenshot:     []string{"s"},
+		ScreenshotANSI: []string{"S"},
+		ExportSnapshot: []string{"e"},
+		Annotate:       []string{"m"},
+		TabPages: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "f1", "f2", "f3", "f4", "f5", "f6", "f7", "f8", "f9"},
+		ShrinkColumn: []string{"ctrl+left"},
+		GrowColumn:   []string{"ctrl+right"},
+		ShrinkRow:    []string{"ctrl+up"},
+		GrowRow:      []string{"ctrl+down"},
+		Select:   []string{"enter"},
+		Back:     []string{"esc"},
+		Debug:    []string{"f12"},
+		RangeShorter: []string{"["},
+		RangeLonger:  []string{"]"},
+		PrevCore:     []string{"<"},
+		NextCore:     []string{">"},
+		Command:      []string{":"},
+		Palette:      []string{"ctrl+p"},
+		Environ:      []string{"e"},
 	}
 }
 
 // TickMsg represents a ticker message for real-time updates
 type TickMsg time.Time
 
+// DeterministicTime is the frozen clock of the deterministic render mode
+var DeterministicTime = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+const (
+	topProcessCount    = 3   // Number of top CPU consumers shown in the CPU panel
+	processPageCount   = 30  // Number of processes listed on the processes page
+	topProcessInterval = 5   // Collect top processes every N ticks, walking the process table is costly
+	alertHistorySize   = 100 // Number of fired/cleared alerts kept for the alerts panel
+	alertCapturePool   = 1024 // Processes whose memory is read to find the largest when a Memory alert fires
+	splitStep          = 0.05 // Split ratio change per resize key press
+	gridTop            = 2   // Screen row of the grid, below the header and export status lines
+)
+
+// splitDrag identifies the grid boundary being dragged with the mouse
+type splitDrag int
+
+const (
+	dragNone splitDrag = iota
+	dragColumn
+	dragRow
+)
+
 // MainModel represents the main application model integrating all components
 type MainModel struct {
 	cpu     CPUModel
 	memory  MemoryModel
 	disk    DiskModel
 	network NetworkModel
+	sensors SensorsModel
+	containers ContainersModel
+	alertsPanel AlertsModel
+	logView     LogModel
+	self        SelfModel
+	kernel      KernelModel
+	processes   ProcessesModel
+	logPath     string // Log file followed in the log panel, set with SetLogFile
+	plugins []PluginModel // Panels of the configured plugins and panel scripts, in that order
+	scripts []*services.Script // Panel scripts run against the snapshot on every tick
+	host    models.HostInfo // Hostname and boot time for the status bar
+	files   models.FileDescriptors // System-wide open files for the status bar, on Linux only
+	processStates   models.ProcessStates          // Zombie and D-state counts for the status bar, on Linux only
+	stateThresholds models.ProcessStateThresholds // Counts above which processStates warn
 	focused FocusedComponent
+	panels  []FocusedComponent // Components in the grid of the active tab, in layout order
+	tabs    []gridTab
+	activeTab int
 	keys    KeyMap
 	width   int
 	height  int
 	showHelp bool
+	showSensors bool
+	showContainers bool
+	showAlerts bool
+	showPlugins bool
+	showProcesses bool
+	showCorrelation bool
+	zoomed  bool // Show the focused grid panel across the full screen
+	filtering bool // Whether the filter prompt for the focused panel is open
 	styleManager *StyleManager
 	collector models.SystemCollector
+	registry  *services.CollectorRegistry // Collectors run on every tick, the system collector's and custom ones
+	degradation *services.DegradationManager // Consecutive failures and back-off per collector
+	debug     *debugStats                  // Timings shown in the debug overlay
+	adaptive  *services.AdaptiveRefresh    // Stretches the refresh interval under high load
+	showDebug bool                         // Draw the debug overlay over the view
+	collected map[string]interface{}      // Latest results of custom collectors by name
 	ticker   *time.Ticker
 	updateInterval time.Duration
+	baseInterval   time.Duration // Interval set by -interval or :interval, restored by profiles without their own
+	processCollector models.ProcessCollector
+	tickCount      int
+	alerts         *models.AlertManager
+	notifiers      []models.Notifier
+	alertHistory   *models.AlertHistory
+	exporters      []models.SnapshotExporter
+	containerCollector models.ContainerCollector
+	settings       config.Config // Config file contents, saved back when the layout changes
+	profile        string        // Profile of the config file in use, "" for none
+	configPath     string        // Config file the layout is saved to; empty disables saving
+	onboarding     bool          // First-run overlay shown until a key is pressed
+	accessible     bool          // Screen reader mode: text summaries instead of panels
+	colorNote      bool          // Footer note on 8-color terminals, shown until a key is pressed
+	commanding     bool          // Whether the command prompt is open
+	prompt         textinput.Model // Text typed into the open filter or command prompt
+	commandNotice  commandNotice // Outcome of the last command
+	annotations    []models.Annotation // Named marks on the timeline, oldest first
+	paletteOpen    bool          // Whether the action palette is open
+	paletteQuery   string        // Text typed into the action palette
+	paletteSelected int          // Index of the highlighted action among the matches
+	dragging       splitDrag
+	now            func() time.Time // Clock for rendered ages and alert timestamps
+	clock          models.Clock     // Clock the refresh ticks wait on
+	recorder       *Recorder        // Test hook capturing update messages and collector results
+	screenshotDir  string           // Directory screenshots are written to
+	screenshot     ScreenshotMsg    // Result of the last screenshot
+	screenshotAt   time.Time        // When the last screenshot was taken
+	bootStatePath  string           // Boot record used to notice reboots between runs
+	bootChecked    bool             // Whether the boot time was compared with the record
+	bootSavedAt    time.Time        // When the record's last-seen time was refreshed
+	reboot         *RebootMsg       // Reboot shown in the banner until a key is pressed
+	historyPath    string           // File the metric history is persisted to; empty disables it
+	historySavedAt time.Time        // When the history was last saved
+	layoutChanges  int              // Bumped on every layout change, only the save of the last one runs
+	lastTick       time.Time        // When the previous refresh tick fired, to notice suspend and resume
 }
 
 // NewMainModel creates a new main application model
@@ -78,7 +319,16 @@ func NewMainModel() MainModel {
 		memory:         NewMemoryModel(),
 		disk:           NewDiskModel(),
 		network:        NewNetworkModel(),
+		sensors:        NewSensorsModel(),
+		containers:     NewContainersModel(),
+		alertsPanel:    NewAlertsModel(),
+		logView:        NewLogModel(),
+		self:           NewSelfModel(),
+		kernel:         NewKernelModel(),
+		processes:      NewProcessesModel(),
 		focused:        FocusCPU,
+		panels:         gridPanels(config.DefaultGridPanels),
+		tabs:           newGridTabs(config.Default()),
 		keys:           DefaultKeyMap(),
 		width:          80,
 		height:         24,
@@ -86,6 +336,19 @@ func NewMainModel() MainModel {
 		styleManager:   styleManager,
 		collector:      collector,
 		updateInterval: time.Second, // 1-second update interval
+		baseInterval:   time.Second,
+		registry:       newSystemRegistry(collector),
+		degradation:    services.NewDegradationManager(time.Second),
+		debug:          newDebugStats(),
+		adaptive:       services.NewAdaptiveRefresh(),
+		collected:      make(map[string]interface{}),
+		processCollector: services.NewGopsutilProcessCollector(),
+		alerts:         models.NewAlertManager(models.DefaultAlertRules()),
+		alertHistory:   models.NewAlertHistory(alertHistorySize),
+		stateThresholds: models.DefaultProcessStateThresholds(),
+		containerCollector: services.NewDockerCollector(),
+		now:            time.Now,
+		clock:          models.SystemClock,
 	}
 }
 
@@ -98,7 +361,16 @@ func NewMainModelWithConfig(updateInterval time.Duration) MainModel {
 		memory:         NewMemoryModel(),
 		disk:           NewDiskModel(),
 		network:        NewNetworkModel(),
+		sensors:        NewSensorsModel(),
+		containers:     NewContainersModel(),
+		alertsPanel:    NewAlertsModel(),
+		logView:        NewLogModel(),
+		self:           NewSelfModel(),
+		kernel:         NewKernelModel(),
+		processes:      NewProcessesModel(),
 		focused:        FocusCPU,
+		panels:         gridPanels(config.DefaultGridPanels),
+		tabs:           newGridTabs(config.Default()),
 		keys:           DefaultKeyMap(),
 		width:          80,
 		height:         24,
@@ -106,24 +378,47 @@ func NewMainModelWithConfig(updateInterval time.Duration) MainModel {
 		styleManager:   styleManager,
 		collector:      collector,
 		updateInterval: updateInterval,
+		baseInterval:   updateInterval,
+		registry:       newSystemRegistry(collector),
+		degradation:    services.NewDegradationManager(updateInterval),
+		debug:          newDebugStats(),
+		adaptive:       services.NewAdaptiveRefresh(),
+		collected:      make(map[string]interface{}),
+		processCollector: services.NewGopsutilProcessCollector(),
+		alerts:         models.NewAlertManager(models.DefaultAlertRules()),
+		alertHistory:   models.NewAlertHistory(alertHistorySize),
+		stateThresholds: models.DefaultProcessStateThresholds(),
+		containerCollector: services.NewDockerCollector(),
+		now:            time.Now,
+		clock:          models.SystemClock,
 	}
 }
 
 // Init initializes the main model
 func (m MainModel) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.cpu.Init(),
 		m.memory.Init(),
 		m.disk.Init(),
 		m.network.Init(),
 		m.tickCmd(), // Start the ticker for real-time updates
 		m.collectAllDataCmd(), // Initial data collection
-	)
+		m.collectTopProcessesCmd(), // Establish the process CPU baseline
+		m.collectHostCmd(), // Hostname and boot time don't change, collect them once
+	}
+	if m.showContainers {
+		// Started on the containers page, don't wait for the first tick
+		cmds = append(cmds, m.collectContainersCmd())
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages and updates the main model state
 func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
+	if m.recorder != nil {
+		m.recorder.Add(RecordUpdate, msg)
+	}
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -133,7 +428,67 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.styleManager.SetDimensions(m.width, m.height)
 		m = m.updateComponentSizes()
 
+	case tea.MouseMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleMouse(msg)
+		cmds = append(cmds, cmd)
+
 	case tea.KeyMsg:
+		// Any key dismisses the reboot banner and the color note and still does its job
+		m.reboot = nil
+		m.colorNote = false
+
+		// Any key but Ctrl+C only dismisses the first-run overlay
+		if m.onboarding && msg.Type != tea.KeyCtrlC {
+			m.onboarding = false
+			return m, m.writeDefaultConfigCmd()
+		}
+
+		// The filter prompt takes all keys but Ctrl+C while it is open
+		if m.filtering {
+			if msg.Type == tea.KeyCtrlC {
+				return m, tea.Quit
+			}
+			return m.handleFilterKey(msg), nil
+		}
+
+		// The command prompt takes all keys but Ctrl+C while it is open
+		if m.commanding {
+			if msg.Type == tea.KeyCtrlC {
+				return m, tea.Quit
+			}
+			return m.handleCommandKey(msg)
+		}
+
+		// The action palette takes all keys but Ctrl+C while it is open
+		if m.paletteOpen {
+			if msg.Type == tea.KeyCtrlC {
+				return m, tea.Quit
+			}
+			return m.handlePaletteKey(msg)
+		}
+
+		// The containers panel owns navigation keys while it is open
+		if m.showContainers {
+			if updated, cmd, handled := m.handleContainersKey(msg); handled {
+				return updated, cmd
+			}
+		}
+
+		// The alert history owns navigation keys while it is open
+		if m.showAlerts {
+			if updated, handled := m.handleAlertsKey(msg); handled {
+				return updated, nil
+			}
+		}
+
+		// The processes page owns navigation keys while it is open
+		if m.showProcesses {
+			if updated, cmd, handled := m.handleProcessesKey(msg); handled {
+				return updated, cmd
+			}
+		}
+
 		// Handle keyboard input
 		switch {
 		case m.containsKey(m.keys.Quit, msg.String()):
@@ -142,6 +497,126 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case m.containsKey(m.keys.Help, msg.String()):
 			m.showHelp = !m.showHelp
 
+		case m.containsKey(m.keys.Debug, msg.String()):
+			m.showDebug = !m.showDebug
+
+		case m.containsKey(m.keys.Sensors, msg.String()):
+			m.showSensors = !m.showSensors
+
+		case m.containsKey(m.keys.Alerts, msg.String()):
+			m.showAlerts = !m.showAlerts
+			m.alertsPanel = m.alertsPanel.SetShowCapture(false)
+
+		case m.containsKey(m.keys.Plugins, msg.String()):
+			m.showPlugins = !m.showPlugins
+
+		case m.containsKey(m.keys.Correlation, msg.String()):
+			m.showCorrelation = !m.showCorrelation
+
+		case m.containsKey(m.keys.Processes, msg.String()):
+			m.showProcesses = !m.showProcesses
+			if m.showProcesses {
+				cmds = append(cmds, m.collectTopProcessesCmd())
+			}
+
+		case m.containsKey(m.keys.TabPages, msg.String()):
+			if index, ok := tabIndex(msg.String()); ok && index < len(m.tabs) {
+				m.showSensors, m.showContainers, m.showAlerts, m.showPlugins, m.showProcesses, m.showCorrelation = false, false, false, false, false, false
+				m = m.selectTab(index)
+			}
+
+		case m.containsKey(m.keys.Filter, msg.String()):
+			if !m.showHelp && !m.showSensors && !m.showContainers && !m.showAlerts && !m.showPlugins && !m.showProcesses && !m.showCorrelation {
+				if filter, ok := m.panelFilter(m.focused); ok {
+					m.filtering, m.prompt = true, newPrompt(filter)
+				}
+			}
+
+		case m.containsKey(m.keys.Command, msg.String()):
+			if !m.showHelp {
+				m.commanding, m.prompt = true, newPrompt("")
+			}
+
+		case m.containsKey(m.keys.Palette, msg.String()):
+			m.paletteOpen, m.paletteQuery, m.paletteSelected = true, "", 0
+
+		case m.containsKey(m.keys.Back, msg.String()):
+			// Escape clears the filter of the focused panel, and the core
+			// selected in the CPU heatmap
+			m, _ = m.setPanelFilter(m.focused, "")
+			if m.focused == FocusCPU {
+				m.cpu = m.cpu.ClearSelectedCore()
+			}
+
+		case m.containsKey(m.keys.Zoom, msg.String()):
+			m.zoomed = !m.zoomed
+
+		case m.containsKey(m.keys.RangeShorter, msg.String()):
+			m = m.stepTimeRange(-1)
+
+		case m.containsKey(m.keys.RangeLonger, msg.String()):
+			m = m.stepTimeRange(1)
+
+		case m.containsKey(m.keys.PrevCore, msg.String()):
+			m = m.stepCore(-1)
+
+		case m.containsKey(m.keys.NextCore, msg.String()):
+			m = m.stepCore(1)
+
+		case m.containsKey(m.keys.Screenshot, msg.String()):
+			cmds = append(cmds, m.screenshotCmd(false))
+
+		case m.containsKey(m.keys.ScreenshotANSI, msg.String()):
+			cmds = append(cmds, m.screenshotCmd(true))
+
+		case m.containsKey(m.keys.ExportSnapshot, msg.String()):
+			cmds = append(cmds, m.exportSnapshotCmd())
+
+		case m.containsKey(m.keys.Annotate, msg.String()):
+			// The prompt opens with the command typed, for the name to follow
+			if !m.showHelp {
+				m.commanding, m.prompt = true, newPrompt("annotate ")
+			}
+
+		case m.containsKey(m.keys.Units, msg.String()):
+			m.sensors = m.sensors.SetUnit(m.sensors.GetUnit().Toggle())
+
+		case m.containsKey(m.keys.RateUnit, msg.String()):
+			m.network = m.network.SetRateUnit(m.network.GetRateUnit().Toggle())
+
+		case m.containsKey(m.keys.Sort, msg.String()):
+			m, _ = m.cyclePanelSort(m.focused)
+
+		case m.containsKey(m.keys.Expand, msg.String()):
+			m, _ = m.togglePanelExpanded(m.focused)
+
+		case m.containsKey(m.keys.Containers, msg.String()):
+			m.showContainers = !m.showContainers
+			m.containers = m.containers.SetShowDetail(false)
+			if m.showContainers {
+				cmds = append(cmds, m.collectContainersCmd())
+			}
+
+		case m.containsKey(m.keys.ShrinkColumn, msg.String()):
+			var cmd tea.Cmd
+			m, cmd = m.resizeGrid(-splitStep, 0)
+			cmds = append(cmds, cmd)
+
+		case m.containsKey(m.keys.GrowColumn, msg.String()):
+			var cmd tea.Cmd
+			m, cmd = m.resizeGrid(splitStep, 0)
+			cmds = append(cmds, cmd)
+
+		case m.containsKey(m.keys.ShrinkRow, msg.String()):
+			var cmd tea.Cmd
+			m, cmd = m.resizeGrid(0, -splitStep)
+			cmds = append(cmds, cmd)
+
+		case m.containsKey(m.keys.GrowRow, msg.String()):
+			var cmd tea.Cmd
+			m, cmd = m.resizeGrid(0, splitStep)
+			cmds = append(cmds, cmd)
+
 		case m.containsKey(m.keys.Refresh, msg.String()):
 			// Manual refresh - trigger immediate data collection
 			cmds = append(cmds, m.collectAllDataCmd())
@@ -169,40 +644,175 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		m.cpu, cmd = m.cpu.Update(msg)
 		cmds = append(cmds, cmd)
+		cmds = append(cmds, m.evaluateAlerts("CPU", "", m.cpu.GetTotal()))
 
 	case MemoryUpdateMsg:
 		var cmd tea.Cmd
 		m.memory, cmd = m.memory.Update(msg)
 		cmds = append(cmds, cmd)
+		cmds = append(cmds, m.evaluateAlerts("Memory", "", m.memory.GetAlertPercent()))
+
+	case WirelessUpdateMsg:
+		m.network, _ = m.network.Update(msg)
+		return m, nil
+
+	case PoolsUpdateMsg, DiskIOUpdateMsg:
+		m.disk, _ = m.disk.Update(msg)
+		return m, nil
 
 	case DiskUpdateMsg:
 		var cmd tea.Cmd
 		m.disk, cmd = m.disk.Update(msg)
 		cmds = append(cmds, cmd)
+		for _, fs := range m.disk.GetFilesystems() {
+			cmds = append(cmds, m.evaluateAlerts("Disk", fs.Mountpoint, fs.UsedPercent))
+		}
+		// Unmounted filesystems are never measured again, so their alerts
+		// are cleared here
+		for _, mountpoint := range m.disk.GetRemoved() {
+			cmds = append(cmds, m.forgetAlerts("Disk", mountpoint))
+		}
 
 	case NetworkUpdateMsg:
 		var cmd tea.Cmd
 		m.network, cmd = m.network.Update(msg)
 		cmds = append(cmds, cmd)
+		if added, removed := m.network.GetInterfaceChanges(); len(added)+len(removed) > 0 {
+			m = m.noticeInterfaceChanges(added, removed)
+		}
+
+	case ContainersUpdateMsg, ContainerLogsMsg:
+		var cmd tea.Cmd
+		m.containers, cmd = m.containers.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case SensorsUpdateMsg:
+		var cmd tea.Cmd
+		m.sensors, cmd = m.sensors.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case HostUpdateMsg:
+		m.host = models.HostInfo(msg)
+		if !m.bootChecked {
+			m.bootChecked = true
+			m.bootSavedAt = m.now()
+			cmds = append(cmds, m.checkBootCmd(m.host))
+		}
+
+	case RebootMsg:
+		m.reboot = &msg
+		log.Printf("System rebooted at %s, previous boot last seen %s", msg.BootTime.Format(time.RFC3339), msg.Previous.LastSeen.Format(time.RFC3339))
+
+	case SnapshotMsg:
+		var cmd tea.Cmd
+		m, cmd = m.applySnapshot(msg)
+		cmds = append(cmds, cmd)
+
+	case CollectedMsg:
+		m.collected[msg.Name] = msg.Data
+
+	case LogUpdateMsg:
+		m.logView, _ = m.logView.Update(msg)
+
+	case SelfUpdateMsg:
+		m.self, _ = m.self.Update(msg)
+
+	case KernelUpdateMsg:
+		m.kernel, _ = m.kernel.Update(msg)
+
+	case PowerUpdateMsg:
+		m.cpu, _ = m.cpu.Update(msg)
+
+	case ProcessStatesUpdateMsg:
+		m.processStates = models.ProcessStates(msg)
+
+	case FilesUpdateMsg:
+		m.files = models.FileDescriptors(msg)
+		cmds = append(cmds, m.evaluateAlerts("Files", "", m.files.UsedPercent()))
+
+	case PluginUpdateMsg:
+		plugins := make([]PluginModel, len(m.plugins))
+		for i, plugin := range m.plugins {
+			plugins[i], _ = plugin.Update(msg)
+		}
+		m.plugins = plugins
+
+	case LayoutSaveMsg:
+		if msg.change == m.layoutChanges {
+			cmds = append(cmds, m.saveLayoutCmd())
+		}
+
+	case LogSnapshotMsg:
+		return m.logSnapshot(), nil
+
+	case ToggleDebugMsg:
+		m.showDebug = !m.showDebug
+		return m, nil
+
+	case ScreenshotMsg:
+		m.screenshot = msg
+		m.screenshotAt = m.now()
+		if msg.Err != nil {
+			log.Printf("Export of %s failed: %v", msg.Path, msg.Err)
+		}
 
 	case TickMsg:
 		// Handle ticker for real-time updates
-		cmds = append(cmds, m.collectAllDataCmd()) // Collect new data
+		m.debug.recordTick(time.Time(msg), m.adaptive.Interval(m.updateInterval), m.showDebug)
+		if sleptBetween(m.lastTick, time.Time(msg), m.adaptive.Interval(m.updateInterval)) {
+			m = m.resyncAfterSleep(time.Time(msg).Round(0).Sub(m.lastTick.Round(0)))
+		}
+		m.lastTick = time.Time(msg)
+		m.adaptRefresh()
+		cmds = append(cmds, m.collectDueCmd()) // Collect new data, failing collectors back off
 		cmds = append(cmds, m.tickCmd())           // Schedule next tick
+		m.recordSnapshot()
+		if m.bootChecked && m.bootStatePath != "" && m.now().Sub(m.bootSavedAt) >= bootSaveInterval {
+			m.bootSavedAt = m.now()
+			cmds = append(cmds, m.saveBootCmd(m.bootSavedAt))
+		}
+		if m.historyPath != "" && m.now().Sub(m.historySavedAt) >= historySaveInterval {
+			m.historySavedAt = m.now()
+			cmds = append(cmds, m.saveHistoryCmd())
+		}
+		cmds = append(cmds, m.runScriptsCmd())
+		if m.showContainers {
+			cmds = append(cmds, m.collectContainersCmd())
+		}
+		m.tickCount++
+		// The processes page refreshes on every tick, the CPU panel's line less often
+		if m.tickCount%topProcessInterval == 0 || m.showProcesses {
+			cmds = append(cmds, m.collectTopProcessesCmd())
+		}
 
-	case models.ErrorMsg:
-		// Forward error messages to appropriate components
+	case TopProcessesMsg:
 		var cmd tea.Cmd
-		switch msg.Component {
-		case "CPU":
-			m.cpu, cmd = m.cpu.Update(msg)
-		case "Memory":
-			m.memory, cmd = m.memory.Update(msg)
-		case "Disk":
-			m.disk, cmd = m.disk.Update(msg)
-		case "Network":
-			m.network, cmd = m.network.Update(msg)
+		m.cpu, cmd = m.cpu.Update(msg[:min(len(msg), topProcessCount)])
+		cmds = append(cmds, cmd)
+		m.processes, _ = m.processes.Update(msg)
+		if m.showProcesses {
+			cmds = append(cmds, m.collectProcessMemoryCmd(m.processes.PIDs()), m.collectProcessFilesCmd(m.processes.PIDs()))
+		}
+
+	case ProcessMemoryMsg, ProcessFilesMsg, ProcessDetailMsg:
+		m.processes, _ = m.processes.Update(msg)
+
+	case AlertCaptureMsg:
+		if m.alertHistory != nil {
+			m.alertHistory.AttachProcesses(msg.Event, msg.Processes)
+		}
+		return m, nil
+
+	case CollectFailedMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleCollectFailed(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
 		}
+
+	case models.ErrorMsg:
+		var cmd tea.Cmd
+		m, cmd = m.forwardError(msg)
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
@@ -211,46 +821,145 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-// View renders the main application view
+// noticeInterfaceChanges logs the network interfaces that appeared or went
+// away, such as USB tethering or Docker networks, and flashes them in the
+// status line unless interface_notices is off
+func (m MainModel) noticeInterfaceChanges(added, removed []string) MainModel {
+	var parts []string
+	if len(added) == 1 {
+		parts = append(parts, "New interface "+added[0])
+	} else if len(added) > 1 {
+		parts = append(parts, "New interfaces "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		parts = append(parts, strings.Join(removed, ", ")+" went away")
+	}
+	text := strings.Join(parts, "; ")
+	log.Printf("Network interfaces changed: %s", text)
+	if !m.settings.NoticeInterfaces() {
+		return m
+	}
+	return m.setCommandNotice(text, false)
+}
+
+// forwardError hands an error message to the component it concerns
+func (m MainModel) forwardError(msg models.ErrorMsg) (MainModel, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg.Component {
+	case "CPU":
+		m.cpu, cmd = m.cpu.Update(msg)
+	case "Memory":
+		m.memory, cmd = m.memory.Update(msg)
+	case "Disk":
+		m.disk, cmd = m.disk.Update(msg)
+	case "Network":
+		m.network, cmd = m.network.Update(msg)
+	case "Sensors":
+		m.sensors, cmd = m.sensors.Update(msg)
+	case "Containers":
+		m.containers, cmd = m.containers.Update(msg)
+	case services.PowerCollectorName:
+		// The zoomed CPU panel shows why power samples fail below the clusters
+		m.cpu, cmd = m.cpu.Update(PowerUpdateMsg{Error: msg.Message, Timestamp: msg.Timestamp})
+	}
+	return m, cmd
+}
+
+// View renders the main application view, with the debug overlay on top
 func (m MainModel) View() string {
+	start := time.Now()
+	defer func() { m.adaptive.RecordRender(time.Since(start)) }()
+	view := m.render()
+	if m.onboarding {
+		view = m.renderOnboarding(view)
+	}
+	if m.paletteOpen {
+		view = m.renderPalette(view)
+	}
+	if m.showDebug {
+		view = m.renderDebugOverlay(view)
+	}
+	return view
+}
+
+// render renders the view of the current page
+func (m MainModel) render() string {
+	m = m.applyRetries(m.now())
+
+	// The screen reader mode replaces the grid at any size, pages keep their views
+	if m.accessible && !m.showContainers && !m.showSensors && !m.showAlerts && !m.showPlugins && !m.showProcesses && !m.showCorrelation {
+		if m.showHelp {
+			return m.renderHelp()
+		}
+		return m.renderAccessible()
+	}
+
+	// Tiny terminals get gauges instead of panels, until even those don't fit
+	if m.styleManager.IsTooSmall() {
+		return m.styleManager.RenderTooSmall()
+	}
+	if m.styleManager.IsCompactTerminal() {
+		return m.renderCompact()
+	}
 	if m.showHelp {
 		return m.renderHelp()
 	}
-
-	// Calculate component dimensions using style manager
-	componentWidth, componentHeight := m.styleManager.CalculateComponentDimensions()
+	if m.showContainers {
+		return m.renderContainers()
+	}
+	if m.showSensors {
+		return m.renderSensors()
+	}
+	if m.showAlerts {
+		return m.renderAlerts()
+	}
+	if m.showPlugins {
+		return m.renderPlugins()
+	}
+	if m.showProcesses {
+		return m.renderProcesses()
+	}
+	if m.showCorrelation {
+		return m.renderCorrelation()
+	}
+	if m.zoomed {
+		return m.renderZoomed()
+	}
 
 	// Update component sizes
-	m.cpu = m.cpu.SetSize(componentWidth, componentHeight)
-	m.memory = m.memory.SetSize(componentWidth, componentHeight)
-	m.disk = m.disk.SetSize(componentWidth, componentHeight)
-	m.network = m.network.SetSize(componentWidth, componentHeight)
+	m = m.updateComponentSizes()
+	widths, heights := m.styleManager.CalculateGridDimensions(len(m.panels))
+	columns, _ := m.styleManager.GridShape(len(m.panels))
 
 	// Render components with focus styling using style manager
-	cpuView := m.styleManager.RenderComponentBorder(m.cpu.View(), m.focused == FocusCPU, componentWidth, componentHeight)
-	memoryView := m.styleManager.RenderComponentBorder(m.memory.View(), m.focused == FocusMemory, componentWidth, componentHeight)
-	diskView := m.styleManager.RenderComponentBorder(m.disk.View(), m.focused == FocusDisk, componentWidth, componentHeight)
-	networkView := m.styleManager.RenderComponentBorder(m.network.View(), m.focused == FocusNetwork, componentWidth, componentHeight)
+	components := make([]string, len(m.panels))
+	for i, panel := range m.panels {
+		width, height := widths[i%columns], heights[i/columns]
+		components[i] = m.styleManager.ForPanel(panel.PanelName()).RenderComponentBorder(m.panelView(panel), m.focused == panel, width, height)
+	}
 
 	// Create responsive layout using style manager
-	components := []string{cpuView, memoryView, diskView, networkView}
 	content := m.styleManager.RenderResponsiveLayout(components)
 
 	// Add header and footer using style manager
-	header := m.styleManager.RenderApplicationHeader("System Monitor")
-	shortcuts := []string{"q: quit", "arrows/tab: navigate", "r: refresh", "?: help"}
-	footer := m.styleManager.RenderApplicationFooter(shortcuts)
+	header := m.styleManager.RenderApplicationHeader(m.headerTitle())
+	footer := m.renderFooter()
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, "", content, "", footer)
+	// The export status and the status bar take the spacer lines around the grid so the layout keeps its height
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderStatusLine(m.now()), content, m.renderStatusBar(m.now()), footer)
 }
 
 
 
-// renderHelp renders the help screen
+// renderHelp renders the help screen, led by the help of the focused panel
 func (m MainModel) renderHelp() string {
 	helpContent := []string{
 		"System Monitor - Keyboard Shortcuts",
 		"",
+	}
+	// The focused panel's keys and metrics come first, above the global help
+	helpContent = append(helpContent, m.renderPanelHelp()...)
+	helpContent = append(helpContent,
 		"Navigation:",
 		"  ↑/↓/←/→, hjkl  Navigate between components",
 		"  Tab, Shift+Tab  Cycle through components",
@@ -258,207 +967,1450 @@ func (m MainModel) renderHelp() string {
 		"Actions:",
 		"  q, Ctrl+C       Quit application",
 		"  r               Manual refresh",
+		"  t               Toggle temperature sensors",
+		"  c               Toggle containers (↑/↓ select, Enter details, Esc back)",
+		"  a               Toggle alert history (↑/↓ select, Enter top processes, Esc back)",
+		"  p               Toggle plugin panels",
+		"  P               Toggle processes (↑/↓ select, Enter details, e environment, Esc back)",
+		"  g               Toggle the correlation view of CPU, disk I/O and network",
+		"  u               Switch temperatures between °C and °F",
+		"  b               Switch network rates between bytes and bits per second",
+		"  o               Cycle the order of the focused disk or network rows",
+		"  x               Expand or collapse similar disk or network rows",
+		"  z               Zoom the focused panel to full screen",
+		"  [, ]            Graph a shorter or longer time range in the focused panel",
+		"  <, >            Select the previous or next core of the CPU heatmap (Esc busiest)",
+		"  1-9, F1-F9      Switch tab",
+		"  /               Filter the focused list (Enter keeps, Esc clears)",
+		"  s, S            Save a screenshot as text (S keeps colors)",
+		"  e               Save the current metrics as JSON",
+		"  m               Mark the timeline with a named annotation",
+		"  Ctrl+←/→/↑/↓    Resize the panel grid (or drag the gaps with the mouse)",
+		"  :               Open the command prompt (Enter runs, Esc cancels)",
+		"  Ctrl+P          Search all actions by name (↑/↓ select, Enter runs)",
 		"  ?, h            Toggle this help",
+		"  F12             Toggle the debug overlay (collection timings)",
+		"",
+	)
+	helpContent = append(helpContent, commandHelp()...)
+	helpContent = append(helpContent,
 		"",
 		"Components:",
 		"  CPU             Real-time CPU usage per core",
 		"  Memory          RAM and swap usage",
 		"  Disk            Filesystem usage and warnings",
 		"  Network         Interface activity and rates",
+		"  Temperatures    CPU, GPU, NVMe and chassis sensors",
+		"  Containers      Image, uptime, restarts and health per container",
+		"  Alerts          Fired and cleared alerts with timestamps",
+		"  Plugins         Metrics reported by external plugin commands",
+		"  Processes       Busiest processes with RSS, PSS, USS and swap",
+		"  Correlation     CPU, disk I/O and network scaled onto one graph",
 		"",
 		"Press any key to return to the main view",
-	}
+	)
 
 	content := strings.Join(helpContent, "\n")
+	if m.accessible {
+		return content
+	}
 	return m.styleManager.RenderHelpScreen(content)
 }
 
-// updateComponentSizes updates all component sizes based on current terminal size
-func (m MainModel) updateComponentSizes() MainModel {
-	componentWidth := (m.width - 3) / 2
-	componentHeight := (m.height - 4) / 2
+// renderSensors renders the temperature sensors panel across the full screen
+func (m MainModel) renderSensors() string {
+	width := m.width - 4
+	height := m.height - 6
+	m.sensors = m.sensors.SetSize(width, height)
 
-	m.cpu = m.cpu.SetSize(componentWidth, componentHeight)
-	m.memory = m.memory.SetSize(componentWidth, componentHeight)
-	m.disk = m.disk.SetSize(componentWidth, componentHeight)
-	m.network = m.network.SetSize(componentWidth, componentHeight)
+	header := m.styleManager.RenderApplicationHeader(m.headerTitle())
+	panel := m.styleManager.ForPanel("sensors").RenderComponentBorder(m.sensors.View(), true, width, height)
+	footer := m.renderFooter()
 
-	return m
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderNotice(m.now()), panel, m.renderStatusBar(m.now()), footer)
 }
 
-// nextFocus returns the next focus component in sequence
-func (m MainModel) nextFocus() FocusedComponent {
-	switch m.focused {
-	case FocusCPU:
-		return FocusMemory
-	case FocusMemory:
-		return FocusDisk
-	case FocusDisk:
-		return FocusNetwork
-	case FocusNetwork:
-		return FocusCPU
-	default:
-		return FocusCPU
-	}
-}
+// renderZoomed renders the focused grid panel across the full screen
+func (m MainModel) renderZoomed() string {
+	width := m.width - 4
+	height := m.height - 6
+	m = m.setPanelSize(m.focused, width, height)
+	m.cpu = m.cpu.SetExpanded(true)
 
-// prevFocus returns the previous focus component in sequence
-func (m MainModel) prevFocus() FocusedComponent {
-	switch m.focused {
-	case FocusCPU:
-		return FocusNetwork
-	case FocusMemory:
-		return FocusCPU
-	case FocusDisk:
-		return FocusMemory
-	case FocusNetwork:
-		return FocusDisk
-	default:
-		return FocusCPU
-	}
+	header := m.styleManager.RenderApplicationHeader(m.headerTitle())
+	status := m.renderStatusLine(m.now())
+	panel := m.styleManager.ForPanel(m.focused.PanelName()).RenderComponentBorder(m.panelView(m.focused), true, width, height)
+	footer := m.renderFooter()
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, status, panel, m.renderStatusBar(m.now()), footer)
 }
 
-// downFocus handles down arrow navigation (top row to bottom row)
-func (m MainModel) downFocus() FocusedComponent {
-	switch m.focused {
-	case FocusCPU:
-		return FocusDisk
-	case FocusMemory:
-		return FocusNetwork
-	case FocusDisk:
-		return FocusDisk // Stay on disk if already on bottom row
-	case FocusNetwork:
-		return FocusNetwork // Stay on network if already on bottom row
+// renderStatusLine renders the tab bar followed by the export status, or the
+// result of a recent screenshot, on the line below the header
+func (m MainModel) renderStatusLine(now time.Time) string {
+	tabs := m.renderTabBar()
+	sinks := m.renderSinkStatus(now)
+	if notice := m.renderNotice(now); notice != "" {
+		sinks = notice
+	}
+	switch {
+	case tabs == "":
+		return sinks
+	case sinks == "":
+		return tabs
 	default:
-		return FocusCPU
+		return tabs + "   " + sinks
 	}
 }
 
-// upFocus handles up arrow navigation (bottom row to top row)
-func (m MainModel) upFocus() FocusedComponent {
-	switch m.focused {
-	case FocusCPU:
-		return FocusCPU // Stay on CPU if already on top row
-	case FocusMemory:
-		return FocusMemory // Stay on memory if already on top row
-	case FocusDisk:
-		return FocusCPU
-	case FocusNetwork:
-		return FocusMemory
-	default:
-		return FocusCPU
+// renderTabBar renders the tab titles with their number keys, highlighting the active tab
+func (m MainModel) renderTabBar() string {
+	if len(m.tabs) < 2 {
+		return ""
 	}
-}
 
-// containsKey checks if a key string is in the provided key list
-func (m MainModel) containsKey(keys []string, key string) bool {
-	for _, k := range keys {
-		if k == key {
-			return true
+	parts := make([]string, len(m.tabs))
+	for i, tab := range m.tabs {
+		label := fmt.Sprintf("%d %s", i+1, tab.name)
+		if i == m.activeTab {
+			parts[i] = m.styleManager.RenderHeader("[" + label + "]")
+		} else {
+			parts[i] = m.styleManager.RenderMutedText(" " + label + " ")
 		}
 	}
-	return false
+	return strings.Join(parts, " ")
 }
 
-// GetFocusedComponent returns the currently focused component
-func (m MainModel) GetFocusedComponent() FocusedComponent {
-	return m.focused
-}
+// selectTab shows the grid of the tab at index, keeping the focus when the
+// focused component is part of it
+func (m MainModel) selectTab(index int) MainModel {
+	if index < 0 || index >= len(m.tabs) {
+		return m
+	}
 
-// SetFocusedComponent sets the focused component
-func (m MainModel) SetFocusedComponent(focus FocusedComponent) MainModel {
-	m.focused = focus
-	return m
+	tab := m.tabs[index]
+	m.activeTab = index
+	m.panels = tab.panels
+	m.styleManager.SetGridColumns(tab.columns)
+	m.focused = m.focusAt(m.focusIndex())
+	m.zoomed = false
+	return m.updateComponentSizes()
 }
 
-// GetCPUModel returns the CPU model
-func (m MainModel) GetCPUModel() CPUModel {
-	return m.cpu
-}
+// renderProcesses renders the processes page across the full screen
+func (m MainModel) renderProcesses() string {
+	width := m.width - 4
+	height := m.height - 6
+	m.processes = m.processes.SetSize(width, height)
 
-// GetMemoryModel returns the memory model
-func (m MainModel) GetMemoryModel() MemoryModel {
-	return m.memory
-}
+	header := m.styleManager.RenderApplicationHeader(m.headerTitle())
+	panel := m.styleManager.ForPanel("processes").RenderComponentBorder(m.processes.View(), true, width, height)
+	footer := m.renderFooter()
 
-// GetDiskModel returns the disk model
-func (m MainModel) GetDiskModel() DiskModel {
-	return m.disk
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderNotice(m.now()), panel, m.renderStatusBar(m.now()), footer)
 }
 
-// GetNetworkModel returns the network model
-func (m MainModel) GetNetworkModel() NetworkModel {
-	return m.network
-}
+// renderContainers renders the containers panel across the full screen
+func (m MainModel) renderContainers() string {
+	width := m.width - 4
+	height := m.height - 6
+	m.containers = m.containers.SetSize(width, height)
 
-// IsShowingHelp returns whether the help screen is currently displayed
-func (m MainModel) IsShowingHelp() bool {
-	return m.showHelp
+	header := m.styleManager.RenderApplicationHeader(m.headerTitle())
+	panel := m.styleManager.ForPanel("containers").RenderComponentBorder(m.containers.View(), true, width, height)
+	footer := m.renderFooter()
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderNotice(m.now()), panel, m.renderStatusBar(m.now()), footer)
 }
 
-// SetShowHelp sets the help display state
-func (m MainModel) SetShowHelp(show bool) MainModel {
-	m.showHelp = show
+// syncAlertsPanel loads the alert history into the alerts panel before it is rendered
+func (m MainModel) syncAlertsPanel() MainModel {
+	msg := AlertsUpdateMsg{}
+	if m.alertHistory != nil {
+		msg.Events = m.alertHistory.Events()
+	}
+	if m.alerts != nil {
+		msg.Active = m.alerts.ActiveCount()
+	}
+	m.alertsPanel, _ = m.alertsPanel.Update(msg)
 	return m
 }
 
-// tickCmd creates a command that sends a TickMsg after the update interval
-func (m MainModel) tickCmd() tea.Cmd {
-	return tea.Tick(m.updateInterval, func(t time.Time) tea.Msg {
-		return TickMsg(t)
+// panelView renders the content of a grid component
+func (m MainModel) panelView(panel FocusedComponent) string {
+	switch panel {
+	case FocusMemory:
+		return m.memory.View()
+	case FocusDisk:
+		return m.disk.View()
+	case FocusNetwork:
+		return m.network.View()
+	case FocusSensors:
+		return m.sensors.View()
+	case FocusAlerts:
+		return m.syncAlertsPanel().alertsPanel.View()
+	case FocusLog:
+		return m.logView.View()
+	case FocusSelf:
+		return m.self.View()
+	case FocusKernel:
+		return m.kernel.View()
+	default:
+		return m.cpu.View()
+	}
+}
+
+// renderAlerts renders the alert history panel across the full screen
+func (m MainModel) renderAlerts() string {
+	width := m.width - 4
+	height := m.height - 6
+
+	m = m.syncAlertsPanel()
+	m.alertsPanel = m.alertsPanel.SetSize(width, height).SetSelectable(true)
+
+	header := m.styleManager.RenderApplicationHeader(m.headerTitle())
+	panel := m.styleManager.ForPanel("alerts").RenderComponentBorder(m.alertsPanel.View(), true, width, height)
+	footer := m.renderFooter()
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderNotice(m.now()), panel, m.renderStatusBar(m.now()), footer)
+}
+
+// renderFooter renders the key hints for the current view, shortening them to the terminal width
+func (m MainModel) renderFooter() string {
+	contextual, global := m.footerHints()
+	if m.commanding {
+		return m.styleManager.RenderApplicationFooter([]string{":" + m.prompt.View()})
+	}
+	if m.colorNote && !m.filtering {
+		// The note replaces the contextual hints until the first key press
+		hints := FitHints(nil, global, m.width)
+		note := truncate(colorSupportNote, max(m.width-lipgloss.Width(strings.Join(hints, footerSeparator)+footerSeparator), 0))
+		return m.styleManager.RenderApplicationFooter(append([]string{note}, hints...))
+	}
+	if !m.filtering {
+		return m.styleManager.RenderApplicationFooter(FitHints(contextual, global, m.width))
+	}
+
+	// The prompt replaces the global hints, keys other than Enter and Escape are typed into it
+	prompt := fmt.Sprintf("Filter %s: %s", m.focused.PanelName(), m.prompt.View())
+	return m.styleManager.RenderApplicationFooter(append([]string{prompt}, FitHints(contextual, nil, m.width-lipgloss.Width(prompt+footerSeparator))...))
+}
+
+// footerHints returns the hints for the open panel, or for the focused panel of
+// the grid, followed by the hints that apply everywhere. All hints are built from
+// the keymap so they follow rebound keys.
+func (m MainModel) footerHints() (contextual, global []KeyHint) {
+	global = []KeyHint{
+		NewKeyHint("quit", m.keys.Quit),
+		NewKeyHint("help", m.keys.Help),
+	}
+
+	switch {
+	case m.filtering:
+		contextual = []KeyHint{
+			NewKeyHint("keep", m.keys.Select),
+			NewKeyHint("clear", m.keys.Back),
+		}
+	case m.showContainers && m.containers.IsShowingDetail():
+		contextual = []KeyHint{
+			NewKeyHint("back", m.keys.Back),
+			NewKeyHint("close", m.keys.Containers),
+		}
+	case m.showContainers:
+		contextual = []KeyHint{
+			NewKeyHint("select", m.keys.Up, m.keys.Down),
+			NewKeyHint("details/expand", m.keys.Select),
+			NewKeyHint("back", m.keys.Containers),
+		}
+	case m.showSensors:
+		contextual = []KeyHint{
+			NewKeyHint("back", m.keys.Sensors),
+			NewKeyHint("°C/°F", m.keys.Units),
+		}
+	case m.showAlerts && m.alertsPanel.IsShowingCapture():
+		contextual = []KeyHint{
+			NewKeyHint("back", m.keys.Back),
+		}
+	case m.showAlerts:
+		contextual = []KeyHint{
+			NewKeyHint("select", m.keys.Up, m.keys.Down),
+			NewKeyHint("top processes", m.keys.Select),
+			NewKeyHint("back", m.keys.Alerts),
+		}
+	case m.showPlugins:
+		contextual = []KeyHint{
+			NewKeyHint("back", m.keys.Plugins),
+		}
+	case m.showCorrelation:
+		contextual = []KeyHint{
+			NewKeyHint("back", m.keys.Correlation),
+		}
+	case m.showProcesses && m.processes.IsShowingDetail():
+		contextual = []KeyHint{
+			NewKeyHint("scroll", m.keys.Up, m.keys.Down),
+			NewKeyHint("environment", m.keys.Environ),
+			NewKeyHint("back", m.keys.Back),
+		}
+	case m.showProcesses:
+		contextual = []KeyHint{
+			NewKeyHint("select", m.keys.Up, m.keys.Down),
+			NewKeyHint("details", m.keys.Select),
+			NewKeyHint("back", m.keys.Processes),
+		}
+	case m.zoomed:
+		contextual = append(m.panelHints(), []KeyHint{
+			NewKeyHint("unzoom", m.keys.Zoom),
+			NewKeyHint("next panel", m.keys.Tab),
+		}...)
+	default:
+		contextual = append(m.panelHints(), []KeyHint{
+			NewKeyHint("navigate", m.keys.Tab),
+			NewKeyHint("zoom", m.keys.Zoom),
+			NewKeyHint("refresh", m.keys.Refresh),
+			NewKeyHint("temps", m.keys.Sensors),
+			NewKeyHint("containers", m.keys.Containers),
+			NewKeyHint("alerts", m.keys.Alerts),
+			NewKeyHint("resize", m.keys.ShrinkColumn, m.keys.GrowColumn),
+		}...)
+		if len(m.plugins) > 0 {
+			contextual = append(contextual, NewKeyHint("plugins", m.keys.Plugins))
+		}
+	}
+	return contextual, global
+}
+
+// panelHints returns the hints for the actions of the focused grid panel, shown
+// ahead of the general grid hints
+func (m MainModel) panelHints() []KeyHint {
+	var hints []KeyHint
+	if filter, ok := m.panelFilter(m.focused); ok {
+		hints = append(hints, NewKeyHint("filter", m.keys.Filter))
+		if filter != "" {
+			hints = append(hints, NewKeyHint("clear filter", m.keys.Back))
+		}
+	}
+	if m.focused == FocusSensors {
+		hints = append(hints, NewKeyHint("°C/°F", m.keys.Units))
+	}
+	if m.focused == FocusNetwork {
+		hints = append(hints, NewKeyHint("bytes/bits", m.keys.RateUnit))
+	}
+	if order, ok := m.panelSort(m.focused); ok {
+		hints = append(hints, NewKeyHint("sort: "+order, m.keys.Sort))
+	}
+	if expanded, ok := m.panelExpanded(m.focused); ok {
+		if expanded {
+			hints = append(hints, NewKeyHint("collapse", m.keys.Expand))
+		} else {
+			hints = append(hints, NewKeyHint("expand", m.keys.Expand))
+		}
+	}
+	if timeRange, ok := m.panelTimeRange(m.focused); ok {
+		hints = append(hints, NewKeyHint("range "+formatTimeRange(timeRange), m.keys.RangeShorter, m.keys.RangeLonger))
+	}
+	if m.focused == FocusCPU && m.cpu.ShowsCoreGrid() {
+		hints = append(hints, NewKeyHint("core", m.keys.PrevCore, m.keys.NextCore))
+	}
+	return hints
+}
+
+// handleFilterKey edits the filter of the focused panel, applying it as it is typed.
+// Enter keeps the filter and closes the prompt, Escape clears it.
+func (m MainModel) handleFilterKey(msg tea.KeyMsg) MainModel {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+		return m
+	case tea.KeyEsc:
+		m.filtering = false
+		m.prompt.SetValue("")
+	default:
+		m.prompt, _ = m.prompt.Update(msg)
+	}
+	if filter, _ := m.panelFilter(m.focused); filter != m.prompt.Value() {
+		m, _ = m.setPanelFilter(m.focused, m.prompt.Value())
+	}
+	return m
+}
+
+// panelSort returns the name of the row order of a grid component, and
+// whether its rows can be ordered
+func (m MainModel) panelSort(panel FocusedComponent) (string, bool) {
+	switch panel {
+	case FocusDisk:
+		return m.disk.GetSort().String(), true
+	case FocusNetwork:
+		return m.network.GetSort().String(), true
+	default:
+		return "", false
+	}
+}
+
+// cyclePanelSort switches a grid component to its next row order, reporting
+// false when its rows cannot be ordered
+func (m MainModel) cyclePanelSort(panel FocusedComponent) (MainModel, bool) {
+	switch panel {
+	case FocusDisk:
+		m.disk = m.disk.SetSort(m.disk.GetSort().Next())
+	case FocusNetwork:
+		m.network = m.network.SetSort(m.network.GetSort().Next())
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+// panelExpanded reports whether the collapsed rows of a grid component are
+// expanded, and whether it collapses similar rows at all
+func (m MainModel) panelExpanded(panel FocusedComponent) (bool, bool) {
+	switch panel {
+	case FocusDisk:
+		return m.disk.IsExpanded(), m.disk.GetCollapse() > 0
+	case FocusNetwork:
+		return m.network.IsExpanded(), m.network.GetCollapse() > 0
+	default:
+		return false, false
+	}
+}
+
+// togglePanelExpanded expands the collapsed rows of a grid component or
+// collapses them again, reporting false when it does not collapse rows
+func (m MainModel) togglePanelExpanded(panel FocusedComponent) (MainModel, bool) {
+	expanded, ok := m.panelExpanded(panel)
+	if !ok {
+		return m, false
+	}
+	switch panel {
+	case FocusDisk:
+		m.disk = m.disk.SetExpanded(!expanded)
+	case FocusNetwork:
+		m.network = m.network.SetExpanded(!expanded)
+	}
+	return m, true
+}
+
+// panelFilter returns the filter of a grid component, and whether it can be filtered
+func (m MainModel) panelFilter(panel FocusedComponent) (string, bool) {
+	switch panel {
+	case FocusDisk:
+		return m.disk.GetFilter(), true
+	case FocusNetwork:
+		return m.network.GetFilter(), true
+	case FocusSensors:
+		return m.sensors.GetFilter(), true
+	case FocusAlerts:
+		return m.alertsPanel.GetFilter(), true
+	case FocusLog:
+		return m.logView.GetFilter(), true
+	default:
+		return "", false
+	}
+}
+
+// setPanelFilter sets the filter of a grid component, reporting false when it cannot be filtered
+func (m MainModel) setPanelFilter(panel FocusedComponent, filter string) (MainModel, bool) {
+	switch panel {
+	case FocusDisk:
+		m.disk = m.disk.SetFilter(filter)
+	case FocusNetwork:
+		m.network = m.network.SetFilter(filter)
+	case FocusSensors:
+		m.sensors = m.sensors.SetFilter(filter)
+	case FocusAlerts:
+		m.alertsPanel = m.alertsPanel.SetFilter(filter)
+	case FocusLog:
+		m.logView = m.logView.SetFilter(filter)
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+// IsFiltering returns whether the filter prompt is open
+func (m MainModel) IsFiltering() bool {
+	return m.filtering
+}
+
+// handleContainersKey handles selection and detail keys while the containers panel is open
+func (m MainModel) handleContainersKey(msg tea.KeyMsg) (MainModel, tea.Cmd, bool) {
+	key := msg.String()
+	switch {
+	case m.containsKey(m.keys.Up, key):
+		m.containers = m.containers.MoveSelection(-1)
+	case m.containsKey(m.keys.Down, key):
+		m.containers = m.containers.MoveSelection(1)
+	case m.containsKey(m.keys.Select, key):
+		if _, ok := m.containers.SelectedGroup(); ok {
+			m.containers = m.containers.ToggleGroup()
+			return m, nil, true
+		}
+		container, ok := m.containers.Selected()
+		if !ok {
+			return m, nil, true
+		}
+		m.containers = m.containers.SetShowDetail(true)
+		return m, m.collectContainerLogsCmd(container.ID), true
+	case m.containsKey(m.keys.Back, key):
+		if !m.containers.IsShowingDetail() {
+			m.showContainers = false
+		}
+		m.containers = m.containers.SetShowDetail(false)
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// handleAlertsKey handles selection keys while the alert history is open,
+// Enter showing the processes captured when the selected alert fired
+func (m MainModel) handleAlertsKey(msg tea.KeyMsg) (MainModel, bool) {
+	key := msg.String()
+	m = m.syncAlertsPanel()
+	switch {
+	case m.alertsPanel.IsShowingCapture():
+		if !m.containsKey(m.keys.Back, key) && !m.containsKey(m.keys.Select, key) {
+			return m, false
+		}
+		m.alertsPanel = m.alertsPanel.SetShowCapture(false)
+	case m.containsKey(m.keys.Up, key):
+		m.alertsPanel = m.alertsPanel.MoveSelection(-1)
+	case m.containsKey(m.keys.Down, key):
+		m.alertsPanel = m.alertsPanel.MoveSelection(1)
+	case m.containsKey(m.keys.Select, key):
+		if _, ok := m.alertsPanel.Selected(); ok {
+			m.alertsPanel = m.alertsPanel.SetShowCapture(true)
+		}
+	case m.containsKey(m.keys.Back, key):
+		m.showAlerts = false
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+// handleProcessesKey handles selection keys while the processes page is
+// open, and scrolling while the detail page of a process is
+func (m MainModel) handleProcessesKey(msg tea.KeyMsg) (MainModel, tea.Cmd, bool) {
+	key := msg.String()
+	if m.processes.IsShowingDetail() {
+		switch {
+		case m.containsKey(m.keys.Up, key):
+			m.processes = m.processes.ScrollDetail(-1)
+		case m.containsKey(m.keys.Down, key):
+			m.processes = m.processes.ScrollDetail(1)
+		case m.containsKey(m.keys.Environ, key):
+			m.processes = m.processes.ToggleEnviron()
+		case m.containsKey(m.keys.Back, key):
+			m.processes = m.processes.SetShowDetail(false)
+		default:
+			return m, nil, false
+		}
+		return m, nil, true
+	}
+
+	switch {
+	case m.containsKey(m.keys.Up, key):
+		m.processes = m.processes.MoveSelection(-1)
+	case m.containsKey(m.keys.Down, key):
+		m.processes = m.processes.MoveSelection(1)
+	case m.containsKey(m.keys.Select, key):
+		proc, ok := m.processes.Selected()
+		if !ok {
+			return m, nil, true
+		}
+		m.processes = m.processes.SetShowDetail(true)
+		return m, m.collectProcessDetailCmd(proc.PID), true
+	case m.containsKey(m.keys.Back, key):
+		m.showProcesses = false
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// sinkStatuses returns the delivery health of every output sink that reports it
+func (m MainModel) sinkStatuses() []models.SinkStatus {
+	var statuses []models.SinkStatus
+	listed := make(map[models.SinkReporter]bool)
+	for _, notifier := range m.notifiers {
+		if reporter, ok := notifier.(models.SinkReporter); ok {
+			statuses = append(statuses, reporter.SinkStatus())
+			listed[reporter] = true
+		}
+	}
+	for _, exporter := range m.exporters {
+		// A sink registered as both, such as the alert recorder, is listed once
+		if reporter, ok := exporter.(models.SinkReporter); ok && !listed[reporter] {
+			statuses = append(statuses, reporter.SinkStatus())
+		}
+	}
+	return statuses
+}
+
+// renderSinkStatus renders a one-line summary of output sink health, empty when no sinks are enabled
+func (m MainModel) renderSinkStatus(now time.Time) string {
+	statuses := m.sinkStatuses()
+	if len(statuses) == 0 {
+		return ""
+	}
+
+	healthy := true
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		var part string
+		switch {
+		case !status.Healthy():
+			healthy = false
+			part = fmt.Sprintf("%s ✗ failing %s", status.Name, formatAge(now.Sub(status.LastError)))
+			if status.LastSuccess.IsZero() {
+				part += ", never delivered"
+			} else {
+				part += fmt.Sprintf(", last ok %s", formatAge(now.Sub(status.LastSuccess)))
+			}
+		case status.LastSuccess.IsZero():
+			part = fmt.Sprintf("%s idle", status.Name)
+		default:
+			part = fmt.Sprintf("%s ✓ %s", status.Name, formatAge(now.Sub(status.LastSuccess)))
+		}
+		if status.Errors > 0 {
+			part += fmt.Sprintf(", %d errors", status.Errors)
+		}
+		if status.QueueDepth > 0 {
+			part += fmt.Sprintf(", queue %d", status.QueueDepth)
+		}
+		if status.Dropped > 0 {
+			part += fmt.Sprintf(", %d dropped", status.Dropped)
+		}
+		parts = append(parts, part)
+	}
+
+	line := truncate("Exports: "+strings.Join(parts, " • "), m.width)
+	if !healthy {
+		return m.styleManager.RenderErrorText(line)
+	}
+	return m.styleManager.RenderMutedText(line)
+}
+
+// formatAge renders a compact "ago" duration
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours())/24)
+	}
+}
+
+// headerTitle returns the application title with the hottest component indicator
+func (m MainModel) headerTitle() string {
+	title := "System Monitor"
+	if m.profile != "" {
+		title += " • Profile: " + m.profile
+	}
+	if hottest, ok := m.sensors.GetHottest(); ok {
+		unit := m.sensors.GetUnit()
+		title += fmt.Sprintf(" • Hottest: %s %s%s", hottest.Kind.String(), m.styleManager.Locale().FormatFloat(unit.FromCelsius(hottest.Temperature), 0), unit.Symbol())
+		if m.sensors.IsOverThreshold(hottest) {
+			title += " ⚠"
+		}
+	}
+	return title
+}
+
+// updateComponentSizes updates all component sizes based on current terminal size
+func (m MainModel) updateComponentSizes() MainModel {
+	if len(m.panels) == 0 {
+		return m
+	}
+	widths, heights := m.styleManager.CalculateGridDimensions(len(m.panels))
+	columns, _ := m.styleManager.GridShape(len(m.panels))
+
+	for i, panel := range m.panels {
+		m = m.setPanelSize(panel, widths[i%columns], heights[i/columns])
+	}
+
+	return m
+}
+
+// setPanelSize sets the dimensions of a grid component
+func (m MainModel) setPanelSize(panel FocusedComponent, width, height int) MainModel {
+	switch panel {
+	case FocusCPU:
+		m.cpu = m.cpu.SetSize(width, height)
+	case FocusMemory:
+		m.memory = m.memory.SetSize(width, height)
+	case FocusDisk:
+		m.disk = m.disk.SetSize(width, height)
+	case FocusNetwork:
+		m.network = m.network.SetSize(width, height)
+	case FocusSensors:
+		m.sensors = m.sensors.SetSize(width, height)
+	case FocusAlerts:
+		m.alertsPanel = m.alertsPanel.SetSize(width, height)
+	case FocusLog:
+		m.logView = m.logView.SetSize(width, height)
+	case FocusSelf:
+		m.self = m.self.SetSize(width, height)
+	case FocusKernel:
+		m.kernel = m.kernel.SetSize(width, height)
+	}
+	return m
+}
+
+// resizeGrid moves the column and row boundaries of the grid by the given ratios
+// and saves the new layout
+func (m MainModel) resizeGrid(columnDelta, rowDelta float64) (MainModel, tea.Cmd) {
+	column, row := m.styleManager.GetSplit()
+	m.styleManager.SetSplit(column+columnDelta, row+rowDelta)
+	m = m.updateComponentSizes()
+	return m.scheduleLayoutSave()
+}
+
+// handleMouse drags the grid boundaries: pressing on the gap between the columns
+// or rows starts a drag, motion resizes and releasing saves the layout
+func (m MainModel) handleMouse(msg tea.MouseMsg) (MainModel, tea.Cmd) {
+	if m.showHelp || m.showContainers || m.showSensors || m.showAlerts || m.showPlugins || m.showProcesses || m.showCorrelation || m.styleManager.IsSmallTerminal() {
+		return m, nil
+	}
+
+	// Only the boundary of a two-column or two-row grid can be dragged. Panels are
+	// two cells wider and taller than their content because of the border.
+	widths, heights := m.styleManager.CalculateGridDimensions(len(m.panels))
+	columnGap, rowGap := -1, -1
+	if len(widths) == 2 {
+		columnGap = widths[0] + 2
+	}
+	if len(heights) == 2 {
+		rowGap = gridTop + heights[0] + 2
+	}
+
+	switch msg.Action {
+	case tea.MouseActionPress:
+		if msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+		switch {
+		case columnGap >= 0 && abs(msg.X-columnGap) <= 1:
+			m.dragging = dragColumn
+		case rowGap >= 0 && abs(msg.Y-rowGap) <= 1:
+			m.dragging = dragRow
+		}
+
+	case tea.MouseActionMotion:
+		availableWidth := float64(m.width - 3*len(widths))
+		availableHeight := float64(m.height - 3*len(heights))
+		column, row := m.styleManager.GetSplit()
+		switch m.dragging {
+		case dragColumn:
+			m.styleManager.SetSplit(float64(msg.X-2)/availableWidth, row)
+		case dragRow:
+			m.styleManager.SetSplit(column, float64(msg.Y-gridTop-2)/availableHeight)
+		}
+		m = m.updateComponentSizes()
+
+	case tea.MouseActionRelease:
+		if m.dragging != dragNone {
+			m.dragging = dragNone
+			return m.scheduleLayoutSave()
+		}
+	}
+	return m, nil
+}
+
+// layoutSaveDelay is how long the layout has to stay unchanged before it is
+// saved, so a run of resize keys writes the config file once
+const layoutSaveDelay = 500 * time.Millisecond
+
+// LayoutSaveMsg asks for the layout to be saved, unless it changed again since
+type LayoutSaveMsg struct {
+	change int // Layout change the save was scheduled for
+}
+
+// scheduleLayoutSave saves the layout once it has stayed unchanged for
+// layoutSaveDelay
+func (m MainModel) scheduleLayoutSave() (MainModel, tea.Cmd) {
+	if m.configPath == "" {
+		return m, nil
+	}
+	m.layoutChanges++
+	change, clock := m.layoutChanges, m.tickClock()
+	return m, func() tea.Msg {
+		<-clock.After(layoutSaveDelay)
+		return LayoutSaveMsg{change: change}
+	}
+}
+
+// saveLayoutCmd writes the current split ratios to the config file
+func (m MainModel) saveLayoutCmd() tea.Cmd {
+	if m.configPath == "" {
+		return nil
+	}
+
+	settings := m.settingsWithSplits()
+	path := m.configPath
+
+	return func() tea.Msg {
+		if err := config.Save(path, settings); err != nil {
+			log.Printf("Failed to save layout: %v", err)
+		}
+		return nil
+	}
+}
+
+// abs returns the absolute value of an int
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// nextFocus returns the next focus component in layout order
+func (m MainModel) nextFocus() FocusedComponent {
+	return m.focusAt(m.focusIndex() + 1)
+}
+
+// prevFocus returns the previous focus component in layout order
+func (m MainModel) prevFocus() FocusedComponent {
+	return m.focusAt(m.focusIndex() - 1)
+}
+
+// downFocus handles down arrow navigation, staying put on the bottom row
+func (m MainModel) downFocus() FocusedComponent {
+	columns, _ := m.styleManager.GridShape(len(m.panels))
+	if index := m.focusIndex() + columns; index < len(m.panels) {
+		return m.panels[index]
+	}
+	return m.focusAt(m.focusIndex())
+}
+
+// upFocus handles up arrow navigation, staying put on the top row
+func (m MainModel) upFocus() FocusedComponent {
+	columns, _ := m.styleManager.GridShape(len(m.panels))
+	if index := m.focusIndex() - columns; index >= 0 {
+		return m.panels[index]
+	}
+	return m.focusAt(m.focusIndex())
+}
+
+// focusIndex returns the grid position of the focused component, or 0 when it
+// is not part of the grid
+func (m MainModel) focusIndex() int {
+	for i, panel := range m.panels {
+		if panel == m.focused {
+			return i
+		}
+	}
+	return 0
+}
+
+// focusAt returns the component at a grid position, wrapping around at both ends
+func (m MainModel) focusAt(index int) FocusedComponent {
+	if len(m.panels) == 0 {
+		return FocusCPU
+	}
+	index %= len(m.panels)
+	if index < 0 {
+		index += len(m.panels)
+	}
+	return m.panels[index]
+}
+
+// containsKey checks if a key string is in the provided key list
+func (m MainModel) containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFocusedComponent returns the currently focused component
+func (m MainModel) GetFocusedComponent() FocusedComponent {
+	return m.focused
+}
+
+// SetFocusedComponent sets the focused component
+func (m MainModel) SetFocusedComponent(focus FocusedComponent) MainModel {
+	m.focused = focus
+	return m
+}
+
+// GetTabNames returns the tab titles in tab bar order
+func (m MainModel) GetTabNames() []string {
+	names := make([]string, len(m.tabs))
+	for i, tab := range m.tabs {
+		names[i] = tab.name
+	}
+	return names
+}
+
+// GetActiveTab returns the index of the active tab
+func (m MainModel) GetActiveTab() int {
+	return m.activeTab
+}
+
+// SetDeterministic switches the deterministic render mode used by golden-file and
+// soak tests on or off. The clock is frozen at DeterministicTime, colors use a
+// fixed profile and numbers the C locale, so View output depends only on the
+// data that was fed in.
+// The color profile is process-wide and stays pinned after the mode is turned off.
+func (m MainModel) SetDeterministic(enabled bool) MainModel {
+	m.now = m.tickClock().Now
+	if enabled {
+		m.now = func() time.Time { return DeterministicTime }
+		lipgloss.SetColorProfile(termenv.ANSI256)
+		lipgloss.SetHasDarkBackground(true)
+		m.styleManager.SetLocale(models.DefaultLocale())
+	}
+	m.containers = m.containers.SetClock(m.now)
+	m.disk = m.disk.SetClock(m.now)
+	return m
+}
+
+// SetClock replaces the clock the refresh ticks and timestamps come from, so
+// tests can advance time with a models.FakeClock instead of sleeping
+func (m MainModel) SetClock(clock models.Clock) MainModel {
+	m.clock = clock
+	m.now = clock.Now
+	m.containers = m.containers.SetClock(m.now)
+	m.disk = m.disk.SetClock(m.now)
+	return m
+}
+
+// SetRateUnit sets whether network rates are shown in bytes or bits per
+// second, until switched with the rate unit key
+func (m MainModel) SetRateUnit(unit models.RateUnit) MainModel {
+	m.network = m.network.SetRateUnit(unit)
+	return m
+}
+
+// GetRateUnit returns the unit network rates are shown in
+func (m MainModel) GetRateUnit() models.RateUnit {
+	return m.network.GetRateUnit()
+}
+
+// SetLocale sets the number and clock conventions of every panel, overriding
+// the environment and the config file
+func (m MainModel) SetLocale(locale models.Locale) MainModel {
+	m.styleManager.SetLocale(locale)
+	return m
+}
+
+// GetLocale returns the number and clock conventions of rendered values
+func (m MainModel) GetLocale() models.Locale {
+	return m.styleManager.Locale()
+}
+
+// SetUpdateInterval changes how often metrics are collected, from the next tick
+func (m MainModel) SetUpdateInterval(interval time.Duration) MainModel {
+	m.updateInterval = interval
+	m.degradation.SetInterval(interval)
+	return m
+}
+
+// GetUpdateInterval returns how often metrics are collected, before any
+// slowdown under high load
+func (m MainModel) GetUpdateInterval() time.Duration {
+	return m.updateInterval
+}
+
+// SetUnits sets whether sizes and byte rates are shown in powers of 1000 (GB)
+// or 1024 (GiB) in every panel
+func (m MainModel) SetUnits(units models.SizeUnits) MainModel {
+	m.styleManager.SetUnits(units)
+	return m
+}
+
+// GetUnits returns the multiples sizes and byte rates are shown in
+func (m MainModel) GetUnits() models.SizeUnits {
+	return m.styleManager.Units()
+}
+
+// SetDiskFullHorizon sets how soon a filesystem has to be estimated to fill up
+// for its forecast in the disk panel to be shown as a warning
+func (m MainModel) SetDiskFullHorizon(horizon time.Duration) MainModel {
+	m.disk = m.disk.SetForecastHorizon(horizon)
+	return m
+}
+
+// tickClock returns the clock ticks wait on, the wall clock unless set
+func (m MainModel) tickClock() models.Clock {
+	if m.clock == nil {
+		return models.SystemClock
+	}
+	return m.clock
+}
+
+// SetZoomed shows the focused grid panel across the full screen
+func (m MainModel) SetZoomed(zoomed bool) MainModel {
+	m.zoomed = zoomed
+	return m
+}
+
+// IsZoomed returns whether the focused grid panel fills the screen
+func (m MainModel) IsZoomed() bool {
+	return m.zoomed
+}
+
+// OpenPage opens one of the full-screen Pages on top of the grid, or otherwise
+// selects the tab with the given name
+func (m MainModel) OpenPage(name string) (MainModel, error) {
+	switch name {
+	case "sensors":
+		m.showSensors = true
+	case "containers":
+		m.showContainers = true
+	case "alerts":
+		m.showAlerts = true
+	case "plugins":
+		m.showPlugins = true
+	case "processes":
+		m.showProcesses = true
+	case "correlation":
+		m.showCorrelation = true
+	case "help":
+		m.showHelp = true
+	default:
+		for i, tab := range m.tabs {
+			if strings.EqualFold(tab.name, name) {
+				return m.selectTab(i), nil
+			}
+		}
+		return m, fmt.Errorf("unknown page %q (want one of %v or a tab: %s)", name, Pages, strings.Join(m.GetTabNames(), ", "))
+	}
+	return m, nil
+}
+
+// GetCPUModel returns the CPU model
+func (m MainModel) GetCPUModel() CPUModel {
+	return m.cpu
+}
+
+// GetMemoryModel returns the memory model
+func (m MainModel) GetMemoryModel() MemoryModel {
+	return m.memory
+}
+
+// GetDiskModel returns the disk model
+func (m MainModel) GetDiskModel() DiskModel {
+	return m.disk
+}
+
+// GetNetworkModel returns the network model
+func (m MainModel) GetNetworkModel() NetworkModel {
+	return m.network
+}
+
+// IsShowingHelp returns whether the help screen is currently displayed
+func (m MainModel) IsShowingHelp() bool {
+	return m.showHelp
+}
+
+// SetShowHelp sets the help display state
+func (m MainModel) SetShowHelp(show bool) MainModel {
+	m.showHelp = show
+	return m
+}
+
+// AddNotifier registers a notifier that receives every fired and cleared alert
+func (m MainModel) AddNotifier(notifier models.Notifier) MainModel {
+	m.notifiers = append(m.notifiers, notifier)
+	return m
+}
+
+// GetAlertManager returns the alert manager
+func (m MainModel) GetAlertManager() *models.AlertManager {
+	return m.alerts
+}
+
+// ApplyConfig applies the style overrides, panel arrangement, tabs, grid layout,
+// temperature settings and plugins of the config file
+func (m MainModel) ApplyConfig(cfg config.Config) MainModel {
+	m.settings = cfg
+	m.profile = ""
+	m.styleManager.ApplyConfig(cfg)
+	m.tabs = newGridTabs(cfg)
+	m = m.addLogTab()
+	m = m.selectTab(0)
+	m = m.updateComponentSizes()
+	m.cpu = m.cpu.SetStyleManager(m.styleManager.ForPanel("cpu"))
+	m.memory = m.memory.SetStyleManager(m.styleManager.ForPanel("memory"))
+	m.disk = m.disk.SetStyleManager(m.styleManager.ForPanel("disk"))
+	m.network = m.network.SetStyleManager(m.styleManager.ForPanel("network")).SetRateUnit(cfg.Rates()).SetRollover(cfg.Rollover()).SetGroups(cfg.NetworkGroups())
+	m.sensors = m.sensors.SetStyleManager(m.styleManager.ForPanel("sensors"))
+	m.sensors = m.sensors.SetUnit(cfg.Unit()).SetThresholds(cfg.Thresholds())
+	m.containers = m.containers.SetStyleManager(m.styleManager.ForPanel("containers"))
+	m.alertsPanel = m.alertsPanel.SetStyleManager(m.styleManager.ForPanel("alerts"))
+	m.logView = m.logView.SetStyleManager(m.styleManager.ForPanel("log"))
+	m.self = m.self.SetStyleManager(m.styleManager.ForPanel("self"))
+	m.kernel = m.kernel.SetStyleManager(m.styleManager.ForPanel("kernel"))
+	m.processes = m.processes.SetStyleManager(m.styleManager.ForPanel("processes"))
+	m.stateThresholds = cfg.ProcessStateThresholds()
+	m.cpu = m.cpu.SetAnomalySigma(cfg.Anomaly())
+	m.memory = m.memory.SetAnomalySigma(cfg.Anomaly()).SetThresholds(cfg.MemoryUsageThresholds())
+	m.network = m.network.SetAnomalySigma(cfg.Anomaly())
+	m.disk = m.disk.SetSort(cfg.DiskOrder()).SetPinned(cfg.PinnedDisks)
+	m.network = m.network.SetSort(cfg.InterfaceOrder()).SetPinned(cfg.PinnedInterfaces)
+	m.disk = m.disk.SetCollapse(cfg.CollapseSimilar).SetThresholds(cfg.DiskUsageThresholds())
+	if m.alerts != nil {
+		m.alerts.SetRules(cfg.AlertRules())
+	}
+	m.network = m.network.SetCollapse(cfg.CollapseSimilar)
+	m = m.applyPlugins(cfg.Plugins)
+	return m
+}
+
+// SetRecorder attaches a recorder that captures every message handed to Update
+// and every collector result, for integration tests; nil detaches it
+func (m MainModel) SetRecorder(recorder *Recorder) MainModel {
+	m.recorder = recorder
+	return m
+}
+
+// SetCollector replaces the system collector and its registered CPU, memory,
+// disk, network and sensor collectors, for tests that feed canned data
+func (m MainModel) SetCollector(collector models.SystemCollector) MainModel {
+	m.collector = collector
+	services.RegisterSystemCollectors(m.registry, collector)
+	return m
+}
+
+// SetProcessCollector sets the collector of the top processes and, when it
+// implements models.ProcessMemoryCollector and models.ProcessFilesCollector,
+// of their memory breakdown and open files
+func (m MainModel) SetProcessCollector(collector models.ProcessCollector) MainModel {
+	m.processCollector = collector
+	return m
+}
+
+// SetContainerCollector sets the container runtime queried by the
+// containers page; nil turns the page's collection off
+func (m MainModel) SetContainerCollector(collector models.ContainerCollector) MainModel {
+	m.containerCollector = collector
+	return m
+}
+
+// SetBarMode sets the glyph set of bars and graphs in every panel
+func (m MainModel) SetBarMode(mode BarMode) MainModel {
+	m.styleManager.SetBarMode(mode)
+	return m
+}
+
+// GetBarMode returns the glyph set of bars and graphs
+func (m MainModel) GetBarMode() BarMode {
+	return m.styleManager.GetBarMode()
+}
+
+// SetConfigPath sets the config file that layout changes are saved to
+func (m MainModel) SetConfigPath(path string) MainModel {
+	m.configPath = path
+	return m
+}
+
+// AddExporter registers an exporter that receives a snapshot on every tick
+func (m MainModel) AddExporter(exporter models.SnapshotExporter) MainModel {
+	m.exporters = append(m.exporters, exporter)
+	return m
+}
+
+// Snapshot returns the latest data of all components as a single snapshot
+func (m MainModel) Snapshot() models.Snapshot {
+	now := m.now()
+	return models.Snapshot{
+		Timestamp: now,
+		CPU: models.CPUInfo{
+			Cores:     m.cpu.GetCores(),
+			Usage:     m.cpu.GetUsage(),
+			Total:     m.cpu.GetTotal(),
+			Limit:     m.cpu.GetLimit(),
+			Timestamp: now,
+		},
+		Memory: models.MemoryInfo{
+			Total:     m.memory.GetTotal(),
+			Used:      m.memory.GetUsed(),
+			Available: m.memory.GetAvailable(),
+			Swap:      m.memory.GetSwap(),
+			Tmpfs:     m.memory.GetTmpfs(),
+			Limit:     m.memory.GetLimit(),
+			LimitUsed: m.memory.GetLimitUsed(),
+			HugePages: m.memory.GetHugePages(),
+			Timestamp: now,
+		},
+		Disks:   m.disk.GetFilesystems(),
+		Network: m.network.GetInterfaces(),
+		Rates:   m.network.GetRates(),
+	}
+}
+
+// recordSnapshot hands the latest snapshot to every exporter
+func (m MainModel) recordSnapshot() {
+	if len(m.exporters) == 0 {
+		return
+	}
+	snapshot := m.Snapshot()
+	for _, exporter := range m.exporters {
+		exporter.Record(snapshot)
+	}
+}
+
+// GetAlertHistory returns the in-memory history of fired and cleared alerts
+func (m MainModel) GetAlertHistory() *models.AlertHistory {
+	return m.alertHistory
+}
+
+// evaluateAlerts checks a measurement against the alert rules and dispatches any transitions
+func (m MainModel) evaluateAlerts(component, subject string, value float64) tea.Cmd {
+	if m.alerts == nil {
+		return nil
+	}
+	return m.dispatchAlerts(component, m.alerts.Evaluate(component, subject, value, m.now()))
+}
+
+// forgetAlerts clears the alerts of a subject that went away and delivers
+// the resulting events like evaluateAlerts
+func (m MainModel) forgetAlerts(component, subject string) tea.Cmd {
+	if m.alerts == nil {
+		return nil
+	}
+	return m.dispatchAlerts(component, m.alerts.Forget(component, subject, m.now()))
+}
+
+// dispatchAlerts records alert events in the history, captures the busiest
+// processes when a CPU or Memory alert fired and delivers the events to the
+// notifiers
+func (m MainModel) dispatchAlerts(component string, events []models.AlertEvent) tea.Cmd {
+	var captures []tea.Cmd
+	if m.alertHistory != nil {
+		for _, event := range events {
+			m.alertHistory.Add(event)
+			if event.Fired && (component == "CPU" || component == "Memory") {
+				captures = append(captures, m.captureProcessesCmd(event))
+			}
+		}
+	}
+	if len(events) == 0 || len(m.notifiers) == 0 {
+		return tea.Batch(captures...)
+	}
+
+	notifiers := m.notifiers
+	return tea.Batch(append(captures, func() tea.Msg {
+		for _, event := range events {
+			for _, notifier := range notifiers {
+				if err := notifier.Notify(event); err != nil {
+					log.Printf("Failed to deliver alert notification: %v", err)
+				}
+			}
+		}
+		return nil
+	})...)
+}
+
+// captureProcessesCmd creates a command sampling the busiest processes in a
+// goroutine the moment event fired, by memory for a Memory alert and by CPU
+// otherwise, for the alert history
+func (m MainModel) captureProcessesCmd(event models.AlertEvent) tea.Cmd {
+	if m.processCollector == nil {
+		return nil
+	}
+	collector := m.processCollector
+	memory, _ := collector.(models.ProcessMemoryCollector)
+	byMemory := event.Rule.Component == "Memory"
+	return m.recordCollect(func() tea.Msg {
+		count := models.AlertCaptureSize
+		if byMemory {
+			count = alertCapturePool
+		}
+		processes, err := collector.CollectTopProcesses(count)
+		if err != nil {
+			log.Printf("Failed to capture the top processes of the %s alert: %v", event.Rule.Component, err)
+			return nil
+		}
+		captured := make([]models.CapturedProcess, len(processes))
+		for i, proc := range processes {
+			captured[i] = models.CapturedProcess{PID: proc.PID, Name: proc.Name, CPUPercent: proc.CPUPercent}
+			if memory != nil {
+				if breakdown, err := memory.CollectProcessMemory(proc.PID); err == nil {
+					captured[i].RSS = breakdown.RSS
+				}
+			}
+		}
+		return AlertCaptureMsg{Event: event, Processes: models.TopCapturedProcesses(captured, byMemory, models.AlertCaptureSize)}
 	})
 }
 
-// collectAllDataCmd creates a batch command to collect all system data concurrently
+// tickCmd creates a command that sends a TickMsg after the update interval,
+// stretched while the system is under high load
+func (m MainModel) tickCmd() tea.Cmd {
+	clock, interval := m.tickClock(), m.adaptive.Interval(m.updateInterval)
+	return func() tea.Msg {
+		return TickMsg(<-clock.After(interval))
+	}
+}
+
+// collectAllDataCmd creates a batch command running every registered collector concurrently
 func (m MainModel) collectAllDataCmd() tea.Cmd {
-	return tea.Batch(
-		m.collectCPUDataCmd(),
-		m.collectMemoryDataCmd(),
-		m.collectDiskDataCmd(),
-		m.collectNetworkDataCmd(),
-	)
+	collectors := m.registry.Collectors()
+	cmds := make([]tea.Cmd, 0, len(collectors))
+	for _, collector := range collectors {
+		cmds = append(cmds, m.collectCmd(collector))
+	}
+	return tea.Batch(cmds...)
 }
 
 // collectCPUDataCmd creates a command to collect CPU data in a goroutine
 func (m MainModel) collectCPUDataCmd() tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
-		cpuInfo, err := m.collector.CollectCPU()
-		if err != nil {
-			return err
-		}
-		return CPUUpdateMsg(cpuInfo)
-	})
+	return m.collectNamedCmd(services.CPUCollectorName)
 }
 
 // collectMemoryDataCmd creates a command to collect memory data in a goroutine
 func (m MainModel) collectMemoryDataCmd() tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
-		memoryInfo, err := m.collector.CollectMemory()
-		if err != nil {
-			return err
-		}
-		return MemoryUpdateMsg(memoryInfo)
-	})
+	return m.collectNamedCmd(services.MemoryCollectorName)
 }
 
 // collectDiskDataCmd creates a command to collect disk data in a goroutine
 func (m MainModel) collectDiskDataCmd() tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
-		diskInfo, err := m.collector.CollectDisk()
+	return m.collectNamedCmd(services.DiskCollectorName)
+}
+
+// collectNetworkDataCmd creates a command to collect network data in a goroutine
+func (m MainModel) collectNetworkDataCmd() tea.Cmd {
+	return m.collectNamedCmd(services.NetworkCollectorName)
+}
+
+// collectSensorsDataCmd creates a command to collect temperature sensors when the collector supports them
+func (m MainModel) collectSensorsDataCmd() tea.Cmd {
+	return m.collectNamedCmd(services.SensorsCollectorName)
+}
+
+// recordCollect wraps a collection command so its result is captured by the
+// recorder, if one is attached
+func (m MainModel) recordCollect(cmd tea.Cmd) tea.Cmd {
+	if m.recorder == nil {
+		return cmd
+	}
+	recorder := m.recorder
+	return func() tea.Msg {
+		msg := cmd()
+		recorder.Add(RecordCollect, msg)
+		return msg
+	}
+}
+
+// collectHostCmd creates a command to collect the hostname and boot time when the collector supports them
+func (m MainModel) collectHostCmd() tea.Cmd {
+	hostCollector, ok := m.collector.(models.HostCollector)
+	if !ok {
+		return nil
+	}
+	return m.recordCollect(func() tea.Msg {
+		host, err := hostCollector.CollectHost()
 		if err != nil {
 			return err
 		}
-		return DiskUpdateMsg(diskInfo)
+		return HostUpdateMsg(host)
 	})
 }
 
-// collectNetworkDataCmd creates a command to collect network data in a goroutine
-func (m MainModel) collectNetworkDataCmd() tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
-		networkInfo, err := m.collector.CollectNetwork()
+// containerLogLines is the number of log lines fetched for the container detail view
+const containerLogLines = 50
+
+// collectContainersCmd creates a command to list containers in a goroutine
+func (m MainModel) collectContainersCmd() tea.Cmd {
+	if m.containerCollector == nil || !m.degradation.Due(services.ContainersCollectorName, m.now()) {
+		return nil
+	}
+	// Collected like the registered collectors, so an absent or slow daemon
+	// is backed off, but only while the containers panel is open
+	collector := m.containerCollector
+	return m.collectCmd(services.NewCollectorFunc(services.ContainersCollectorName, func() (interface{}, error) {
+		return collector.CollectContainers()
+	}))
+}
+
+// collectContainerLogsCmd creates a command to fetch a container's log tail on demand
+func (m MainModel) collectContainerLogsCmd(id string) tea.Cmd {
+	if m.containerCollector == nil {
+		return nil
+	}
+	collector := m.containerCollector
+	return m.recordCollect(func() tea.Msg {
+		lines, err := collector.ContainerLogs(id, containerLogLines)
+		return ContainerLogsMsg{ID: id, Lines: lines, Err: err}
+	})
+}
+
+// collectProcessMemoryCmd creates a command to read the memory breakdown of
+// the listed processes in a goroutine. Processes that exited are left out.
+func (m MainModel) collectProcessMemoryCmd(pids []int32) tea.Cmd {
+	collector, ok := m.processCollector.(models.ProcessMemoryCollector)
+	if !ok || len(pids) == 0 {
+		return nil
+	}
+	return m.recordCollect(func() tea.Msg {
+		var memory ProcessMemoryMsg
+		for _, pid := range pids {
+			if breakdown, err := collector.CollectProcessMemory(pid); err == nil {
+				memory = append(memory, breakdown)
+			}
+		}
+		return memory
+	})
+}
+
+// collectProcessDetailCmd creates a command to read the detail of a process
+// in a goroutine, when the process collector supports it
+func (m MainModel) collectProcessDetailCmd(pid int32) tea.Cmd {
+	collector, ok := m.processCollector.(models.ProcessDetailCollector)
+	if !ok {
+		return func() tea.Msg {
+			return ProcessDetailMsg{PID: pid, Err: fmt.Errorf("process details are not supported")}
+		}
+	}
+	return m.recordCollect(func() tea.Msg {
+		detail, err := collector.CollectProcessDetail(pid)
+		return ProcessDetailMsg{PID: pid, Detail: detail, Err: err}
+	})
+}
+
+// collectProcessFilesCmd creates a command to count the open files of the
+// listed processes in a goroutine. Processes that exited or that may not be
+// inspected are left out.
+func (m MainModel) collectProcessFilesCmd(pids []int32) tea.Cmd {
+	collector, ok := m.processCollector.(models.ProcessFilesCollector)
+	if !ok || len(pids) == 0 {
+		return nil
+	}
+	return m.recordCollect(func() tea.Msg {
+		var files ProcessFilesMsg
+		for _, pid := range pids {
+			if open, err := collector.CollectProcessFiles(pid); err == nil {
+				files = append(files, open)
+			}
+		}
+		return files
+	})
+}
+
+// collectTopProcessesCmd creates a command to sample the heaviest CPU consumers in a goroutine
+func (m MainModel) collectTopProcessesCmd() tea.Cmd {
+	if m.processCollector == nil {
+		return nil
+	}
+	return m.recordCollect(func() tea.Msg {
+		count := topProcessCount
+		if m.showProcesses {
+			count = processPageCount
+		}
+		processes, err := m.processCollector.CollectTopProcesses(count)
 		if err != nil {
 			return err
 		}
-		return NetworkUpdateMsg(networkInfo)
+		return TopProcessesMsg(processes)
 	})
-}
\ No newline at end of file
+}
@@ -1,10 +1,16 @@
 package ui
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services/fake"
 )
 
 func TestNewMainModel(t *testing.T) {
@@ -29,6 +35,30 @@ func TestNewMainModel(t *testing.T) {
 	}
 }
 
+func TestNewMainModelWithConfig_OpensEveryPage(t *testing.T) {
+	base := NewMainModelWithConfig(2 * time.Second)
+	updated, _ := base.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	base = updated.(MainModel)
+
+	for _, page := range append(Pages, base.GetTabNames()...) {
+		model, err := base.OpenPage(page)
+		if err != nil {
+			t.Fatalf("OpenPage(%q) failed: %v", page, err)
+		}
+		if view := model.View(); view == "" {
+			t.Errorf("Expected the %s page to render", page)
+		}
+	}
+
+	// The page keys open the same pages
+	for _, key := range []string{"t", "c"} {
+		updated, _ := base.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		if view := updated.View(); view == "" {
+			t.Errorf("Expected %s to open a page that renders", key)
+		}
+	}
+}
+
 func TestMainModelInit(t *testing.T) {
 	model := NewMainModel()
 	cmd := model.Init()
@@ -492,6 +522,33 @@ func TestMainModelHelpDisplay(t *testing.T) {
 	}
 }
 
+func TestMainModelPanelHelp(t *testing.T) {
+	model := NewMainModel()
+	model.focused = FocusNetwork
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	view := stripStyles(updated.(MainModel).View())
+
+	for _, want := range []string{"Network panel:", "bytes/bits", "zoom", "session totals", "Navigation:"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the help of the network panel:\n%s", want, view)
+		}
+	}
+	if strings.Index(view, "Network panel:") > strings.Index(view, "Navigation:") {
+		t.Error("Expected the panel help above the global help")
+	}
+
+	model.focused = FocusMemory
+	model.showHelp = true
+	if view := stripStyles(model.View()); !strings.Contains(view, "Memory panel:") || !strings.Contains(view, "Available is what can be handed out") || strings.Contains(view, "Network panel:") {
+		t.Errorf("Expected only the memory panel's help, got:\n%s", view)
+	}
+
+	model.showProcesses = true
+	if view := stripStyles(model.View()); strings.Contains(view, "Memory panel:") {
+		t.Error("Expected no panel help over a full-screen page")
+	}
+}
+
 func TestMainModelKeyboardShortcutMapping(t *testing.T) {
 	model := NewMainModel()
 	keyMap := model.keys
@@ -550,4 +607,970 @@ func TestMainModelHelpToggleFromAnyState(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestMainModelTopProcessesCollection(t *testing.T) {
+	model := NewMainModel()
+
+	// Top processes are only sampled every topProcessInterval ticks
+	for i := 1; i < topProcessInterval; i++ {
+		updated, _ := model.Update(TickMsg(time.Now()))
+		model = updated.(MainModel)
+	}
+	if model.tickCount != topProcessInterval-1 {
+		t.Errorf("Expected tick count %d, got %d", topProcessInterval-1, model.tickCount)
+	}
+
+	updated, _ := model.Update(TopProcessesMsg([]models.ProcessInfo{{PID: 42, Name: "make", CPUPercent: 90}}))
+	model = updated.(MainModel)
+
+	if len(model.GetCPUModel().GetTopProcesses()) != 1 {
+		t.Error("Expected TopProcessesMsg to be forwarded to the CPU model")
+	}
+}
+
+func TestMainModelSensorsToggle(t *testing.T) {
+	model := NewMainModel()
+
+	keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")}
+	updated, _ := model.Update(keyMsg)
+	model = updated.(MainModel)
+	if !model.showSensors {
+		t.Fatal("Expected sensors panel to be shown after pressing t")
+	}
+	if !strings.Contains(model.View(), "Temperatures") {
+		t.Error("Expected sensors view to contain 'Temperatures'")
+	}
+
+	updated, _ = model.Update(keyMsg)
+	model = updated.(MainModel)
+	if model.showSensors {
+		t.Error("Expected sensors panel to be hidden after pressing t again")
+	}
+}
+
+func TestMainModelHottestIndicator(t *testing.T) {
+	model := NewMainModel()
+
+	if strings.Contains(model.headerTitle(), "Hottest") {
+		t.Error("Expected no hottest indicator without sensor data")
+	}
+
+	updated, _ := model.Update(SensorsUpdateMsg([]models.SensorInfo{
+		{Key: "coretemp", Kind: models.SensorCPU, Temperature: 55},
+		{Key: "nvidia_gpu0", Kind: models.SensorGPU, Temperature: 91},
+	}))
+	model = updated.(MainModel)
+
+	title := model.headerTitle()
+	if !strings.Contains(title, "Hottest: GPU 91°C") {
+		t.Errorf("Expected hottest GPU indicator, got %q", title)
+	}
+	if !strings.Contains(title, "⚠") {
+		t.Errorf("Expected warning marker for GPU over threshold, got %q", title)
+	}
+}
+
+// recordingNotifier captures alert events for assertions
+type recordingNotifier struct {
+	events []models.AlertEvent
+}
+
+func (r *recordingNotifier) Notify(event models.AlertEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestMainModelAlertNotifications(t *testing.T) {
+	notifier := &recordingNotifier{}
+	model := NewMainModel().AddNotifier(notifier)
+
+	updated, cmd := model.Update(MemoryUpdateMsg(models.MemoryInfo{
+		Total:     100,
+		Used:      97,
+		Available: 3,
+		Timestamp: time.Now(),
+	}))
+	model = updated.(MainModel)
+	runCmd(cmd)
+
+	if len(notifier.events) != 1 {
+		t.Fatalf("Expected 1 alert notification, got %d", len(notifier.events))
+	}
+	if !notifier.events[0].Fired || notifier.events[0].Rule.Component != "Memory" {
+		t.Errorf("Expected fired memory alert, got %+v", notifier.events[0])
+	}
+
+	// Still above threshold, no duplicate notification
+	_, cmd = model.Update(MemoryUpdateMsg(models.MemoryInfo{Total: 100, Used: 98, Timestamp: time.Now()}))
+	runCmd(cmd)
+	if len(notifier.events) != 1 {
+		t.Errorf("Expected no duplicate notification, got %d events", len(notifier.events))
+	}
+
+	_, cmd = model.Update(DiskUpdateMsg([]models.DiskInfo{{Mountpoint: "/", UsedPercent: 99}}))
+	runCmd(cmd)
+	if len(notifier.events) != 2 || notifier.events[1].Subject != "/" {
+		t.Errorf("Expected disk alert for /, got %+v", notifier.events)
+	}
+}
+
+func TestMainModelAlertHistory(t *testing.T) {
+	model := NewMainModel()
+
+	updated, _ := model.Update(MemoryUpdateMsg(models.MemoryInfo{Total: 100, Used: 97, Timestamp: time.Now()}))
+	model = updated.(MainModel)
+	updated, _ = model.Update(MemoryUpdateMsg(models.MemoryInfo{Total: 100, Used: 50, Timestamp: time.Now()}))
+	model = updated.(MainModel)
+
+	events := model.GetAlertHistory().Events()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events in history without notifiers, got %d", len(events))
+	}
+	if events[0].Fired || !events[1].Fired {
+		t.Errorf("Expected cleared event first, got %+v", events)
+	}
+
+	keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}
+	updated, _ = model.Update(keyMsg)
+	model = updated.(MainModel)
+	if !model.showAlerts {
+		t.Fatal("Expected alerts panel to be shown after pressing a")
+	}
+	view := model.View()
+	if !strings.Contains(view, "Alerts (0 active)") || !strings.Contains(view, "CLEARED") {
+		t.Errorf("Expected alert history in view, got %q", view)
+	}
+
+	updated, _ = model.Update(keyMsg)
+	model = updated.(MainModel)
+	if model.showAlerts {
+		t.Error("Expected alerts panel to be hidden after pressing a again")
+	}
+}
+
+// runCmd executes a command tree synchronously, expanding batches
+func runCmd(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, c := range batch {
+			runCmd(c)
+		}
+	}
+}
+
+// fakeContainerCollector returns canned containers and logs
+type fakeContainerCollector struct {
+	containers []models.ContainerInfo
+	logsFor    string
+	err        error
+	calls      int
+}
+
+func (f *fakeContainerCollector) CollectContainers() ([]models.ContainerInfo, error) {
+	f.calls++
+	return f.containers, f.err
+}
+
+func (f *fakeContainerCollector) ContainerLogs(id string, lines int) ([]string, error) {
+	f.logsFor = id
+	return []string{"ready"}, nil
+}
+
+func TestMainModelContainersPanel(t *testing.T) {
+	collector := &fakeContainerCollector{containers: []models.ContainerInfo{
+		{ID: "a1", Name: "web", Image: "nginx", State: "running"},
+		{ID: "b2", Name: "db", Image: "postgres", State: "running"},
+	}}
+	model := NewMainModel()
+	model.containerCollector = collector
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	model = updated.(MainModel)
+	if !model.showContainers {
+		t.Fatal("Expected containers panel to open")
+	}
+	updated, _ = model.Update(cmd())
+	model = updated.(MainModel)
+	if len(model.containers.GetContainers()) != 2 {
+		t.Fatalf("Expected 2 containers, got %d", len(model.containers.GetContainers()))
+	}
+
+	// Down moves the selection instead of the panel focus
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model = updated.(MainModel)
+	if model.focused != FocusCPU {
+		t.Error("Expected panel focus to stay unchanged while containers are open")
+	}
+
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(MainModel)
+	if !model.containers.IsShowingDetail() {
+		t.Fatal("Expected detail view after Enter")
+	}
+	updated, _ = model.Update(cmd())
+	model = updated.(MainModel)
+	if collector.logsFor != "b2" {
+		t.Errorf("Expected logs fetched for db, got %q", collector.logsFor)
+	}
+	if !strings.Contains(model.View(), "ready") {
+		t.Error("Expected log tail in detail view")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(MainModel)
+	if model.containers.IsShowingDetail() || !model.showContainers {
+		t.Error("Expected Esc to return from detail to the list")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(MainModel)
+	if model.showContainers {
+		t.Error("Expected Esc on the list to close the containers panel")
+	}
+}
+
+func TestMainModelRateUnitKey(t *testing.T) {
+	model := NewMainModel()
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if updated.(MainModel).GetRateUnit() != models.BitsPerSecond {
+		t.Fatal("Expected b to switch network rates to bits")
+	}
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if updated.(MainModel).GetRateUnit() != models.BytesPerSecond {
+		t.Error("Expected b again to switch back to bytes")
+	}
+}
+
+func TestMainModelSortKey(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(MainModel)
+	press := func() {
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+		model = updated.(MainModel)
+	}
+
+	model.focused = FocusDisk
+	press()
+	if order := model.disk.GetSort(); order != models.DiskSortMountpoint {
+		t.Fatalf("Expected o to order the disks by mountpoint, got %v", order)
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "o: sort: mountpoint") {
+		t.Errorf("Expected the order in the footer, got:\n%s", view)
+	}
+
+	model.focused = FocusNetwork
+	press()
+	if order := model.network.GetSort(); order != models.InterfaceSortName {
+		t.Errorf("Expected o to order the interfaces by name, got %v", order)
+	}
+
+	model.focused = FocusCPU
+	press()
+	if model.disk.GetSort() != models.DiskSortMountpoint || model.network.GetSort() != models.InterfaceSortName {
+		t.Error("Expected o to leave the orders alone on the CPU panel")
+	}
+}
+
+func TestMainModelExpandKey(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(MainModel)
+	press := func() {
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+		model = updated.(MainModel)
+	}
+
+	// Nothing to expand until collapse_similar is set
+	model.focused = FocusDisk
+	press()
+	if model.disk.IsExpanded() {
+		t.Error("Expected x to do nothing without collapsed rows")
+	}
+
+	model = model.ApplyConfig(config.Config{CollapseSimilar: 5})
+	model.focused = FocusDisk
+	press()
+	if !model.disk.IsExpanded() || model.network.IsExpanded() {
+		t.Fatal("Expected x to expand the disk rows only")
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "x: collapse") {
+		t.Errorf("Expected the collapse hint in the footer, got:\n%s", view)
+	}
+	press()
+	if model.disk.IsExpanded() {
+		t.Error("Expected x to collapse the disk rows again")
+	}
+}
+
+func TestMainModelCoreKeys(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(MainModel)
+	usage := make([]float64, 64)
+	usage[10] = 90
+	updated, _ = model.Update(CPUUpdateMsg(models.CPUInfo{Cores: 64, Usage: usage, Total: 1.4, Timestamp: time.Now()}))
+	model = updated.(MainModel)
+	press := func(key string) {
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		model = updated.(MainModel)
+	}
+
+	if view := stripStyles(model.View()); !strings.Contains(view, "</>: core") {
+		t.Errorf("Expected the core hint in the footer, got:\n%s", view)
+	}
+	press(">")
+	press(">")
+	if core := model.cpu.GetSelectedCore(); core != 12 {
+		t.Errorf("Expected two steps past the busiest core, got %d", core)
+	}
+	press("<")
+	if core := model.cpu.GetSelectedCore(); core != 11 {
+		t.Errorf("Expected a step back, got %d", core)
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(MainModel)
+	if core := model.cpu.GetSelectedCore(); core != 10 {
+		t.Errorf("Expected Esc to go back to the busiest core, got %d", core)
+	}
+
+	// Other panels leave the selection alone
+	model.focused = FocusDisk
+	press(">")
+	if core := model.cpu.GetSelectedCore(); core != 10 {
+		t.Errorf("Expected > to do nothing outside the CPU panel, got %d", core)
+	}
+}
+
+func TestMainModel_DiskThresholdAlerts(t *testing.T) {
+	model := NewMainModel().ApplyConfig(config.Config{DiskThresholds: map[string]config.Thresholds{"/data": {Warning: 95, Critical: 97}}})
+	update := func(used float64) {
+		updated, _ := model.Update(DiskUpdateMsg([]models.DiskInfo{{Mountpoint: "/", UsedPercent: 50}, {Mountpoint: "/data", UsedPercent: used}}))
+		model = updated.(MainModel)
+	}
+
+	// /data is past the global 95% but not its own 97%
+	update(96)
+	if count := model.alerts.ActiveCount(); count != 0 {
+		t.Errorf("Expected no alert for /data at 96%%, got %d active", count)
+	}
+	update(97)
+	if count := model.alerts.ActiveCount(); count != 1 {
+		t.Errorf("Expected /data to alert at 97%%, got %d active", count)
+	}
+}
+
+func TestMainModel_MemoryAvailableAlerts(t *testing.T) {
+	model := NewMainModel().ApplyConfig(config.Config{MemoryThresholds: &config.MemoryThresholds{Basis: "available"}})
+	update := func(available uint64) {
+		updated, _ := model.Update(MemoryUpdateMsg(models.MemoryInfo{Total: 100, Used: 99, Available: available}))
+		model = updated.(MainModel)
+	}
+
+	// Nearly all of RAM is used, but most of it can be reclaimed
+	update(40)
+	if count := model.alerts.ActiveCount(); count != 0 {
+		t.Errorf("Expected no alert with 40%% available, got %d active", count)
+	}
+	update(9)
+	if count := model.alerts.ActiveCount(); count != 1 {
+		t.Errorf("Expected an alert with 9%% available, got %d active", count)
+	}
+}
+
+func TestMainModel_UnmountedDiskAlertClears(t *testing.T) {
+	model := NewMainModel()
+	update := func(filesystems ...models.DiskInfo) {
+		updated, _ := model.Update(DiskUpdateMsg(filesystems))
+		model = updated.(MainModel)
+	}
+
+	update(models.DiskInfo{Mountpoint: "/", UsedPercent: 50}, models.DiskInfo{Mountpoint: "/mnt/usb", UsedPercent: 97})
+	if count := model.alerts.ActiveCount(); count != 1 {
+		t.Fatalf("Expected /mnt/usb to alert at 97%%, got %d active", count)
+	}
+
+	// Unmounting the full drive clears its alert instead of leaving it firing
+	update(models.DiskInfo{Mountpoint: "/", UsedPercent: 50})
+	if count := model.alerts.ActiveCount(); count != 0 {
+		t.Errorf("Expected no alert after the unmount, got %d active", count)
+	}
+	events := model.alertHistory.Events()
+	if len(events) != 2 || events[0].Fired || !events[0].Gone || events[0].Subject != "/mnt/usb" {
+		t.Errorf("Expected a clearing event for /mnt/usb, got %v", events)
+	}
+}
+
+func TestMainModelUnits(t *testing.T) {
+	model := NewMainModel().ApplyConfig(config.Default()).SetUnits(models.SIUnits)
+	if size := model.memory.styleManager.FormatBytes(8000000000); size != "8.0GB" {
+		t.Errorf("Expected memory sizes in powers of 1000, got %q", size)
+	}
+	if size := model.disk.styleManager.FormatBytes(1500000); size != "1.5MB" {
+		t.Errorf("Expected disk sizes in powers of 1000, got %q", size)
+	}
+	if rate := model.network.formatRate(2500000); rate != "2.5MB/s" {
+		t.Errorf("Expected network rates in powers of 1000, got %q", rate)
+	}
+
+	model = model.SetUnits(models.IECUnits)
+	if size := model.memory.styleManager.FormatBytes(1536); size != "1.5KiB" {
+		t.Errorf("Expected iec units to use binary suffixes, got %q", size)
+	}
+}
+
+func TestMainModelContainersBackoff(t *testing.T) {
+	clock := models.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	collector := &fakeContainerCollector{err: models.CreateSystemError(models.SystemAccessError, "Containers", "Failed to list containers", nil)}
+	model := NewMainModel().SetClock(clock)
+	model.containerCollector = collector
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	model = updated.(MainModel)
+	updated, _ = model.Update(cmd())
+	model = updated.(MainModel)
+	if !strings.Contains(model.View(), "Failed to list containers") {
+		t.Error("Expected the error in the containers panel")
+	}
+
+	// The failed collector is not queried again until its retry is due
+	if model.collectContainersCmd() != nil {
+		t.Error("Expected no collection while the containers collector is backed off")
+	}
+	clock.Advance(time.Minute)
+	collector.err = nil
+	if cmd := model.collectContainersCmd(); cmd == nil {
+		t.Fatal("Expected a collection once the retry is due")
+	} else {
+		updated, _ = model.Update(cmd())
+		model = updated.(MainModel)
+	}
+	if collector.calls != 2 || model.collectContainersCmd() == nil {
+		t.Errorf("Expected the recovered collector to run on every tick, got %d calls", collector.calls)
+	}
+}
+
+func TestMainModelContainerGroupToggle(t *testing.T) {
+	compose := map[string]string{models.LabelComposeProject: "shop"}
+	collector := &fakeContainerCollector{containers: []models.ContainerInfo{
+		{ID: "a1", Name: "web", Image: "nginx", State: "running", Labels: compose},
+		{ID: "b2", Name: "db", Image: "postgres", State: "running", Labels: compose},
+	}}
+	model := NewMainModel()
+	model.containerCollector = collector
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	model = updated.(MainModel)
+	updated, _ = model.Update(cmd())
+	model = updated.(MainModel)
+
+	// Enter on a group expands it instead of opening the detail view
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(MainModel)
+	if cmd != nil || model.containers.IsShowingDetail() {
+		t.Error("Expected Enter on a group not to open the detail view")
+	}
+	if !model.containers.IsExpanded("compose/shop") {
+		t.Fatal("Expected Enter to expand the group")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(MainModel)
+	if model.containers.IsExpanded("compose/shop") {
+		t.Error("Expected a second Enter to collapse the group")
+	}
+}
+
+// reportingNotifier is a notifier with a fixed delivery health
+type reportingNotifier struct {
+	recordingNotifier
+	status models.SinkStatus
+}
+
+func (r *reportingNotifier) SinkStatus() models.SinkStatus {
+	return r.status
+}
+
+func TestMainModelSinkStatus(t *testing.T) {
+	model := NewMainModel()
+	model.width = 200
+	now := time.Now()
+
+	if model.renderSinkStatus(now) != "" {
+		t.Error("Expected no export status without sinks")
+	}
+
+	// Notifiers that don't report health are not sinks
+	model = model.AddNotifier(&recordingNotifier{})
+	if model.renderSinkStatus(now) != "" {
+		t.Error("Expected no export status for non-reporting notifiers")
+	}
+
+	sink := &reportingNotifier{status: models.SinkStatus{Name: "webhook", Delivered: 3, LastSuccess: now.Add(-12 * time.Second)}}
+	model = model.AddNotifier(sink)
+	line := model.renderSinkStatus(now)
+	if !strings.Contains(line, "webhook ✓ 12s ago") {
+		t.Errorf("Expected healthy webhook status, got %q", line)
+	}
+	if !strings.Contains(model.View(), "Exports:") {
+		t.Error("Expected export status in the main view")
+	}
+
+	sink.status.Errors = 5
+	sink.status.QueueDepth = 2
+	sink.status.Dropped = 7
+	sink.status.LastError = now.Add(-3 * time.Minute)
+	sink.status.LastSuccess = now.Add(-2 * time.Hour)
+	line = model.renderSinkStatus(now)
+	for _, want := range []string{"webhook ✗ failing 3m ago", "last ok 2h ago", "5 errors", "queue 2", "7 dropped"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Expected %q in export status, got %q", want, line)
+		}
+	}
+}
+
+// recordingExporter captures recorded snapshots
+type recordingExporter struct {
+	snapshots []models.Snapshot
+}
+
+func (r *recordingExporter) Record(snapshot models.Snapshot) {
+	r.snapshots = append(r.snapshots, snapshot)
+}
+
+func TestMainModelExporters(t *testing.T) {
+	exporter := &recordingExporter{}
+	model := NewMainModel().AddExporter(exporter)
+
+	updated, _ := model.Update(MemoryUpdateMsg(models.MemoryInfo{Total: 100, Used: 40, Available: 60, Timestamp: time.Now()}))
+	model = updated.(MainModel)
+	updated, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{{Mountpoint: "/", UsedPercent: 50}}))
+	model = updated.(MainModel)
+
+	model.Update(TickMsg(time.Now()))
+	if len(exporter.snapshots) != 1 {
+		t.Fatalf("Expected one snapshot per tick, got %d", len(exporter.snapshots))
+	}
+
+	snapshot := exporter.snapshots[0]
+	if snapshot.Memory.Used != 40 || snapshot.Memory.Total != 100 {
+		t.Errorf("Expected memory data in snapshot, got %+v", snapshot.Memory)
+	}
+	if len(snapshot.Disks) != 1 || snapshot.Disks[0].Mountpoint != "/" {
+		t.Errorf("Expected disk data in snapshot, got %+v", snapshot.Disks)
+	}
+	if snapshot.Timestamp.IsZero() {
+		t.Error("Expected snapshot timestamp")
+	}
+}
+
+func TestMainModelApplyConfig(t *testing.T) {
+	model := NewMainModel().ApplyConfig(config.Config{
+		Panels: map[string]config.Style{"disk": {BarFilled: "#"}},
+	})
+
+	if model.disk.styleManager != model.styleManager.ForPanel("disk") {
+		t.Error("Expected disk panel to use its configured style manager")
+	}
+	if model.cpu.styleManager != model.styleManager {
+		t.Error("Expected panels without overrides to use the global style manager")
+	}
+}
+
+func TestMainModelTemperatureUnit(t *testing.T) {
+	model := NewMainModel().ApplyConfig(config.Config{TemperatureUnit: "fahrenheit"})
+	updated, _ := model.Update(SensorsUpdateMsg([]models.SensorInfo{
+		{Key: "coretemp", Kind: models.SensorCPU, Temperature: 50},
+	}))
+	model = updated.(MainModel)
+
+	if title := model.headerTitle(); !strings.Contains(title, "CPU 122°F") {
+		t.Errorf("Expected hottest temperature in Fahrenheit, got %q", title)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	model = updated.(MainModel)
+	if title := model.headerTitle(); !strings.Contains(title, "CPU 50°C") {
+		t.Errorf("Expected u to switch back to Celsius, got %q", title)
+	}
+}
+
+func TestMainModelResizeGrid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	clock := models.NewFakeClock(DeterministicTime)
+	model := NewMainModel().ApplyConfig(config.Config{Layout: config.Layout{ColumnSplit: 0.6}}).SetConfigPath(path).SetClock(clock)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 126, Height: 46})
+	model = updated.(MainModel)
+
+	if model.GetCPUModel().width != 72 || model.GetMemoryModel().width != 48 {
+		t.Errorf("Expected configured 72/48 column split, got %d/%d", model.GetCPUModel().width, model.GetMemoryModel().width)
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlDown})
+	model = updated.(MainModel)
+	if model.GetCPUModel().height != 22 || model.GetDiskModel().height != 18 {
+		t.Errorf("Expected ctrl+down to grow the top row to 22/18, got %d/%d", model.GetCPUModel().height, model.GetDiskModel().height)
+	}
+
+	// A run of resizes is saved once, after the last one
+	updated, next := model.Update(tea.KeyMsg{Type: tea.KeyCtrlUp})
+	model = updated.(MainModel)
+	updated, last := model.Update(tea.KeyMsg{Type: tea.KeyCtrlDown})
+	model = updated.(MainModel)
+
+	saves := make(chan tea.Msg, 3)
+	for _, cmd := range []tea.Cmd{cmd, next, last} {
+		go func() { saves <- cmd() }()
+	}
+	clock.BlockUntil(3)
+	clock.Advance(layoutSaveDelay)
+	var writes []tea.Cmd
+	for range 3 {
+		updated, save := model.Update(<-saves)
+		model = updated.(MainModel)
+		if save != nil {
+			writes = append(writes, save)
+		}
+	}
+	if len(writes) != 1 {
+		t.Fatalf("Expected a single save for the run of resizes, got %d", len(writes))
+	}
+	runCmd(writes[0])
+	saved, err := config.Load(path, true)
+	if err != nil {
+		t.Fatalf("Expected layout to be saved: %v", err)
+	}
+	if column, row := saved.Layout.Splits(); column != 0.6 || row != 0.55 {
+		t.Errorf("Expected saved splits 0.6/0.55, got %v/%v", column, row)
+	}
+}
+
+func TestMainModelDragColumn(t *testing.T) {
+	model := NewMainModel()
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 126, Height: 46})
+	model = updated.(MainModel)
+
+	// The gap between the columns sits right after the left panel and its border
+	press := tea.MouseMsg{X: 62, Y: 10, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+	motion := tea.MouseMsg{X: 92, Y: 10, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft}
+	release := tea.MouseMsg{X: 92, Y: 10, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft}
+
+	for _, msg := range []tea.Msg{press, motion, release} {
+		updated, _ = model.Update(msg)
+		model = updated.(MainModel)
+	}
+
+	if model.GetCPUModel().width != 90 || model.GetMemoryModel().width != 30 {
+		t.Errorf("Expected drag to resize columns to 90/30, got %d/%d", model.GetCPUModel().width, model.GetMemoryModel().width)
+	}
+
+	// Motion without a drag leaves the layout alone
+	updated, _ = model.Update(tea.MouseMsg{X: 40, Y: 10, Action: tea.MouseActionMotion})
+	if updated.(MainModel).GetCPUModel().width != 90 {
+		t.Error("Expected mouse motion without a drag to be ignored")
+	}
+}
+
+
+func TestMainModelFooterHints(t *testing.T) {
+	model := NewMainModel()
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(MainModel)
+
+	footer := model.renderFooter()
+	for _, want := range []string{"tab: navigate", "t: temps", "ctrl+←/ctrl+→: resize", "q: quit", "?: help"} {
+		if !strings.Contains(footer, want) {
+			t.Errorf("Expected grid footer to contain %q, got %q", want, footer)
+		}
+	}
+
+	// Overlays show their own actions instead of the grid's
+	model.showSensors = true
+	footer = model.renderFooter()
+	if !strings.Contains(footer, "t: back") || !strings.Contains(footer, "u: °C/°F") || strings.Contains(footer, "navigate") {
+		t.Errorf("Expected sensors footer, got %q", footer)
+	}
+	model.showSensors = false
+
+	model.showContainers = true
+	if footer = model.renderFooter(); !strings.Contains(footer, "↑/↓: select") || !strings.Contains(footer, "enter: details/expand") {
+		t.Errorf("Expected containers footer, got %q", footer)
+	}
+
+	// Hints follow the keymap
+	model.keys.Up = []string{"w"}
+	model.keys.Down = []string{"s"}
+	if footer = model.renderFooter(); !strings.Contains(footer, "w/s: select") {
+		t.Errorf("Expected hints from the rebound keymap, got %q", footer)
+	}
+}
+
+func TestMainModelPanelArrangement(t *testing.T) {
+	model := NewMainModel().SetFocusedComponent(FocusDisk)
+	model = model.ApplyConfig(config.Config{Layout: config.Layout{Panels: []string{"memory", "cpu", "sensors"}, Columns: 3}})
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 130, Height: 30})
+	model = updated.(MainModel)
+
+	// Focus moves to the first panel when the focused one is not in the grid
+	if model.GetFocusedComponent() != FocusMemory {
+		t.Errorf("Expected focus on the first configured panel, got %v", model.GetFocusedComponent())
+	}
+
+	// Tab follows the configured order and wraps around
+	for _, want := range []FocusedComponent{FocusCPU, FocusSensors, FocusMemory} {
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+		model = updated.(MainModel)
+		if model.GetFocusedComponent() != want {
+			t.Errorf("Expected focus %v, got %v", want, model.GetFocusedComponent())
+		}
+	}
+
+	// A single row has nowhere to go up or down
+	if model.downFocus() != FocusMemory || model.upFocus() != FocusMemory {
+		t.Error("Expected up/down to stay in a single-row grid")
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "Temperatures") || strings.Contains(view, "Network Activity") {
+		t.Errorf("Expected only the configured panels in the grid, got %s", view)
+	}
+	if model.GetCPUModel().width != 40 {
+		t.Errorf("Expected three 40-cell columns, got %d", model.GetCPUModel().width)
+	}
+}
+
+func TestMainModelSingleColumn(t *testing.T) {
+	model := NewMainModel().ApplyConfig(config.Config{Layout: config.Layout{Columns: 1}})
+
+	if model.downFocus() != FocusMemory {
+		t.Errorf("Expected down from CPU to reach Memory in a single column, got %v", model.downFocus())
+	}
+	model = model.SetFocusedComponent(FocusNetwork)
+	if model.downFocus() != FocusNetwork || model.upFocus() != FocusDisk {
+		t.Error("Expected up/down to move one panel at a time in a single column")
+	}
+}
+
+func TestMainModelZoom(t *testing.T) {
+	model := NewMainModel().SetFocusedComponent(FocusDisk)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MainModel)
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	model = updated.(MainModel)
+	if !model.IsZoomed() {
+		t.Fatal("Expected z to zoom the focused panel")
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "Disk Usage") || strings.Contains(view, "Network Activity") {
+		t.Errorf("Expected only the disk panel when zoomed, got %s", view)
+	}
+	if !strings.Contains(view, "z: unzoom") {
+		t.Errorf("Expected unzoom hint in the footer, got %s", view)
+	}
+
+	// Tab moves the zoom to the next panel
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model = updated.(MainModel)
+	if view = model.View(); !strings.Contains(view, "Network Activity") {
+		t.Errorf("Expected the network panel zoomed after tab, got %s", view)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	if updated.(MainModel).IsZoomed() {
+		t.Error("Expected z to return to the grid")
+	}
+}
+
+func TestMainModelZoom_CPUPower(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector())
+	cpu, _ := fake.NewCollector().CollectCPU()
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	updated, _ = updated.(MainModel).Update(CPUUpdateMsg(cpu))
+	updated, _ = updated.(MainModel).Update(collectedMsg("Power", models.CPUPower{
+		Clusters:  []models.CPUCluster{{Name: "E", Cores: 4, Frequency: 972, Active: 12}},
+		Timestamp: time.Now(),
+	}))
+	model = updated.(MainModel)
+
+	if view := stripStyles(model.View()); strings.Contains(view, "E×4:") {
+		t.Errorf("Expected no clusters in the grid, got:\n%s", view)
+	}
+	if view := stripStyles(model.SetZoomed(true).View()); !strings.Contains(view, "E×4:") {
+		t.Errorf("Expected the clusters on the zoomed CPU panel, got:\n%s", view)
+	}
+
+	// A failed collection is backed off and its reason shown below the clusters
+	err := models.CreateSystemError(models.PermissionError, "Power", "powermetrics must be invoked as the superuser", nil)
+	updated, _ = model.Update(CollectFailedMsg{Name: "Power", Err: err})
+	model = updated.(MainModel)
+	if view := stripStyles(model.SetZoomed(true).View()); !strings.Contains(view, "Power: powermetrics must be invoked as the superuser") || !strings.Contains(view, "E×4:") {
+		t.Errorf("Expected the power error below the clusters, got:\n%s", view)
+	}
+	if model.degradation.Due("Power", model.now()) {
+		t.Error("Expected the failed power collector to be backed off")
+	}
+}
+
+func TestMainModelOpenPage(t *testing.T) {
+	model, err := NewMainModel().OpenPage("sensors")
+	if err != nil || !strings.Contains(model.View(), "t: back") {
+		t.Errorf("Expected the sensors page, got %v", err)
+	}
+
+	if model, err := NewMainModel().OpenPage("processes"); err != nil || !strings.Contains(model.View(), "P: back") {
+		t.Errorf("Expected the processes page, got %v", err)
+	}
+
+	if _, err := NewMainModel().OpenPage("nonexistent"); err == nil {
+		t.Error("Expected error for an unknown page")
+	}
+}
+
+func TestMainModelTabs(t *testing.T) {
+	model := NewMainModel()
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MainModel)
+
+	if names := model.GetTabNames(); strings.Join(names, ",") != "Overview,Storage,Network,Health" {
+		t.Fatalf("Unexpected default tabs %v", names)
+	}
+	if view := model.View(); !strings.Contains(view, "[1 Overview]") || !strings.Contains(view, "2 Storage") {
+		t.Errorf("Expected tab bar with the active tab marked, got %s", view)
+	}
+
+	// Number keys switch tabs and move the focus into the tab
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	model = updated.(MainModel)
+	if model.GetActiveTab() != 1 || model.GetFocusedComponent() != FocusDisk {
+		t.Errorf("Expected storage tab with disk focused, got tab %d focus %v", model.GetActiveTab(), model.GetFocusedComponent())
+	}
+	if view := model.View(); !strings.Contains(view, "Disk Usage") || strings.Contains(view, "CPU Usage") {
+		t.Errorf("Expected only the disk panel on the storage tab, got %s", view)
+	}
+	if model.GetDiskModel().width != 117 {
+		t.Errorf("Expected the disk panel to fill the width, got %d", model.GetDiskModel().width)
+	}
+
+	// F-keys work too, and keys past the last tab are ignored
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyF4})
+	model = updated.(MainModel)
+	if model.GetActiveTab() != 3 || model.GetFocusedComponent() != FocusSensors {
+		t.Errorf("Expected health tab with sensors focused, got tab %d focus %v", model.GetActiveTab(), model.GetFocusedComponent())
+	}
+	if model.downFocus() != FocusAlerts {
+		t.Error("Expected alerts below sensors in the single-column health tab")
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("9")})
+	if updated.(MainModel).GetActiveTab() != 3 {
+		t.Error("Expected a key without a tab to be ignored")
+	}
+
+	// Tabs can be turned off in the config file
+	model = NewMainModel().ApplyConfig(config.Config{Tabs: []config.Tab{}})
+	if len(model.GetTabNames()) != 1 || model.renderTabBar() != "" {
+		t.Error("Expected no tab bar with tabs turned off")
+	}
+}
+
+func TestMainModelDeterministicRender(t *testing.T) {
+	render := func() string {
+		model := NewMainModel().SetDeterministic(true)
+		msgs := []tea.Msg{
+			tea.WindowSizeMsg{Width: 120, Height: 40},
+			MemoryUpdateMsg(models.MemoryInfo{Total: 100, Used: 97, Available: 3}),
+			ContainersUpdateMsg([]models.ContainerInfo{
+				{ID: "abc", Name: "web", Image: "nginx:1.25", State: "running", StartedAt: DeterministicTime.Add(-90 * time.Minute)},
+			}),
+		}
+		for _, msg := range msgs {
+			updated, _ := model.Update(msg)
+			model = updated.(MainModel)
+		}
+
+		views := []string{model.View()}
+		for _, page := range []string{"alerts", "containers"} {
+			opened, _ := model.OpenPage(page)
+			views = append(views, opened.View())
+		}
+		return strings.Join(views, "\n")
+	}
+
+	first := render()
+	if second := render(); first != second {
+		t.Error("Expected identical output across renders in deterministic mode")
+	}
+
+	// Timestamps come from the frozen clock
+	if !strings.Contains(first, "12:00:00  FIRED") {
+		t.Errorf("Expected alert stamped with the frozen clock, got %s", first)
+	}
+	if !strings.Contains(first, "1h30m") {
+		t.Errorf("Expected uptime relative to the frozen clock, got %s", first)
+	}
+}
+
+func TestMainModelFilterPrompt(t *testing.T) {
+	model := NewMainModel().SetFocusedComponent(FocusDisk)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MainModel)
+	updated, _ = model.Update(DiskUpdateMsg([]models.DiskInfo{
+		{Mountpoint: "/", Total: 100, Used: 50, UsedPercent: 50},
+		{Mountpoint: "/home", Total: 100, Used: 20, UsedPercent: 20},
+	}))
+	model = updated.(MainModel)
+
+	press := func(msgs ...tea.KeyMsg) {
+		for _, msg := range msgs {
+			updated, _ := model.Update(msg)
+			model = updated.(MainModel)
+		}
+	}
+	runes := func(text string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)} }
+
+	press(runes("/"))
+	if !model.IsFiltering() {
+		t.Fatal("Expected / to open the filter prompt")
+	}
+
+	// Keys are typed into the prompt and filter live, q does not quit
+	press(runes("h"), runes("o"), runes("q"), tea.KeyMsg{Type: tea.KeyBackspace})
+	if model.GetDiskModel().GetFilter() != "ho" || len(model.GetDiskModel().GetVisibleFilesystems()) != 1 {
+		t.Errorf("Expected live filter \"ho\", got %q", model.GetDiskModel().GetFilter())
+	}
+	if footer := model.renderFooter(); !strings.Contains(footer, "Filter disk: ho") || !strings.Contains(footer, "esc: clear") {
+		t.Errorf("Expected filter prompt in the footer, got %q", footer)
+	}
+
+	// Enter keeps the filter, Escape clears it
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.IsFiltering() || model.GetDiskModel().GetFilter() != "ho" {
+		t.Error("Expected enter to close the prompt and keep the filter")
+	}
+
+	// The prompt reopens on the filter, and its cursor moves to edit it
+	press(runes("/"), tea.KeyMsg{Type: tea.KeyLeft}, tea.KeyMsg{Type: tea.KeyLeft}, runes("/"), tea.KeyMsg{Type: tea.KeyEnter})
+	if model.GetDiskModel().GetFilter() != "/ho" || len(model.GetDiskModel().GetVisibleFilesystems()) != 1 {
+		t.Errorf("Expected the filter edited to \"/ho\", got %q", model.GetDiskModel().GetFilter())
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.GetDiskModel().GetFilter() != "" {
+		t.Error("Expected escape to clear the filter")
+	}
+
+	press(runes("/"), runes("x"), tea.KeyMsg{Type: tea.KeyEsc})
+	if model.IsFiltering() || model.GetDiskModel().GetFilter() != "" {
+		t.Error("Expected escape in the prompt to clear the filter and close it")
+	}
+
+	// Panels without lists have no filter
+	model = model.SetFocusedComponent(FocusCPU)
+	press(runes("/"))
+	if model.IsFiltering() {
+		t.Error("Expected no filter prompt for the CPU panel")
+	}
+}
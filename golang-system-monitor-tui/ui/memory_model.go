@@ -2,10 +2,10 @@ package ui
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"golang-system-monitor-tui/models"
 )
@@ -19,6 +19,15 @@ type MemoryModel struct {
 	used       uint64    // Used RAM in bytes
 	available  uint64    // Available RAM in bytes
 	swap       models.SwapInfo // Swap memory information
+	tmpfs      []models.DiskInfo // RAM-backed filesystems such as /dev/shm
+	limit      uint64    // cgroup memory limit in bytes, 0 without a limit
+	limitUsed  uint64    // Memory charged to the cgroup in bytes
+	hugePages  models.HugePages // Huge page pool, shown only when configured
+	usageStats models.RunningStats // Session average and peak of the RAM usage in percent
+	baseline   models.Baseline     // Usual RAM usage in percent, learned from the recent samples
+	deviation  float64             // Standard deviations of the RAM usage from the baseline before it
+	anomalySigma float64           // Deviation from which the usage is marked unusual, 0 to never mark it
+	thresholds models.MemoryThresholds // Share of RAM and percentages the RAM bar is colored by
 	lastUpdate time.Time // Last update timestamp
 	width      int       // Component width for rendering
 	height     int       // Component height for rendering
@@ -26,6 +35,9 @@ type MemoryModel struct {
 	hasError bool         // Whether the component has an error
 	errorMessage string   // Current error message
 	lastError time.Time   // Timestamp of last error
+	retryIn time.Duration // Time until the failing collector is retried
+	version  uint64       // Changes with every change of the rendered state
+	cache    *viewCache   // Last rendered view, shared by copies of the model
 }
 
 // NewMemoryModel creates a new memory model instance
@@ -35,10 +47,14 @@ func NewMemoryModel() MemoryModel {
 		used:         0,
 		available:    0,
 		swap:         models.SwapInfo{},
+		anomalySigma: models.DefaultAnomalySigma,
+		thresholds:   models.DefaultMemoryThresholds(models.MemoryBasisUsed),
 		lastUpdate:   time.Now(),
 		width:        40,
 		height:       8,
 		styleManager: NewStyleManager(),
+		version:      nextRenderVersion(),
+		cache:        newViewCache(),
 	}
 }
 
@@ -49,6 +65,7 @@ func (m MemoryModel) Init() tea.Cmd {
 
 // Update handles messages and updates the memory model state
 func (m MemoryModel) Update(msg tea.Msg) (MemoryModel, tea.Cmd) {
+	m.version = nextRenderVersion()
 	switch msg := msg.(type) {
 	case MemoryUpdateMsg:
 		// Clear any previous errors on successful update
@@ -60,7 +77,17 @@ func (m MemoryModel) Update(msg tea.Msg) (MemoryModel, tea.Cmd) {
 		m.used = msg.Used
 		m.available = msg.Available
 		m.swap = msg.Swap
+		m.tmpfs = msg.Tmpfs
+		m.limit = msg.Limit
+		m.limitUsed = msg.LimitUsed
+		m.hugePages = msg.HugePages
 		m.lastUpdate = msg.Timestamp
+		if msg.Total > 0 {
+			usedPercent := models.MemoryInfo(msg).UsedPercent()
+			m.usageStats = m.usageStats.Add(usedPercent)
+			m.deviation = m.baseline.Deviation(usedPercent, memoryBaselineFloor)
+			m.baseline = m.baseline.Add(usedPercent)
+		}
 		
 	case models.ErrorMsg:
 		// Handle error messages for Memory component
@@ -75,26 +102,30 @@ func (m MemoryModel) Update(msg tea.Msg) (MemoryModel, tea.Cmd) {
 
 // View renders the memory model
 func (m MemoryModel) View() string {
-	var sections []string
+	return m.cache.render(m.version, m.styleManager, m.render)
+}
+
+// render draws the view of the current state
+func (m MemoryModel) render() string {
+	buf := getRenderBuffer()
+	defer buf.release()
+	sections := buf.lines
 	
 	// Header
-	header := m.styleManager.RenderHeader("Memory Usage")
+	header := m.styleManager.RenderHeader("Memory Usage") + renderUnusualMarker(m.styleManager, "", m.deviation, m.anomalySigma)
 	sections = append(sections, header)
 
 	// Handle error state
 	if m.hasError {
 		sections = append(sections, m.styleManager.RenderErrorText("Error: "+m.errorMessage))
-		sections = append(sections, m.styleManager.RenderMutedText("Memory data unavailable"))
+		sections = append(sections, renderUnavailable(m.styleManager, "Memory data unavailable", m.retryIn))
 		
 		// Show fallback display with N/A values
 		sections = append(sections, "RAM: N/A")
 		sections = append(sections, "Swap: N/A")
 		
 		// Add spacing
-		for len(sections) < m.height {
-			sections = append(sections, "")
-		}
-		return strings.Join(sections, "\n")
+		return buf.join(sections, m.height)
 	}
 
 	// Handle loading state
@@ -106,73 +137,123 @@ func (m MemoryModel) View() string {
 	// RAM usage
 	ramUsagePercent := float64(m.used) / float64(m.total) * 100
 	barWidth := m.styleManager.GetProgressBarWidth(m.width, 6) // "RAM: " = 5 chars + space
-	ramBar := m.styleManager.RenderProgressBar(ramUsagePercent, barWidth, false)
-	ramLine := fmt.Sprintf("RAM: %s %.1f%%", ramBar, ramUsagePercent)
+	ramBar := m.styleManager.RenderColoredBar(ramUsagePercent, barWidth, m.GetUsageColor())
+	ramLine := fmt.Sprintf("RAM: %s %s", ramBar, m.styleManager.Locale().FormatPercent(ramUsagePercent, 1))
 	sections = append(sections, ramLine)
 
 	// RAM details in human-readable format
 	ramDetails := fmt.Sprintf("     %s / %s", 
-		m.formatBytes(m.used), 
-		m.formatBytes(m.total))
+		m.styleManager.FormatBytes(m.used), 
+		m.styleManager.FormatBytes(m.total))
+	if m.limit > 0 {
+		ramDetails += fmt.Sprintf(" (limit %s)", m.styleManager.FormatBytes(m.limit))
+	}
+	// The share the bar is colored by, when it is not the used one shown above
+	if m.thresholds.Basis == models.MemoryBasisAvailable {
+		ramDetails += fmt.Sprintf(", %s available", m.styleManager.Locale().FormatPercent(m.GetAlertPercent(), 0))
+	}
+	// Session average and peak, when they fit on the line
+	if m.usageStats.Count > 1 {
+		if withStats := ramDetails + ", " + formatPercentStats(m.styleManager.Locale(), m.usageStats); lipgloss.Width(withStats) <= m.width {
+			ramDetails = withStats
+		}
+	}
 	sections = append(sections, m.styleManager.RenderMutedText(ramDetails))
 
+	// Usage against the container's cgroup limit, which runs out before the host's RAM
+	if m.limit > 0 {
+		limitPercent := min(float64(m.limitUsed)/float64(m.limit)*100, 100)
+		barWidth := m.styleManager.GetProgressBarWidth(m.width, 7) // "Limit: " = 7 chars
+		limitBar := m.styleManager.RenderProgressBar(limitPercent, barWidth, false)
+		sections = append(sections, fmt.Sprintf("Limit: %s %s", limitBar, m.styleManager.Locale().FormatPercent(limitPercent, 1)))
+	}
+
 	// Swap usage (if swap is configured)
 	if m.swap.Total > 0 {
 		swapUsagePercent := float64(m.swap.Used) / float64(m.swap.Total) * 100
 		barWidth := m.styleManager.GetProgressBarWidth(m.width, 7) // "Swap: " = 6 chars + space
 		swapBar := m.styleManager.RenderProgressBar(swapUsagePercent, barWidth, false)
-		swapLine := fmt.Sprintf("Swap: %s %.1f%%", swapBar, swapUsagePercent)
+		swapLine := fmt.Sprintf("Swap: %s %s", swapBar, m.styleManager.Locale().FormatPercent(swapUsagePercent, 1))
 		sections = append(sections, swapLine)
 
 		// Swap details in human-readable format
 		swapDetails := fmt.Sprintf("      %s / %s", 
-			m.formatBytes(m.swap.Used), 
-			m.formatBytes(m.swap.Total))
+			m.styleManager.FormatBytes(m.swap.Used), 
+			m.styleManager.FormatBytes(m.swap.Total))
 		sections = append(sections, m.styleManager.RenderMutedText(swapDetails))
 	} else {
 		sections = append(sections, m.styleManager.RenderMutedText("Swap: Not configured"))
 	}
 
-	// Add spacing if we have fewer lines than available height
-	for len(sections) < m.height {
-		sections = append(sections, "")
+	// Huge pages are taken from RAM up front, used or not
+	if m.hugePages.Total > 0 {
+		barWidth := m.styleManager.GetProgressBarWidth(m.width, 7) // "Huge: " = 6 chars + space
+		hugeBar := m.styleManager.RenderProgressBar(m.hugePages.UsedPercent(), barWidth, false)
+		sections = append(sections, fmt.Sprintf("Huge: %s %s", hugeBar, m.styleManager.Locale().FormatPercent(m.hugePages.UsedPercent(), 1)))
+
+		hugeDetails := fmt.Sprintf("      %s / %s",
+			m.styleManager.FormatBytes(m.hugePages.Used()*m.hugePages.Size),
+			m.styleManager.FormatBytes(m.hugePages.Total*m.hugePages.Size))
+		if m.hugePages.Reserved > 0 {
+			hugeDetails += fmt.Sprintf(", %s reserved", m.styleManager.FormatBytes(m.hugePages.Reserved*m.hugePages.Size))
+		}
+		sections = append(sections, m.styleManager.RenderMutedText(truncate(hugeDetails, m.width)))
 	}
 
-	return strings.Join(sections, "\n")
-}
-
+	// Shared memory and other tmpfs mounts, as far as the height allows
+	if len(m.tmpfs) > 0 && len(sections) < m.height {
+		var used uint64
+		for _, mount := range m.tmpfs {
+			used += mount.Used
+		}
+		sections = append(sections, fmt.Sprintf("tmpfs: %s in RAM", m.styleManager.FormatBytes(used)))
+		for _, mount := range m.tmpfs {
+			if len(sections) >= m.height {
+				break
+			}
+			mountDetails := fmt.Sprintf("  %s %s / %s",
+				padRight(mount.Mountpoint, 13),
+				m.styleManager.FormatBytes(mount.Used),
+				m.styleManager.FormatBytes(mount.Total))
+			sections = append(sections, m.styleManager.RenderMutedText(mountDetails))
+		}
+	}
 
+	// Add spacing if we have fewer lines than available height
+	return buf.join(sections, m.height)
+}
 
-// formatBytes converts bytes to human-readable format (GB/MB/KB)
-func (m MemoryModel) formatBytes(bytes uint64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-		TB = GB * 1024
-	)
 
-	switch {
-	case bytes >= TB:
-		return fmt.Sprintf("%.1fTB", float64(bytes)/TB)
-	case bytes >= GB:
-		return fmt.Sprintf("%.1fGB", float64(bytes)/GB)
-	case bytes >= MB:
-		return fmt.Sprintf("%.1fMB", float64(bytes)/MB)
-	case bytes >= KB:
-		return fmt.Sprintf("%.1fKB", float64(bytes)/KB)
-	default:
-		return fmt.Sprintf("%dB", bytes)
-	}
-}
 
 // SetSize sets the component dimensions
 func (m MemoryModel) SetSize(width, height int) MemoryModel {
+	if width != m.width || height != m.height {
+		m.version = nextRenderVersion()
+	}
 	m.width = width
 	m.height = height
 	return m
 }
 
+// SetRetryIn sets the time until the failing collector is retried, shown
+// below the error
+func (m MemoryModel) SetRetryIn(retryIn time.Duration) MemoryModel {
+	if retryIn != m.retryIn {
+		m.version = nextRenderVersion()
+	}
+	m.retryIn = retryIn
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m MemoryModel) SetStyleManager(styleManager *StyleManager) MemoryModel {
+	if styleManager != m.styleManager {
+		m.version = nextRenderVersion()
+	}
+	m.styleManager = styleManager
+	return m
+}
+
 // GetTotal returns the total memory in bytes
 func (m MemoryModel) GetTotal() uint64 {
 	return m.total
@@ -193,6 +274,44 @@ func (m MemoryModel) GetSwap() models.SwapInfo {
 	return m.swap
 }
 
+// SetAnomalySigma sets the deviation from the baseline, in standard
+// deviations, from which the RAM usage is marked unusual; 0 never marks it
+func (m MemoryModel) SetAnomalySigma(sigma float64) MemoryModel {
+	m.version = nextRenderVersion()
+	m.anomalySigma = sigma
+	return m
+}
+
+// IsUnusual reports whether the RAM usage is marked unusual
+func (m MemoryModel) IsUnusual() bool {
+	return models.Unusual(m.deviation, m.anomalySigma)
+}
+
+// GetUsageStats returns the session average and peak of the RAM usage in percent
+func (m MemoryModel) GetUsageStats() models.RunningStats {
+	return m.usageStats
+}
+
+// GetTmpfs returns the usage of tmpfs mounts, shared memory first
+func (m MemoryModel) GetTmpfs() []models.DiskInfo {
+	return m.tmpfs
+}
+
+// GetLimit returns the cgroup memory limit in bytes, 0 without a limit
+func (m MemoryModel) GetLimit() uint64 {
+	return m.limit
+}
+
+// GetLimitUsed returns the memory charged to the cgroup in bytes
+func (m MemoryModel) GetLimitUsed() uint64 {
+	return m.limitUsed
+}
+
+// GetHugePages returns the huge page pool, zero when none is configured
+func (m MemoryModel) GetHugePages() models.HugePages {
+	return m.hugePages
+}
+
 // GetUsagePercent returns the memory usage percentage
 func (m MemoryModel) GetUsagePercent() float64 {
 	if m.total == 0 {
@@ -201,6 +320,32 @@ func (m MemoryModel) GetUsagePercent() float64 {
 	return float64(m.used) / float64(m.total) * 100
 }
 
+// SetThresholds sets the share of RAM and the percentages the RAM bar is
+// colored by
+func (m MemoryModel) SetThresholds(thresholds models.MemoryThresholds) MemoryModel {
+	m.version = nextRenderVersion()
+	m.thresholds = thresholds
+	return m
+}
+
+// GetThresholds returns the share of RAM and the percentages the RAM bar is
+// colored by
+func (m MemoryModel) GetThresholds() models.MemoryThresholds {
+	return m.thresholds
+}
+
+// GetAlertPercent returns the percentage of RAM used or available, as the
+// basis of the thresholds says, which colors the RAM bar and fires the
+// Memory alert
+func (m MemoryModel) GetAlertPercent() float64 {
+	return m.thresholds.Percent(models.MemoryInfo{Total: m.total, Used: m.used, Available: m.available})
+}
+
+// GetUsageColor returns the color of the RAM usage against the thresholds
+func (m MemoryModel) GetUsageColor() lipgloss.AdaptiveColor {
+	return m.styleManager.GetMemoryColor(m.GetAlertPercent(), m.thresholds)
+}
+
 // GetSwapUsagePercent returns the swap usage percentage
 func (m MemoryModel) GetSwapUsagePercent() float64 {
 	if m.swap.Total == 0 {
@@ -221,6 +366,7 @@ func (m MemoryModel) GetErrorMessage() string {
 
 // ClearError clears the current error state
 func (m MemoryModel) ClearError() MemoryModel {
+	m.version = nextRenderVersion()
 	m.hasError = false
 	m.errorMessage = ""
 	return m
@@ -228,6 +374,7 @@ func (m MemoryModel) ClearError() MemoryModel {
 
 // SetError sets an error state for the component
 func (m MemoryModel) SetError(message string) MemoryModel {
+	m.version = nextRenderVersion()
 	m.hasError = true
 	m.errorMessage = message
 	m.lastError = time.Now()
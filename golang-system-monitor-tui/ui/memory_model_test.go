@@ -132,19 +132,19 @@ func TestMemoryModel_View_WithData(t *testing.T) {
 		t.Error("Expected view to contain 'RAM:' section")
 	}
 	if !strings.Contains(view, "50.0%") {
-		t.Error("Expected view to contain '50.0%' usage (8GB/16GB)")
+		t.Error("Expected view to contain '50.0%' usage (8GiB/16GiB)")
 	}
-	if !strings.Contains(view, "16.0GB") {
-		t.Error("Expected view to contain '16.0GB' total memory")
+	if !strings.Contains(view, "16.0GiB") {
+		t.Error("Expected view to contain '16.0GiB' total memory")
 	}
-	if !strings.Contains(view, "8.0GB") {
-		t.Error("Expected view to contain '8.0GB' used memory")
+	if !strings.Contains(view, "8.0GiB") {
+		t.Error("Expected view to contain '8.0GiB' used memory")
 	}
 	if !strings.Contains(view, "Swap:") {
 		t.Error("Expected view to contain 'Swap:' section")
 	}
 	if !strings.Contains(view, "25.0%") {
-		t.Error("Expected view to contain '25.0%' swap usage (1GB/4GB)")
+		t.Error("Expected view to contain '25.0%' swap usage (1GiB/4GiB)")
 	}
 }
 
@@ -172,6 +172,121 @@ func TestMemoryModel_View_NoSwap(t *testing.T) {
 	}
 }
 
+func TestMemoryModel_View_Tmpfs(t *testing.T) {
+	model := NewMemoryModel().SetSize(40, 7)
+
+	memoryInfo := models.MemoryInfo{
+		Total:     8 * 1024 * 1024 * 1024, // 8GB
+		Used:      4 * 1024 * 1024 * 1024, // 4GB
+		Available: 4 * 1024 * 1024 * 1024, // 4GB
+		Tmpfs: []models.DiskInfo{
+			{Mountpoint: "/dev/shm", Filesystem: "tmpfs", Total: 4 * 1024 * 1024 * 1024, Used: 512 * 1024 * 1024},
+			{Mountpoint: "/run", Filesystem: "tmpfs", Total: 800 * 1024 * 1024, Used: 2 * 1024 * 1024},
+			{Mountpoint: "/tmp", Filesystem: "tmpfs", Total: 4 * 1024 * 1024 * 1024, Used: 1024 * 1024},
+		},
+		Timestamp: time.Now(),
+	}
+
+	model, _ = model.Update(MemoryUpdateMsg(memoryInfo))
+	if len(model.GetTmpfs()) != 3 {
+		t.Fatalf("Expected 3 tmpfs mounts, got %d", len(model.GetTmpfs()))
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "tmpfs: 515.0MiB in RAM") {
+		t.Errorf("Expected the total tmpfs usage, got:\n%s", view)
+	}
+	if !strings.Contains(view, "/dev/shm") || !strings.Contains(view, "512.0MiB / 4.0GiB") {
+		t.Errorf("Expected the /dev/shm usage, got:\n%s", view)
+	}
+
+	// Mounts that don't fit the height are left out
+	if lines := strings.Split(view, "\n"); len(lines) != 7 {
+		t.Errorf("Expected the view to keep its height of 7 lines, got %d", len(lines))
+	}
+	if strings.Contains(view, "/tmp") {
+		t.Errorf("Expected /tmp to be cut off by the height, got:\n%s", view)
+	}
+}
+
+func TestMemoryModel_View_CgroupLimit(t *testing.T) {
+	model := NewMemoryModel().SetSize(40, 8)
+	model, _ = model.Update(MemoryUpdateMsg(models.MemoryInfo{
+		Total:     8 * 1024 * 1024 * 1024, // 8GB
+		Used:      4 * 1024 * 1024 * 1024, // 4GB
+		Available: 4 * 1024 * 1024 * 1024, // 4GB
+		Limit:     4 * 1024 * 1024 * 1024, // 4GB
+		LimitUsed: 3 * 1024 * 1024 * 1024, // 3GB
+		Timestamp: time.Now(),
+	}))
+	if model.GetLimit() != 4*1024*1024*1024 || model.GetLimitUsed() != 3*1024*1024*1024 {
+		t.Fatalf("Expected the cgroup limit to be kept, got %d / %d", model.GetLimitUsed(), model.GetLimit())
+	}
+
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "4.0GiB / 8.0GiB (limit 4.0GiB)") {
+		t.Errorf("Expected the host and cgroup totals, got:\n%s", view)
+	}
+	if !strings.Contains(view, "Limit:") || !strings.Contains(view, "75.0%") {
+		t.Errorf("Expected the usage against the limit, got:\n%s", view)
+	}
+
+	// Without a limit the panel shows the host only
+	model, _ = model.Update(MemoryUpdateMsg(models.MemoryInfo{Total: 8 * 1024 * 1024 * 1024, Used: 1024 * 1024 * 1024}))
+	if view := stripStyles(model.View()); strings.Contains(view, "limit") || strings.Contains(view, "Limit:") {
+		t.Errorf("Expected no limit without a cgroup limit, got:\n%s", view)
+	}
+}
+
+func TestMemoryModel_Thresholds(t *testing.T) {
+	// Most of the used RAM is cache the kernel can drop
+	info := models.MemoryInfo{
+		Total:     16 * 1024 * 1024 * 1024, // 16GB
+		Used:      15 * 1024 * 1024 * 1024, // 15GB
+		Available: 8 * 1024 * 1024 * 1024,  // 8GB
+		Timestamp: time.Now(),
+	}
+	model, _ := NewMemoryModel().SetSize(50, 8).Update(MemoryUpdateMsg(info))
+	if model.GetUsageColor() != model.styleManager.colors.Critical {
+		t.Errorf("Expected 94%% used to be red by default, got %v", model.GetUsageColor())
+	}
+
+	model = model.SetThresholds(models.DefaultMemoryThresholds(models.MemoryBasisAvailable))
+	if percent := model.GetAlertPercent(); percent != 50 {
+		t.Errorf("Expected 50%% available, got %.1f", percent)
+	}
+	if model.GetUsageColor() != model.styleManager.colors.Normal {
+		t.Errorf("Expected half of RAM available to be fine, got %v", model.GetUsageColor())
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "15.0GiB / 16.0GiB, 50% available") {
+		t.Errorf("Expected the available share on the details line, got:\n%s", view)
+	}
+}
+
+func TestMemoryModel_View_HugePages(t *testing.T) {
+	model := NewMemoryModel().SetSize(40, 8)
+	model, _ = model.Update(MemoryUpdateMsg(models.MemoryInfo{
+		Total:     8 * 1024 * 1024 * 1024, // 8GB
+		Used:      6 * 1024 * 1024 * 1024, // 6GB
+		HugePages: models.HugePages{Total: 1024, Free: 256, Reserved: 64, Size: 2 * 1024 * 1024},
+		Timestamp: time.Now(),
+	}))
+
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "Huge:") || !strings.Contains(view, "75.0%") {
+		t.Errorf("Expected the huge page pool usage, got:\n%s", view)
+	}
+	if !strings.Contains(view, "1.5GiB / 2.0GiB, 128.0MiB reserved") {
+		t.Errorf("Expected the huge page sizes, got:\n%s", view)
+	}
+
+	// Without a pool nothing is shown
+	model, _ = model.Update(MemoryUpdateMsg(models.MemoryInfo{Total: 8 * 1024 * 1024 * 1024, Used: 1024 * 1024 * 1024}))
+	if view := stripStyles(model.View()); strings.Contains(view, "Huge") {
+		t.Errorf("Expected no huge pages without a pool, got:\n%s", view)
+	}
+}
+
 func TestMemoryModel_FormatBytes(t *testing.T) {
 	model := NewMemoryModel()
 
@@ -181,18 +296,18 @@ func TestMemoryModel_FormatBytes(t *testing.T) {
 	}{
 		{0, "0B"},
 		{512, "512B"},
-		{1024, "1.0KB"},
-		{1536, "1.5KB"},
-		{1024 * 1024, "1.0MB"},
-		{1536 * 1024, "1.5MB"},
-		{1024 * 1024 * 1024, "1.0GB"},
-		{1536 * 1024 * 1024, "1.5GB"},
-		{1024 * 1024 * 1024 * 1024, "1.0TB"},
-		{1536 * 1024 * 1024 * 1024, "1.5TB"},
+		{1024, "1.0KiB"},
+		{1536, "1.5KiB"},
+		{1024 * 1024, "1.0MiB"},
+		{1536 * 1024, "1.5MiB"},
+		{1024 * 1024 * 1024, "1.0GiB"},
+		{1536 * 1024 * 1024, "1.5GiB"},
+		{1024 * 1024 * 1024 * 1024, "1.0TiB"},
+		{1536 * 1024 * 1024 * 1024, "1.5TiB"},
 	}
 
 	for _, test := range tests {
-		result := model.formatBytes(test.bytes)
+		result := model.styleManager.FormatBytes(test.bytes)
 		if result != test.expected {
 			t.Errorf("formatBytes(%d) = %s, expected %s", test.bytes, result, test.expected)
 		}
@@ -370,9 +485,10 @@ func BenchmarkMemoryModel_View(b *testing.B) {
 	}
 	model, _ = model.Update(MemoryUpdateMsg(memoryInfo))
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		model.View()
+		model.render() // View would return the cached frame
 	}
 }
 
@@ -382,6 +498,27 @@ func BenchmarkMemoryModel_FormatBytes(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		model.formatBytes(testBytes)
+		model.styleManager.FormatBytes(testBytes)
 	}
-}
\ No newline at end of file
+}
+
+func TestMemoryModel_SessionStats(t *testing.T) {
+	model := NewMemoryModel().SetSize(60, 8)
+	const gb = 1024 * 1024 * 1024
+	for _, used := range []uint64{4, 8, 2} {
+		model, _ = model.Update(MemoryUpdateMsg(models.MemoryInfo{Total: 16 * gb, Used: used * gb, Timestamp: time.Now()}))
+	}
+
+	stats := model.GetUsageStats()
+	if stats.Count != 3 || stats.Max != 50 || stats.Mean != 29.166666666666664 {
+		t.Errorf("Expected 3 samples with a peak of 50%%, got %+v", stats)
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "avg 29.2%, max 50.0%") {
+		t.Errorf("Expected the average and peak next to the RAM details, got:\n%s", view)
+	}
+
+	// Narrow panels keep the RAM details without them
+	if view := stripStyles(model.SetSize(30, 8).View()); strings.Contains(view, "avg") {
+		t.Errorf("Expected no statistics in a narrow panel, got:\n%s", view)
+	}
+}
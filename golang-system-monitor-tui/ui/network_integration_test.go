@@ -11,6 +11,8 @@ import (
 func TestNetworkModel_IntegrationWithCollector(t *testing.T) {
 	// Create a real collector
 	collector := services.NewGopsutilCollector()
+	clock := models.NewFakeClock(time.Now())
+	collector.SetClock(clock)
 	
 	// Create network model
 	model := NewNetworkModel()
@@ -34,8 +36,8 @@ func TestNetworkModel_IntegrationWithCollector(t *testing.T) {
 		t.Errorf("Expected network interfaces to be populated")
 	}
 	
-	// Wait a bit and collect again for rate calculation
-	time.Sleep(100 * time.Millisecond)
+	// Advance the clock and collect again for rate calculation
+	clock.Advance(time.Second)
 	
 	networkInfo2, err := collector.CollectNetwork()
 	if err != nil {
@@ -125,12 +127,12 @@ func TestNetworkModel_RateCalculationAccuracy(t *testing.T) {
 	sendRateStr := model.formatRate(stats.SendRate)
 	recvRateStr := model.formatRate(stats.RecvRate)
 	
-	if sendRateStr != "500.0KB/s" {
-		t.Errorf("Expected send rate format '500.0KB/s', got '%s'", sendRateStr)
+	if sendRateStr != "500.0KiB/s" {
+		t.Errorf("Expected send rate format '500.0KiB/s', got '%s'", sendRateStr)
 	}
 	
-	if recvRateStr != "1023.4KB/s" {
-		t.Errorf("Expected recv rate format '1023.4KB/s', got '%s'", recvRateStr)
+	if recvRateStr != "1023.4KiB/s" {
+		t.Errorf("Expected recv rate format '1023.4KiB/s', got '%s'", recvRateStr)
 	}
 }
 
@@ -192,12 +194,12 @@ func TestNetworkModel_ViewFormatting(t *testing.T) {
 		t.Errorf("Expected view to contain upload/download arrows")
 	}
 	
-	if !contains(view, "1000.0KB/s") {
-		t.Errorf("Expected view to contain rate '1000.0KB/s'")
+	if !contains(view, "1000.0KiB/s") {
+		t.Errorf("Expected view to contain rate '1000.0KiB/s'")
 	}
 	
-	if !contains(view, "2.0MB/s") {
-		t.Errorf("Expected view to contain rate '2.0MB/s'")
+	if !contains(view, "2.0MiB/s") {
+		t.Errorf("Expected view to contain rate '2.0MiB/s'")
 	}
 }
 
@@ -2,23 +2,40 @@ package ui
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"golang-system-monitor-tui/format"
 	"golang-system-monitor-tui/models"
 )
 
 // NetworkUpdateMsg represents a network update message
 type NetworkUpdateMsg []models.NetworkInfo
 
+// WirelessUpdateMsg carries the links of the wireless interfaces
+type WirelessUpdateMsg []models.WirelessLink
+
 // NetworkModel represents the network monitoring component
 type NetworkModel struct {
 	interfaces    []models.NetworkInfo         // Current network interface information
 	previousData  []models.NetworkInfo         // Previous measurement for rate calculation
 	rates         map[string]models.NetworkStats // Calculated transfer rates
+	rateStats     map[string]models.RateStats  // Session average and peak rates of the current interfaces
+	totals        map[string]models.TrafficTotals // Bytes transferred since startup, kept for interfaces that went away
+	wireless      map[string]models.WirelessLink // SSID and signal of the wireless interfaces, by interface
+	groups        []models.InterfaceGroup       // Interfaces shown as one row with their combined rates, e.g. VPN
+	baselines     map[string]models.Baseline    // Usual combined rate of each interface, learned from the recent samples
+	deviations    map[string]float64            // Standard deviations of the combined rates from the baselines before them
+	anomalySigma  float64                       // Deviation from which a rate is marked unusual, 0 to never mark it
+	totalHistory  models.Ring[float64]          // Bytes sent and received per second by all interfaces, for the correlation view
+	resync        bool                          // The next update starts a new baseline instead of computing rates
+	added         []string                      // Interfaces that appeared with the last update, none on the first
+	removed       []string                      // Interfaces that went away with the last update
 	lastUpdate    time.Time                    // Last update timestamp
 	width         int                          // Component width for rendering
 	height        int                          // Component height for rendering
@@ -26,6 +43,16 @@ type NetworkModel struct {
 	hasError bool         // Whether the component has an error
 	errorMessage string   // Current error message
 	lastError time.Time   // Timestamp of last error
+	retryIn time.Duration // Time until the failing collector is retried
+	filter   string       // Interface name filter typed with /
+	rateUnit models.RateUnit // Rates shown in bytes or bits per second
+	rollover models.RolloverStrategy // Rate of a counter that went backwards
+	order    models.InterfaceSort // Order of the interface rows
+	pinned   []string             // Interfaces listed first whatever the order and groups, in this order
+	collapse int                  // Interfaces of one family share a row from this many, 0 never
+	expanded bool                 // Collapsed rows are shown an interface each
+	version  uint64       // Changes with every change of the rendered state
+	cache    *viewCache   // Last rendered view, shared by copies of the model
 }
 
 // NewNetworkModel creates a new network model instance
@@ -34,13 +61,24 @@ func NewNetworkModel() NetworkModel {
 		interfaces:   []models.NetworkInfo{},
 		previousData: []models.NetworkInfo{},
 		rates:        make(map[string]models.NetworkStats),
+		groups:       models.DefaultInterfaceGroups(),
+		anomalySigma: models.DefaultAnomalySigma,
 		lastUpdate:   time.Now(),
 		width:        50,
 		height:       10,
 		styleManager: NewStyleManager(),
+		version:      nextRenderVersion(),
+		cache:        newViewCache(),
 	}
 }
 
+// Resync makes the next update a new baseline for the rates, after a gap
+// in the samples such as a suspend
+func (m NetworkModel) Resync() NetworkModel {
+	m.resync = true
+	return m
+}
+
 // Init initializes the network model
 func (m NetworkModel) Init() tea.Cmd {
 	return nil
@@ -48,6 +86,7 @@ func (m NetworkModel) Init() tea.Cmd {
 
 // Update handles messages and updates the network model state
 func (m NetworkModel) Update(msg tea.Msg) (NetworkModel, tea.Cmd) {
+	m.version = nextRenderVersion()
 	switch msg := msg.(type) {
 	case NetworkUpdateMsg:
 		// Clear any previous errors on successful update
@@ -56,15 +95,64 @@ func (m NetworkModel) Update(msg tea.Msg) (NetworkModel, tea.Cmd) {
 		
 		// Store previous data for rate calculation
 		m.previousData = m.interfaces
+		if m.resync {
+			m.previousData, m.resync = nil, false
+			m.rates = make(map[string]models.NetworkStats)
+		}
 		
 		// Update current interface data
+		m.added, m.removed = nil, nil
+		if len(m.interfaces) > 0 {
+			m.added, m.removed = interfaceChanges(m.interfaces, msg)
+		}
 		m.interfaces = []models.NetworkInfo(msg)
+		if len(m.removed) > 0 {
+			m.rates, m.wireless = pruneInterfaces(m.rates, m.interfaces), pruneInterfaces(m.wireless, m.interfaces)
+		}
 		m.lastUpdate = time.Now()
+		if len(m.interfaces) > 0 {
+			// Prefer the collection timestamp so rates and display agree
+			m.lastUpdate = m.interfaces[0].Timestamp
+		}
 		
 		// Calculate transfer rates if we have previous data
 		if len(m.previousData) > 0 {
 			m.rates = m.calculateRates(m.previousData, m.interfaces)
+
+			// Build new statistics rather than updating the map earlier
+			// models share; interfaces that went away are dropped
+			rateStats := make(map[string]models.RateStats, len(m.rates))
+			for name, rate := range m.rates {
+				rateStats[name] = m.rateStats[name].Add(rate)
+			}
+			m.rateStats = rateStats
+			m.totals = m.addTotals(m.previousData, m.interfaces)
+
+			baselines := make(map[string]models.Baseline, len(m.rates))
+			deviations := make(map[string]float64, len(m.rates))
+			for name, rate := range m.rates {
+				total := rate.SendRate + rate.RecvRate
+				deviations[name] = m.baselines[name].Deviation(total, networkBaselineFloor)
+				baselines[name] = m.baselines[name].Add(total)
+			}
+			m.baselines, m.deviations = baselines, deviations
+
+			if m.totalHistory.Cap() == 0 {
+				m.totalHistory = models.NewRing[float64](ioHistoryLength)
+			}
+			var total float64
+			for _, rate := range m.rates {
+				total += rate.SendRate + rate.RecvRate
+			}
+			m.totalHistory = m.totalHistory.Push(total)
+		}
+
+	case WirelessUpdateMsg:
+		wireless := make(map[string]models.WirelessLink, len(msg))
+		for _, link := range msg {
+			wireless[link.Interface] = link
 		}
+		m.wireless = wireless
 		
 	case models.ErrorMsg:
 		// Handle error messages for Network component
@@ -79,26 +167,34 @@ func (m NetworkModel) Update(msg tea.Msg) (NetworkModel, tea.Cmd) {
 
 // View renders the network model
 func (m NetworkModel) View() string {
-	var sections []string
+	return m.cache.render(m.version, m.styleManager, m.render)
+}
+
+// render draws the view of the current state
+func (m NetworkModel) render() string {
+	buf := getRenderBuffer()
+	defer buf.release()
+	sections := buf.lines
 	
 	// Header
-	header := m.styleManager.RenderHeader("Network Activity")
+	interfaces := m.GetVisibleInterfaces()
+	header := renderFilteredHeader(m.styleManager, "Network Activity", m.filter, len(interfaces), len(m.interfaces))
+	if name, deviation := m.mostUnusual(interfaces); name != "" {
+		header += renderUnusualMarker(m.styleManager, name, deviation, m.anomalySigma)
+	}
 	sections = append(sections, header)
 
 	// Handle error state
 	if m.hasError {
 		sections = append(sections, m.styleManager.RenderErrorText("Error: "+m.errorMessage))
-		sections = append(sections, m.styleManager.RenderMutedText("Network data unavailable"))
+		sections = append(sections, renderUnavailable(m.styleManager, "Network data unavailable", m.retryIn))
 		
 		// Show fallback display with N/A values
 		sections = append(sections, "Interfaces: N/A")
 		sections = append(sections, "Activity: N/A")
 		
 		// Add spacing
-		for len(sections) < m.height {
-			sections = append(sections, "")
-		}
-		return strings.Join(sections, "\n")
+		return buf.join(sections, m.height)
 	}
 
 	// Handle loading state
@@ -106,91 +202,274 @@ func (m NetworkModel) View() string {
 		return m.styleManager.RenderPlaceholder("Network Activity", "Loading network data...")
 	}
 
+	if len(interfaces) == 0 {
+		sections = append(sections, m.styleManager.RenderMutedText("No interfaces match the filter"))
+	}
+
 	// Normal display
-	// Render each network interface
-	for _, iface := range m.interfaces {
+	// Render each network interface: name and transfer rates, with the bytes
+	// transferred on a detail line under the rates
+	table := m.styleManager.NewTable(m.width-2,
+		TableColumn{Width: 12},
+		TableColumn{Width: 1},
+		TableColumn{Width: 11, Right: true},
+		TableColumn{Width: 1},
+		TableColumn{Width: 11, Right: true})
+	// The total of all interfaces comes first, so a long list does not cut it off
+	if len(m.interfaces) > 1 {
+		sections = append(sections, m.renderTotal(table)...)
+	}
+
+	ungrouped, groups := m.groupInterfaces(interfaces)
+	collapsed := m.collapseRows(ungrouped)
+	rows := len(collapsed) + len(groups)
+	top := len(sections) // Header and total, above the rows
+	for _, row := range collapsed {
+		if len(row) > 1 {
+			sections = append(sections, m.renderCollapsed(table, row)...)
+			continue
+		}
+		iface := row[0]
 		// Get transfer rates for this interface
 		stats, hasRates := m.rates[iface.Interface]
 		
-		// Interface name (truncate if too long)
-		interfaceName := iface.Interface
-		if len(interfaceName) > 12 {
-			interfaceName = interfaceName[:9] + "..."
-		}
-		
 		// Create interface line with transfer rates
-		var rateLine string
+		send, recv := "N/A", "N/A"
 		if hasRates {
-			rateLine = fmt.Sprintf("%-12s ↑ %8s ↓ %8s", 
-				interfaceName,
-				m.formatRate(stats.SendRate),
-				m.formatRate(stats.RecvRate))
-		} else {
-			rateLine = fmt.Sprintf("%-12s ↑ %8s ↓ %8s", 
-				interfaceName, "N/A", "N/A")
+			send, recv = m.formatRate(stats.SendRate), m.formatRate(stats.RecvRate)
 		}
+		rateLine := table.Row(pinLabel(m.styleManager, iface.Interface, m.IsPinned(iface.Interface)), "↑", send, "↓", recv)
 		
 		// Apply color based on activity level using style manager
 		styledLine := m.styleByActivityWithManager(rateLine, stats)
 		sections = append(sections, styledLine)
 		
 		// Add total bytes transferred (optional detail line)
-		totalLine := fmt.Sprintf("%-12s   %8s   %8s", 
-			"",
-			m.formatBytes(iface.BytesSent),
-			m.formatBytes(iface.BytesRecv))
+		totalLine := table.Row("", "", m.styleManager.FormatBytes(iface.BytesSent), "", m.styleManager.FormatBytes(iface.BytesRecv))
 		
 		sections = append(sections, m.styleManager.RenderMutedText(totalLine))
+
+		// SSID, signal and link rate of wireless interfaces
+		if link, ok := m.wireless[iface.Interface]; ok {
+			sections = append(sections, m.renderWireless(link))
+		}
+
+		// Session average and peak rates, when every interface has room for them
+		if stats, ok := m.rateStats[iface.Interface]; ok && stats.Send.Count > 1 && top+3*rows <= m.height {
+			statsLine := fmt.Sprintf("  avg ↑ %s ↓ %s, max ↑ %s ↓ %s",
+				m.formatRate(stats.Send.Mean), m.formatRate(stats.Recv.Mean),
+				m.formatRate(stats.Send.Max), m.formatRate(stats.Recv.Max))
+			if lipgloss.Width(statsLine) <= m.width {
+				sections = append(sections, m.styleManager.RenderMutedText(statsLine))
+			}
+		}
+
+		// Data used since startup, for metered connections
+		if totals, ok := m.totals[iface.Interface]; ok && top+4*rows <= m.height {
+			sessionLine := fmt.Sprintf("  session: %s ↓ / %s ↑", m.styleManager.FormatBytes(totals.Recv), m.styleManager.FormatBytes(totals.Sent))
+			if lipgloss.Width(sessionLine) <= m.width {
+				sections = append(sections, m.styleManager.RenderMutedText(sessionLine))
+			}
+		}
+	}
+
+	// Grouped interfaces, e.g. VPN tunnels, as one row with their combined
+	// rates and the member names below
+	for _, group := range groups {
+		stats, hasRates := m.groupRates(group.members)
+		send, recv := "N/A", "N/A"
+		if hasRates {
+			send, recv = m.formatRate(stats.SendRate), m.formatRate(stats.RecvRate)
+		}
+		sections = append(sections, m.styleByActivityWithManager(table.Row(group.name, "↑", send, "↓", recv), stats))
+		names := make([]string, len(group.members))
+		for i, member := range group.members {
+			names[i] = member.Interface
+		}
+		sections = append(sections, m.styleManager.RenderMutedText(truncate("  "+strings.Join(names, ", "), m.width)))
 	}
 
 	// Add spacing if we have fewer lines than available height
-	for len(sections) < m.height {
-		sections = append(sections, "")
+	return buf.join(sections, m.height)
+}
+
+// interfaceChanges returns the names of the interfaces in current but not in
+// previous, and those in previous but not in current
+func interfaceChanges(previous, current []models.NetworkInfo) (added, removed []string) {
+	seen := make(map[string]bool, len(previous))
+	for _, iface := range previous {
+		seen[iface.Interface] = true
+	}
+	for _, iface := range current {
+		if !seen[iface.Interface] {
+			added = append(added, iface.Interface)
+		}
+		delete(seen, iface.Interface)
+	}
+	for _, iface := range previous {
+		if seen[iface.Interface] {
+			removed = append(removed, iface.Interface)
+		}
 	}
+	return added, removed
+}
 
-	return strings.Join(sections, "\n")
+// pruneInterfaces returns the entries of byInterface for the interfaces still
+// present, in a new map since earlier models share the old one
+func pruneInterfaces[V any](byInterface map[string]V, interfaces []models.NetworkInfo) map[string]V {
+	pruned := make(map[string]V, len(interfaces))
+	for _, iface := range interfaces {
+		if value, ok := byInterface[iface.Interface]; ok {
+			pruned[iface.Interface] = value
+		}
+	}
+	return pruned
 }
 
-// calculateRates calculates transfer rates between two network measurements
-func (m NetworkModel) calculateRates(previous, current []models.NetworkInfo) map[string]models.NetworkStats {
-	rates := make(map[string]models.NetworkStats)
-	
-	// Create a map of previous measurements for quick lookup
-	prevMap := make(map[string]models.NetworkInfo)
+// interfaceGroup is a group of the Network panel with the interfaces in it
+type interfaceGroup struct {
+	name    string
+	members []models.NetworkInfo
+}
+
+// groupInterfaces splits interfaces into those shown on their own and the
+// groups with at least one member, in the order of the configured groups
+func (m NetworkModel) groupInterfaces(interfaces []models.NetworkInfo) ([]models.NetworkInfo, []interfaceGroup) {
+	if len(m.groups) == 0 {
+		return interfaces, nil
+	}
+	var ungrouped []models.NetworkInfo
+	members := make(map[string][]models.NetworkInfo)
+	for _, iface := range interfaces {
+		// Pinned interfaces keep their own row
+		if group, ok := models.GroupOf(m.groups, iface.Interface); ok && !m.IsPinned(iface.Interface) {
+			members[group.Name] = append(members[group.Name], iface)
+		} else {
+			ungrouped = append(ungrouped, iface)
+		}
+	}
+	var groups []interfaceGroup
+	for _, group := range m.groups {
+		if len(members[group.Name]) > 0 {
+			groups = append(groups, interfaceGroup{name: group.Name, members: members[group.Name]})
+		}
+	}
+	return ungrouped, groups
+}
+
+// renderCollapsed renders similar interfaces as one row with their combined
+// rates, e.g. "veth* (24)", and the bytes they transferred on the detail line
+func (m NetworkModel) renderCollapsed(table Table, interfaces []models.NetworkInfo) []string {
+	names := make([]string, len(interfaces))
+	var sent, recv uint64
+	for i, iface := range interfaces {
+		names[i] = iface.Interface
+		sent += iface.BytesSent
+		recv += iface.BytesRecv
+	}
+	stats, hasRates := m.groupRates(interfaces)
+	sendRate, recvRate := "N/A", "N/A"
+	if hasRates {
+		sendRate, recvRate = m.formatRate(stats.SendRate), m.formatRate(stats.RecvRate)
+	}
+	return []string{
+		m.styleByActivityWithManager(table.Row(collapsedLabel(names), "↑", sendRate, "↓", recvRate), stats),
+		m.styleManager.RenderMutedText(table.Row("", "", m.styleManager.FormatBytes(sent), "", m.styleManager.FormatBytes(recv))),
+	}
+}
+
+// renderTotal renders the bold TOTAL row with the combined rates of all
+// interfaces, whatever the filter, and the bytes they transferred on the
+// detail line
+func (m NetworkModel) renderTotal(table Table) []string {
+	var sent, recv uint64
+	for _, iface := range m.interfaces {
+		sent += iface.BytesSent
+		recv += iface.BytesRecv
+	}
+	send, receive := "N/A", "N/A"
+	if len(m.rates) > 0 {
+		send, receive = m.formatRate(m.GetTotalSendRate()), m.formatRate(m.GetTotalRecvRate())
+	}
+	return []string{
+		m.styleManager.RenderBoldText(table.Row("TOTAL", "↑", send, "↓", receive)),
+		m.styleManager.RenderMutedText(table.Row("", "", m.styleManager.FormatBytes(sent), "", m.styleManager.FormatBytes(recv))),
+	}
+}
+
+// collapseRows puts the interfaces of one family, such as the veth pairs of
+// containers, on a shared row, leaving out the pinned ones, and every
+// interface on its own row while the rows are expanded
+func (m NetworkModel) collapseRows(interfaces []models.NetworkInfo) [][]models.NetworkInfo {
+	min := m.collapse
+	if m.expanded {
+		min = 0
+	}
+	return models.Collapse(interfaces, min, func(iface models.NetworkInfo) string {
+		if m.IsPinned(iface.Interface) {
+			return ""
+		}
+		return models.UnitFamily(iface.Interface)
+	})
+}
+
+// groupRates sums the transfer rates of interfaces, reporting whether any of
+// them has rates yet
+func (m NetworkModel) groupRates(interfaces []models.NetworkInfo) (models.NetworkStats, bool) {
+	var total models.NetworkStats
+	hasRates := false
+	for _, iface := range interfaces {
+		if stats, ok := m.rates[iface.Interface]; ok {
+			total.SendRate += stats.SendRate
+			total.RecvRate += stats.RecvRate
+			hasRates = true
+		}
+	}
+	return total, hasRates
+}
+
+// renderWireless renders the association of a wireless interface, e.g.
+// "  Wi-Fi HomeNet -52 dBm (96%) 866.7 Mbit/s", highlighted when the signal
+// is weak
+func (m NetworkModel) renderWireless(link models.WirelessLink) string {
+	if !link.Connected {
+		return m.styleManager.RenderMutedText("  Wi-Fi not connected")
+	}
+	locale := m.styleManager.Locale()
+	line := fmt.Sprintf("  Wi-Fi %s %d dBm (%s)", link.SSID, link.SignalDBm, locale.FormatPercent(float64(link.SignalPercent), 0))
+	if link.LinkRate > 0 {
+		line += " " + locale.FormatFloat(link.LinkRate, 1) + " Mbit/s"
+	}
+	line = truncate(line, m.width)
+	if link.Weak() {
+		return m.styleManager.RenderWarningText(line)
+	}
+	return m.styleManager.RenderMutedText(line)
+}
+
+// addTotals returns the session totals with the bytes transferred between two
+// measurements added, in a new map since earlier models share the old one
+func (m NetworkModel) addTotals(previous, current []models.NetworkInfo) map[string]models.TrafficTotals {
+	totals := make(map[string]models.TrafficTotals, len(m.totals))
+	for name, total := range m.totals {
+		totals[name] = total
+	}
+	prevMap := make(map[string]models.NetworkInfo, len(previous))
 	for _, prev := range previous {
 		prevMap[prev.Interface] = prev
 	}
-	
 	for _, curr := range current {
-		if prev, exists := prevMap[curr.Interface]; exists {
-			timeDiff := curr.Timestamp.Sub(prev.Timestamp).Seconds()
-			if timeDiff > 0 {
-				var sendRate, recvRate float64
-				
-				// Handle counter rollover by checking if current < previous
-				if curr.BytesSent >= prev.BytesSent {
-					sendRate = float64(curr.BytesSent-prev.BytesSent) / timeDiff
-				} else {
-					// Counter rollover detected, set rate to 0
-					sendRate = 0
-				}
-				
-				if curr.BytesRecv >= prev.BytesRecv {
-					recvRate = float64(curr.BytesRecv-prev.BytesRecv) / timeDiff
-				} else {
-					// Counter rollover detected, set rate to 0
-					recvRate = 0
-				}
-				
-				rates[curr.Interface] = models.NetworkStats{
-					SendRate: sendRate,
-					RecvRate: recvRate,
-				}
-			}
+		if prev, ok := prevMap[curr.Interface]; ok {
+			totals[curr.Interface] = totals[curr.Interface].Add(prev, curr)
 		}
 	}
-	
-	return rates
+	return totals
+}
+
+// calculateRates calculates transfer rates between two network measurements,
+// applying the rollover strategy to counters that went backwards
+func (m NetworkModel) calculateRates(previous, current []models.NetworkInfo) map[string]models.NetworkStats {
+	return m.rollover.NetworkRates(previous, current, m.rates)
 }
 
 // styleByActivityWithManager applies color styling based on network activity level using style manager
@@ -209,70 +488,251 @@ func (m NetworkModel) styleByActivityWithManager(text string, stats models.Netwo
 	}
 }
 
-// formatRate converts bytes per second to human-readable format
+// formatRate converts bytes per second to human-readable format, in bits per
+// second when that unit is selected
 func (m NetworkModel) formatRate(bytesPerSec float64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-	)
-
-	switch {
-	case bytesPerSec >= GB:
-		return fmt.Sprintf("%.1fGB/s", bytesPerSec/GB)
-	case bytesPerSec >= MB:
-		return fmt.Sprintf("%.1fMB/s", bytesPerSec/MB)
-	case bytesPerSec >= KB:
-		return fmt.Sprintf("%.1fKB/s", bytesPerSec/KB)
-	case bytesPerSec > 0:
-		return fmt.Sprintf("%.0fB/s", bytesPerSec)
-	default:
-		return "0B/s"
-	}
-}
-
-// formatBytes converts bytes to human-readable format
-func (m NetworkModel) formatBytes(bytes uint64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-		TB = GB * 1024
-	)
-
-	switch {
-	case bytes >= TB:
-		return fmt.Sprintf("%.1fTB", float64(bytes)/TB)
-	case bytes >= GB:
-		return fmt.Sprintf("%.1fGB", float64(bytes)/GB)
-	case bytes >= MB:
-		return fmt.Sprintf("%.1fMB", float64(bytes)/MB)
-	case bytes >= KB:
-		return fmt.Sprintf("%.1fKB", float64(bytes)/KB)
-	default:
-		return fmt.Sprintf("%dB", bytes)
-	}
+	return format.Rate(bytesPerSec, m.rateUnit, m.styleManager.Format())
 }
 
 
 
 // SetSize sets the component dimensions
 func (m NetworkModel) SetSize(width, height int) NetworkModel {
+	if width != m.width || height != m.height {
+		m.version = nextRenderVersion()
+	}
 	m.width = width
 	m.height = height
 	return m
 }
 
+// SetRetryIn sets the time until the failing collector is retried, shown
+// below the error
+func (m NetworkModel) SetRetryIn(retryIn time.Duration) NetworkModel {
+	if retryIn != m.retryIn {
+		m.version = nextRenderVersion()
+	}
+	m.retryIn = retryIn
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m NetworkModel) SetStyleManager(styleManager *StyleManager) NetworkModel {
+	if styleManager != m.styleManager {
+		m.version = nextRenderVersion()
+	}
+	m.styleManager = styleManager
+	return m
+}
+
+// SetRateUnit sets whether rates are shown in bytes or bits per second
+func (m NetworkModel) SetRateUnit(unit models.RateUnit) NetworkModel {
+	if unit != m.rateUnit {
+		m.version = nextRenderVersion()
+	}
+	m.rateUnit = unit
+	return m
+}
+
+// GetRateUnit returns the unit rates are shown in
+func (m NetworkModel) GetRateUnit() models.RateUnit {
+	return m.rateUnit
+}
+
+// SetRollover sets what the rate of a counter that went backwards reads as
+func (m NetworkModel) SetRollover(strategy models.RolloverStrategy) NetworkModel {
+	m.rollover = strategy
+	return m
+}
+
+// GetRollover returns what the rate of a counter that went backwards reads as
+func (m NetworkModel) GetRollover() models.RolloverStrategy {
+	return m.rollover
+}
+
+// SetSort sets the order of the interface rows
+func (m NetworkModel) SetSort(order models.InterfaceSort) NetworkModel {
+	if order != m.order {
+		m.version = nextRenderVersion()
+	}
+	m.order = order
+	return m
+}
+
+// GetSort returns the order of the interface rows
+func (m NetworkModel) GetSort() models.InterfaceSort {
+	return m.order
+}
+
+// GetInterfaceChanges returns the interfaces that appeared and went away with
+// the last update
+func (m NetworkModel) GetInterfaceChanges() (added, removed []string) {
+	return m.added, m.removed
+}
+
+// SetFilter shows only interfaces whose name contains filter
+func (m NetworkModel) SetFilter(filter string) NetworkModel {
+	if filter != m.filter {
+		m.version = nextRenderVersion()
+	}
+	m.filter = filter
+	return m
+}
+
+// GetFilter returns the interface name filter
+func (m NetworkModel) GetFilter() string {
+	return m.filter
+}
+
+// GetVisibleInterfaces returns the interfaces that pass the filter, the
+// pinned ones first and the others in the sort order
+func (m NetworkModel) GetVisibleInterfaces() []models.NetworkInfo {
+	if m.filter == "" && m.order == models.InterfaceSortSystem && len(m.pinned) == 0 {
+		return m.interfaces
+	}
+	var visible []models.NetworkInfo
+	for _, iface := range m.interfaces {
+		if matchesFilter(iface.Interface, m.filter) {
+			visible = append(visible, iface)
+		}
+	}
+	m.order.Sort(visible, m.rates)
+	return models.PinFirst(visible, m.pinned, func(iface models.NetworkInfo) string { return iface.Interface })
+}
+
+// SetPinned lists the interfaces named in names first, in that order, and
+// on their own rows even when they belong to a group
+func (m NetworkModel) SetPinned(names []string) NetworkModel {
+	if !slices.Equal(names, m.pinned) {
+		m.version = nextRenderVersion()
+	}
+	m.pinned = names
+	return m
+}
+
+// GetPinned returns the names of the pinned interfaces
+func (m NetworkModel) GetPinned() []string {
+	return m.pinned
+}
+
+// IsPinned reports whether the interface named name is pinned
+func (m NetworkModel) IsPinned(name string) bool {
+	return slices.Contains(m.pinned, name)
+}
+
+// SetCollapse collapses interfaces of one family, such as eth0 and eth1 or
+// the veth pairs of containers, into one row once there are at least min of
+// them; 0 keeps every interface on its own row. Grouped interfaces are not
+// collapsed.
+func (m NetworkModel) SetCollapse(min int) NetworkModel {
+	if min != m.collapse {
+		m.version = nextRenderVersion()
+	}
+	m.collapse = min
+	return m
+}
+
+// GetCollapse returns the number of interfaces of one family that share a row
+func (m NetworkModel) GetCollapse() int {
+	return m.collapse
+}
+
+// SetExpanded shows collapsed rows an interface each
+func (m NetworkModel) SetExpanded(expanded bool) NetworkModel {
+	if expanded != m.expanded {
+		m.version = nextRenderVersion()
+	}
+	m.expanded = expanded
+	return m
+}
+
+// IsExpanded reports whether collapsed rows are shown an interface each
+func (m NetworkModel) IsExpanded() bool {
+	return m.expanded
+}
+
+// GetRows returns the visible interfaces outside the groups by row, several
+// on a row collapsed into one
+func (m NetworkModel) GetRows() [][]models.NetworkInfo {
+	ungrouped, _ := m.groupInterfaces(m.GetVisibleInterfaces())
+	return m.collapseRows(ungrouped)
+}
+
 // GetInterfaces returns the current network interface information
 func (m NetworkModel) GetInterfaces() []models.NetworkInfo {
 	return m.interfaces
 }
 
+// mostUnusual returns the interface whose rate deviates most from its
+// baseline, empty when none is unusual
+func (m NetworkModel) mostUnusual(interfaces []models.NetworkInfo) (string, float64) {
+	name, deviation := "", 0.0
+	for _, iface := range interfaces {
+		if d := m.deviations[iface.Interface]; models.Unusual(d, m.anomalySigma) && math.Abs(d) > math.Abs(deviation) {
+			name, deviation = iface.Interface, d
+		}
+	}
+	return name, deviation
+}
+
+// SetAnomalySigma sets the deviation from the baseline, in standard
+// deviations, from which the rate of an interface is marked unusual; 0 never
+// marks it
+func (m NetworkModel) SetAnomalySigma(sigma float64) NetworkModel {
+	m.version = nextRenderVersion()
+	m.anomalySigma = sigma
+	return m
+}
+
+// GetUnusualInterfaces returns the interfaces whose rates are marked unusual
+func (m NetworkModel) GetUnusualInterfaces() []string {
+	var unusual []string
+	for _, iface := range m.interfaces {
+		if models.Unusual(m.deviations[iface.Interface], m.anomalySigma) {
+			unusual = append(unusual, iface.Interface)
+		}
+	}
+	return unusual
+}
+
+// SetGroups sets the interface groups shown as one row each; none shows
+// every interface on its own
+func (m NetworkModel) SetGroups(groups []models.InterfaceGroup) NetworkModel {
+	m.version = nextRenderVersion()
+	m.groups = groups
+	return m
+}
+
+// GetGroupRates returns the combined transfer rates of the visible
+// interfaces in the named group, and whether the group has any
+func (m NetworkModel) GetGroupRates(name string) (models.NetworkStats, bool) {
+	_, groups := m.groupInterfaces(m.GetVisibleInterfaces())
+	for _, group := range groups {
+		if group.name == name {
+			stats, _ := m.groupRates(group.members)
+			return stats, true
+		}
+	}
+	return models.NetworkStats{}, false
+}
+
+// GetWireless returns the link of a wireless interface
+func (m NetworkModel) GetWireless(name string) (models.WirelessLink, bool) {
+	link, ok := m.wireless[name]
+	return link, ok
+}
+
 // GetRates returns the current transfer rates
 func (m NetworkModel) GetRates() map[string]models.NetworkStats {
 	return m.rates
 }
 
+// GetTotalRateHistory returns a copy of the bytes sent and received per second
+// by all interfaces, oldest first
+func (m NetworkModel) GetTotalRateHistory() []float64 {
+	return m.totalHistory.Values()
+}
+
 // GetTotalSendRate returns the total send rate across all interfaces
 func (m NetworkModel) GetTotalSendRate() float64 {
 	var total float64
@@ -323,6 +783,20 @@ func (m NetworkModel) GetRateByInterface(name string) (models.NetworkStats, bool
 	return stats, exists
 }
 
+// GetSessionTotals returns the bytes the named interface transferred since
+// startup
+func (m NetworkModel) GetSessionTotals(name string) (models.TrafficTotals, bool) {
+	totals, ok := m.totals[name]
+	return totals, ok
+}
+
+// GetRateStatsByInterface returns the session average and peak transfer rates
+// of a specific interface
+func (m NetworkModel) GetRateStatsByInterface(name string) (models.RateStats, bool) {
+	stats, exists := m.rateStats[name]
+	return stats, exists
+}
+
 // HasError returns whether the component has an error
 func (m NetworkModel) HasError() bool {
 	return m.hasError
@@ -335,6 +809,7 @@ func (m NetworkModel) GetErrorMessage() string {
 
 // ClearError clears the current error state
 func (m NetworkModel) ClearError() NetworkModel {
+	m.version = nextRenderVersion()
 	m.hasError = false
 	m.errorMessage = ""
 	return m
@@ -342,6 +817,7 @@ func (m NetworkModel) ClearError() NetworkModel {
 
 // SetError sets an error state for the component
 func (m NetworkModel) SetError(message string) NetworkModel {
+	m.version = nextRenderVersion()
 	m.hasError = true
 	m.errorMessage = message
 	m.lastError = time.Now()
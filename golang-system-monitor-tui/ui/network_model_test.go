@@ -7,7 +7,9 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"golang-system-monitor-tui/config"
 	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services/fake"
 )
 
 func TestNewNetworkModel(t *testing.T) {
@@ -253,6 +255,92 @@ func TestNetworkModel_calculateRates_CounterRollover(t *testing.T) {
 	}
 }
 
+func TestNetworkModel_calculateRates_WrapStrategy(t *testing.T) {
+	model := NewNetworkModel().SetRollover(models.RolloverWrap)
+	baseTime := time.Now()
+
+	previous := []models.NetworkInfo{{Interface: "eth0", BytesSent: 4294967295, Timestamp: baseTime}}
+	current := []models.NetworkInfo{{Interface: "eth0", BytesSent: 1023, Timestamp: baseTime.Add(time.Second)}}
+
+	// The 32-bit counter wrapped: 1 byte to reach 2^32 and 1023 after it
+	if rate := model.calculateRates(previous, current)["eth0"].SendRate; rate != 1024 {
+		t.Errorf("Expected SendRate to be 1024.0 across the wrap, got %f", rate)
+	}
+}
+
+func TestNetworkModel_Update_InterfaceHotplug(t *testing.T) {
+	model := NewNetworkModel().SetSize(60, 20)
+	baseTime := time.Now()
+	sample := func(offset time.Duration, names ...string) NetworkUpdateMsg {
+		msg := make(NetworkUpdateMsg, len(names))
+		for i, name := range names {
+			msg[i] = models.NetworkInfo{Interface: name, BytesSent: uint64(offset / time.Millisecond), Timestamp: baseTime.Add(offset)}
+		}
+		return msg
+	}
+
+	model, _ = model.Update(sample(0, "eth0", "wlan0"))
+	if added, removed := model.GetInterfaceChanges(); added != nil || removed != nil {
+		t.Errorf("Expected no changes on the first update, got +%v -%v", added, removed)
+	}
+	model, _ = model.Update(WirelessUpdateMsg{{Interface: "wlan0", Connected: true, SSID: "Home"}})
+	model, _ = model.Update(sample(time.Second, "eth0", "wlan0"))
+
+	// USB tethering comes up: its row shows N/A until it has a second sample
+	model, _ = model.Update(sample(2*time.Second, "eth0", "wlan0", "usb0"))
+	if added, removed := model.GetInterfaceChanges(); len(added) != 1 || added[0] != "usb0" || removed != nil {
+		t.Errorf("Expected usb0 to appear, got +%v -%v", added, removed)
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "usb0") {
+		t.Errorf("Expected a row for usb0, got:\n%s", view)
+	}
+
+	// Wi-Fi goes away: its rates and link are dropped with its row
+	model, _ = model.Update(sample(3*time.Second, "eth0", "usb0"))
+	if added, removed := model.GetInterfaceChanges(); added != nil || len(removed) != 1 || removed[0] != "wlan0" {
+		t.Errorf("Expected wlan0 to go away, got +%v -%v", added, removed)
+	}
+	if _, ok := model.GetRates()["wlan0"]; ok {
+		t.Error("Expected the rates of wlan0 to be pruned")
+	}
+	if _, ok := model.GetWireless("wlan0"); ok {
+		t.Error("Expected the link of wlan0 to be pruned")
+	}
+	if view := stripStyles(model.View()); strings.Contains(view, "wlan0") || strings.Contains(view, "Home") {
+		t.Errorf("Expected no trace of wlan0 in the view, got:\n%s", view)
+	}
+}
+
+func TestMainModel_InterfaceNotices(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MainModel)
+	update := func(names ...string) string {
+		t.Helper()
+		msg := make(NetworkUpdateMsg, len(names))
+		for i, name := range names {
+			msg[i] = models.NetworkInfo{Interface: name, Timestamp: DeterministicTime}
+		}
+		updated, _ := model.Update(msg)
+		model = updated.(MainModel)
+		return stripStyles(model.View())
+	}
+
+	update("eth0")
+	if view := update("eth0", "usb0", "docker0"); !strings.Contains(view, "New interfaces usb0, docker0") {
+		t.Errorf("Expected the new interfaces in the status line, got:\n%s", view)
+	}
+	if view := update("eth0", "usb1"); !strings.Contains(view, "New interface usb1; usb0, docker0 went away") {
+		t.Errorf("Expected the changed interfaces in the status line, got:\n%s", view)
+	}
+
+	off := false
+	model = model.ApplyConfig(config.Config{InterfaceNotices: &off}).SetDeterministic(true)
+	if view := update("eth0"); strings.Contains(view, "usb1 went away") {
+		t.Errorf("Expected no notice with interface_notices off, got:\n%s", view)
+	}
+}
+
 func TestNetworkModel_Update_OtherMessages(t *testing.T) {
 	model := NewNetworkModel()
 	originalModel := model
@@ -328,12 +416,12 @@ func TestNetworkModel_View_WithData(t *testing.T) {
 	}
 
 	// Should show rates
-	if !strings.Contains(view, "1.0KB/s") {
-		t.Errorf("Expected view to contain send rate '1.0KB/s', got: %s", view)
+	if !strings.Contains(view, "1.0KiB/s") {
+		t.Errorf("Expected view to contain send rate '1.0KiB/s', got: %s", view)
 	}
 
-	if !strings.Contains(view, "2.0KB/s") {
-		t.Errorf("Expected view to contain receive rate '2.0KB/s', got: %s", view)
+	if !strings.Contains(view, "2.0KiB/s") {
+		t.Errorf("Expected view to contain receive rate '2.0KiB/s', got: %s", view)
 	}
 }
 
@@ -346,12 +434,12 @@ func TestNetworkModel_formatRate(t *testing.T) {
 	}{
 		{0, "0B/s"},
 		{512, "512B/s"},
-		{1024, "1.0KB/s"},
-		{1536, "1.5KB/s"},
-		{1048576, "1.0MB/s"},
-		{1572864, "1.5MB/s"},
-		{1073741824, "1.0GB/s"},
-		{1610612736, "1.5GB/s"},
+		{1024, "1.0KiB/s"},
+		{1536, "1.5KiB/s"},
+		{1048576, "1.0MiB/s"},
+		{1572864, "1.5MiB/s"},
+		{1073741824, "1.0GiB/s"},
+		{1610612736, "1.5GiB/s"},
 	}
 
 	for _, test := range tests {
@@ -362,6 +450,27 @@ func TestNetworkModel_formatRate(t *testing.T) {
 	}
 }
 
+func TestNetworkModel_formatRate_Bits(t *testing.T) {
+	model := NewNetworkModel().SetRateUnit(models.BitsPerSecond)
+
+	tests := []struct {
+		bytesPerSec float64
+		expected    string
+	}{
+		{0, "0bps"},
+		{100, "800bps"},
+		{1000, "8.0Kbps"},
+		{1250000, "10.0Mbps"},
+		{125000000, "1.0Gbps"},
+	}
+
+	for _, test := range tests {
+		if result := model.formatRate(test.bytesPerSec); result != test.expected {
+			t.Errorf("formatRate(%.0f) = %s, expected %s", test.bytesPerSec, result, test.expected)
+		}
+	}
+}
+
 func TestNetworkModel_formatBytes(t *testing.T) {
 	model := NewNetworkModel()
 
@@ -371,17 +480,17 @@ func TestNetworkModel_formatBytes(t *testing.T) {
 	}{
 		{0, "0B"},
 		{512, "512B"},
-		{1024, "1.0KB"},
-		{1536, "1.5KB"},
-		{1048576, "1.0MB"},
-		{1572864, "1.5MB"},
-		{1073741824, "1.0GB"},
-		{1610612736, "1.5GB"},
-		{1099511627776, "1.0TB"},
+		{1024, "1.0KiB"},
+		{1536, "1.5KiB"},
+		{1048576, "1.0MiB"},
+		{1572864, "1.5MiB"},
+		{1073741824, "1.0GiB"},
+		{1610612736, "1.5GiB"},
+		{1099511627776, "1.0TiB"},
 	}
 
 	for _, test := range tests {
-		result := model.formatBytes(test.bytes)
+		result := model.styleManager.FormatBytes(test.bytes)
 		if result != test.expected {
 			t.Errorf("formatBytes(%d) = %s, expected %s", test.bytes, result, test.expected)
 		}
@@ -611,4 +720,280 @@ func TestNetworkModel_StyleManagerIntegration(t *testing.T) {
 		}
 		// We can't easily test colors in unit tests, but we ensure the function doesn't crash
 	}
-}
\ No newline at end of file
+}
+
+func TestNetworkModel_Filter(t *testing.T) {
+	model := NewNetworkModel()
+	model, _ = model.Update(NetworkUpdateMsg([]models.NetworkInfo{
+		{Interface: "eth0", Timestamp: time.Now()},
+		{Interface: "wlan0", Timestamp: time.Now()},
+		{Interface: "docker0", Timestamp: time.Now()},
+	}))
+
+	model = model.SetFilter("0")
+	if len(model.GetVisibleInterfaces()) != 3 {
+		t.Errorf("Expected all interfaces to match, got %d", len(model.GetVisibleInterfaces()))
+	}
+
+	model = model.SetFilter("WLAN")
+	view := model.View()
+	if !strings.Contains(view, "wlan0") || strings.Contains(view, "eth0") || !strings.Contains(view, "(1 of 3)") {
+		t.Errorf("Expected only wlan0, got %s", view)
+	}
+	if model.GetFilter() != "WLAN" {
+		t.Errorf("Expected filter to be kept, got %q", model.GetFilter())
+	}
+}
+
+func BenchmarkNetworkModel_View(b *testing.B) {
+	model := NewNetworkModel().SetSize(60, 20)
+	now := time.Now()
+	model, _ = model.Update(NetworkUpdateMsg([]models.NetworkInfo{
+		{Interface: "eth0", BytesSent: 100 << 20, BytesRecv: 200 << 20, Timestamp: now.Add(-time.Second)},
+		{Interface: "wlan0", BytesSent: 50 << 20, BytesRecv: 80 << 20, Timestamp: now.Add(-time.Second)},
+	}))
+	model, _ = model.Update(NetworkUpdateMsg([]models.NetworkInfo{
+		{Interface: "eth0", BytesSent: 101 << 20, BytesRecv: 210 << 20, Timestamp: now},
+		{Interface: "wlan0", BytesSent: 50 << 20, BytesRecv: 81 << 20, Timestamp: now},
+	}))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		model.render() // View would return the cached frame
+	}
+}
+
+func TestNetworkModel_SessionStats(t *testing.T) {
+	model := NewNetworkModel().SetSize(70, 10)
+	base := time.Now()
+	update := func(second int, sent, recv uint64, names ...string) {
+		var infos []models.NetworkInfo
+		for _, name := range names {
+			infos = append(infos, models.NetworkInfo{Interface: name, BytesSent: sent, BytesRecv: recv, Timestamp: base.Add(time.Duration(second) * time.Second)})
+		}
+		model, _ = model.Update(NetworkUpdateMsg(infos))
+	}
+	update(0, 0, 0, "eth0", "wlan0")
+	update(1, 1024, 4096, "eth0", "wlan0")
+	update(2, 4096, 4096, "eth0", "wlan0")
+
+	stats, ok := model.GetRateStatsByInterface("eth0")
+	if !ok || stats.Send.Count != 2 || stats.Send.Mean != 2048 || stats.Send.Max != 3072 || stats.Recv.Max != 4096 {
+		t.Errorf("Expected the send average of 2KiB/s and peaks of 3KiB/s and 4KiB/s, got %+v (%v)", stats, ok)
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "avg ↑ 2.0KiB/s ↓ 2.0KiB/s, max ↑ 3.0KiB/s ↓ 4.0KiB/s") {
+		t.Errorf("Expected the average and peak rates below each interface, got:\n%s", view)
+	}
+
+	// Interfaces that went away take their statistics with them
+	update(3, 8192, 8192, "eth0")
+	if _, ok := model.GetRateStatsByInterface("wlan0"); ok {
+		t.Error("Expected the statistics of the removed interface to be dropped")
+	}
+}
+
+func TestNetworkModel_SessionTotals(t *testing.T) {
+	// Room for the TOTAL row and four lines per interface
+	model := NewNetworkModel().SetSize(70, 12)
+	base := time.Now()
+	update := func(second int, sent, recv uint64, names ...string) {
+		var infos []models.NetworkInfo
+		for _, name := range names {
+			infos = append(infos, models.NetworkInfo{Interface: name, BytesSent: sent, BytesRecv: recv, Timestamp: base.Add(time.Duration(second) * time.Second)})
+		}
+		model, _ = model.Update(NetworkUpdateMsg(infos))
+	}
+	update(0, 1<<30, 1<<30, "eth0", "wlan0")
+	update(1, 1<<30+340<<20, 1<<30+1<<29, "eth0", "wlan0")
+	update(2, 1<<30+340<<20, 2<<30+1<<29, "eth0", "wlan0")
+
+	totals, ok := model.GetSessionTotals("eth0")
+	if !ok || totals.Sent != 340<<20 || totals.Recv != 3<<29 {
+		t.Errorf("Expected 340MiB sent and 1.5GiB received since startup, got %+v (%v)", totals, ok)
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "session: 1.5GiB ↓ / 340.0MiB ↑") {
+		t.Errorf("Expected the session totals below each interface, got:\n%s", view)
+	}
+
+	// Unlike the rate statistics, totals survive an interface going away
+	update(3, 0, 0, "eth0")
+	update(4, 0, 0, "eth0", "wlan0")
+	if totals, ok := model.GetSessionTotals("wlan0"); !ok || totals.Sent != 340<<20 {
+		t.Errorf("Expected the totals of the re-plugged interface to be kept, got %+v (%v)", totals, ok)
+	}
+}
+
+func TestNetworkModel_Wireless(t *testing.T) {
+	model := NewNetworkModel().SetSize(60, 12)
+	model, _ = model.Update(NetworkUpdateMsg([]models.NetworkInfo{
+		{Interface: "eth0", BytesSent: 1000, BytesRecv: 2000},
+		{Interface: "wlan0", BytesSent: 1000, BytesRecv: 2000},
+		{Interface: "wlan1"},
+	}))
+	model, _ = model.Update(WirelessUpdateMsg([]models.WirelessLink{
+		{Interface: "wlan0", Connected: true, SSID: "HomeNet", SignalDBm: -52, SignalPercent: 96, LinkRate: 866.7},
+		{Interface: "wlan1"},
+	}))
+
+	if link, ok := model.GetWireless("wlan0"); !ok || link.SSID != "HomeNet" {
+		t.Errorf("Expected the wlan0 link, got %+v (%v)", link, ok)
+	}
+	if _, ok := model.GetWireless("eth0"); ok {
+		t.Error("Expected no link for a wired interface")
+	}
+	view := stripStyles(model.View())
+	for _, want := range []string{"Wi-Fi HomeNet -52 dBm (96%) 866.7 Mbit/s", "Wi-Fi not connected"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the view, got:\n%s", want, view)
+		}
+	}
+	if strings.Count(view, "Wi-Fi") != 2 {
+		t.Errorf("Expected a Wi-Fi line only under wireless interfaces, got:\n%s", view)
+	}
+}
+
+func TestNetworkModel_TotalRow(t *testing.T) {
+	model := NewNetworkModel().SetSize(60, 20)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	update := func(at time.Time, bytes uint64) {
+		model, _ = model.Update(NetworkUpdateMsg([]models.NetworkInfo{
+			{Interface: "eth0", BytesSent: bytes, BytesRecv: 2 * bytes, Timestamp: at},
+			{Interface: "wlan0", BytesSent: bytes, BytesRecv: bytes, Timestamp: at},
+		}))
+	}
+	update(start, 0)
+	view := stripStyles(model.View())
+	if lines := strings.Split(view, "\n"); !strings.HasPrefix(lines[1], "TOTAL") || !strings.Contains(lines[1], "N/A") {
+		t.Errorf("Expected a TOTAL row without rates before the second sample, got:\n%s", view)
+	}
+
+	update(start.Add(time.Second), 1024)
+	view = stripStyles(model.View())
+	lines := strings.Split(view, "\n")
+	if !strings.HasPrefix(lines[1], "TOTAL") || !strings.Contains(lines[1], "2.0KiB/s") || !strings.Contains(lines[1], "3.0KiB/s") {
+		t.Errorf("Expected the combined rates on the TOTAL row, got:\n%s", view)
+	}
+	if !strings.Contains(lines[2], "2.0KiB") || !strings.Contains(lines[2], "3.0KiB") {
+		t.Errorf("Expected the combined bytes under the TOTAL row, got:\n%s", view)
+	}
+}
+
+func TestNetworkModel_Pinned(t *testing.T) {
+	model := NewNetworkModel().SetSize(60, 20).SetPinned([]string{"wg0", "eth1"})
+	model, _ = model.Update(NetworkUpdateMsg([]models.NetworkInfo{
+		{Interface: "eth0"},
+		{Interface: "eth1"},
+		{Interface: "wg0"},
+		{Interface: "tailscale0"},
+	}))
+
+	visible := model.GetVisibleInterfaces()
+	if len(visible) != 4 || visible[0].Interface != "wg0" || visible[1].Interface != "eth1" || visible[2].Interface != "eth0" {
+		t.Errorf("Expected the pinned interfaces first in pin order, got %+v", visible)
+	}
+	// A pinned interface leaves its group for a row of its own
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "★ wg0") || !strings.Contains(view, "VPN") || strings.Contains(view, "wg0, tailscale0") {
+		t.Errorf("Expected wg0 on its own pinned row and tailscale0 in the VPN group, got:\n%s", view)
+	}
+	if strings.Index(view, "★ wg0") > strings.Index(view, "eth0") {
+		t.Errorf("Expected the pinned rows at the top, got:\n%s", view)
+	}
+}
+
+func TestNetworkModel_Collapse(t *testing.T) {
+	model := NewNetworkModel().SetSize(60, 20).SetCollapse(3)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	update := func(at time.Time, bytes uint64) {
+		model, _ = model.Update(NetworkUpdateMsg([]models.NetworkInfo{
+			{Interface: "eth0", BytesSent: bytes, BytesRecv: bytes, Timestamp: at},
+			{Interface: "veth1a2b3c4", BytesSent: bytes, BytesRecv: bytes, Timestamp: at},
+			{Interface: "veth5d6e7f8", BytesSent: bytes, BytesRecv: bytes, Timestamp: at},
+			{Interface: "veth9a0b1c2", BytesSent: bytes, BytesRecv: 2 * bytes, Timestamp: at},
+		}))
+	}
+	update(start, 0)
+	update(start.Add(time.Second), 1024)
+
+	rows := model.GetRows()
+	if len(rows) != 2 || len(rows[1]) != 3 {
+		t.Fatalf("Expected the veth interfaces on one row, got %+v", rows)
+	}
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "veth* (3)") || strings.Contains(view, "veth5d6e7f8") {
+		t.Errorf("Expected one summary row for the veth interfaces, got:\n%s", view)
+	}
+	// The rates and bytes add up: 3 KiB/s sent and 4 KiB/s received
+	if !strings.Contains(view, "3.0KiB/s") || !strings.Contains(view, "4.0KiB/s") {
+		t.Errorf("Expected the combined rates, got:\n%s", view)
+	}
+
+	model = model.SetExpanded(true)
+	if view := stripStyles(model.View()); !strings.Contains(view, "veth5d6e7f8") {
+		t.Errorf("Expected the veth interfaces listed when expanded, got:\n%s", view)
+	}
+}
+
+func TestNetworkModel_Groups(t *testing.T) {
+	model := NewNetworkModel().SetSize(60, 20)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	update := func(at time.Time, bytes uint64) {
+		model, _ = model.Update(NetworkUpdateMsg([]models.NetworkInfo{
+			{Interface: "eth0", BytesSent: bytes, BytesRecv: bytes, Timestamp: at},
+			{Interface: "wg0", BytesSent: bytes, BytesRecv: 2 * bytes, Timestamp: at},
+			{Interface: "tailscale0", BytesSent: bytes, BytesRecv: bytes, Timestamp: at},
+		}))
+	}
+	update(start, 0)
+	update(start.Add(time.Second), 1024)
+
+	// The VPN interfaces are one row with their combined rates
+	stats, ok := model.GetGroupRates("VPN")
+	if !ok || stats.SendRate != 2048 || stats.RecvRate != 3072 {
+		t.Errorf("Expected the VPN group to send 2048 and receive 3072 B/s, got %+v (%v)", stats, ok)
+	}
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "VPN") || !strings.Contains(view, "wg0, tailscale0") {
+		t.Errorf("Expected the VPN row with its members, got:\n%s", view)
+	}
+	if strings.Contains(view, "wg0 ") {
+		t.Errorf("Expected wg0 not to get a row of its own, got:\n%s", view)
+	}
+
+	// The group disappears with its members, and without groups
+	if _, ok := model.SetFilter("eth").GetGroupRates("VPN"); ok {
+		t.Error("Expected no VPN group when the filter hides its members")
+	}
+	model = model.SetGroups(nil)
+	if view := stripStyles(model.View()); strings.Contains(view, "VPN") || !strings.Contains(view, "tailscale0") {
+		t.Errorf("Expected every interface on its own without groups, got:\n%s", view)
+	}
+}
+
+func TestNetworkModel_Unusual(t *testing.T) {
+	model := NewNetworkModel().SetSize(70, 12)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var sent uint64
+	sample := func(i int, rate uint64) {
+		sent += rate
+		model, _ = model.Update(NetworkUpdateMsg([]models.NetworkInfo{
+			{Interface: "eth0", BytesSent: sent, Timestamp: start.Add(time.Duration(i) * time.Second)},
+			{Interface: "eth1", BytesSent: uint64(i) * 1024, Timestamp: start.Add(time.Duration(i) * time.Second)},
+		}))
+	}
+	for i := 0; i <= models.BaselineWarmup; i++ {
+		sample(i, 1<<20) // Steady 1 MiB/s
+	}
+	if unusual := model.GetUnusualInterfaces(); len(unusual) != 0 {
+		t.Fatalf("Expected steady traffic to be usual, got %v", unusual)
+	}
+
+	sample(models.BaselineWarmup+1, 5<<20)
+	if unusual := model.GetUnusualInterfaces(); len(unusual) != 1 || unusual[0] != "eth0" {
+		t.Errorf("Expected eth0 to be unusual, got %v", unusual)
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "unusual eth0 +") {
+		t.Errorf("Expected the unusual interface in the header, got:\n%s", view)
+	}
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/config"
+)
+
+// onboardingWidth is the outer width of the first-run overlay
+const onboardingWidth = 58
+
+// renderOnboarding draws the first-run introduction in a box centered over view
+func (m MainModel) renderOnboarding(view string) string {
+	keys := func(bindings ...[]string) string {
+		var labels []string
+		for _, binding := range bindings {
+			if len(binding) > 0 {
+				labels = append(labels, keyLabel(binding[0]))
+			}
+		}
+		return strings.Join(labels, "/")
+	}
+	row := func(key, desc string) string {
+		return fmt.Sprintf("  %-10s %s", key, desc)
+	}
+
+	lines := []string{
+		m.styleManager.RenderHeader("Welcome to System Monitor"),
+		"",
+		row(keys(m.keys.Left, m.keys.Down, m.keys.Up, m.keys.Right), "Move between panels"),
+		row(keys(m.keys.Tab), "Cycle through panels"),
+		row(keys(m.keys.Zoom), "Zoom the focused panel to full screen"),
+		row(keys(m.keys.Filter), "Filter the focused list"),
+		row(keys(m.keys.Help), "Help for the focused panel and all keys"),
+		row(keys(m.keys.Quit), "Quit"),
+		"",
+	}
+	if m.configPath != "" {
+		lines = append(lines, m.styleManager.RenderMutedText(truncate("Settings: "+m.configPath, onboardingWidth-4)))
+	}
+	lines = append(lines, m.styleManager.RenderMutedText("Press any key to start"))
+
+	box := m.styleManager.RenderComponentBorder(strings.Join(lines, "\n"), true, onboardingWidth-2, len(lines))
+	x := max((m.width-onboardingWidth)/2, 0)
+	y := max((m.height-lipgloss.Height(box))/2, 0)
+	return overlay(view, box, x, y)
+}
+
+// writeDefaultConfigCmd writes the settings in use to the config file once
+// the first-run overlay is dismissed, so it is not shown again. A file that
+// appeared in the meantime is left alone.
+func (m MainModel) writeDefaultConfigCmd() tea.Cmd {
+	if m.configPath == "" {
+		return nil
+	}
+	path, settings := m.configPath, m.settings
+	return func() tea.Msg {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			return nil
+		}
+		if err := config.Save(path, settings); err != nil {
+			log.Printf("Failed to write the default config file: %v", err)
+		}
+		return nil
+	}
+}
+
+// SetOnboarding shows the first-run overlay until a key is pressed
+func (m MainModel) SetOnboarding(show bool) MainModel {
+	m.onboarding = show
+	return m
+}
+
+// IsOnboarding returns whether the first-run overlay is shown
+func (m MainModel) IsOnboarding() bool {
+	return m.onboarding
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/config"
+)
+
+func TestMainModel_Onboarding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "monitor", "config.json")
+	model := NewMainModel().ApplyConfig(config.Default()).SetConfigPath(path).SetOnboarding(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MainModel)
+
+	view := stripStyles(model.View())
+	for _, want := range []string{"Welcome to System Monitor", "Zoom the focused panel", "Press any key to start", "Settings: /tmp/"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the first-run overlay:\n%s", want, view)
+		}
+	}
+
+	// The key dismissing the overlay does nothing else
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	model = updated.(MainModel)
+	if model.IsOnboarding() || strings.Contains(stripStyles(model.View()), "Welcome to System Monitor") {
+		t.Fatal("Expected a key to dismiss the overlay")
+	}
+	if cmd == nil {
+		t.Fatal("Expected dismissing the overlay to write the config file")
+	}
+	if msg := cmd(); msg != nil {
+		t.Errorf("Expected the key not to quit, got %T", msg)
+	}
+
+	settings, err := config.Load(path, true)
+	if err != nil {
+		t.Fatalf("Expected a default config file, got %v", err)
+	}
+	if settings.Layout.ColumnSplit != 0 {
+		t.Errorf("Expected default settings, got %+v", settings.Layout)
+	}
+}
+
+func TestMainModel_OnboardingKeepsExistingConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	model := NewMainModel().SetConfigPath(path).SetOnboarding(true)
+	if err := os.WriteFile(path, []byte(`{"rate_unit": "bits"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if updated.(MainModel).IsOnboarding() || cmd == nil {
+		t.Fatal("Expected Enter to dismiss the overlay")
+	}
+	cmd()
+	if data, _ := os.ReadFile(path); string(data) != `{"rate_unit": "bits"}` {
+		t.Errorf("Expected a config file written meanwhile to be kept, got %s", data)
+	}
+}
@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	paletteWidth   = 56 // Outer width of the action palette
+	paletteMatches = 8  // Actions listed at most
+)
+
+// paletteAction is one entry of the action palette
+type paletteAction struct {
+	name string
+	key  string // Key that does the same outside the palette, "" for none
+	run  func(MainModel) (MainModel, tea.Cmd)
+}
+
+// namedKeys maps key names of the keymap that are not plain runes to the keys
+// the palette presses for them
+var namedKeys = map[string]tea.KeyType{
+	"tab": tea.KeyTab,
+	"f1":  tea.KeyF1, "f2": tea.KeyF2, "f3": tea.KeyF3, "f4": tea.KeyF4, "f5": tea.KeyF5,
+	"f6": tea.KeyF6, "f7": tea.KeyF7, "f8": tea.KeyF8, "f9": tea.KeyF9, "f12": tea.KeyF12,
+}
+
+// pressAction returns an action that presses the first key of binding, so
+// the palette does exactly what the key does
+func pressAction(name string, binding []string) paletteAction {
+	if len(binding) == 0 {
+		return paletteAction{name: name, run: func(m MainModel) (MainModel, tea.Cmd) { return m, nil }}
+	}
+	key := binding[0]
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	if keyType, ok := namedKeys[key]; ok {
+		msg = tea.KeyMsg{Type: keyType}
+	}
+	return paletteAction{name: name, key: keyLabel(key), run: func(m MainModel) (MainModel, tea.Cmd) {
+		updated, cmd := m.Update(msg)
+		return updated.(MainModel), cmd
+	}}
+}
+
+// paletteActions lists every action of the palette: focusing each panel of
+// the grid, switching tabs and profiles, and the actions bound to keys
+func (m MainModel) paletteActions() []paletteAction {
+	var actions []paletteAction
+	for _, panel := range m.panels {
+		panel := panel
+		actions = append(actions, paletteAction{name: "Focus " + panelTitles[panel] + " panel", run: func(m MainModel) (MainModel, tea.Cmd) {
+			m.showSensors, m.showContainers, m.showAlerts, m.showPlugins, m.showProcesses, m.showCorrelation = false, false, false, false, false, false
+			m.focused = panel
+			return m, nil
+		}})
+	}
+	for i, tab := range m.tabs {
+		i := i
+		actions = append(actions, paletteAction{name: "Switch to tab " + tab.name, run: func(m MainModel) (MainModel, tea.Cmd) {
+			m.showSensors, m.showContainers, m.showAlerts, m.showPlugins, m.showProcesses, m.showCorrelation = false, false, false, false, false, false
+			return m.selectTab(i), nil
+		}})
+	}
+
+	for _, name := range m.settings.ProfileNames() {
+		name := name
+		actions = append(actions, paletteAction{name: "Switch to profile " + name, run: func(m MainModel) (MainModel, tea.Cmd) {
+			return m.switchProfile(name)
+		}})
+	}
+
+	return append(actions,
+		pressAction("Toggle help", m.keys.Help),
+		pressAction("Toggle temperature sensors", m.keys.Sensors),
+		pressAction("Toggle containers", m.keys.Containers),
+		pressAction("Toggle alert history", m.keys.Alerts),
+		pressAction("Toggle the correlation view", m.keys.Correlation),
+		pressAction("Toggle plugin panels", m.keys.Plugins),
+		pressAction("Toggle processes", m.keys.Processes),
+		pressAction("Zoom the focused panel", m.keys.Zoom),
+		pressAction("Filter the focused list", m.keys.Filter),
+		pressAction("Switch temperatures between °C and °F", m.keys.Units),
+		pressAction("Switch network rates between bytes and bits", m.keys.RateUnit),
+		pressAction("Cycle the order of the focused disk or network rows", m.keys.Sort),
+		pressAction("Expand or collapse similar disk or network rows", m.keys.Expand),
+		pressAction("Select the next core of the CPU heatmap", m.keys.NextCore),
+		pressAction("Export a screenshot as text", m.keys.Screenshot),
+		pressAction("Export a screenshot with colors", m.keys.ScreenshotANSI),
+		pressAction("Export the metrics as JSON", m.keys.ExportSnapshot),
+		pressAction("Annotate the timeline", m.keys.Annotate),
+		pressAction("Refresh now", m.keys.Refresh),
+		pressAction("Open the command prompt", m.keys.Command),
+		pressAction("Toggle the debug overlay", m.keys.Debug),
+		pressAction("Quit", m.keys.Quit),
+	)
+}
+
+// matchingActions returns the actions matching the palette query, best first
+func (m MainModel) matchingActions() []paletteAction {
+	type scored struct {
+		action paletteAction
+		score  int
+	}
+	var matches []scored
+	for _, action := range m.paletteActions() {
+		if score, ok := fuzzyScore(m.paletteQuery, action.name); ok {
+			matches = append(matches, scored{action, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	actions := make([]paletteAction, len(matches))
+	for i, match := range matches {
+		actions[i] = match.action
+	}
+	return actions
+}
+
+// fuzzyScore reports whether the runes of query appear in text in order,
+// ignoring case, and scores the match: runes matched next to each other or
+// at the start of a word score higher, so "tp" ranks "Toggle processes"
+// above "Export a screenshot"
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(text))
+	score, qi, last := 0, 0, -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if unicode.IsSpace(q[qi]) {
+			qi++
+			ti--
+			continue
+		}
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == last+1 {
+			score += 4
+		}
+		if ti == 0 || t[ti-1] == ' ' {
+			score += 3
+		}
+		last = ti
+		qi++
+	}
+	for qi < len(q) && unicode.IsSpace(q[qi]) {
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// handlePaletteKey edits the palette query and moves the selection; Enter
+// runs the selected action and Escape closes the palette
+func (m MainModel) handlePaletteKey(msg tea.KeyMsg) (MainModel, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEsc || m.containsKey(m.keys.Palette, msg.String()):
+		m.paletteOpen = false
+	case msg.Type == tea.KeyEnter:
+		m.paletteOpen = false
+		if actions := m.matchingActions(); m.paletteSelected < len(actions) {
+			return actions[m.paletteSelected].run(m)
+		}
+	case msg.Type == tea.KeyUp:
+		m.paletteSelected = max(m.paletteSelected-1, 0)
+	case msg.Type == tea.KeyDown:
+		m.paletteSelected = min(m.paletteSelected+1, max(len(m.matchingActions())-1, 0))
+	case msg.Type == tea.KeyBackspace:
+		if runes := []rune(m.paletteQuery); len(runes) > 0 {
+			m.paletteQuery = string(runes[:len(runes)-1])
+			m.paletteSelected = 0
+		}
+	case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace:
+		m.paletteQuery += string(msg.Runes)
+		m.paletteSelected = 0
+	}
+	return m, nil
+}
+
+// renderPalette draws the action palette in a box over the top of view
+func (m MainModel) renderPalette(view string) string {
+	inner := paletteWidth - 4
+	lines := []string{
+		m.styleManager.RenderHeader("Actions"),
+		truncate("> "+m.paletteQuery+"█", inner),
+	}
+
+	actions := m.matchingActions()
+	if len(actions) == 0 {
+		lines = append(lines, m.styleManager.RenderMutedText("No matching actions"))
+	}
+	first := max(m.paletteSelected-paletteMatches+1, 0)
+	for i := first; i < len(actions) && i < first+paletteMatches; i++ {
+		key := actions[i].key
+		name := fit(actions[i].name, inner-lipgloss.Width(key)-3)
+		line := "  " + name + " " + m.styleManager.RenderMutedText(key)
+		if i == m.paletteSelected {
+			line = m.styleManager.RenderHighlightText("▶ "+name) + " " + m.styleManager.RenderMutedText(key)
+		}
+		lines = append(lines, line)
+	}
+
+	box := m.styleManager.RenderComponentBorder(strings.Join(lines, "\n"), true, paletteWidth-2, len(lines))
+	return overlay(view, box, max((m.width-paletteWidth)/2, 0), 2)
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openPalette presses Ctrl+P and types query into the palette
+func openPalette(t *testing.T, model MainModel, query string) MainModel {
+	t.Helper()
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	model = updated.(MainModel)
+	if !model.paletteOpen {
+		t.Fatal("Expected Ctrl+P to open the action palette")
+	}
+	if query != "" {
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(query)})
+		model = updated.(MainModel)
+	}
+	return model
+}
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query, text string
+		match       bool
+	}{
+		{"", "Toggle help", true},
+		{"help", "Toggle help", true},
+		{"tgh", "Toggle help", true},
+		{"TOGGLE", "Toggle help", true},
+		{"toggle help", "Toggle help", true},
+		{"hept", "Toggle help", false},
+		{"helpx", "Toggle help", false},
+	}
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.query, tt.text); ok != tt.match {
+			t.Errorf("fuzzyScore(%q, %q) matched = %v, want %v", tt.query, tt.text, ok, tt.match)
+		}
+	}
+
+	exact, _ := fuzzyScore("proc", "Toggle processes")
+	scattered, _ := fuzzyScore("proc", "Export a screenshot with colors")
+	if exact <= scattered {
+		t.Errorf("Expected consecutive matches to score higher, got %d and %d", exact, scattered)
+	}
+}
+
+func TestMainModel_PaletteRunsAction(t *testing.T) {
+	model := openPalette(t, NewMainModel(), "toggle proc")
+	actions := model.matchingActions()
+	if len(actions) == 0 || actions[0].name != "Toggle processes" {
+		t.Fatalf("Expected Toggle processes to match first, got %v", actions)
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(MainModel)
+	if model.paletteOpen {
+		t.Error("Expected Enter to close the palette")
+	}
+	if !model.showProcesses {
+		t.Error("Expected the action to open the processes page")
+	}
+}
+
+func TestMainModel_PaletteFocusesPanel(t *testing.T) {
+	model := openPalette(t, NewMainModel(), "focus netw")
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(MainModel)
+	if model.GetFocusedComponent() != FocusNetwork {
+		t.Errorf("Expected the Network panel to be focused, got %v", model.GetFocusedComponent())
+	}
+}
+
+func TestMainModel_PaletteNavigation(t *testing.T) {
+	model := openPalette(t, NewMainModel(), "toggle")
+	first := model.matchingActions()[0].name
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	model = updated.(MainModel)
+	if model.paletteSelected != 0 {
+		t.Errorf("Expected ↑ to stop at the first action, got %d", model.paletteSelected)
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model = updated.(MainModel)
+	if model.paletteSelected != 1 {
+		t.Errorf("Expected ↓ to select the second action, got %d", model.paletteSelected)
+	}
+
+	// Keys that act outside the palette are typed into it instead
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	model = updated.(MainModel)
+	if model.paletteQuery != "toggleq" || model.paletteSelected != 0 {
+		t.Errorf("Expected q to be typed and reset the selection, got %q at %d", model.paletteQuery, model.paletteSelected)
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	model = updated.(MainModel)
+	if model.matchingActions()[0].name != first {
+		t.Errorf("Expected Backspace to restore the matches, got %q", model.matchingActions()[0].name)
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(MainModel)
+	if model.paletteOpen || cmd != nil {
+		t.Error("Expected Escape to close the palette without running an action")
+	}
+}
+
+func TestMainModel_PaletteView(t *testing.T) {
+	updated, _ := NewMainModel().Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model := openPalette(t, updated.(MainModel), "screenshot")
+
+	view := stripStyles(model.View())
+	for _, want := range []string{"Actions", "> screenshot█", "Export a screenshot as text", "Export a screenshot with colors"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the palette to show %q, got:\n%s", want, view)
+		}
+	}
+
+	model = openPalette(t, updated.(MainModel), "zzzz")
+	if view := stripStyles(model.View()); !strings.Contains(view, "No matching actions") {
+		t.Errorf("Expected an empty palette to say so, got:\n%s", view)
+	}
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// panelTitles names the grid components in the help screen
+var panelTitles = map[FocusedComponent]string{
+	FocusCPU:     "CPU",
+	FocusMemory:  "Memory",
+	FocusDisk:    "Disk",
+	FocusNetwork: "Network",
+	FocusSensors: "Temperatures",
+	FocusAlerts:  "Alerts",
+	FocusLog:     "Log",
+	FocusSelf:    "Monitor Process",
+	FocusKernel:  "Kernel",
+}
+
+// panelMetrics explains what the values of each grid component mean
+var panelMetrics = map[FocusedComponent][]string{
+	FocusCPU: {
+		"Total is the share of time all cores spent running anything but the idle task",
+		"Split divides the time into user (usr), kernel (sys), I/O wait (io),",
+		"  steal (st), taken by the hypervisor for other guests, and interrupts (irq);",
+		"  high io points at I/O-bound load, steady st at an oversold virtual machine",
+		"Per-core bars use the same measure for each logical core; cores that do",
+		"  not fit a line each become a heatmap, < and > pick the core detailed below",
+		"Top lists the processes using the most CPU since the previous refresh",
+		"Limit shows a cgroup CPU quota below the number of cores",
+		"\"unusual\" marks a total far from its usual level of the last minutes",
+	},
+	FocusMemory: {
+		"Used is memory held by processes that cannot be reclaimed without swapping",
+		"Available is what can be handed out without swapping: free memory plus",
+		"  page cache and buffers the kernel drops when it needs the space;",
+		"  memory_thresholds can color the bar by it instead of used",
+		"Swap is memory moved to disk; steady use there slows the system down",
+		"tmpfs filesystems keep their files in RAM and count as used memory",
+		"Huge is the huge page pool, shown when one is configured; reserved pages",
+		"  are promised to a mapping but not touched yet",
+	},
+	FocusDisk: {
+		"Usage is the share of each filesystem's capacity that is used",
+		"Yellow from 70% and red from 90%, unless disk_thresholds sets others",
+		"\"full in\" extrapolates the growth since startup to when the disk fills up",
+		"With -pools, ZFS pools and btrfs filesystems are listed first, red when degraded",
+	},
+	FocusNetwork: {
+		"Rates are bytes sent (↑) and received (↓) per second since the last refresh",
+		"The line under the rates is the total since the interface came up",
+		"session totals count only what was transferred since the monitor started",
+		"Wi-Fi lines show the network, signal strength and link rate; yellow below 40%",
+	},
+	FocusSensors: {
+		"Temperatures of CPU, GPU, NVMe and chassis sensors",
+		"warn and crit are the thresholds the sensor line turns yellow and red at",
+	},
+	FocusAlerts: {
+		"Alerts fire when a metric crosses its threshold and clear once it falls",
+		"  5 points below it, so a value hovering at the threshold fires once",
+	},
+	FocusLog: {
+		"The newest lines of the followed log file, with errors highlighted",
+	},
+	FocusSelf: {
+		"Resources used by the monitor itself: CPU, resident memory, Go heap",
+		"  and garbage collection pauses",
+	},
+	FocusKernel: {
+		"Context switches, interrupts and forks per second since the last refresh;",
+		"  a jump in context switches or forks often means lock contention or a",
+		"  process spawning short-lived children in a loop",
+		"Entropy is the size of the kernel's random pool; since Linux 5.18 it",
+		"  always reads 256 bits, on older kernels a low value can stall programs",
+		"  reading /dev/random",
+	},
+}
+
+// renderPanelHelp renders the keys and metric explanations of the focused
+// grid component, shown above the global help
+func (m MainModel) renderPanelHelp() []string {
+	title, ok := panelTitles[m.focused]
+	if !ok || m.showSensors || m.showContainers || m.showAlerts || m.showPlugins || m.showProcesses || m.showCorrelation {
+		return nil
+	}
+
+	lines := []string{title + " panel:"}
+	hints := append(m.panelHints(), NewKeyHint("zoom", m.keys.Zoom))
+	for _, hint := range hints {
+		if len(hint.Keys) == 0 {
+			continue
+		}
+		keys := make([]string, len(hint.Keys))
+		for i, key := range hint.Keys {
+			keys[i] = keyLabel(key)
+		}
+		lines = append(lines, fmt.Sprintf("  %-15s %s", strings.Join(keys, "/"), hint.Desc))
+	}
+	for _, metric := range panelMetrics[m.focused] {
+		lines = append(lines, "  "+metric)
+	}
+	return append(lines, "")
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pinLabel returns the name of a row, behind a star when it is pinned
+func pinLabel(styleManager *StyleManager, name string, pinned bool) string {
+	switch {
+	case !pinned:
+		return name
+	case styleManager.barMode == BarASCII:
+		return "* " + name
+	default:
+		return "★ " + name
+	}
+}
+
+// pin pins the filesystem or interface called name to the top of the focused
+// Disk or Network panel, or unpins it, and saves the pins to the config file
+func (m MainModel) pin(name string, pinned bool) (MainModel, tea.Cmd) {
+	var pins []string
+	switch m.focused {
+	case FocusDisk:
+		pins = m.settings.PinnedDisks
+	case FocusNetwork:
+		pins = m.settings.PinnedInterfaces
+	default:
+		return m.setCommandNotice("The "+m.focused.PanelName()+" panel has no rows to pin", true), nil
+	}
+
+	// New slices, since earlier models share the old one
+	index := slices.Index(pins, name)
+	switch {
+	case pinned && index >= 0:
+		return m.setCommandNotice(name+" is already pinned", true), nil
+	case pinned:
+		pins = append(slices.Clip(pins), name)
+	case index < 0:
+		return m.setCommandNotice(name+" is not pinned", true), nil
+	default:
+		pins = slices.Delete(slices.Clone(pins), index, index+1)
+	}
+
+	if m.focused == FocusDisk {
+		m.settings.PinnedDisks = pins
+		m.disk = m.disk.SetPinned(pins)
+	} else {
+		m.settings.PinnedInterfaces = pins
+		m.network = m.network.SetPinned(pins)
+	}
+	if pinned {
+		m = m.setCommandNotice("Pinned "+name, false)
+	} else {
+		m = m.setCommandNotice("Unpinned "+name, false)
+	}
+	return m.scheduleLayoutSave()
+}
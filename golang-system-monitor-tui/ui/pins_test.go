@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+)
+
+func TestMainModel_CommandPin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	model := NewMainModel().ApplyConfig(config.Default()).SetConfigPath(path)
+	model.disk, _ = model.disk.Update(DiskUpdateMsg([]models.DiskInfo{{Mountpoint: "/"}, {Mountpoint: "/home"}}))
+	notice := func() string { return stripStyles(model.renderNotice(model.now())) }
+
+	model.focused = FocusDisk
+	model, cmd := typeCommand(t, model, "pin /home")
+	if pinned := model.disk.GetPinned(); !reflect.DeepEqual(pinned, []string{"/home"}) {
+		t.Fatalf("Expected /home pinned, got %v", pinned)
+	}
+	if model.disk.GetVisibleFilesystems()[0].Mountpoint != "/home" {
+		t.Error("Expected the pinned filesystem first")
+	}
+	if notice() != "Pinned /home" {
+		t.Errorf("Expected a confirmation, got %q", notice())
+	}
+	if cmd == nil {
+		t.Error("Expected the pins to be saved")
+	}
+	runCmd(model.saveLayoutCmd())
+	saved, err := config.Load(path, true)
+	if err != nil {
+		t.Fatalf("Expected the config file to be saved: %v", err)
+	}
+	if !reflect.DeepEqual(saved.PinnedDisks, []string{"/home"}) {
+		t.Errorf("Expected the pin in the config file, got %v", saved.PinnedDisks)
+	}
+
+	model, _ = typeCommand(t, model, "pin /home")
+	if notice() != "/home is already pinned" {
+		t.Errorf("Expected pinning twice to be refused, got %q", notice())
+	}
+	model, _ = typeCommand(t, model, "unpin /home")
+	if len(model.disk.GetPinned()) != 0 || len(model.settings.PinnedDisks) != 0 {
+		t.Errorf("Expected /home unpinned, got %v", model.disk.GetPinned())
+	}
+	model, _ = typeCommand(t, model, "unpin /home")
+	if notice() != "/home is not pinned" {
+		t.Errorf("Expected unpinning a row that is not pinned to be refused, got %q", notice())
+	}
+
+	// Interface names may contain spaces, e.g. on Windows
+	model.focused = FocusNetwork
+	model, _ = typeCommand(t, model, "pin Wi-Fi 2")
+	if pinned := model.network.GetPinned(); !reflect.DeepEqual(pinned, []string{"Wi-Fi 2"}) {
+		t.Errorf("Expected Wi-Fi 2 pinned, got %v", pinned)
+	}
+
+	model.focused = FocusCPU
+	model, _ = typeCommand(t, model, "pin cpu0")
+	if !strings.Contains(notice(), "has no rows to pin") {
+		t.Errorf("Expected the CPU panel to refuse pins, got %q", notice())
+	}
+}
+
+func TestMainModel_PinnedFromConfig(t *testing.T) {
+	model := NewMainModel().ApplyConfig(config.Config{PinnedDisks: []string{"/data"}, PinnedInterfaces: []string{"eth1"}})
+	if !model.disk.IsPinned("/data") || !model.network.IsPinned("eth1") {
+		t.Errorf("Expected the pins of the config file, got %v and %v", model.disk.GetPinned(), model.network.GetPinned())
+	}
+}
@@ -0,0 +1,256 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+)
+
+// PluginUpdateMsg carries the output of one plugin run
+type PluginUpdateMsg models.PluginOutput
+
+// PluginModel renders the metrics of an external plugin: gauges for metrics
+// with a full scale, key/value lines for the others
+type PluginModel struct {
+	name         string              // Configured plugin name
+	output       models.PluginOutput // Last successful output
+	width        int                 // Component width for rendering
+	height       int                 // Component height for rendering
+	styleManager *StyleManager       // Style manager for consistent styling
+	hasError     bool                // Whether the last run failed
+	errorMessage string              // Why the last run failed
+	lastError    time.Time           // Timestamp of the last failed run
+}
+
+// NewPluginModel creates a plugin model for the named plugin
+func NewPluginModel(name string) PluginModel {
+	return PluginModel{
+		name:         name,
+		width:        40,
+		height:       8,
+		styleManager: NewStyleManager(),
+	}
+}
+
+// Init initializes the plugin model
+func (m PluginModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles the output of runs of this plugin. A failed run keeps the
+// last metrics on screen below the error.
+func (m PluginModel) Update(msg tea.Msg) (PluginModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case PluginUpdateMsg:
+		if msg.Name != m.name {
+			return m, nil
+		}
+		if msg.Error != "" {
+			m.hasError = true
+			m.errorMessage = msg.Error
+			m.lastError = msg.Timestamp
+			return m, nil
+		}
+		m.hasError = false
+		m.errorMessage = ""
+		m.output = models.PluginOutput(msg)
+	}
+	return m, nil
+}
+
+// View renders the plugin model
+func (m PluginModel) View() string {
+	title := m.GetTitle()
+	if !m.hasError && m.output.Timestamp.IsZero() {
+		return m.styleManager.RenderPlaceholder(title, "Waiting for plugin output...")
+	}
+
+	sections := []string{m.styleManager.RenderHeader(title)}
+	if m.hasError {
+		sections = append(sections, m.styleManager.RenderErrorText(truncate("Error: "+m.errorMessage, m.width)))
+	}
+	if len(m.output.Metrics) == 0 && !m.hasError {
+		sections = append(sections, m.styleManager.RenderMutedText("No metrics reported"))
+	}
+
+	labelWidth := 0
+	for _, metric := range m.output.Metrics {
+		labelWidth = max(labelWidth, lipgloss.Width(metric.Label)+1)
+	}
+	labelWidth = min(labelWidth, m.width/2)
+
+	for _, metric := range m.output.Metrics {
+		if len(sections) >= m.height {
+			break
+		}
+		label := fmt.Sprintf("%-*s", labelWidth, truncate(metric.Label, labelWidth-1)+":")
+		if metric.IsGauge() {
+			bar := m.styleManager.RenderProgressBar(metric.Percent(), m.styleManager.GetProgressBarWidth(m.width, labelWidth+1), false)
+			sections = append(sections, fmt.Sprintf("%s %s %s", label, bar, m.styleManager.Locale().FormatPercent(metric.Percent(), 1)))
+			continue
+		}
+		sections = append(sections, truncate(label+" "+m.formatValue(metric), m.width))
+	}
+
+	// Add spacing if we have fewer lines than available height
+	for len(sections) < m.height {
+		sections = append(sections, "")
+	}
+	return strings.Join(sections, "\n")
+}
+
+// formatValue renders the text of a metric, or its value with the unit
+func (m PluginModel) formatValue(metric models.PluginMetric) string {
+	if metric.Text != "" {
+		return metric.Text
+	}
+	precision := 2
+	if metric.Value == math.Trunc(metric.Value) {
+		precision = 0
+	}
+	value := m.styleManager.Locale().FormatFloat(metric.Value, precision)
+	if metric.Unit != "" {
+		value += " " + metric.Unit
+	}
+	return value
+}
+
+// SetSize sets the component dimensions
+func (m PluginModel) SetSize(width, height int) PluginModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m PluginModel) SetStyleManager(styleManager *StyleManager) PluginModel {
+	m.styleManager = styleManager
+	return m
+}
+
+// GetName returns the configured plugin name
+func (m PluginModel) GetName() string {
+	return m.name
+}
+
+// GetTitle returns the title reported by the plugin, or its name
+func (m PluginModel) GetTitle() string {
+	if m.output.Title != "" {
+		return m.output.Title
+	}
+	return m.name
+}
+
+// GetOutput returns the last successful output of the plugin
+func (m PluginModel) GetOutput() models.PluginOutput {
+	return m.output
+}
+
+// HasError returns whether the last run of the plugin failed
+func (m PluginModel) HasError() bool {
+	return m.hasError
+}
+
+// GetErrorMessage returns why the last run of the plugin failed
+func (m PluginModel) GetErrorMessage() string {
+	return m.errorMessage
+}
+
+// applyPlugins registers a collector for every configured plugin, replacing
+// those of a previous config, and rebuilds the plugin panels
+func (m MainModel) applyPlugins(plugins []config.Plugin) MainModel {
+	for _, collector := range m.registry.Collectors() {
+		if strings.HasPrefix(collector.Name(), services.PluginCollectorPrefix) {
+			m.registry.Unregister(collector.Name())
+		}
+	}
+	for _, plugin := range plugins {
+		m.registry.Replace(services.NewPluginCollector(plugin.Name, plugin.Command, plugin.TimeoutDuration()))
+	}
+	return m.rebuildPluginPanels()
+}
+
+// rebuildPluginPanels creates a panel for every configured plugin followed by
+// every panel script, keeping the output of panels that already existed
+func (m MainModel) rebuildPluginPanels() MainModel {
+	var names []string
+	for _, plugin := range m.settings.Plugins {
+		names = append(names, plugin.Name)
+	}
+	for _, script := range m.scripts {
+		names = append(names, script.Name)
+	}
+
+	previous := make(map[string]PluginModel, len(m.plugins))
+	for _, panel := range m.plugins {
+		previous[panel.GetName()] = panel
+	}
+	m.plugins = make([]PluginModel, 0, len(names))
+	for _, name := range names {
+		panel, ok := previous[name]
+		if !ok {
+			panel = NewPluginModel(name)
+		}
+		m.plugins = append(m.plugins, panel.SetStyleManager(m.styleManager.ForPanel("plugins")))
+	}
+	return m
+}
+
+// renderPlugins renders the plugin panels in a grid across the full screen,
+// two columns on wide terminals
+func (m MainModel) renderPlugins() string {
+	header := m.styleManager.RenderApplicationHeader(m.headerTitle())
+	footer := m.renderFooter()
+	styles := m.styleManager.ForPanel("plugins")
+
+	if len(m.plugins) == 0 {
+		width := m.width - 4
+		height := m.height - 6
+		view := styles.RenderPlaceholder("Plugins", "No plugins configured, add them to the \"plugins\" list of the config file")
+		panel := styles.RenderComponentBorder(view, true, width, height)
+		return lipgloss.JoinVertical(lipgloss.Left, header, m.renderNotice(m.now()), panel, m.renderStatusBar(m.now()), footer)
+	}
+
+	columns := 1
+	if m.width >= 100 && len(m.plugins) > 1 {
+		columns = 2
+	}
+	rows := (len(m.plugins) + columns - 1) / columns
+	width := (m.width-2-(columns-1))/columns - 2
+	height := max((m.height-4)/rows-2, 1)
+
+	var lines []string
+	for row := 0; row < rows; row++ {
+		var cells []string
+		for column := 0; column < columns; column++ {
+			i := row*columns + column
+			if i >= len(m.plugins) {
+				break
+			}
+			if column > 0 {
+				cells = append(cells, " ")
+			}
+			view := m.plugins[i].SetSize(width, height).View()
+			cells = append(cells, styles.RenderComponentBorder(view, false, width, height))
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderNotice(m.now()), content, m.renderStatusBar(m.now()), footer)
+}
+
+// GetPluginModels returns the panels of the configured plugins
+func (m MainModel) GetPluginModels() []PluginModel {
+	plugins := make([]PluginModel, len(m.plugins))
+	copy(plugins, m.plugins)
+	return plugins
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestPluginModel_View(t *testing.T) {
+	model := NewPluginModel("db").SetSize(60, 8)
+	if view := stripStyles(model.View()); !strings.Contains(view, "db") || !strings.Contains(view, "Waiting for plugin output") {
+		t.Errorf("Expected a placeholder before the first run, got:\n%s", view)
+	}
+
+	model, _ = model.Update(PluginUpdateMsg{
+		Name:  "db",
+		Title: "PostgreSQL",
+		Metrics: []models.PluginMetric{
+			{Label: "connections", Value: 42, Max: 100},
+			{Label: "replication lag", Value: 1.5, Unit: "s"},
+			{Label: "role", Text: "primary"},
+		},
+		Timestamp: time.Now(),
+	})
+	view := stripStyles(model.View())
+	for _, want := range []string{"PostgreSQL", "connections:", "42.0%", "replication lag: 1.50 s", "role:", "primary"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the view, got:\n%s", want, view)
+		}
+	}
+	if lines := strings.Count(model.View(), "\n") + 1; lines != 8 {
+		t.Errorf("Expected the view padded to 8 lines, got %d", lines)
+	}
+
+	// Output of other plugins is ignored
+	model, _ = model.Update(PluginUpdateMsg{Name: "queue", Title: "Queue", Timestamp: time.Now()})
+	if model.GetTitle() != "PostgreSQL" {
+		t.Errorf("Expected output of another plugin to be ignored, got title %q", model.GetTitle())
+	}
+
+	// A failed run keeps the last metrics below the error
+	model, _ = model.Update(PluginUpdateMsg{Name: "db", Error: "timed out after 5s", Timestamp: time.Now()})
+	if !model.HasError() || model.GetErrorMessage() != "timed out after 5s" {
+		t.Fatalf("Expected the error to be recorded, got %q", model.GetErrorMessage())
+	}
+	view = stripStyles(model.View())
+	if !strings.Contains(view, "Error: timed out after 5s") || !strings.Contains(view, "connections:") {
+		t.Errorf("Expected the error and the last metrics, got:\n%s", view)
+	}
+
+	model, _ = model.Update(PluginUpdateMsg{Name: "db", Timestamp: time.Now()})
+	if model.HasError() || !strings.Contains(stripStyles(model.View()), "No metrics reported") {
+		t.Errorf("Expected a successful run to clear the error, got:\n%s", stripStyles(model.View()))
+	}
+}
+
+func TestPluginModel_View_ClipsToHeight(t *testing.T) {
+	metrics := make([]models.PluginMetric, 10)
+	for i := range metrics {
+		metrics[i] = models.PluginMetric{Label: "queue", Value: float64(i)}
+	}
+	model, _ := NewPluginModel("q").SetSize(40, 4).Update(PluginUpdateMsg{Name: "q", Metrics: metrics, Timestamp: time.Now()})
+	if lines := strings.Count(model.View(), "\n") + 1; lines != 4 {
+		t.Errorf("Expected the view clipped to 4 lines, got %d", lines)
+	}
+}
+
+func TestMainModel_PluginsPage(t *testing.T) {
+	cfg := config.Config{Plugins: []config.Plugin{
+		{Name: "db", Command: []string{"db-stats"}},
+		{Name: "queue", Command: []string{"queue-depth"}, Timeout: "2s"},
+	}}
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector()).ApplyConfig(cfg)
+	if _, ok := model.GetRegistry().Lookup(services.PluginCollectorPrefix + "queue"); !ok {
+		t.Fatal("Expected a collector for every configured plugin")
+	}
+	if plugins := model.GetPluginModels(); len(plugins) != 2 || plugins[0].GetName() != "db" {
+		t.Fatalf("Expected a panel for every configured plugin, got %d", len(plugins))
+	}
+
+	// Reapplying the config replaces the plugins of the previous one
+	model = model.ApplyConfig(config.Config{Plugins: cfg.Plugins[:1]})
+	if _, ok := model.GetRegistry().Lookup(services.PluginCollectorPrefix + "queue"); ok {
+		t.Error("Expected the removed plugin's collector to be unregistered")
+	}
+	model = model.ApplyConfig(cfg)
+
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	updated, _ = updated.(MainModel).Update(collectedMsg(services.PluginCollectorPrefix+"db", models.PluginOutput{
+		Name:      "db",
+		Title:     "PostgreSQL",
+		Metrics:   []models.PluginMetric{{Label: "connections", Value: 7}},
+		Timestamp: time.Now(),
+	}))
+	updated, _ = updated.(MainModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	view := stripStyles(updated.(MainModel).View())
+	for _, want := range []string{"PostgreSQL", "connections: 7", "queue", "Waiting for plugin output"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q on the plugins page, got:\n%s", want, view)
+		}
+	}
+	if lines := strings.Count(updated.(MainModel).View(), "\n") + 1; lines > 30 {
+		t.Errorf("Expected the plugins page to fit the terminal, got %d lines", lines)
+	}
+
+	updated, _ = updated.(MainModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if strings.Contains(stripStyles(updated.(MainModel).View()), "PostgreSQL") {
+		t.Error("Expected the plugins key to close the page")
+	}
+}
+
+func TestMainModel_PluginsPage_Empty(t *testing.T) {
+	model, err := NewMainModel().SetDeterministic(true).OpenPage("plugins")
+	if err != nil {
+		t.Fatalf("Expected the plugins page to open: %v", err)
+	}
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	if view := stripStyles(updated.(MainModel).View()); !strings.Contains(view, "No plugins configured") {
+		t.Errorf("Expected a placeholder without plugins, got:\n%s", view)
+	}
+}
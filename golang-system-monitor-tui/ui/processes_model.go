@@ -0,0 +1,386 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"golang-system-monitor-tui/models"
+)
+
+// ProcessMemoryMsg carries the memory breakdown of the listed processes
+type ProcessMemoryMsg []models.ProcessMemory
+
+// ProcessFilesMsg carries the open file descriptors of the listed processes
+type ProcessFilesMsg []models.ProcessFiles
+
+// ProcessDetailMsg carries the detail of a process, read when its detail page opens
+type ProcessDetailMsg struct {
+	PID    int32
+	Detail models.ProcessDetail
+	Err    error
+}
+
+// processDetailMaps is the number of memory mappings listed on the detail page
+const processDetailMaps = 5
+
+// ProcessesModel lists the busiest processes with their memory breakdown.
+// RSS counts shared pages in full, so forked servers such as nginx or
+// postgres look many times larger than they are; PSS and USS show what each
+// process really costs.
+type ProcessesModel struct {
+	processes    []models.ProcessInfo           // Latest sample, busiest first
+	memory       map[int32]models.ProcessMemory // Memory breakdown by PID
+	files        map[int32]models.ProcessFiles  // Open files and their limit by PID
+	selected     int                            // Index of the selected process
+	showDetail   bool                           // Whether the detail page is open
+	detailProc   models.ProcessInfo             // Process shown on the detail page
+	detail       *models.ProcessDetail          // Detail of detailProc, nil while it is read
+	detailError  string                         // Why the detail could not be read
+	detailScroll int                            // First detail line shown
+	showEnviron  bool                           // Whether the detail page lists the environment
+	width        int                            // Component width for rendering
+	height       int                            // Component height for rendering
+	styleManager *StyleManager                  // Style manager for consistent styling
+}
+
+// NewProcessesModel creates a new processes model instance
+func NewProcessesModel() ProcessesModel {
+	return ProcessesModel{
+		memory:       make(map[int32]models.ProcessMemory),
+		files:        make(map[int32]models.ProcessFiles),
+		width:        60,
+		height:       10,
+		styleManager: NewStyleManager(),
+	}
+}
+
+// Init initializes the processes model
+func (m ProcessesModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles process samples and memory breakdowns
+func (m ProcessesModel) Update(msg tea.Msg) (ProcessesModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case TopProcessesMsg:
+		// Keep the selection on the same process as the order changes
+		selected, hadSelection := m.Selected()
+		m.processes = []models.ProcessInfo(msg)
+		m.selected = 0
+		for i, proc := range m.processes {
+			if hadSelection && proc.PID == selected.PID {
+				m.selected = i
+			}
+		}
+
+	case ProcessMemoryMsg:
+		memory := make(map[int32]models.ProcessMemory, len(msg))
+		for _, breakdown := range msg {
+			memory[breakdown.PID] = breakdown
+		}
+		m.memory = memory
+
+	case ProcessFilesMsg:
+		files := make(map[int32]models.ProcessFiles, len(msg))
+		for _, open := range msg {
+			files[open.PID] = open
+		}
+		m.files = files
+
+	case ProcessDetailMsg:
+		if msg.PID != m.detailProc.PID {
+			break
+		}
+		m.detail, m.detailError = nil, ""
+		if msg.Err != nil {
+			m.detailError = msg.Err.Error()
+		} else {
+			detail := msg.Detail
+			m.detail = &detail
+		}
+	}
+	return m, nil
+}
+
+// View renders the process list above the detail of the selected process,
+// or the detail page when it is open
+func (m ProcessesModel) View() string {
+	if m.showDetail {
+		return m.renderDetailPage()
+	}
+	if len(m.processes) == 0 {
+		return m.styleManager.RenderPlaceholder("Processes", "Sampling processes...")
+	}
+
+	table := m.styleManager.NewTable(m.width-2,
+		TableColumn{Width: 1},
+		TableColumn{Title: "PID", Width: 7, Right: true},
+		TableColumn{Title: "NAME", Width: 20},
+		TableColumn{Title: "CPU", Width: 6, Right: true},
+		TableColumn{Title: "RSS", Width: 9, Right: true},
+		TableColumn{Title: "PSS", Width: 9, Right: true},
+		TableColumn{Title: "USS", Width: 9, Right: true},
+		TableColumn{Title: "SWAP", Width: 9, Right: true})
+	sections := []string{m.styleManager.RenderHeader("Processes"), table.Header()}
+
+	// The detail pane takes the bottom lines, the list scrolls to the selection
+	rows := m.height - len(sections) - 5
+	if rows < 1 {
+		rows = 1
+	}
+	first := 0
+	if m.selected >= rows {
+		first = m.selected - rows + 1
+	}
+	for i := first; i < len(m.processes) && i < first+rows; i++ {
+		if i == m.selected {
+			sections = append(sections, m.styleManager.RenderHighlightText(m.renderRow(table, "▶", m.processes[i])))
+		} else {
+			sections = append(sections, m.renderRow(table, "", m.processes[i]))
+		}
+	}
+	for len(sections) < m.height-4 {
+		sections = append(sections, "")
+	}
+
+	if proc, ok := m.Selected(); ok {
+		sections = append(sections, "")
+		sections = append(sections, m.renderDetail(proc)...)
+	}
+	return strings.Join(sections, "\n")
+}
+
+// renderRow renders one process behind marker, with dashes until its memory is read
+func (m ProcessesModel) renderRow(table Table, marker string, proc models.ProcessInfo) string {
+	rss, pss, uss, swap := "-", "-", "-", "-"
+	if memory, ok := m.memory[proc.PID]; ok {
+		rss, swap = m.styleManager.FormatBytes(memory.RSS), m.styleManager.FormatBytes(memory.Swap)
+		if memory.Detailed {
+			pss, uss = m.styleManager.FormatBytes(memory.PSS), m.styleManager.FormatBytes(memory.USS)
+		}
+	}
+	return table.Row(marker, strconv.Itoa(int(proc.PID)), proc.Name,
+		m.styleManager.Locale().FormatPercent(proc.CPUPercent, 1),
+		rss, pss, uss, swap)
+}
+
+// renderDetail explains the memory of the selected process in two lines,
+// followed by its open files once they are read
+func (m ProcessesModel) renderDetail(proc models.ProcessInfo) []string {
+	files, ok := m.files[proc.PID]
+	if !ok {
+		return append(m.renderMemoryDetail(proc), "")
+	}
+	return append(m.renderMemoryDetail(proc), m.renderFiles(files))
+}
+
+// renderFiles renders the open files of a process against its limit,
+// highlighted from models.FileDescriptorWarning percent of the limit
+func (m ProcessesModel) renderFiles(files models.ProcessFiles) string {
+	locale := m.styleManager.Locale()
+	if files.Limit == 0 {
+		return "Open files " + locale.FormatInt(int64(files.Open))
+	}
+	line := fmt.Sprintf("Open files %s of %s (%s)", locale.FormatInt(int64(files.Open)), locale.FormatInt(int64(files.Limit)),
+		locale.FormatPercent(files.UsedPercent(), 0))
+	if files.UsedPercent() >= models.FileDescriptorWarning {
+		return m.styleManager.RenderWarningText(line + ", close to the limit")
+	}
+	return line
+}
+
+// renderMemoryDetail explains the memory of the selected process in two lines
+func (m ProcessesModel) renderMemoryDetail(proc models.ProcessInfo) []string {
+	title := m.styleManager.RenderHighlightText(fmt.Sprintf("%s (%d)", proc.Name, proc.PID))
+	memory, ok := m.memory[proc.PID]
+	switch {
+	case !ok:
+		return []string{title, m.styleManager.RenderMutedText("Reading memory...")}
+	case !memory.Detailed:
+		return []string{title, fmt.Sprintf("Resident %s, swapped %s. %s", m.styleManager.FormatBytes(memory.RSS), m.styleManager.FormatBytes(memory.Swap),
+			m.styleManager.RenderMutedText("PSS and USS need /proc/<pid>/smaps_rollup (Linux)"))}
+	}
+
+	shared := uint64(0)
+	if memory.RSS > memory.USS {
+		shared = memory.RSS - memory.USS
+	}
+	return []string{title, fmt.Sprintf("Resident %s: %s private, %s shared with other processes. Proportional share %s, swapped %s",
+		m.styleManager.FormatBytes(memory.RSS), m.styleManager.FormatBytes(memory.USS), m.styleManager.FormatBytes(shared), m.styleManager.FormatBytes(memory.PSS), m.styleManager.FormatBytes(memory.Swap))}
+}
+
+// renderDetailPage renders the command line, thread count, IO counters,
+// largest memory mappings, open files and, when shown, the environment of
+// the process the detail page was opened for, scrolled to detailScroll
+func (m ProcessesModel) renderDetailPage() string {
+	proc := m.detailProc
+	header := m.styleManager.RenderHeader(fmt.Sprintf("Process %s (%d)", proc.Name, proc.PID))
+	var lines []string
+	switch {
+	case m.detailError != "":
+		lines = []string{m.styleManager.RenderErrorText("Error: " + m.detailError)}
+	case m.detail == nil:
+		lines = []string{m.styleManager.RenderMutedText("Reading process...")}
+	default:
+		lines = m.detailLines(*m.detail)
+	}
+
+	rows := m.height - 1
+	if rows < 1 {
+		rows = 1
+	}
+	scroll := min(m.detailScroll, max(len(lines)-rows, 0))
+	lines = lines[scroll:min(scroll+rows, len(lines))]
+	return strings.Join(append([]string{header}, lines...), "\n")
+}
+
+// detailLines renders the parts of a process detail, one value per line
+func (m ProcessesModel) detailLines(detail models.ProcessDetail) []string {
+	styles, locale := m.styleManager, m.styleManager.Locale()
+	// part renders a labeled value, or why the part could not be read
+	part := func(label, name, value string) string {
+		if reason, ok := detail.Errors[name]; ok {
+			return fmt.Sprintf("%-10s%s", label, styles.RenderMutedText("unavailable: "+reason))
+		}
+		return fmt.Sprintf("%-10s%s", label, value)
+	}
+
+	cmdline := detail.Cmdline
+	if cmdline == "" {
+		cmdline = "-"
+	}
+	lines := strings.Split(ansi.Wrap(part("Command", "command line", cmdline), m.width, ""), "\n")
+	lines = append(lines,
+		part("Threads", "threads", locale.FormatInt(int64(detail.Threads))),
+		part("IO", "IO", fmt.Sprintf("read %s in %s calls, wrote %s in %s calls",
+			styles.FormatBytes(detail.IO.ReadBytes), locale.FormatInt(int64(detail.IO.ReadCount)),
+			styles.FormatBytes(detail.IO.WriteBytes), locale.FormatInt(int64(detail.IO.WriteCount)))))
+	if files, ok := m.files[detail.PID]; ok {
+		lines = append(lines, m.renderFiles(files))
+	}
+
+	lines = append(lines, "", part("Mappings", "memory maps", fmt.Sprintf("%s, most resident first", locale.FormatInt(int64(detail.MapCount)))))
+	for _, mapping := range detail.Maps[:min(len(detail.Maps), processDetailMaps)] {
+		path := mapping.Path
+		if path == "" {
+			path = "[anonymous]"
+		}
+		lines = append(lines, truncate(fmt.Sprintf("  %9s of %9s  %s", styles.FormatBytes(mapping.RSS), styles.FormatBytes(mapping.Size), path), m.width))
+	}
+
+	lines = append(lines, "", part("Files", "open files", locale.FormatInt(int64(len(detail.OpenFiles)))+" open"))
+	for _, path := range detail.OpenFiles {
+		lines = append(lines, truncate("  "+path, m.width))
+	}
+
+	environ := fmt.Sprintf("%s variables, press e to show", locale.FormatInt(int64(len(detail.Environ))))
+	if m.showEnviron {
+		environ = fmt.Sprintf("%s variables", locale.FormatInt(int64(len(detail.Environ))))
+	}
+	lines = append(lines, "", part("Env", "environment", environ))
+	if m.showEnviron {
+		for _, variable := range detail.Environ {
+			lines = append(lines, truncate("  "+variable, m.width))
+		}
+	}
+	return lines
+}
+
+// PIDs returns the listed processes, busiest first
+func (m ProcessesModel) PIDs() []int32 {
+	pids := make([]int32, len(m.processes))
+	for i, proc := range m.processes {
+		pids[i] = proc.PID
+	}
+	return pids
+}
+
+// MoveSelection moves the selection by delta rows, clamped to the list
+func (m ProcessesModel) MoveSelection(delta int) ProcessesModel {
+	m.selected += delta
+	if m.selected >= len(m.processes) {
+		m.selected = len(m.processes) - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+	return m
+}
+
+// Selected returns the selected process, false when the list is empty
+func (m ProcessesModel) Selected() (models.ProcessInfo, bool) {
+	if m.selected < 0 || m.selected >= len(m.processes) {
+		return models.ProcessInfo{}, false
+	}
+	return m.processes[m.selected], true
+}
+
+// GetMemory returns the memory breakdown of a listed process
+func (m ProcessesModel) GetMemory(pid int32) (models.ProcessMemory, bool) {
+	memory, ok := m.memory[pid]
+	return memory, ok
+}
+
+// SetShowDetail opens the detail page of the selected process, or closes it.
+// Opening it drops the previous detail until the new one is read.
+func (m ProcessesModel) SetShowDetail(show bool) ProcessesModel {
+	m.showDetail = show
+	m.detail, m.detailError, m.detailScroll, m.showEnviron = nil, "", 0, false
+	if proc, ok := m.Selected(); ok && show {
+		m.detailProc = proc
+	}
+	return m
+}
+
+// IsShowingDetail returns whether the detail page is open
+func (m ProcessesModel) IsShowingDetail() bool {
+	return m.showDetail
+}
+
+// ScrollDetail scrolls the detail page by delta lines
+func (m ProcessesModel) ScrollDetail(delta int) ProcessesModel {
+	m.detailScroll = max(m.detailScroll+delta, 0)
+	if m.detail != nil {
+		m.detailScroll = min(m.detailScroll, len(m.detailLines(*m.detail)))
+	}
+	return m
+}
+
+// ToggleEnviron shows or hides the environment on the detail page, which is
+// hidden at first as it often holds secrets
+func (m ProcessesModel) ToggleEnviron() ProcessesModel {
+	m.showEnviron = !m.showEnviron
+	return m
+}
+
+// GetDetail returns the detail of the process the detail page shows, false
+// while it is read or when it could not be
+func (m ProcessesModel) GetDetail() (models.ProcessDetail, bool) {
+	if m.detail == nil {
+		return models.ProcessDetail{}, false
+	}
+	return *m.detail, true
+}
+
+// GetFiles returns the open files of a listed process
+func (m ProcessesModel) GetFiles(pid int32) (models.ProcessFiles, bool) {
+	files, ok := m.files[pid]
+	return files, ok
+}
+
+// SetSize sets the component dimensions
+func (m ProcessesModel) SetSize(width, height int) ProcessesModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m ProcessesModel) SetStyleManager(styleManager *StyleManager) ProcessesModel {
+	m.styleManager = styleManager
+	return m
+}
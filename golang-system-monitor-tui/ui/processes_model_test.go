@@ -0,0 +1,305 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services/fake"
+)
+
+func testProcesses() []models.ProcessInfo {
+	return []models.ProcessInfo{
+		{PID: 100, Name: "postgres", CPUPercent: 42},
+		{PID: 200, Name: "nginx", CPUPercent: 7.5},
+		{PID: 300, Name: "sshd", CPUPercent: 0},
+	}
+}
+
+func testProcessMemory() []models.ProcessMemory {
+	return []models.ProcessMemory{
+		{PID: 100, RSS: 512 << 20, PSS: 96 << 20, USS: 64 << 20, Swap: 8 << 20, Detailed: true},
+		{PID: 200, RSS: 20 << 20, Swap: 0},
+	}
+}
+
+func TestProcessesModel_View(t *testing.T) {
+	model := NewProcessesModel().SetSize(90, 14)
+	if !strings.Contains(model.View(), "Sampling processes") {
+		t.Error("Expected a placeholder before the first sample")
+	}
+
+	model, _ = model.Update(TopProcessesMsg(testProcesses()))
+	model, _ = model.Update(ProcessMemoryMsg(testProcessMemory()))
+	view := stripStyles(model.View())
+
+	for _, want := range []string{
+		"PSS", "USS", "SWAP",
+		"postgres", "512.0MiB", "96.0MiB", "64.0MiB", "8.0MiB",
+		// Shared pages are RSS less USS
+		"postgres (100)", "64.0MiB private, 448.0MiB shared",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q, got:\n%s", want, view)
+		}
+	}
+	if lines := strings.Split(view, "\n"); len(lines) != 14 {
+		t.Errorf("Expected the view to fill 14 lines, got %d", len(lines))
+	}
+}
+
+func TestProcessesModel_ViewWithoutDetails(t *testing.T) {
+	model := NewProcessesModel().SetSize(90, 14)
+	model, _ = model.Update(TopProcessesMsg(testProcesses()))
+	model, _ = model.Update(ProcessMemoryMsg(testProcessMemory()))
+	model = model.MoveSelection(1)
+
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "nginx (200)") || !strings.Contains(view, "PSS and USS need") {
+		t.Errorf("Expected the RSS-only detail for nginx, got:\n%s", view)
+	}
+
+	model = model.MoveSelection(1)
+	if view := stripStyles(model.View()); !strings.Contains(view, "Reading memory") {
+		t.Errorf("Expected sshd's memory to be pending, got:\n%s", view)
+	}
+}
+
+func TestProcessesModel_ViewFiles(t *testing.T) {
+	model := NewProcessesModel().SetSize(90, 14)
+	model, _ = model.Update(TopProcessesMsg(testProcesses()))
+	model, _ = model.Update(ProcessFilesMsg{{PID: 100, Open: 900, Limit: 1024}, {PID: 200, Open: 12}})
+
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "Open files 900 of 1024 (88%), close to the limit") {
+		t.Errorf("Expected the open files of postgres near its limit, got:\n%s", view)
+	}
+	if lines := strings.Split(view, "\n"); len(lines) != 14 {
+		t.Errorf("Expected the view to fill 14 lines, got %d", len(lines))
+	}
+
+	model = model.MoveSelection(1)
+	if view := stripStyles(model.View()); !strings.Contains(view, "Open files 12") {
+		t.Errorf("Expected the open files of nginx without a limit, got:\n%s", view)
+	}
+	model = model.MoveSelection(1)
+	if view := stripStyles(model.View()); strings.Contains(view, "Open files") {
+		t.Errorf("Expected no open files for sshd, got:\n%s", view)
+	}
+}
+
+func TestProcessesModel_DetailPage(t *testing.T) {
+	model := NewProcessesModel().SetSize(80, 30)
+	model, _ = model.Update(TopProcessesMsg(testProcesses()))
+	model = model.SetShowDetail(true)
+	if !model.IsShowingDetail() || !strings.Contains(stripStyles(model.View()), "Reading process") {
+		t.Fatalf("Expected the detail page to wait for the detail, got:\n%s", stripStyles(model.View()))
+	}
+
+	// A detail read for a process the page no longer shows is ignored
+	model, _ = model.Update(ProcessDetailMsg{PID: 200, Detail: models.ProcessDetail{PID: 200}})
+	if _, ok := model.GetDetail(); ok {
+		t.Error("Expected the detail of another process to be ignored")
+	}
+
+	detail, _ := (&fakeProcessCollector{}).CollectProcessDetail(100)
+	model, _ = model.Update(ProcessDetailMsg{PID: 100, Detail: detail})
+	view := stripStyles(model.View())
+	for _, want := range []string{
+		"Process postgres (100)",
+		"Command   postgres -D /var/lib/postgresql/data",
+		"Threads   4",
+		"IO        unavailable: permission denied",
+		"Mappings  120, most resident first",
+		"6.0MiB of    8.0MiB  [heap]",
+		"Files     1 open",
+		"/var/lib/postgresql/data/base/1/1259",
+		"Env       1 variables, press e to show",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the detail page to contain %q, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "PGDATA") {
+		t.Error("Expected the environment to be hidden until asked for")
+	}
+	if view := stripStyles(model.ToggleEnviron().View()); !strings.Contains(view, "PGDATA=/var/lib/postgresql/data") {
+		t.Errorf("Expected the environment to be listed, got:\n%s", view)
+	}
+
+	scrolled := stripStyles(model.SetSize(80, 4).ScrollDetail(2).View())
+	if lines := strings.Split(scrolled, "\n"); len(lines) != 4 || !strings.HasPrefix(lines[0], "Process postgres") || !strings.HasPrefix(lines[1], "IO") {
+		t.Errorf("Expected the header above the lines scrolled by 2, got:\n%s", scrolled)
+	}
+
+	model, _ = model.Update(ProcessDetailMsg{PID: 100, Err: models.CreateSystemError(models.SystemAccessError, "Process", "Process 100 not found", nil)})
+	if view := stripStyles(model.View()); !strings.Contains(view, "Error: ") || !strings.Contains(view, "not found") {
+		t.Errorf("Expected the error on the detail page, got:\n%s", view)
+	}
+	if model = model.SetShowDetail(false); model.IsShowingDetail() || !strings.Contains(stripStyles(model.View()), "PSS") {
+		t.Error("Expected the list back after closing the detail page")
+	}
+}
+
+func TestProcessesModel_Selection(t *testing.T) {
+	model := NewProcessesModel()
+	if _, ok := model.Selected(); ok {
+		t.Error("Expected no selection without processes")
+	}
+
+	model, _ = model.Update(TopProcessesMsg(testProcesses()))
+	model = model.MoveSelection(1)
+	if selected, _ := model.Selected(); selected.Name != "nginx" {
+		t.Errorf("Expected nginx to be selected, got %s", selected.Name)
+	}
+
+	// The selection follows the process when the order changes
+	model, _ = model.Update(TopProcessesMsg([]models.ProcessInfo{
+		{PID: 200, Name: "nginx", CPUPercent: 90},
+		{PID: 100, Name: "postgres", CPUPercent: 1},
+	}))
+	if selected, _ := model.Selected(); selected.PID != 200 {
+		t.Errorf("Expected nginx to stay selected, got %+v", selected)
+	}
+
+	model = model.MoveSelection(5)
+	if selected, _ := model.Selected(); selected.PID != 100 {
+		t.Errorf("Expected the selection clamped to the last process, got %+v", selected)
+	}
+	if pids := model.PIDs(); len(pids) != 2 || pids[0] != 200 {
+		t.Errorf("Expected the PIDs busiest first, got %v", pids)
+	}
+}
+
+// fakeProcessCollector returns canned processes and memory breakdowns
+type fakeProcessCollector struct {
+	requested int
+}
+
+func (c *fakeProcessCollector) CollectTopProcesses(n int) ([]models.ProcessInfo, error) {
+	c.requested = n
+	return testProcesses(), nil
+}
+
+func (c *fakeProcessCollector) CollectProcessMemory(pid int32) (models.ProcessMemory, error) {
+	for _, memory := range testProcessMemory() {
+		if memory.PID == pid {
+			return memory, nil
+		}
+	}
+	return models.ProcessMemory{}, models.CreateSystemError(models.SystemAccessError, "Process", "gone", nil)
+}
+
+func (c *fakeProcessCollector) CollectProcessFiles(pid int32) (models.ProcessFiles, error) {
+	if pid != 100 {
+		return models.ProcessFiles{}, models.CreateSystemError(models.PermissionError, "Process", "denied", nil)
+	}
+	return models.ProcessFiles{PID: 100, Open: 900, Limit: 1024}, nil
+}
+
+func (c *fakeProcessCollector) CollectProcessDetail(pid int32) (models.ProcessDetail, error) {
+	return models.ProcessDetail{
+		PID:       pid,
+		Cmdline:   "postgres -D /var/lib/postgresql/data",
+		Environ:   []string{"PGDATA=/var/lib/postgresql/data"},
+		OpenFiles: []string{"/var/lib/postgresql/data/base/1/1259"},
+		Threads:   4,
+		IO:        models.ProcessIO{ReadCount: 10, ReadBytes: 4096},
+		Maps:      []models.ProcessMapping{{Path: "[heap]", Size: 8 << 20, RSS: 6 << 20}},
+		MapCount:  120,
+		Errors:    map[string]string{"IO": "permission denied"},
+	}, nil
+}
+
+// cmdMsgs runs cmd and returns its messages, those of batches flattened
+func cmdMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, cmdMsgs(c)...)
+	}
+	return msgs
+}
+
+func TestMainModel_ProcessesPage(t *testing.T) {
+	collector := &fakeProcessCollector{}
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector()).SetProcessCollector(collector)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model = updated.(MainModel)
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	model = updated.(MainModel)
+	if collector.requested != 0 {
+		t.Fatal("Expected the processes to be collected by the returned command")
+	}
+	for _, msg := range cmdMsgs(cmd) {
+		updated, cmd = model.Update(msg)
+		model = updated.(MainModel)
+	}
+	if collector.requested != processPageCount {
+		t.Errorf("Expected the page to ask for %d processes, got %d", processPageCount, collector.requested)
+	}
+
+	// The sample asks for the memory of the listed processes
+	for _, msg := range cmdMsgs(cmd) {
+		updated, _ = model.Update(msg)
+		model = updated.(MainModel)
+	}
+	if memory, ok := model.processes.GetMemory(100); !ok || memory.PSS != 96<<20 {
+		t.Fatalf("Expected the memory breakdown of postgres, got %+v", memory)
+	}
+	if _, ok := model.processes.GetMemory(300); ok {
+		t.Error("Expected no breakdown for a process that could not be read")
+	}
+	if files, ok := model.processes.GetFiles(100); !ok || files.Open != 900 {
+		t.Errorf("Expected the open files of postgres, got %+v", files)
+	}
+
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "postgres (100)") || !strings.Contains(view, "P: back") {
+		t.Errorf("Expected the processes page, got:\n%s", view)
+	}
+	if top := model.GetCPUModel().GetTopProcesses(); len(top) != topProcessCount {
+		t.Errorf("Expected the CPU panel to keep %d top processes, got %d", topProcessCount, len(top))
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model = updated.(MainModel)
+	if selected, _ := model.processes.Selected(); selected.Name != "nginx" {
+		t.Errorf("Expected down to select nginx, got %s", selected.Name)
+	}
+
+	// Enter opens the detail page of the selection, Esc goes back to the list
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(MainModel)
+	for _, msg := range cmdMsgs(cmd) {
+		updated, _ = model.Update(msg)
+		model = updated.(MainModel)
+	}
+	if detail, ok := model.processes.GetDetail(); !ok || detail.PID != 200 {
+		t.Errorf("Expected the detail of nginx, got %+v", detail)
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if view := stripStyles(updated.(MainModel).View()); !strings.Contains(view, "PGDATA") || !strings.Contains(view, "environment") {
+		t.Errorf("Expected e to list the environment, got:\n%s", view)
+	}
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(MainModel)
+	if model.processes.IsShowingDetail() || !model.showProcesses {
+		t.Error("Expected Esc to close only the detail page")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if view := stripStyles(updated.(MainModel).View()); strings.Contains(view, "postgres (100)") {
+		t.Errorf("Expected Esc to close the processes page, got:\n%s", view)
+	}
+}
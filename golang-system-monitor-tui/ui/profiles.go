@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"golang-system-monitor-tui/config"
+)
+
+// SetProfile switches to the named profile of the config file, replacing the
+// grid, tabs and sensor thresholds with the profile's and refreshing at its
+// interval; "" goes back to the config file without a profile. A profile
+// without an interval refreshes at the one set by -interval or :interval.
+func (m MainModel) SetProfile(name string) (MainModel, error) {
+	cfg, err := m.settings.WithProfile(name)
+	if err != nil {
+		return m, err
+	}
+
+	m.profile = name
+	m.styleManager.SetSplit(cfg.Layout.Splits())
+	m.tabs = newGridTabs(cfg)
+	m = m.addLogTab()
+	m = m.selectTab(0)
+	m = m.updateComponentSizes()
+	m.sensors = m.sensors.SetThresholds(cfg.Thresholds())
+	interval := m.settings.Profiles[name].IntervalDuration()
+	if interval <= 0 {
+		interval = m.baseInterval
+	}
+	m = m.SetUpdateInterval(interval)
+	return m, nil
+}
+
+// GetProfile returns the name of the active profile, "" for none
+func (m MainModel) GetProfile() string {
+	return m.profile
+}
+
+// settingsWithSplits returns the config file contents with the current split
+// ratios, stored in the active profile when it has its own layout
+func (m MainModel) settingsWithSplits() config.Config {
+	settings := m.settings
+	column, row := m.styleManager.GetSplit()
+
+	profile, ok := settings.Profiles[m.profile]
+	if !ok || profile.Layout == nil {
+		settings.Layout.ColumnSplit, settings.Layout.RowSplit = column, row
+		return settings
+	}
+
+	// Copy the profiles, the map is shared with m.settings
+	layout := *profile.Layout
+	layout.ColumnSplit, layout.RowSplit = column, row
+	profile.Layout = &layout
+	profiles := make(map[string]config.Profile, len(settings.Profiles))
+	for name, p := range settings.Profiles {
+		profiles[name] = p
+	}
+	profiles[m.profile] = profile
+	settings.Profiles = profiles
+	return settings
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+)
+
+// profileSettings returns a config file with a server and a laptop profile
+func profileSettings() config.Config {
+	settings := config.Default()
+	settings.Profiles = map[string]config.Profile{
+		"server": {
+			Layout:           &config.Layout{Panels: []string{"cpu", "disk"}, Columns: 1, RowSplit: 0.7},
+			Tabs:             []config.Tab{},
+			Interval:         "5s",
+			SensorThresholds: map[string]config.Thresholds{"cpu": {Warning: 80, Critical: 95}},
+		},
+		"laptop": {},
+	}
+	return settings
+}
+
+func TestMainModel_SetProfile(t *testing.T) {
+	model := NewMainModelWithConfig(time.Second).ApplyConfig(profileSettings())
+
+	model, err := model.SetProfile("server")
+	if err != nil {
+		t.Fatalf("SetProfile failed: %v", err)
+	}
+	if model.GetProfile() != "server" {
+		t.Errorf("Expected the server profile, got %q", model.GetProfile())
+	}
+	if names := model.GetTabNames(); len(names) != 1 {
+		t.Errorf("Expected the profile to leave only Overview, got %v", names)
+	}
+	if len(model.panels) != 2 || model.panels[1] != FocusDisk {
+		t.Errorf("Expected the profile's grid, got %v", model.panels)
+	}
+	if model.GetUpdateInterval() != 5*time.Second {
+		t.Errorf("Expected the profile's interval, got %v", model.GetUpdateInterval())
+	}
+	if warning := model.sensors.thresholds[models.SensorCPU].Warning; warning != 80 {
+		t.Errorf("Expected the profile's CPU warning threshold, got %v", warning)
+	}
+	if title := model.headerTitle(); !strings.Contains(title, "Profile: server") {
+		t.Errorf("Expected the header to name the profile, got %q", title)
+	}
+
+	// A profile without an interval goes back to the one the model started with
+	model, _ = model.SetProfile("laptop")
+	if model.GetUpdateInterval() != time.Second {
+		t.Errorf("Expected the base interval for a profile without one, got %v", model.GetUpdateInterval())
+	}
+
+	// Leaving the profile restores the config file's grid and the base interval
+	model, _ = model.SetProfile("server")
+	model, _ = model.SetProfile("")
+	if len(model.GetTabNames()) != 1+len(config.DefaultTabs()) || len(model.panels) != 4 {
+		t.Errorf("Expected the config file's tabs and grid, got %v and %v", model.GetTabNames(), model.panels)
+	}
+	if model.GetUpdateInterval() != time.Second {
+		t.Errorf("Expected the base interval to be restored, got %v", model.GetUpdateInterval())
+	}
+
+	if _, err := model.SetProfile("desktop"); err == nil {
+		t.Error("Expected an unknown profile to fail")
+	}
+}
+
+func TestMainModel_ProfileCommand(t *testing.T) {
+	model, _ := typeCommand(t, NewMainModel().ApplyConfig(profileSettings()), "profile laptop")
+	if model.GetProfile() != "laptop" {
+		t.Errorf("Expected :profile to switch profiles, got %q", model.GetProfile())
+	}
+	if notice := stripStyles(model.renderNotice(model.now())); notice != "Using the laptop profile" {
+		t.Errorf("Expected a confirmation, got %q", notice)
+	}
+
+	model, _ = typeCommand(t, model, "profile desktop")
+	if model.GetProfile() != "laptop" {
+		t.Errorf("Expected an unknown profile to keep the current one, got %q", model.GetProfile())
+	}
+	if notice := stripStyles(model.renderNotice(model.now())); !strings.Contains(notice, `unknown profile "desktop"`) {
+		t.Errorf("Expected an error, got %q", notice)
+	}
+
+	model, _ = typeCommand(t, model, "profile")
+	if model.GetProfile() != "" {
+		t.Errorf("Expected :profile alone to leave the profile, got %q", model.GetProfile())
+	}
+
+	// :interval replaces the interval profiles without one go back to
+	model, _ = typeCommand(t, model, "interval 2s")
+	model, _ = typeCommand(t, model, "profile server")
+	model, _ = typeCommand(t, model, "profile")
+	if model.GetUpdateInterval() != 2*time.Second {
+		t.Errorf("Expected :profile alone to go back to the :interval, got %v", model.GetUpdateInterval())
+	}
+}
+
+func TestMainModel_ProfilePalette(t *testing.T) {
+	model := openPalette(t, NewMainModel().ApplyConfig(profileSettings()), "profile serv")
+	actions := model.matchingActions()
+	if len(actions) == 0 || actions[0].name != "Switch to profile server" {
+		t.Fatalf("Expected the server profile to match first, got %v", actions)
+	}
+	model, _ = actions[0].run(model)
+	if model.GetProfile() != "server" {
+		t.Errorf("Expected the palette to switch profiles, got %q", model.GetProfile())
+	}
+}
+
+func TestMainModel_ProfileSavesSplits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	model, _ := NewMainModel().ApplyConfig(profileSettings()).SetConfigPath(path).SetProfile("server")
+	if _, row := model.styleManager.GetSplit(); row != 0.7 {
+		t.Errorf("Expected the profile's row split, got %v", row)
+	}
+
+	model.styleManager.SetSplit(0.6, 0.4)
+	runCmd(model.saveLayoutCmd())
+	saved, err := config.Load(path, true)
+	if err != nil {
+		t.Fatalf("Expected the layout to be saved: %v", err)
+	}
+	if layout := saved.Profiles["server"].Layout; layout == nil || layout.ColumnSplit != 0.6 || layout.RowSplit != 0.4 {
+		t.Errorf("Expected the splits in the profile's layout, got %+v", layout)
+	}
+	if saved.Layout.ColumnSplit != 0 || saved.Layout.RowSplit != 0 {
+		t.Errorf("Expected the config file's layout to be kept, got %+v", saved.Layout)
+	}
+	if model.settings.Profiles["server"].Layout.RowSplit != 0.7 {
+		t.Error("Expected saving to leave the model's settings alone")
+	}
+}
@@ -0,0 +1,19 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// newPrompt returns the text input shared by the filter and command prompts,
+// focused and holding value with the cursor at its end. The cursor does not
+// blink, so an open prompt schedules no redraws of its own.
+func newPrompt(value string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = ""
+	input.Cursor.SetMode(cursor.CursorStatic)
+	input.SetValue(value)
+	input.CursorEnd()
+	input.Focus()
+	return input
+}
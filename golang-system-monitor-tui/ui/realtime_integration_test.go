@@ -7,146 +7,15 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services/fake"
 )
 
-// MockSystemCollector implements SystemCollector for testing
-type MockSystemCollector struct {
-	cpuCallCount     int
-	memoryCallCount  int
-	diskCallCount    int
-	networkCallCount int
-	simulateError    bool
-	errorComponent   string
-}
-
-func NewMockSystemCollector() *MockSystemCollector {
-	return &MockSystemCollector{}
-}
-
-func (m *MockSystemCollector) CollectCPU() (models.CPUInfo, error) {
-	m.cpuCallCount++
-	if m.simulateError && m.errorComponent == "CPU" {
-		return models.CPUInfo{}, models.CreateSystemError(models.SystemAccessError, "CPU", "Mock CPU error", nil)
-	}
-	
-	return models.CPUInfo{
-		Cores:     4,
-		Usage:     []float64{25.0, 50.0, 75.0, 90.0},
-		Total:     60.0,
-		Timestamp: time.Now(),
-	}, nil
-}
-
-func (m *MockSystemCollector) CollectMemory() (models.MemoryInfo, error) {
-	m.memoryCallCount++
-	if m.simulateError && m.errorComponent == "Memory" {
-		return models.MemoryInfo{}, models.CreateSystemError(models.SystemAccessError, "Memory", "Mock memory error", nil)
-	}
-	
-	return models.MemoryInfo{
-		Total:     16 * 1024 * 1024 * 1024, // 16GB
-		Used:      8 * 1024 * 1024 * 1024,  // 8GB
-		Available: 8 * 1024 * 1024 * 1024,  // 8GB
-		Swap: models.SwapInfo{
-			Total: 4 * 1024 * 1024 * 1024, // 4GB
-			Used:  1 * 1024 * 1024 * 1024, // 1GB
-			Free:  3 * 1024 * 1024 * 1024, // 3GB
-		},
-		Timestamp: time.Now(),
-	}, nil
-}
-
-func (m *MockSystemCollector) CollectDisk() ([]models.DiskInfo, error) {
-	m.diskCallCount++
-	if m.simulateError && m.errorComponent == "Disk" {
-		return nil, models.CreateSystemError(models.SystemAccessError, "Disk", "Mock disk error", nil)
-	}
-	
-	return []models.DiskInfo{
-		{
-			Device:      "/dev/sda1",
-			Mountpoint:  "/",
-			Filesystem:  "ext4",
-			Total:       500 * 1024 * 1024 * 1024, // 500GB
-			Used:        300 * 1024 * 1024 * 1024, // 300GB
-			Available:   200 * 1024 * 1024 * 1024, // 200GB
-			UsedPercent: 60.0,
-		},
-	}, nil
-}
-
-func (m *MockSystemCollector) CollectNetwork() ([]models.NetworkInfo, error) {
-	m.networkCallCount++
-	if m.simulateError && m.errorComponent == "Network" {
-		return nil, models.CreateSystemError(models.SystemAccessError, "Network", "Mock network error", nil)
-	}
-	
-	return []models.NetworkInfo{
-		{
-			Interface:   "eth0",
-			BytesSent:   uint64(m.networkCallCount * 1000),
-			BytesRecv:   uint64(m.networkCallCount * 2000),
-			PacketsSent: uint64(m.networkCallCount * 10),
-			PacketsRecv: uint64(m.networkCallCount * 20),
-			Timestamp:   time.Now(),
-		},
-	}, nil
-}
-
-func (m *MockSystemCollector) GetCallCounts() (int, int, int, int) {
-	return m.cpuCallCount, m.memoryCallCount, m.diskCallCount, m.networkCallCount
-}
-
-func (m *MockSystemCollector) SetSimulateError(component string) {
-	m.simulateError = true
-	m.errorComponent = component
-}
-
-func (m *MockSystemCollector) ClearError() {
-	m.simulateError = false
-	m.errorComponent = ""
-}
-
-func (m *MockSystemCollector) CalculateNetworkRates(previous, current []models.NetworkInfo) map[string]models.NetworkStats {
-	rates := make(map[string]models.NetworkStats)
-	
-	// Create a map of previous measurements for quick lookup
-	prevMap := make(map[string]models.NetworkInfo)
-	for _, prev := range previous {
-		prevMap[prev.Interface] = prev
-	}
-	
-	for _, curr := range current {
-		if prev, exists := prevMap[curr.Interface]; exists {
-			timeDiff := curr.Timestamp.Sub(prev.Timestamp).Seconds()
-			if timeDiff > 0 {
-				var sendRate, recvRate float64
-				
-				if curr.BytesSent >= prev.BytesSent {
-					sendRate = float64(curr.BytesSent-prev.BytesSent) / timeDiff
-				}
-				
-				if curr.BytesRecv >= prev.BytesRecv {
-					recvRate = float64(curr.BytesRecv-prev.BytesRecv) / timeDiff
-				}
-				
-				rates[curr.Interface] = models.NetworkStats{
-					SendRate: sendRate,
-					RecvRate: recvRate,
-				}
-			}
-		}
-	}
-	
-	return rates
-}
-
 // TestRealTimeUpdateSystem tests the complete real-time update system
 func TestRealTimeUpdateSystem(t *testing.T) {
 	// Create model with mock collector
-	mockCollector := NewMockSystemCollector()
+	mockCollector := fake.NewCollector()
 	model := NewMainModel()
-	model.collector = mockCollector
+	model = model.SetCollector(mockCollector)
 	model.updateInterval = 100 * time.Millisecond // Faster updates for testing
 
 	// Test individual components of the real-time update system
@@ -200,7 +69,7 @@ func TestRealTimeUpdateSystem(t *testing.T) {
 	}
 
 	// Verify that the mock collector was called
-	cpuCount, memoryCount, diskCount, networkCount := mockCollector.GetCallCounts()
+	cpuCount, memoryCount, diskCount, networkCount := mockCollector.CallCounts()
 	if cpuCount != 1 || memoryCount != 1 || diskCount != 1 || networkCount != 1 {
 		t.Errorf("Expected all collectors to be called once, got CPU:%d, Memory:%d, Disk:%d, Network:%d",
 			cpuCount, memoryCount, diskCount, networkCount)
@@ -209,9 +78,9 @@ func TestRealTimeUpdateSystem(t *testing.T) {
 
 // TestConcurrentDataCollection tests that data collection happens concurrently
 func TestConcurrentDataCollection(t *testing.T) {
-	mockCollector := NewMockSystemCollector()
+	mockCollector := fake.NewCollector()
 	model := NewMainModel()
-	model.collector = mockCollector
+	model = model.SetCollector(mockCollector)
 
 	// Execute individual data collection commands to test concurrent behavior
 	start := time.Now()
@@ -252,7 +121,7 @@ func TestConcurrentDataCollection(t *testing.T) {
 	duration := time.Since(start)
 
 	// Verify all data was collected
-	cpuCount, memoryCount, diskCount, networkCount := mockCollector.GetCallCounts()
+	cpuCount, memoryCount, diskCount, networkCount := mockCollector.CallCounts()
 	if cpuCount != 1 || memoryCount != 1 || diskCount != 1 || networkCount != 1 {
 		t.Errorf("Expected all collectors to be called once, got CPU:%d, Memory:%d, Disk:%d, Network:%d",
 			cpuCount, memoryCount, diskCount, networkCount)
@@ -286,9 +155,9 @@ func TestConcurrentDataCollection(t *testing.T) {
 
 // TestUpdatePerformance tests the performance of the update system
 func TestUpdatePerformance(t *testing.T) {
-	mockCollector := NewMockSystemCollector()
+	mockCollector := fake.NewCollector()
 	model := NewMainModel()
-	model.collector = mockCollector
+	model = model.SetCollector(mockCollector)
 
 	// Measure time for multiple update cycles
 	iterations := 100
@@ -341,7 +210,7 @@ func TestUpdatePerformance(t *testing.T) {
 	}
 
 	// Verify all data was collected the expected number of times
-	cpuCount, memoryCount, diskCount, networkCount := mockCollector.GetCallCounts()
+	cpuCount, memoryCount, diskCount, networkCount := mockCollector.CallCounts()
 	if cpuCount != iterations || memoryCount != iterations || diskCount != iterations || networkCount != iterations {
 		t.Errorf("Expected %d calls to each collector, got CPU:%d, Memory:%d, Disk:%d, Network:%d",
 			iterations, cpuCount, memoryCount, diskCount, networkCount)
@@ -350,9 +219,9 @@ func TestUpdatePerformance(t *testing.T) {
 
 // TestUpdateAccuracy tests the accuracy of data updates
 func TestUpdateAccuracy(t *testing.T) {
-	mockCollector := NewMockSystemCollector()
+	mockCollector := fake.NewCollector()
 	model := NewMainModel()
-	model.collector = mockCollector
+	model = model.SetCollector(mockCollector)
 
 	// Collect initial data by executing individual commands
 	cpuCmd := model.collectCPUDataCmd()
@@ -430,51 +299,60 @@ func TestUpdateAccuracy(t *testing.T) {
 
 // TestErrorHandlingInRealTimeUpdates tests error handling during real-time updates
 func TestErrorHandlingInRealTimeUpdates(t *testing.T) {
-	mockCollector := NewMockSystemCollector()
+	mockCollector := fake.NewCollector()
 	model := NewMainModel()
-	model.collector = mockCollector
+	model = model.SetCollector(mockCollector)
 
 	// Test CPU error handling
-	mockCollector.SetSimulateError("CPU")
+	mockCollector.Fail("CPU")
 	cpuCmd := model.collectCPUDataCmd()
 	msg := cpuCmd()
 	
-	if _, ok := msg.(models.SystemError); !ok {
+	if failed, ok := msg.(CollectFailedMsg); !ok || failed.Name != "CPU" {
+		t.Error("Expected CollectFailedMsg for CPU collection failure")
+	} else if _, ok := failed.Err.(models.SystemError); !ok {
 		t.Error("Expected SystemError for CPU collection failure")
 	}
 
 	// Test Memory error handling
-	mockCollector.SetSimulateError("Memory")
+	mockCollector.Fail("Memory")
 	memoryCmd := model.collectMemoryDataCmd()
 	msg = memoryCmd()
 	
-	if _, ok := msg.(models.SystemError); !ok {
+	if failed, ok := msg.(CollectFailedMsg); !ok || failed.Name != "Memory" {
+		t.Error("Expected CollectFailedMsg for Memory collection failure")
+	} else if _, ok := failed.Err.(models.SystemError); !ok {
 		t.Error("Expected SystemError for Memory collection failure")
 	}
 
 	// Test Disk error handling
-	mockCollector.SetSimulateError("Disk")
+	mockCollector.Fail("Disk")
 	diskCmd := model.collectDiskDataCmd()
 	msg = diskCmd()
 	
-	if _, ok := msg.(models.SystemError); !ok {
+	if failed, ok := msg.(CollectFailedMsg); !ok || failed.Name != "Disk" {
+		t.Error("Expected CollectFailedMsg for Disk collection failure")
+	} else if _, ok := failed.Err.(models.SystemError); !ok {
 		t.Error("Expected SystemError for Disk collection failure")
 	}
 
 	// Test Network error handling
-	mockCollector.SetSimulateError("Network")
+	mockCollector.Fail("Network")
 	networkCmd := model.collectNetworkDataCmd()
 	msg = networkCmd()
 	
-	if _, ok := msg.(models.SystemError); !ok {
+	if failed, ok := msg.(CollectFailedMsg); !ok || failed.Name != "Network" {
+		t.Error("Expected CollectFailedMsg for Network collection failure")
+	} else if _, ok := failed.Err.(models.SystemError); !ok {
 		t.Error("Expected SystemError for Network collection failure")
 	}
 }
 
-// TestTickerFunctionality tests the ticker mechanism
+// TestTickerFunctionality tests the ticker mechanism on a fake clock, so the
+// tick arrives after exactly one interval without real sleeps
 func TestTickerFunctionality(t *testing.T) {
-	model := NewMainModel()
-	model.updateInterval = 10 * time.Millisecond // Very fast for testing
+	clock := models.NewFakeClock(DeterministicTime)
+	model := NewMainModel().SetClock(clock)
 
 	// Get ticker command
 	tickCmd := model.tickCmd()
@@ -482,29 +360,58 @@ func TestTickerFunctionality(t *testing.T) {
 		t.Fatal("tickCmd() should return a command")
 	}
 
-	// Execute ticker command and measure timing
-	start := time.Now()
-	msg := tickCmd()
-	duration := time.Since(start)
+	ticks := make(chan tea.Msg, 1)
+	go func() { ticks <- tickCmd() }()
+	clock.BlockUntil(1)
+
+	// Nothing is sent before the interval has passed
+	clock.Advance(model.updateInterval - time.Millisecond)
+	select {
+	case msg := <-ticks:
+		t.Fatalf("Expected no tick before the interval, got %v", msg)
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+	msg := <-ticks
+	tick, ok := msg.(TickMsg)
+	if !ok {
+		t.Fatalf("Expected TickMsg from ticker command, got %T", msg)
+	}
+	if want := DeterministicTime.Add(model.updateInterval); !time.Time(tick).Equal(want) {
+		t.Errorf("Expected the tick at %v, got %v", want, time.Time(tick))
+	}
+	if !model.now().Equal(clock.Now()) {
+		t.Errorf("Expected the model clock to follow the fake clock, got %v", model.now())
+	}
+}
+
+// TestNetworkRatesOnFakeClock tests that rates are taken over the interval the
+// clock was advanced by, without waiting for it to pass
+func TestNetworkRatesOnFakeClock(t *testing.T) {
+	clock := models.NewFakeClock(DeterministicTime)
+	collector := fake.NewCollector()
+	collector.SetClock(clock)
+	model := NewMainModel().SetClock(clock).SetCollector(collector)
 
-	// Should return TickMsg
-	if _, ok := msg.(TickMsg); !ok {
-		t.Error("Expected TickMsg from ticker command")
+	for i := 0; i < 2; i++ {
+		updated, _ := model.Update(model.collectNetworkDataCmd()())
+		model = updated.(MainModel)
+		clock.Advance(2 * time.Second)
 	}
 
-	// Should respect the update interval (with some tolerance)
-	expectedDuration := 10 * time.Millisecond
-	tolerance := 5 * time.Millisecond
-	if duration < expectedDuration-tolerance || duration > expectedDuration+tolerance {
-		t.Errorf("Ticker duration %v not within expected range %v ± %v", duration, expectedDuration, tolerance)
+	// The mock counters grow by 1000 bytes sent and 2000 received per call
+	rate := model.GetNetworkModel().GetRates()["eth0"]
+	if rate.SendRate != 500 || rate.RecvRate != 1000 {
+		t.Errorf("Expected 500 B/s sent and 1000 B/s received, got %v and %v", rate.SendRate, rate.RecvRate)
 	}
 }
 
 // TestSmoothRendering tests that updates don't cause rendering issues
 func TestSmoothRendering(t *testing.T) {
-	mockCollector := NewMockSystemCollector()
+	mockCollector := fake.NewCollector()
 	model := NewMainModel()
-	model.collector = mockCollector
+	model = model.SetCollector(mockCollector)
 
 	// Set reasonable dimensions
 	model.width = 80
@@ -545,9 +452,9 @@ func containsString(s, substr string) bool {
 
 // BenchmarkRealTimeUpdate benchmarks the real-time update performance
 func BenchmarkRealTimeUpdate(b *testing.B) {
-	mockCollector := NewMockSystemCollector()
+	mockCollector := fake.NewCollector()
 	model := NewMainModel()
-	model.collector = mockCollector
+	model = model.SetCollector(mockCollector)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -564,9 +471,9 @@ func BenchmarkRealTimeUpdate(b *testing.B) {
 
 // BenchmarkDataCollection benchmarks individual data collection operations
 func BenchmarkDataCollection(b *testing.B) {
-	mockCollector := NewMockSystemCollector()
+	mockCollector := fake.NewCollector()
 	model := NewMainModel()
-	model.collector = mockCollector
+	model = model.SetCollector(mockCollector)
 
 	b.Run("CPU", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+)
+
+// bootSaveInterval is how often the boot record's last-seen time is refreshed,
+// bounding how far it lags behind an unexpected reset
+const bootSaveInterval = time.Minute
+
+// RebootMsg reports that the host booted since the monitor last ran
+type RebootMsg struct {
+	BootTime time.Time
+	Previous models.BootRecord // Record of the boot seen by the previous run
+}
+
+// SetBootStatePath sets the file the boot record is kept in, so reboots
+// between runs are reported; empty disables the check
+func (m MainModel) SetBootStatePath(path string) MainModel {
+	m.bootStatePath = path
+	return m
+}
+
+// GetReboot returns the reboot shown in the banner, if any
+func (m MainModel) GetReboot() (RebootMsg, bool) {
+	if m.reboot == nil {
+		return RebootMsg{}, false
+	}
+	return *m.reboot, true
+}
+
+// checkBootCmd compares the boot time of the host with the persisted record
+// and records the current boot
+func (m MainModel) checkBootCmd(host models.HostInfo) tea.Cmd {
+	if m.bootStatePath == "" || host.BootTime.IsZero() {
+		return nil
+	}
+	path, now := m.bootStatePath, m.now()
+	return func() tea.Msg {
+		previous, err := services.LoadBootRecord(path)
+		if err != nil {
+			log.Printf("Reboot check: %v", err)
+		}
+		if err := services.SaveBootRecord(path, models.BootRecord{BootTime: host.BootTime, LastSeen: now}); err != nil {
+			log.Printf("Reboot check: %v", err)
+		}
+		if !previous.Rebooted(host.BootTime) {
+			return nil
+		}
+		return RebootMsg{BootTime: host.BootTime, Previous: previous}
+	}
+}
+
+// saveBootCmd refreshes the last-seen time of the current boot
+func (m MainModel) saveBootCmd(now time.Time) tea.Cmd {
+	path, record := m.bootStatePath, models.BootRecord{BootTime: m.host.BootTime, LastSeen: now}
+	return func() tea.Msg {
+		if err := services.SaveBootRecord(path, record); err != nil {
+			log.Printf("Reboot check: %v", err)
+		}
+		return nil
+	}
+}
+
+// renderRebootBanner renders the reboot notice until a key is pressed
+func (m MainModel) renderRebootBanner(now time.Time) string {
+	if m.reboot == nil {
+		return ""
+	}
+	locale := m.styleManager.Locale()
+	text := fmt.Sprintf("System rebooted %s at %s", formatAge(now.Sub(m.reboot.BootTime)), locale.FormatTime(m.reboot.BootTime))
+	if lastSeen := m.reboot.Previous.LastSeen; !lastSeen.IsZero() && lastSeen.Before(m.reboot.BootTime) {
+		text += fmt.Sprintf(", previous boot last seen %s", locale.FormatTime(lastSeen))
+	}
+	return m.styleManager.RenderWarningText(truncate(text+" (any key dismisses)", m.width))
+}
+
+// renderNotice renders the transient notice line: the outcome of a command,
+// the result of a screenshot, or the reboot banner
+func (m MainModel) renderNotice(now time.Time) string {
+	if notice := m.renderCommandNotice(now); notice != "" {
+		return notice
+	}
+	if notice := m.renderScreenshotNotice(now); notice != "" {
+		return notice
+	}
+	return m.renderRebootBanner(now)
+}
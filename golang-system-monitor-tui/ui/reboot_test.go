@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services"
+)
+
+func TestMainModel_RebootBanner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "boot.json")
+	previousBoot := DeterministicTime.Add(-48 * time.Hour)
+	lastSeen := DeterministicTime.Add(-time.Hour)
+	if err := services.SaveBootRecord(path, models.BootRecord{BootTime: previousBoot, LastSeen: lastSeen}); err != nil {
+		t.Fatal(err)
+	}
+
+	model := NewMainModel().SetDeterministic(true).SetBootStatePath(path)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	boot := DeterministicTime.Add(-12 * time.Minute)
+	updated, cmd := updated.(MainModel).Update(HostUpdateMsg{Hostname: "box", BootTime: boot})
+	if cmd == nil {
+		t.Fatal("Expected a command checking the boot record")
+	}
+	msg, ok := cmd().(RebootMsg)
+	if !ok || !msg.BootTime.Equal(boot) || !msg.Previous.LastSeen.Equal(lastSeen) {
+		t.Fatalf("Expected a reboot since the recorded boot, got %#v", msg)
+	}
+	if record, err := services.LoadBootRecord(path); err != nil || !record.BootTime.Equal(boot) {
+		t.Errorf("Expected the current boot to be recorded, got %+v (%v)", record, err)
+	}
+
+	updated, _ = updated.(MainModel).Update(msg)
+	view := stripStyles(updated.(MainModel).View())
+	if !strings.Contains(view, "System rebooted 12m ago at 11:48:00, previous boot last seen 11:00:00") {
+		t.Errorf("Expected the reboot banner, got:\n%s", view)
+	}
+
+	// Any key dismisses the banner
+	updated, _ = updated.(MainModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if _, ok := updated.(MainModel).GetReboot(); ok || strings.Contains(stripStyles(updated.(MainModel).View()), "System rebooted") {
+		t.Error("Expected a key press to dismiss the banner")
+	}
+
+	// The next run of the same boot doesn't report a reboot
+	model = NewMainModel().SetDeterministic(true).SetBootStatePath(path)
+	_, cmd = model.Update(HostUpdateMsg{Hostname: "box", BootTime: boot})
+	if msg := cmd(); msg != nil {
+		t.Errorf("Expected no reboot for the recorded boot, got %#v", msg)
+	}
+}
+
+func TestMainModel_RebootCheckDisabled(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true)
+	if _, cmd := model.Update(HostUpdateMsg{Hostname: "box", BootTime: DeterministicTime}); cmd != nil {
+		if msg := cmd(); msg != nil {
+			t.Errorf("Expected no reboot check without a state file, got %#v", msg)
+		}
+	}
+}
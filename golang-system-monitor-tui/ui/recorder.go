@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RecordKind tells where a recorded message came from
+type RecordKind int
+
+const (
+	RecordUpdate  RecordKind = iota // Message handed to MainModel.Update
+	RecordCollect                   // Result returned by a collection command
+)
+
+// String returns the record kind name
+func (k RecordKind) String() string {
+	if k == RecordCollect {
+		return "collect"
+	}
+	return "update"
+}
+
+// Record is a single message captured by a Recorder
+type Record struct {
+	Seq  int        // Position in the overall sequence, starting at 1
+	Kind RecordKind // Update message or collector result
+	Name string     // Message type name, e.g. "CPUUpdateMsg"; "error" for failed collections
+	Msg  tea.Msg
+}
+
+// Recorder keeps the most recent messages seen by a MainModel in a fixed-size
+// ring. It is a test hook: integration tests attach one with SetRecorder and
+// assert on the exact sequence of updates without running the program.
+type Recorder struct {
+	mu       sync.Mutex
+	records  []Record
+	next     int // Index the next record is written to
+	seq      int
+	capacity int
+}
+
+// NewRecorder creates a recorder holding up to capacity messages
+func NewRecorder(capacity int) *Recorder {
+	if capacity <= 0 {
+		capacity = 256
+	}
+	return &Recorder{
+		records:  make([]Record, 0, capacity),
+		capacity: capacity,
+	}
+}
+
+// Add records a message, overwriting the oldest one when the ring is full
+func (r *Recorder) Add(kind RecordKind, msg tea.Msg) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	record := Record{Seq: r.seq, Kind: kind, Name: recordName(msg), Msg: msg}
+	if len(r.records) < r.capacity {
+		r.records = append(r.records, record)
+	} else {
+		r.records[r.next] = record
+	}
+	r.next = (r.next + 1) % r.capacity
+}
+
+// Records returns the recorded messages, oldest first
+func (r *Recorder) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]Record, 0, len(r.records))
+	start := 0
+	if len(r.records) == r.capacity {
+		start = r.next
+	}
+	for i := 0; i < len(r.records); i++ {
+		result = append(result, r.records[(start+i)%len(r.records)])
+	}
+	return result
+}
+
+// Names returns the type names of the recorded messages of a kind, oldest first
+func (r *Recorder) Names(kind RecordKind) []string {
+	var names []string
+	for _, record := range r.Records() {
+		if record.Kind == kind {
+			names = append(names, record.Name)
+		}
+	}
+	return names
+}
+
+// Len returns the number of recorded messages
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.records)
+}
+
+// Reset discards all recorded messages
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = r.records[:0]
+	r.next = 0
+	r.seq = 0
+}
+
+// recordName returns the unqualified type name of a message
+func recordName(msg tea.Msg) string {
+	if _, ok := msg.(error); ok {
+		return "error"
+	}
+	name := fmt.Sprintf("%T", msg)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestRecorder_Ring(t *testing.T) {
+	recorder := NewRecorder(3)
+	recorder.Add(RecordUpdate, CPUUpdateMsg{})
+	recorder.Add(RecordCollect, MemoryUpdateMsg{})
+	recorder.Add(RecordUpdate, DiskUpdateMsg{})
+	recorder.Add(RecordCollect, errors.New("boom"))
+
+	records := recorder.Records()
+	if len(records) != 3 || recorder.Len() != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+
+	var names []string
+	for _, record := range records {
+		names = append(names, record.Name)
+	}
+	if want := []string{"MemoryUpdateMsg", "DiskUpdateMsg", "error"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected oldest record to be overwritten, got %v", names)
+	}
+	if records[0].Seq != 2 || records[2].Seq != 4 {
+		t.Errorf("Expected sequence numbers 2..4, got %d..%d", records[0].Seq, records[2].Seq)
+	}
+	if got := recorder.Names(RecordCollect); !reflect.DeepEqual(got, []string{"MemoryUpdateMsg", "error"}) {
+		t.Errorf("Expected collect names, got %v", got)
+	}
+
+	recorder.Reset()
+	if recorder.Len() != 0 {
+		t.Errorf("Expected empty recorder after reset, got %d", recorder.Len())
+	}
+}
+
+func TestMainModel_Recorder(t *testing.T) {
+	recorder := NewRecorder(0)
+	model := NewMainModel().SetCollector(fake.NewCollector()).SetRecorder(recorder)
+
+	// Deliver the collection results in order, as the program would
+	batch, ok := model.collectAllDataCmd()().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Expected collectAllDataCmd to return a batch")
+	}
+	var current tea.Model = model
+	for _, cmd := range batch {
+		if cmd == nil {
+			continue
+		}
+		current, _ = current.Update(cmd())
+	}
+
+	want := []string{"CPUUpdateMsg", "MemoryUpdateMsg", "DiskUpdateMsg", "NetworkUpdateMsg"}
+	if got := recorder.Names(RecordCollect); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected collector results %v, got %v", want, got)
+	}
+	if got := recorder.Names(RecordUpdate); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected update messages %v, got %v", want, got)
+	}
+
+	records := recorder.Records()
+	if records[0].Kind != RecordCollect || records[1].Kind != RecordUpdate {
+		t.Errorf("Expected each collector result to precede its update, got %v then %v", records[0].Kind, records[1].Kind)
+	}
+	if cpu, ok := records[1].Msg.(CPUUpdateMsg); !ok || cpu.Total != 60.0 {
+		t.Errorf("Expected recorded CPU update with mock total, got %#v", records[1].Msg)
+	}
+
+	// Detached recorders see nothing
+	current.(MainModel).SetRecorder(nil).Update(TickMsg{})
+	if recorder.Len() != len(records) {
+		t.Errorf("Expected no records after detaching, got %d", recorder.Len())
+	}
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"log"
+	"time"
+)
+
+// A tick arriving this many intervals late, and at least sleepGapMin after the
+// previous one, means the host was suspended in between
+const (
+	sleepGapIntervals = 5
+	sleepGapMin       = 30 * time.Second
+)
+
+// sleptBetween reports whether the host was suspended between the ticks at
+// last and now. The wall clock is compared: the monotonic clock does not
+// advance during suspend on Linux.
+func sleptBetween(last, now time.Time, interval time.Duration) bool {
+	if last.IsZero() {
+		return false
+	}
+	gap := now.Round(0).Sub(last.Round(0))
+	return gap >= sleepGapMin && gap >= sleepGapIntervals*interval
+}
+
+// resyncAfterSleep drops the samples rates are computed from, so the first
+// collection after a resume becomes the new baseline instead of turning the
+// counters of the whole gap into one spike
+func (m MainModel) resyncAfterSleep(gap time.Duration) MainModel {
+	log.Printf("Resynced after sleep: no tick for %v", gap.Round(time.Second))
+	m.network = m.network.Resync()
+	m.disk = m.disk.Resync()
+	m.kernel = m.kernel.Resync()
+	return m.setCommandNotice("Resynced after sleep", false)
+}
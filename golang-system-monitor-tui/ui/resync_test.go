@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestSleptBetween(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		last     time.Time
+		gap      time.Duration
+		interval time.Duration
+		want     bool
+	}{
+		{"first tick", time.Time{}, time.Hour, time.Second, false},
+		{"on time", start, time.Second, time.Second, false},
+		{"slow refresh", start, 10 * time.Second, time.Second, false},
+		{"suspended", start, time.Hour, time.Second, true},
+		{"long interval", start, time.Minute, 30 * time.Second, false},
+		{"clock set back", start, -time.Hour, time.Second, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sleptBetween(tt.last, start.Add(tt.gap), tt.interval); got != tt.want {
+				t.Errorf("sleptBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMainModel_ResyncAfterSleep(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MainModel)
+	update := func(msg tea.Msg) {
+		t.Helper()
+		updated, _ := model.Update(msg)
+		model = updated.(MainModel)
+	}
+	sample := func(bytes uint64, at time.Time) NetworkUpdateMsg {
+		return NetworkUpdateMsg{{Interface: "eth0", BytesSent: bytes, BytesRecv: bytes, Timestamp: at}}
+	}
+
+	start := DeterministicTime
+	update(TickMsg(start))
+	update(sample(0, start))
+	update(KernelUpdateMsg{ContextSwitches: 100, Timestamp: start})
+	update(TickMsg(start.Add(time.Second)))
+	update(sample(1000, start.Add(time.Second)))
+	if rate := model.network.GetRates()["eth0"].SendRate; rate != 1000 {
+		t.Fatalf("Expected 1000 B/s before the suspend, got %v", rate)
+	}
+
+	// An hour without ticks: the counters of the whole gap must not become one rate
+	resumed := start.Add(time.Hour)
+	update(TickMsg(resumed))
+	if view := stripStyles(model.View()); !strings.Contains(view, "Resynced after sleep") {
+		t.Errorf("Expected the resync notice, got:\n%s", view)
+	}
+	update(sample(50_000_000_000, resumed))
+	update(KernelUpdateMsg{ContextSwitches: 1_000_000_000, Timestamp: resumed})
+	update(DiskIOUpdateMsg{ReadRate: 1e12, WriteRate: 1e12, Timestamp: resumed})
+	if rates := model.network.GetRates(); len(rates) != 0 {
+		t.Errorf("Expected no network rates right after the resume, got %+v", rates)
+	}
+	if model.kernel.hasRates {
+		t.Errorf("Expected no kernel rates right after the resume, got %+v", model.kernel.rates)
+	}
+	if io := model.disk.GetIO(); io.TotalRate() != 0 || len(model.disk.GetIOHistory()) != 0 {
+		t.Errorf("Expected the disk rates over the gap to be dropped, got %+v", io)
+	}
+
+	// The first sample after the resume is the new baseline
+	update(TickMsg(resumed.Add(time.Second)))
+	update(sample(50_000_002_000, resumed.Add(time.Second)))
+	update(KernelUpdateMsg{ContextSwitches: 1_000_000_500, Timestamp: resumed.Add(time.Second)})
+	if rate := model.network.GetRates()["eth0"].SendRate; rate != 2000 {
+		t.Errorf("Expected 2000 B/s after the resume, got %v", rate)
+	}
+	if want := (models.KernelRates{ContextSwitches: 500}); model.kernel.rates != want {
+		t.Errorf("Expected %+v after the resume, got %+v", want, model.kernel.rates)
+	}
+}
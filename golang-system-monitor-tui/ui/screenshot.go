@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"golang-system-monitor-tui/models"
+)
+
+// screenshotNoticeDuration is how long the status line reports a screenshot
+const screenshotNoticeDuration = 5 * time.Second
+
+// ScreenshotMsg reports the file a screenshot or snapshot was written to, or
+// the failure
+type ScreenshotMsg struct {
+	Path     string
+	Err      error
+	Snapshot bool // The JSON snapshot of the metrics was written rather than the frame
+}
+
+// LogSnapshotMsg asks for the current metrics to be written to the log as
+// one line of JSON, for a running instance without keyboard access
+type LogSnapshotMsg struct{}
+
+// ScreenshotName returns the timestamped file name of a screenshot taken at
+// now: .txt for plain text, .ans when the frame keeps its ANSI codes
+func ScreenshotName(now time.Time, keepANSI bool) string {
+	extension := ".txt"
+	if keepANSI {
+		extension = ".ans"
+	}
+	return "screenshot-" + now.Format("20060102-150405") + extension
+}
+
+// WriteScreenshot writes a rendered frame to path, stripping colors and other
+// escape codes unless keepANSI is set
+func WriteScreenshot(path, frame string, keepANSI bool) error {
+	if !keepANSI {
+		frame = ansi.Strip(frame)
+	}
+	if err := os.WriteFile(path, []byte(frame+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write screenshot: %w", err)
+	}
+	return nil
+}
+
+// SnapshotName returns the timestamped file name of a JSON snapshot taken at now
+func SnapshotName(now time.Time) string {
+	return "snapshot-" + now.Format("20060102-150405") + ".json"
+}
+
+// WriteSnapshot writes the metrics of a snapshot to path as indented JSON
+func WriteSnapshot(path string, snapshot models.Snapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// logSnapshot writes the current metrics, as the snapshot export has them,
+// to the log as one line of JSON
+func (m MainModel) logSnapshot() MainModel {
+	snapshot := m.Snapshot()
+	snapshot.Annotations = m.annotations
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		log.Printf("Failed to encode snapshot: %v", err)
+		return m.setCommandNotice("Snapshot failed: "+err.Error(), true)
+	}
+	log.Printf("Snapshot: %s", data)
+	return m.setCommandNotice("Logged a snapshot of the metrics", false)
+}
+
+// exportSnapshotCmd writes the current metrics as JSON to a timestamped file
+// in the screenshot directory
+func (m MainModel) exportSnapshotCmd() tea.Cmd {
+	snapshot := m.Snapshot()
+	snapshot.Annotations = m.annotations
+	path := filepath.Join(m.screenshotDir, SnapshotName(m.now()))
+	return func() tea.Msg {
+		return ScreenshotMsg{Path: path, Err: WriteSnapshot(path, snapshot), Snapshot: true}
+	}
+}
+
+// screenshotCmd writes the current frame to a timestamped file in the
+// screenshot directory
+func (m MainModel) screenshotCmd(keepANSI bool) tea.Cmd {
+	frame := m.View()
+	path := filepath.Join(m.screenshotDir, ScreenshotName(m.now(), keepANSI))
+	return func() tea.Msg {
+		return ScreenshotMsg{Path: path, Err: WriteScreenshot(path, frame, keepANSI)}
+	}
+}
+
+// renderScreenshotNotice renders the result of the last screenshot for a few
+// seconds after it was taken
+func (m MainModel) renderScreenshotNotice(now time.Time) string {
+	if m.screenshotAt.IsZero() || now.Sub(m.screenshotAt) > screenshotNoticeDuration {
+		return ""
+	}
+	if m.screenshot.Err != nil {
+		what := "Screenshot"
+		if m.screenshot.Snapshot {
+			what = "Snapshot"
+		}
+		return m.styleManager.RenderCriticalText(what + " failed: " + m.screenshot.Err.Error())
+	}
+	return m.styleManager.RenderMutedText("Saved " + m.screenshot.Path)
+}
+
+// SetScreenshotDir sets the directory screenshots are written to, the working
+// directory by default
+func (m MainModel) SetScreenshotDir(dir string) MainModel {
+	m.screenshotDir = dir
+	return m
+}
+
+// CollectOnce collects every metric once, runs the panel scripts and applies
+// the results, for one-shot renders outside the Bubble Tea program. Network rates need two
+// samples and stay at zero.
+func (m MainModel) CollectOnce() MainModel {
+	var cmds []tea.Cmd
+	for _, collector := range m.registry.Collectors() {
+		cmds = append(cmds, m.collectCmd(collector))
+	}
+	cmds = append(cmds, m.collectHostCmd())
+	for _, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+		updated, _ := m.Update(cmd())
+		m = updated.(MainModel)
+	}
+
+	// Scripts derive their metrics from the collected data
+	snapshot := m.Snapshot()
+	for _, script := range m.scripts {
+		updated, _ := m.Update(PluginUpdateMsg(script.Run(snapshot)))
+		m = updated.(MainModel)
+	}
+	return m
+}
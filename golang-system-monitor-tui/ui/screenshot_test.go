@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestScreenshotName(t *testing.T) {
+	if name := ScreenshotName(DeterministicTime, false); name != "screenshot-20240101-120000.txt" {
+		t.Errorf("Unexpected plain screenshot name %q", name)
+	}
+	if name := ScreenshotName(DeterministicTime, true); name != "screenshot-20240101-120000.ans" {
+		t.Errorf("Unexpected ANSI screenshot name %q", name)
+	}
+}
+
+func TestWriteScreenshot(t *testing.T) {
+	dir := t.TempDir()
+	frame := "\x1b[1;36mSystem Monitor\x1b[0m\nCPU 60%"
+
+	plain := filepath.Join(dir, "plain.txt")
+	if err := WriteScreenshot(plain, frame, false); err != nil {
+		t.Fatalf("WriteScreenshot failed: %v", err)
+	}
+	if data, _ := os.ReadFile(plain); string(data) != "System Monitor\nCPU 60%\n" {
+		t.Errorf("Expected the frame without escape codes, got %q", data)
+	}
+
+	colored := filepath.Join(dir, "colored.ans")
+	if err := WriteScreenshot(colored, frame, true); err != nil {
+		t.Fatalf("WriteScreenshot failed: %v", err)
+	}
+	if data, _ := os.ReadFile(colored); string(data) != frame+"\n" {
+		t.Errorf("Expected the frame with escape codes, got %q", data)
+	}
+
+	if err := WriteScreenshot(filepath.Join(dir, "missing", "x.txt"), frame, false); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestMainModel_ScreenshotKey(t *testing.T) {
+	dir := t.TempDir()
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector()).SetScreenshotDir(dir)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model = updated.(MainModel).CollectOnce()
+	if model.cpu.GetTotal() != 60 {
+		t.Fatalf("Expected CollectOnce to apply the mock CPU data, got %v", model.cpu.GetTotal())
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if cmd == nil {
+		t.Fatal("Expected a command writing the screenshot")
+	}
+	result, ok := cmd().(ScreenshotMsg)
+	if !ok {
+		t.Fatal("Expected the command to report the screenshot")
+	}
+	if result.Err != nil || result.Path != filepath.Join(dir, "screenshot-20240101-120000.txt") {
+		t.Fatalf("Unexpected screenshot result %+v", result)
+	}
+	data, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatalf("Expected the screenshot file: %v", err)
+	}
+	if !strings.Contains(string(data), "CPU Usage") || strings.Contains(string(data), "\x1b[") {
+		t.Errorf("Expected a plain-text frame of the grid, got:\n%s", data)
+	}
+
+	// The status line reports the file for a few seconds
+	updated, _ = updated.(MainModel).Update(result)
+	if view := updated.(MainModel).View(); !strings.Contains(stripStyles(view), "Saved "+result.Path) {
+		t.Errorf("Expected the screenshot path in the status line, got:\n%s", view)
+	}
+
+	updated, _ = updated.(MainModel).Update(ScreenshotMsg{Err: errors.New("disk full")})
+	if view := updated.(MainModel).View(); !strings.Contains(stripStyles(view), "Screenshot failed: disk full") {
+		t.Errorf("Expected the screenshot error in the status line, got:\n%s", view)
+	}
+}
+
+func TestMainModel_ExportSnapshotKey(t *testing.T) {
+	dir := t.TempDir()
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector()).SetScreenshotDir(dir)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model = updated.(MainModel).CollectOnce()
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if cmd == nil {
+		t.Fatal("Expected a command writing the snapshot")
+	}
+	result, ok := cmd().(ScreenshotMsg)
+	if !ok || !result.Snapshot {
+		t.Fatal("Expected the command to report the snapshot")
+	}
+	if result.Err != nil || result.Path != filepath.Join(dir, "snapshot-20240101-120000.json") {
+		t.Fatalf("Unexpected snapshot result %+v", result)
+	}
+	data, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatalf("Expected the snapshot file: %v", err)
+	}
+	var snapshot models.Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		t.Fatalf("Expected a JSON snapshot, got %v:\n%s", err, data)
+	}
+	if snapshot.CPU.Total != 60 || len(snapshot.Disks) == 0 {
+		t.Errorf("Expected the collected metrics in the snapshot, got %+v", snapshot)
+	}
+
+	updated, _ = updated.(MainModel).Update(result)
+	if view := updated.(MainModel).View(); !strings.Contains(stripStyles(view), "Saved "+result.Path) {
+		t.Errorf("Expected the snapshot path in the status line, got:\n%s", view)
+	}
+	updated, _ = updated.(MainModel).Update(ScreenshotMsg{Err: errors.New("disk full"), Snapshot: true})
+	if view := updated.(MainModel).View(); !strings.Contains(stripStyles(view), "Snapshot failed: disk full") {
+		t.Errorf("Expected the snapshot error in the status line, got:\n%s", view)
+	}
+}
+
+func TestMainModel_LogSnapshotMsg(t *testing.T) {
+	var buf strings.Builder
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model = updated.(MainModel).CollectOnce()
+
+	updated, _ = model.Update(LogSnapshotMsg{})
+	data, ok := strings.CutPrefix(strings.TrimSpace(buf.String()), "Snapshot: ")
+	if !ok {
+		t.Fatalf("Expected the snapshot in the log, got %q", buf.String())
+	}
+	var snapshot models.Snapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		t.Fatalf("Expected a JSON snapshot, got %v:\n%s", err, data)
+	}
+	if snapshot.CPU.Total != 60 {
+		t.Errorf("Expected the collected metrics in the snapshot, got %+v", snapshot)
+	}
+	if view := updated.(MainModel).View(); !strings.Contains(stripStyles(view), "Logged a snapshot of the metrics") {
+		t.Errorf("Expected the notice in the status line, got:\n%s", view)
+	}
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"log"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/services"
+)
+
+// SetScripts sets the panel scripts run on every tick. Their panels follow
+// the plugin panels; a script named like a configured plugin is skipped.
+func (m MainModel) SetScripts(scripts []*services.Script) MainModel {
+	plugins := make(map[string]bool, len(m.settings.Plugins))
+	for _, plugin := range m.settings.Plugins {
+		plugins[plugin.Name] = true
+	}
+	m.scripts = nil
+	for _, script := range scripts {
+		if plugins[script.Name] {
+			log.Printf("Skipping script %s: a plugin has the same name", script.Name)
+			continue
+		}
+		m.scripts = append(m.scripts, script)
+	}
+	return m.rebuildPluginPanels()
+}
+
+// runScriptsCmd runs every panel script against the latest snapshot
+func (m MainModel) runScriptsCmd() tea.Cmd {
+	if len(m.scripts) == 0 {
+		return nil
+	}
+	snapshot := m.Snapshot()
+	cmds := make([]tea.Cmd, len(m.scripts))
+	for i, script := range m.scripts {
+		cmds[i] = func() tea.Msg {
+			return PluginUpdateMsg(script.Run(snapshot))
+		}
+	}
+	return tea.Batch(cmds...)
+}
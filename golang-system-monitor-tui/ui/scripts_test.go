@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/services"
+	"golang-system-monitor-tui/services/fake"
+)
+
+func TestMainModel_Scripts(t *testing.T) {
+	headroom, err := services.ParseScript("headroom", "title = \"Headroom\"\ndef panel(s):\n    return [metric(\"cpu\", 100 - s.cpu.total, \"%\")]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clash, err := services.ParseScript("db", "def panel(s):\n    return [metric(\"x\", 1)]")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{Plugins: []config.Plugin{{Name: "db", Command: []string{"db-stats"}}}}
+	model := NewMainModel().SetDeterministic(true).SetCollector(fake.NewCollector()).ApplyConfig(cfg)
+	model = model.SetScripts([]*services.Script{headroom, clash})
+	panels := model.GetPluginModels()
+	if len(panels) != 2 || panels[0].GetName() != "db" || panels[1].GetName() != "headroom" {
+		t.Fatalf("Expected the plugin panel followed by the script panel, got %d panels", len(panels))
+	}
+
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model = updated.(MainModel).CollectOnce()
+	if output := model.GetPluginModels()[1].GetOutput(); output.Title != "Headroom" || output.Metrics[0].Value != 40 {
+		t.Fatalf("Expected CollectOnce to run the script against the mock CPU data, got %+v", output)
+	}
+
+	// Reapplying the config keeps the script panels and their output
+	model = model.ApplyConfig(cfg)
+	if panels := model.GetPluginModels(); len(panels) != 2 || panels[1].GetTitle() != "Headroom" {
+		t.Errorf("Expected the script panel to survive a config change, got %d panels", len(panels))
+	}
+
+	model, _ = model.OpenPage("plugins")
+	if view := stripStyles(model.View()); !strings.Contains(view, "cpu: 40 %") {
+		t.Errorf("Expected the script metrics on the plugins page, got:\n%s", view)
+	}
+}
+
+func TestMainModel_ScriptsRunOnTick(t *testing.T) {
+	script, err := services.ParseScript("cores", "def panel(s):\n    return [metric(\"cores\", s.cpu.cores)]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	model := NewMainModel().SetCollector(fake.NewCollector()).SetScripts([]*services.Script{script})
+	if cmd := model.runScriptsCmd(); cmd == nil {
+		t.Fatal("Expected a command running the scripts")
+	} else if msg, ok := cmd().(PluginUpdateMsg); !ok || msg.Name != "cores" {
+		t.Errorf("Expected the script output, got %#v", cmd())
+	}
+
+	if cmd := NewMainModel().runScriptsCmd(); cmd != nil {
+		t.Error("Expected no command without scripts")
+	}
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+)
+
+// SelfUpdateMsg carries the resource usage of the monitor process
+type SelfUpdateMsg models.SelfStats
+
+// SelfModel represents the self-monitoring panel: the CPU, memory,
+// goroutines and GC statistics of the monitor itself
+type SelfModel struct {
+	stats        models.SelfStats // Latest sample, zero until the first one
+	width        int              // Component width for rendering
+	height       int              // Component height for rendering
+	styleManager *StyleManager    // Style manager for consistent styling
+	hasError     bool             // Whether the process statistics could not be read
+	errorMessage string           // Why the process statistics could not be read
+	lastError    time.Time        // Timestamp of the last read failure
+}
+
+// NewSelfModel creates a new self-monitoring model instance
+func NewSelfModel() SelfModel {
+	return SelfModel{
+		width:        30,
+		height:       10,
+		styleManager: NewStyleManager(),
+	}
+}
+
+// Init initializes the self-monitoring model
+func (m SelfModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages and updates the self-monitoring model state
+func (m SelfModel) Update(msg tea.Msg) (SelfModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SelfUpdateMsg:
+		// The runtime statistics are valid even when the process ones failed
+		m.stats = models.SelfStats(msg)
+		m.hasError = msg.Error != ""
+		m.errorMessage = msg.Error
+		if m.hasError {
+			m.lastError = msg.Timestamp
+		}
+	}
+	return m, nil
+}
+
+// View renders the self-monitoring model
+func (m SelfModel) View() string {
+	if m.stats.Timestamp.IsZero() {
+		return m.styleManager.RenderPlaceholder("Monitor Process", "Loading process statistics...")
+	}
+
+	locale := m.styleManager.Locale()
+	sections := []string{m.styleManager.RenderHeader("Monitor Process")}
+	if m.hasError {
+		sections = append(sections, m.styleManager.RenderErrorText(truncate("Error: "+m.errorMessage, m.width)))
+	} else {
+		barWidth := m.styleManager.GetProgressBarWidth(m.width, 12) // "CPU:        " = 12 chars
+		bar := m.styleManager.RenderProgressBar(min(m.stats.CPUPercent, 100), barWidth, false)
+		sections = append(sections,
+			fmt.Sprintf("CPU:        %s %s", bar, locale.FormatPercent(m.stats.CPUPercent, 1)),
+			fmt.Sprintf("RSS:        %s", m.styleManager.FormatBytes(m.stats.RSS)))
+	}
+	sections = append(sections,
+		"Goroutines: "+locale.FormatInt(int64(m.stats.Goroutines)),
+		fmt.Sprintf("Heap:       %s / %s", m.styleManager.FormatBytes(m.stats.HeapAlloc), m.styleManager.FormatBytes(m.stats.HeapSys)),
+		fmt.Sprintf("GC:         %s cycles", locale.FormatInt(int64(m.stats.NumGC))))
+	if m.stats.NumGC > 0 {
+		pauses := fmt.Sprintf("            last pause %s, total %s",
+			formatDebugDuration(m.stats.LastPause),
+			formatDebugDuration(m.stats.TotalPause))
+		sections = append(sections, m.styleManager.RenderMutedText(truncate(pauses, m.width)))
+	}
+
+	// Add spacing if we have fewer lines than available height
+	for len(sections) < m.height {
+		sections = append(sections, "")
+	}
+	return strings.Join(sections, "\n")
+}
+
+// SetSize sets the component dimensions
+func (m SelfModel) SetSize(width, height int) SelfModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m SelfModel) SetStyleManager(styleManager *StyleManager) SelfModel {
+	m.styleManager = styleManager
+	return m
+}
+
+// GetStats returns the latest sample of the monitor process
+func (m SelfModel) GetStats() models.SelfStats {
+	return m.stats
+}
+
+// HasError returns whether the process statistics could not be read
+func (m SelfModel) HasError() bool {
+	return m.hasError
+}
+
+// GetSelfModel returns the self-monitoring model
+func (m MainModel) GetSelfModel() SelfModel {
+	return m.self
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+)
+
+func TestSelfModel_View(t *testing.T) {
+	model := NewSelfModel().SetSize(50, 8)
+	if view := stripStyles(model.View()); !strings.Contains(view, "Loading process statistics") {
+		t.Errorf("Expected a placeholder before the first sample, got:\n%s", view)
+	}
+
+	model, _ = model.Update(SelfUpdateMsg{
+		CPUPercent: 1.5,
+		RSS:        12 * 1024 * 1024,
+		Goroutines: 14,
+		HeapAlloc:  3 * 1024 * 1024,
+		HeapSys:    8 * 1024 * 1024,
+		NumGC:      23,
+		LastPause:  45 * time.Microsecond,
+		TotalPause: 1200 * time.Microsecond,
+		Timestamp:  time.Now(),
+	})
+	view := stripStyles(model.View())
+	for _, want := range []string{"Monitor Process", "1.5%", "RSS:        12.0MiB", "Goroutines: 14", "Heap:       3.0MiB / 8.0MiB", "23 cycles", "last pause 45µs, total 1.2ms"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in:\n%s", want, view)
+		}
+	}
+	if lines := strings.Count(view, "\n") + 1; lines != 8 {
+		t.Errorf("Expected 8 lines, got %d", lines)
+	}
+}
+
+func TestSelfModel_Locale(t *testing.T) {
+	styleManager := NewStyleManager()
+	locale, _ := models.ParseLocale("de_DE")
+	styleManager.SetLocale(locale)
+	model := NewSelfModel().SetSize(50, 8).SetStyleManager(styleManager)
+
+	model, _ = model.Update(SelfUpdateMsg{CPUPercent: 1.5, RSS: 1536 * 1024, Goroutines: 1200, NumGC: 34567, Timestamp: time.Now()})
+	view := stripStyles(model.View())
+	for _, want := range []string{"1,5%", "1,5MiB", "Goroutines: 1.200", "34.567 cycles"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in:\n%s", want, view)
+		}
+	}
+}
+
+func TestSelfModel_Error(t *testing.T) {
+	model, _ := NewSelfModel().SetSize(60, 8).Update(SelfUpdateMsg{
+		Goroutines: 9,
+		Error:      "Failed to read the CPU usage: permission denied",
+		Timestamp:  time.Now(),
+	})
+	if !model.HasError() {
+		t.Fatal("Expected an error")
+	}
+	view := stripStyles(model.View())
+	if !strings.Contains(view, "permission denied") || !strings.Contains(view, "Goroutines: 9") {
+		t.Errorf("Expected the error and the runtime statistics, got:\n%s", view)
+	}
+	if strings.Contains(view, "RSS:") {
+		t.Errorf("Expected no process statistics, got:\n%s", view)
+	}
+}
+
+func TestMainModel_SelfPanel(t *testing.T) {
+	cfg := config.Default()
+	cfg.Layout.Panels = []string{"cpu", "self"}
+	model := NewMainModel().SetDeterministic(true).ApplyConfig(cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(MainModel)
+
+	updated, _ = model.Update(collectedMsg("Self", models.SelfStats{Goroutines: 7, Timestamp: time.Now()}))
+	model = updated.(MainModel)
+	if model.GetSelfModel().GetStats().Goroutines != 7 {
+		t.Fatal("Expected the sample routed to the self panel")
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "Monitor Process") || !strings.Contains(view, "Goroutines: 7") {
+		t.Errorf("Expected the self panel in the grid, got:\n%s", view)
+	}
+}
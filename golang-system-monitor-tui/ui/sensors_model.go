@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/models"
+)
+
+// SensorsUpdateMsg represents a temperature sensors update message
+type SensorsUpdateMsg []models.SensorInfo
+
+// SensorsModel represents the unified temperature sensors component
+type SensorsModel struct {
+	sensors      []models.SensorInfo                           // Current sensor readings, sorted by kind
+	thresholds   map[models.SensorKind]models.SensorThresholds // Fallback thresholds per sensor kind
+	unit         models.TemperatureUnit                        // Unit temperatures are displayed in
+	lastUpdate   time.Time                                     // Last update timestamp
+	width        int                                           // Component width for rendering
+	height       int                                           // Component height for rendering
+	styleManager *StyleManager                                 // Style manager for consistent styling
+	hasError     bool                                          // Whether the component has an error
+	errorMessage string                                        // Current error message
+	lastError    time.Time                                     // Timestamp of last error
+	retryIn      time.Duration                                 // Time until the failing collector is retried
+	filter       string                                        // Sensor name or kind filter typed with /
+}
+
+// NewSensorsModel creates a new sensors model instance
+func NewSensorsModel() SensorsModel {
+	return SensorsModel{
+		sensors:      []models.SensorInfo{},
+		thresholds:   map[models.SensorKind]models.SensorThresholds{},
+		lastUpdate:   time.Now(),
+		width:        50,
+		height:       10,
+		styleManager: NewStyleManager(),
+	}
+}
+
+// Init initializes the sensors model
+func (m SensorsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages and updates the sensors model state
+func (m SensorsModel) Update(msg tea.Msg) (SensorsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SensorsUpdateMsg:
+		// Clear any previous errors on successful update
+		m.hasError = false
+		m.errorMessage = ""
+
+		// Group readings by kind so related sensors render together
+		m.sensors = append([]models.SensorInfo(nil), msg...)
+		sort.SliceStable(m.sensors, func(i, j int) bool {
+			if m.sensors[i].Kind != m.sensors[j].Kind {
+				return m.sensors[i].Kind < m.sensors[j].Kind
+			}
+			return m.sensors[i].Key < m.sensors[j].Key
+		})
+		m.lastUpdate = time.Now()
+
+	case models.ErrorMsg:
+		// Handle error messages for Sensors component
+		if msg.Component == "Sensors" {
+			m.hasError = true
+			m.errorMessage = msg.Message
+			m.lastError = msg.Timestamp
+		}
+	}
+	return m, nil
+}
+
+// View renders the sensors model
+func (m SensorsModel) View() string {
+	var sections []string
+
+	// Header
+	sensors := m.GetVisibleSensors()
+	header := renderFilteredHeader(m.styleManager, "Temperatures", m.filter, len(sensors), len(m.sensors))
+	sections = append(sections, header)
+
+	// Handle error state
+	if m.hasError {
+		sections = append(sections, m.styleManager.RenderErrorText("Error: "+m.errorMessage))
+		sections = append(sections, renderUnavailable(m.styleManager, "Sensor data unavailable", m.retryIn))
+		for len(sections) < m.height {
+			sections = append(sections, "")
+		}
+		return strings.Join(sections, "\n")
+	}
+
+	// Handle loading state
+	if len(m.sensors) == 0 {
+		return m.styleManager.RenderPlaceholder("Temperatures", "No temperature sensors detected")
+	}
+
+	if len(sensors) == 0 {
+		sections = append(sections, m.styleManager.RenderMutedText("No sensors match the filter"))
+	}
+
+	for _, sensor := range sensors {
+		thresholds := sensor.Thresholds(m.thresholds)
+		line := fmt.Sprintf("%-7s %s %s  %s",
+			sensor.Kind.String(), fit(sensor.Key, 24),
+			m.FormatTemperature(sensor.Temperature),
+			fmt.Sprintf("warn %s / crit %s",
+				m.styleManager.Locale().FormatFloat(m.unit.FromCelsius(thresholds.Warning), 0),
+				m.styleManager.Locale().FormatFloat(m.unit.FromCelsius(thresholds.Critical), 0)))
+		sections = append(sections, m.styleSensorLine(line, sensor))
+	}
+
+	// Add spacing if we have fewer lines than available height
+	for len(sections) < m.height {
+		sections = append(sections, "")
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+// styleSensorLine colors a sensor line according to its effective thresholds
+func (m SensorsModel) styleSensorLine(line string, sensor models.SensorInfo) string {
+	thresholds := sensor.Thresholds(m.thresholds)
+	switch {
+	case sensor.Temperature >= thresholds.Critical:
+		return m.styleManager.RenderCriticalText(line)
+	case sensor.Temperature >= thresholds.Warning:
+		return m.styleManager.RenderWarningText(line)
+	default:
+		return line
+	}
+}
+
+// SetSize sets the component dimensions
+func (m SensorsModel) SetSize(width, height int) SensorsModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetUnit sets the unit temperatures are displayed in
+func (m SensorsModel) SetUnit(unit models.TemperatureUnit) SensorsModel {
+	m.unit = unit
+	return m
+}
+
+// GetUnit returns the unit temperatures are displayed in
+func (m SensorsModel) GetUnit() models.TemperatureUnit {
+	return m.unit
+}
+
+// SetThresholds sets the configured thresholds per sensor kind, in Celsius,
+// which take precedence over the limits the hardware reports
+func (m SensorsModel) SetThresholds(thresholds map[models.SensorKind]models.SensorThresholds) SensorsModel {
+	m.thresholds = thresholds
+	return m
+}
+
+// FormatTemperature renders a Celsius temperature in the display unit, e.g. "176.0°F"
+func (m SensorsModel) FormatTemperature(celsius float64) string {
+	return fmt.Sprintf("%6s%s", m.styleManager.Locale().FormatFloat(m.unit.FromCelsius(celsius), 1), m.unit.Symbol())
+}
+
+// SetRetryIn sets the time until the failing collector is retried, shown
+// below the error
+func (m SensorsModel) SetRetryIn(retryIn time.Duration) SensorsModel {
+	m.retryIn = retryIn
+	return m
+}
+
+// SetStyleManager sets the style manager used to render the component
+func (m SensorsModel) SetStyleManager(styleManager *StyleManager) SensorsModel {
+	m.styleManager = styleManager
+	return m
+}
+
+// SetFilter shows only sensors whose name or kind contains filter
+func (m SensorsModel) SetFilter(filter string) SensorsModel {
+	m.filter = filter
+	return m
+}
+
+// GetFilter returns the sensor filter
+func (m SensorsModel) GetFilter() string {
+	return m.filter
+}
+
+// GetVisibleSensors returns the sensors that pass the filter
+func (m SensorsModel) GetVisibleSensors() []models.SensorInfo {
+	if m.filter == "" {
+		return m.sensors
+	}
+	var visible []models.SensorInfo
+	for _, sensor := range m.sensors {
+		if matchesFilter(sensor.Key, m.filter) || matchesFilter(sensor.Kind.String(), m.filter) {
+			visible = append(visible, sensor)
+		}
+	}
+	return visible
+}
+
+// GetSensors returns the current sensor readings
+func (m SensorsModel) GetSensors() []models.SensorInfo {
+	return m.sensors
+}
+
+// GetHottest returns the hottest sensor, if any readings are available
+func (m SensorsModel) GetHottest() (models.SensorInfo, bool) {
+	return models.HottestSensor(m.sensors)
+}
+
+// IsOverThreshold reports whether the sensor is at or above its warning threshold
+func (m SensorsModel) IsOverThreshold(sensor models.SensorInfo) bool {
+	return sensor.Temperature >= sensor.Thresholds(m.thresholds).Warning
+}
+
+// HasError returns whether the component has an error
+func (m SensorsModel) HasError() bool {
+	return m.hasError
+}
+
+// GetErrorMessage returns the current error message
+func (m SensorsModel) GetErrorMessage() string {
+	return m.errorMessage
+}
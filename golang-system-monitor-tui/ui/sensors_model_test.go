@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestNewSensorsModel(t *testing.T) {
+	model := NewSensorsModel()
+
+	if len(model.sensors) != 0 {
+		t.Errorf("Expected no sensors, got %v", model.sensors)
+	}
+	if model.thresholds == nil {
+		t.Error("Expected default thresholds to be initialized")
+	}
+	if model.styleManager == nil {
+		t.Error("Expected style manager to be initialized")
+	}
+}
+
+func TestSensorsModel_Update(t *testing.T) {
+	model := NewSensorsModel()
+
+	sensors := []models.SensorInfo{
+		{Key: "nvme_composite", Kind: models.SensorNVMe, Temperature: 45},
+		{Key: "coretemp_package_id_0", Kind: models.SensorCPU, Temperature: 62},
+		{Key: "amdgpu_edge", Kind: models.SensorGPU, Temperature: 71},
+	}
+	model, cmd := model.Update(SensorsUpdateMsg(sensors))
+	if cmd != nil {
+		t.Errorf("Expected Update() to return nil cmd, got %v", cmd)
+	}
+
+	got := model.GetSensors()
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 sensors, got %d", len(got))
+	}
+	if got[0].Kind != models.SensorCPU || got[1].Kind != models.SensorGPU || got[2].Kind != models.SensorNVMe {
+		t.Errorf("Expected sensors grouped by kind, got %v", got)
+	}
+
+	hottest, ok := model.GetHottest()
+	if !ok || hottest.Key != "amdgpu_edge" {
+		t.Errorf("Expected amdgpu_edge to be hottest, got %v", hottest)
+	}
+}
+
+func TestSensorsModel_Thresholds(t *testing.T) {
+	model := NewSensorsModel()
+
+	cool := models.SensorInfo{Key: "nvme", Kind: models.SensorNVMe, Temperature: 40}
+	hot := models.SensorInfo{Key: "nvme", Kind: models.SensorNVMe, Temperature: 75}
+	reported := models.SensorInfo{Key: "cpu", Kind: models.SensorCPU, Temperature: 75, High: 70}
+
+	if model.IsOverThreshold(cool) {
+		t.Error("Expected 40°C NVMe to be under threshold")
+	}
+	if !model.IsOverThreshold(hot) {
+		t.Error("Expected 75°C NVMe to be over the NVMe warning threshold")
+	}
+	if !model.IsOverThreshold(reported) {
+		t.Error("Expected hardware reported high threshold to be honored")
+	}
+}
+
+func TestSensorsModel_View(t *testing.T) {
+	model := NewSensorsModel()
+
+	if !strings.Contains(model.View(), "No temperature sensors detected") {
+		t.Error("Expected placeholder when no sensors are available")
+	}
+
+	model, _ = model.Update(SensorsUpdateMsg([]models.SensorInfo{
+		{Key: "coretemp_package_id_0", Kind: models.SensorCPU, Temperature: 62.5},
+	}))
+	view := model.View()
+	if !strings.Contains(view, "Temperatures") {
+		t.Error("Expected view to contain 'Temperatures' header")
+	}
+	if !strings.Contains(view, "62.5°C") {
+		t.Errorf("Expected view to contain sensor temperature, got: %s", view)
+	}
+}
+
+func TestSensorsModel_Fahrenheit(t *testing.T) {
+	model := NewSensorsModel().SetUnit(models.Fahrenheit)
+	model, _ = model.Update(SensorsUpdateMsg([]models.SensorInfo{
+		{Key: "coretemp_package_id_0", Kind: models.SensorCPU, Temperature: 80},
+	}))
+
+	view := model.View()
+	if !strings.Contains(view, "176.0°F") {
+		t.Errorf("Expected temperature in Fahrenheit, got: %s", view)
+	}
+	if !strings.Contains(view, "warn 176 / crit 203") {
+		t.Errorf("Expected thresholds in Fahrenheit, got: %s", view)
+	}
+
+	// Thresholds are stored in Celsius regardless of the display unit
+	model = model.SetThresholds(map[models.SensorKind]models.SensorThresholds{models.SensorCPU: {Warning: 85, Critical: 100}})
+	if model.IsOverThreshold(model.GetSensors()[0]) {
+		t.Error("Expected 80°C to be under an 85°C warning threshold")
+	}
+}
+
+func TestSensorsModel_ErrorHandling(t *testing.T) {
+	model := NewSensorsModel()
+
+	errMsg := models.ErrorMsg{Component: "Sensors", Message: "no hwmon", Timestamp: time.Now()}
+	model, _ = model.Update(errMsg)
+	if !model.HasError() || model.GetErrorMessage() != "no hwmon" {
+		t.Error("Expected sensors error to be recorded")
+	}
+
+	model, _ = model.Update(SensorsUpdateMsg([]models.SensorInfo{{Key: "cpu", Temperature: 50}}))
+	if model.HasError() {
+		t.Error("Expected error to clear after successful update")
+	}
+}
@@ -0,0 +1,9 @@
+package ui
+
+import "golang-system-monitor-tui/models"
+
+// formatPercentStats renders the session average and peak of a percentage,
+// e.g. "avg 31.0%, max 97.0%"
+func formatPercentStats(locale models.Locale, stats models.RunningStats) string {
+	return "avg " + locale.FormatPercent(stats.Mean, 1) + ", max " + locale.FormatPercent(stats.Max, 1)
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"golang-system-monitor-tui/format"
+	"golang-system-monitor-tui/models"
+	"golang-system-monitor-tui/services/fake"
+)
+
+var soakCycles = flag.Int("soak.cycles", 2000, "update and render cycles driven by TestSoak")
+
+const (
+	// soakStep is the simulated time between cycles: at a minute the default
+	// run spans more than a day, long enough for every history tier to wrap
+	soakStep = time.Minute
+	// soakHeapSlack is how far the live heap may grow past its size after the
+	// warm-up, covering caches that fill up slowly
+	soakHeapSlack = 8 << 20
+	// soakInterfaces is the number of short-lived interfaces cycled through,
+	// like the veth pairs of containers coming and going
+	soakInterfaces = 50
+)
+
+// soakMessages builds the metric updates of one cycle: load alternating
+// between calm and alerting every 30 cycles, and a churning interface
+func soakMessages(cycle int, now time.Time) []tea.Msg {
+	load := 20.0
+	if (cycle/30)%2 == 1 {
+		load = 97
+	}
+	const gb = 1024 * 1024 * 1024
+	counter := uint64(cycle) * 1024 * 1024
+	return []tea.Msg{
+		TickMsg(now),
+		CPUUpdateMsg{Usage: []float64{load, load / 2, load / 3, load / 4}, Total: load, Cores: 4, Timestamp: now},
+		MemoryUpdateMsg{Total: 16 * gb, Used: uint64(load / 100 * 16 * gb), Timestamp: now},
+		DiskUpdateMsg{{Device: "/dev/sda1", Mountpoint: "/", Total: 100 * gb, Used: uint64(load) * gb, UsedPercent: load}},
+		NetworkUpdateMsg{
+			{Interface: "eth0", BytesSent: counter, BytesRecv: 2 * counter, Timestamp: now},
+			{Interface: fmt.Sprintf("veth%d", cycle%soakInterfaces), BytesSent: counter, BytesRecv: counter, Timestamp: now},
+		},
+	}
+}
+
+// soakCycle applies one cycle of updates and renders the frame
+func soakCycle(model MainModel, cycle int, now time.Time) MainModel {
+	for _, msg := range soakMessages(cycle, now) {
+		updated, _ := model.Update(msg)
+		model = updated.(MainModel)
+	}
+	_ = model.View()
+	return model
+}
+
+// liveHeap returns the heap in use after a full collection
+func liveHeap() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+// TestSoak drives the model through many update and render cycles and checks
+// that the heap and every history stay bounded. Longer runs guard against
+// slow leaks, e.g. go test ./ui -run Soak -timeout 30m -soak.cycles 1000000
+func TestSoak(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping soak test in short mode")
+	}
+
+	clock := models.NewFakeClock(DeterministicTime)
+	model := NewMainModel().SetDeterministic(true).SetClock(clock).SetCollector(fake.NewCollector())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	model = updated.(MainModel)
+
+	cycles := max(*soakCycles, 100)
+	warmup := min(200, cycles/10)
+	checkEvery := max(cycles/10, 1)
+	var baseline uint64
+	for cycle := 0; cycle < cycles; cycle++ {
+		clock.Advance(soakStep)
+		model = soakCycle(model, cycle, clock.Now())
+
+		switch {
+		case cycle == warmup:
+			baseline = liveHeap()
+		case cycle > warmup && cycle%checkEvery == 0:
+			if heap := liveHeap(); heap > baseline+soakHeapSlack {
+				t.Fatalf("Heap grew from %s to %s after %d cycles", format.Bytes(baseline, format.DefaultOptions()), format.Bytes(heap, format.DefaultOptions()), cycle)
+			}
+		}
+	}
+	if heap := liveHeap(); heap > baseline+soakHeapSlack {
+		t.Errorf("Heap grew from %s to %s after %d cycles", format.Bytes(baseline, format.DefaultOptions()), format.Bytes(heap, format.DefaultOptions()), cycles)
+	}
+
+	cpu := model.GetCPUModel()
+	if got := len(cpu.GetTotalHistory()); got > cpu.maxHistory {
+		t.Errorf("Expected at most %d recent CPU samples, got %d", cpu.maxHistory, got)
+	}
+	for _, tier := range models.DefaultTiers {
+		if got, limit := len(cpu.GetLongHistory(tier.Span)), int(tier.Span/tier.Step)+1; got > limit {
+			t.Errorf("Expected at most %d values in the %v tier, got %d", limit, tier.Step, got)
+		}
+	}
+	if got := len(model.GetAlertHistory().Events()); got == 0 || got > alertHistorySize {
+		t.Errorf("Expected between 1 and %d alerts kept, got %d", alertHistorySize, got)
+	}
+	network := model.GetNetworkModel()
+	if got := len(network.rateStats); got > len(network.GetInterfaces()) {
+		t.Errorf("Expected rate statistics for the %d current interfaces only, got %d", len(network.GetInterfaces()), got)
+	}
+	if stats := cpu.GetTotalStats(); stats.Count != cycles {
+		t.Errorf("Expected %d samples in the session statistics, got %d", cycles, stats.Count)
+	}
+}
+
+// BenchmarkSoakCycle measures one update and render cycle of the soak test
+func BenchmarkSoakCycle(b *testing.B) {
+	clock := models.NewFakeClock(DeterministicTime)
+	model := NewMainModel().SetDeterministic(true).SetClock(clock).SetCollector(fake.NewCollector())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	model = updated.(MainModel)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clock.Advance(soakStep)
+		model = soakCycle(model, i, clock.Now())
+	}
+}
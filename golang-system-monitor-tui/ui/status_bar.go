@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/models"
+)
+
+// HostUpdateMsg represents a host identity update message
+type HostUpdateMsg models.HostInfo
+
+// ProcessStatesUpdateMsg carries the zombie and uninterruptible process counts, on Linux only
+type ProcessStatesUpdateMsg models.ProcessStates
+
+// FilesUpdateMsg carries the system-wide open files, on Linux only
+type FilesUpdateMsg models.FileDescriptors
+
+// statusSeparator joins the parts of the status bar
+const statusSeparator = " │ "
+
+// renderStatusBar renders the one-line summary above the footer: the slowed
+// refresh interval under high load, zombie and D-state process counts above
+// their thresholds, the open files when close to the kernel's limit, hostname, uptime, total CPU, memory, the fullest filesystem and total network
+// throughput. Parts without data yet are left out, and trailing parts are
+// dropped when the terminal is too narrow.
+func (m MainModel) renderStatusBar(now time.Time) string {
+	var parts []string
+	if m.adaptive.Slowed() {
+		// First, so it is the last part dropped on narrow terminals
+		parts = append(parts, m.styleManager.RenderWarningText("⏱ "+m.adaptive.Interval(m.updateInterval).String()+" (high load)"))
+	}
+	parts = append(parts, m.processStateChips()...)
+	if percent := m.files.UsedPercent(); percent >= models.FileDescriptorWarning {
+		parts = append(parts, fmt.Sprintf("Files %s of %s", m.renderPercent(percent), m.styleManager.Locale().FormatInt(int64(m.files.Max))))
+	}
+	if m.host.Hostname != "" {
+		parts = append(parts, m.styleManager.RenderHighlightText(m.host.Hostname))
+	}
+	if uptime := m.host.Uptime(now); uptime > 0 {
+		parts = append(parts, "up "+formatUptime(uptime))
+	}
+	if m.cpu.GetCores() > 0 {
+		parts = append(parts, "CPU "+m.renderPercent(m.cpu.GetTotal()))
+	}
+	if m.memory.GetTotal() > 0 {
+		percent := m.styleManager.Locale().FormatPercent(m.memory.GetUsagePercent(), 0)
+		parts = append(parts, "Mem "+lipgloss.NewStyle().Foreground(m.memory.GetUsageColor()).Render(percent))
+	}
+	if fullest, ok := fullestFilesystem(m.disk.GetFilesystems()); ok {
+		parts = append(parts, fmt.Sprintf("Disk %s %s", m.renderPercent(fullest.UsedPercent), fullest.Mountpoint))
+	}
+	if len(m.network.GetInterfaces()) > 0 {
+		parts = append(parts, fmt.Sprintf("Net ↓%s ↑%s",
+			m.network.formatRate(m.network.GetTotalRecvRate()),
+			m.network.formatRate(m.network.GetTotalSendRate())))
+	}
+
+	separator := m.styleManager.RenderMutedText(statusSeparator)
+	for len(parts) > 0 {
+		line := strings.Join(parts, separator)
+		if lipgloss.Width(line) <= m.width {
+			return line
+		}
+		parts = parts[:len(parts)-1]
+	}
+	return ""
+}
+
+// processStateChips renders a warning per process state whose count is
+// above its threshold, e.g. "⚠ 7 zombie"
+func (m MainModel) processStateChips() []string {
+	var chips []string
+	if m.processStates.Zombie > m.stateThresholds.Zombie {
+		chips = append(chips, m.styleManager.RenderWarningText(fmt.Sprintf("⚠ %d zombie", m.processStates.Zombie)))
+	}
+	if m.processStates.Uninterruptible > m.stateThresholds.Uninterruptible {
+		chips = append(chips, m.styleManager.RenderWarningText(fmt.Sprintf("⚠ %d D-state", m.processStates.Uninterruptible)))
+	}
+	return chips
+}
+
+// renderPercent renders a usage percentage in the color of its usage level
+func (m MainModel) renderPercent(percentage float64) string {
+	return lipgloss.NewStyle().
+		Foreground(m.styleManager.GetUsageColor(percentage)).
+		Render(m.styleManager.Locale().FormatPercent(percentage, 0))
+}
+
+// fullestFilesystem returns the filesystem with the highest usage
+func fullestFilesystem(filesystems []models.DiskInfo) (models.DiskInfo, bool) {
+	if len(filesystems) == 0 {
+		return models.DiskInfo{}, false
+	}
+	fullest := filesystems[0]
+	for _, fs := range filesystems[1:] {
+		if fs.UsedPercent > fullest.UsedPercent {
+			fullest = fs
+		}
+	}
+	return fullest, true
+}
+
+// formatUptime renders a compact uptime, e.g. "3d4h" or "2h15m"
+func formatUptime(uptime time.Duration) string {
+	switch {
+	case uptime >= 24*time.Hour:
+		return fmt.Sprintf("%dd%dh", int(uptime.Hours())/24, int(uptime.Hours())%24)
+	case uptime >= time.Hour:
+		return fmt.Sprintf("%dh%dm", int(uptime.Hours()), int(uptime.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(uptime.Minutes()))
+	}
+}
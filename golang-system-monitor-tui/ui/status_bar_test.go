@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
+)
+
+// ansiPattern matches the color escapes of rendered text
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestMainModel_StatusBar(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	model = updated.(MainModel)
+
+	if bar := model.renderStatusBar(DeterministicTime); bar != "" {
+		t.Errorf("Expected empty status bar before any data, got %q", bar)
+	}
+
+	msgs := []tea.Msg{
+		HostUpdateMsg{Hostname: "build-01", BootTime: DeterministicTime.Add(-27 * time.Hour)},
+		CPUUpdateMsg{Cores: 2, Usage: []float64{40, 60}, Total: 50, Timestamp: DeterministicTime},
+		MemoryUpdateMsg{Total: 1000, Used: 250, Available: 750, Timestamp: DeterministicTime},
+		DiskUpdateMsg{
+			{Mountpoint: "/", Total: 100, Used: 40, UsedPercent: 40},
+			{Mountpoint: "/var", Total: 100, Used: 92, UsedPercent: 92},
+		},
+		NetworkUpdateMsg{{Interface: "eth0", Timestamp: DeterministicTime}},
+	}
+	for _, msg := range msgs {
+		updated, _ = model.Update(msg)
+		model = updated.(MainModel)
+	}
+
+	bar := ansiPattern.ReplaceAllString(model.renderStatusBar(DeterministicTime), "")
+	for _, want := range []string{"build-01", "up 1d3h", "CPU 50%", "Mem 25%", "Disk 92% /var", "Net ↓0B/s ↑0B/s"} {
+		if !strings.Contains(bar, want) {
+			t.Errorf("Expected status bar to contain %q, got %q", want, bar)
+		}
+	}
+	if !strings.Contains(model.View(), "build-01") {
+		t.Error("Expected the status bar in the grid view")
+	}
+
+	// Trailing parts are dropped on narrow terminals
+	model.width = 40
+	bar = model.renderStatusBar(DeterministicTime)
+	if lipgloss.Width(bar) > 40 || !strings.Contains(bar, "build-01") || strings.Contains(bar, "Net") {
+		t.Errorf("Expected a shortened status bar, got %q", bar)
+	}
+}
+
+func TestMainModel_StatusBarFiles(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	updated, _ = updated.Update(FilesUpdateMsg{Allocated: 5000, Max: 10000})
+	model = updated.(MainModel)
+	if bar := ansiPattern.ReplaceAllString(model.renderStatusBar(DeterministicTime), ""); strings.Contains(bar, "Files") {
+		t.Errorf("Expected no open files far from the limit, got %q", bar)
+	}
+
+	updated, _ = model.Update(FilesUpdateMsg{Allocated: 9600, Max: 10000})
+	model = updated.(MainModel)
+	if bar := ansiPattern.ReplaceAllString(model.renderStatusBar(DeterministicTime), ""); !strings.Contains(bar, "Files 96% of 10000") {
+		t.Errorf("Expected the open files close to the limit, got %q", bar)
+	}
+	if model.alerts.ActiveCount() != 1 {
+		t.Errorf("Expected the Files alert to fire at 96%%, got %d active", model.alerts.ActiveCount())
+	}
+}
+
+func TestMainModel_StatusBarProcessStates(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	updated, _ = updated.Update(ProcessStatesUpdateMsg{Total: 300, Zombie: 5, Uninterruptible: 2})
+	model = updated.(MainModel)
+	if bar := model.renderStatusBar(DeterministicTime); strings.Contains(bar, "⚠") {
+		t.Errorf("Expected no warning at the thresholds, got %q", bar)
+	}
+
+	updated, _ = model.Update(ProcessStatesUpdateMsg{Total: 300, Zombie: 7, Uninterruptible: 4})
+	bar := ansiPattern.ReplaceAllString(updated.(MainModel).renderStatusBar(DeterministicTime), "")
+	if !strings.HasPrefix(bar, "⚠ 7 zombie │ ⚠ 4 D-state") {
+		t.Errorf("Expected both chips first in the status bar, got %q", bar)
+	}
+
+	// A threshold of 0 warns about any zombie
+	settings := config.Default()
+	zero := 0
+	settings.ProcessStates = &config.ProcessStates{Zombie: &zero}
+	updated, _ = model.ApplyConfig(settings).Update(ProcessStatesUpdateMsg{Total: 300, Zombie: 1})
+	if bar := ansiPattern.ReplaceAllString(updated.(MainModel).renderStatusBar(DeterministicTime), ""); !strings.Contains(bar, "⚠ 1 zombie") || strings.Contains(bar, "D-state") {
+		t.Errorf("Expected the configured zombie threshold, got %q", bar)
+	}
+}
+
+func TestFullestFilesystem(t *testing.T) {
+	if _, ok := fullestFilesystem(nil); ok {
+		t.Error("Expected no filesystem for an empty list")
+	}
+
+	fullest, ok := fullestFilesystem([]models.DiskInfo{
+		{Mountpoint: "/", UsedPercent: 10},
+		{Mountpoint: "/home", UsedPercent: 75},
+		{Mountpoint: "/boot", UsedPercent: 30},
+	})
+	if !ok || fullest.Mountpoint != "/home" {
+		t.Errorf("Expected /home, got %q", fullest.Mountpoint)
+	}
+}
+
+func TestFormatUptime(t *testing.T) {
+	tests := map[time.Duration]string{
+		5 * time.Minute:              "5m",
+		2*time.Hour + 15*time.Minute: "2h15m",
+		3*24*time.Hour + 4*time.Hour: "3d4h",
+	}
+	for uptime, want := range tests {
+		if got := formatUptime(uptime); got != want {
+			t.Errorf("formatUptime(%v) = %q, want %q", uptime, got, want)
+		}
+	}
+}
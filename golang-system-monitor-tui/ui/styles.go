@@ -5,55 +5,224 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/format"
+	"golang-system-monitor-tui/models"
 )
 
-// ColorScheme defines the application color palette
+// ColorScheme defines the application color palette. Every color has a
+// variant for dark and for light terminal backgrounds; lipgloss picks the one
+// matching the detected background.
 type ColorScheme struct {
 	// Usage level colors
-	Normal   lipgloss.Color // Green for normal usage (0-70%)
-	Warning  lipgloss.Color // Yellow for warning usage (70-90%)
-	Critical lipgloss.Color // Red for critical usage (90%+)
+	Normal   lipgloss.AdaptiveColor // Green for normal usage (0-70%)
+	Warning  lipgloss.AdaptiveColor // Yellow for warning usage (70-90%)
+	Critical lipgloss.AdaptiveColor // Red for critical usage (90%+)
 	
 	// UI element colors
-	Header   lipgloss.Color // Cyan for headers and titles
-	Focused  lipgloss.Color // Cyan for focused components
-	Unfocused lipgloss.Color // Gray for unfocused components
-	Text     lipgloss.Color // Default text color
-	Muted    lipgloss.Color // Gray for secondary text
-	Background lipgloss.Color // Background color
+	Header   lipgloss.AdaptiveColor // Cyan for headers and titles
+	Focused  lipgloss.AdaptiveColor // Cyan for focused components
+	Unfocused lipgloss.AdaptiveColor // Gray for unfocused components
+	Text     lipgloss.AdaptiveColor // Default text color
+	Muted    lipgloss.AdaptiveColor // Gray for secondary text
+	Background lipgloss.AdaptiveColor // Background color
 }
 
-// DefaultColorScheme returns the default color scheme
+// DefaultColorScheme returns the default color scheme. The light variants are
+// darker shades, since bright yellow, cyan and white are unreadable on white.
 func DefaultColorScheme() ColorScheme {
 	return ColorScheme{
-		Normal:     lipgloss.Color("2"),  // Green
-		Warning:    lipgloss.Color("3"),  // Yellow
-		Critical:   lipgloss.Color("1"),  // Red
-		Header:     lipgloss.Color("6"),  // Cyan
-		Focused:    lipgloss.Color("6"),  // Cyan
-		Unfocused:  lipgloss.Color("8"),  // Gray
-		Text:       lipgloss.Color("15"), // White
-		Muted:      lipgloss.Color("8"),  // Gray
-		Background: lipgloss.Color("0"),  // Black
+		Normal:     lipgloss.AdaptiveColor{Dark: "2", Light: "28"},   // Green
+		Warning:    lipgloss.AdaptiveColor{Dark: "3", Light: "136"},  // Yellow, dark goldenrod on light
+		Critical:   lipgloss.AdaptiveColor{Dark: "1", Light: "160"},  // Red
+		Header:     lipgloss.AdaptiveColor{Dark: "6", Light: "30"},   // Cyan, teal on light
+		Focused:    lipgloss.AdaptiveColor{Dark: "6", Light: "30"},   // Cyan, teal on light
+		Unfocused:  lipgloss.AdaptiveColor{Dark: "8", Light: "248"},  // Gray
+		Text:       lipgloss.AdaptiveColor{Dark: "15", Light: "0"},   // White, black on light
+		Muted:      lipgloss.AdaptiveColor{Dark: "8", Light: "242"},  // Gray
+		Background: lipgloss.AdaptiveColor{Dark: "0", Light: "15"},   // Black, white on light
 	}
 }
 
+// fixedColor returns a color that is the same on every background, for
+// colors set in the config file
+func fixedColor(value string) lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Light: value, Dark: value}
+}
+
 // StyleManager handles all styling operations
 type StyleManager struct {
-	colors ColorScheme
-	width  int
-	height int
+	colors    ColorScheme
+	barFilled string // Glyph for the filled part of progress bars
+	barEmpty  string // Glyph for the empty part of progress bars
+	barMode   BarMode // Glyph set of bars and graphs
+	panels    map[string]*StyleManager // Per-panel styles from the config file
+	columnSplit float64 // Share of the grid width given to the left column
+	rowSplit    float64 // Share of the grid height given to the top row
+	columns     int     // Number of grid columns
+	locale    models.Locale // Number and clock conventions for rendered values
+	units     models.SizeUnits // Multiples sizes and byte rates are shown in
+	colorSupport ColorSupport // Color capability the colors were chosen for
+	width     int
+	height    int
+	version   uint64 // Changes whenever a setting affecting rendered views does
 }
 
 // NewStyleManager creates a new style manager
 func NewStyleManager() *StyleManager {
 	return &StyleManager{
-		colors: DefaultColorScheme(),
-		width:  80,
-		height: 24,
+		colors:    DefaultColorScheme(),
+		barFilled: "█",
+		barEmpty:  "░",
+		columnSplit: config.DefaultSplit,
+		rowSplit:    config.DefaultSplit,
+		columns:     config.DefaultGridColumns,
+		locale:    models.DefaultLocale(),
+		width:     80,
+		height:    24,
+	}
+}
+
+// ApplyConfig applies the global style overrides of the config file and
+// prepares a derived style manager for every panel
+func (s *StyleManager) ApplyConfig(cfg config.Config) {
+	s.applyStyle(cfg.Style)
+	s.SetSplit(cfg.Layout.Splits())
+	_, s.columns = cfg.Layout.Grid()
+	s.locale = cfg.LocaleSettings()
+	s.version = nextRenderVersion()
+
+	s.panels = make(map[string]*StyleManager)
+	for name, style := range cfg.Panels {
+		panel := &StyleManager{
+			colors:    s.colors,
+			colorSupport: s.colorSupport,
+			barFilled: s.barFilled,
+			barEmpty:  s.barEmpty,
+			barMode:   s.barMode,
+			locale:    s.locale,
+			units:     s.units,
+			width:     s.width,
+			height:    s.height,
+		}
+		panel.applyStyle(style)
+		panel.version = nextRenderVersion()
+		s.panels[name] = panel
+	}
+}
+
+// Locale returns the number and clock conventions used for rendered values
+func (s *StyleManager) Locale() models.Locale {
+	return s.locale
+}
+
+// SetLocale sets the number and clock conventions of s and its panels
+func (s *StyleManager) SetLocale(locale models.Locale) {
+	s.locale = locale
+	s.version = nextRenderVersion()
+	for _, panel := range s.panels {
+		panel.locale = locale
+		panel.version = nextRenderVersion()
+	}
+}
+
+// Units returns the multiples sizes and byte rates are shown in
+func (s *StyleManager) Units() models.SizeUnits {
+	return s.units
+}
+
+// SetUnits sets the multiples sizes and byte rates of s and its panels are
+// shown in
+func (s *StyleManager) SetUnits(units models.SizeUnits) {
+	s.units = units
+	s.version = nextRenderVersion()
+	for _, panel := range s.panels {
+		panel.units = units
+		panel.version = nextRenderVersion()
+	}
+}
+
+// Format returns the options sizes and rates are rendered with
+func (s *StyleManager) Format() format.Options {
+	opts := format.DefaultOptions()
+	opts.Locale, opts.Units = s.locale, s.units
+	return opts
+}
+
+// FormatBytes converts bytes to human-readable format in the selected units
+func (s *StyleManager) FormatBytes(bytes uint64) string {
+	return format.Bytes(bytes, s.Format())
+}
+
+// SetDarkBackground picks the color variants for a dark or light terminal
+// background, overriding the detected one
+func (s *StyleManager) SetDarkBackground(dark bool) {
+	lipgloss.SetHasDarkBackground(dark)
+	s.version = nextRenderVersion()
+	for _, panel := range s.panels {
+		panel.version = nextRenderVersion()
+	}
+}
+
+// ForPanel returns the style manager for the named panel, or s itself when the
+// panel has no overrides
+func (s *StyleManager) ForPanel(name string) *StyleManager {
+	if panel, ok := s.panels[name]; ok {
+		return panel
+	}
+	return s
+}
+
+// applyStyle overrides the colors and glyphs set in style
+func (s *StyleManager) applyStyle(style config.Style) {
+	color := func(target *lipgloss.AdaptiveColor, value string) {
+		if value != "" {
+			*target = fixedColor(value)
+		}
+	}
+	color(&s.colors.Unfocused, style.Border)
+	color(&s.colors.Focused, style.FocusedBorder)
+	color(&s.colors.Header, style.Header)
+	color(&s.colors.Text, style.Text)
+	color(&s.colors.Muted, style.Muted)
+	color(&s.colors.Normal, style.Normal)
+	color(&s.colors.Warning, style.Warning)
+	color(&s.colors.Critical, style.Critical)
+
+	if style.BarFilled != "" {
+		s.barFilled = style.BarFilled
+	}
+	if style.BarEmpty != "" {
+		s.barEmpty = style.BarEmpty
+	}
+	if style.Bars != "" {
+		s.barMode, _ = ParseBarMode(style.Bars)
+	}
+}
+
+// SetBarMode sets the glyph set of bars and graphs of s and its panels, e.g.
+// from the -braille and -ascii flags. ASCII mode also draws ASCII borders.
+func (s *StyleManager) SetBarMode(mode BarMode) {
+	s.barMode = mode
+	s.version = nextRenderVersion()
+	for _, panel := range s.panels {
+		panel.barMode = mode
+		panel.version = nextRenderVersion()
 	}
 }
 
+// Version returns the version of the settings that affect rendered views:
+// colors, glyphs and locale. Views cached at another version are stale.
+func (s *StyleManager) Version() uint64 {
+	return s.version
+}
+
+// GetBarMode returns the glyph set of bars and graphs
+func (s *StyleManager) GetBarMode() BarMode {
+	return s.barMode
+}
+
 // SetDimensions updates the terminal dimensions
 func (s *StyleManager) SetDimensions(width, height int) {
 	s.width = width
@@ -61,7 +230,7 @@ func (s *StyleManager) SetDimensions(width, height int) {
 }
 
 // GetUsageColor returns the appropriate color for a usage percentage
-func (s *StyleManager) GetUsageColor(percentage float64) lipgloss.Color {
+func (s *StyleManager) GetUsageColor(percentage float64) lipgloss.AdaptiveColor {
 	switch {
 	case percentage >= 90:
 		return s.colors.Critical
@@ -72,57 +241,102 @@ func (s *StyleManager) GetUsageColor(percentage float64) lipgloss.Color {
 	}
 }
 
-// RenderProgressBar creates a styled progress bar
-func (s *StyleManager) RenderProgressBar(percentage float64, width int, showPercentage bool) string {
-	if width <= 0 {
-		width = 20
+// GetThresholdColor returns the color of a usage percentage against
+// thresholds other than the default 70% and 90%
+func (s *StyleManager) GetThresholdColor(percentage float64, thresholds models.UsageThresholds) lipgloss.AdaptiveColor {
+	switch {
+	case percentage >= thresholds.Critical:
+		return s.colors.Critical
+	case percentage >= thresholds.Warning:
+		return s.colors.Warning
+	default:
+		return s.colors.Normal
 	}
+}
 
-	// Calculate filled portion
-	filled := int((percentage / 100.0) * float64(width))
-	if filled > width {
-		filled = width
+// GetMemoryColor returns the color of memory at percent of RAM used or
+// available, as the basis of thresholds says
+func (s *StyleManager) GetMemoryColor(percentage float64, thresholds models.MemoryThresholds) lipgloss.AdaptiveColor {
+	switch {
+	case thresholds.IsCritical(percentage):
+		return s.colors.Critical
+	case thresholds.IsWarning(percentage):
+		return s.colors.Warning
+	default:
+		return s.colors.Normal
 	}
+}
 
-	// Create the bar
-	filledChar := "█"
-	emptyChar := "░"
-	bar := strings.Repeat(filledChar, filled) + strings.Repeat(emptyChar, width-filled)
-
-	// Apply color based on usage level
-	color := s.GetUsageColor(percentage)
-	styledBar := lipgloss.NewStyle().Foreground(color).Render(bar)
+// RenderProgressBar creates a styled progress bar
+func (s *StyleManager) RenderProgressBar(percentage float64, width int, showPercentage bool) string {
+	styledBar := s.RenderColoredBar(percentage, width, s.GetUsageColor(percentage))
 
 	// Add percentage if requested
 	if showPercentage {
 		percentText := lipgloss.NewStyle().
 			Foreground(s.colors.Text).
-			Render(lipgloss.PlaceHorizontal(6, lipgloss.Right, fmt.Sprintf("%.1f%%", percentage)))
+			Render(lipgloss.PlaceHorizontal(6, lipgloss.Right, s.locale.FormatPercent(percentage, 1)))
 		return styledBar + " " + percentText
 	}
 
 	return styledBar
 }
 
-// RenderHeader creates a styled header
+// RenderThresholdBar creates a progress bar colored by thresholds other than
+// the default 70% and 90%
+func (s *StyleManager) RenderThresholdBar(percentage float64, width int, thresholds models.UsageThresholds) string {
+	return s.RenderColoredBar(percentage, width, s.GetThresholdColor(percentage, thresholds))
+}
+
+// RenderColoredBar creates a progress bar filled to percentage in color
+func (s *StyleManager) RenderColoredBar(percentage float64, width int, color lipgloss.AdaptiveColor) string {
+	if width <= 0 {
+		width = 20
+	}
+
+	// Calculate filled portion
+	filled := int((percentage / 100.0) * float64(width))
+	if filled > width {
+		filled = width
+	}
+
+	// Bars repeat from frame to frame, so styled ones are cached
+	return renderStyled(color, false, func(buf []byte) []byte {
+		switch s.barMode {
+		case BarBraille:
+			return appendBrailleBar(buf, percentage, width)
+		case BarASCII:
+			return appendRepeat(appendRepeat(buf, "#", filled), "-", width-filled)
+		default:
+			return appendRepeat(appendRepeat(buf, s.barFilled, filled), s.barEmpty, width-filled)
+		}
+	})
+}
+
+// RenderHeader creates a styled header; panel titles repeat every frame, so
+// styled ones are cached
 func (s *StyleManager) RenderHeader(title string) string {
-	return lipgloss.NewStyle().
-		Bold(true).
-		Foreground(s.colors.Header).
-		Render(title)
+	return renderStyled(s.colors.Header, true, func(buf []byte) []byte {
+		return append(buf, title...)
+	})
 }
 
 // RenderComponentBorder creates a styled border for components
 func (s *StyleManager) RenderComponentBorder(content string, focused bool, width, height int) string {
-	var borderColor lipgloss.Color
+	var borderColor lipgloss.AdaptiveColor
 	if focused {
 		borderColor = s.colors.Focused
 	} else {
 		borderColor = s.colors.Unfocused
 	}
 
+	border := lipgloss.RoundedBorder()
+	if s.barMode == BarASCII {
+		border = lipgloss.ASCIIBorder()
+	}
+
 	style := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(border).
 		BorderForeground(borderColor).
 		Width(width).
 		Height(height).
@@ -156,6 +370,14 @@ func (s *StyleManager) RenderHighlightText(text string) string {
 		Render(text)
 }
 
+// RenderBoldText creates bold text in the regular color, e.g. for totals
+func (s *StyleManager) RenderBoldText(text string) string {
+	return lipgloss.NewStyle().
+		Foreground(s.colors.Text).
+		Bold(true).
+		Render(text)
+}
+
 // RenderWarningText creates styled warning text
 func (s *StyleManager) RenderWarningText(text string) string {
 	return lipgloss.NewStyle().
@@ -180,25 +402,91 @@ func (s *StyleManager) RenderErrorText(text string) string {
 		Render(text)
 }
 
-// CalculateComponentDimensions calculates optimal component dimensions
-func (s *StyleManager) CalculateComponentDimensions() (width, height int) {
-	// Reserve space for borders, padding, header, and footer
-	availableWidth := s.width - 6  // Account for borders and spacing
-	availableHeight := s.height - 6 // Account for header, footer, and spacing
+// SetSplit sets the share of the grid given to the left column and the top row,
+// clamped so that no panel collapses
+func (s *StyleManager) SetSplit(column, row float64) {
+	clamp := func(value float64) float64 {
+		if value < config.MinSplit {
+			return config.MinSplit
+		}
+		if value > config.MaxSplit {
+			return config.MaxSplit
+		}
+		return value
+	}
+	s.columnSplit = clamp(column)
+	s.rowSplit = clamp(row)
+}
 
-	// Split into 2x2 grid
-	componentWidth := availableWidth / 2
-	componentHeight := availableHeight / 2
+// GetSplit returns the column and row split ratios
+func (s *StyleManager) GetSplit() (column, row float64) {
+	return s.columnSplit, s.rowSplit
+}
 
-	// Ensure minimum dimensions
-	if componentWidth < 30 {
-		componentWidth = 30
+// SetGridColumns sets the number of columns the grid layout fills row by row
+func (s *StyleManager) SetGridColumns(columns int) {
+	if columns < 1 {
+		columns = 1
+	}
+	s.columns = columns
+}
+
+// GridShape returns the number of columns and rows used to lay out count components
+func (s *StyleManager) GridShape(count int) (columns, rows int) {
+	if count < 1 {
+		return 0, 0
 	}
-	if componentHeight < 8 {
-		componentHeight = 8
+	columns = s.columns
+	if columns > count {
+		columns = count
 	}
+	rows = (count + columns - 1) / columns
+	return columns, rows
+}
 
-	return componentWidth, componentHeight
+// CalculateComponentDimensions calculates the dimensions of the first component of the default grid
+func (s *StyleManager) CalculateComponentDimensions() (width, height int) {
+	widths, heights := s.CalculateGridDimensions(len(config.DefaultGridPanels))
+	return widths[0], heights[0]
+}
+
+// CalculateGridDimensions calculates the content width of every column and the
+// content height of every row when count components are laid out in the grid.
+// The split ratios apply to grids with two columns or two rows; other grids are
+// split evenly.
+func (s *StyleManager) CalculateGridDimensions(count int) (widths, heights []int) {
+	columns, rows := s.GridShape(count)
+
+	// Every panel takes three cells for its border and the gap to its neighbour
+	availableWidth := s.width - 3*columns
+	availableHeight := s.height - 3*rows
+
+	return splitSizes(availableWidth, columns, s.columnSplit, 30), splitSizes(availableHeight, rows, s.rowSplit, 8)
+}
+
+// splitSizes divides available space into count parts of at least minimum each,
+// using split as the share of the first part when there are two
+func splitSizes(available, count int, split float64, minimum int) []int {
+	sizes := make([]int, count)
+	switch count {
+	case 0:
+		return sizes
+	case 2:
+		sizes[0] = int(float64(available)*split + 0.5)
+		sizes[1] = available - sizes[0]
+	default:
+		for i := range sizes {
+			sizes[i] = available / count
+		}
+	}
+
+	// Ensure minimum dimensions
+	for i := range sizes {
+		if sizes[i] < minimum {
+			sizes[i] = minimum
+		}
+	}
+	return sizes
 }
 
 // IsSmallTerminal checks if the terminal is too small for optimal display
@@ -206,6 +494,52 @@ func (s *StyleManager) IsSmallTerminal() bool {
 	return s.width < 80 || s.height < 24
 }
 
+// Terminals narrower or shorter than the compact dimensions get one gauge per
+// line instead of panels; below the absolute minimum not even that fits
+const (
+	compactWidth  = 50
+	compactHeight = 15
+	minimumWidth  = 20
+	minimumHeight = 5
+)
+
+// IsCompactTerminal checks if the terminal is too small for panels and should use compact mode
+func (s *StyleManager) IsCompactTerminal() bool {
+	return s.width < compactWidth || s.height < compactHeight
+}
+
+// IsTooSmall checks if the terminal is below the absolute minimum dimensions
+func (s *StyleManager) IsTooSmall() bool {
+	return s.width < minimumWidth || s.height < minimumHeight
+}
+
+// GetAbsoluteMinimumDimensions returns the dimensions below which nothing is rendered
+func (s *StyleManager) GetAbsoluteMinimumDimensions() (width, height int) {
+	return minimumWidth, minimumHeight
+}
+
+// RenderGauge renders a single-line gauge of width cells: label, bar and percentage
+func (s *StyleManager) RenderGauge(label string, percentage float64, width int) string {
+	prefix := lipgloss.NewStyle().Foreground(s.colors.Text).Render(fmt.Sprintf("%-4s ", label))
+	suffix := fmt.Sprintf(" %4s", s.locale.FormatPercent(percentage, 0))
+	barWidth := width - lipgloss.Width(prefix) - len(suffix)
+	if barWidth < 1 {
+		barWidth = 1
+	}
+	return prefix + s.RenderProgressBar(percentage, barWidth, false) + suffix
+}
+
+// RenderTooSmall renders the message shown when the terminal is below the absolute minimum
+func (s *StyleManager) RenderTooSmall() string {
+	message := fmt.Sprintf("Terminal too small: %dx%d, need %dx%d", s.width, s.height, minimumWidth, minimumHeight)
+	text := lipgloss.NewStyle().
+		Foreground(s.colors.Critical).
+		Width(s.width).
+		Align(lipgloss.Center).
+		Render(message)
+	return lipgloss.PlaceVertical(s.height, lipgloss.Center, text)
+}
+
 // GetMinimumDimensions returns the minimum required terminal dimensions
 func (s *StyleManager) GetMinimumDimensions() (width, height int) {
 	return 80, 24
@@ -218,24 +552,36 @@ func (s *StyleManager) RenderResponsiveLayout(components []string) string {
 		return s.renderVerticalLayout(components)
 	}
 	
-	// For normal terminals, use 2x2 grid
-	return s.render2x2Layout(components)
+	// For normal terminals, use the configured grid
+	return s.renderGridLayout(components)
 }
 
-// render2x2Layout creates a 2x2 grid layout
-func (s *StyleManager) render2x2Layout(components []string) string {
-	if len(components) < 4 {
-		// Pad with empty components if needed
-		for len(components) < 4 {
-			components = append(components, "")
+// renderGridLayout lays components out row by row in the configured number of columns
+func (s *StyleManager) renderGridLayout(components []string) string {
+	columns, _ := s.GridShape(len(components))
+
+	var rows []string
+	for start := 0; start < len(components); start += columns {
+		end := start + columns
+		if end > len(components) {
+			end = len(components)
+		}
+
+		cells := make([]string, 0, 2*columns-1)
+		for i, component := range components[start:end] {
+			if i > 0 {
+				cells = append(cells, " ")
+			}
+			cells = append(cells, component)
+		}
+
+		if len(rows) > 0 {
+			rows = append(rows, "")
 		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
 	}
 
-	// Create top and bottom rows
-	topRow := lipgloss.JoinHorizontal(lipgloss.Top, components[0], " ", components[1])
-	bottomRow := lipgloss.JoinHorizontal(lipgloss.Top, components[2], " ", components[3])
-	
-	return lipgloss.JoinVertical(lipgloss.Left, topRow, "", bottomRow)
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
 // renderVerticalLayout creates a vertical stack layout for small terminals
@@ -262,7 +608,7 @@ func (s *StyleManager) RenderApplicationHeader(title string) string {
 
 // RenderApplicationFooter creates the main application footer
 func (s *StyleManager) RenderApplicationFooter(shortcuts []string) string {
-	footerText := strings.Join(shortcuts, " • ")
+	footerText := strings.Join(shortcuts, footerSeparator)
 	return lipgloss.NewStyle().
 		Foreground(s.colors.Muted).
 		Align(lipgloss.Center).
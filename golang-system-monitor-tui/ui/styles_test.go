@@ -1,33 +1,57 @@
 package ui
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"golang-system-monitor-tui/config"
+	"golang-system-monitor-tui/models"
 )
 
 func TestDefaultColorScheme(t *testing.T) {
 	scheme := DefaultColorScheme()
 	
-	// Test that all colors are defined
-	if scheme.Normal == "" {
-		t.Error("Normal color should be defined")
-	}
-	if scheme.Warning == "" {
-		t.Error("Warning color should be defined")
-	}
-	if scheme.Critical == "" {
-		t.Error("Critical color should be defined")
+	// Test that all colors are defined for both backgrounds
+	colors := map[string]lipgloss.AdaptiveColor{
+		"Normal":    scheme.Normal,
+		"Warning":   scheme.Warning,
+		"Critical":  scheme.Critical,
+		"Header":    scheme.Header,
+		"Focused":   scheme.Focused,
+		"Unfocused": scheme.Unfocused,
+		"Text":      scheme.Text,
+		"Muted":     scheme.Muted,
+	}
+	for name, color := range colors {
+		if color.Dark == "" || color.Light == "" {
+			t.Errorf("%s color should be defined for dark and light backgrounds, got %+v", name, color)
+		}
 	}
-	if scheme.Header == "" {
-		t.Error("Header color should be defined")
+	if scheme.Text.Dark == scheme.Text.Light {
+		t.Error("Text color should differ between dark and light backgrounds")
 	}
-	if scheme.Focused == "" {
-		t.Error("Focused color should be defined")
+}
+
+func TestAdaptiveColors(t *testing.T) {
+	defer lipgloss.SetHasDarkBackground(true)
+	NewMainModel().SetDeterministic(true) // Pins a color profile
+	sm := NewStyleManager()
+
+	lipgloss.SetHasDarkBackground(true)
+	dark := sm.RenderWarningText("80%")
+	lipgloss.SetHasDarkBackground(false)
+	light := sm.RenderWarningText("80%")
+	if dark == light || !strings.Contains(light, "38;5;136") {
+		t.Errorf("Expected dark goldenrod on light backgrounds, got %q and %q", dark, light)
 	}
-	if scheme.Unfocused == "" {
-		t.Error("Unfocused color should be defined")
+
+	// Colors from the config file apply to both backgrounds
+	sm.ApplyConfig(config.Config{Style: config.Style{Warning: "#e0af68"}})
+	if sm.GetUsageColor(80) != (lipgloss.AdaptiveColor{Light: "#e0af68", Dark: "#e0af68"}) {
+		t.Errorf("Expected the configured warning color on every background, got %+v", sm.GetUsageColor(80))
 	}
 }
 
@@ -66,7 +90,7 @@ func TestGetUsageColor(t *testing.T) {
 	
 	tests := []struct {
 		percentage float64
-		expected   lipgloss.Color
+		expected   lipgloss.AdaptiveColor
 	}{
 		{0, sm.colors.Normal},
 		{50, sm.colors.Normal},
@@ -88,6 +112,20 @@ func TestGetUsageColor(t *testing.T) {
 	}
 }
 
+func TestGetThresholdColor(t *testing.T) {
+	sm := NewStyleManager()
+	thresholds := models.UsageThresholds{Warning: 95, Critical: 97}
+	for percentage, want := range map[float64]lipgloss.AdaptiveColor{
+		92: sm.colors.Normal,
+		95: sm.colors.Warning,
+		97: sm.colors.Critical,
+	} {
+		if got := sm.GetThresholdColor(percentage, thresholds); got != want {
+			t.Errorf("GetThresholdColor(%v) = %v, want %v", percentage, got, want)
+		}
+	}
+}
+
 func TestRenderProgressBar(t *testing.T) {
 	sm := NewStyleManager()
 	
@@ -209,6 +247,41 @@ func TestCalculateComponentDimensions(t *testing.T) {
 	}
 }
 
+func TestCalculateGridDimensions(t *testing.T) {
+	sm := NewStyleManager()
+	sm.SetDimensions(126, 46)
+
+	widths, heights := sm.CalculateGridDimensions(4)
+	if !reflect.DeepEqual(widths, []int{60, 60}) || !reflect.DeepEqual(heights, []int{20, 20}) {
+		t.Errorf("Expected even 60/60 x 20/20 split, got %v x %v", widths, heights)
+	}
+
+	sm.SetSplit(0.75, 0.25)
+	widths, heights = sm.CalculateGridDimensions(4)
+	if !reflect.DeepEqual(widths, []int{90, 30}) || !reflect.DeepEqual(heights, []int{10, 30}) {
+		t.Errorf("Expected 90/30 x 10/30 split, got %v x %v", widths, heights)
+	}
+
+	// Ratios are clamped so that no panel collapses
+	sm.SetSplit(0.95, 0.05)
+	if column, row := sm.GetSplit(); column != 0.8 || row != 0.2 {
+		t.Errorf("Expected splits clamped to 0.8/0.2, got %v/%v", column, row)
+	}
+
+	// Grids with other shapes are split evenly
+	sm.SetGridColumns(3)
+	widths, heights = sm.CalculateGridDimensions(6)
+	if !reflect.DeepEqual(widths, []int{39, 39, 39}) || len(heights) != 2 {
+		t.Errorf("Expected three even columns in two rows, got %v x %v", widths, heights)
+	}
+
+	sm.SetGridColumns(1)
+	widths, heights = sm.CalculateGridDimensions(4)
+	if !reflect.DeepEqual(widths, []int{123}) || !reflect.DeepEqual(heights, []int{8, 8, 8, 8}) {
+		t.Errorf("Expected a single column of four rows, got %v x %v", widths, heights)
+	}
+}
+
 func TestIsSmallTerminal(t *testing.T) {
 	sm := NewStyleManager()
 	
@@ -276,21 +349,26 @@ func TestRenderResponsiveLayout(t *testing.T) {
 	}
 }
 
-func TestRender2x2Layout(t *testing.T) {
+func TestRenderGridLayout(t *testing.T) {
 	sm := NewStyleManager()
 	
-	// Test with exactly 4 components
+	// Test the default two columns
 	components := []string{"A", "B", "C", "D"}
-	layout := sm.render2x2Layout(components)
-	if layout == "" {
-		t.Error("2x2 layout should not be empty")
+	layout := sm.renderGridLayout(components)
+	if layout != "A B\n   \nC D" {
+		t.Errorf("Expected 2x2 grid, got %q", layout)
+	}
+	
+	// Test an incomplete last row
+	shortLayout := sm.renderGridLayout([]string{"A", "B", "C"})
+	if shortLayout != "A B\n   \nC  " {
+		t.Errorf("Expected incomplete last row, got %q", shortLayout)
 	}
 	
-	// Test with fewer than 4 components (should pad)
-	shortComponents := []string{"A", "B"}
-	shortLayout := sm.render2x2Layout(shortComponents)
-	if shortLayout == "" {
-		t.Error("2x2 layout with padding should not be empty")
+	// Test a single column
+	sm.SetGridColumns(1)
+	if column := sm.renderGridLayout([]string{"A", "B"}); column != "A\n \nB" {
+		t.Errorf("Expected single column, got %q", column)
 	}
 }
 
@@ -432,4 +510,62 @@ func TestResponsiveBehavior(t *testing.T) {
 				test.width, test.height, test.shouldBeSmall, isSmall)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestApplyConfig(t *testing.T) {
+	sm := NewStyleManager()
+	sm.ApplyConfig(config.Config{
+		Style: config.Style{FocusedBorder: "#7aa2f7", BarFilled: "▰", BarEmpty: "▱"},
+		Panels: map[string]config.Style{
+			"cpu": {Normal: "#9ece6a", BarFilled: "#"},
+		},
+	})
+
+	if sm.colors.Focused != fixedColor("#7aa2f7") {
+		t.Errorf("Expected global focused border override, got %v", sm.colors.Focused)
+	}
+	if sm.colors.Normal != DefaultColorScheme().Normal {
+		t.Error("Expected unset fields to keep their defaults")
+	}
+	if bar := sm.RenderProgressBar(50, 4, false); !strings.Contains(bar, "▰▰▱▱") {
+		t.Errorf("Expected global bar glyphs, got %q", bar)
+	}
+
+	cpu := sm.ForPanel("cpu")
+	if cpu == sm {
+		t.Fatal("Expected a derived style manager for the cpu panel")
+	}
+	if cpu.colors.Normal != fixedColor("#9ece6a") || cpu.colors.Focused != fixedColor("#7aa2f7") {
+		t.Errorf("Expected cpu overrides on top of the global style, got %+v", cpu.colors)
+	}
+	if bar := cpu.RenderProgressBar(50, 4, false); !strings.Contains(bar, "##▱▱") {
+		t.Errorf("Expected cpu bar glyph override, got %q", bar)
+	}
+
+	if sm.ForPanel("memory") != sm {
+		t.Error("Expected panels without overrides to share the global style manager")
+	}
+}
+
+func TestApplyConfig_Locale(t *testing.T) {
+	sm := NewStyleManager()
+	if sm.Locale() != models.DefaultLocale() {
+		t.Errorf("Expected the C locale by default, got %+v", sm.Locale())
+	}
+
+	sm.ApplyConfig(config.Config{
+		Locale: "de_DE.UTF-8",
+		Panels: map[string]config.Style{"disk": {Normal: "2"}},
+	})
+	if bar := sm.RenderProgressBar(45.25, 10, true); !strings.Contains(bar, "45,2%") {
+		t.Errorf("Expected a decimal comma in the bar percentage, got %q", bar)
+	}
+	if sm.ForPanel("disk").Locale().Decimal != "," {
+		t.Error("Expected panel style managers to share the locale")
+	}
+
+	sm.SetLocale(models.DefaultLocale())
+	if sm.ForPanel("disk").Locale() != models.DefaultLocale() {
+		t.Error("Expected SetLocale to update the panel style managers")
+	}
+}
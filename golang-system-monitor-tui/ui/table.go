@@ -0,0 +1,104 @@
+package ui
+
+import "strings"
+
+// TableColumn describes one column of a Table
+type TableColumn struct {
+	Title string // Header text, a table without titles has no header row
+	Width int    // Columns taken by a fixed column, the minimum of a flex column
+	Flex  bool   // Share the width left over by the fixed columns
+	Right bool   // Right-align the cells
+}
+
+// Table lays out rows of cells in aligned columns separated by a space. Cells
+// are measured by their printed width, so styled text and wide runes line up,
+// and cut with an ellipsis when they do not fit.
+type Table struct {
+	styleManager *StyleManager
+	columns      []TableColumn
+	widths       []int
+}
+
+// NewTable creates a table width columns wide, splitting what the fixed
+// columns leave evenly between the flex columns
+func (s *StyleManager) NewTable(width int, columns ...TableColumn) Table {
+	widths := make([]int, len(columns))
+	remaining := width - (len(columns) - 1)
+	flex := 0
+	for i, column := range columns {
+		widths[i] = column.Width
+		if column.Flex {
+			flex++
+		} else {
+			remaining -= column.Width
+		}
+	}
+
+	for i, column := range columns {
+		if !column.Flex {
+			continue
+		}
+		share := remaining / flex
+		remaining -= share
+		flex--
+		widths[i] = max(share, column.Width)
+	}
+
+	return Table{styleManager: s, columns: columns, widths: widths}
+}
+
+// ColumnWidth returns the width of column i, e.g. to size a bar drawn in it
+func (t Table) ColumnWidth(i int) int {
+	return t.widths[i]
+}
+
+// Header renders the column titles, or "" when no column has one
+func (t Table) Header() string {
+	titles := make([]string, len(t.columns))
+	hasTitle := false
+	for i, column := range t.columns {
+		titles[i] = column.Title
+		hasTitle = hasTitle || column.Title != ""
+	}
+	if !hasTitle {
+		return ""
+	}
+	return t.styleManager.RenderMutedText(t.Row(titles...))
+}
+
+// Row renders one line of cells. A row with fewer cells than columns lets its
+// last cell span the remaining columns, as for a detail line under a row.
+func (t Table) Row(cells ...string) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i >= len(t.columns) {
+			break
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+
+		width := t.widths[i]
+		if i == len(cells)-1 {
+			for _, rest := range t.widths[i+1:] {
+				width += rest + 1
+			}
+		}
+		b.WriteString(t.cell(cell, width, t.columns[i].Right, i == len(cells)-1))
+	}
+	return b.String()
+}
+
+// cell fits text into width columns, leaving a trailing left-aligned cell
+// unpadded so lines carry no trailing spaces
+func (t Table) cell(text string, width int, right, last bool) string {
+	text = truncate(text, width)
+	switch {
+	case right:
+		return padLeft(text, width)
+	case last:
+		return text
+	default:
+		return padRight(text, width)
+	}
+}
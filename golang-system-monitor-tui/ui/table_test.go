@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTable_Widths(t *testing.T) {
+	table := NewStyleManager().NewTable(40,
+		TableColumn{Width: 10},
+		TableColumn{Width: 5, Flex: true},
+		TableColumn{Width: 5, Flex: true},
+		TableColumn{Width: 6, Right: true})
+
+	// 40 columns less 3 separators and 16 fixed leave 21 for the flex columns
+	if got := []int{table.ColumnWidth(0), table.ColumnWidth(1), table.ColumnWidth(2), table.ColumnWidth(3)}; got[1]+got[2] != 21 || got[0] != 10 || got[3] != 6 {
+		t.Errorf("Expected the flex columns to share 21 columns, got %v", got)
+	}
+
+	narrow := NewStyleManager().NewTable(12, TableColumn{Width: 10}, TableColumn{Width: 5, Flex: true})
+	if narrow.ColumnWidth(1) != 5 {
+		t.Errorf("Expected a flex column to keep its minimum width, got %d", narrow.ColumnWidth(1))
+	}
+}
+
+func TestTable_Row(t *testing.T) {
+	styleManager := NewStyleManager()
+	table := styleManager.NewTable(30,
+		TableColumn{Title: "NAME", Width: 8},
+		TableColumn{Title: "RATE", Width: 6, Right: true},
+		TableColumn{Title: "NOTE", Width: 8})
+
+	tests := []struct {
+		name  string
+		cells []string
+		want  string
+	}{
+		{"aligned", []string{"eth0", "1.0", "ok"}, "eth0        1.0 ok"},
+		{"truncated", []string{"wlp0s20f3", "1.0", "ok"}, "wlp0s...    1.0 ok"},
+		{"spanning", []string{"", "sent 1.0KiB, received 2.0KiB"}, "         sent 1.0KiB,..."},
+		{"styled", []string{styleManager.RenderCriticalText("eth0"), "1.0", "ok"}, styleManager.RenderCriticalText("eth0") + "        1.0 ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := table.Row(tt.cells...); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if header := stripStyles(table.Header()); header != "NAME       RATE NOTE" {
+		t.Errorf("Expected the column titles, got %q", header)
+	}
+	if header := styleManager.NewTable(30, TableColumn{Width: 8}).Header(); header != "" {
+		t.Errorf("Expected no header without titles, got %q", header)
+	}
+	if width := lipgloss.Width(table.Row("a", "b", "a long note that overflows")); width != 24 {
+		t.Errorf("Expected a row as wide as its columns, got %d", width)
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// textWidth returns the columns s takes in the terminal: escape sequences
+// take none and wide runes such as CJK take two
+func textWidth(s string) int {
+	return lipgloss.Width(s)
+}
+
+// truncate shortens s to width columns, adding an ellipsis when cut. Styles
+// are kept and runes are never split.
+func truncate(s string, width int) string {
+	if textWidth(s) <= width {
+		return s
+	}
+	if width <= 3 {
+		return ansi.Truncate(s, max(width, 0), "")
+	}
+	return ansi.Truncate(s, width, "...")
+}
+
+// padRight pads s with spaces to width columns
+func padRight(s string, width int) string {
+	if gap := width - textWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// padLeft right-aligns s in width columns
+func padLeft(s string, width int) string {
+	if gap := width - textWidth(s); gap > 0 {
+		return strings.Repeat(" ", gap) + s
+	}
+	return s
+}
+
+// fit truncates or pads s to exactly width columns, for a left-aligned column
+func fit(s string, width int) string {
+	return padRight(truncate(s, width), width)
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTruncate(t *testing.T) {
+	styled := lipgloss.NewStyle().Bold(true).Render("eth0-bridge")
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  int // Width of the result
+	}{
+		{"fits", "eth0", 8, 4},
+		{"ascii", "very-long-interface", 12, 12},
+		{"multibyte", "Überschrift-Übersicht", 10, 10},
+		{"wide runes", "温度センサー温度センサー", 9, 9},
+		{"styled", styled, 8, 8},
+		{"tiny", "interface", 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.text, tt.width)
+			if width := textWidth(got); width > tt.want || width < tt.want-1 {
+				t.Errorf("truncate(%q, %d) = %q, %d columns wide", tt.text, tt.width, got, width)
+			}
+		})
+	}
+
+	if got := truncate("Überschrift-Übersicht", 10); got != "Übersch..." {
+		t.Errorf("Expected the cut to keep whole runes, got %q", got)
+	}
+	if got := stripStyles(truncate(styled, 8)); got != "eth0-..." {
+		t.Errorf("Expected styles to be ignored when measuring, got %q", got)
+	}
+}
+
+func TestFit(t *testing.T) {
+	if got := fit("温度", 6); got != "温度  " {
+		t.Errorf("Expected wide runes padded by their columns, got %q", got)
+	}
+	if got := fit("temperature", 6); got != "tem..." {
+		t.Errorf("Expected a long name to be cut, got %q", got)
+	}
+	if got := padLeft("1.0", 5); got != "  1.0" {
+		t.Errorf("Expected right alignment, got %q", got)
+	}
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// graphRanges are the windows of the trend graphs, stepped through with [ and ]
+// in the focused panel. Up to an hour they come from the 1s and 10s tiers of
+// models.DefaultTiers, a day from the 1m tier.
+var graphRanges = []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour, 24 * time.Hour}
+
+// formatTimeRange renders a graph window, e.g. "15m" or "1h"
+func formatTimeRange(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
+// renderTimeAxis renders the time axis below a graph of width cells spanning
+// the time range: its start on the left and "now" on the right
+func renderTimeAxis(timeRange time.Duration, width int) string {
+	start, end := "-"+formatTimeRange(timeRange), "now"
+	gap := width - len(start) - len(end)
+	if gap < 1 {
+		return ""
+	}
+	return start + strings.Repeat(" ", gap) + end
+}
+
+// panelTimeRange returns the graph window of a grid component, reporting
+// false when it has no history graph
+func (m MainModel) panelTimeRange(panel FocusedComponent) (time.Duration, bool) {
+	switch panel {
+	case FocusCPU:
+		return m.cpu.GetTimeRange(), true
+	default:
+		return 0, false
+	}
+}
+
+// setPanelTimeRange sets the graph window of a grid component, reporting
+// false when it has no history graph
+func (m MainModel) setPanelTimeRange(panel FocusedComponent, timeRange time.Duration) (MainModel, bool) {
+	switch panel {
+	case FocusCPU:
+		m.cpu = m.cpu.SetTimeRange(timeRange)
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+// stepTimeRange switches the graph of the focused panel to the next longer,
+// or shorter, window
+func (m MainModel) stepTimeRange(delta int) MainModel {
+	current, ok := m.panelTimeRange(m.focused)
+	if !ok {
+		return m
+	}
+	index := max(slices.Index(graphRanges, current), 0)
+	index = min(max(index+delta, 0), len(graphRanges)-1)
+	m, _ = m.setPanelTimeRange(m.focused, graphRanges[index])
+	return m
+}
+
+// GetTimeRange returns the graph window of the focused panel, 0 when it has
+// no history graph
+func (m MainModel) GetTimeRange() time.Duration {
+	timeRange, _ := m.panelTimeRange(m.focused)
+	return timeRange
+}
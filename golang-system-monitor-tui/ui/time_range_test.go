@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormatTimeRange(t *testing.T) {
+	tests := map[time.Duration]string{
+		time.Minute:      "1m",
+		15 * time.Minute: "15m",
+		time.Hour:        "1h",
+		24 * time.Hour:   "24h",
+	}
+	for d, want := range tests {
+		if got := formatTimeRange(d); got != want {
+			t.Errorf("formatTimeRange(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestRenderTimeAxis(t *testing.T) {
+	if got := renderTimeAxis(15*time.Minute, 12); got != "-15m     now" {
+		t.Errorf("Expected the range and now at both ends, got %q", got)
+	}
+	if got := renderTimeAxis(15*time.Minute, 7); got != "" {
+		t.Errorf("Expected no axis without room for both labels, got %q", got)
+	}
+}
+
+func TestMainModel_TimeRangeKeys(t *testing.T) {
+	model := NewMainModel().SetDeterministic(true)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(MainModel)
+	if model.GetTimeRange() != time.Minute {
+		t.Fatalf("Expected a 1m range by default, got %v", model.GetTimeRange())
+	}
+
+	longer := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")}
+	shorter := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")}
+	for _, want := range []time.Duration{5 * time.Minute, 15 * time.Minute, time.Hour, 24 * time.Hour, 24 * time.Hour} {
+		updated, _ = model.Update(longer)
+		model = updated.(MainModel)
+		if model.GetTimeRange() != want {
+			t.Errorf("Expected %v after ], got %v", want, model.GetTimeRange())
+		}
+	}
+	if model.GetCPUModel().GetTimeRange() != 24*time.Hour {
+		t.Errorf("Expected the CPU graph to span a day, got %v", model.GetCPUModel().GetTimeRange())
+	}
+	if view := stripStyles(model.View()); !strings.Contains(view, "range 24h") {
+		t.Errorf("Expected the range in the footer hints, got:\n%s", view)
+	}
+
+	for range graphRanges {
+		updated, _ = model.Update(shorter)
+		model = updated.(MainModel)
+	}
+	if model.GetTimeRange() != time.Minute {
+		t.Errorf("Expected [ to stop at the shortest range, got %v", model.GetTimeRange())
+	}
+
+	// Panels without a history graph ignore the keys
+	model = model.SetFocusedComponent(FocusMemory)
+	updated, _ = model.Update(longer)
+	model = updated.(MainModel)
+	if model.GetTimeRange() != 0 || model.GetCPUModel().GetTimeRange() != time.Minute {
+		t.Errorf("Expected no range change outside history panels, got %v", model.GetCPUModel().GetTimeRange())
+	}
+	if view := stripStyles(model.View()); strings.Contains(view, "range") {
+		t.Errorf("Expected no range hint for the memory panel, got:\n%s", view)
+	}
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// renderVersions hands out the versions of component states. Versions are
+// unique across all components, so copies of a model that diverge after
+// sharing a view cache never match each other's cached view.
+var renderVersions atomic.Uint64
+
+// nextRenderVersion returns a fresh version for a changed component state
+func nextRenderVersion() uint64 {
+	return renderVersions.Add(1)
+}
+
+// viewKey identifies what a rendered view was drawn from
+type viewKey struct {
+	version      uint64          // Version of the component state
+	styleManager *StyleManager   // Style manager it was drawn with
+	styles       uint64          // Version of the style manager's settings
+	profile      termenv.Profile // Color profile of the terminal
+	dark         bool            // Whether the terminal background is dark
+}
+
+// viewCache holds the last view of a component, so frames in which neither
+// its data nor its dimensions changed return it instead of rendering again.
+// Copies of a model share the cache; it is safe for concurrent use.
+type viewCache struct {
+	mu   sync.Mutex
+	key  viewKey
+	view string
+}
+
+// newViewCache creates an empty view cache
+func newViewCache() *viewCache {
+	return &viewCache{}
+}
+
+// render returns the cached view when the component is still at version and
+// styled the same way, and calls render otherwise. A nil cache always renders.
+func (c *viewCache) render(version uint64, styleManager *StyleManager, render func() string) string {
+	if c == nil || version == 0 {
+		return render()
+	}
+	key := viewKey{
+		version:      version,
+		styleManager: styleManager,
+		styles:       styleManager.Version(),
+		profile:      lipgloss.ColorProfile(),
+		dark:         lipgloss.HasDarkBackground(),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.key == key {
+		return c.view
+	}
+	c.view = render()
+	c.key = key
+	return c.view
+}
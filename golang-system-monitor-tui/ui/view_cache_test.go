@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golang-system-monitor-tui/models"
+)
+
+func TestViewCache_Render(t *testing.T) {
+	cache := newViewCache()
+	sm := NewStyleManager()
+	renders := 0
+	render := func() string {
+		renders++
+		return "view"
+	}
+
+	cache.render(1, sm, render)
+	cache.render(1, sm, render)
+	if renders != 1 {
+		t.Errorf("Expected the unchanged view rendered once, got %d renders", renders)
+	}
+	cache.render(2, sm, render)
+	if renders != 2 {
+		t.Error("Expected a new version to render again")
+	}
+	sm.SetBarMode(BarASCII)
+	cache.render(2, sm, render)
+	if renders != 3 {
+		t.Error("Expected changed styles to render again")
+	}
+	cache.render(2, NewStyleManager(), render)
+	if renders != 4 {
+		t.Error("Expected another style manager to render again")
+	}
+
+	var none *viewCache
+	none.render(2, sm, render)
+	none.render(2, sm, render)
+	if renders != 6 {
+		t.Error("Expected a nil cache to render every time")
+	}
+}
+
+func TestCPUModel_CachedView(t *testing.T) {
+	model := NewCPUModel().SetSize(50, 8)
+	model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{Cores: 2, Usage: []float64{10, 20}, Total: 15, Timestamp: time.Now()}))
+	first := model.View()
+
+	// Setting the same size keeps the cached view
+	if same := model.SetSize(50, 8); same.version != model.version {
+		t.Error("Expected an unchanged size to keep the version")
+	}
+
+	// Copies share the cache but never each other's views
+	updated, _ := model.Update(CPUUpdateMsg(models.CPUInfo{Cores: 2, Usage: []float64{90, 95}, Total: 92.5, Timestamp: time.Now()}))
+	if view := stripStyles(updated.View()); !strings.Contains(view, "92.5%") {
+		t.Errorf("Expected the new data rendered, got:\n%s", view)
+	}
+	if view := model.View(); view != first {
+		t.Errorf("Expected the earlier copy to keep its view, got:\n%s", stripStyles(view))
+	}
+	if resized := model.SetSize(60, 8).View(); resized == first {
+		t.Error("Expected a resize to render again")
+	}
+
+	model.styleManager.SetBarMode(BarASCII)
+	if view := stripStyles(model.View()); !strings.Contains(view, "#") {
+		t.Errorf("Expected the ASCII bars after changing the bar mode, got:\n%s", view)
+	}
+}
+
+func BenchmarkCPUModel_ViewCached(b *testing.B) {
+	model := NewCPUModel().SetSize(60, 20)
+	model, _ = model.Update(CPUUpdateMsg(models.CPUInfo{
+		Cores:     8,
+		Usage:     []float64{25.5, 30.2, 45.8, 60.1, 15.3, 80.7, 35.4, 50.9},
+		Total:     42.7,
+		Timestamp: time.Now(),
+	}))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		model.View()
+	}
+}